@@ -49,14 +49,12 @@ var createCommand = cli.Command{
 		for _, pod := range pods {
 			progressc := make(chan []*progress.ImageFetch)
 			go cmd.ShowDownloadProgress(progressc)
+			phasesc := make(chan []progress.Phase)
+			go cmd.ShowCreatePhases(phasesc)
 
-			err := client.CreatePod(progressc, pod)
+			result, err := client.CreateAndStartPodWithPhases(progressc, phasesc, pod)
 			close(progressc)
-			if err != nil {
-				return err
-			}
-
-			result, err := client.StartPod(pod.Metadata.Name)
+			close(phasesc)
 			if err != nil {
 				return err
 			}
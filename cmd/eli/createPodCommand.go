@@ -51,17 +51,12 @@ var createPodCommand = cli.Command{
 		progressc := make(chan []*progress.ImageFetch)
 		go cmd.ShowDownloadProgress(progressc)
 
-		err := client.CreatePod(progressc, pod)
+		result, err := client.CreateAndStartPod(progressc, pod)
 		close(progressc)
 		if err != nil {
 			return err
 		}
 
-		result, err := client.StartPod(pod.Metadata.Name)
-		if err != nil {
-			return err
-		}
-
 		writer := printers.GetNewTabWriter(os.Stdout)
 		defer writer.Flush()
 		printer := cmd.GetPrinter(clicontext)
@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"strings"
 	"time"
 
 	"github.com/ernoaapa/eliot/cmd"
@@ -18,7 +19,16 @@ var getNodesCommand = cli.Command{
 	UsageText: `eli get nodes [options]
 			 
 	 # Get table of known nodes
-	 eli get nodes`,
+	 eli get nodes
+
+	 # Get table of nodes in the group
+	 eli get nodes --group region=eu`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "group",
+			Usage: "List only the nodes in the group, e.g. --group region=eu",
+		},
+	},
 	Action: func(clicontext *cli.Context) error {
 		uiline := ui.NewLine().Loading("Discover from network automatically...")
 
@@ -26,6 +36,13 @@ var getNodesCommand = cli.Command{
 		if err != nil {
 			uiline.Fatalf("Failed to auto-discover nodes in network: %s", err)
 		}
+		if group := clicontext.String("group"); group != "" {
+			parts := strings.SplitN(group, "=", 2)
+			if len(parts) != 2 {
+				uiline.Fatalf("Invalid --group parameter [%s]. It must be key=value, e.g. '--group region=eu'", group)
+			}
+			nodes = discovery.FilterByGroup(nodes, parts[0], parts[1])
+		}
 		uiline.Donef("Discovered %d nodes from network", len(nodes))
 
 		writer := printers.GetNewTabWriter(os.Stdout)
@@ -15,12 +15,26 @@ var getPodsCommand = cli.Command{
 	UsageText: `eli get pods [options]
 			 
 	 # Get table of running pods
-	 eli get pods`,
+	 eli get pods
+
+	 # Get pods from all namespaces
+	 eli get pods --all-namespaces`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all-namespaces",
+			Usage: "List pods from all namespaces",
+		},
+	},
 	Action: func(clicontext *cli.Context) error {
 		config := cmd.GetConfigProvider(clicontext)
 		client := cmd.GetClient(config)
 
-		pods, err := client.GetPods()
+		getPods := client.GetPods
+		if clicontext.Bool("all-namespaces") {
+			getPods = client.GetAllPods
+		}
+
+		pods, err := getPods()
 		if err != nil {
 			return err
 		}
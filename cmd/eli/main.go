@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ernoaapa/eliot/cmd"
+	eliotversion "github.com/ernoaapa/eliot/pkg/version"
 	log "github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 )
@@ -52,12 +53,18 @@ func main() {
 			Usage:  "Use specific node endpoint. E.g. '192.168.1.101:5000'",
 			EnvVar: "ELIOT_ENDPOINT",
 		},
+		cli.StringFlag{
+			Name:   "token",
+			Usage:  "Token to authenticate to the node with. By default reads from the endpoint config.",
+			EnvVar: "ELIOT_TOKEN",
+		},
 		cli.StringFlag{
 			Name:   "node",
 			Usage:  "Use specific node by name. E.g. 'somehost.local'",
 			EnvVar: "ELIOT_NODE",
 		},
 	}, cmd.GlobalFlags...)
+	eliotversion.VERSION = version
 	app.Version = fmt.Sprintf("Version: %s, Commit: %s, Build at: %s", version, commit, date)
 	app.Before = cmd.GlobalBefore
 
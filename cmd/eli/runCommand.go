@@ -123,10 +123,10 @@ var runCommand = cli.Command{
 		progressc := make(chan []*progress.ImageFetch)
 		go cmd.ShowDownloadProgress(progressc)
 
-		createErr := client.CreatePod(progressc, pod)
+		result, err := client.CreateAndStartPod(progressc, pod)
 		close(progressc)
-		if createErr != nil {
-			return errors.Wrapf(createErr, "Error in creating pod")
+		if err != nil {
+			return errors.Wrapf(err, "Error in creating pod")
 		}
 
 		if rm {
@@ -141,11 +141,6 @@ var runCommand = cli.Command{
 			}()
 		}
 
-		result, err := client.StartPod(name)
-		if err != nil {
-			return errors.Wrapf(err, "Error in starting pod")
-		}
-
 		attachContainerID, err := cmd.FindRunningContainerID(result, name)
 		if err != nil {
 			return errors.Wrapf(err, "Cannot attach to container")
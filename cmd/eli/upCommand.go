@@ -178,15 +178,10 @@ var upCommand = cli.Command{
 		progressc := make(chan []*progress.ImageFetch)
 		go cmd.ShowDownloadProgress(progressc)
 
-		createErr := client.CreatePod(progressc, pod, opts...)
+		result, err := client.CreateAndStartPod(progressc, pod, opts...)
 		close(progressc)
-		if createErr != nil {
-			return errors.Wrapf(createErr, "Error in creating pod")
-		}
-
-		result, err := client.StartPod(name)
 		if err != nil {
-			return errors.Wrapf(err, "Error in starting pod")
+			return errors.Wrapf(err, "Error in creating pod")
 		}
 
 		if rm {
@@ -4,19 +4,28 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/ernoaapa/eliot/cmd"
 	"github.com/ernoaapa/eliot/pkg/api"
 	"github.com/ernoaapa/eliot/pkg/controller"
 	"github.com/ernoaapa/eliot/pkg/discovery"
+	"github.com/ernoaapa/eliot/pkg/model"
 	"github.com/ernoaapa/eliot/pkg/node"
+	"github.com/ernoaapa/eliot/pkg/pidfile"
 	"github.com/ernoaapa/eliot/pkg/profile"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/mapping"
+	"github.com/ernoaapa/eliot/pkg/secrets"
+	eliotversion "github.com/ernoaapa/eliot/pkg/version"
 	log "github.com/sirupsen/logrus"
 	"github.com/thejerf/suture"
 	"github.com/urfave/cli"
+	"google.golang.org/grpc/keepalive"
 )
 
 // Get overrided at build time
@@ -36,6 +45,8 @@ func main() {
 	 # Listen custom port
 	 eliotd --grpc-api-listen 0.0.0.0:5001
 	 
+	 # When started by systemd socket activation, the passed socket is used instead of --grpc-api-listen
+
 	 # Disable lifecycle controller and enable only the GRPC API
 	 eliotd  --grpc=true --lifecycle-controller=false`
 	app.Description = `API for create/update/delete the containers and a way to connect into the containers.`
@@ -46,22 +57,149 @@ func main() {
 			EnvVar: "ELIOT_CONTAINERD",
 			Value:  "/run/containerd/containerd.sock",
 		},
+		cli.StringFlag{
+			Name:   "containerd-namespace",
+			Usage:  "Default containerd namespace for the pods which don't define metadata.namespace",
+			EnvVar: "ELIOT_CONTAINERD_NAMESPACE",
+			Value:  model.DefaultNamespace,
+		},
+		cli.StringFlag{
+			Name:   "label-prefix",
+			Usage:  "Prefix for the containerd container label keys, change to avoid conflicts with other containerd clients",
+			EnvVar: "ELIOT_LABEL_PREFIX",
+			Value:  mapping.DefaultLabelPrefix,
+		},
+		cli.DurationFlag{
+			Name:   "containerd-wait-timeout",
+			Usage:  "how long to wait containerd to become available at startup",
+			EnvVar: "ELIOT_CONTAINERD_WAIT_TIMEOUT",
+			Value:  1 * time.Minute,
+		},
+		cli.BoolFlag{
+			Name:   "reap-orphans",
+			Usage:  "Clean up at startup the containerd tasks what unclean restart left without container or stopped but not deleted",
+			EnvVar: "ELIOT_REAP_ORPHANS",
+		},
+		cli.BoolFlag{
+			Name:   "allow-power-control",
+			Usage:  "Allow rebooting and shutting down the node through the API, containers get stopped gracefully first",
+			EnvVar: "ELIOT_ALLOW_POWER_CONTROL",
+		},
+		cli.BoolFlag{
+			Name:   "debug-events",
+			Usage:  "Allow streaming the raw containerd events through the API for debugging",
+			EnvVar: "ELIOT_DEBUG_EVENTS",
+		},
+		cli.BoolFlag{
+			Name:   "maintenance",
+			Usage:  "Start in maintenance mode where the API rejects creating and starting containers and the controllers don't start the scheduled or the idle containers, existing containers keep running. Can be ended through the API",
+			EnvVar: "ELIOT_MAINTENANCE",
+		},
+		cli.DurationFlag{
+			Name:   "reconcile-pause-max-timeout",
+			Usage:  "The longest time the controllers can be paused through the API before they resume automatically",
+			EnvVar: "ELIOT_RECONCILE_PAUSE_MAX_TIMEOUT",
+			Value:  1 * time.Hour,
+		},
+		cli.IntFlag{
+			Name:   "reconcile-history-size",
+			Usage:  "How many of the most recent lifecycle controller actions to keep in memory for the API",
+			EnvVar: "ELIOT_RECONCILE_HISTORY_SIZE",
+			Value:  100,
+		},
 		cli.StringFlag{
 			Name:   "containerd-snapshotter",
 			Usage:  "containerd snapshotter to use",
 			EnvVar: "ELIOT_CONTAINERD_SNAPSHOTTER",
 			Value:  "overlayfs",
 		},
+		cli.StringFlag{
+			Name:   "containerd-unpack-snapshotter",
+			Usage:  "containerd snapshotter to unpack pulled images and create the containers with, e.g. lazy pulling snapshotter. Defaults to --containerd-snapshotter",
+			EnvVar: "ELIOT_CONTAINERD_UNPACK_SNAPSHOTTER",
+		},
 		cli.DurationFlag{
 			Name:   "timeout, t",
 			Usage:  "total timeout for runtime requests",
 			EnvVar: "ELIOT_TIMEOUT",
 		},
+		cli.DurationFlag{
+			Name:   "unpack-timeout",
+			Usage:  "timeout for unpacking pulled image, separate from --timeout because unpack can take long on slow storage",
+			EnvVar: "ELIOT_UNPACK_TIMEOUT",
+		},
+		cli.DurationFlag{
+			Name:   "image-pull-timeout",
+			Usage:  "timeout for pulling image, separate from --timeout because pulling large image over slow network can take long. Defaults to --timeout",
+			EnvVar: "ELIOT_IMAGE_PULL_TIMEOUT",
+		},
+		cli.DurationFlag{
+			Name:   "pull-lease-duration",
+			Usage:  "how long pulled images are protected from garbage collection before any container uses them, zero disables",
+			EnvVar: "ELIOT_PULL_LEASE_DURATION",
+			Value:  1 * time.Hour,
+		},
+		cli.StringSliceFlag{
+			Name:   "registry-ca-file",
+			Usage:  "PEM encoded CA certificates to trust in addition to the system certificates when pulling images, e.g. for on-prem registry with internal CA",
+			EnvVar: "ELIOT_REGISTRY_CA_FILE",
+		},
+		cli.StringSliceFlag{
+			Name:   "insecure-registry",
+			Usage:  "Registry host, with optional port, what doesn't get the TLS certificate verified when pulling images",
+			EnvVar: "ELIOT_INSECURE_REGISTRY",
+		},
+		cli.StringFlag{
+			Name:   "init-path",
+			Usage:  "Init binary, e.g. /usr/bin/tini, what runs as PID 1 and reaps zombie processes in the containers with init enabled",
+			EnvVar: "ELIOT_INIT_PATH",
+		},
+		cli.StringFlag{
+			Name:   "bandwidth-interface",
+			Usage:  "Network interface, e.g. the uplink eth0, where the container egress rate limits get applied with tc. Empty disables the limits",
+			EnvVar: "ELIOT_BANDWIDTH_INTERFACE",
+		},
+		cli.StringFlag{
+			Name:   "max-image-size",
+			Usage:  "Reject images larger than this before pulling, e.g. 500MB. Empty means no limit",
+			EnvVar: "ELIOT_MAX_IMAGE_SIZE",
+		},
+		cli.StringSliceFlag{
+			Name:   "trusted-key",
+			Usage:  "PEM encoded public key file, e.g. cosign.pub, what the images must be signed with. Can be given multiple times, without keys the signatures don't get verified",
+			EnvVar: "ELIOT_TRUSTED_KEYS",
+		},
+		cli.IntFlag{
+			Name:   "max-containers",
+			Usage:  "Reject creating containers when the node already has this many containers, the stopped containers count too. Zero means no limit",
+			EnvVar: "ELIOT_MAX_CONTAINERS",
+		},
+		cli.StringSliceFlag{
+			Name:   "namespace-quota",
+			Usage:  "Limit the containers, total container memory limits and image disk usage in namespace, e.g. tenant-a:containers=10,memory=512MB,images=2GB. Can be given for multiple namespaces",
+			EnvVar: "ELIOT_NAMESPACE_QUOTAS",
+		},
+		cli.StringSliceFlag{
+			Name:   "host-env-allowlist",
+			Usage:  "eliotd environment variable what containers can forward with hostEnv, e.g. the values what the provisioning system sets. Can be given multiple times",
+			EnvVar: "ELIOT_HOST_ENV_ALLOWLIST",
+		},
+		cli.DurationFlag{
+			Name:   "snapshot-cleanup-timeout",
+			Usage:  "how long to wait the container snapshot to be removed when container gets deleted, so that the container can be created again with the same ID right away, zero disables waiting",
+			EnvVar: "ELIOT_SNAPSHOT_CLEANUP_TIMEOUT",
+		},
 		cli.BoolTFlag{
 			Name:   "lifecycle-controller",
 			Usage:  "Enable container lifecycle controller",
 			EnvVar: "ELIOT_LIFECYCLE_CONTROLLER",
 		},
+		cli.DurationFlag{
+			Name:   "restart-backoff-reset",
+			Usage:  "How long restarted container must keep running before its restart delay resets back to zero",
+			EnvVar: "ELIOT_RESTART_BACKOFF_RESET",
+			Value:  10 * time.Minute,
+		},
 		cli.BoolTFlag{
 			Name:   "grpc-api",
 			Usage:  "Enable GRPC API server",
@@ -73,6 +211,56 @@ func main() {
 			EnvVar: "ELIOT_GRPC_API_LISTEN",
 			Value:  "localhost:5000",
 		},
+		cli.DurationFlag{
+			Name:   "grpc-keepalive-time",
+			Usage:  "How long the GRPC connection can be idle before the server pings the client to check the connection is alive",
+			EnvVar: "ELIOT_GRPC_KEEPALIVE_TIME",
+			Value:  1 * time.Minute,
+		},
+		cli.DurationFlag{
+			Name:   "grpc-keepalive-timeout",
+			Usage:  "How long the server waits response to the keepalive ping before closing the connection",
+			EnvVar: "ELIOT_GRPC_KEEPALIVE_TIMEOUT",
+			Value:  20 * time.Second,
+		},
+		cli.DurationFlag{
+			Name:   "grpc-max-connection-idle",
+			Usage:  "How long the GRPC connection can be without any calls before the server closes it, zero means no limit",
+			EnvVar: "ELIOT_GRPC_MAX_CONNECTION_IDLE",
+		},
+		cli.DurationFlag{
+			Name:   "grpc-keepalive-min-time",
+			Usage:  "Minimum interval the clients are allowed to send keepalive pings, the server disconnects clients what ping more often",
+			EnvVar: "ELIOT_GRPC_KEEPALIVE_MIN_TIME",
+			Value:  10 * time.Second,
+		},
+		cli.StringFlag{
+			Name:   "default-registry",
+			Usage:  "Registry to pull images from when image reference doesn't include registry hostname",
+			EnvVar: "ELIOT_DEFAULT_REGISTRY",
+			Value:  "docker.io",
+		},
+		cli.StringFlag{
+			Name:   "data-dir",
+			Usage:  "Directory where eliotd stores its persistent data, e.g. the pull secrets and the container file secrets",
+			EnvVar: "ELIOT_DATA_DIR",
+			Value:  "/var/lib/eliot",
+		},
+		cli.StringFlag{
+			Name:   "pidfile",
+			Usage:  "Write eliotd pid to the file for init scripts, the file gets removed on shutdown. E.g. /var/run/eliotd.pid",
+			EnvVar: "ELIOT_PIDFILE",
+		},
+		cli.StringFlag{
+			Name:   "pull-secrets-key-file",
+			Usage:  "File containing the 32 byte key the secrets get encrypted with, generated if missing. Must be outside --data-dir, e.g. on separate partition, so the encrypted secrets and the key don't get exposed together. The secret store is disabled without it",
+			EnvVar: "ELIOT_PULL_SECRETS_KEY_FILE",
+		},
+		cli.StringFlag{
+			Name:   "api-tokens-file",
+			Usage:  "File containing the API tokens, one '<token> <namespace>[,<namespace>...]' per line, '*' permits all namespaces. Authentication is disabled if not set",
+			EnvVar: "ELIOT_API_TOKENS_FILE",
+		},
 		cli.BoolTFlag{
 			Name:   "discovery",
 			Usage:  "Enable discover GRPC server over zeroconf",
@@ -89,12 +277,36 @@ func main() {
 			EnvVar: "ELIOT_PROFILE_ADDRESS",
 			Value:  "0.0.0.0:8000",
 		},
+		cli.Float64Flag{
+			Name:   "supervisor-failure-threshold",
+			Usage:  "How many service failures within the decay period puts the supervisor into backoff",
+			EnvVar: "ELIOT_SUPERVISOR_FAILURE_THRESHOLD",
+			Value:  5,
+		},
+		cli.DurationFlag{
+			Name:   "supervisor-failure-decay",
+			Usage:  "Time period over which the service failure count decays",
+			EnvVar: "ELIOT_SUPERVISOR_FAILURE_DECAY",
+			Value:  30 * time.Second,
+		},
+		cli.DurationFlag{
+			Name:   "supervisor-failure-backoff",
+			Usage:  "How long the supervisor waits before restarting the services after exceeding the failure threshold",
+			EnvVar: "ELIOT_SUPERVISOR_FAILURE_BACKOFF",
+			Value:  15 * time.Second,
+		},
 		cli.StringFlag{
 			Name:   "labels",
 			Usage:  "Comma separated list of node labels. E.g. --labels node=rpi3,location=home,environment=testing",
 			EnvVar: "ELIOT_LABELS",
 		},
+		cli.StringFlag{
+			Name:   "groups",
+			Usage:  "Comma separated list of node groups what get advertised in the discovery. E.g. --groups deployment=prod,customer=acme,region=eu",
+			EnvVar: "ELIOT_GROUPS",
+		},
 	}, cmd.GlobalFlags...)
+	eliotversion.VERSION = version
 	app.Version = fmt.Sprintf("Version: %s, Commit: %s, Build at: %s", version, commit, date)
 	app.Before = cmd.GlobalBefore
 
@@ -104,42 +316,147 @@ func main() {
 			grpcPort   = parseGrpcPort(grpcListen)
 		)
 
-		resolver := node.NewResolver(grpcPort, version, cmd.GetLabels(clicontext))
+		if path := clicontext.String("pidfile"); path != "" {
+			file, err := pidfile.Write(path)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := file.Remove(); err != nil {
+					log.Warnln(err)
+				}
+			}()
+		}
+
+		if err := model.SetDefaultNamespace(clicontext.String("containerd-namespace")); err != nil {
+			return err
+		}
+
+		if err := mapping.SetLabelPrefix(clicontext.String("label-prefix")); err != nil {
+			return err
+		}
+
+		resolver := node.NewResolver(grpcPort, version, cmd.GetLabels(clicontext), cmd.GetGroups(clicontext))
 		node := resolver.GetInfo()
-		client := cmd.GetRuntimeClient(clicontext, node.Hostname)
+		client := cmd.GetRuntimeClient(clicontext, node.Hostname, resolver.GetInfo)
+		defer client.Close()
+		if err := client.WaitForReady(clicontext.Duration("containerd-wait-timeout")); err != nil {
+			return err
+		}
+
+		if clicontext.Bool("reap-orphans") {
+			count, err := client.ReapOrphans()
+			if err != nil {
+				log.Warnf("Failed to reap orphan containerd tasks: %s", err)
+			} else {
+				log.Infof("Reaped %d orphan containerd tasks", count)
+			}
+		}
 
-		supervisor := suture.NewSimple("eliotd")
-		serviceCount := 0
+		supervisor := newSupervisor(clicontext)
+		var services, listen []string
+		pause := controller.NewReconcilePause(clicontext.Duration("reconcile-pause-max-timeout"))
+		readiness := controller.NewReadiness()
+		history := controller.NewReconcileHistory(clicontext.Int("reconcile-history-size"))
+		maintenance := controller.NewMaintenance()
+		if clicontext.Bool("maintenance") {
+			maintenance.Set(true, "eliotd started with --maintenance")
+		}
 
 		if clicontext.BoolT("profile") {
 			profileAddr := clicontext.String("profile-address")
 			log.Infof("profiling enabled, address: %s", profileAddr)
 			supervisor.Add(profile.NewServer(profileAddr))
-			serviceCount++
+			services = append(services, "profile")
+			listen = append(listen, fmt.Sprintf("profile %s", profileAddr))
 		}
 
 		if clicontext.Bool("grpc-api") {
 			log.Infoln("grpc-api enabled")
-			supervisor.Add(api.NewServer(grpcListen, client, resolver))
-			serviceCount++
+			listener, err := api.SystemdListener()
+			if err != nil {
+				return err
+			}
+			secretStore, err := newSecretStore(clicontext)
+			if err != nil {
+				return err
+			}
+			if secretStore != nil {
+				client.SetSecretResolver(func(namespace, name string) ([]byte, error) {
+					secret, err := secretStore.GetSecret(namespace, name)
+					return secret.Data, err
+				})
+			}
+			opts := []api.ServerOpts{
+				api.WithDefaultRegistry(clicontext.String("default-registry")),
+				api.WithPullSecrets(secretStore),
+				api.WithKeepalive(keepalive.ServerParameters{
+					Time:              clicontext.Duration("grpc-keepalive-time"),
+					Timeout:           clicontext.Duration("grpc-keepalive-timeout"),
+					MaxConnectionIdle: clicontext.Duration("grpc-max-connection-idle"),
+				}, keepalive.EnforcementPolicy{
+					MinTime:             clicontext.Duration("grpc-keepalive-min-time"),
+					PermitWithoutStream: true,
+				}),
+			}
+			if tokensFile := clicontext.String("api-tokens-file"); tokensFile != "" {
+				auth, err := api.LoadTokens(tokensFile)
+				if err != nil {
+					return err
+				}
+				log.Infoln("API token authentication enabled")
+				opts = append(opts, api.WithAuthentication(auth))
+			}
+			if clicontext.Bool("allow-power-control") {
+				log.Infoln("power control through the API enabled")
+				opts = append(opts, api.WithPowerControl())
+			}
+			if clicontext.Bool("debug-events") {
+				log.Infoln("runtime event stream through the API enabled")
+				opts = append(opts, api.WithDebugEvents())
+			}
+			opts = append(opts, api.WithMaintenance(maintenance))
+			if clicontext.Bool("lifecycle-controller") {
+				opts = append(opts, api.WithReconcilePause(pause), api.WithReconcileHistory(history), api.WithReadiness(readiness))
+			}
+			if listener != nil {
+				log.Infof("Using socket from systemd socket activation: %s", listener.Addr())
+				opts = append(opts, api.WithListener(listener))
+				listen = append(listen, fmt.Sprintf("grpc-api %s (systemd socket)", listener.Addr()))
+			} else {
+				listen = append(listen, fmt.Sprintf("grpc-api %s", grpcListen))
+			}
+			supervisor.Add(api.NewServer(grpcListen, client, resolver, opts...))
+			services = append(services, "grpc-api")
 		}
 
 		if clicontext.Bool("lifecycle-controller") {
 			log.Infoln("lifecycle-controller enabled")
-			supervisor.Add(controller.NewLifecycle(client))
-			serviceCount++
+			pods := controller.NewPodWatcher(client)
+			supervisor.Add(pods)
+			supervisor.Add(controller.NewLifecycle(client, pods, pause, history, readiness, clicontext.Duration("restart-backoff-reset")))
+			supervisor.Add(controller.NewProber(client, pods, pause))
+			supervisor.Add(controller.NewScheduler(client, pods, pause, maintenance, history))
+			supervisor.Add(controller.NewFileWatcher(client, pods, pause))
+			supervisor.Add(controller.NewNetworkWatcher(client, pods, pause))
+			supervisor.Add(controller.NewIdleStopper(client, pods, pause, maintenance, history))
+			services = append(services, "lifecycle-controller")
 		}
 
 		if clicontext.Bool("grpc-api") && clicontext.Bool("discovery") {
 			log.Infoln("grpc discovery over zeroconf enabled")
-			supervisor.Add(discovery.NewServer(node.Hostname, grpcPort, version))
-			serviceCount++
+			labels, _ := resolver.SubscribeLabels()
+			supervisor.Add(discovery.NewServer(node.Hostname, grpcPort, version, node.Groups, node.Labels, labels))
+			services = append(services, "discovery")
 		}
 
-		if serviceCount == 0 {
+		if len(services) == 0 {
 			return errors.New("Nothing to run. You should enable one of [grpc-api, lifecycle-controller, discovery]")
 		}
 
+		cmd.NewStartupReport(client, *resolver.GetInfo(), clicontext.String("containerd"), services, listen).Log()
+
+		stopOnSignal(supervisor)
 		supervisor.Serve()
 
 		return nil
@@ -150,6 +467,32 @@ func main() {
 	}
 }
 
+// newSupervisor creates the supervisor what restarts the failed services
+// and logs the restarts so the service instability is visible
+func newSupervisor(clicontext *cli.Context) *suture.Supervisor {
+	return suture.New("eliotd", suture.Spec{
+		Log: func(message string) {
+			log.Warnln(message)
+		},
+		FailureThreshold: clicontext.Float64("supervisor-failure-threshold"),
+		FailureDecay:     clicontext.Duration("supervisor-failure-decay").Seconds(),
+		FailureBackoff:   clicontext.Duration("supervisor-failure-backoff"),
+	})
+}
+
+// stopOnSignal stops the supervisor on SIGINT or SIGTERM so that eliotd shuts down cleanly,
+// e.g. removes the pidfile, when the init system stops it
+func stopOnSignal(supervisor *suture.Supervisor) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		log.Infof("Received %s, shutting down...", sig)
+		signal.Stop(signals)
+		supervisor.Stop()
+	}()
+}
+
 func parseGrpcPort(addr string) int {
 	parts := strings.Split(addr, ":")
 	if len(parts) != 2 {
@@ -162,3 +505,47 @@ func parseGrpcPort(addr string) int {
 	}
 	return port
 }
+
+// newSecretStore returns nil if the key file is not configured, the key must not be in the data dir
+// with the encrypted secrets because then the encryption wouldn't protect them from the disk access
+func newSecretStore(clicontext *cli.Context) (*secrets.Store, error) {
+	dataDir := clicontext.String("data-dir")
+	keyFile := clicontext.String("pull-secrets-key-file")
+	if keyFile == "" {
+		log.Warnln("Secret store disabled, set --pull-secrets-key-file to enable the pull secrets and the secret files")
+		return nil, nil
+	}
+	if isWithinDir(keyFile, dataDir) {
+		return nil, fmt.Errorf("Secret key file [%s] must not be in the data dir [%s] where the encrypted secrets are stored", keyFile, dataDir)
+	}
+	key, err := secrets.LoadOrCreateKey(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	store, err := secrets.NewStore(filepath.Join(dataDir, "pull-secrets"), key)
+	if err != nil {
+		return nil, err
+	}
+	migrated, err := store.MigrateGlobalSecrets(model.DefaultNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to move the pull secrets to the default namespace: %s", err)
+	}
+	if migrated > 0 {
+		log.Infof("Moved %d pull secrets to namespace [%s], the secrets can be used only in their own namespace", migrated, model.DefaultNamespace)
+	}
+	return store, nil
+}
+
+// isWithinDir returns true if the path is the dir or inside it
+func isWithinDir(path, dir string) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"time"
+
+	"github.com/c2h5oh/datasize"
 	ui "github.com/ernoaapa/eliot/pkg/cmd/ui"
 	"github.com/ernoaapa/eliot/pkg/progress"
 )
@@ -18,6 +21,10 @@ func ShowDownloadProgress(progressc <-chan []*progress.ImageFetch) {
 			if fetch.IsDone() {
 				if fetch.Failed {
 					lines[fetch.Image].Errorf("Failed %s", fetch.Image)
+				} else if stats := fetch.GetStats(); stats != nil && stats.Cached {
+					lines[fetch.Image].Donef("Using cached %s", fetch.Image)
+				} else if stats != nil {
+					lines[fetch.Image].Donef("Downloaded %s (%d/%d layers, %s in %s)", fetch.Image, stats.FetchedLayers, stats.Layers, datasize.ByteSize(stats.BytesFetched).HR(), stats.Duration.Round(100*time.Millisecond))
 				} else {
 					lines[fetch.Image].Donef("Downloaded %s", fetch.Image)
 				}
@@ -32,3 +39,27 @@ func ShowDownloadProgress(progressc <-chan []*progress.ImageFetch) {
 		line.Donef("Completed %s", image)
 	}
 }
+
+// ShowCreatePhases prints UI line for each container and updates it with the
+// create and start phase until the phases channel closes
+func ShowCreatePhases(phasesc <-chan []progress.Phase) {
+	lines := map[string]ui.Line{}
+	for phases := range phasesc {
+		for _, phase := range phases {
+			line, ok := lines[phase.Container]
+			if !ok {
+				line = ui.NewLine()
+				lines[phase.Container] = line
+			}
+
+			switch phase.Phase {
+			case progress.PhaseRunning:
+				line.Donef("Container %s running", phase.Container)
+			case progress.PhaseFailed:
+				line.Errorf("Container %s failed: %s", phase.Container, phase.Error)
+			default:
+				line.Loadingf("Container %s %s", phase.Container, phase.Phase)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	log "github.com/sirupsen/logrus"
+)
+
+// StartupReport is the summary of the node, the runtime and the eliotd configuration what gets logged
+// when eliotd starts, so that diagnosing a device doesn't require checking each of them separately
+type StartupReport struct {
+	Lines    []string
+	Warnings []string
+}
+
+// NewStartupReport probes the containerd runtime and summarises it with the resolved node info,
+// the enabled services and the addresses what the services listen
+func NewStartupReport(client runtime.Client, node model.NodeInfo, containerd string, services, listen []string) (report StartupReport) {
+	report.add("eliotd %s on node [%s] %s/%s", node.Version, node.Hostname, node.OS, node.Arch)
+	report.add("Node identity: machine-id=%s system-uuid=%s boot-id=%s", node.MachineID, node.SystemUUID, node.BootID)
+	report.add("Node labels: %s", formatKeyValues(node.Labels))
+	if len(node.Groups) > 0 {
+		report.add("Node groups: %s", formatKeyValues(node.Groups))
+	}
+	report.add("Node addresses: %s", formatAddresses(node.Addresses))
+
+	info, err := client.GetRuntimeInfo()
+	if err != nil {
+		report.warn("containerd at [%s] is not reachable: %s", containerd, err)
+	} else {
+		report.add("containerd %s (%s) at [%s], snapshotters: %s, runtimes: %s", info.ContainerdVersion, info.ContainerdRevision, containerd, formatList(info.Snapshotters), formatList(info.Runtimes))
+		report.add("Snapshotter [%s], containers: %d, runtime features: %s", info.Snapshotter, info.Containers, formatList(info.Features))
+		if !contains(info.Snapshotters, info.Snapshotter) {
+			report.warn("Snapshotter [%s] is not available in containerd%s, creating containers will fail", info.Snapshotter, getPluginError(info.Plugins, info.Snapshotter))
+		}
+	}
+
+	report.add("Enabled services: %s", formatList(services))
+	report.add("Listening: %s", formatList(listen))
+
+	if !node.ClockSynchronized {
+		report.warn("System clock is not synchronized, TLS connections e.g. image pulls can fail until it gets synced")
+	}
+	for _, fault := range node.Faults {
+		report.warn("Failed to resolve node %s: %s", fault.Field, fault.Reason)
+	}
+	return report
+}
+
+// Log logs the report lines as info and the warnings as warnings
+func (r StartupReport) Log() {
+	for _, line := range r.Lines {
+		log.Infoln(line)
+	}
+	for _, warning := range r.Warnings {
+		log.Warnln(warning)
+	}
+}
+
+func (r *StartupReport) add(format string, args ...interface{}) {
+	r.Lines = append(r.Lines, fmt.Sprintf(format, args...))
+}
+
+func (r *StartupReport) warn(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// getPluginError returns the reason why containerd failed to load the snapshotter plugin, empty if not known
+func getPluginError(plugins []model.PluginStatus, id string) string {
+	for _, plugin := range plugins {
+		if plugin.ID == id && plugin.Error != "" {
+			return fmt.Sprintf(" (%s)", plugin.Error)
+		}
+	}
+	return ""
+}
+
+func formatKeyValues(values map[string]string) string {
+	pairs := []string{}
+	for key, value := range values {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(pairs)
+	return formatList(pairs)
+}
+
+func formatAddresses(addresses []net.IP) string {
+	result := []string{}
+	for _, address := range addresses {
+		result = append(result, address.String())
+	}
+	return formatList(result)
+}
+
+func formatList(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRuntimeInfoClient struct {
+	runtime.Client
+	info model.RuntimeInfo
+	err  error
+}
+
+func (c *fakeRuntimeInfoClient) GetRuntimeInfo() (model.RuntimeInfo, error) {
+	return c.info, c.err
+}
+
+func newStartupNode() model.NodeInfo {
+	return model.NodeInfo{
+		Hostname:          "rpi3",
+		Version:           "1.0.0",
+		OS:                "linux",
+		Arch:              "arm",
+		MachineID:         "machine",
+		SystemUUID:        "uuid",
+		BootID:            "boot",
+		Labels:            map[string]string{"location": "home", "env": "testing"},
+		Addresses:         []net.IP{net.ParseIP("192.168.1.2")},
+		ClockSynchronized: true,
+	}
+}
+
+func TestStartupReport(t *testing.T) {
+	client := &fakeRuntimeInfoClient{info: model.RuntimeInfo{
+		ContainerdVersion:  "v1.0.0",
+		ContainerdRevision: "abc",
+		Snapshotter:        "overlayfs",
+		Snapshotters:       []string{"native", "overlayfs"},
+		Runtimes:           []string{"io.containerd.runtime.v1.linux"},
+	}}
+
+	report := NewStartupReport(client, newStartupNode(), "/run/containerd/containerd.sock", []string{"grpc-api"}, []string{"grpc-api localhost:5000"})
+	assert.Contains(t, report.Lines, "Node labels: env=testing, location=home")
+	assert.Contains(t, report.Lines, "containerd v1.0.0 (abc) at [/run/containerd/containerd.sock], snapshotters: native, overlayfs, runtimes: io.containerd.runtime.v1.linux")
+	assert.Contains(t, report.Lines, "Listening: grpc-api localhost:5000")
+	assert.Empty(t, report.Warnings)
+}
+
+func TestStartupReportWarnings(t *testing.T) {
+	client := &fakeRuntimeInfoClient{info: model.RuntimeInfo{
+		Snapshotter:  "overlayfs",
+		Snapshotters: []string{"native"},
+		Plugins:      []model.PluginStatus{{Type: "io.containerd.snapshotter.v1", ID: "overlayfs", Error: "not supported"}},
+	}}
+	node := newStartupNode()
+	node.ClockSynchronized = false
+
+	report := NewStartupReport(client, node, "/run/containerd/containerd.sock", nil, nil)
+	assert.Equal(t, []string{
+		"Snapshotter [overlayfs] is not available in containerd (not supported), creating containers will fail",
+		"System clock is not synchronized, TLS connections e.g. image pulls can fail until it gets synced",
+	}, report.Warnings)
+}
+
+func TestStartupReportContainerdNotReachable(t *testing.T) {
+	client := &fakeRuntimeInfoClient{err: fmt.Errorf("connection refused")}
+
+	report := NewStartupReport(client, newStartupNode(), "/run/containerd/containerd.sock", nil, nil)
+	assert.Equal(t, []string{"containerd at [/run/containerd/containerd.sock] is not reachable: connection refused"}, report.Warnings)
+	assert.Contains(t, report.Lines, "Enabled services: none")
+}
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"context"
+	"crypto"
+	"crypto/x509"
 	"encoding/csv"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/signal"
 	"os/user"
@@ -12,12 +15,15 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/c2h5oh/datasize"
 	"github.com/ernoaapa/eliot/pkg/cmd"
 	ui "github.com/ernoaapa/eliot/pkg/cmd/ui"
 	"github.com/ernoaapa/eliot/pkg/discovery"
+	"github.com/ernoaapa/eliot/pkg/model"
 	"github.com/ernoaapa/eliot/pkg/printers"
 	"github.com/ernoaapa/eliot/pkg/sync"
 	"github.com/ernoaapa/eliot/pkg/utils"
+	"github.com/ernoaapa/eliot/pkg/version"
 
 	"github.com/sirupsen/logrus"
 
@@ -89,6 +95,9 @@ func GetClient(config *config.Provider) *api.Client {
 			logrus.Debugf("Connection failure: %s", err)
 			uiline.Fatalf("Failed connect to %s (%s)", endpoints[0].Name, endpoints[0].URL)
 		}
+		if err := version.CheckServerCompatibility(info.Version, info.MinClientVersion); err != nil {
+			uiline.Fatalf("Incompatible node %s (%s): %s", info.Hostname, endpoints[0].URL, err)
+		}
 		uiline.Donef("Connected to %s (%s)", info.Hostname, endpoints[0].URL)
 		return client
 	default:
@@ -117,6 +126,10 @@ func GetConfigProvider(clicontext *cli.Context) *config.Provider {
 		provider.OverrideNamespace(clicontext.GlobalString("namespace"))
 	}
 
+	if clicontext.GlobalIsSet("token") && clicontext.GlobalString("token") != "" {
+		provider.OverrideToken(clicontext.GlobalString("token"))
+	}
+
 	if clicontext.GlobalIsSet("endpoint") && clicontext.GlobalString("endpoint") != "" {
 		provider.OverrideEndpoints([]config.Endpoint{{
 			Name: clicontext.GlobalString("endpoint"),
@@ -139,6 +152,10 @@ func GetConfigProvider(clicontext *cli.Context) *config.Provider {
 
 		endpoints := []config.Endpoint{}
 		for _, node := range node {
+			if err := version.CheckServerCompatibility(node.Version, node.MinClientVersion); err != nil {
+				ui.NewLine().Warnf("Skip incompatible node %s: %s", node.Hostname, err)
+				continue
+			}
 			if len(node.Addresses) > 0 {
 				endpoints = append(endpoints, config.Endpoint{
 					Name: node.Hostname,
@@ -170,36 +187,132 @@ func UpdateConfig(clicontext *cli.Context, updated *config.Config) error {
 
 // GetLabels return --labels CLI parameter value as string map
 func GetLabels(clicontext *cli.Context) map[string]string {
-	if !clicontext.IsSet("labels") {
+	return getKeyValues(clicontext, "labels")
+}
+
+// GetGroups return --groups CLI parameter value as string map
+func GetGroups(clicontext *cli.Context) map[string]string {
+	return getKeyValues(clicontext, "groups")
+}
+
+func getKeyValues(clicontext *cli.Context, name string) map[string]string {
+	if !clicontext.IsSet(name) {
 		return map[string]string{}
 	}
 
-	param := clicontext.String("labels")
+	param := clicontext.String(name)
 	values := strings.Split(param, ",")
 
-	labels := map[string]string{}
+	result := map[string]string{}
 	for _, value := range values {
 		pair := strings.Split(value, "=")
 		if len(pair) == 2 {
-			labels[pair[0]] = pair[1]
+			result[pair[0]] = pair[1]
 		} else {
-			ui.NewLine().Fatalf("Invalid --labels parameter [%s]. It must be comma separated key=value list. E.g. '--labels foo=bar,one=two'", param)
+			ui.NewLine().Fatalf("Invalid --%s parameter [%s]. It must be comma separated key=value list. E.g. '--%s foo=bar,one=two'", name, param, name)
 		}
 	}
-	return labels
+	return result
 }
 
 // GetRuntimeClient initialises new runtime client from CLI parameters
-func GetRuntimeClient(clicontext *cli.Context, hostname string) runtime.Client {
+func GetRuntimeClient(clicontext *cli.Context, hostname string, deviceInfo runtime.DeviceInfo) runtime.Client {
 	return runtime.NewContainerdClient(
 		context.Background(),
 		clicontext.GlobalDuration("timeout"),
-		clicontext.GlobalString("containerd"),
 		clicontext.String("containerd-snapshotter"),
+		clicontext.GlobalString("containerd"),
 		hostname,
+		runtime.WithUnpackTimeout(clicontext.GlobalDuration("unpack-timeout")),
+		runtime.WithPullTimeout(clicontext.GlobalDuration("image-pull-timeout")),
+		runtime.WithPullLease(clicontext.Duration("pull-lease-duration")),
+		runtime.WithMaxImageSize(getMaxImageSize(clicontext)),
+		runtime.WithUnpackSnapshotter(clicontext.String("containerd-unpack-snapshotter")),
+		runtime.WithDeviceInfo(deviceInfo),
+		runtime.WithRegistryTLS(getRegistryTLS(clicontext)),
+		runtime.WithInitPath(clicontext.String("init-path")),
+		runtime.WithBandwidthDevice(clicontext.String("bandwidth-interface")),
+		runtime.WithMaxContainers(clicontext.Int("max-containers")),
+		runtime.WithTrustedKeys(getTrustedKeys(clicontext)),
+		runtime.WithQuotas(getNamespaceQuotas(clicontext)),
+		runtime.WithSnapshotCleanup(clicontext.Duration("snapshot-cleanup-timeout")),
+		runtime.WithHostEnv(clicontext.StringSlice("host-env-allowlist")),
 	)
 }
 
+// getTrustedKeys loads the --trusted-key public keys what the images must be signed with
+func getTrustedKeys(clicontext *cli.Context) []crypto.PublicKey {
+	keys := []crypto.PublicKey{}
+	for _, file := range clicontext.StringSlice("trusted-key") {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			ui.NewLine().Fatalf("Failed to read --trusted-key [%s]: %s", file, err)
+		}
+		key, err := runtime.ParseTrustedKey(data)
+		if err != nil {
+			ui.NewLine().Fatalf("Invalid --trusted-key [%s]: %s", file, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// getNamespaceQuotas parses the --namespace-quota parameters, e.g. tenant-a:containers=10,memory=512MB,images=2GB
+func getNamespaceQuotas(clicontext *cli.Context) map[string]model.NamespaceQuota {
+	quotas := map[string]model.NamespaceQuota{}
+	for _, value := range clicontext.StringSlice("namespace-quota") {
+		namespace, quota, err := runtime.ParseNamespaceQuota(value)
+		if err != nil {
+			ui.NewLine().Fatalf("Invalid --namespace-quota parameter [%s]: %s. E.g. '--namespace-quota tenant-a:containers=10,memory=512MB,images=2GB'", value, err)
+		}
+		quotas[namespace] = quota
+	}
+	return quotas
+}
+
+// getRegistryTLS loads the --registry-ca-file certificates on top of the system certificates
+// and resolves the --insecure-registry hosts what don't get the certificate verified
+func getRegistryTLS(clicontext *cli.Context) runtime.RegistryTLS {
+	config := runtime.RegistryTLS{
+		InsecureHosts: clicontext.StringSlice("insecure-registry"),
+	}
+
+	files := clicontext.StringSlice("registry-ca-file")
+	if len(files) == 0 {
+		return config
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		logrus.Warnf("Failed to load system certificates, trust only the --registry-ca-file certificates: %s", err)
+		pool = x509.NewCertPool()
+	}
+	for _, file := range files {
+		pem, err := ioutil.ReadFile(file)
+		if err != nil {
+			ui.NewLine().Fatalf("Failed to read --registry-ca-file [%s]: %s", file, err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			ui.NewLine().Fatalf("Invalid --registry-ca-file [%s]. It must contain PEM encoded certificates", file)
+		}
+	}
+	config.RootCAs = pool
+	return config
+}
+
+// getMaxImageSize parses the --max-image-size parameter, e.g. 500MB
+func getMaxImageSize(clicontext *cli.Context) int64 {
+	value := clicontext.String("max-image-size")
+	if value == "" {
+		return 0
+	}
+	var size datasize.ByteSize
+	if err := size.UnmarshalText([]byte(value)); err != nil {
+		ui.NewLine().Fatalf("Invalid --max-image-size parameter [%s]. It must be size with unit, e.g. '--max-image-size 500MB'", value)
+	}
+	return int64(size.Bytes())
+}
+
 // GetPrinter returns printer for formating resources output
 func GetPrinter(clicontext *cli.Context) printers.ResourcePrinter {
 	switch output := clicontext.GlobalString("output"); output {
@@ -139,3 +139,16 @@ func TestGetConfigProviderWithEndpointFlag(t *testing.T) {
 		URL:  "1.2.3.4:5000",
 	}}, provider.GetEndpoints(), "")
 }
+
+func TestGetGroups(t *testing.T) {
+	flags := flag.NewFlagSet("test", 0)
+	flags.String("groups", "", "")
+
+	clicontext := cli.NewContext(nil, flags, nil)
+	flags.Parse([]string{"--groups", "region=eu,customer=acme"})
+
+	assert.Equal(t, map[string]string{
+		"region":   "eu",
+		"customer": "acme",
+	}, GetGroups(clicontext))
+}
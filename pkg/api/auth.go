@@ -0,0 +1,310 @@
+package api
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"fmt"
+	"os"
+	"strings"
+
+	containers "github.com/ernoaapa/eliot/pkg/api/services/containers/v1"
+	pods "github.com/ernoaapa/eliot/pkg/api/services/pods/v1"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// authorizationKey is the metadata key where the client passes the token
+	authorizationKey = "authorization"
+	// bearerPrefix is the authorization metadata value prefix before the token
+	bearerPrefix = "Bearer "
+	// allNamespaces is the token scope what permits access to every namespace and to the node level operations
+	allNamespaces = "*"
+	// unconfinedProfile is the AppArmor profile what disables the confinement
+	unconfinedProfile = "unconfined"
+)
+
+// publicMethods can be called with any valid token, e.g. the client checks the node version before anything else
+var publicMethods = map[string]bool{
+	"/eliot.services.node.v1.Node/Info":     true,
+	"/eliot.services.node.v1.Node/Identity": true,
+	"/grpc.health.v1.Health/Check":          true,
+}
+
+// Authenticator resolves the API tokens to the namespaces they are permitted to access
+type Authenticator struct {
+	tokens []tokenScope
+}
+
+type tokenScope struct {
+	token      string
+	namespaces map[string]bool
+}
+
+// NewAuthenticator creates new Authenticator from token to namespaces mapping
+// Namespace "*" permits access to all namespaces, to the node level operations and creating pods with host access
+func NewAuthenticator(tokens map[string][]string) *Authenticator {
+	auth := &Authenticator{}
+	for token, namespaces := range tokens {
+		scope := tokenScope{token: token, namespaces: map[string]bool{}}
+		for _, namespace := range namespaces {
+			scope.namespaces[namespace] = true
+		}
+		auth.tokens = append(auth.tokens, scope)
+	}
+	return auth
+}
+
+// LoadTokens reads the token file where each line is token and comma separated list of
+// namespaces the token permits, e.g. "s3cr3t dev,staging". Empty lines and lines starting with # are skipped
+func LoadTokens(path string) (*Authenticator, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to open token file [%s]", path)
+	}
+	defer file.Close()
+
+	tokens := map[string][]string{}
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, errors.Errorf("Invalid token file [%s] line %d, must be in format '<token> <namespace>[,<namespace>...]'", path, lineNumber)
+		}
+		if _, ok := tokens[fields[0]]; ok {
+			return nil, errors.Errorf("Invalid token file [%s] line %d, duplicate token", path, lineNumber)
+		}
+		tokens[fields[0]] = strings.Split(fields[1], ",")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "Failed to read token file [%s]", path)
+	}
+	return NewAuthenticator(tokens), nil
+}
+
+// authenticate returns the namespaces the token in the context metadata permits
+func (a *Authenticator) authenticate(ctx context.Context) (map[string]bool, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	value := getMetadataValue(md, authorizationKey)
+	if value == "" {
+		return nil, status.Error(codes.Unauthenticated, "Missing authorization token")
+	}
+	if !strings.HasPrefix(value, bearerPrefix) {
+		return nil, status.Error(codes.Unauthenticated, "Invalid authorization, must be in format 'Bearer <token>'")
+	}
+	token := strings.TrimPrefix(value, bearerPrefix)
+
+	var found map[string]bool
+	for _, scope := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(scope.token), []byte(token)) == 1 {
+			found = scope.namespaces
+		}
+	}
+	if found == nil {
+		return nil, status.Error(codes.Unauthenticated, "Invalid authorization token")
+	}
+	return found, nil
+}
+
+// authorize checks that the permitted namespaces contain the requested ones
+// Empty requested namespaces means node level operation what requires access to all namespaces
+func authorize(permitted map[string]bool, method string, requested []string) error {
+	if permitted[allNamespaces] {
+		return nil
+	}
+	if len(requested) == 0 {
+		return status.Errorf(codes.PermissionDenied, "Token is not permitted to call %s, requires access to all namespaces", method)
+	}
+	for _, namespace := range requested {
+		if !permitted[namespace] {
+			return status.Errorf(codes.PermissionDenied, "Token is not permitted to access namespace [%s]", namespace)
+		}
+	}
+	return nil
+}
+
+// authorizePrivileged checks that the token permits all namespaces if the request creates pods with fields what
+// give access to the host, e.g. host mounts or capabilities, because such pod can escape its namespace
+func authorizePrivileged(permitted map[string]bool, req interface{}) error {
+	if permitted[allNamespaces] {
+		return nil
+	}
+	for _, pod := range getCreatedPods(req) {
+		if field := getPrivilegedField(pod); field != "" {
+			return status.Errorf(codes.PermissionDenied, "Token is not permitted to create pod [%s] with %s, requires access to all namespaces", pod.GetMetadata().GetName(), field)
+		}
+	}
+	return nil
+}
+
+// getCreatedPods returns the pods what the request creates
+func getCreatedPods(req interface{}) []*pods.Pod {
+	switch r := req.(type) {
+	case *pods.CreatePodRequest:
+		return []*pods.Pod{r.GetPod()}
+	case *pods.RunPodRequest:
+		return []*pods.Pod{r.GetPod()}
+	case *pods.ApplyPodsRequest:
+		return r.GetPods()
+	}
+	return nil
+}
+
+// getPrivilegedField returns the name of the first field what gives the pod access to the host, empty if none
+func getPrivilegedField(pod *pods.Pod) string {
+	spec := pod.GetSpec()
+	switch {
+	case spec.GetHostNetwork():
+		return "hostNetwork"
+	case spec.GetHostPID():
+		return "hostPID"
+	}
+	for _, container := range spec.GetContainers() {
+		if field := getPrivilegedContainerField(container); field != "" {
+			return fmt.Sprintf("container [%s] %s", container.GetName(), field)
+		}
+	}
+	return ""
+}
+
+func getPrivilegedContainerField(container *containers.Container) string {
+	switch {
+	case container.GetSpecPatch() != "":
+		return "specPatch"
+	case container.GetCapabilities() != nil:
+		return "capabilities"
+	case len(container.GetSysctls()) > 0:
+		return "sysctls"
+	case len(container.GetMounts()) > 0:
+		return "host mounts"
+	case len(container.GetDevices()) > 0:
+		return "devices"
+	case len(container.GetAccelerators()) > 0:
+		return "accelerators"
+	case container.GetHostNetwork():
+		return "hostNetwork"
+	case container.GetCgroupParent() != "":
+		return "cgroupParent"
+	case container.GetAppArmorProfile() == unconfinedProfile:
+		return "unconfined appArmorProfile"
+	case container.GetOomScoreAdj() < 0:
+		return "negative oomScoreAdj"
+	}
+	return ""
+}
+
+// unaryInterceptor authenticates every unary call and checks that the requested namespaces are permitted
+func (a *Authenticator) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	permitted, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !publicMethods[info.FullMethod] {
+		if err := authorize(permitted, info.FullMethod, getRequestNamespaces(req)); err != nil {
+			return nil, err
+		}
+	}
+	if err := authorizePrivileged(permitted, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamInterceptor authenticates every streaming call and checks that the namespace in the metadata
+// and the namespaces in the received messages are permitted
+func (a *Authenticator) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	permitted, err := a.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	authorized := &authorizedStream{ServerStream: stream, permitted: permitted, method: info.FullMethod}
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	if namespace := getMetadataValue(md, "namespace"); namespace != "" {
+		if err := authorize(permitted, info.FullMethod, []string{namespace}); err != nil {
+			return err
+		}
+		authorized.checked = true
+	}
+	return handler(srv, authorized)
+}
+
+// authorizedStream checks the namespaces of the received messages
+// If the namespace was not in the metadata, the first message must have it or the token must permit all namespaces
+type authorizedStream struct {
+	grpc.ServerStream
+	permitted map[string]bool
+	method    string
+	checked   bool
+}
+
+// RecvMsg implements grpc.ServerStream
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := authorizePrivileged(s.permitted, m); err != nil {
+		return err
+	}
+
+	namespaces := getRequestNamespaces(m)
+	if len(namespaces) == 0 && s.checked {
+		return nil
+	}
+	if err := authorize(s.permitted, s.method, namespaces); err != nil {
+		return err
+	}
+	s.checked = true
+	return nil
+}
+
+// getRequestNamespaces returns the namespaces the request targets, e.g. both the source and the target of a move
+// Returns nil for requests what target all namespaces or no namespace at all
+func getRequestNamespaces(req interface{}) (result []string) {
+	if r, ok := req.(interface{ GetAllNamespaces() bool }); ok && r.GetAllNamespaces() {
+		return nil
+	}
+	if r, ok := req.(interface{ GetNamespace() string }); ok {
+		result = append(result, model.ResolveNamespace(r.GetNamespace()))
+	}
+	if r, ok := req.(interface{ GetTargetNamespace() string }); ok {
+		result = append(result, model.ResolveNamespace(r.GetTargetNamespace()))
+	}
+	if r, ok := req.(interface{ GetPod() *pods.Pod }); ok && r.GetPod() != nil {
+		result = append(result, model.ResolveNamespace(r.GetPod().GetMetadata().GetNamespace()))
+	}
+	if r, ok := req.(interface{ GetPods() []*pods.Pod }); ok {
+		for _, pod := range r.GetPods() {
+			result = append(result, model.ResolveNamespace(pod.GetMetadata().GetNamespace()))
+		}
+	}
+	return result
+}
+
+// chainUnaryInterceptors makes the outer interceptor call the inner before the handler
+func chainUnaryInterceptors(outer, inner grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return outer(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return inner(ctx, req, info, handler)
+		})
+	}
+}
+
+// chainStreamInterceptors makes the outer interceptor call the inner before the handler
+func chainStreamInterceptors(outer, inner grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return outer(srv, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+			return inner(srv, stream, info, handler)
+		})
+	}
+}
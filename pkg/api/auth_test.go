@@ -0,0 +1,209 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	core "github.com/ernoaapa/eliot/pkg/api/core"
+	containers "github.com/ernoaapa/eliot/pkg/api/services/containers/v1"
+	images "github.com/ernoaapa/eliot/pkg/api/services/images/v1"
+	node "github.com/ernoaapa/eliot/pkg/api/services/node/v1"
+	pods "github.com/ernoaapa/eliot/pkg/api/services/pods/v1"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testAuth = NewAuthenticator(map[string][]string{
+	"admin-token": {"*"},
+	"dev-token":   {"dev", "staging"},
+})
+
+func withToken(token string, pairs ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(append([]string{"authorization", "Bearer " + token}, pairs...)...))
+}
+
+func callUnary(ctx context.Context, method string, req interface{}) error {
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	_, err := testAuth.unaryInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	return err
+}
+
+func TestUnaryAuthRequiresValidToken(t *testing.T) {
+	err := callUnary(context.Background(), "/eliot.services.pods.v1.Pods/List", &pods.ListPodsRequest{Namespace: "dev"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should reject call without token")
+
+	err = callUnary(withToken("wrong"), "/eliot.services.pods.v1.Pods/List", &pods.ListPodsRequest{Namespace: "dev"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should reject unknown token")
+
+	err = callUnary(withToken("dev-token"), "/eliot.services.pods.v1.Pods/List", &pods.ListPodsRequest{Namespace: "dev"})
+	assert.NoError(t, err)
+}
+
+func TestUnaryAuthRejectsCrossNamespaceCalls(t *testing.T) {
+	ctx := withToken("dev-token")
+
+	err := callUnary(ctx, "/eliot.services.pods.v1.Pods/List", &pods.ListPodsRequest{Namespace: "prod"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "should reject other namespace")
+
+	err = callUnary(ctx, "/eliot.services.pods.v1.Pods/List", &pods.ListPodsRequest{AllNamespaces: true})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "should reject listing all namespaces")
+
+	err = callUnary(ctx, "/eliot.services.pods.v1.Pods/ValidateManifest", &pods.ValidateManifestRequest{Pods: []*pods.Pod{
+		{Metadata: &core.ResourceMetadata{Namespace: "dev"}},
+		{Metadata: &core.ResourceMetadata{Namespace: "prod"}},
+	}})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "should reject if any of the pods is in other namespace")
+
+	err = callUnary(ctx, "/eliot.services.node.v1.Node/ResourceSummary", &node.ResourceSummaryRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "should reject node level operation")
+
+	err = callUnary(ctx, "/eliot.services.node.v1.Node/Info", &node.InfoRequest{})
+	assert.NoError(t, err, "should allow public method")
+
+	err = callUnary(withToken("admin-token"), "/eliot.services.pods.v1.Pods/List", &pods.ListPodsRequest{AllNamespaces: true})
+	assert.NoError(t, err, "should allow all namespaces with '*'")
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	messages []*containers.StdinStreamRequest
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	*m.(*containers.StdinStreamRequest) = *s.messages[0]
+	s.messages = s.messages[1:]
+	return nil
+}
+
+type fakeCreateRequestStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	request *pods.CreatePodRequest
+}
+
+func (s *fakeCreateRequestStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeCreateRequestStream) RecvMsg(m interface{}) error {
+	*m.(*pods.CreatePodRequest) = *s.request
+	return nil
+}
+
+func callStream(ctx context.Context) error {
+	stream := &fakeServerStream{ctx: ctx, messages: []*containers.StdinStreamRequest{{Input: []byte("foo")}}}
+	info := &grpc.StreamServerInfo{FullMethod: "/eliot.services.containers.v1.Containers/Attach"}
+	return testAuth.streamInterceptor(nil, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+		return stream.RecvMsg(&containers.StdinStreamRequest{})
+	})
+}
+
+func TestStreamAuthChecksMetadataNamespace(t *testing.T) {
+	assert.NoError(t, callStream(withToken("dev-token", "namespace", "dev")))
+	assert.Equal(t, codes.PermissionDenied, status.Code(callStream(withToken("dev-token", "namespace", "prod"))))
+	assert.Equal(t, codes.PermissionDenied, status.Code(callStream(withToken("dev-token"))), "should require all namespaces without namespace")
+	assert.Equal(t, codes.Unauthenticated, status.Code(callStream(context.Background())))
+}
+
+func TestLoadTokens(t *testing.T) {
+	file, err := ioutil.TempFile("", "tokens")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString("# comment\n\nadmin-token *\ndev-token dev,staging\n")
+	assert.NoError(t, err)
+	file.Close()
+
+	auth, err := LoadTokens(file.Name())
+	assert.NoError(t, err)
+	assert.Len(t, auth.tokens, 2)
+
+	assert.NoError(t, ioutil.WriteFile(file.Name(), []byte("only-token\n"), 0600))
+	_, err = LoadTokens(file.Name())
+	assert.Error(t, err, "should fail if the namespaces are missing")
+}
+
+func TestUnaryAuthScopesDeleteNamespace(t *testing.T) {
+	ctx := withToken("dev-token")
+
+	err := callUnary(ctx, "/eliot.services.pods.v1.Pods/DeleteNamespace", &pods.DeleteNamespaceRequest{Namespace: "dev", RemoveNamespace: true})
+	assert.NoError(t, err)
+
+	err = callUnary(ctx, "/eliot.services.pods.v1.Pods/DeleteNamespace", &pods.DeleteNamespaceRequest{Namespace: "prod"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "should reject deleting other namespace")
+}
+
+func TestUnaryAuthChecksMoveTargetNamespace(t *testing.T) {
+	ctx := withToken("dev-token")
+
+	err := callUnary(ctx, "/eliot.services.containers.v1.Containers/Move", &containers.MoveContainerRequest{Namespace: "dev", ContainerID: "foo", TargetNamespace: "staging"})
+	assert.NoError(t, err)
+
+	err = callUnary(ctx, "/eliot.services.containers.v1.Containers/Move", &containers.MoveContainerRequest{Namespace: "dev", ContainerID: "foo", TargetNamespace: "prod"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "should reject moving to other namespace")
+}
+
+func TestUnaryAuthScopesSecrets(t *testing.T) {
+	ctx := withToken("dev-token")
+
+	err := callUnary(ctx, "/eliot.services.images.v1.Images/PutPullSecret", &images.PutPullSecretRequest{Namespace: "dev"})
+	assert.NoError(t, err)
+
+	err = callUnary(ctx, "/eliot.services.images.v1.Images/DeletePullSecret", &images.DeletePullSecretRequest{Namespace: "prod", Name: "registry"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "should reject deleting the secret of other namespace")
+
+	err = callUnary(ctx, "/eliot.services.images.v1.Images/PutSecret", &images.PutSecretRequest{Namespace: "dev", Name: "api-key"})
+	assert.NoError(t, err)
+
+	err = callUnary(ctx, "/eliot.services.images.v1.Images/PutSecret", &images.PutSecretRequest{Name: "api-key"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "should reject writing the secret of default namespace")
+}
+
+func TestAuthRequiresAllNamespacesForPrivilegedPods(t *testing.T) {
+	newPod := func(container *containers.Container) *pods.Pod {
+		return &pods.Pod{
+			Metadata: &core.ResourceMetadata{Name: "foo", Namespace: "dev"},
+			Spec:     &pods.PodSpec{Containers: []*containers.Container{container}},
+		}
+	}
+
+	err := callUnary(withToken("dev-token"), "/eliot.services.pods.v1.Pods/Run", &pods.RunPodRequest{Pod: newPod(&containers.Container{Name: "foo", Image: "alpine"})})
+	assert.NoError(t, err)
+
+	for _, container := range []*containers.Container{
+		{Name: "foo", SpecPatch: `{"process":{"user":{"uid":0}}}`},
+		{Name: "foo", Capabilities: &containers.Capabilities{Effective: []string{"CAP_SYS_ADMIN"}}},
+		{Name: "foo", Sysctls: map[string]string{"net.ipv4.ip_forward": "1"}},
+		{Name: "foo", Mounts: []*containers.Mount{{Source: "/", Destination: "/host"}}},
+		{Name: "foo", Devices: []*containers.Device{{HostPath: "/dev/mem"}}},
+		{Name: "foo", AppArmorProfile: "unconfined"},
+	} {
+		err := callUnary(withToken("dev-token"), "/eliot.services.pods.v1.Pods/Run", &pods.RunPodRequest{Pod: newPod(container)})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "should reject privileged container %v", container)
+
+		err = callUnary(withToken("dev-token"), "/eliot.services.pods.v1.Pods/Apply", &pods.ApplyPodsRequest{Namespace: "dev", Pods: []*pods.Pod{newPod(container)}})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "should reject applying privileged container %v", container)
+
+		err = callUnary(withToken("admin-token"), "/eliot.services.pods.v1.Pods/Run", &pods.RunPodRequest{Pod: newPod(container)})
+		assert.NoError(t, err, "should allow privileged container with access to all namespaces")
+	}
+
+	hostNetwork := newPod(&containers.Container{Name: "foo"})
+	hostNetwork.Spec.HostNetwork = true
+	stream := &fakeCreateRequestStream{ctx: withToken("dev-token"), request: &pods.CreatePodRequest{Pod: hostNetwork}}
+	err = testAuth.streamInterceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/eliot.services.pods.v1.Pods/Create"}, func(srv interface{}, stream grpc.ServerStream) error {
+		return stream.RecvMsg(&pods.CreatePodRequest{})
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "should reject creating host network pod")
+}
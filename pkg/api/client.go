@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
@@ -14,11 +15,13 @@ import (
 
 	"github.com/ernoaapa/eliot/pkg/api/mapping"
 	containers "github.com/ernoaapa/eliot/pkg/api/services/containers/v1"
+	images "github.com/ernoaapa/eliot/pkg/api/services/images/v1"
 	node "github.com/ernoaapa/eliot/pkg/api/services/node/v1"
 	pods "github.com/ernoaapa/eliot/pkg/api/services/pods/v1"
 	"github.com/ernoaapa/eliot/pkg/api/stream"
 	"github.com/ernoaapa/eliot/pkg/config"
 	"github.com/ernoaapa/eliot/pkg/progress"
+	"github.com/pkg/errors"
 	"github.com/rs/xid"
 )
 
@@ -30,14 +33,25 @@ type Client struct {
 }
 
 // NewClient creates new RPC server client
+// If the endpoint has token, it gets passed with every call
 func NewClient(namespace string, endpoint config.Endpoint) *Client {
+	ctx := context.Background()
+	if endpoint.Token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, authorizationKey, bearerPrefix+endpoint.Token)
+	}
 	return &Client{
 		namespace,
 		endpoint,
-		context.Background(),
+		ctx,
 	}
 }
 
+// outgoingContext returns the client context with the metadata added to the client metadata
+func (c *Client) outgoingContext(md metadata.MD) context.Context {
+	existing, _ := metadata.FromOutgoingContext(c.ctx)
+	return metadata.NewOutgoingContext(c.ctx, metadata.Join(existing, md))
+}
+
 // GetInfo calls server and get node info
 func (c *Client) GetInfo() (*node.Info, error) {
 	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
@@ -55,227 +69,1169 @@ func (c *Client) GetInfo() (*node.Info, error) {
 	return resp.GetInfo(), nil
 }
 
-// GetPods calls server and fetches all pods information
-func (c *Client) GetPods() ([]*pods.Pod, error) {
+// GetIdentity calls server and get node identifiers and labels
+func (c *Client) GetIdentity() (*node.Identity, error) {
 	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
 	if err != nil {
 		return nil, err
 	}
 	defer conn.Close()
 
-	client := pods.NewPodsClient(conn)
-	resp, err := client.List(c.ctx, &pods.ListPodsRequest{
-		Namespace: c.Namespace,
-	})
+	client := node.NewNodeClient(conn)
+	resp, err := client.Identity(c.ctx, &node.IdentityRequest{})
 	if err != nil {
 		return nil, err
 	}
 
-	return resp.GetPods(), nil
+	return resp.GetIdentity(), nil
 }
 
-// GetPod return Pod by name
-func (c *Client) GetPod(podName string) (*pods.Pod, error) {
-	pods, err := c.GetPods()
+// StreamStats streams the node stats at given interval and calls the handler for each,
+// stops when the handler returns error or the client context get cancelled
+func (c *Client) StreamStats(interval time.Duration, handler func(*node.Stats) error) error {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer conn.Close()
 
-	for _, pod := range pods {
-		if pod.Metadata.Name == podName {
-			return pod, nil
-		}
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+
+	client := node.NewNodeClient(conn)
+	s, err := client.Stats(ctx, &node.StatsRequest{
+		Interval: int64(interval / time.Millisecond),
+	})
+	if err != nil {
+		return err
 	}
-	return nil, fmt.Errorf("Pod with name [%s] not found", podName)
-}
 
-// CreatePod creates new pod to the node
-func (c *Client) CreatePod(status chan<- []*progress.ImageFetch, pod *pods.Pod, opts ...PodOpts) error {
-	for _, o := range opts {
-		err := o(pod)
+	for {
+		resp, err := s.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
 		if err != nil {
+			return errors.Wrapf(err, "Received error while reading node stats stream")
+		}
+		if err := handler(resp.GetStats()); err != nil {
 			return err
 		}
 	}
+}
 
+// StreamContainerStats streams the container resource usage sampled at given interval and calls the handler for each,
+// stops when the handler returns error or the client context get cancelled
+func (c *Client) StreamContainerStats(containerID string, interval time.Duration, handler func(*containers.ContainerStats) error) error {
 	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
 	if err != nil {
 		return err
 	}
 	defer conn.Close()
 
-	client := pods.NewPodsClient(conn)
-	stream, err := client.Create(c.ctx, &pods.CreatePodRequest{
-		Pod: pod,
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+
+	client := containers.NewContainersClient(conn)
+	s, err := client.ContainerStats(ctx, &containers.ContainerStatsRequest{
+		Namespace:   c.Namespace,
+		ContainerID: containerID,
+		Interval:    int64(interval / time.Millisecond),
 	})
 	if err != nil {
 		return err
 	}
 
 	for {
-		resp, err := stream.Recv()
-		if err == io.EOF {
-			err = stream.CloseSend()
-			return err
+		resp, err := s.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
 		}
 		if err != nil {
+			return errors.Wrapf(err, "Received error while reading container [%s] stats stream", containerID)
+		}
+		if err := handler(resp.GetStats()); err != nil {
 			return err
 		}
+	}
+}
 
-		status <- mapping.MapAPIModelToImageFetchProgress(resp.Images)
+// GetDiskUsage calls server and get disk usage of the images and containers in the namespace
+func (c *Client) GetDiskUsage() (*node.DiskUsage, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := node.NewNodeClient(conn)
+	resp, err := client.DiskUsage(c.ctx, &node.DiskUsageRequest{Namespace: c.Namespace})
+	if err != nil {
+		return nil, err
 	}
+
+	return resp.GetUsage(), nil
 }
 
-// StartPod starts created pod in node
-func (c *Client) StartPod(name string) (*pods.Pod, error) {
+// GetResourceSummary calls server to fetch the resource usage of each namespace
+func (c *Client) GetResourceSummary() ([]*node.NamespaceSummary, error) {
 	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
 	if err != nil {
 		return nil, err
 	}
 	defer conn.Close()
 
-	client := pods.NewPodsClient(conn)
-	resp, err := client.Start(c.ctx, &pods.StartPodRequest{
-		Namespace: c.Namespace,
-		Name:      name,
-	})
+	client := node.NewNodeClient(conn)
+	resp, err := client.ResourceSummary(c.ctx, &node.ResourceSummaryRequest{})
 	if err != nil {
 		return nil, err
 	}
+	return resp.GetNamespaces(), nil
+}
 
-	return resp.GetPod(), nil
+// DescribeDevice calls server and fetches the node info, runtime capabilities, resource summary
+// and pods with container statuses in single request
+func (c *Client) DescribeDevice() (*node.DescribeDeviceResponse, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := node.NewNodeClient(conn)
+	return client.DescribeDevice(c.ctx, &node.DescribeDeviceRequest{})
 }
 
-// DeletePod removes pod from the node
-func (c *Client) DeletePod(pod *pods.Pod) (*pods.Pod, error) {
+// SetLabels calls server to set and remove the node labels, returns the node labels after the change
+func (c *Client) SetLabels(labels map[string]string, remove []string) ([]*node.Label, error) {
 	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
 	if err != nil {
 		return nil, err
 	}
 	defer conn.Close()
 
-	client := pods.NewPodsClient(conn)
+	set := []*node.Label{}
+	for key, value := range labels {
+		set = append(set, &node.Label{Key: key, Value: value})
+	}
 
-	resp, err := client.Delete(c.ctx, &pods.DeletePodRequest{
-		Namespace: pod.Metadata.Namespace,
-		Name:      pod.Metadata.Name,
+	client := node.NewNodeClient(conn)
+	resp, err := client.SetLabels(c.ctx, &node.SetLabelsRequest{
+		Labels: set,
+		Remove: remove,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return resp.GetPod(), nil
+	return resp.GetLabels(), nil
 }
 
-// Attach hooks to container main process stdin/stout
-func (c *Client) Attach(containerID string, attachIO AttachIO, hooks ...AttachHooks) (err error) {
-	done := make(chan struct{})
-	errc := make(chan error)
+// WatchLabels calls server to stream the node labels, the handler receives the current labels
+// and then the labels after every change until the handler returns error or the connection closes
+func (c *Client) WatchLabels(handler func([]*node.Label) error) error {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
 
-	md := metadata.Pairs(
-		"namespace", c.Namespace,
-		"container", containerID,
-	)
-	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(c.ctx, md))
+	ctx, cancel := context.WithCancel(c.ctx)
 	defer cancel()
 
+	client := node.NewNodeClient(conn)
+	s, err := client.WatchLabels(ctx, &node.WatchLabelsRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := s.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Received error while reading node labels stream")
+		}
+		if err := handler(resp.GetLabels()); err != nil {
+			return err
+		}
+	}
+}
+
+// RuntimeEvents calls server to stream the raw containerd events matching the filters and calls
+// the handler with every event until the handler returns error or the server closes the stream
+func (c *Client) RuntimeEvents(allNamespaces bool, filters []string, handler func(*node.RuntimeEvent) error) error {
 	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
 	if err != nil {
 		return err
 	}
 	defer conn.Close()
 
-	client := containers.NewContainersClient(conn)
-	log.Debugf("Open connection to server to start stdin/stdout streaming")
-	s, err := client.Attach(ctx)
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+
+	client := node.NewNodeClient(conn)
+	s, err := client.RuntimeEvents(ctx, &node.RuntimeEventsRequest{
+		Namespace:     c.Namespace,
+		AllNamespaces: allNamespaces,
+		Filters:       filters,
+	})
 	if err != nil {
 		return err
 	}
 
-	go func() {
-		errc <- stream.PipeStdout(s, attachIO.Stdout, attachIO.Stderr)
-	}()
+	for {
+		resp, err := s.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Received error while reading runtime events stream")
+		}
+		if err := handler(resp.GetEvent()); err != nil {
+			return err
+		}
+	}
+}
 
-	if attachIO.Stdin != nil {
-		go func() {
-			errc <- stream.PipeStdin(s, attachIO.Stdin)
-		}()
+// Capabilities calls server to fetch the API methods and the optional features what the server supports
+func (c *Client) Capabilities() (*node.CapabilitiesResponse, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
 	}
+	defer conn.Close()
 
-	for _, hook := range hooks {
-		go hook(c.Endpoint, done)
+	client := node.NewNodeClient(conn)
+	return client.Capabilities(c.ctx, &node.CapabilitiesRequest{})
+}
+
+// ExportState calls server to reconstruct the pod manifests from the containers in the client namespace
+// or in all namespaces, the pods can be created again with CreatePod
+func (c *Client) ExportState(allNamespaces bool) ([]*pods.Pod, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
 	}
+	defer conn.Close()
 
-	for {
-		err := <-errc
-		close(done)
-		return err
+	client := node.NewNodeClient(conn)
+	resp, err := client.ExportState(c.ctx, &node.ExportStateRequest{
+		Namespace:     c.Namespace,
+		AllNamespaces: allNamespaces,
+	})
+	if err != nil {
+		return nil, err
 	}
+	return resp.GetPods(), nil
 }
 
-// Exec executes command inside some container
-func (c *Client) Exec(containerID string, args []string, tty bool, attachIO AttachIO, hooks ...AttachHooks) (err error) {
-	done := make(chan struct{})
-	errc := make(chan error)
+// SetSnapshotter calls server to change the snapshotter what new containers get created with
+// Returns the runtime capabilities with the changed snapshotter
+func (c *Client) SetSnapshotter(snapshotter string) (*node.RuntimeInfo, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
 
-	md := metadata.Pairs(
-		"namespace", c.Namespace,
-		"container", containerID,
-		"execid", xid.New().String(),
-		"args", strings.Join(args, " "),
-		"tty", strconv.FormatBool(tty),
-	)
-	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(c.ctx, md))
-	defer cancel()
+	client := node.NewNodeClient(conn)
+	resp, err := client.SetSnapshotter(c.ctx, &node.SetSnapshotterRequest{
+		Snapshotter: snapshotter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetRuntime(), nil
+}
 
+// MigrateSnapshotter changes the node snapshotter and unpacks the images to it, optionally recreates the containers
+// with it, and calls the handler for each migrated image and container
+func (c *Client) MigrateSnapshotter(snapshotter string, recreateContainers bool, handler func(*node.SnapshotterMigrationStep)) error {
 	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
 	if err != nil {
 		return err
 	}
 	defer conn.Close()
 
-	client := containers.NewContainersClient(conn)
-	log.Debugf("Open connection to server to start stdin/stdout streaming")
-	s, err := client.Exec(ctx)
+	client := node.NewNodeClient(conn)
+	s, err := client.MigrateSnapshotter(c.ctx, &node.MigrateSnapshotterRequest{
+		Snapshotter:        snapshotter,
+		RecreateContainers: recreateContainers,
+	})
 	if err != nil {
 		return err
 	}
 
-	go func() {
-		errc <- stream.PipeStdout(s, attachIO.Stdout, attachIO.Stderr)
-	}()
-
-	if attachIO.Stdin != nil {
-		go func() {
-			errc <- stream.PipeStdin(s, attachIO.Stdin)
-		}()
+	for {
+		resp, err := s.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		handler(resp.GetStep())
 	}
+}
 
-	for _, hook := range hooks {
-		go hook(c.Endpoint, done)
+// Reboot calls server to stop the containers and reboot the node
+// Zero grace period means that the containers have the pod stop grace period time to stop
+func (c *Client) Reboot(gracePeriod time.Duration) (int, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return 0, err
 	}
+	defer conn.Close()
 
-	for {
-		err := <-errc
-		close(done)
-		return err
+	client := node.NewNodeClient(conn)
+	resp, err := client.Reboot(c.ctx, &node.RebootRequest{
+		GracePeriodSeconds: int64(gracePeriod / time.Second),
+	})
+	if err != nil {
+		return 0, err
 	}
+	return int(resp.GetStoppedContainers()), nil
 }
 
-// Signal sends kill signal to container process
-func (c *Client) Signal(containerID string, signal syscall.Signal) (err error) {
+// Poweroff calls server to stop the containers and shut down the node
+// Zero grace period means that the containers have the pod stop grace period time to stop
+func (c *Client) Poweroff(gracePeriod time.Duration) (int, error) {
 	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer conn.Close()
 
-	client := containers.NewContainersClient(conn)
+	client := node.NewNodeClient(conn)
+	resp, err := client.Poweroff(c.ctx, &node.PoweroffRequest{
+		GracePeriodSeconds: int64(gracePeriod / time.Second),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.GetStoppedContainers()), nil
+}
 
-	_, err = client.Signal(c.ctx, &containers.SignalRequest{
-		Namespace:   c.Namespace,
-		ContainerID: containerID,
-		Signal:      int32(signal),
+// PauseReconcile calls server to stop the controllers restarting and killing containers
+// Zero timeout means the server max pause timeout, returns the time when reconciling resumes automatically
+func (c *Client) PauseReconcile(timeout time.Duration) (time.Time, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer conn.Close()
+
+	client := node.NewNodeClient(conn)
+	resp, err := client.PauseReconcile(c.ctx, &node.PauseReconcileRequest{
+		TimeoutSeconds: int64(timeout / time.Second),
 	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(resp.GetResumeAt(), 0), nil
+}
 
-	return err
+// GetMaintenance calls server to get the node maintenance mode state
+func (c *Client) GetMaintenance() (*node.Maintenance, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := node.NewNodeClient(conn)
+	resp, err := client.Maintenance(c.ctx, &node.MaintenanceRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetMaintenance(), nil
+}
+
+// SetMaintenance calls server to enable or disable the node maintenance mode
+func (c *Client) SetMaintenance(enabled bool, reason string) (*node.Maintenance, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := node.NewNodeClient(conn)
+	resp, err := client.SetMaintenance(c.ctx, &node.SetMaintenanceRequest{
+		Enabled: enabled,
+		Reason:  reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetMaintenance(), nil
+}
+
+// ResumeReconcile calls server to end the pause and reconcile the containers back to the desired state
+func (c *Client) ResumeReconcile() error {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := node.NewNodeClient(conn)
+	_, err = client.ResumeReconcile(c.ctx, &node.ResumeReconcileRequest{})
+	return err
+}
+
+// GetReconcileHistory calls server and fetches the most recent lifecycle controller actions oldest first
+func (c *Client) GetReconcileHistory() ([]*node.ReconcileRecord, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := node.NewNodeClient(conn)
+	resp, err := client.ReconcileHistory(c.ctx, &node.ReconcileHistoryRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetRecords(), nil
+}
+
+// GetPods calls server and fetches all pods information
+func (c *Client) GetPods() ([]*pods.Pod, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := pods.NewPodsClient(conn)
+	resp, err := client.List(c.ctx, &pods.ListPodsRequest{
+		Namespace: c.Namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetPods(), nil
+}
+
+// GetAllPods calls server and fetches pods from all namespaces
+func (c *Client) GetAllPods() ([]*pods.Pod, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := pods.NewPodsClient(conn)
+	resp, err := client.List(c.ctx, &pods.ListPodsRequest{
+		AllNamespaces: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetPods(), nil
+}
+
+// GetPod return Pod by name
+func (c *Client) GetPod(podName string) (*pods.Pod, error) {
+	pods, err := c.GetPods()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pod := range pods {
+		if pod.Metadata.Name == podName {
+			return pod, nil
+		}
+	}
+	return nil, fmt.Errorf("Pod with name [%s] not found", podName)
+}
+
+// ValidateManifest calls server to check the pods against the node without creating anything
+// Returns the validation errors, empty if the pods are valid
+func (c *Client) ValidateManifest(manifest []*pods.Pod) ([]*pods.ValidationError, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := pods.NewPodsClient(conn)
+	resp, err := client.ValidateManifest(c.ctx, &pods.ValidateManifestRequest{
+		Pods: manifest,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetErrors(), nil
+}
+
+// ApplyPods calls server to converge the namespace to the desired pods
+// Returns the actions what the server took, the pods what are not in the manifest get removed
+func (c *Client) ApplyPods(namespace string, manifest []*pods.Pod) ([]*pods.ApplyAction, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := pods.NewPodsClient(conn)
+	resp, err := client.Apply(c.ctx, &pods.ApplyPodsRequest{
+		Namespace: namespace,
+		Pods:      manifest,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetActions(), nil
+}
+
+// CreatePod creates new pod to the node
+func (c *Client) CreatePod(status chan<- []*progress.ImageFetch, pod *pods.Pod, opts ...PodOpts) error {
+	_, err := c.createPod(status, nil, pod, false, opts...)
+	return err
+}
+
+// CreateAndStartPod creates new pod to the node and starts it
+// If any container fails to get created or started, the node removes all the pod containers
+func (c *Client) CreateAndStartPod(status chan<- []*progress.ImageFetch, pod *pods.Pod, opts ...PodOpts) (*pods.Pod, error) {
+	return c.CreateAndStartPodWithPhases(status, nil, pod, opts...)
+}
+
+// CreateAndStartPodWithPhases creates new pod to the node and starts it, and sends each container phase change,
+// e.g. pulling or starting, to the phases channel if the channel is not nil
+func (c *Client) CreateAndStartPodWithPhases(status chan<- []*progress.ImageFetch, phases chan<- []progress.Phase, pod *pods.Pod, opts ...PodOpts) (*pods.Pod, error) {
+	statuses, err := c.createPod(status, phases, pod, true, opts...)
+	if err != nil {
+		return nil, err
+	}
+	pod.Status = &pods.PodStatus{
+		ContainerStatuses: statuses,
+	}
+	return pod, nil
+}
+
+// createPod creates the pod and returns the container statuses from the last response
+func (c *Client) createPod(status chan<- []*progress.ImageFetch, phases chan<- []progress.Phase, pod *pods.Pod, start bool, opts ...PodOpts) (statuses []*containers.ContainerStatus, err error) {
+	for _, o := range opts {
+		err := o(pod)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := pods.NewPodsClient(conn)
+	stream, err := client.Create(c.ctx, &pods.CreatePodRequest{
+		Pod:   pod,
+		Start: start,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return statuses, stream.CloseSend()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(resp.ContainerStatuses) > 0 {
+			statuses = resp.ContainerStatuses
+		}
+		status <- mapping.MapAPIModelToImageFetchProgress(resp.Images)
+		if phases != nil && len(resp.Phases) > 0 {
+			phases <- mapping.MapAPIModelToPhases(resp.Phases)
+		}
+	}
+}
+
+// RunPod runs the pod containers once to completion in the node and returns the exit codes and the output,
+// the node removes the containers after they exit. Zero timeout lets the containers run as long as they need
+func (c *Client) RunPod(pod *pods.Pod, timeout time.Duration, opts ...PodOpts) ([]*pods.RunResult, error) {
+	for _, o := range opts {
+		if err := o(pod); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := pods.NewPodsClient(conn)
+	resp, err := client.Run(c.ctx, &pods.RunPodRequest{
+		Pod:            pod,
+		TimeoutSeconds: int64(timeout / time.Second),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetResults(), nil
+}
+
+// StartPod starts created pod in node
+func (c *Client) StartPod(name string) (*pods.Pod, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := pods.NewPodsClient(conn)
+	resp, err := client.Start(c.ctx, &pods.StartPodRequest{
+		Namespace: c.Namespace,
+		Name:      name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetPod(), nil
+}
+
+// DeletePod removes pod from the node
+func (c *Client) DeletePod(pod *pods.Pod) (*pods.Pod, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := pods.NewPodsClient(conn)
+
+	resp, err := client.Delete(c.ctx, &pods.DeletePodRequest{
+		Namespace: pod.Metadata.Namespace,
+		Name:      pod.Metadata.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPod(), nil
+}
+
+// DeleteNamespaceContainers deletes all the containers in the client namespace and if removeNamespace is true,
+// also the images and the namespace itself
+func (c *Client) DeleteNamespaceContainers(removeNamespace bool) ([]*containers.ContainerStatus, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := pods.NewPodsClient(conn)
+
+	resp, err := client.DeleteNamespace(c.ctx, &pods.DeleteNamespaceRequest{
+		Namespace:       c.Namespace,
+		RemoveNamespace: removeNamespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetContainerStatuses(), nil
+}
+
+// Attach hooks to container main process stdin/stout
+func (c *Client) Attach(containerID string, attachIO AttachIO, hooks ...AttachHooks) (err error) {
+	done := make(chan struct{})
+	errc := make(chan error)
+
+	md := metadata.Pairs(
+		"namespace", c.Namespace,
+		"container", containerID,
+	)
+	ctx, cancel := context.WithCancel(c.outgoingContext(md))
+	defer cancel()
+
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := containers.NewContainersClient(conn)
+	log.Debugf("Open connection to server to start stdin/stdout streaming")
+	s, err := client.Attach(ctx)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		errc <- stream.PipeStdout(s, attachIO.Stdout, attachIO.Stderr)
+	}()
+
+	if attachIO.Stdin != nil {
+		go func() {
+			errc <- stream.PipeStdin(s, attachIO.Stdin)
+		}()
+	}
+
+	for _, hook := range hooks {
+		go hook(c.Endpoint, done)
+	}
+
+	for {
+		err := <-errc
+		close(done)
+		return err
+	}
+}
+
+// Exec executes command inside some container
+func (c *Client) Exec(containerID string, args []string, tty bool, attachIO AttachIO, hooks ...AttachHooks) (err error) {
+	done := make(chan struct{})
+	errc := make(chan error)
+
+	md := metadata.Pairs(
+		"namespace", c.Namespace,
+		"container", containerID,
+		"execid", xid.New().String(),
+		"args", strings.Join(args, " "),
+		"tty", strconv.FormatBool(tty),
+	)
+	ctx, cancel := context.WithCancel(c.outgoingContext(md))
+	defer cancel()
+
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := containers.NewContainersClient(conn)
+	log.Debugf("Open connection to server to start stdin/stdout streaming")
+	s, err := client.Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		errc <- stream.PipeStdout(s, attachIO.Stdout, attachIO.Stderr)
+	}()
+
+	if attachIO.Stdin != nil {
+		go func() {
+			errc <- stream.PipeStdin(s, attachIO.Stdin)
+		}()
+	}
+
+	for _, hook := range hooks {
+		go hook(c.Endpoint, done)
+	}
+
+	for {
+		err := <-errc
+		close(done)
+		return err
+	}
+}
+
+// Signal sends kill signal to container process
+func (c *Client) Signal(containerID string, signal syscall.Signal) (err error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := containers.NewContainersClient(conn)
+
+	_, err = client.Signal(c.ctx, &containers.SignalRequest{
+		Namespace:   c.Namespace,
+		ContainerID: containerID,
+		Signal:      int32(signal),
+	})
+
+	return err
+}
+
+// GetContainerStatuses resolves task statuses of given containers, or all containers in the namespace if no ids given
+func (c *Client) GetContainerStatuses(containerIDs ...string) (map[string]string, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := containers.NewContainersClient(conn)
+	resp, err := client.Statuses(c.ctx, &containers.ContainerStatusesRequest{
+		Namespace:    c.Namespace,
+		ContainerIDs: containerIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetStatuses(), nil
+}
+
+// InspectContainer returns the container details, including the resolved OCI spec, as stored in the node runtime
+func (c *Client) InspectContainer(containerID string) (*containers.InspectContainerResponse, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := containers.NewContainersClient(conn)
+	return client.Inspect(c.ctx, &containers.InspectContainerRequest{
+		Namespace:   c.Namespace,
+		ContainerID: containerID,
+	})
+}
+
+// DiffContainer returns the paths what the container has added, modified or deleted compared to the image
+func (c *Client) DiffContainer(containerID string) ([]*containers.FileChange, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := containers.NewContainersClient(conn)
+	resp, err := client.Diff(c.ctx, &containers.DiffContainerRequest{
+		Namespace:   c.Namespace,
+		ContainerID: containerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetChanges(), nil
+}
+
+// CommitContainer creates new image from the container filesystem and returns the normalized reference and digest
+func (c *Client) CommitContainer(containerID, ref string) (*containers.CommitContainerResponse, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := containers.NewContainersClient(conn)
+	return client.Commit(c.ctx, &containers.CommitContainerRequest{
+		Namespace:   c.Namespace,
+		ContainerID: containerID,
+		Ref:         ref,
+	})
+}
+
+// MoveContainer calls server to move the stopped container to another namespace
+func (c *Client) MoveContainer(containerID, targetNamespace string) (*containers.ContainerStatus, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := containers.NewContainersClient(conn)
+	resp, err := client.Move(c.ctx, &containers.MoveContainerRequest{
+		Namespace:       c.Namespace,
+		ContainerID:     containerID,
+		TargetNamespace: targetNamespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Status, nil
+}
+
+// ListProcesses calls server to list the processes running in the container
+func (c *Client) ListProcesses(containerID string) ([]*containers.Process, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := containers.NewContainersClient(conn)
+	resp, err := client.Top(c.ctx, &containers.TopRequest{
+		Namespace:   c.Namespace,
+		ContainerID: containerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetProcesses(), nil
+}
+
+// WaitForStatus blocks until the container reaches the status (running or stopped) or the timeout exceeds
+func (c *Client) WaitForStatus(containerID, status string, timeout time.Duration) error {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := containers.NewContainersClient(conn)
+	_, err = client.Wait(c.ctx, &containers.WaitRequest{
+		Namespace:   c.Namespace,
+		ContainerID: containerID,
+		Status:      status,
+		Timeout:     int64(timeout / time.Millisecond),
+	})
+	return err
+}
+
+// ImportImage streams OCI image archive from the reader to the node and returns imported image names
+// The labels get added to the imported images
+func (c *Client) ImportImage(reader io.Reader, labels map[string]string) ([]string, error) {
+	md := metadata.Pairs(
+		"namespace", c.Namespace,
+	)
+	for key, value := range labels {
+		md["labels"] = append(md["labels"], fmt.Sprintf("%s=%s", key, value))
+	}
+	ctx, cancel := context.WithCancel(c.outgoingContext(md))
+	defer cancel()
+
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := images.NewImagesClient(conn)
+	s, err := client.Import(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			if err := s.Send(&images.ImportImageRequest{Data: buf[:n]}); err != nil {
+				return nil, errors.Wrapf(err, "Sending image archive to stream returned error")
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error while reading image archive")
+		}
+	}
+
+	resp, err := s.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetImages(), nil
+}
+
+// PrePullImages pulls and unpacks the images in the node without creating containers
+// Returns result for each image, the pull failures are reported in the results instead of the error
+// The pull secrets are names of the secrets stored with PutPullSecret, the labels get added to the images
+func (c *Client) PrePullImages(refs, pullSecrets []string, labels map[string]string) ([]*images.PrePullResult, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := images.NewImagesClient(conn)
+	resp, err := client.PrePull(c.ctx, &images.PrePullRequest{
+		Namespace:        c.Namespace,
+		Refs:             refs,
+		ImagePullSecrets: pullSecrets,
+		Labels:           labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetResults(), nil
+}
+
+// CancelPull cancels the in-progress pulls of the image in the namespace, returns the normalized image reference
+func (c *Client) CancelPull(ref string) (string, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	client := images.NewImagesClient(conn)
+	resp, err := client.CancelPull(c.ctx, &images.CancelPullRequest{
+		Namespace: c.Namespace,
+		Ref:       ref,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetRef(), nil
+}
+
+// ListImages returns the images in the node, only the images what have all the labels if any given
+func (c *Client) ListImages(labels map[string]string) ([]*images.Image, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := images.NewImagesClient(conn)
+	resp, err := client.ListImages(c.ctx, &images.ListImagesRequest{
+		Namespace: c.Namespace,
+		Labels:    labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetImages(), nil
+}
+
+// TagImage gives the image additional reference in the node and returns the normalized new reference
+func (c *Client) TagImage(ref, newRef string) (string, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	client := images.NewImagesClient(conn)
+	resp, err := client.Tag(c.ctx, &images.TagImageRequest{
+		Namespace: c.Namespace,
+		Ref:       ref,
+		NewRef:    newRef,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetRef(), nil
+}
+
+// GetImageConfig returns the image defaults, e.g. the entrypoint and the exposed ports
+// The node pulls the image with the pull secrets if it doesn't have the image yet
+func (c *Client) GetImageConfig(ref string, pullSecrets []string) (*images.ImageConfig, error) {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := images.NewImagesClient(conn)
+	resp, err := client.ImageConfig(c.ctx, &images.ImageConfigRequest{
+		Namespace:        c.Namespace,
+		Ref:              ref,
+		ImagePullSecrets: pullSecrets,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetConfig(), nil
+}
+
+// PutPullSecret stores the registry credentials in the node for the pods of the client namespace,
+// replaces existing secret with the same name
+func (c *Client) PutPullSecret(name, registry, username, password string) error {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := images.NewImagesClient(conn)
+	_, err = client.PutPullSecret(c.ctx, &images.PutPullSecretRequest{
+		Secret: &images.PullSecret{
+			Name:     name,
+			Registry: registry,
+			Username: username,
+			Password: password,
+		},
+		Namespace: c.Namespace,
+	})
+	return err
+}
+
+// DeletePullSecret removes the registry credentials of the client namespace from the node
+func (c *Client) DeletePullSecret(name string) error {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := images.NewImagesClient(conn)
+	_, err = client.DeletePullSecret(c.ctx, &images.DeletePullSecretRequest{
+		Name:      name,
+		Namespace: c.Namespace,
+	})
+	return err
+}
+
+// PutSecret stores the data in the client namespace of the node so that the containers can mount it as file,
+// replaces existing secret with the same name
+func (c *Client) PutSecret(name string, data []byte) error {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := images.NewImagesClient(conn)
+	_, err = client.PutSecret(c.ctx, &images.PutSecretRequest{
+		Name:      name,
+		Data:      data,
+		Namespace: c.Namespace,
+	})
+	return err
+}
+
+// DeleteSecret removes the secret of the client namespace from the node
+func (c *Client) DeleteSecret(name string) error {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := images.NewImagesClient(conn)
+	_, err = client.DeleteSecret(c.ctx, &images.DeleteSecretRequest{
+		Name:      name,
+		Namespace: c.Namespace,
+	})
+	return err
+}
+
+// ExportImage fetches image from the node as OCI image archive and writes it to the writer
+func (c *Client) ExportImage(ref string, writer io.Writer) error {
+	conn, err := grpc.Dial(c.Endpoint.URL, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := images.NewImagesClient(conn)
+	s, err := client.Export(c.ctx, &images.ExportImageRequest{
+		Namespace: c.Namespace,
+		Ref:       ref,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := s.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Received error while reading image archive stream")
+		}
+		if _, err := writer.Write(resp.GetData()); err != nil {
+			return errors.Wrapf(err, "Error while writing image archive")
+		}
+	}
 }
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// unaryLoggingInterceptor logs every unary call with the method, duration and the status code
+func unaryLoggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCall(info.FullMethod, time.Since(start), err)
+	return resp, err
+}
+
+// streamLoggingInterceptor logs every streaming call with the method, duration and the status code
+// The duration is the whole stream lifetime, i.e. until the handler returns
+func streamLoggingInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	logCall(info.FullMethod, time.Since(start), err)
+	return err
+}
+
+func logCall(method string, duration time.Duration, err error) {
+	entry := log.WithFields(log.Fields{
+		"method":   method,
+		"duration": duration,
+		"code":     status.Code(err).String(),
+	})
+	if err != nil {
+		entry.Infof("GRPC call failed: %s", err)
+		return
+	}
+	entry.Debug("GRPC call")
+}
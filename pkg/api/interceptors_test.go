@@ -0,0 +1,52 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func captureLog(fn func()) string {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	fn()
+	return buf.String()
+}
+
+func TestUnaryLoggingInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/eliot.services.pods.v1.Pods/List"}
+
+	output := captureLog(func() {
+		_, err := unaryLoggingInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.NotFound, "not found")
+		})
+		assert.Error(t, err)
+	})
+
+	assert.Contains(t, output, "method=/eliot.services.pods.v1.Pods/List")
+	assert.Contains(t, output, "code=NotFound")
+	assert.Contains(t, output, "duration=")
+}
+
+func TestStreamLoggingInterceptor(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/eliot.services.pods.v1.Pods/Create"}
+
+	output := captureLog(func() {
+		err := streamLoggingInterceptor(nil, nil, info, func(srv interface{}, stream grpc.ServerStream) error {
+			return errors.New("failed")
+		})
+		assert.Error(t, err)
+	})
+
+	assert.Contains(t, output, "method=/eliot.services.pods.v1.Pods/Create")
+	assert.Contains(t, output, "code=Unknown")
+}
@@ -24,3 +24,6 @@ type AttachIO struct {
 func NewAttachIO(stdin io.Reader, stdout, stderr io.Writer) AttachIO {
 	return AttachIO{stdin, stdout, stderr}
 }
+
+// ServerOpts configures the API server
+type ServerOpts func(server *Server)
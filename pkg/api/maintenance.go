@@ -0,0 +1,14 @@
+package api
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkMaintenance returns Unavailable error with the reason if the maintenance mode is enabled
+func (s *Server) checkMaintenance() error {
+	if err := s.maintenance.Check(); err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return nil
+}
@@ -1,7 +1,11 @@
 package mapping
 
 import (
+	"time"
+
 	containers "github.com/ernoaapa/eliot/pkg/api/services/containers/v1"
+	images "github.com/ernoaapa/eliot/pkg/api/services/images/v1"
+	node "github.com/ernoaapa/eliot/pkg/api/services/node/v1"
 	pods "github.com/ernoaapa/eliot/pkg/api/services/pods/v1"
 	"github.com/ernoaapa/eliot/pkg/model"
 )
@@ -25,6 +29,13 @@ func MapPodToInternalModel(pod *pods.Pod) model.Pod {
 			Containers:  MapContainerToInternalModel(pod.Spec.Containers),
 			HostNetwork: pod.Spec.HostNetwork,
 			HostPID:     pod.Spec.HostPID,
+
+			StopGracePeriod: time.Duration(pod.Spec.StopGracePeriodSeconds) * time.Second,
+			Resources:       mapResourcesToInternalModel(pod.Spec.Resources),
+
+			ImagePullSecrets: pod.Spec.ImagePullSecrets,
+			LogLevel:         pod.Spec.LogLevel,
+			LogLevelEnv:      pod.Spec.LogLevelEnv,
 		},
 	}
 }
@@ -33,14 +44,47 @@ func MapPodToInternalModel(pod *pods.Pod) model.Pod {
 func MapContainerToInternalModel(containers []*containers.Container) (result []model.Container) {
 	for _, container := range containers {
 		result = append(result, model.Container{
-			Name:       container.Name,
-			Image:      container.Image,
-			Tty:        container.Tty,
-			Args:       container.Args,
-			Env:        container.Env,
-			WorkingDir: container.WorkingDir,
-			Mounts:     mapMountsToInternalModel(container.Mounts),
-			Pipe:       mapPipeToInternalModel(container.Pipe),
+			ID:              container.Id,
+			Name:            container.Name,
+			Image:           container.Image,
+			Tty:             container.Tty,
+			Args:            container.Args,
+			Env:             container.Env,
+			WorkingDir:      container.WorkingDir,
+			Mounts:          mapMountsToInternalModel(container.Mounts),
+			Pipe:            mapPipeToInternalModel(container.Pipe),
+			DNS:             container.Dns,
+			ExtraHosts:      container.ExtraHosts,
+			Resources:       mapResourcesToInternalModel(container.Resources),
+			Hostname:        container.Hostname,
+			Domainname:      container.Domainname,
+			CgroupParent:    container.CgroupParent,
+			Ulimits:         mapUlimitsToInternalModel(container.Ulimits),
+			Annotations:     container.Annotations,
+			Sysctls:         container.Sysctls,
+			Capabilities:    mapCapabilitiesToInternalModel(container.Capabilities),
+			OOMScoreAdj:     int(container.OomScoreAdj),
+			Init:            container.Init,
+			AppArmorProfile: container.AppArmorProfile,
+			HostNetwork:     container.HostNetwork,
+			Tmpfs:           mapTmpfsToInternalModel(container.Tmpfs),
+
+			LivenessProbe:            mapProbeToInternalModel(container.LivenessProbe),
+			ReadinessProbe:           mapProbeToInternalModel(container.ReadinessProbe),
+			RestartOnChange:          mapFileWatchToInternalModel(container.RestartOnChange),
+			IdleStop:                 mapIdleStopToInternalModel(container.IdleStop),
+			Devices:                  mapDevicesToInternalModel(container.Devices),
+			Accelerators:             container.Accelerators,
+			StartPriority:            int(container.StartPriority),
+			StorageQuota:             container.StorageQuota,
+			HostEnv:                  container.HostEnv,
+			Schedule:                 container.Schedule,
+			LogDriver:                container.LogDriver,
+			Files:                    mapFileMountsToInternalModel(container.Files),
+			EgressRateLimit:          container.EgressRateLimit,
+			RestartOnNetworkRecovery: container.RestartOnNetworkRecovery,
+			SpecPatch:                container.SpecPatch,
+			LogLevel:                 container.LogLevel,
 		})
 	}
 	return result
@@ -67,7 +111,129 @@ func mapMountsToInternalModel(mounts []*containers.Mount) (result []model.Mount)
 			Source:      mount.Source,
 			Destination: mount.Destination,
 			Options:     mount.Options,
+			Propagation: mount.Propagation,
+		})
+	}
+	return result
+}
+
+func mapTmpfsToInternalModel(mounts []*containers.TmpfsMount) (result []model.TmpfsMount) {
+	for _, mount := range mounts {
+		result = append(result, model.TmpfsMount{
+			Destination: mount.Destination,
+			SizeLimit:   mount.SizeLimit,
+			Options:     mount.Options,
+		})
+	}
+	return result
+}
+
+func mapDevicesToInternalModel(devices []*containers.Device) (result []model.Device) {
+	for _, device := range devices {
+		result = append(result, model.Device{
+			HostPath:      device.HostPath,
+			ContainerPath: device.ContainerPath,
+			Permissions:   device.Permissions,
+		})
+	}
+	return result
+}
+
+func mapFileMountsToInternalModel(files []*containers.FileMount) (result []model.FileMount) {
+	for _, file := range files {
+		result = append(result, model.FileMount{
+			Path:    file.Path,
+			Content: file.Content,
+			Secret:  file.Secret,
+			Mode:    file.Mode,
 		})
 	}
 	return result
 }
+
+func mapFileWatchToInternalModel(watch *containers.FileWatch) *model.FileWatch {
+	if watch == nil {
+		return nil
+	}
+	return &model.FileWatch{
+		Paths:    watch.Paths,
+		Debounce: time.Duration(watch.DebounceSeconds) * time.Second,
+	}
+}
+
+func mapIdleStopToInternalModel(idleStop *containers.IdleStop) *model.IdleStop {
+	if idleStop == nil {
+		return nil
+	}
+	return &model.IdleStop{
+		Timeout: time.Duration(idleStop.TimeoutSeconds) * time.Second,
+		Port:    int(idleStop.Port),
+		Signal:  idleStop.Signal,
+	}
+}
+
+func mapProbeToInternalModel(probe *containers.Probe) *model.Probe {
+	if probe == nil {
+		return nil
+	}
+	return &model.Probe{
+		Exec:             probe.Exec,
+		InitialDelay:     time.Duration(probe.InitialDelaySeconds) * time.Second,
+		Period:           time.Duration(probe.PeriodSeconds) * time.Second,
+		Timeout:          time.Duration(probe.TimeoutSeconds) * time.Second,
+		FailureThreshold: int(probe.FailureThreshold),
+	}
+}
+
+func mapUlimitsToInternalModel(ulimits []*containers.Ulimit) (result []model.Ulimit) {
+	for _, ulimit := range ulimits {
+		result = append(result, model.Ulimit{
+			Name: ulimit.Name,
+			Soft: ulimit.Soft,
+			Hard: ulimit.Hard,
+		})
+	}
+	return result
+}
+
+func mapCapabilitiesToInternalModel(capabilities *containers.Capabilities) *model.Capabilities {
+	if capabilities == nil {
+		return nil
+	}
+	return &model.Capabilities{
+		Effective:   capabilities.Effective,
+		Permitted:   capabilities.Permitted,
+		Bounding:    capabilities.Bounding,
+		Inheritable: capabilities.Inheritable,
+	}
+}
+
+func mapResourcesToInternalModel(resources *containers.Resources) *model.Resources {
+	if resources == nil {
+		return nil
+	}
+	return &model.Resources{
+		MemoryLimit:     resources.MemoryLimit,
+		CPULimit:        resources.CpuLimit,
+		MemorySwapLimit: resources.MemorySwapLimit,
+	}
+}
+
+// MapPullSecretToInternalModel maps API pull secret model to internal model
+func MapPullSecretToInternalModel(secret *images.PullSecret) model.PullSecret {
+	return model.PullSecret{
+		Name:     secret.Name,
+		Registry: secret.Registry,
+		Username: secret.Username,
+		Password: secret.Password,
+	}
+}
+
+// MapLabelsToInternalModel maps API labels to key-value map
+func MapLabelsToInternalModel(labels []*node.Label) map[string]string {
+	result := map[string]string{}
+	for _, label := range labels {
+		result[label.Key] = label.Value
+	}
+	return result
+}
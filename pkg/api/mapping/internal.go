@@ -2,9 +2,12 @@ package mapping
 
 import (
 	"net"
+	"sort"
+	"time"
 
 	core "github.com/ernoaapa/eliot/pkg/api/core"
 	containers "github.com/ernoaapa/eliot/pkg/api/services/containers/v1"
+	images "github.com/ernoaapa/eliot/pkg/api/services/images/v1"
 	node "github.com/ernoaapa/eliot/pkg/api/services/node/v1"
 	pods "github.com/ernoaapa/eliot/pkg/api/services/pods/v1"
 	"github.com/ernoaapa/eliot/pkg/model"
@@ -14,9 +17,11 @@ import (
 func MapInfoToAPIModel(info *model.NodeInfo) *node.Info {
 	return &node.Info{
 		Uptime:      info.Uptime,
-		Labels:      mapLabelsToAPIModel(info.Labels),
+		Labels:      MapLabelsToAPIModel(info.Labels),
+		Groups:      MapLabelsToAPIModel(info.Groups),
 		Hostname:    info.Hostname,
 		Addresses:   addressesToString(info.Addresses),
+		Interfaces:  mapInterfacesToAPIModel(info.Interfaces),
 		GrpcPort:    int64(info.GrpcPort),
 		MachineID:   info.MachineID,
 		SystemUUID:  info.SystemUUID,
@@ -25,13 +30,42 @@ func MapInfoToAPIModel(info *model.NodeInfo) *node.Info {
 		Os:          info.OS,
 		Version:     info.Version,
 		Filesystems: mapFilesystemsToAPIModel(info.Filesystems),
+
+		MinClientVersion: info.MinClientVersion,
+		Faults:           mapFaultsToAPIModel(info.Faults),
+
+		Time:              info.Time.Unix(),
+		ClockSynchronized: info.ClockSynchronized,
+	}
+}
+
+// MapIdentityToAPIModel maps internal node identity model to API model
+func MapIdentityToAPIModel(identity *model.NodeIdentity) *node.Identity {
+	return &node.Identity{
+		MachineID:  identity.MachineID,
+		SystemUUID: identity.SystemUUID,
+		BootID:     identity.BootID,
+		Labels:     MapLabelsToAPIModel(identity.Labels),
 	}
 }
 
-func mapLabelsToAPIModel(labels map[string]string) (result []*node.Label) {
+// MapLabelsToAPIModel maps the labels to API model sorted by the key
+func MapLabelsToAPIModel(labels map[string]string) (result []*node.Label) {
 	for key, value := range labels {
 		result = append(result, &node.Label{Key: key, Value: value})
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+func mapInterfacesToAPIModel(interfaces []model.NetworkInterface) (result []*node.NetworkInterface) {
+	for _, iface := range interfaces {
+		result = append(result, &node.NetworkInterface{
+			Name:      iface.Name,
+			Mac:       iface.MAC,
+			Addresses: addressesToString(iface.Addresses),
+		})
+	}
 	return result
 }
 
@@ -62,6 +96,12 @@ func MapPodToAPIModel(pod model.Pod) *pods.Pod {
 			HostNetwork:   pod.Spec.HostNetwork,
 			HostPID:       pod.Spec.HostPID,
 			RestartPolicy: pod.Spec.RestartPolicy,
+
+			StopGracePeriodSeconds: int64(pod.Spec.StopGracePeriod / time.Second),
+			Resources:              mapResourcesToAPIModel(pod.Spec.Resources),
+			ImagePullSecrets:       pod.Spec.ImagePullSecrets,
+			LogLevel:               pod.Spec.LogLevel,
+			LogLevelEnv:            pod.Spec.LogLevelEnv,
 		},
 		Status: &pods.PodStatus{
 			Hostname:          pod.Status.Hostname,
@@ -70,22 +110,156 @@ func MapPodToAPIModel(pod model.Pod) *pods.Pod {
 	}
 }
 
+// MapContainerInspectToAPIModel maps internal container inspect model to API model
+func MapContainerInspectToAPIModel(inspect model.ContainerInspect) *containers.InspectContainerResponse {
+	return &containers.InspectContainerResponse{
+		ContainerID: inspect.ID,
+		Image:       inspect.Image,
+		Labels:      inspect.Labels,
+		Runtime:     inspect.Runtime,
+		Snapshotter: inspect.Snapshotter,
+		SnapshotKey: inspect.SnapshotKey,
+		Spec:        inspect.Spec,
+	}
+}
+
+// MapFileChangesToAPIModel maps list of internal FileChange models to API model
+func MapFileChangesToAPIModel(changes []model.FileChange) (result []*containers.FileChange) {
+	for _, change := range changes {
+		result = append(result, &containers.FileChange{
+			Kind: change.Kind,
+			Path: change.Path,
+		})
+	}
+	return result
+}
+
+// MapProcessesToAPIModel maps internal container process models to API model
+func MapProcessesToAPIModel(processes []model.Process) (result []*containers.Process) {
+	for _, process := range processes {
+		result = append(result, &containers.Process{
+			Pid:   int32(process.PID),
+			Ppid:  int32(process.PPID),
+			State: process.State,
+			Args:  process.Args,
+		})
+	}
+	return result
+}
+
+// MapImagesToAPIModel maps internal image models to API model
+func MapImagesToAPIModel(source []model.Image) (result []*images.Image) {
+	for _, image := range source {
+		result = append(result, &images.Image{
+			Name:      image.Name,
+			Digest:    image.Digest,
+			Labels:    image.Labels,
+			CreatedAt: image.CreatedAt.Unix(),
+		})
+	}
+	return result
+}
+
+// MapImageConfigToAPIModel maps internal image config to API model
+func MapImageConfigToAPIModel(config model.ImageConfig) *images.ImageConfig {
+	return &images.ImageConfig{
+		User:         config.User,
+		Entrypoint:   config.Entrypoint,
+		Cmd:          config.Cmd,
+		Env:          config.Env,
+		WorkingDir:   config.WorkingDir,
+		ExposedPorts: config.ExposedPorts,
+		Volumes:      config.Volumes,
+		Labels:       config.Labels,
+		StopSignal:   config.StopSignal,
+		Os:           config.OS,
+		Architecture: config.Architecture,
+	}
+}
+
 // MapContainersToAPIModel maps list of internal Container models to API model
 func MapContainersToAPIModel(source []model.Container) (result []*containers.Container) {
 	for _, container := range source {
 		result = append(result, &containers.Container{
-			Name:       container.Name,
-			Image:      container.Image,
-			WorkingDir: container.WorkingDir,
-			Args:       container.Args,
-			Env:        container.Env,
-			Mounts:     mapMountsToAPIModel(container.Mounts),
-			Pipe:       mapPipeToAPIModel(container.Pipe),
+			Id:              container.ID,
+			Name:            container.Name,
+			Image:           container.Image,
+			WorkingDir:      container.WorkingDir,
+			Args:            container.Args,
+			Env:             container.Env,
+			Mounts:          mapMountsToAPIModel(container.Mounts),
+			Pipe:            mapPipeToAPIModel(container.Pipe),
+			Dns:             container.DNS,
+			ExtraHosts:      container.ExtraHosts,
+			Resources:       mapResourcesToAPIModel(container.Resources),
+			Hostname:        container.Hostname,
+			Domainname:      container.Domainname,
+			CgroupParent:    container.CgroupParent,
+			Ulimits:         mapUlimitsToAPIModel(container.Ulimits),
+			Annotations:     container.Annotations,
+			Sysctls:         container.Sysctls,
+			Capabilities:    mapCapabilitiesToAPIModel(container.Capabilities),
+			OomScoreAdj:     int32(container.OOMScoreAdj),
+			Init:            container.Init,
+			AppArmorProfile: container.AppArmorProfile,
+			HostNetwork:     container.HostNetwork,
+			Tmpfs:           mapTmpfsToAPIModel(container.Tmpfs),
+
+			LivenessProbe:            mapProbeToAPIModel(container.LivenessProbe),
+			ReadinessProbe:           mapProbeToAPIModel(container.ReadinessProbe),
+			RestartOnChange:          mapFileWatchToAPIModel(container.RestartOnChange),
+			IdleStop:                 mapIdleStopToAPIModel(container.IdleStop),
+			Devices:                  mapDevicesToAPIModel(container.Devices),
+			Accelerators:             container.Accelerators,
+			StartPriority:            int32(container.StartPriority),
+			StorageQuota:             container.StorageQuota,
+			HostEnv:                  container.HostEnv,
+			Schedule:                 container.Schedule,
+			LogDriver:                container.LogDriver,
+			Files:                    mapFileMountsToAPIModel(container.Files),
+			EgressRateLimit:          container.EgressRateLimit,
+			RestartOnNetworkRecovery: container.RestartOnNetworkRecovery,
+			SpecPatch:                container.SpecPatch,
+			LogLevel:                 container.LogLevel,
+		})
+	}
+	return result
+}
+
+func mapResourcesToAPIModel(resources *model.Resources) *containers.Resources {
+	if resources == nil {
+		return nil
+	}
+	return &containers.Resources{
+		MemoryLimit:     resources.MemoryLimit,
+		CpuLimit:        resources.CPULimit,
+		MemorySwapLimit: resources.MemorySwapLimit,
+	}
+}
+
+func mapUlimitsToAPIModel(ulimits []model.Ulimit) (result []*containers.Ulimit) {
+	for _, ulimit := range ulimits {
+		result = append(result, &containers.Ulimit{
+			Name: ulimit.Name,
+			Soft: ulimit.Soft,
+			Hard: ulimit.Hard,
 		})
 	}
 	return result
 }
 
+func mapCapabilitiesToAPIModel(capabilities *model.Capabilities) *containers.Capabilities {
+	if capabilities == nil {
+		return nil
+	}
+	return &containers.Capabilities{
+		Effective:   capabilities.Effective,
+		Permitted:   capabilities.Permitted,
+		Bounding:    capabilities.Bounding,
+		Inheritable: capabilities.Inheritable,
+	}
+}
+
 func mapMountsToAPIModel(mounts []model.Mount) (result []*containers.Mount) {
 	for _, mount := range mounts {
 		result = append(result, &containers.Mount{
@@ -93,11 +267,80 @@ func mapMountsToAPIModel(mounts []model.Mount) (result []*containers.Mount) {
 			Source:      mount.Source,
 			Destination: mount.Destination,
 			Options:     mount.Options,
+			Propagation: mount.Propagation,
 		})
 	}
 	return result
 }
 
+func mapTmpfsToAPIModel(mounts []model.TmpfsMount) (result []*containers.TmpfsMount) {
+	for _, mount := range mounts {
+		result = append(result, &containers.TmpfsMount{
+			Destination: mount.Destination,
+			SizeLimit:   mount.SizeLimit,
+			Options:     mount.Options,
+		})
+	}
+	return result
+}
+
+func mapFileMountsToAPIModel(files []model.FileMount) (result []*containers.FileMount) {
+	for _, file := range files {
+		result = append(result, &containers.FileMount{
+			Path:    file.Path,
+			Content: file.Content,
+			Secret:  file.Secret,
+			Mode:    file.Mode,
+		})
+	}
+	return result
+}
+
+func mapDevicesToAPIModel(devices []model.Device) (result []*containers.Device) {
+	for _, device := range devices {
+		result = append(result, &containers.Device{
+			HostPath:      device.HostPath,
+			ContainerPath: device.ContainerPath,
+			Permissions:   device.Permissions,
+		})
+	}
+	return result
+}
+
+func mapFileWatchToAPIModel(watch *model.FileWatch) *containers.FileWatch {
+	if watch == nil {
+		return nil
+	}
+	return &containers.FileWatch{
+		Paths:           watch.Paths,
+		DebounceSeconds: int64(watch.Debounce / time.Second),
+	}
+}
+
+func mapIdleStopToAPIModel(idleStop *model.IdleStop) *containers.IdleStop {
+	if idleStop == nil {
+		return nil
+	}
+	return &containers.IdleStop{
+		TimeoutSeconds: int64(idleStop.Timeout / time.Second),
+		Port:           int32(idleStop.Port),
+		Signal:         idleStop.Signal,
+	}
+}
+
+func mapProbeToAPIModel(probe *model.Probe) *containers.Probe {
+	if probe == nil {
+		return nil
+	}
+	return &containers.Probe{
+		Exec:                probe.Exec,
+		InitialDelaySeconds: int64(probe.InitialDelay / time.Second),
+		PeriodSeconds:       int64(probe.Period / time.Second),
+		TimeoutSeconds:      int64(probe.Timeout / time.Second),
+		FailureThreshold:    int32(probe.FailureThreshold),
+	}
+}
+
 func mapPipeToAPIModel(pipe *model.PipeSet) *containers.PipeSet {
 	if pipe == nil {
 		return nil
@@ -120,11 +363,43 @@ func MapContainerStatusesToAPIModel(statuses []model.ContainerStatus) (result []
 			Image:        status.Image,
 			State:        status.State,
 			RestartCount: int32(status.RestartCount),
+
+			LastExitCode:   int32(status.LastExitCode),
+			LastExitReason: status.LastExitReason,
+			Ready:          status.Ready,
+			LastStartedAt:  mapTimeToUnix(status.LastStartedAt),
 		})
 	}
 	return result
 }
 
+// MapRunResultsToAPIModel maps list of internal one-shot run results to API model
+func MapRunResultsToAPIModel(results []model.RunResult) (result []*pods.RunResult) {
+	for _, r := range results {
+		result = append(result, &pods.RunResult{
+			Name:        r.Name,
+			ContainerID: r.ContainerID,
+			ExitCode:    int32(r.ExitCode),
+			ExitReason:  r.ExitReason,
+			TimedOut:    r.TimedOut,
+			Stdout:      []byte(r.Stdout),
+			Stderr:      []byte(r.Stderr),
+			Truncated:   r.Truncated,
+			StartedAt:   mapTimeToUnix(r.StartedAt),
+			FinishedAt:  mapTimeToUnix(r.FinishedAt),
+		})
+	}
+	return result
+}
+
+// mapTimeToUnix returns the time in unix seconds, zero for zero time
+func mapTimeToUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
 func mapFilesystemsToAPIModel(disks []model.Filesystem) (result []*node.Filesystem) {
 	for _, disk := range disks {
 		result = append(result, &node.Filesystem{
@@ -138,3 +413,163 @@ func mapFilesystemsToAPIModel(disks []model.Filesystem) (result []*node.Filesyst
 	}
 	return result
 }
+
+func mapFaultsToAPIModel(faults []model.NodeFault) (result []*node.Fault) {
+	for _, fault := range faults {
+		result = append(result, &node.Fault{
+			Field:  fault.Field,
+			Reason: fault.Reason,
+		})
+	}
+	return result
+}
+
+// MapStatsToAPIModel maps internal node stats model to API model
+func MapStatsToAPIModel(stats *model.NodeStats) *node.Stats {
+	return &node.Stats{
+		Load1:       stats.Load1,
+		Load5:       stats.Load5,
+		Load15:      stats.Load15,
+		MemoryTotal: stats.MemoryTotal,
+		MemoryFree:  stats.MemoryFree,
+		Temperature: stats.Temperature,
+		Filesystems: mapFilesystemsToAPIModel(stats.Filesystems),
+		Uptime:      stats.Uptime,
+	}
+}
+
+// MapDiskUsageToAPIModel maps internal disk usage model to API model
+func MapDiskUsageToAPIModel(usage model.DiskUsage) *node.DiskUsage {
+	result := &node.DiskUsage{
+		ContentSize: usage.ContentSize,
+	}
+	for _, container := range usage.Containers {
+		result.Containers = append(result.Containers, &node.ContainerDiskUsage{
+			ContainerID: container.ContainerID,
+			Name:        container.Name,
+			Pod:         container.Pod,
+			Size:        container.Size,
+			Inodes:      container.Inodes,
+		})
+	}
+	return result
+}
+
+// MapNamespaceSummariesToAPIModel maps internal namespace summary models to API model
+func MapNamespaceSummariesToAPIModel(summaries []model.NamespaceSummary) (result []*node.NamespaceSummary) {
+	for _, summary := range summaries {
+		result = append(result, &node.NamespaceSummary{
+			Namespace:         summary.Namespace,
+			Containers:        int32(summary.Containers),
+			RunningContainers: int32(summary.RunningContainers),
+			CpuUsage:          summary.CPUUsage,
+			MemoryUsage:       summary.MemoryUsage,
+			ImageSize:         summary.ImageSize,
+			SnapshotSize:      summary.SnapshotSize,
+		})
+	}
+	return result
+}
+
+// MapReconcileRecordsToAPIModel maps internal reconcile records to API model
+func MapReconcileRecordsToAPIModel(records []model.ReconcileRecord) (result []*node.ReconcileRecord) {
+	for _, record := range records {
+		result = append(result, &node.ReconcileRecord{
+			Time:        record.Time.Unix(),
+			Namespace:   record.Namespace,
+			Pod:         record.Pod,
+			ContainerID: record.ContainerID,
+			Action:      record.Action,
+			Error:       record.Error,
+		})
+	}
+	return result
+}
+
+// MapApplyActionsToAPIModel maps the reconcile records of the pod apply to API model
+func MapApplyActionsToAPIModel(records []model.ReconcileRecord) (result []*pods.ApplyAction) {
+	for _, record := range records {
+		result = append(result, &pods.ApplyAction{
+			Pod:         record.Pod,
+			ContainerID: record.ContainerID,
+			Action:      record.Action,
+			Error:       record.Error,
+		})
+	}
+	return result
+}
+
+// MapRuntimeEventToAPIModel maps internal runtime event to API model
+func MapRuntimeEventToAPIModel(event model.RuntimeEvent) *node.RuntimeEvent {
+	return &node.RuntimeEvent{
+		Timestamp: event.Timestamp.UnixNano(),
+		Namespace: event.Namespace,
+		Topic:     event.Topic,
+		Subject:   event.Subject,
+		TypeUrl:   event.TypeURL,
+		Payload:   event.Payload,
+	}
+}
+
+// MapMaintenanceToAPIModel maps internal maintenance mode state to API model
+func MapMaintenanceToAPIModel(maintenance model.Maintenance) *node.Maintenance {
+	return &node.Maintenance{
+		Enabled: maintenance.Enabled,
+		Reason:  maintenance.Reason,
+		Since:   mapTimeToUnix(maintenance.Since),
+	}
+}
+
+// MapRuntimeInfoToAPIModel maps internal runtime info model to API model
+func MapRuntimeInfoToAPIModel(info model.RuntimeInfo) *node.RuntimeInfo {
+	return &node.RuntimeInfo{
+		ContainerdVersion:  info.ContainerdVersion,
+		ContainerdRevision: info.ContainerdRevision,
+		Snapshotter:        info.Snapshotter,
+		Snapshotters:       info.Snapshotters,
+		Runtimes:           info.Runtimes,
+		Plugins:            mapPluginStatusesToAPIModel(info.Plugins),
+		Containers:         int64(info.Containers),
+		MaxContainers:      int64(info.MaxContainers),
+	}
+}
+
+// MapSnapshotterMigrationStepToAPIModel maps internal snapshotter migration step to API model
+func MapSnapshotterMigrationStepToAPIModel(step model.SnapshotterMigrationStep) *node.SnapshotterMigrationStep {
+	return &node.SnapshotterMigrationStep{
+		Namespace: step.Namespace,
+		Kind:      step.Kind,
+		Name:      step.Name,
+		Current:   int32(step.Current),
+		Total:     int32(step.Total),
+		Skipped:   step.Skipped,
+		Error:     step.Error,
+	}
+}
+
+func mapPluginStatusesToAPIModel(plugins []model.PluginStatus) (result []*node.PluginStatus) {
+	for _, plugin := range plugins {
+		result = append(result, &node.PluginStatus{
+			Type:  plugin.Type,
+			Id:    plugin.ID,
+			Error: plugin.Error,
+		})
+	}
+	return result
+}
+
+// MapContainerStatsToAPIModel maps internal container stats model to API model
+func MapContainerStatsToAPIModel(stats model.ContainerStats) *containers.ContainerStats {
+	return &containers.ContainerStats{
+		ContainerID:      stats.ContainerID,
+		Time:             stats.Time.UnixNano() / int64(time.Millisecond),
+		CpuPercent:       stats.CPUPercent,
+		MemoryUsage:      stats.MemoryUsage,
+		MemoryLimit:      stats.MemoryLimit,
+		NetworkAvailable: stats.NetworkAvailable,
+		NetworkRxBytes:   stats.NetworkRxBytes,
+		NetworkTxBytes:   stats.NetworkTxBytes,
+		NetworkRxRate:    stats.NetworkRxRate,
+		NetworkTxRate:    stats.NetworkTxRate,
+	}
+}
@@ -1,6 +1,8 @@
 package mapping
 
 import (
+	"time"
+
 	pb "github.com/ernoaapa/eliot/pkg/api/services/pods/v1"
 	"github.com/ernoaapa/eliot/pkg/progress"
 )
@@ -23,6 +25,7 @@ func MapImageFetchProgressToAPIModel(progresses []*progress.ImageFetch) (result
 			Resolved:    progress.Resolved,
 			Failed:      progress.Failed,
 			Layers:      Layers,
+			Stats:       mapPullStatsToAPIModel(progress.GetStats()),
 		})
 	}
 	return result
@@ -40,12 +43,61 @@ func MapAPIModelToImageFetchProgress(progresses []*pb.ImageFetch) (result []*pro
 				Total:  layer.Total,
 			}
 		}
-		result = append(result, progress.CreateImageFetch(
+		fetch := progress.CreateImageFetch(
 			image.ContainerID,
 			image.Image,
 			image.Resolved,
 			statuses,
-		))
+		)
+		if image.Stats != nil {
+			fetch.SetStats(progress.PullStats{
+				Cached:        image.Stats.Cached,
+				Duration:      time.Duration(image.Stats.Duration) * time.Millisecond,
+				BytesFetched:  image.Stats.BytesFetched,
+				Layers:        int(image.Stats.Layers),
+				FetchedLayers: int(image.Stats.FetchedLayers),
+			})
+		}
+		result = append(result, fetch)
+	}
+	return result
+}
+
+func mapPullStatsToAPIModel(stats *progress.PullStats) *pb.PullStats {
+	if stats == nil {
+		return nil
+	}
+	return &pb.PullStats{
+		Cached:        stats.Cached,
+		Duration:      int64(stats.Duration / time.Millisecond),
+		BytesFetched:  stats.BytesFetched,
+		Layers:        int32(stats.Layers),
+		FetchedLayers: int32(stats.FetchedLayers),
+	}
+}
+
+// MapPhasesToAPIModel maps container create phase changes to API model
+func MapPhasesToAPIModel(phases []progress.Phase) (result []*pb.CreatePhase) {
+	for _, phase := range phases {
+		result = append(result, &pb.CreatePhase{
+			Container: phase.Container,
+			Phase:     phase.Phase,
+			Error:     phase.Error,
+			Time:      phase.Time.UnixNano() / int64(time.Millisecond),
+		})
+	}
+	return result
+}
+
+// MapAPIModelToPhases maps container create phase changes from API model
+func MapAPIModelToPhases(phases []*pb.CreatePhase) (result []progress.Phase) {
+	for _, phase := range phases {
+		result = append(result, progress.Phase{
+			Container: phase.Container,
+			Phase:     phase.Phase,
+			Error:     phase.Error,
+			Time:      time.Unix(0, phase.Time*int64(time.Millisecond)),
+		})
 	}
 	return result
 }
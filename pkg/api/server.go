@@ -3,8 +3,12 @@ package api
 import (
 	"fmt"
 	"net"
+	"os"
+	goruntime "runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -14,16 +18,39 @@ import (
 
 	"github.com/ernoaapa/eliot/pkg/api/mapping"
 	containers "github.com/ernoaapa/eliot/pkg/api/services/containers/v1"
+	images "github.com/ernoaapa/eliot/pkg/api/services/images/v1"
 	node "github.com/ernoaapa/eliot/pkg/api/services/node/v1"
 	pods "github.com/ernoaapa/eliot/pkg/api/services/pods/v1"
 	"github.com/ernoaapa/eliot/pkg/api/stream"
+	"github.com/ernoaapa/eliot/pkg/controller"
 	resolver "github.com/ernoaapa/eliot/pkg/node"
 	"github.com/ernoaapa/eliot/pkg/progress"
 	"github.com/ernoaapa/eliot/pkg/runtime"
+	"github.com/ernoaapa/eliot/pkg/secrets"
+	"github.com/ernoaapa/eliot/pkg/utils"
+	"github.com/ernoaapa/eliot/pkg/version"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	health "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+const (
+	// minStatsInterval is the shortest allowed interval for streaming node stats
+	minStatsInterval = time.Second
+	// maxConcurrentPulls limits how many images get pulled in parallel to not saturate slow links
+	maxConcurrentPulls = 3
+	// powerActionDelay is how long to wait before reboot or poweroff so the response reaches the client
+	powerActionDelay = time.Second
+	// minMemoryLimit is the smallest memory limit what runc accepts for a container
+	minMemoryLimit = 6 * 1024 * 1024
+	// ReadinessService is the health check service name what reports SERVING once the lifecycle controller
+	// has got all the containers running and ready after boot, the empty service name reports the liveness
+	ReadinessService = "eliot.Readiness"
 )
 
 // Server implements the GRPC API for the eli
@@ -32,6 +59,29 @@ type Server struct {
 	client   runtime.Client
 	grpc     *grpc.Server
 	listen   string
+	listener net.Listener
+	registry string
+	pulls    chan struct{}
+	secrets  *secrets.Store
+
+	powerControl bool
+	debugEvents  bool
+	reboot       func() error
+	poweroff     func() error
+
+	pause *controller.ReconcilePause
+	// history contains the lifecycle controller actions, nil if the lifecycle controller is not enabled
+	history *controller.ReconcileHistory
+	// readiness tells when the lifecycle controller has converged, nil if the lifecycle controller is not enabled
+	readiness *controller.Readiness
+	// converger applies the desired pods what Apply gets
+	converger *controller.Converger
+	// auth authenticates and authorizes the calls, nil if the authentication is not enabled
+	auth *Authenticator
+	// maintenance rejects creating and starting containers while enabled
+	maintenance *controller.Maintenance
+
+	grpcOpts []grpc.ServerOption
 }
 
 // Info is Node service Info implementation
@@ -41,60 +91,661 @@ func (s *Server) Info(context context.Context, req *node.InfoRequest) (*node.Inf
 	}, nil
 }
 
+// Identity is Node service Identity implementation
+func (s *Server) Identity(context context.Context, req *node.IdentityRequest) (*node.IdentityResponse, error) {
+	return &node.IdentityResponse{
+		Identity: mapping.MapIdentityToAPIModel(s.resolver.GetIdentity()),
+	}, nil
+}
+
+// SetLabels is Node service SetLabels implementation
+func (s *Server) SetLabels(context context.Context, req *node.SetLabelsRequest) (*node.SetLabelsResponse, error) {
+	labels, err := s.resolver.UpdateLabels(mapping.MapLabelsToInternalModel(req.Labels), req.Remove)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	log.Infof("Node labels changed, %d labels set and %d removed", len(req.Labels), len(req.Remove))
+	return &node.SetLabelsResponse{
+		Labels: mapping.MapLabelsToAPIModel(labels),
+	}, nil
+}
+
+// WatchLabels is Node service WatchLabels implementation
+func (s *Server) WatchLabels(req *node.WatchLabelsRequest, server node.Node_WatchLabelsServer) error {
+	changes, cancel := s.resolver.SubscribeLabels()
+	defer cancel()
+
+	labels := s.resolver.GetLabels()
+	for {
+		if err := server.Send(&node.WatchLabelsResponse{
+			Labels: mapping.MapLabelsToAPIModel(labels),
+		}); err != nil {
+			return errors.Wrap(err, "Failed to send node labels")
+		}
+
+		select {
+		case <-server.Context().Done():
+			log.Debugf("Client disconnected, stop streaming node labels")
+			return nil
+		case labels = <-changes:
+		}
+	}
+}
+
+// Check is gRPC Health service Check implementation
+// Both the services report NOT_SERVING while containerd is not connected because nothing works without it
+// Without the lifecycle controller there's nothing to converge so the node is ready when the API serves
+func (s *Server) Check(context context.Context, req *health.HealthCheckRequest) (*health.HealthCheckResponse, error) {
+	switch req.Service {
+	case "":
+		if !s.client.IsConnected() {
+			return &health.HealthCheckResponse{Status: health.HealthCheckResponse_NOT_SERVING}, nil
+		}
+		return &health.HealthCheckResponse{Status: health.HealthCheckResponse_SERVING}, nil
+	case ReadinessService:
+		if !s.client.IsConnected() || (s.readiness != nil && !s.readiness.IsReady()) {
+			return &health.HealthCheckResponse{Status: health.HealthCheckResponse_NOT_SERVING}, nil
+		}
+		return &health.HealthCheckResponse{Status: health.HealthCheckResponse_SERVING}, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "Unknown health check service [%s], must be empty or %s", req.Service, ReadinessService)
+}
+
+// logHealthChange logs how the containerd connection change affects the health check
+func logHealthChange(connected bool) {
+	if connected {
+		log.Infof("Health check reports %s, containerd is connected", health.HealthCheckResponse_SERVING)
+	} else {
+		log.Warnf("Health check reports %s until containerd connection comes back", health.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// Capabilities is Node service Capabilities implementation
+func (s *Server) Capabilities(context context.Context, req *node.CapabilitiesRequest) (*node.CapabilitiesResponse, error) {
+	runtimeInfo, err := s.client.GetRuntimeInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to resolve runtime info")
+	}
+
+	features := append(s.getFeatures(), runtimeInfo.Features...)
+	sort.Strings(features)
+
+	return &node.CapabilitiesResponse{
+		Version:          version.VERSION,
+		MinClientVersion: version.MinClientVersion,
+		Methods:          s.getMethods(),
+		Features:         features,
+		Snapshotters:     runtimeInfo.Snapshotters,
+	}, nil
+}
+
+// unimplementedMethods are registered because they are in the API definition, but always fail as unimplemented
+var unimplementedMethods = map[string]bool{
+	"/eliot.services.containers.v1.Containers/Logs": true,
+}
+
+// getMethods returns the full names of the registered API methods sorted, without the legacy aliases
+// and the unimplemented methods
+func (s *Server) getMethods() (result []string) {
+	if s.grpc == nil {
+		return result
+	}
+	for service, info := range s.grpc.GetServiceInfo() {
+		if service == pods.LegacyServiceName {
+			continue
+		}
+		for _, method := range info.Methods {
+			name := fmt.Sprintf("/%s/%s", service, method.Name)
+			if !unimplementedMethods[name] {
+				result = append(result, name)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// getFeatures returns the optional API features what are enabled in the server options
+func (s *Server) getFeatures() (result []string) {
+	enabled := map[string]bool{
+		model.FeaturePowerControl:     s.powerControl,
+		model.FeatureReconcilePause:   s.pause != nil,
+		model.FeatureReconcileHistory: s.history != nil,
+		model.FeatureAuthentication:   s.auth != nil,
+		model.FeaturePullSecrets:      s.secrets != nil,
+		model.FeatureDebugEvents:      s.debugEvents,
+	}
+	for feature, ok := range enabled {
+		if ok {
+			result = append(result, feature)
+		}
+	}
+	return result
+}
+
+// DescribeDevice is Node service DescribeDevice implementation
+func (s *Server) DescribeDevice(context context.Context, req *node.DescribeDeviceRequest) (*node.DescribeDeviceResponse, error) {
+	runtimeInfo, err := s.client.GetRuntimeInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to resolve runtime info")
+	}
+
+	summaries, err := s.getNamespaceSummaries()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.client.GetAllPods()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to fetch pods")
+	}
+
+	return &node.DescribeDeviceResponse{
+		Info:       mapping.MapInfoToAPIModel(s.resolver.GetInfo()),
+		Runtime:    mapping.MapRuntimeInfoToAPIModel(runtimeInfo),
+		Namespaces: mapping.MapNamespaceSummariesToAPIModel(summaries),
+		Pods:       mapping.MapPodsToAPIModel(p),
+	}, nil
+}
+
+// ExportState is Node service ExportState implementation
+func (s *Server) ExportState(context context.Context, req *node.ExportStateRequest) (*node.ExportStateResponse, error) {
+	namespaces := []string{req.Namespace}
+	if req.AllNamespaces {
+		var err error
+		namespaces, err = s.client.GetNamespaces()
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to fetch namespaces")
+		}
+	}
+
+	result := []*pods.Pod{}
+	for _, namespace := range namespaces {
+		exported, err := s.client.ExportPods(namespace)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to export pods in namespace [%s]", namespace)
+		}
+		for _, pod := range mapping.MapPodsToAPIModel(exported) {
+			pod.Status = nil
+			result = append(result, pod)
+		}
+	}
+	return &node.ExportStateResponse{Pods: result}, nil
+}
+
+// SetSnapshotter is Node service SetSnapshotter implementation
+func (s *Server) SetSnapshotter(context context.Context, req *node.SetSnapshotterRequest) (*node.SetSnapshotterResponse, error) {
+	if err := s.client.SetSnapshotter(req.Snapshotter); err != nil {
+		if runtime.IsNotFound(err) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, errors.Wrap(err, "Failed to change snapshotter")
+	}
+
+	runtimeInfo, err := s.client.GetRuntimeInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to resolve runtime info")
+	}
+	return &node.SetSnapshotterResponse{
+		Runtime: mapping.MapRuntimeInfoToAPIModel(runtimeInfo),
+	}, nil
+}
+
+// MigrateSnapshotter is Node service MigrateSnapshotter implementation
+// The migration continues even if the client disconnects, the client can resume by migrating again
+func (s *Server) MigrateSnapshotter(req *node.MigrateSnapshotterRequest, server node.Node_MigrateSnapshotterServer) error {
+	if req.RecreateContainers {
+		if err := s.checkMaintenance(); err != nil {
+			return err
+		}
+	}
+	err := s.client.MigrateSnapshotter(req.Snapshotter, req.RecreateContainers, func(step model.SnapshotterMigrationStep) {
+		if err := server.Send(&node.MigrateSnapshotterResponse{
+			Step: mapping.MapSnapshotterMigrationStepToAPIModel(step),
+		}); err != nil {
+			log.Debugf("Failed to send snapshotter migration progress: %s", err)
+		}
+	})
+	if runtime.IsNotFound(err) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+// Stats is Node service Stats implementation
+// Sends the stats at the requested interval until the client disconnects
+func (s *Server) Stats(req *node.StatsRequest, server node.Node_StatsServer) error {
+	interval := time.Duration(req.Interval) * time.Millisecond
+	if interval < minStatsInterval {
+		interval = minStatsInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := server.Send(&node.StatsResponse{
+			Stats: mapping.MapStatsToAPIModel(s.resolver.GetStats()),
+		}); err != nil {
+			return errors.Wrap(err, "Failed to send node stats")
+		}
+
+		select {
+		case <-server.Context().Done():
+			log.Debugf("Client disconnected, stop streaming node stats")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DiskUsage is Node service DiskUsage implementation
+func (s *Server) DiskUsage(context context.Context, req *node.DiskUsageRequest) (*node.DiskUsageResponse, error) {
+	usage, err := s.client.GetDiskUsage(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to resolve disk usage in namespace [%s]", req.Namespace)
+	}
+	return &node.DiskUsageResponse{
+		Usage: mapping.MapDiskUsageToAPIModel(usage),
+	}, nil
+}
+
+// ResourceSummary is Node service ResourceSummary implementation
+func (s *Server) ResourceSummary(context context.Context, req *node.ResourceSummaryRequest) (*node.ResourceSummaryResponse, error) {
+	summaries, err := s.getNamespaceSummaries()
+	if err != nil {
+		return nil, err
+	}
+	return &node.ResourceSummaryResponse{
+		Namespaces: mapping.MapNamespaceSummariesToAPIModel(summaries),
+	}, nil
+}
+
+func (s *Server) getNamespaceSummaries() ([]model.NamespaceSummary, error) {
+	namespaces, err := s.client.GetNamespaces()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to fetch namespaces")
+	}
+
+	summaries := []model.NamespaceSummary{}
+	for _, namespace := range namespaces {
+		summary, err := s.getNamespaceSummary(namespace)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+func (s *Server) getNamespaceSummary(namespace string) (model.NamespaceSummary, error) {
+	summary := model.NamespaceSummary{Namespace: namespace}
+
+	pods, err := s.client.GetPods(namespace)
+	if err != nil {
+		return summary, errors.Wrapf(err, "Failed to fetch pods in namespace [%s]", namespace)
+	}
+	for _, pod := range pods {
+		summary.Containers += len(pod.Spec.Containers)
+	}
+
+	metrics, err := s.client.GetContainerMetrics(namespace)
+	if err != nil {
+		return summary, errors.Wrapf(err, "Failed to resolve container metrics in namespace [%s]", namespace)
+	}
+	summary.RunningContainers = len(metrics)
+	for _, m := range metrics {
+		summary.CPUUsage += m.CPUUsage
+		summary.MemoryUsage += m.MemoryUsage
+	}
+
+	usage, err := s.client.GetDiskUsage(namespace)
+	if err != nil {
+		return summary, errors.Wrapf(err, "Failed to resolve disk usage in namespace [%s]", namespace)
+	}
+	summary.ImageSize = usage.ContentSize
+	for _, container := range usage.Containers {
+		summary.SnapshotSize += container.Size
+	}
+	return summary, nil
+}
+
+// Reboot is Node service Reboot implementation
+func (s *Server) Reboot(context context.Context, req *node.RebootRequest) (*node.RebootResponse, error) {
+	count, err := s.drainNode(time.Duration(req.GracePeriodSeconds) * time.Second)
+	if err != nil {
+		return nil, err
+	}
+	go s.runPowerAction("reboot", s.reboot)
+	return &node.RebootResponse{StoppedContainers: int32(count)}, nil
+}
+
+// Poweroff is Node service Poweroff implementation
+func (s *Server) Poweroff(context context.Context, req *node.PoweroffRequest) (*node.PoweroffResponse, error) {
+	count, err := s.drainNode(time.Duration(req.GracePeriodSeconds) * time.Second)
+	if err != nil {
+		return nil, err
+	}
+	go s.runPowerAction("poweroff", s.poweroff)
+	return &node.PoweroffResponse{StoppedContainers: int32(count)}, nil
+}
+
+// drainNode stops all managed containers gracefully, pods in parallel so the grace periods don't add up
+// The controllers get paused for the drain and the power action so they don't start the containers again
+// Returns the number of the containers what got stopped
+func (s *Server) drainNode(gracePeriod time.Duration) (int, error) {
+	if !s.powerControl {
+		return 0, status.Error(codes.PermissionDenied, "Power control is disabled, start eliotd with --allow-power-control to enable it")
+	}
+
+	if s.pause != nil {
+		s.pause.Pause(0)
+	}
+	pods, err := s.client.GetAllPods()
+	if err != nil {
+		s.resumeAfterDrain()
+		return 0, errors.Wrap(err, "Cannot fetch pods, cannot stop containers before power action")
+	}
+
+	var (
+		wg    sync.WaitGroup
+		count = 0
+	)
+	for _, pod := range pods {
+		ids := []string{}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			ids = append(ids, containerStatus.ContainerID)
+		}
+		count += len(ids)
+
+		podGracePeriod := pod.Spec.StopGracePeriod
+		if gracePeriod > 0 {
+			podGracePeriod = gracePeriod
+		}
+
+		wg.Add(1)
+		go func(pod model.Pod, ids []string, gracePeriod time.Duration) {
+			defer wg.Done()
+			if err := s.client.TerminateContainers(pod.Metadata.Namespace, ids, gracePeriod); err != nil {
+				log.Warnf("Failed to stop pod [%s] containers gracefully: %s", pod.Metadata.Name, err)
+			}
+		}(pod, ids, podGracePeriod)
+	}
+	wg.Wait()
+	return count, nil
+}
+
+func (s *Server) runPowerAction(name string, action func() error) {
+	time.Sleep(powerActionDelay)
+	log.Infof("Containers stopped, executing %s", name)
+	if err := action(); err != nil {
+		log.Errorf("Failed to %s the node: %s", name, err)
+		s.resumeAfterDrain()
+	}
+}
+
+// resumeAfterDrain resumes the controllers if the power action cannot be done, so the containers get started again
+func (s *Server) resumeAfterDrain() {
+	if s.pause != nil {
+		s.pause.Resume()
+	}
+}
+
+// PauseReconcile is Node service PauseReconcile implementation
+func (s *Server) PauseReconcile(context context.Context, req *node.PauseReconcileRequest) (*node.PauseReconcileResponse, error) {
+	if s.pause == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Lifecycle controller is not enabled, nothing to pause")
+	}
+	resumeAt := s.pause.Pause(time.Duration(req.TimeoutSeconds) * time.Second)
+	return &node.PauseReconcileResponse{ResumeAt: resumeAt.Unix()}, nil
+}
+
+// ResumeReconcile is Node service ResumeReconcile implementation
+func (s *Server) ResumeReconcile(context context.Context, req *node.ResumeReconcileRequest) (*node.ResumeReconcileResponse, error) {
+	if s.pause == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Lifecycle controller is not enabled, nothing to resume")
+	}
+	s.pause.Resume()
+	return &node.ResumeReconcileResponse{}, nil
+}
+
+// Maintenance is Node service Maintenance implementation
+func (s *Server) Maintenance(context context.Context, req *node.MaintenanceRequest) (*node.MaintenanceResponse, error) {
+	return &node.MaintenanceResponse{
+		Maintenance: mapping.MapMaintenanceToAPIModel(s.maintenance.Get()),
+	}, nil
+}
+
+// SetMaintenance is Node service SetMaintenance implementation
+func (s *Server) SetMaintenance(context context.Context, req *node.SetMaintenanceRequest) (*node.SetMaintenanceResponse, error) {
+	return &node.SetMaintenanceResponse{
+		Maintenance: mapping.MapMaintenanceToAPIModel(s.maintenance.Set(req.Enabled, req.Reason)),
+	}, nil
+}
+
+// RuntimeEvents is Node service RuntimeEvents implementation
+func (s *Server) RuntimeEvents(req *node.RuntimeEventsRequest, server node.Node_RuntimeEventsServer) error {
+	if !s.debugEvents {
+		return status.Error(codes.PermissionDenied, "Runtime events are disabled, start eliotd with --debug-events to enable them")
+	}
+	// Empty namespace streams all namespaces, so it must be given only when all namespaces were requested and authorized
+	namespace := model.ResolveNamespace(req.Namespace)
+	if req.AllNamespaces {
+		namespace = ""
+	}
+
+	log.Debugf("Start streaming runtime events, namespace [%s], filters %v", namespace, req.Filters)
+	return s.client.StreamEvents(server.Context(), namespace, req.Filters, func(event model.RuntimeEvent) error {
+		if err := server.Send(&node.RuntimeEventsResponse{
+			Event: mapping.MapRuntimeEventToAPIModel(event),
+		}); err != nil {
+			return errors.Wrap(err, "Failed to send runtime event")
+		}
+		return nil
+	})
+}
+
+// ReconcileHistory is Node service ReconcileHistory implementation
+func (s *Server) ReconcileHistory(context context.Context, req *node.ReconcileHistoryRequest) (*node.ReconcileHistoryResponse, error) {
+	if s.history == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Lifecycle controller is not enabled, no reconcile history")
+	}
+	return &node.ReconcileHistoryResponse{
+		Records: mapping.MapReconcileRecordsToAPIModel(s.history.List()),
+	}, nil
+}
+
 // Create is 'pods' service Create implementation
 func (s *Server) Create(req *pods.CreatePodRequest, server pods.Pods_CreateServer) error {
-	pod := mapping.MapPodToInternalModel(req.Pod)
+	if err := s.checkMaintenance(); err != nil {
+		return err
+	}
+	pod, err := s.getValidPod(req.Pod)
+	if err != nil {
+		return err
+	}
 	var (
-		done       = make(chan struct{})
+		// mutex guards the progresses and the statuses what the update loop reads
+		mutex      sync.Mutex
 		progresses = []*progress.ImageFetch{}
+		statuses   = []model.ContainerStatus{}
+		phases     = progress.NewPhases()
 	)
-	defer close(done)
 
 	if err := s.ensurePodNotExist(pod.Metadata.Namespace, pod.Metadata.Name); err != nil {
 		return errors.Wrapf(err, "Cannot create pod [%s]", pod.Metadata.Name)
 	}
 
+	// Check before pulling so that the pod doesn't get partially created
+	if err := s.client.CheckContainerLimit(len(pod.Spec.Containers)); err != nil {
+		if runtime.IsLimitExceeded(err) {
+			return status.Errorf(codes.ResourceExhausted, "Cannot create pod [%s]: %s", pod.Metadata.Name, err)
+		}
+		return errors.Wrapf(err, "Cannot create pod [%s]", pod.Metadata.Name)
+	}
+	if err := s.client.CheckNamespaceQuota(pod, nil); err != nil {
+		if runtime.IsLimitExceeded(err) {
+			return status.Errorf(codes.ResourceExhausted, "Cannot create pod [%s]: %s", pod.Metadata.Name, err)
+		}
+		return errors.Wrapf(err, "Cannot create pod [%s]", pod.Metadata.Name)
+	}
+
+	for i, container := range pod.Spec.Containers {
+		phases.Set(container.Name, progress.PhaseResolving)
+		image, err := utils.NormalizeImageRef(container.Image, s.registry)
+		if err != nil {
+			return errors.Wrapf(err, "Cannot create pod [%s], container [%s] has invalid image", pod.Metadata.Name, container.Name)
+		}
+		pod.Spec.Containers[i].Image = image
+	}
+
+	done, stopped := make(chan struct{}), make(chan struct{})
 	go func() {
+		defer close(stopped)
 		for {
 			select {
 			case <-done:
-				// Send last update
-				images := mapping.MapImageFetchProgressToAPIModel(progresses)
-
-				if err := server.Send(&pods.CreatePodStreamResponse{Images: images}); err != nil {
-					log.Warnf("Error while sending last create pod status back to client: %s", err)
-				}
 				return // End update loop
 			case <-time.After(100 * time.Millisecond):
+				mutex.Lock()
 				images := mapping.MapImageFetchProgressToAPIModel(progresses)
+				mutex.Unlock()
 
-				if err := server.Send(&pods.CreatePodStreamResponse{Images: images}); err != nil {
+				if err := server.Send(&pods.CreatePodStreamResponse{
+					Images: images,
+					Phases: mapping.MapPhasesToAPIModel(phases.Take()),
+				}); err != nil {
 					log.Warnf("Error while sending create pod status back to client: %s", err)
 				}
 			}
 		}
 	}()
+	// The last update must be sent before returning, the stream cannot be used after the handler returns
+	defer func() {
+		close(done)
+		<-stopped
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if err := server.Send(&pods.CreatePodStreamResponse{
+			Images:            mapping.MapImageFetchProgressToAPIModel(progresses),
+			ContainerStatuses: mapping.MapContainerStatusesToAPIModel(statuses),
+			Phases:            mapping.MapPhasesToAPIModel(phases.Take()),
+		}); err != nil {
+			log.Warnf("Error while sending last create pod status back to client: %s", err)
+		}
+	}()
 
 	for _, container := range pod.Spec.Containers {
-		progress := progress.NewImageFetch(container.Name, container.Image)
-		progresses = append(progresses, progress)
+		fetch := progress.NewImageFetch(container.Name, container.Image)
+		fetch.TrackPhases(phases)
+		mutex.Lock()
+		progresses = append(progresses, fetch)
+		mutex.Unlock()
+
+		phases.Set(container.Name, progress.PhasePulling)
+		if err := s.pullImage(pod.Metadata.Namespace, container.Image, pod.Spec.ImagePullSecrets, nil, fetch); err != nil {
+			fetch.SetToFailed()
+			phases.Fail(container.Name, err)
+			return s.removeOnFailure(req.Start, pod, statuses, errors.Wrapf(err, "Failed to pull image [%s]", container.Image))
+		}
+		fetch.AllDone()
 
-		if err := s.client.PullImage(pod.Metadata.Namespace, container.Image, progress); err != nil {
-			progress.SetToFailed()
-			return errors.Wrapf(err, "Failed to pull image [%s]", container.Image)
+		phases.Set(container.Name, progress.PhaseCreating)
+		resolved, err := s.resolveFileSecrets(pod.Metadata.Namespace, container)
+		if err != nil {
+			phases.Fail(container.Name, err)
+			return s.removeOnFailure(req.Start, pod, statuses, errors.Wrapf(err, "Failed to resolve container [%s] files", container.Name))
 		}
-		progress.AllDone()
 
-		_, err := s.client.CreateContainer(pod, container)
+		status, err := s.client.CreateContainer(pod, resolved)
 		if err != nil {
-			return errors.Wrapf(err, "Failed to create container [%s]", container.Name)
+			phases.Fail(container.Name, err)
+			return s.removeOnFailure(req.Start, pod, statuses, errors.Wrapf(err, "Failed to create container [%s]", container.Name))
 		}
-		log.Debugf("Container [%s] created", container.Name)
+		log.Debugf("Container [%s] created with id [%s]", container.Name, status.ContainerID)
+		mutex.Lock()
+		statuses = append(statuses, status)
+		mutex.Unlock()
+	}
+
+	if req.Start {
+		started, err := s.startContainers(pod, statuses, phases)
+		if err != nil {
+			return s.removeOnFailure(true, pod, statuses, err)
+		}
+		mutex.Lock()
+		statuses = started
+		mutex.Unlock()
 	}
 
 	return nil
 }
 
+// removeOnFailure removes the created containers if the create is part of the create and start flow
+// so that the failed flow doesn't leave behind containers what never got started
+func (s *Server) removeOnFailure(remove bool, pod model.Pod, created []model.ContainerStatus, err error) error {
+	if !remove || len(created) == 0 {
+		return err
+	}
+
+	ids := []string{}
+	for _, status := range created {
+		ids = append(ids, status.ContainerID)
+	}
+	if _, removeErr := s.client.StopContainers(pod.Metadata.Namespace, ids, pod.Spec.StopGracePeriod); removeErr != nil {
+		log.Warnf("Failed to remove the pod [%s] containers after failed create and start: %s", pod.Metadata.Name, removeErr)
+		return errors.Wrapf(err, "Pod [%s] containers might be left behind", pod.Metadata.Name)
+	}
+	log.Debugf("Removed the pod [%s] containers after failed create and start", pod.Metadata.Name)
+	return errors.Wrapf(err, "Removed the created pod [%s] containers", pod.Metadata.Name)
+}
+
+// pullImage pulls the image, waits while maxConcurrentPulls other pulls are in progress
+func (s *Server) pullImage(namespace, ref string, secretNames []string, labels map[string]string, progress *progress.ImageFetch) error {
+	pullSecrets, err := s.getPullSecrets(namespace, secretNames)
+	if err != nil {
+		return err
+	}
+
+	s.pulls <- struct{}{}
+	defer func() { <-s.pulls }()
+
+	return s.client.PullImage(namespace, ref, pullSecrets, labels, progress)
+}
+
+// resolveFileSecrets returns copy of the container where the secret files have the secret content
+// The secrets get resolved from the pod namespace, the pods cannot mount the secrets of other namespaces
+func (s *Server) resolveFileSecrets(namespace string, container model.Container) (model.Container, error) {
+	files := []model.FileMount{}
+	for _, file := range container.Files {
+		if file.Secret != "" {
+			if s.secrets == nil {
+				return container, fmt.Errorf("Cannot use secret [%s], the secret store is not configured", file.Secret)
+			}
+			secret, err := s.secrets.GetSecret(model.ResolveNamespace(namespace), file.Secret)
+			if err != nil {
+				return container, err
+			}
+			file.Content = string(secret.Data)
+		}
+		files = append(files, file)
+	}
+	container.Files = files
+	return container, nil
+}
+
+// getPullSecrets resolves the pull secrets from the namespace, the pods cannot use the secrets of other namespaces
+func (s *Server) getPullSecrets(namespace string, names []string) ([]model.PullSecret, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if s.secrets == nil {
+		return nil, fmt.Errorf("Cannot use pull secrets %s, the secret store is not configured", names)
+	}
+	return s.secrets.GetAll(model.ResolveNamespace(namespace), names)
+}
+
 func (s *Server) ensurePodNotExist(namespace, name string) error {
 	_, err := s.client.GetPod(namespace, name)
 	if err != nil {
@@ -106,33 +757,228 @@ func (s *Server) ensurePodNotExist(namespace, name string) error {
 	return fmt.Errorf("Pod [%s] in namespace [%s] already exist", name, namespace)
 }
 
+// Run is 'pods' service Run implementation
+// The containers run concurrently, the response is sent once all of them have exited and got removed
+func (s *Server) Run(context context.Context, req *pods.RunPodRequest) (*pods.RunPodResponse, error) {
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
+	pod, err := s.getValidPod(req.Pod)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensurePodNotExist(pod.Metadata.Namespace, pod.Metadata.Name); err != nil {
+		return nil, errors.Wrapf(err, "Cannot run pod [%s]", pod.Metadata.Name)
+	}
+
+	if err := s.client.CheckContainerLimit(len(pod.Spec.Containers)); err != nil {
+		if runtime.IsLimitExceeded(err) {
+			return nil, status.Errorf(codes.ResourceExhausted, "Cannot run pod [%s]: %s", pod.Metadata.Name, err)
+		}
+		return nil, errors.Wrapf(err, "Cannot run pod [%s]", pod.Metadata.Name)
+	}
+	if err := s.client.CheckNamespaceQuota(pod, nil); err != nil {
+		if runtime.IsLimitExceeded(err) {
+			return nil, status.Errorf(codes.ResourceExhausted, "Cannot run pod [%s]: %s", pod.Metadata.Name, err)
+		}
+		return nil, errors.Wrapf(err, "Cannot run pod [%s]", pod.Metadata.Name)
+	}
+
+	resolved := []model.Container{}
+	for _, container := range pod.Spec.Containers {
+		image, err := utils.NormalizeImageRef(container.Image, s.registry)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot run pod [%s], container [%s] has invalid image", pod.Metadata.Name, container.Name)
+		}
+		container.Image = image
+
+		if err := s.pullImage(pod.Metadata.Namespace, container.Image, pod.Spec.ImagePullSecrets, nil, progress.NewImageFetch(container.Name, container.Image)); err != nil {
+			return nil, errors.Wrapf(err, "Failed to pull image [%s]", container.Image)
+		}
+
+		withFiles, err := s.resolveFileSecrets(pod.Metadata.Namespace, container)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to resolve container [%s] files", container.Name)
+		}
+		resolved = append(resolved, withFiles)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results = make([]model.RunResult, len(resolved))
+		errs    = make([]error, len(resolved))
+		timeout = time.Duration(req.TimeoutSeconds) * time.Second
+	)
+	for i, container := range resolved {
+		wg.Add(1)
+		go func(i int, container model.Container) {
+			defer wg.Done()
+			results[i], errs[i] = s.client.RunContainer(pod, container, timeout)
+		}(i, container)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to run container [%s]", resolved[i].Name)
+		}
+	}
+
+	return &pods.RunPodResponse{
+		Results: mapping.MapRunResultsToAPIModel(results),
+	}, nil
+}
+
+// Apply is 'pods' service Apply implementation
+// The pods get validated before the converger computes and applies the changes, the pods without namespace
+// get the request namespace and the pods in another namespace get rejected
+func (s *Server) Apply(context context.Context, req *pods.ApplyPodsRequest) (*pods.ApplyPodsResponse, error) {
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
+	namespace := model.ResolveNamespace(req.Namespace)
+
+	var (
+		capacity = s.getNodeCapacity()
+		desired  = []model.Pod{}
+		names    = map[string]bool{}
+	)
+	for _, p := range req.Pods {
+		if p.GetMetadata() == nil || p.GetSpec() == nil {
+			return nil, status.Error(codes.InvalidArgument, "Pod must have metadata and spec")
+		}
+		pod := mapping.MapPodToInternalModel(p)
+		if pod.Metadata.Namespace == "" {
+			pod.Metadata.Namespace = namespace
+		}
+		if pod.Metadata.Namespace != namespace {
+			return nil, status.Errorf(codes.InvalidArgument, "Pod [%s] is in namespace [%s], all the pods must be in namespace [%s]", pod.Metadata.Name, pod.Metadata.Namespace, namespace)
+		}
+		if names[pod.Metadata.Name] {
+			return nil, status.Errorf(codes.InvalidArgument, "Pod [%s] is defined more than once", pod.Metadata.Name)
+		}
+		names[pod.Metadata.Name] = true
+
+		if err := s.checkValidPod(pod, capacity); err != nil {
+			return nil, err
+		}
+		for i, container := range pod.Spec.Containers {
+			image, err := utils.NormalizeImageRef(container.Image, s.registry)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "Pod [%s] container [%s] has invalid image: %s", pod.Metadata.Name, container.Name, err)
+			}
+			pod.Spec.Containers[i].Image = image
+		}
+		desired = append(desired, pod)
+	}
+
+	records, err := s.converger.Converge(namespace, desired, s.prepareContainer)
+	if err != nil {
+		if runtime.IsLimitExceeded(err) {
+			return nil, status.Errorf(codes.ResourceExhausted, "Cannot apply pods to namespace [%s]: %s", namespace, err)
+		}
+		return nil, errors.Wrapf(err, "Failed to apply pods to namespace [%s] after %d action(s)", namespace, len(records))
+	}
+	return &pods.ApplyPodsResponse{
+		Actions: mapping.MapApplyActionsToAPIModel(records),
+	}, nil
+}
+
+// prepareContainer pulls the container image and resolves the secret files before the container gets created
+func (s *Server) prepareContainer(pod model.Pod, container model.Container) (model.Container, error) {
+	if err := s.pullImage(pod.Metadata.Namespace, container.Image, pod.Spec.ImagePullSecrets, nil, progress.NewImageFetch(container.Name, container.Image)); err != nil {
+		return container, errors.Wrapf(err, "Failed to pull image [%s]", container.Image)
+	}
+	return s.resolveFileSecrets(pod.Metadata.Namespace, container)
+}
+
 // Start is 'pods' service Start implementation
 func (s *Server) Start(context context.Context, req *pods.StartPodRequest) (*pods.StartPodResponse, error) {
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
 	pod, err := s.client.GetPod(req.Namespace, req.Name)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to find containers to start for pod [%s] in namespace [%s]", req.Name, req.Namespace)
 	}
 
+	statuses, err := s.startContainers(pod, pod.Status.ContainerStatuses, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pod.Status.ContainerStatuses = statuses
+
+	return &pods.StartPodResponse{
+		Pod: mapping.MapPodToAPIModel(pod),
+	}, nil
+}
+
+// startContainers starts the pod containers and returns the container statuses
+// The containers start in the start priority order, the containers with the same priority start concurrently
+// and the next priority starts once all of them have started. Fails if any of the containers fails to start
+// The scheduled containers don't get started, the scheduler controller starts them at the scheduled time
+// The phases, if not nil, get the task create and start phases of each container
+func (s *Server) startContainers(pod model.Pod, statuses []model.ContainerStatus, phases *progress.Phases) ([]model.ContainerStatus, error) {
 	iosets, err := buildContainerIOSets(pod.Metadata.Name, pod.Spec.Containers)
 	if err != nil {
-		return nil, errors.Wrapf(err, "Cannot start pod [%s], error while building IO sets for containers", req.Name)
+		return nil, errors.Wrapf(err, "Cannot start pod [%s], error while building IO sets for containers", pod.Metadata.Name)
 	}
 
-	statuses := []model.ContainerStatus{}
-	for _, status := range pod.Status.ContainerStatuses {
-		status, err := s.client.StartContainer(pod.Metadata.Namespace, status.ContainerID, *iosets[status.Name])
-		if err != nil {
-			return nil, errors.Wrapf(err, "Failed to start container [%s]", status.Name)
-		}
-		log.Debugf("Container [%s] started", status.Name)
-		statuses = append(statuses, status)
+	scheduled := map[string]bool{}
+	for _, container := range pod.Spec.Containers {
+		scheduled[container.Name] = container.Schedule != ""
 	}
 
-	pod.Status.ContainerStatuses = statuses
+	started := map[string]model.ContainerStatus{}
+	for _, group := range pod.GroupByStartPriority(statuses) {
+		var (
+			mutex sync.Mutex
+			wg    sync.WaitGroup
+			errs  = make([]error, len(group))
+		)
+		for i, status := range group {
+			if scheduled[status.Name] {
+				log.Debugf("Container [%s] is scheduled, don't start it with the pod", status.Name)
+				continue
+			}
+			wg.Add(1)
+			go func(i int, status model.ContainerStatus) {
+				defer wg.Done()
+				name := status.Name
+				result, err := s.client.StartContainerWithProgress(pod.Metadata.Namespace, status.ContainerID, *iosets[name], func(phase string) {
+					phases.Set(name, phase)
+				})
+				if err != nil {
+					phases.Fail(name, err)
+					errs[i] = errors.Wrapf(err, "Failed to start container [%s]", name)
+					return
+				}
+				phases.Set(name, progress.PhaseRunning)
+				log.Debugf("Container [%s] started", name)
+				mutex.Lock()
+				defer mutex.Unlock()
+				started[name] = result
+			}(i, status)
+		}
+		wg.Wait()
 
-	return &pods.StartPodResponse{
-		Pod: mapping.MapPodToAPIModel(pod),
-	}, nil
+		for _, err := range errs {
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := []model.ContainerStatus{}
+	for _, status := range statuses {
+		if startedStatus, ok := started[status.Name]; ok {
+			status = startedStatus
+		}
+		result = append(result, status)
+	}
+	return result, nil
 }
 
 func buildContainerIOSets(podName string, containers []model.Container) (map[string]*runtime.IOSet, error) {
@@ -166,13 +1012,14 @@ func (s *Server) Delete(context context.Context, req *pods.DeletePodRequest) (*p
 		return nil, errors.Wrapf(err, "Cannot fetch pod containers, cannot delete pod [%s]", req.Name)
 	}
 
-	statuses := []model.ContainerStatus{}
+	ids := []string{}
 	for _, containerStatus := range pod.Status.ContainerStatuses {
-		status, err := s.client.StopContainer(req.Namespace, containerStatus.ContainerID)
-		if err != nil {
-			return nil, errors.Wrapf(err, "Error while stopping container [%s]", containerStatus.ContainerID)
-		}
-		statuses = append(statuses, status)
+		ids = append(ids, containerStatus.ContainerID)
+	}
+
+	statuses, err := s.client.StopContainers(req.Namespace, ids, pod.Spec.StopGracePeriod)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error while stopping pod [%s] containers", req.Name)
 	}
 
 	pod.Status.ContainerStatuses = statuses
@@ -182,9 +1029,28 @@ func (s *Server) Delete(context context.Context, req *pods.DeletePodRequest) (*p
 	}, nil
 }
 
+// DeleteNamespace is 'pods' service DeleteNamespace implementation
+func (s *Server) DeleteNamespace(context context.Context, req *pods.DeleteNamespaceRequest) (*pods.DeleteNamespaceResponse, error) {
+	statuses, err := s.client.DeleteNamespaceContainers(req.Namespace, req.RemoveNamespace)
+	if err != nil {
+		return nil, err
+	}
+	return &pods.DeleteNamespaceResponse{
+		ContainerStatuses: mapping.MapContainerStatusesToAPIModel(statuses),
+	}, nil
+}
+
 // List is 'pods' service List implementation
 func (s *Server) List(context context.Context, req *pods.ListPodsRequest) (*pods.ListPodsResponse, error) {
-	p, err := s.client.GetPods(req.Namespace)
+	var (
+		p   []model.Pod
+		err error
+	)
+	if req.AllNamespaces {
+		p, err = s.client.GetAllPods()
+	} else {
+		p, err = s.client.GetPods(req.Namespace)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -193,6 +1059,126 @@ func (s *Server) List(context context.Context, req *pods.ListPodsRequest) (*pods
 	}, nil
 }
 
+// ValidateManifest is 'pods' service ValidateManifest implementation
+func (s *Server) ValidateManifest(context context.Context, req *pods.ValidateManifestRequest) (*pods.ValidateManifestResponse, error) {
+	capacity := s.getNodeCapacity()
+	result := []*pods.ValidationError{}
+	for _, pod := range req.Pods {
+		if pod.GetMetadata() == nil || pod.GetSpec() == nil {
+			result = append(result, &pods.ValidationError{
+				Message: "Pod must have metadata and spec",
+			})
+			continue
+		}
+		result = append(result, s.validatePod(mapping.MapPodToInternalModel(pod), capacity)...)
+	}
+	return &pods.ValidateManifestResponse{Errors: result}, nil
+}
+
+// getValidPod maps the API pod to the internal model and validates it before anything gets done in the runtime
+func (s *Server) getValidPod(p *pods.Pod) (model.Pod, error) {
+	if p.GetMetadata() == nil || p.GetSpec() == nil {
+		return model.Pod{}, status.Error(codes.InvalidArgument, "Pod must have metadata and spec")
+	}
+	pod := mapping.MapPodToInternalModel(p)
+	return pod, s.checkValidPod(pod, s.getNodeCapacity())
+}
+
+// checkValidPod returns InvalidArgument error with the first validation error if the pod is invalid
+func (s *Server) checkValidPod(pod model.Pod, capacity nodeCapacity) error {
+	if errs := s.validatePod(pod, capacity); len(errs) > 0 {
+		return status.Errorf(codes.InvalidArgument, "Pod [%s] is invalid, %s: %s", pod.Metadata.Name, errs[0].Field, errs[0].Message)
+	}
+	return nil
+}
+
+// nodeCapacity is the CPU in millicores and memory in bytes what the node has, zero if unknown
+type nodeCapacity struct {
+	cpu    int64
+	memory int64
+}
+
+func (s *Server) getNodeCapacity() nodeCapacity {
+	capacity := nodeCapacity{cpu: int64(goruntime.NumCPU()) * 1000}
+	if s.resolver != nil {
+		capacity.memory = int64(s.resolver.GetStats().MemoryTotal)
+	}
+	return capacity
+}
+
+// validatePod runs the model validation and the checks what need the node state,
+// the same checks what creating the pod would fail on
+func (s *Server) validatePod(pod model.Pod, capacity nodeCapacity) (result []*pods.ValidationError) {
+	addError := func(field, format string, args ...interface{}) {
+		result = append(result, &pods.ValidationError{
+			Pod:     pod.Metadata.Name,
+			Field:   field,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if err := model.Validate([]model.Pod{pod}); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range errs {
+				addError(strings.TrimPrefix(e.Namespace(), "Pod."), "Failed on the '%s' validation", e.Tag())
+			}
+		} else {
+			addError("", "%s", err)
+		}
+	}
+
+	for _, name := range pod.Spec.ImagePullSecrets {
+		if _, err := s.getPullSecrets(pod.Metadata.Namespace, []string{name}); err != nil {
+			addError("Spec.ImagePullSecrets", "%s", err)
+		}
+	}
+
+	if pod.Spec.Resources != nil {
+		validateResources("Spec.Resources", *pod.Spec.Resources, capacity, addError)
+	}
+
+	names := map[string]bool{}
+	for i, container := range pod.Spec.Containers {
+		field := fmt.Sprintf("Spec.Containers[%d]", i)
+		if names[container.Name] {
+			addError(field+".Name", "Duplicate container name [%s]", container.Name)
+		}
+		names[container.Name] = true
+
+		if _, err := utils.NormalizeImageRef(container.Image, s.registry); container.Image != "" && err != nil {
+			addError(field+".Image", "Invalid image reference [%s]: %s", container.Image, err)
+		}
+
+		if container.Resources != nil {
+			validateResources(field+".Resources", *container.Resources, capacity, addError)
+		}
+
+		if pod.Spec.HostNetwork || container.HostNetwork {
+			for name := range container.Sysctls {
+				if model.IsNetworkSysctl(name) {
+					addError(field+".Sysctls", "Container runs in the host network namespace, sysctl [%s] would change the host settings", name)
+				}
+			}
+		}
+	}
+	return result
+}
+
+func validateResources(field string, resources model.Resources, capacity nodeCapacity, addError func(field, format string, args ...interface{})) {
+	if resources.MemoryLimit > 0 && resources.MemoryLimit < minMemoryLimit {
+		addError(field+".MemoryLimit", "Memory limit %d bytes is too small to start a container, minimum is %d bytes", resources.MemoryLimit, minMemoryLimit)
+	}
+	if resources.MemorySwapLimit != 0 && resources.MemoryLimit == 0 {
+		addError(field+".MemorySwapLimit", "Memory swap limit requires memory limit")
+	}
+	if capacity.memory > 0 && resources.MemoryLimit > capacity.memory {
+		addError(field+".MemoryLimit", "Memory limit %d bytes is more than the node total memory %d bytes", resources.MemoryLimit, capacity.memory)
+	}
+	if capacity.cpu > 0 && resources.CPULimit > capacity.cpu {
+		addError(field+".CPULimit", "CPU limit %d millicores is more than the node has, %d millicores", resources.CPULimit, capacity.cpu)
+	}
+}
+
 // Exec connects to process in container and streams stdout and stderr outputs to client
 func (s *Server) Exec(server containers.Containers_ExecServer) error {
 	md, ok := metadata.FromIncomingContext(server.Context())
@@ -221,7 +1207,7 @@ func (s *Server) Exec(server containers.Containers_ExecServer) error {
 		return fmt.Errorf("You must define 'args' metadata")
 	}
 
-	log.Debugf("Execute command [%s](tty: %s) in container [%s] in namespace [%s]", strings.Join(args, " "), tty, containerID, namespace)
+	log.Debugf("Execute command [%s](tty: %t) in container [%s] in namespace [%s]", strings.Join(args, " "), tty, containerID, namespace)
 	return s.client.Exec(
 		namespace,
 		containerID,
@@ -276,6 +1262,371 @@ func (s *Server) Signal(cxt context.Context, req *containers.SignalRequest) (*co
 	return &containers.SignalResponse{}, nil
 }
 
+// Import is 'images' service Import implementation, reads OCI image archive from the stream
+func (s *Server) Import(server images.Images_ImportServer) error {
+	md, ok := metadata.FromIncomingContext(server.Context())
+	if !ok {
+		return fmt.Errorf("Incoming Import request don't have metadata. You must provide 'namespace' through metadata")
+	}
+	namespace := getMetadataValue(md, "namespace")
+	if namespace == "" {
+		return fmt.Errorf("You must define 'namespace' metadata")
+	}
+
+	labels, err := parseLabels(md["labels"])
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Import image archive to namespace [%s]", namespace)
+	refs, err := s.client.ImportImage(namespace, stream.NewArchiveReader(server), labels)
+	if err != nil {
+		if runtime.IsInvalid(err) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return errors.Wrapf(err, "Failed to import image archive to namespace [%s]", namespace)
+	}
+	return server.SendAndClose(&images.ImportImageResponse{
+		Images: refs,
+	})
+}
+
+// Export is 'images' service Export implementation, streams image as OCI image archive
+func (s *Server) Export(req *images.ExportImageRequest, server images.Images_ExportServer) error {
+	log.Debugf("Export image [%s] from namespace [%s]", req.Ref, req.Namespace)
+	if err := s.client.ExportImage(req.Namespace, req.Ref, stream.NewArchiveWriter(server)); err != nil {
+		return errors.Wrapf(err, "Failed to export image [%s]", req.Ref)
+	}
+	return nil
+}
+
+// PrePull pulls and unpacks the images concurrently without creating containers
+// so later pod create doesn't need to wait the download
+func (s *Server) PrePull(context context.Context, req *images.PrePullRequest) (*images.PrePullResponse, error) {
+	var (
+		wg      sync.WaitGroup
+		results = make([]*images.PrePullResult, len(req.Refs))
+	)
+	for i, ref := range req.Refs {
+		image, err := utils.NormalizeImageRef(ref, s.registry)
+		if err != nil {
+			results[i] = &images.PrePullResult{Ref: ref, Error: err.Error()}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, image string) {
+			defer wg.Done()
+
+			result := &images.PrePullResult{Ref: image}
+			if err := s.pullImage(req.Namespace, image, req.ImagePullSecrets, req.Labels, progress.NewImageFetch("", image)); err != nil {
+				log.Warnf("Failed to pre-pull image [%s]: %s", image, err)
+				result.Error = err.Error()
+			}
+			results[i] = result
+		}(i, image)
+	}
+	wg.Wait()
+
+	return &images.PrePullResponse{Results: results}, nil
+}
+
+// CancelPull is 'images' service CancelPull implementation
+func (s *Server) CancelPull(context context.Context, req *images.CancelPullRequest) (*images.CancelPullResponse, error) {
+	image, err := utils.NormalizeImageRef(req.Ref, s.registry)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.client.CancelPull(req.Namespace, image); err != nil {
+		if runtime.IsNotFound(err) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+	return &images.CancelPullResponse{Ref: image}, nil
+}
+
+// Tag is 'images' service Tag implementation
+func (s *Server) Tag(context context.Context, req *images.TagImageRequest) (*images.TagImageResponse, error) {
+	ref, err := utils.NormalizeImageRef(req.Ref, s.registry)
+	if err != nil {
+		return nil, err
+	}
+	newRef, err := utils.NormalizeImageRef(req.NewRef, s.registry)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.client.TagImage(req.Namespace, ref, newRef); err != nil {
+		return nil, err
+	}
+	return &images.TagImageResponse{Ref: newRef}, nil
+}
+
+// ImageConfig is 'images' service ImageConfig implementation
+// The image gets pulled if it's not in the namespace so that the client doesn't need to pull it first
+func (s *Server) ImageConfig(context context.Context, req *images.ImageConfigRequest) (*images.ImageConfigResponse, error) {
+	ref, err := utils.NormalizeImageRef(req.Ref, s.registry)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := s.client.GetImageConfig(req.Namespace, ref)
+	if runtime.IsNotFound(err) {
+		if err := s.pullImage(req.Namespace, ref, req.ImagePullSecrets, nil, progress.NewImageFetch("", ref)); err != nil {
+			return nil, errors.Wrapf(err, "Failed to pull image [%s]", ref)
+		}
+		config, err = s.client.GetImageConfig(req.Namespace, ref)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &images.ImageConfigResponse{
+		Ref:    ref,
+		Config: mapping.MapImageConfigToAPIModel(config),
+	}, nil
+}
+
+// PutPullSecret is 'images' service PutPullSecret implementation
+func (s *Server) PutPullSecret(context context.Context, req *images.PutPullSecretRequest) (*images.PutPullSecretResponse, error) {
+	if s.secrets == nil {
+		return nil, errors.New("Pull secret store is not configured")
+	}
+	if req.Secret == nil {
+		return nil, errors.New("Pull secret is required")
+	}
+	if err := s.secrets.Put(model.ResolveNamespace(req.Namespace), mapping.MapPullSecretToInternalModel(req.Secret)); err != nil {
+		return nil, err
+	}
+	return &images.PutPullSecretResponse{}, nil
+}
+
+// DeletePullSecret is 'images' service DeletePullSecret implementation
+func (s *Server) DeletePullSecret(context context.Context, req *images.DeletePullSecretRequest) (*images.DeletePullSecretResponse, error) {
+	if s.secrets == nil {
+		return nil, errors.New("Pull secret store is not configured")
+	}
+	if err := s.secrets.Delete(model.ResolveNamespace(req.Namespace), req.Name); err != nil {
+		return nil, err
+	}
+	return &images.DeletePullSecretResponse{}, nil
+}
+
+// PutSecret is 'images' service PutSecret implementation
+func (s *Server) PutSecret(context context.Context, req *images.PutSecretRequest) (*images.PutSecretResponse, error) {
+	if s.secrets == nil {
+		return nil, errors.New("Secret store is not configured")
+	}
+	if err := s.secrets.PutSecret(model.ResolveNamespace(req.Namespace), model.Secret{Name: req.Name, Data: req.Data}); err != nil {
+		return nil, err
+	}
+	return &images.PutSecretResponse{}, nil
+}
+
+// DeleteSecret is 'images' service DeleteSecret implementation
+func (s *Server) DeleteSecret(context context.Context, req *images.DeleteSecretRequest) (*images.DeleteSecretResponse, error) {
+	if s.secrets == nil {
+		return nil, errors.New("Secret store is not configured")
+	}
+	if err := s.secrets.DeleteSecret(model.ResolveNamespace(req.Namespace), req.Name); err != nil {
+		return nil, err
+	}
+	return &images.DeleteSecretResponse{}, nil
+}
+
+// Statuses resolves multiple container task statuses in single call
+func (s *Server) Statuses(cxt context.Context, req *containers.ContainerStatusesRequest) (*containers.ContainerStatusesResponse, error) {
+	statuses, err := s.client.GetContainerTaskStatuses(req.Namespace, req.ContainerIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &containers.ContainerStatusesResponse{
+		Statuses: statuses,
+	}, nil
+}
+
+// Wait blocks until the container reaches the requested status
+func (s *Server) Wait(cxt context.Context, req *containers.WaitRequest) (*containers.WaitResponse, error) {
+	timeout := time.Duration(req.Timeout) * time.Millisecond
+	if err := s.client.WaitForStatus(req.Namespace, req.ContainerID, req.Status, timeout); err != nil {
+		return nil, err
+	}
+	return &containers.WaitResponse{}, nil
+}
+
+// Inspect returns the container details as stored in the runtime
+func (s *Server) Inspect(cxt context.Context, req *containers.InspectContainerRequest) (*containers.InspectContainerResponse, error) {
+	inspect, err := s.client.InspectContainer(req.Namespace, req.ContainerID)
+	if err != nil {
+		return nil, err
+	}
+	return mapping.MapContainerInspectToAPIModel(inspect), nil
+}
+
+// Diff lists the container filesystem changes compared to the image
+func (s *Server) Diff(cxt context.Context, req *containers.DiffContainerRequest) (*containers.DiffContainerResponse, error) {
+	changes, err := s.client.DiffContainer(req.Namespace, req.ContainerID)
+	if err != nil {
+		return nil, err
+	}
+	return &containers.DiffContainerResponse{
+		Changes: mapping.MapFileChangesToAPIModel(changes),
+	}, nil
+}
+
+// Commit creates new image from the container filesystem
+func (s *Server) Commit(cxt context.Context, req *containers.CommitContainerRequest) (*containers.CommitContainerResponse, error) {
+	ref, err := utils.NormalizeImageRef(req.Ref, s.registry)
+	if err != nil {
+		return nil, err
+	}
+	image, err := s.client.CommitContainer(req.Namespace, req.ContainerID, ref)
+	if err != nil {
+		if runtime.IsNotFound(err) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+	return &containers.CommitContainerResponse{Ref: image.Name, Digest: image.Digest}, nil
+}
+
+// Move is 'containers' service Move implementation
+func (s *Server) Move(cxt context.Context, req *containers.MoveContainerRequest) (*containers.MoveContainerResponse, error) {
+	if req.TargetNamespace == "" {
+		return nil, status.Error(codes.InvalidArgument, "Target namespace is required")
+	}
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
+	moved, err := s.client.MoveContainer(req.Namespace, req.ContainerID, req.TargetNamespace)
+	if err != nil {
+		switch {
+		case runtime.IsNotFound(err):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case runtime.IsAlreadyExists(err):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case runtime.IsInvalid(err):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+	return &containers.MoveContainerResponse{
+		Status: mapping.MapContainerStatusesToAPIModel([]model.ContainerStatus{moved})[0],
+	}, nil
+}
+
+// Top is 'containers' service Top implementation
+func (s *Server) Top(cxt context.Context, req *containers.TopRequest) (*containers.TopResponse, error) {
+	processes, err := s.client.ListProcesses(req.Namespace, req.ContainerID)
+	if err != nil {
+		return nil, err
+	}
+	return &containers.TopResponse{
+		Processes: mapping.MapProcessesToAPIModel(processes),
+	}, nil
+}
+
+// Logs is 'containers' service Logs implementation
+// The container output goes through the FIFOs only to the attached clients and doesn't get stored,
+// so there is nothing to download until eliotd persists the container logs
+func (s *Server) Logs(context context.Context, req *containers.LogsRequest) (*containers.LogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "Cannot download container [%s] logs, eliotd doesn't store the container output, use attach to follow it", req.ContainerID)
+}
+
+// ContainerStats is 'containers' service ContainerStats implementation
+// The metrics get sampled in the handler so the sampling stops when the client disconnects
+func (s *Server) ContainerStats(req *containers.ContainerStatsRequest, server containers.Containers_ContainerStatsServer) error {
+	interval := time.Duration(req.Interval) * time.Millisecond
+	if interval < minStatsInterval {
+		interval = minStatsInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var previous *model.ContainerMetrics
+	for {
+		current, err := s.client.GetContainerMetric(req.Namespace, req.ContainerID)
+		if err != nil {
+			return err
+		}
+
+		if err := server.Send(&containers.ContainerStatsResponse{
+			Stats: mapping.MapContainerStatsToAPIModel(computeContainerStats(previous, current)),
+		}); err != nil {
+			return errors.Wrap(err, "Failed to send container stats")
+		}
+		previous = &current
+
+		select {
+		case <-server.Context().Done():
+			log.Debugf("Client disconnected, stop streaming container [%s] stats", req.ContainerID)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// computeContainerStats computes the rates from the difference of the consecutive samples
+// The rates are zero for the first sample and when the counters got reset, e.g. the container restarted
+func computeContainerStats(previous *model.ContainerMetrics, current model.ContainerMetrics) model.ContainerStats {
+	stats := model.ContainerStats{
+		ContainerID:      current.ContainerID,
+		Time:             current.Timestamp,
+		MemoryUsage:      current.MemoryUsage,
+		MemoryLimit:      current.MemoryLimit,
+		NetworkAvailable: current.NetworkAvailable,
+		NetworkRxBytes:   current.NetworkRxBytes,
+		NetworkTxBytes:   current.NetworkTxBytes,
+	}
+	if previous == nil {
+		return stats
+	}
+
+	elapsed := current.Timestamp.Sub(previous.Timestamp)
+	if elapsed <= 0 {
+		return stats
+	}
+	if current.CPUUsage >= previous.CPUUsage {
+		stats.CPUPercent = float64(current.CPUUsage-previous.CPUUsage) / float64(elapsed.Nanoseconds()) * 100
+	}
+	stats.NetworkRxRate = counterRate(previous.NetworkRxBytes, current.NetworkRxBytes, elapsed)
+	stats.NetworkTxRate = counterRate(previous.NetworkTxBytes, current.NetworkTxBytes, elapsed)
+	return stats
+}
+
+// counterRate returns the per second rate of the counter, zero if the counter got reset
+func counterRate(previous, current uint64, elapsed time.Duration) float64 {
+	if current < previous {
+		return 0
+	}
+	return float64(current-previous) / elapsed.Seconds()
+}
+
+// ListImages is 'images' service ListImages implementation
+func (s *Server) ListImages(context context.Context, req *images.ListImagesRequest) (*images.ListImagesResponse, error) {
+	result, err := s.client.GetImages(req.Namespace, req.Labels)
+	if err != nil {
+		return nil, err
+	}
+	return &images.ListImagesResponse{
+		Images: mapping.MapImagesToAPIModel(result),
+	}, nil
+}
+
+// parseLabels parses labels in format key=value
+func parseLabels(values []string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("Invalid label [%s], must be in format key=value", value)
+		}
+		result[parts[0]] = parts[1]
+	}
+	return result, nil
+}
+
 func getMetadataValue(md metadata.MD, key string) string {
 	if val, ok := md[key]; ok {
 		return val[0]
@@ -284,24 +1635,50 @@ func getMetadataValue(md metadata.MD, key string) string {
 }
 
 // NewServer creates new API server
-func NewServer(listen string, client runtime.Client, resolver *resolver.Resolver) *Server {
+func NewServer(listen string, client runtime.Client, nodeResolver *resolver.Resolver, opts ...ServerOpts) *Server {
 	apiserver := &Server{
-		resolver: resolver,
+		resolver: nodeResolver,
 		client:   client,
 		listen:   listen,
+		pulls:    make(chan struct{}, maxConcurrentPulls),
+		reboot:   resolver.Reboot,
+		poweroff: resolver.Poweroff,
+	}
+
+	for _, opt := range opts {
+		opt(apiserver)
+	}
+	if apiserver.maintenance == nil {
+		apiserver.maintenance = controller.NewMaintenance()
+	}
+	apiserver.converger = controller.NewConverger(client, apiserver.history)
+	// Watching the connection keeps it up to date also without requests, so the health check notices when containerd goes down
+	client.OnConnectionChange(logHealthChange)
+
+	unaryInterceptor := grpc.UnaryServerInterceptor(unaryLoggingInterceptor)
+	streamInterceptor := grpc.StreamServerInterceptor(streamLoggingInterceptor)
+	if apiserver.auth != nil {
+		unaryInterceptor = chainUnaryInterceptors(unaryInterceptor, apiserver.auth.unaryInterceptor)
+		streamInterceptor = chainStreamInterceptors(streamInterceptor, apiserver.auth.streamInterceptor)
 	}
 
-	apiserver.grpc = grpc.NewServer()
+	apiserver.grpc = grpc.NewServer(append([]grpc.ServerOption{
+		grpc.UnaryInterceptor(unaryInterceptor),
+		grpc.StreamInterceptor(streamInterceptor),
+	}, apiserver.grpcOpts...)...)
 	pods.RegisterPodsServer(apiserver.grpc, apiserver)
+	pods.RegisterLegacyPodsServer(apiserver.grpc, apiserver)
 	containers.RegisterContainersServer(apiserver.grpc, apiserver)
 	node.RegisterNodeServer(apiserver.grpc, apiserver)
+	images.RegisterImagesServer(apiserver.grpc, apiserver)
+	health.RegisterHealthServer(apiserver.grpc, apiserver)
 	return apiserver
 }
 
 // Serve starts the server to serve GRPC server
 func (s *Server) Serve() {
 	log.Println("Start GRPC server...")
-	lis, err := net.Listen("tcp", s.listen)
+	lis, err := s.getListener()
 	if err != nil {
 		log.Panicf("Failed to start API server to listen [%s]: %s", s.listen, err)
 	}
@@ -311,6 +1688,28 @@ func (s *Server) Serve() {
 	}
 }
 
+// getListener returns new listener for each Serve call because the GRPC server closes the listener when
+// it stops, so the supervisor can restart Serve. The listener given with WithListener gets duplicated
+// so that it stays open for the next Serve
+func (s *Server) getListener() (net.Listener, error) {
+	if s.listener == nil {
+		return net.Listen("tcp", s.listen)
+	}
+
+	filer, ok := s.listener.(interface {
+		File() (*os.File, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Listener [%s] cannot be duplicated for serving", s.listener.Addr())
+	}
+	file, err := filer.File()
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to duplicate listener [%s]", s.listener.Addr())
+	}
+	defer file.Close()
+	return net.FileListener(file)
+}
+
 // Stop the GRPC server
 func (s *Server) Stop() {
 	log.Infof("Stop GRPC server...")
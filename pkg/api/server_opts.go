@@ -0,0 +1,148 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+
+	"github.com/ernoaapa/eliot/pkg/controller"
+	"github.com/ernoaapa/eliot/pkg/secrets"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// systemdListenFdsStart is the first file descriptor systemd passes to the activated process
+const systemdListenFdsStart = 3
+
+// WithListener makes the server to serve given listener instead of binding the listen address
+func WithListener(listener net.Listener) ServerOpts {
+	return func(server *Server) {
+		server.listener = listener
+	}
+}
+
+// WithDefaultRegistry sets the registry used for image references without registry hostname
+func WithDefaultRegistry(registry string) ServerOpts {
+	return func(server *Server) {
+		server.registry = registry
+	}
+}
+
+// WithPullSecrets sets the store where the pull secrets get stored and resolved from
+func WithPullSecrets(store *secrets.Store) ServerOpts {
+	return func(server *Server) {
+		server.secrets = store
+	}
+}
+
+// WithPowerControl allows rebooting and shutting down the node through the API
+func WithPowerControl() ServerOpts {
+	return func(server *Server) {
+		server.powerControl = true
+	}
+}
+
+// WithDebugEvents allows streaming the raw containerd events through the API
+func WithDebugEvents() ServerOpts {
+	return func(server *Server) {
+		server.debugEvents = true
+	}
+}
+
+// WithMaintenance sets the maintenance mode state what the server shares with the controllers,
+// the mode can be enabled and ended through the API
+func WithMaintenance(maintenance *controller.Maintenance) ServerOpts {
+	return func(server *Server) {
+		server.maintenance = maintenance
+	}
+}
+
+// WithReconcilePause allows pausing and resuming the controllers through the API
+func WithReconcilePause(pause *controller.ReconcilePause) ServerOpts {
+	return func(server *Server) {
+		server.pause = pause
+	}
+}
+
+// WithReadiness makes the readiness health check report the lifecycle controller convergence
+func WithReadiness(readiness *controller.Readiness) ServerOpts {
+	return func(server *Server) {
+		server.readiness = readiness
+	}
+}
+
+// WithKeepalive configures how the server pings idle clients and how often clients are allowed to ping,
+// so broken connections get detected and NAT mappings of long-lived streams stay open
+func WithKeepalive(params keepalive.ServerParameters, policy keepalive.EnforcementPolicy) ServerOpts {
+	return func(server *Server) {
+		server.grpcOpts = append(server.grpcOpts,
+			grpc.KeepaliveParams(params),
+			grpc.KeepaliveEnforcementPolicy(policy),
+		)
+	}
+}
+
+// SystemdListener returns the socket passed by the systemd socket activation
+// or nil if the process is not socket activated
+func SystemdListener() (net.Listener, error) {
+	count, err := systemdListenFds(os.Getenv("LISTEN_PID"), os.Getenv("LISTEN_FDS"), os.Getpid())
+	if err != nil || count == 0 {
+		return nil, err
+	}
+	if count > 1 {
+		return nil, fmt.Errorf("Received %d sockets from systemd, only one is supported", count)
+	}
+
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	syscall.CloseOnExec(systemdListenFdsStart)
+	file := os.NewFile(uintptr(systemdListenFdsStart), "LISTEN_FD_3")
+	defer file.Close()
+
+	listener, err := net.FileListener(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to use socket passed by systemd")
+	}
+	return listener, nil
+}
+
+// systemdListenFds resolves the number of sockets passed to the process with pid
+func systemdListenFds(listenPid, listenFds string, pid int) (int, error) {
+	if listenPid == "" || listenFds == "" {
+		return 0, nil
+	}
+
+	targetPid, err := strconv.Atoi(listenPid)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Invalid LISTEN_PID value [%s]", listenPid)
+	}
+	if targetPid != pid {
+		return 0, nil
+	}
+
+	count, err := strconv.Atoi(listenFds)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Invalid LISTEN_FDS value [%s]", listenFds)
+	}
+	return count, nil
+}
+
+// WithReconcileHistory allows fetching the lifecycle controller actions through the API
+func WithReconcileHistory(history *controller.ReconcileHistory) ServerOpts {
+	return func(server *Server) {
+		server.history = history
+	}
+}
+
+// WithAuthentication requires every call to have token what the authenticator permits
+// to access the requested namespaces
+func WithAuthentication(auth *Authenticator) ServerOpts {
+	return func(server *Server) {
+		server.auth = auth
+	}
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/keepalive"
+)
+
+func TestSystemdListenFds(t *testing.T) {
+	count, err := systemdListenFds("123", "1", 123)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	count, err = systemdListenFds("", "", 123)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count, "should return zero if not socket activated")
+
+	count, err = systemdListenFds("456", "1", 123)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count, "should ignore sockets passed to other process")
+
+	_, err = systemdListenFds("123", "foo", 123)
+	assert.Error(t, err)
+}
+
+func TestWithKeepalive(t *testing.T) {
+	server := &Server{}
+	WithKeepalive(keepalive.ServerParameters{Time: time.Minute}, keepalive.EnforcementPolicy{MinTime: 10 * time.Second})(server)
+	assert.Len(t, server.grpcOpts, 2, "should add keepalive params and enforcement policy")
+}
@@ -1,9 +1,33 @@
 package api
 
 import (
+	gocontext "context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	core "github.com/ernoaapa/eliot/pkg/api/core"
+	containers "github.com/ernoaapa/eliot/pkg/api/services/containers/v1"
+	images "github.com/ernoaapa/eliot/pkg/api/services/images/v1"
+	node "github.com/ernoaapa/eliot/pkg/api/services/node/v1"
+	pods "github.com/ernoaapa/eliot/pkg/api/services/pods/v1"
+	"github.com/ernoaapa/eliot/pkg/config"
+	"github.com/ernoaapa/eliot/pkg/controller"
+	"github.com/ernoaapa/eliot/pkg/model"
+	resolver "github.com/ernoaapa/eliot/pkg/node"
+	"github.com/ernoaapa/eliot/pkg/progress"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	"github.com/ernoaapa/eliot/pkg/secrets"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 func TestGetMetadataValue(t *testing.T) {
@@ -21,3 +45,1201 @@ func TestGetMetadataValue(t *testing.T) {
 	assert.Equal(t, "first", getMetadataValue(md, "crazy"))
 	assert.Equal(t, "", getMetadataValue(md, "dontexist"))
 }
+
+type fakePowerClient struct {
+	runtime.Client
+	mutex      sync.Mutex
+	pods       []model.Pod
+	terminated map[string]time.Duration
+}
+
+func (c *fakePowerClient) GetAllPods() ([]model.Pod, error) {
+	return c.pods, nil
+}
+
+func (c *fakePowerClient) TerminateContainers(namespace string, ids []string, gracePeriod time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, id := range ids {
+		c.terminated[id] = gracePeriod
+	}
+	return nil
+}
+
+func newPowerTestPod(name string, gracePeriod time.Duration, ids ...string) model.Pod {
+	pod := model.Pod{
+		Metadata: model.NewMetadata("default", name),
+		Spec:     model.PodSpec{StopGracePeriod: gracePeriod},
+	}
+	for _, id := range ids {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, model.ContainerStatus{ContainerID: id})
+	}
+	return pod
+}
+
+func TestRebootRequiresPowerControl(t *testing.T) {
+	client := &fakePowerClient{terminated: map[string]time.Duration{}}
+	server := &Server{client: client}
+
+	_, err := server.Reboot(nil, &node.RebootRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Empty(t, client.terminated, "should not stop containers")
+}
+
+func TestRebootStopsContainersFirst(t *testing.T) {
+	var (
+		rebooted = make(chan struct{})
+		client   = &fakePowerClient{
+			pods: []model.Pod{
+				newPowerTestPod("foo", 30*time.Second, "foo-1", "foo-2"),
+				newPowerTestPod("bar", 0, "bar-1"),
+			},
+			terminated: map[string]time.Duration{},
+		}
+		server = &Server{client: client}
+	)
+	WithPowerControl()(server)
+	server.reboot = func() error {
+		assert.Len(t, client.terminated, 3, "should stop containers before reboot")
+		close(rebooted)
+		return nil
+	}
+
+	resp, err := server.Reboot(nil, &node.RebootRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), resp.StoppedContainers)
+	assert.Equal(t, 30*time.Second, client.terminated["foo-1"], "should use the pod stop grace period")
+
+	select {
+	case <-rebooted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("node was not rebooted")
+	}
+}
+
+func TestRebootPausesControllersUntilPowerAction(t *testing.T) {
+	var (
+		failed = make(chan struct{})
+		client = &fakePowerClient{
+			pods:       []model.Pod{newPowerTestPod("foo", 0, "foo-1")},
+			terminated: map[string]time.Duration{},
+		}
+		server = &Server{client: client, pause: controller.NewReconcilePause(time.Hour)}
+	)
+	WithPowerControl()(server)
+	server.reboot = func() error {
+		assert.True(t, server.pause.IsPaused(), "should keep controllers paused until the reboot")
+		close(failed)
+		return errors.New("reboot not permitted")
+	}
+
+	_, err := server.Reboot(nil, &node.RebootRequest{})
+	assert.NoError(t, err)
+	assert.True(t, server.pause.IsPaused(), "should pause controllers during the drain")
+
+	select {
+	case <-failed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reboot was not executed")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for server.pause.IsPaused() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.False(t, server.pause.IsPaused(), "should resume controllers if the reboot fails")
+}
+
+func TestPoweroffGracePeriodOverridesPodGracePeriod(t *testing.T) {
+	client := &fakePowerClient{
+		pods:       []model.Pod{newPowerTestPod("foo", 30*time.Second, "foo-1")},
+		terminated: map[string]time.Duration{},
+	}
+	server := &Server{client: client, poweroff: func() error { return nil }}
+	WithPowerControl()(server)
+
+	_, err := server.Poweroff(nil, &node.PoweroffRequest{GracePeriodSeconds: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, client.terminated["foo-1"])
+}
+
+type fakeTagClient struct {
+	runtime.Client
+	tagged map[string]string
+}
+
+func (c *fakeTagClient) TagImage(namespace, ref, newRef string) error {
+	c.tagged[newRef] = ref
+	return nil
+}
+
+func TestTagNormalizesImageRefs(t *testing.T) {
+	client := &fakeTagClient{tagged: map[string]string{}}
+	server := &Server{client: client}
+
+	resp, err := server.Tag(nil, &images.TagImageRequest{
+		Ref:    "nginx@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+		NewRef: "myapp:stable",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "docker.io/library/myapp:stable", resp.Ref)
+	assert.Equal(t, "docker.io/library/nginx@sha256:0000000000000000000000000000000000000000000000000000000000000000", client.tagged["docker.io/library/myapp:stable"])
+
+	_, err = server.Tag(nil, &images.TagImageRequest{Ref: "nginx", NewRef: "My App"})
+	assert.Error(t, err, "should reject invalid new reference")
+}
+
+type fakeImageConfigClient struct {
+	runtime.Client
+	pulled []string
+}
+
+func (c *fakeImageConfigClient) GetImageConfig(namespace, ref string) (model.ImageConfig, error) {
+	if len(c.pulled) == 0 {
+		return model.ImageConfig{}, runtime.ErrWithMessagef(runtime.ErrNotFound, "Image [%s] not found", ref)
+	}
+	return model.ImageConfig{ExposedPorts: []string{"80/tcp"}}, nil
+}
+
+func (c *fakeImageConfigClient) PullImage(namespace, ref string, secrets []model.PullSecret, labels map[string]string, progress *progress.ImageFetch) error {
+	c.pulled = append(c.pulled, ref)
+	return nil
+}
+
+func TestImageConfigPullsMissingImage(t *testing.T) {
+	client := &fakeImageConfigClient{}
+	server := &Server{client: client, pulls: make(chan struct{}, 1)}
+
+	resp, err := server.ImageConfig(nil, &images.ImageConfigRequest{Ref: "nginx"})
+	assert.NoError(t, err)
+	assert.Equal(t, "docker.io/library/nginx:latest", resp.Ref)
+	assert.Equal(t, []string{"80/tcp"}, resp.Config.ExposedPorts)
+	assert.Equal(t, []string{"docker.io/library/nginx:latest"}, client.pulled)
+
+	_, err = server.ImageConfig(nil, &images.ImageConfigRequest{Ref: "nginx"})
+	assert.NoError(t, err)
+	assert.Len(t, client.pulled, 1, "should use the image what is already pulled")
+}
+
+func TestPauseReconcileRequiresLifecycleController(t *testing.T) {
+	server := &Server{}
+
+	_, err := server.PauseReconcile(nil, &node.PauseReconcileRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestPauseAndResumeReconcile(t *testing.T) {
+	pause := controller.NewReconcilePause(time.Hour)
+	server := &Server{pause: pause}
+
+	resp, err := server.PauseReconcile(nil, &node.PauseReconcileRequest{TimeoutSeconds: 60})
+	assert.NoError(t, err)
+	assert.InDelta(t, time.Now().Add(time.Minute).Unix(), resp.ResumeAt, 1)
+	assert.True(t, pause.IsPaused())
+
+	_, err = server.ResumeReconcile(nil, &node.ResumeReconcileRequest{})
+	assert.NoError(t, err)
+	assert.False(t, pause.IsPaused())
+}
+
+func TestReconcileHistory(t *testing.T) {
+	_, err := (&Server{}).ReconcileHistory(nil, &node.ReconcileHistoryRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "should require lifecycle controller")
+
+	history := controller.NewReconcileHistory(10)
+	history.Add(model.ReconcileRecord{Time: time.Unix(100, 0), Namespace: "default", ContainerID: "foo", Action: model.ReconcileFailed, Error: "boom"})
+	server := &Server{history: history}
+
+	resp, err := server.ReconcileHistory(nil, &node.ReconcileHistoryRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*node.ReconcileRecord{
+		{Time: 100, Namespace: "default", ContainerID: "foo", Action: "failed", Error: "boom"},
+	}, resp.Records)
+}
+
+type fakeSummaryClient struct {
+	runtime.Client
+	watched bool
+}
+
+func (c *fakeSummaryClient) OnConnectionChange(listener runtime.ConnectionListener) {
+	c.watched = true
+}
+
+func (c *fakeSummaryClient) GetNamespaces() ([]string, error) {
+	return []string{"default", "other"}, nil
+}
+
+func (c *fakeSummaryClient) GetPods(namespace string) ([]model.Pod, error) {
+	if namespace != "default" {
+		return []model.Pod{}, nil
+	}
+	return []model.Pod{
+		{Spec: model.PodSpec{Containers: []model.Container{{Name: "foo"}, {Name: "bar"}}}},
+	}, nil
+}
+
+func (c *fakeSummaryClient) GetContainerMetrics(namespace string) ([]model.ContainerMetrics, error) {
+	if namespace != "default" {
+		return nil, nil
+	}
+	return []model.ContainerMetrics{
+		{ContainerID: "foo", CPUUsage: 100, MemoryUsage: 1024},
+		{ContainerID: "bar", CPUUsage: 50, MemoryUsage: 2048},
+	}, nil
+}
+
+func (c *fakeSummaryClient) GetDiskUsage(namespace string) (model.DiskUsage, error) {
+	if namespace != "default" {
+		return model.DiskUsage{ContentSize: 10}, nil
+	}
+	return model.DiskUsage{
+		ContentSize: 1000,
+		Containers:  []model.ContainerDiskUsage{{Size: 20}, {Size: 30}},
+	}, nil
+}
+
+func TestResourceSummary(t *testing.T) {
+	server := &Server{client: &fakeSummaryClient{}}
+
+	resp, err := server.ResourceSummary(nil, &node.ResourceSummaryRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*node.NamespaceSummary{
+		{Namespace: "default", Containers: 2, RunningContainers: 2, CpuUsage: 150, MemoryUsage: 3072, ImageSize: 1000, SnapshotSize: 50},
+		{Namespace: "other", ImageSize: 10},
+	}, resp.Namespaces)
+}
+
+func (c *fakeSummaryClient) GetAllPods() ([]model.Pod, error) {
+	return c.GetPods("default")
+}
+
+func (c *fakeSummaryClient) GetRuntimeInfo() (model.RuntimeInfo, error) {
+	return model.RuntimeInfo{ContainerdVersion: "v1.1.0", Snapshotter: "overlayfs", Snapshotters: []string{"native", "overlayfs"}, Features: []string{model.FeatureInit}}, nil
+}
+
+func TestDescribeDevice(t *testing.T) {
+	server := &Server{
+		client:   &fakeSummaryClient{},
+		resolver: resolver.NewResolver(5000, "test", map[string]string{"location": "helsinki"}, map[string]string{"region": "eu"}),
+	}
+
+	resp, err := server.DescribeDevice(nil, &node.DescribeDeviceRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5000), resp.Info.GrpcPort)
+	assert.Contains(t, resp.Info.Labels, &node.Label{Key: "location", Value: "helsinki"})
+	assert.Equal(t, []*node.Label{{Key: "region", Value: "eu"}}, resp.Info.Groups)
+	assert.Equal(t, &node.RuntimeInfo{ContainerdVersion: "v1.1.0", Snapshotter: "overlayfs", Snapshotters: []string{"native", "overlayfs"}}, resp.Runtime)
+	assert.Len(t, resp.Namespaces, 2)
+	assert.Len(t, resp.Pods, 1)
+}
+
+func TestSetLabels(t *testing.T) {
+	server := &Server{resolver: resolver.NewResolver(5000, "test", map[string]string{"location": "unknown"}, map[string]string{})}
+
+	resp, err := server.SetLabels(nil, &node.SetLabelsRequest{Labels: []*node.Label{{Key: "location", Value: "helsinki"}}})
+	assert.NoError(t, err)
+	assert.Contains(t, resp.Labels, &node.Label{Key: "location", Value: "helsinki"})
+
+	_, err = server.SetLabels(nil, &node.SetLabelsRequest{Remove: []string{"eliot.io/arch"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+type fakeWatchLabelsStream struct {
+	node.Node_WatchLabelsServer
+	ctx      gocontext.Context
+	received chan []*node.Label
+}
+
+func (s *fakeWatchLabelsStream) Context() gocontext.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchLabelsStream) Send(resp *node.WatchLabelsResponse) error {
+	s.received <- resp.Labels
+	return nil
+}
+
+func TestWatchLabels(t *testing.T) {
+	server := &Server{resolver: resolver.NewResolver(5000, "test", map[string]string{"location": "unknown"}, map[string]string{})}
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	stream := &fakeWatchLabelsStream{ctx: ctx, received: make(chan []*node.Label)}
+
+	done := make(chan error)
+	go func() {
+		done <- server.WatchLabels(&node.WatchLabelsRequest{}, stream)
+	}()
+
+	assert.Contains(t, <-stream.received, &node.Label{Key: "location", Value: "unknown"}, "should send the current labels first")
+
+	_, err := server.resolver.UpdateLabels(map[string]string{"location": "helsinki"}, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, <-stream.received, &node.Label{Key: "location", Value: "helsinki"}, "should send the changed labels")
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestCapabilities(t *testing.T) {
+	server := NewServer("localhost:5000", &fakeSummaryClient{}, nil, WithPowerControl())
+
+	resp, err := server.Capabilities(nil, &node.CapabilitiesRequest{})
+	assert.NoError(t, err)
+	assert.Contains(t, resp.Methods, "/eliot.services.pods.v1.Pods/Run")
+	assert.Contains(t, resp.Methods, "/eliot.services.containers.v1.Node/Capabilities")
+	assert.Equal(t, []string{model.FeatureInit, model.FeaturePowerControl}, resp.Features)
+	assert.Equal(t, []string{"native", "overlayfs"}, resp.Snapshotters)
+}
+
+func TestNewServerWatchesContainerdConnection(t *testing.T) {
+	client := &fakeSummaryClient{}
+	NewServer("localhost:5000", client, nil)
+	assert.True(t, client.watched, "should watch the connection for the health check")
+}
+
+func TestServeAgainAfterListenerClosed(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	server := &Server{listener: listener}
+
+	first, err := server.getListener()
+	assert.NoError(t, err)
+	assert.NoError(t, first.Close(), "GRPC server closes the listener when it stops")
+
+	second, err := server.getListener()
+	assert.NoError(t, err, "should serve the given listener again after restart")
+	defer second.Close()
+	assert.Equal(t, listener.Addr().String(), second.Addr().String())
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	assert.NoError(t, err, "should accept connections after the first listener closed")
+	conn.Close()
+}
+
+func TestServeLegacyPodsServiceName(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := NewServer("", &fakeSummaryClient{}, nil, WithListener(listener))
+	go server.Serve()
+	defer server.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	resp := &pods.ListPodsResponse{}
+	err = grpc.Invoke(gocontext.Background(), "/cand.services.pods.v1.Pods/List", &pods.ListPodsRequest{Namespace: "default"}, resp, conn)
+	assert.NoError(t, err, "should serve the clients what use the name before the package rename")
+	assert.Len(t, resp.Pods, 1)
+
+	methods, err := server.Capabilities(nil, &node.CapabilitiesRequest{})
+	assert.NoError(t, err)
+	assert.NotContains(t, methods.Methods, "/cand.services.pods.v1.Pods/List", "should not report the legacy alias")
+}
+
+func TestLogsIsUnimplemented(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := NewServer("", &fakeSummaryClient{}, nil, WithListener(listener))
+	go server.Serve()
+	defer server.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	_, err = containers.NewContainersClient(conn).Logs(gocontext.Background(), &containers.LogsRequest{Namespace: "default", ContainerID: "foo"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	methods, err := server.Capabilities(nil, &node.CapabilitiesRequest{})
+	assert.NoError(t, err)
+	assert.NotContains(t, methods.Methods, "/eliot.services.containers.v1.Containers/Logs", "should not report the unimplemented method")
+	assert.Contains(t, methods.Methods, "/eliot.services.containers.v1.Containers/Top")
+}
+
+func (c *fakeSummaryClient) ExportPods(namespace string) ([]model.Pod, error) {
+	return []model.Pod{
+		{
+			Metadata: model.NewMetadata(namespace, "my-pod"),
+			Spec:     model.PodSpec{Containers: []model.Container{{Name: "foo", Image: "docker.io/library/alpine:latest"}}},
+			Status:   model.PodStatus{Hostname: "test"},
+		},
+	}, nil
+}
+
+func TestExportState(t *testing.T) {
+	server := &Server{client: &fakeSummaryClient{}}
+
+	resp, err := server.ExportState(nil, &node.ExportStateRequest{Namespace: "default"})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Pods, 1)
+	assert.Equal(t, "default", resp.Pods[0].Metadata.Namespace)
+	assert.Equal(t, "docker.io/library/alpine:latest", resp.Pods[0].Spec.Containers[0].Image)
+	assert.Nil(t, resp.Pods[0].Status)
+
+	resp, err = server.ExportState(nil, &node.ExportStateRequest{AllNamespaces: true})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Pods, 2)
+	assert.Equal(t, "other", resp.Pods[1].Metadata.Namespace)
+}
+
+func TestValidateManifest(t *testing.T) {
+	server := &Server{}
+	capacity := nodeCapacity{cpu: 4000, memory: 1024 * 1024 * 1024}
+
+	errs := server.validatePod(model.Pod{
+		Metadata: model.NewMetadata("default", "foo"),
+		Spec: model.PodSpec{
+			HostNetwork:      true,
+			ImagePullSecrets: []string{"registry"},
+			Resources:        &model.Resources{CPULimit: 8000},
+			Containers: []model.Container{
+				{Name: "bar", Image: "docker.io/library/nginx", Resources: &model.Resources{MemoryLimit: 1024}},
+				{Name: "bar", Image: "docker.io/library/nginx", Sysctls: map[string]string{"net.core.somaxconn": "1024"}},
+			},
+		},
+	}, capacity)
+
+	fields := []string{}
+	for _, e := range errs {
+		assert.Equal(t, "foo", e.Pod)
+		fields = append(fields, e.Field)
+	}
+	assert.Equal(t, []string{
+		"Spec.ImagePullSecrets",
+		"Spec.Resources.CPULimit",
+		"Spec.Containers[0].Resources.MemoryLimit",
+		"Spec.Containers[1].Name",
+		"Spec.Containers[1].Sysctls",
+	}, fields)
+}
+
+func TestValidateManifestReportsModelErrors(t *testing.T) {
+	server := &Server{}
+
+	errs := server.validatePod(model.Pod{
+		Metadata: model.NewMetadata("default", "foo"),
+		Spec: model.PodSpec{
+			Containers: []model.Container{{Name: "bar"}},
+		},
+	}, nodeCapacity{})
+
+	assert.Len(t, errs, 1, "should report the missing image only once")
+	assert.Equal(t, "Spec.Containers[0].Image", errs[0].Field)
+}
+
+func TestValidateManifestRequiresMetadata(t *testing.T) {
+	server := &Server{}
+
+	resp, err := server.ValidateManifest(nil, &pods.ValidateManifestRequest{
+		Pods: []*pods.Pod{{}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Errors, 1)
+}
+
+func TestApplyRejectsInvalidPods(t *testing.T) {
+	server := &Server{}
+	newPod := func(namespace, name string) *pods.Pod {
+		return &pods.Pod{
+			Metadata: &core.ResourceMetadata{Namespace: namespace, Name: name},
+			Spec:     &pods.PodSpec{Containers: []*containers.Container{{Name: "app", Image: "nginx"}}},
+		}
+	}
+
+	_, err := server.Apply(nil, &pods.ApplyPodsRequest{Namespace: "tenant-a", Pods: []*pods.Pod{newPod("tenant-b", "foo")}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "should reject pod in another namespace")
+
+	_, err = server.Apply(nil, &pods.ApplyPodsRequest{Namespace: "tenant-a", Pods: []*pods.Pod{newPod("", "foo"), newPod("", "foo")}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "should reject duplicate pods")
+}
+
+func TestParseLabels(t *testing.T) {
+	labels, err := parseLabels([]string{"batch=2018-06", "approved-by=security=team"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"batch": "2018-06", "approved-by": "security=team"}, labels)
+
+	_, err = parseLabels([]string{"batch"})
+	assert.Error(t, err, "should require value")
+
+	_, err = parseLabels([]string{"=foo"})
+	assert.Error(t, err, "should require key")
+}
+
+func TestComputeContainerStats(t *testing.T) {
+	start := time.Now()
+	first := model.ContainerMetrics{ContainerID: "foo", Timestamp: start, CPUUsage: 1000000000, MemoryUsage: 4096, NetworkRxBytes: 1000}
+	second := model.ContainerMetrics{ContainerID: "foo", Timestamp: start.Add(2 * time.Second), CPUUsage: 1500000000, MemoryUsage: 8192, NetworkRxBytes: 3000}
+
+	stats := computeContainerStats(nil, first)
+	assert.Equal(t, 0.0, stats.CPUPercent, "should not have rates in the first sample")
+	assert.Equal(t, uint64(4096), stats.MemoryUsage)
+
+	stats = computeContainerStats(&first, second)
+	assert.InDelta(t, 25.0, stats.CPUPercent, 0.001, "should compute CPU usage between the samples")
+	assert.InDelta(t, 1000.0, stats.NetworkRxRate, 0.001)
+	assert.Equal(t, uint64(8192), stats.MemoryUsage)
+
+	stats = computeContainerStats(&second, first)
+	assert.Equal(t, 0.0, stats.CPUPercent, "should not compute rates when the counters got reset")
+}
+
+type fakeCreateClient struct {
+	runtime.Client
+	removed   []string
+	full      bool
+	quotaFull bool
+	pulled    bool
+}
+
+func (c *fakeCreateClient) CheckContainerLimit(count int) error {
+	if c.full {
+		return runtime.ErrWithMessagef(runtime.ErrLimitExceeded, "Cannot create %d container(s)", count)
+	}
+	return nil
+}
+
+func (c *fakeCreateClient) CheckNamespaceQuota(pod model.Pod, released []string) error {
+	if c.quotaFull {
+		return runtime.ErrWithMessagef(runtime.ErrLimitExceeded, "Namespace [%s] quota exceeded", pod.Metadata.Namespace)
+	}
+	return nil
+}
+
+func (c *fakeCreateClient) GetPod(namespace, name string) (model.Pod, error) {
+	return model.Pod{}, runtime.ErrNotFound
+}
+
+func (c *fakeCreateClient) PullImage(namespace, ref string, secrets []model.PullSecret, labels map[string]string, status *progress.ImageFetch) error {
+	c.pulled = true
+	return nil
+}
+
+func (c *fakeCreateClient) CreateContainer(pod model.Pod, container model.Container) (model.ContainerStatus, error) {
+	if container.Name == "broken" {
+		return model.ContainerStatus{}, errors.New("failed")
+	}
+	return model.ContainerStatus{ContainerID: container.Name + "-id", Name: container.Name}, nil
+}
+
+func (c *fakeCreateClient) StopContainers(namespace string, ids []string, gracePeriod time.Duration) ([]model.ContainerStatus, error) {
+	c.removed = append(c.removed, ids...)
+	return nil, nil
+}
+
+func (c *fakeCreateClient) RunContainer(pod model.Pod, container model.Container, timeout time.Duration) (model.RunResult, error) {
+	if container.Name == "broken" {
+		return model.RunResult{}, errors.New("failed")
+	}
+	return model.RunResult{Name: container.Name, ExitCode: 3, Stdout: "hello\n"}, nil
+}
+
+func (c *fakeCreateClient) StartContainerWithProgress(namespace, id string, io runtime.IOSet, phase func(string)) (model.ContainerStatus, error) {
+	phase(progress.PhaseStarting)
+	return model.ContainerStatus{ContainerID: id, Name: strings.TrimSuffix(id, "-id"), State: "running"}, nil
+}
+
+func (c *fakeCreateClient) OnConnectionChange(listener runtime.ConnectionListener) {}
+
+type fakeCreateStream struct {
+	pods.Pods_CreateServer
+}
+
+func (s *fakeCreateStream) Send(*pods.CreatePodStreamResponse) error {
+	return nil
+}
+
+func newCreateRequest(start bool) *pods.CreatePodRequest {
+	return &pods.CreatePodRequest{
+		Start: start,
+		Pod: &pods.Pod{
+			Metadata: &core.ResourceMetadata{Name: "foo", Namespace: "default"},
+			Spec: &pods.PodSpec{Containers: []*containers.Container{
+				{Name: "first", Image: "docker.io/library/alpine"},
+				{Name: "broken", Image: "docker.io/library/alpine"},
+			}},
+		},
+	}
+}
+
+func TestCreateAndStartRemovesCreatedContainersOnFailure(t *testing.T) {
+	client := &fakeCreateClient{}
+	server := &Server{client: client, pulls: make(chan struct{}, maxConcurrentPulls)}
+
+	err := server.Create(newCreateRequest(true), &fakeCreateStream{})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"first-id"}, client.removed, "should remove the created containers")
+}
+
+func TestCreateKeepsCreatedContainersOnFailure(t *testing.T) {
+	client := &fakeCreateClient{}
+	server := &Server{client: client, pulls: make(chan struct{}, maxConcurrentPulls)}
+
+	err := server.Create(newCreateRequest(false), &fakeCreateStream{})
+	assert.Error(t, err)
+	assert.Empty(t, client.removed, "should not remove containers without start")
+}
+
+func TestCreateSendsLastStatusesAndPhasesBeforeReturning(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := NewServer("", &fakeCreateClient{}, nil, WithListener(listener))
+	go server.Serve()
+	defer server.Stop()
+
+	images := make(chan []*progress.ImageFetch)
+	phases := make(chan []progress.Phase)
+	last := map[string]string{}
+	received := make(chan struct{})
+	go func() {
+		defer close(received)
+		for {
+			select {
+			case <-images:
+			case changes, ok := <-phases:
+				if !ok {
+					return
+				}
+				for _, change := range changes {
+					last[change.Container] = change.Phase
+				}
+			}
+		}
+	}()
+
+	req := newCreateRequest(true)
+	req.Pod.Spec.Containers[1].Name = "second"
+	client := NewClient("default", config.Endpoint{URL: listener.Addr().String()})
+	pod, err := client.CreateAndStartPodWithPhases(images, phases, req.Pod)
+	close(phases)
+	<-received
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	if assert.Len(t, pod.Status.ContainerStatuses, 2, "should receive the statuses in the last message") {
+		assert.Equal(t, "first-id", pod.Status.ContainerStatuses[0].ContainerID)
+	}
+	assert.Equal(t, map[string]string{"first": progress.PhaseRunning, "second": progress.PhaseRunning}, last, "should end the stream with the running phases")
+}
+
+func TestCreateRejectsWhenContainerLimitReached(t *testing.T) {
+	client := &fakeCreateClient{full: true}
+	server := &Server{client: client, pulls: make(chan struct{}, maxConcurrentPulls)}
+
+	err := server.Create(newCreateRequest(true), &fakeCreateStream{})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.False(t, client.pulled, "should not pull images when the limit is reached")
+}
+
+func TestCreateRejectsWhenNamespaceQuotaExceeded(t *testing.T) {
+	client := &fakeCreateClient{quotaFull: true}
+	server := &Server{client: client, pulls: make(chan struct{}, maxConcurrentPulls)}
+
+	err := server.Create(newCreateRequest(true), &fakeCreateStream{})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "Namespace [default] quota exceeded")
+	assert.False(t, client.pulled, "should not pull images when the quota is exceeded")
+}
+
+func TestRunReturnsResultsInContainerOrder(t *testing.T) {
+	client := &fakeCreateClient{}
+	server := &Server{client: client, pulls: make(chan struct{}, maxConcurrentPulls)}
+	req := newCreateRequest(false)
+	req.Pod.Spec.Containers[1].Name = "second"
+
+	resp, err := server.Run(nil, &pods.RunPodRequest{Pod: req.Pod, TimeoutSeconds: 10})
+	assert.NoError(t, err)
+	assert.True(t, client.pulled)
+	assert.Len(t, resp.Results, 2)
+	assert.Equal(t, "first", resp.Results[0].Name)
+	assert.Equal(t, "second", resp.Results[1].Name)
+	assert.Equal(t, int32(3), resp.Results[0].ExitCode)
+	assert.Equal(t, []byte("hello\n"), resp.Results[0].Stdout)
+}
+
+func TestRunFailsIfContainerFails(t *testing.T) {
+	server := &Server{client: &fakeCreateClient{}, pulls: make(chan struct{}, maxConcurrentPulls)}
+
+	_, err := server.Run(nil, &pods.RunPodRequest{Pod: newCreateRequest(false).Pod})
+	assert.Error(t, err)
+}
+
+func TestCreateAndRunRejectInvalidPodBeforeRuntimeCalls(t *testing.T) {
+	// Every runtime call would panic because the client doesn't implement anything
+	server := &Server{client: &fakeStartClient{}, pulls: make(chan struct{}, maxConcurrentPulls)}
+	req := newCreateRequest(true)
+	req.Pod.Metadata.Name = "invalid name"
+
+	err := server.Create(req, &fakeCreateStream{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.Run(nil, &pods.RunPodRequest{Pod: req.Pod})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.Run(nil, &pods.RunPodRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "should reject missing pod")
+}
+
+type fakeStartClient struct {
+	runtime.Client
+}
+
+func (c *fakeStartClient) StartContainerWithProgress(namespace, id string, io runtime.IOSet, phase func(string)) (model.ContainerStatus, error) {
+	phase(progress.PhaseCreatingTask)
+	if id == "broken-id" {
+		return model.ContainerStatus{}, errors.New("exec format error")
+	}
+	phase(progress.PhaseStarting)
+	return model.ContainerStatus{ContainerID: id, State: "running"}, nil
+}
+
+func TestStartContainersReportsPhases(t *testing.T) {
+	server := &Server{client: &fakeStartClient{}}
+	phases := progress.NewPhases()
+	pod := model.Pod{
+		Metadata: model.NewMetadata("default", "foo"),
+		Spec:     model.PodSpec{Containers: []model.Container{{Name: "first", StartPriority: 1}, {Name: "broken"}}},
+	}
+
+	_, err := server.startContainers(pod, []model.ContainerStatus{
+		{ContainerID: "first-id", Name: "first"},
+		{ContainerID: "broken-id", Name: "broken"},
+	}, phases)
+	assert.Error(t, err)
+
+	result := []string{}
+	for _, phase := range phases.Take() {
+		result = append(result, phase.Container+":"+phase.Phase)
+	}
+	assert.Equal(t, []string{
+		"first:creating-task", "first:starting", "first:running",
+		"broken:creating-task", "broken:failed",
+	}, result)
+}
+
+type fakeOrderClient struct {
+	runtime.Client
+	mutex   sync.Mutex
+	running int
+	order   []string
+	waiting chan struct{}
+}
+
+func (c *fakeOrderClient) StartContainerWithProgress(namespace, id string, io runtime.IOSet, phase func(string)) (model.ContainerStatus, error) {
+	c.mutex.Lock()
+	c.order = append(c.order, id)
+	if id != "logging-id" {
+		c.running++
+		if c.running == 2 {
+			close(c.waiting)
+		}
+	}
+	c.mutex.Unlock()
+
+	if id != "logging-id" {
+		// Both same priority containers must be starting at the same time
+		select {
+		case <-c.waiting:
+		case <-time.After(5 * time.Second):
+			return model.ContainerStatus{}, errors.New("containers didn't start concurrently")
+		}
+	}
+	return model.ContainerStatus{ContainerID: id, State: "running"}, nil
+}
+
+func TestStartContainersInStartPriorityOrder(t *testing.T) {
+	client := &fakeOrderClient{waiting: make(chan struct{})}
+	server := &Server{client: client}
+	pod := model.Pod{
+		Metadata: model.NewMetadata("default", "foo"),
+		Spec: model.PodSpec{Containers: []model.Container{
+			{Name: "app"}, {Name: "worker"}, {Name: "logging", StartPriority: 1},
+		}},
+	}
+
+	result, err := server.startContainers(pod, []model.ContainerStatus{
+		{ContainerID: "app-id", Name: "app"},
+		{ContainerID: "worker-id", Name: "worker"},
+		{ContainerID: "logging-id", Name: "logging"},
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "logging-id", client.order[0], "should start higher priority first")
+	assert.Equal(t, []string{"app-id", "worker-id", "logging-id"}, []string{result[0].ContainerID, result[1].ContainerID, result[2].ContainerID}, "should keep the status order")
+	assert.Equal(t, "running", result[0].State)
+}
+
+type fakeDeleteNamespaceClient struct {
+	runtime.Client
+	namespace       string
+	removeNamespace bool
+}
+
+func (c *fakeDeleteNamespaceClient) DeleteNamespaceContainers(namespace string, removeNamespace bool) ([]model.ContainerStatus, error) {
+	c.namespace = namespace
+	c.removeNamespace = removeNamespace
+	return []model.ContainerStatus{{ContainerID: "foo-id", Name: "foo", State: "stopped"}}, nil
+}
+
+func TestDeleteNamespace(t *testing.T) {
+	client := &fakeDeleteNamespaceClient{}
+	server := &Server{client: client}
+
+	resp, err := server.DeleteNamespace(nil, &pods.DeleteNamespaceRequest{Namespace: "dev", RemoveNamespace: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", client.namespace)
+	assert.True(t, client.removeNamespace)
+	assert.Len(t, resp.ContainerStatuses, 1)
+	assert.Equal(t, "foo-id", resp.ContainerStatuses[0].ContainerID)
+}
+
+type fakeSnapshotterClient struct {
+	fakeSummaryClient
+	snapshotter string
+}
+
+func (c *fakeSnapshotterClient) SetSnapshotter(snapshotter string) error {
+	if snapshotter != "native" && snapshotter != "overlayfs" {
+		return runtime.ErrWithMessagef(runtime.ErrNotFound, "Snapshotter [%s] is not available", snapshotter)
+	}
+	c.snapshotter = snapshotter
+	return nil
+}
+
+func (c *fakeSnapshotterClient) GetRuntimeInfo() (model.RuntimeInfo, error) {
+	return model.RuntimeInfo{Snapshotter: c.snapshotter, Snapshotters: []string{"native", "overlayfs"}}, nil
+}
+
+func TestSetSnapshotter(t *testing.T) {
+	server := &Server{client: &fakeSnapshotterClient{snapshotter: "overlayfs"}}
+
+	resp, err := server.SetSnapshotter(nil, &node.SetSnapshotterRequest{Snapshotter: "native"})
+	assert.NoError(t, err)
+	assert.Equal(t, "native", resp.Runtime.Snapshotter)
+
+	_, err = server.SetSnapshotter(nil, &node.SetSnapshotterRequest{Snapshotter: "zfs"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "should reject unavailable snapshotter")
+}
+
+type fakeMigrateClient struct {
+	fakeSnapshotterClient
+	recreate bool
+}
+
+func (c *fakeMigrateClient) MigrateSnapshotter(snapshotter string, recreate bool, report func(model.SnapshotterMigrationStep)) error {
+	if err := c.SetSnapshotter(snapshotter); err != nil {
+		return err
+	}
+	c.recreate = recreate
+	report(model.SnapshotterMigrationStep{Namespace: "default", Kind: model.MigrationImage, Name: "docker.io/library/alpine:latest", Current: 1, Total: 2, Skipped: true})
+	report(model.SnapshotterMigrationStep{Namespace: "default", Kind: model.MigrationContainer, Name: "foo-id", Current: 2, Total: 2})
+	return nil
+}
+
+type fakeMigrateStream struct {
+	node.Node_MigrateSnapshotterServer
+	steps []*node.SnapshotterMigrationStep
+}
+
+func (s *fakeMigrateStream) Send(resp *node.MigrateSnapshotterResponse) error {
+	s.steps = append(s.steps, resp.Step)
+	return nil
+}
+
+func TestMigrateSnapshotter(t *testing.T) {
+	client := &fakeMigrateClient{fakeSnapshotterClient: fakeSnapshotterClient{snapshotter: "overlayfs"}}
+	server := &Server{client: client}
+	stream := &fakeMigrateStream{}
+
+	err := server.MigrateSnapshotter(&node.MigrateSnapshotterRequest{Snapshotter: "native", RecreateContainers: true}, stream)
+	assert.NoError(t, err)
+	assert.True(t, client.recreate)
+	assert.Len(t, stream.steps, 2)
+	assert.True(t, stream.steps[0].Skipped)
+	assert.Equal(t, "foo-id", stream.steps[1].Name)
+
+	err = server.MigrateSnapshotter(&node.MigrateSnapshotterRequest{Snapshotter: "zfs"}, stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "should reject unavailable snapshotter")
+}
+
+func TestMigrateSnapshotterRecreateInMaintenance(t *testing.T) {
+	client := &fakeMigrateClient{fakeSnapshotterClient: fakeSnapshotterClient{snapshotter: "overlayfs"}}
+	maintenance := controller.NewMaintenance()
+	maintenance.Set(true, "OS upgrade")
+	server := &Server{client: client, maintenance: maintenance}
+
+	err := server.MigrateSnapshotter(&node.MigrateSnapshotterRequest{Snapshotter: "native", RecreateContainers: true}, &fakeMigrateStream{})
+	assert.Equal(t, codes.Unavailable, status.Code(err), "should not recreate the containers in maintenance")
+	assert.False(t, client.recreate)
+
+	err = server.MigrateSnapshotter(&node.MigrateSnapshotterRequest{Snapshotter: "native"}, &fakeMigrateStream{})
+	assert.NoError(t, err, "should migrate the images without recreating the containers")
+}
+
+type fakeEventsClient struct {
+	runtime.Client
+	namespace string
+	filters   []string
+}
+
+func (c *fakeEventsClient) StreamEvents(ctx gocontext.Context, namespace string, filters []string, handler func(model.RuntimeEvent) error) error {
+	c.namespace, c.filters = namespace, filters
+	return handler(model.RuntimeEvent{Timestamp: time.Unix(0, 1500), Namespace: "default", Topic: "/tasks/exit", Subject: "foo-id"})
+}
+
+type fakeRuntimeEventsStream struct {
+	node.Node_RuntimeEventsServer
+	events []*node.RuntimeEvent
+}
+
+func (s *fakeRuntimeEventsStream) Context() gocontext.Context {
+	return gocontext.Background()
+}
+
+func (s *fakeRuntimeEventsStream) Send(resp *node.RuntimeEventsResponse) error {
+	s.events = append(s.events, resp.Event)
+	return nil
+}
+
+func TestRuntimeEvents(t *testing.T) {
+	client := &fakeEventsClient{}
+	stream := &fakeRuntimeEventsStream{}
+
+	err := (&Server{client: client}).RuntimeEvents(&node.RuntimeEventsRequest{}, stream)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "should require debug events enabled")
+
+	server := &Server{client: client, debugEvents: true}
+	err = server.RuntimeEvents(&node.RuntimeEventsRequest{Namespace: "default", AllNamespaces: true, Filters: []string{`topic~="/tasks/"`}}, stream)
+	assert.NoError(t, err)
+	assert.Equal(t, "", client.namespace, "should stream all namespaces")
+	assert.Equal(t, []string{`topic~="/tasks/"`}, client.filters)
+	assert.Equal(t, []*node.RuntimeEvent{{Timestamp: 1500, Namespace: "default", Topic: "/tasks/exit", Subject: "foo-id"}}, stream.events)
+
+	err = server.RuntimeEvents(&node.RuntimeEventsRequest{}, stream)
+	assert.NoError(t, err)
+	assert.Equal(t, model.DefaultNamespace, client.namespace, "should stream only the default namespace without namespace")
+}
+
+type fakeCancelPullClient struct {
+	fakeSummaryClient
+	pulling string
+}
+
+func (c *fakeCancelPullClient) CancelPull(namespace, ref string) error {
+	if ref != c.pulling {
+		return runtime.ErrWithMessagef(runtime.ErrNotFound, "No pull of image [%s] in progress in namespace [%s]", ref, namespace)
+	}
+	return nil
+}
+
+func TestCancelPull(t *testing.T) {
+	server := &Server{client: &fakeCancelPullClient{pulling: "docker.io/library/alpine:latest"}}
+
+	resp, err := server.CancelPull(nil, &images.CancelPullRequest{Namespace: "default", Ref: "alpine"})
+	assert.NoError(t, err)
+	assert.Equal(t, "docker.io/library/alpine:latest", resp.Ref)
+
+	_, err = server.CancelPull(nil, &images.CancelPullRequest{Namespace: "default", Ref: "busybox"})
+	assert.Equal(t, codes.NotFound, status.Code(err), "should return not found if the image is not being pulled")
+}
+
+func TestResolveFileSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	store, err := secrets.NewStore(dir, make([]byte, 32))
+	assert.NoError(t, err)
+	server := &Server{secrets: store}
+	_, err = server.PutSecret(nil, &images.PutSecretRequest{Namespace: "dev", Name: "api-key", Data: []byte("s3cr3t")})
+	assert.NoError(t, err)
+	container := model.Container{Name: "foo", Files: []model.FileMount{
+		{Path: "/etc/app/config.yml", Content: "debug: true"},
+		{Path: "/run/secrets/api-key", Secret: "api-key"},
+	}}
+
+	resolved, err := server.resolveFileSecrets("dev", container)
+	assert.NoError(t, err)
+	assert.Equal(t, "debug: true", resolved.Files[0].Content)
+	assert.Equal(t, "s3cr3t", resolved.Files[1].Content)
+	assert.Equal(t, "", container.Files[1].Content, "should not modify the original container")
+
+	_, err = server.resolveFileSecrets("dev", model.Container{Files: []model.FileMount{{Path: "/foo", Secret: "missing"}}})
+	assert.Error(t, err, "should return error if secret not found")
+
+	_, err = server.resolveFileSecrets("other", container)
+	assert.Error(t, err, "should not resolve the secret of other namespace")
+	_, err = server.resolveFileSecrets("", container)
+	assert.Error(t, err, "should resolve the secret from the default namespace when namespace is not given")
+}
+
+func TestGetPullSecretsFromPodNamespace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	store, err := secrets.NewStore(dir, make([]byte, 32))
+	assert.NoError(t, err)
+	server := &Server{secrets: store}
+
+	_, err = server.PutPullSecret(nil, &images.PutPullSecretRequest{Namespace: "dev", Secret: &images.PullSecret{Name: "registry", Registry: "registry.example.com", Password: "s3cr3t"}})
+	assert.NoError(t, err)
+
+	result, err := server.getPullSecrets("dev", []string{"registry"})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+
+	_, err = server.getPullSecrets("prod", []string{"registry"})
+	assert.Error(t, err, "should not use the secret of other namespace")
+}
+
+type fakeCommitClient struct {
+	runtime.Client
+	committed map[string]string
+}
+
+func (c *fakeCommitClient) CommitContainer(namespace, id, newRef string) (model.Image, error) {
+	if id != "foo-bar" {
+		return model.Image{}, runtime.ErrWithMessagef(runtime.ErrNotFound, "Container [%s] not found", id)
+	}
+	c.committed[newRef] = id
+	return model.Image{Name: newRef, Digest: "sha256:0000"}, nil
+}
+
+func TestCommitNormalizesImageRef(t *testing.T) {
+	client := &fakeCommitClient{committed: map[string]string{}}
+	server := &Server{client: client}
+
+	resp, err := server.Commit(nil, &containers.CommitContainerRequest{ContainerID: "foo-bar", Ref: "myapp:dev"})
+	assert.NoError(t, err)
+	assert.Equal(t, "docker.io/library/myapp:dev", resp.Ref)
+	assert.Equal(t, "sha256:0000", resp.Digest)
+	assert.Equal(t, "foo-bar", client.committed["docker.io/library/myapp:dev"])
+
+	_, err = server.Commit(nil, &containers.CommitContainerRequest{ContainerID: "missing", Ref: "myapp:dev"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+type fakeMoveClient struct {
+	runtime.Client
+	moved map[string]string
+}
+
+func (c *fakeMoveClient) MoveContainer(namespace, id, targetNamespace string) (model.ContainerStatus, error) {
+	switch id {
+	case "missing":
+		return model.ContainerStatus{}, runtime.ErrWithMessagef(runtime.ErrNotFound, "Container [%s] not found", id)
+	case "running":
+		return model.ContainerStatus{}, runtime.ErrWithMessagef(runtime.ErrInvalid, "Container [%s] is running, stop it before moving", id)
+	case "exists":
+		return model.ContainerStatus{}, runtime.ErrWithMessagef(runtime.ErrAlreadyExists, "Container [%s] already exist in namespace [%s]", id, targetNamespace)
+	}
+	c.moved[id] = targetNamespace
+	return model.ContainerStatus{ContainerID: id, Name: "bar", State: "stopped"}, nil
+}
+
+func TestMove(t *testing.T) {
+	client := &fakeMoveClient{moved: map[string]string{}}
+	server := &Server{client: client}
+
+	resp, err := server.Move(nil, &containers.MoveContainerRequest{Namespace: "default", ContainerID: "foo-bar", TargetNamespace: "tenant-a"})
+	assert.NoError(t, err)
+	assert.Equal(t, "foo-bar", resp.Status.ContainerID)
+	assert.Equal(t, "tenant-a", client.moved["foo-bar"])
+
+	_, err = server.Move(nil, &containers.MoveContainerRequest{Namespace: "default", ContainerID: "foo-bar"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "should require target namespace")
+
+	_, err = server.Move(nil, &containers.MoveContainerRequest{ContainerID: "missing", TargetNamespace: "tenant-a"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = server.Move(nil, &containers.MoveContainerRequest{ContainerID: "running", TargetNamespace: "tenant-a"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = server.Move(nil, &containers.MoveContainerRequest{ContainerID: "exists", TargetNamespace: "tenant-a"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestMaintenanceRejectsNewWorkloads(t *testing.T) {
+	maintenance := controller.NewMaintenance()
+	maintenance.Set(true, "OS upgrade")
+	server := &Server{client: &fakeMoveClient{moved: map[string]string{}}}
+	WithMaintenance(maintenance)(server)
+
+	resp, err := server.Maintenance(nil, &node.MaintenanceRequest{})
+	assert.NoError(t, err)
+	assert.True(t, resp.Maintenance.Enabled)
+	assert.Equal(t, "OS upgrade", resp.Maintenance.Reason)
+	assert.NotZero(t, resp.Maintenance.Since)
+
+	_, err = server.Run(nil, &pods.RunPodRequest{Pod: &pods.Pod{Metadata: &core.ResourceMetadata{Name: "foo", Namespace: "default"}}})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Contains(t, err.Error(), "OS upgrade")
+
+	_, err = server.Start(nil, &pods.StartPodRequest{Namespace: "default", Name: "foo"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	_, err = server.Move(nil, &containers.MoveContainerRequest{ContainerID: "foo-bar", TargetNamespace: "tenant-a"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	disabled, err := server.SetMaintenance(nil, &node.SetMaintenanceRequest{Enabled: false})
+	assert.NoError(t, err)
+	assert.False(t, disabled.Maintenance.Enabled)
+	assert.Zero(t, disabled.Maintenance.Since)
+
+	_, err = server.Move(nil, &containers.MoveContainerRequest{ContainerID: "foo-bar", TargetNamespace: "tenant-a"})
+	assert.NoError(t, err, "should accept the workloads after the maintenance")
+}
+
+func TestSetMaintenanceDefaultReason(t *testing.T) {
+	server := &Server{maintenance: controller.NewMaintenance()}
+	resp, err := server.SetMaintenance(nil, &node.SetMaintenanceRequest{Enabled: true})
+	assert.NoError(t, err)
+	assert.Equal(t, controller.DefaultMaintenanceReason, resp.Maintenance.Reason)
+}
+
+type fakeConnectionClient struct {
+	runtime.Client
+	connected bool
+}
+
+func (c *fakeConnectionClient) IsConnected() bool {
+	return c.connected
+}
+
+func TestHealthCheckReadiness(t *testing.T) {
+	readiness := controller.NewReadiness()
+	server := &Server{client: &fakeConnectionClient{connected: true}, readiness: readiness}
+
+	resp, err := server.Check(nil, &health.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, health.HealthCheckResponse_SERVING, resp.Status, "should be alive before ready")
+
+	resp, err = server.Check(nil, &health.HealthCheckRequest{Service: ReadinessService})
+	assert.NoError(t, err)
+	assert.Equal(t, health.HealthCheckResponse_NOT_SERVING, resp.Status)
+
+	readiness.SetReady(time.Now())
+	resp, err = server.Check(nil, &health.HealthCheckRequest{Service: ReadinessService})
+	assert.NoError(t, err)
+	assert.Equal(t, health.HealthCheckResponse_SERVING, resp.Status)
+
+	_, err = server.Check(nil, &health.HealthCheckRequest{Service: "foo"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	resp, err = (&Server{client: &fakeConnectionClient{connected: true}}).Check(nil, &health.HealthCheckRequest{Service: ReadinessService})
+	assert.NoError(t, err)
+	assert.Equal(t, health.HealthCheckResponse_SERVING, resp.Status, "should be ready without lifecycle controller")
+}
+
+func TestHealthCheckContainerdDisconnected(t *testing.T) {
+	readiness := controller.NewReadiness()
+	readiness.SetReady(time.Now())
+	server := &Server{client: &fakeConnectionClient{connected: false}, readiness: readiness}
+
+	resp, err := server.Check(nil, &health.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, health.HealthCheckResponse_NOT_SERVING, resp.Status, "should not be alive without containerd")
+
+	resp, err = server.Check(nil, &health.HealthCheckRequest{Service: ReadinessService})
+	assert.NoError(t, err)
+	assert.Equal(t, health.HealthCheckResponse_NOT_SERVING, resp.Status, "should not be ready without containerd")
+}
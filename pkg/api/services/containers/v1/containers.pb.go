@@ -13,12 +13,42 @@ It has these top-level messages:
 	StdoutStreamResponse
 	SignalRequest
 	SignalResponse
+	ContainerStatusesRequest
+	ContainerStatusesResponse
+	WaitRequest
+	WaitResponse
+	InspectContainerRequest
+	InspectContainerResponse
+	DiffContainerRequest
+	DiffContainerResponse
+	CommitContainerRequest
+	CommitContainerResponse
+	MoveContainerRequest
+	MoveContainerResponse
+	TopRequest
+	TopResponse
+	LogsRequest
+	LogsResponse
+	Process
+	FileChange
 	Container
+	Capabilities
+	Probe
+	FileWatch
+	Device
+	IdleStop
+	FileMount
+	TmpfsMount
+	Ulimit
+	Resources
 	PipeSet
 	PipeFromStdout
 	PipeToStdin
 	Mount
 	ContainerStatus
+	ContainerStatsRequest
+	ContainerStatsResponse
+	ContainerStats
 */
 package containers
 
@@ -123,6 +153,478 @@ func (m *SignalResponse) String() string            { return proto.CompactTextSt
 func (*SignalResponse) ProtoMessage()               {}
 func (*SignalResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
+type ContainerStatusesRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	// Container ids to resolve, all containers in the namespace if empty
+	ContainerIDs []string `protobuf:"bytes,2,rep,name=containerIDs" json:"containerIDs,omitempty"`
+}
+
+func (m *ContainerStatusesRequest) Reset()                    { *m = ContainerStatusesRequest{} }
+func (m *ContainerStatusesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ContainerStatusesRequest) ProtoMessage()               {}
+func (*ContainerStatusesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *ContainerStatusesRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ContainerStatusesRequest) GetContainerIDs() []string {
+	if m != nil {
+		return m.ContainerIDs
+	}
+	return nil
+}
+
+type ContainerStatusesResponse struct {
+	// Container task statuses by the container id
+	Statuses map[string]string `protobuf:"bytes,1,rep,name=statuses" json:"statuses,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ContainerStatusesResponse) Reset()                    { *m = ContainerStatusesResponse{} }
+func (m *ContainerStatusesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ContainerStatusesResponse) ProtoMessage()               {}
+func (*ContainerStatusesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *ContainerStatusesResponse) GetStatuses() map[string]string {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+type WaitRequest struct {
+	Namespace   string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	ContainerID string `protobuf:"bytes,2,opt,name=containerID" json:"containerID,omitempty"`
+	// Target status, "running" or "stopped"
+	Status string `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
+	// Timeout in milliseconds, zero means no timeout
+	Timeout int64 `protobuf:"varint,4,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *WaitRequest) Reset()                    { *m = WaitRequest{} }
+func (m *WaitRequest) String() string            { return proto.CompactTextString(m) }
+func (*WaitRequest) ProtoMessage()               {}
+func (*WaitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *WaitRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *WaitRequest) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+func (m *WaitRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *WaitRequest) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+type WaitResponse struct {
+}
+
+func (m *WaitResponse) Reset()                    { *m = WaitResponse{} }
+func (m *WaitResponse) String() string            { return proto.CompactTextString(m) }
+func (*WaitResponse) ProtoMessage()               {}
+func (*WaitResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+type InspectContainerRequest struct {
+	Namespace   string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	ContainerID string `protobuf:"bytes,2,opt,name=containerID" json:"containerID,omitempty"`
+}
+
+func (m *InspectContainerRequest) Reset()                    { *m = InspectContainerRequest{} }
+func (m *InspectContainerRequest) String() string            { return proto.CompactTextString(m) }
+func (*InspectContainerRequest) ProtoMessage()               {}
+func (*InspectContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *InspectContainerRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *InspectContainerRequest) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+type InspectContainerResponse struct {
+	ContainerID string            `protobuf:"bytes,1,opt,name=containerID" json:"containerID,omitempty"`
+	Image       string            `protobuf:"bytes,2,opt,name=image" json:"image,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,3,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Runtime     string            `protobuf:"bytes,4,opt,name=runtime" json:"runtime,omitempty"`
+	Snapshotter string            `protobuf:"bytes,5,opt,name=snapshotter" json:"snapshotter,omitempty"`
+	SnapshotKey string            `protobuf:"bytes,6,opt,name=snapshotKey" json:"snapshotKey,omitempty"`
+	// Resolved OCI runtime spec in JSON format
+	Spec []byte `protobuf:"bytes,7,opt,name=spec,proto3" json:"spec,omitempty"`
+}
+
+func (m *InspectContainerResponse) Reset()                    { *m = InspectContainerResponse{} }
+func (m *InspectContainerResponse) String() string            { return proto.CompactTextString(m) }
+func (*InspectContainerResponse) ProtoMessage()               {}
+func (*InspectContainerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *InspectContainerResponse) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+func (m *InspectContainerResponse) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *InspectContainerResponse) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *InspectContainerResponse) GetRuntime() string {
+	if m != nil {
+		return m.Runtime
+	}
+	return ""
+}
+
+func (m *InspectContainerResponse) GetSnapshotter() string {
+	if m != nil {
+		return m.Snapshotter
+	}
+	return ""
+}
+
+func (m *InspectContainerResponse) GetSnapshotKey() string {
+	if m != nil {
+		return m.SnapshotKey
+	}
+	return ""
+}
+
+func (m *InspectContainerResponse) GetSpec() []byte {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+type DiffContainerRequest struct {
+	Namespace   string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	ContainerID string `protobuf:"bytes,2,opt,name=containerID" json:"containerID,omitempty"`
+}
+
+func (m *DiffContainerRequest) Reset()                    { *m = DiffContainerRequest{} }
+func (m *DiffContainerRequest) String() string            { return proto.CompactTextString(m) }
+func (*DiffContainerRequest) ProtoMessage()               {}
+func (*DiffContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *DiffContainerRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *DiffContainerRequest) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+type DiffContainerResponse struct {
+	Changes []*FileChange `protobuf:"bytes,1,rep,name=changes" json:"changes,omitempty"`
+}
+
+func (m *DiffContainerResponse) Reset()                    { *m = DiffContainerResponse{} }
+func (m *DiffContainerResponse) String() string            { return proto.CompactTextString(m) }
+func (*DiffContainerResponse) ProtoMessage()               {}
+func (*DiffContainerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *DiffContainerResponse) GetChanges() []*FileChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+type CommitContainerRequest struct {
+	Namespace   string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	ContainerID string `protobuf:"bytes,2,opt,name=containerID" json:"containerID,omitempty"`
+	Ref         string `protobuf:"bytes,3,opt,name=ref" json:"ref,omitempty"`
+}
+
+func (m *CommitContainerRequest) Reset()                    { *m = CommitContainerRequest{} }
+func (m *CommitContainerRequest) String() string            { return proto.CompactTextString(m) }
+func (*CommitContainerRequest) ProtoMessage()               {}
+func (*CommitContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *CommitContainerRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *CommitContainerRequest) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+func (m *CommitContainerRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+type CommitContainerResponse struct {
+	Ref    string `protobuf:"bytes,1,opt,name=ref" json:"ref,omitempty"`
+	Digest string `protobuf:"bytes,2,opt,name=digest" json:"digest,omitempty"`
+}
+
+func (m *CommitContainerResponse) Reset()                    { *m = CommitContainerResponse{} }
+func (m *CommitContainerResponse) String() string            { return proto.CompactTextString(m) }
+func (*CommitContainerResponse) ProtoMessage()               {}
+func (*CommitContainerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *CommitContainerResponse) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *CommitContainerResponse) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+type MoveContainerRequest struct {
+	Namespace       string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	ContainerID     string `protobuf:"bytes,2,opt,name=containerID" json:"containerID,omitempty"`
+	TargetNamespace string `protobuf:"bytes,3,opt,name=targetNamespace" json:"targetNamespace,omitempty"`
+}
+
+func (m *MoveContainerRequest) Reset()                    { *m = MoveContainerRequest{} }
+func (m *MoveContainerRequest) String() string            { return proto.CompactTextString(m) }
+func (*MoveContainerRequest) ProtoMessage()               {}
+func (*MoveContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *MoveContainerRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *MoveContainerRequest) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+func (m *MoveContainerRequest) GetTargetNamespace() string {
+	if m != nil {
+		return m.TargetNamespace
+	}
+	return ""
+}
+
+type MoveContainerResponse struct {
+	Status *ContainerStatus `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+}
+
+func (m *MoveContainerResponse) Reset()                    { *m = MoveContainerResponse{} }
+func (m *MoveContainerResponse) String() string            { return proto.CompactTextString(m) }
+func (*MoveContainerResponse) ProtoMessage()               {}
+func (*MoveContainerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *MoveContainerResponse) GetStatus() *ContainerStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type TopRequest struct {
+	Namespace   string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	ContainerID string `protobuf:"bytes,2,opt,name=containerID" json:"containerID,omitempty"`
+}
+
+func (m *TopRequest) Reset()                    { *m = TopRequest{} }
+func (m *TopRequest) String() string            { return proto.CompactTextString(m) }
+func (*TopRequest) ProtoMessage()               {}
+func (*TopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *TopRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *TopRequest) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+type TopResponse struct {
+	Processes []*Process `protobuf:"bytes,1,rep,name=processes" json:"processes,omitempty"`
+}
+
+func (m *TopResponse) Reset()                    { *m = TopResponse{} }
+func (m *TopResponse) String() string            { return proto.CompactTextString(m) }
+func (*TopResponse) ProtoMessage()               {}
+func (*TopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *TopResponse) GetProcesses() []*Process {
+	if m != nil {
+		return m.Processes
+	}
+	return nil
+}
+
+type LogsRequest struct {
+	Namespace   string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	ContainerID string `protobuf:"bytes,2,opt,name=containerID" json:"containerID,omitempty"`
+}
+
+func (m *LogsRequest) Reset()                    { *m = LogsRequest{} }
+func (m *LogsRequest) String() string            { return proto.CompactTextString(m) }
+func (*LogsRequest) ProtoMessage()               {}
+func (*LogsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *LogsRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *LogsRequest) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+type LogsResponse struct {
+	// The container output in the order it was written
+	Output []byte `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+}
+
+func (m *LogsResponse) Reset()                    { *m = LogsResponse{} }
+func (m *LogsResponse) String() string            { return proto.CompactTextString(m) }
+func (*LogsResponse) ProtoMessage()               {}
+func (*LogsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *LogsResponse) GetOutput() []byte {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+type Process struct {
+	Pid  int32 `protobuf:"varint,1,opt,name=pid" json:"pid,omitempty"`
+	Ppid int32 `protobuf:"varint,2,opt,name=ppid" json:"ppid,omitempty"`
+	// Process state letter, e.g. R running, S sleeping or Z zombie
+	State string   `protobuf:"bytes,3,opt,name=state" json:"state,omitempty"`
+	Args  []string `protobuf:"bytes,4,rep,name=args" json:"args,omitempty"`
+}
+
+func (m *Process) Reset()                    { *m = Process{} }
+func (m *Process) String() string            { return proto.CompactTextString(m) }
+func (*Process) ProtoMessage()               {}
+func (*Process) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *Process) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *Process) GetPpid() int32 {
+	if m != nil {
+		return m.Ppid
+	}
+	return 0
+}
+
+func (m *Process) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *Process) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+type FileChange struct {
+	// One of added, modified or deleted
+	Kind string `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	Path string `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
+}
+
+func (m *FileChange) Reset()                    { *m = FileChange{} }
+func (m *FileChange) String() string            { return proto.CompactTextString(m) }
+func (*FileChange) ProtoMessage()               {}
+func (*FileChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *FileChange) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *FileChange) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
 type Container struct {
 	Name       string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	Image      string   `protobuf:"bytes,2,opt,name=image" json:"image,omitempty"`
@@ -132,69 +634,690 @@ type Container struct {
 	Env        []string `protobuf:"bytes,6,rep,name=env" json:"env,omitempty"`
 	Mounts     []*Mount `protobuf:"bytes,7,rep,name=mounts" json:"mounts,omitempty"`
 	Pipe       *PipeSet `protobuf:"bytes,8,opt,name=pipe" json:"pipe,omitempty"`
+	// DNS servers written to the container /etc/resolv.conf
+	Dns []string `protobuf:"bytes,9,rep,name=dns" json:"dns,omitempty"`
+	// Extra /etc/hosts entries in format 'hostname:ip'
+	ExtraHosts []string `protobuf:"bytes,10,rep,name=extraHosts" json:"extraHosts,omitempty"`
+	// Optional unique container id, generated by the server if not given
+	Id         string     `protobuf:"bytes,11,opt,name=id" json:"id,omitempty"`
+	Resources  *Resources `protobuf:"bytes,12,opt,name=resources" json:"resources,omitempty"`
+	Hostname   string     `protobuf:"bytes,13,opt,name=hostname" json:"hostname,omitempty"`
+	Domainname string     `protobuf:"bytes,14,opt,name=domainname" json:"domainname,omitempty"`
+	// Cgroup under what the container cgroup gets created, e.g. /eliot.slice
+	CgroupParent string `protobuf:"bytes,15,opt,name=cgroupParent" json:"cgroupParent,omitempty"`
+	// Process resource limits, e.g. nofile for max open files
+	Ulimits []*Ulimit `protobuf:"bytes,16,rep,name=ulimits" json:"ulimits,omitempty"`
+	// OCI spec annotations, e.g. to configure sandboxed runtime
+	Annotations map[string]string `protobuf:"bytes,17,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Run the container in the host network namespace, e.g. for mDNS or DHCP
+	HostNetwork bool `protobuf:"varint,18,opt,name=hostNetwork" json:"hostNetwork,omitempty"`
+	// Size limited in-memory mounts, e.g. writable /tmp
+	Tmpfs []*TmpfsMount `protobuf:"bytes,19,rep,name=tmpfs" json:"tmpfs,omitempty"`
+	// Failing liveness probe restarts the container
+	LivenessProbe *Probe `protobuf:"bytes,20,opt,name=livenessProbe" json:"livenessProbe,omitempty"`
+	// Failing readiness probe marks the container not ready without restarting it
+	ReadinessProbe *Probe `protobuf:"bytes,21,opt,name=readinessProbe" json:"readinessProbe,omitempty"`
+	// JSON merge patch (RFC 7386) applied to the generated OCI runtime spec,
+	// e.g. {"process":{"noNewPrivileges":true}}
+	SpecPatch string `protobuf:"bytes,22,opt,name=specPatch" json:"specPatch,omitempty"`
+	// Namespaced kernel parameters, e.g. net.core.somaxconn
+	Sysctls map[string]string `protobuf:"bytes,23,rep,name=sysctls" json:"sysctls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Replaces the default process capabilities entirely when given
+	Capabilities *Capabilities `protobuf:"bytes,24,opt,name=capabilities" json:"capabilities,omitempty"`
+	// OOM killer score adjustment from -1000 (never kill) to 1000 (kill first), zero is the default
+	OomScoreAdj int32 `protobuf:"varint,25,opt,name=oomScoreAdj" json:"oomScoreAdj,omitempty"`
+	// Run eliotd --init-path binary as PID 1 to reap the zombie processes
+	Init bool `protobuf:"varint,26,opt,name=init" json:"init,omitempty"`
+	// Restart the container when the watched host files change
+	RestartOnChange *FileWatch `protobuf:"bytes,27,opt,name=restartOnChange" json:"restartOnChange,omitempty"`
+	// AppArmor profile to confine the container with, must be loaded on the host
+	AppArmorProfile string `protobuf:"bytes,28,opt,name=appArmorProfile" json:"appArmorProfile,omitempty"`
+	// Cron expression when to start the container, e.g. "0 3 * * *", the container doesn't get
+	// started with the pod nor restarted when it exits
+	Schedule string `protobuf:"bytes,29,opt,name=schedule" json:"schedule,omitempty"`
+	// Where the container stdout/stderr goes, "file" (default) or "journald"
+	LogDriver string `protobuf:"bytes,30,opt,name=logDriver" json:"logDriver,omitempty"`
+	// Files mounted read-only to the container from in-memory storage
+	Files []*FileMount `protobuf:"bytes,31,rep,name=files" json:"files,omitempty"`
+	// Outgoing network traffic limit in bits per second, zero means no limit
+	EgressRateLimit int64 `protobuf:"varint,32,opt,name=egressRateLimit" json:"egressRateLimit,omitempty"`
+	// Restart the running container when the host network comes back online
+	RestartOnNetworkRecovery bool `protobuf:"varint,33,opt,name=restartOnNetworkRecovery" json:"restartOnNetworkRecovery,omitempty"`
+	// Log level injected as environment variable, overrides the pod log level
+	LogLevel string `protobuf:"bytes,34,opt,name=logLevel" json:"logLevel,omitempty"`
+	// Stop the container when idle and start it again on the next connection to the port
+	IdleStop *IdleStop `protobuf:"bytes,35,opt,name=idleStop" json:"idleStop,omitempty"`
+	// Host device nodes passed through to the container
+	Devices []*Device `protobuf:"bytes,36,rep,name=devices" json:"devices,omitempty"`
+	// Host accelerators passed through with their driver libraries, e.g. "coral", "coral-usb", "jetson" or "nvidia"
+	Accelerators []string `protobuf:"bytes,37,rep,name=accelerators" json:"accelerators,omitempty"`
+	// Higher priority containers start first when the pod gets started, the same priority start concurrently
+	StartPriority int32 `protobuf:"varint,38,opt,name=startPriority" json:"startPriority,omitempty"`
+	// Maximum size of the container writable layer in bytes, zero means no limit
+	// Requires overlayfs on XFS with project quotas or btrfs with quotas enabled
+	StorageQuota int64 `protobuf:"varint,39,opt,name=storageQuota" json:"storageQuota,omitempty"`
+	// Names of the eliotd environment variables forwarded to the container, must be in eliotd --host-env-allowlist
+	HostEnv []string `protobuf:"bytes,40,rep,name=hostEnv" json:"hostEnv,omitempty"`
+}
+
+func (m *Container) Reset()                    { *m = Container{} }
+func (m *Container) String() string            { return proto.CompactTextString(m) }
+func (*Container) ProtoMessage()               {}
+func (*Container) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *Container) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Container) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *Container) GetTty() bool {
+	if m != nil {
+		return m.Tty
+	}
+	return false
+}
+
+func (m *Container) GetWorkingDir() string {
+	if m != nil {
+		return m.WorkingDir
+	}
+	return ""
+}
+
+func (m *Container) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *Container) GetEnv() []string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *Container) GetMounts() []*Mount {
+	if m != nil {
+		return m.Mounts
+	}
+	return nil
+}
+
+func (m *Container) GetPipe() *PipeSet {
+	if m != nil {
+		return m.Pipe
+	}
+	return nil
+}
+
+func (m *Container) GetDns() []string {
+	if m != nil {
+		return m.Dns
+	}
+	return nil
+}
+
+func (m *Container) GetExtraHosts() []string {
+	if m != nil {
+		return m.ExtraHosts
+	}
+	return nil
+}
+
+func (m *Container) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Container) GetResources() *Resources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *Container) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *Container) GetDomainname() string {
+	if m != nil {
+		return m.Domainname
+	}
+	return ""
+}
+
+func (m *Container) GetCgroupParent() string {
+	if m != nil {
+		return m.CgroupParent
+	}
+	return ""
+}
+
+func (m *Container) GetUlimits() []*Ulimit {
+	if m != nil {
+		return m.Ulimits
+	}
+	return nil
+}
+
+func (m *Container) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+func (m *Container) GetHostNetwork() bool {
+	if m != nil {
+		return m.HostNetwork
+	}
+	return false
+}
+
+func (m *Container) GetTmpfs() []*TmpfsMount {
+	if m != nil {
+		return m.Tmpfs
+	}
+	return nil
+}
+
+func (m *Container) GetLivenessProbe() *Probe {
+	if m != nil {
+		return m.LivenessProbe
+	}
+	return nil
+}
+
+func (m *Container) GetReadinessProbe() *Probe {
+	if m != nil {
+		return m.ReadinessProbe
+	}
+	return nil
+}
+
+func (m *Container) GetSpecPatch() string {
+	if m != nil {
+		return m.SpecPatch
+	}
+	return ""
+}
+
+func (m *Container) GetSysctls() map[string]string {
+	if m != nil {
+		return m.Sysctls
+	}
+	return nil
+}
+
+func (m *Container) GetCapabilities() *Capabilities {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *Container) GetOomScoreAdj() int32 {
+	if m != nil {
+		return m.OomScoreAdj
+	}
+	return 0
+}
+
+func (m *Container) GetInit() bool {
+	if m != nil {
+		return m.Init
+	}
+	return false
+}
+
+func (m *Container) GetRestartOnChange() *FileWatch {
+	if m != nil {
+		return m.RestartOnChange
+	}
+	return nil
+}
+
+func (m *Container) GetAppArmorProfile() string {
+	if m != nil {
+		return m.AppArmorProfile
+	}
+	return ""
+}
+
+func (m *Container) GetSchedule() string {
+	if m != nil {
+		return m.Schedule
+	}
+	return ""
+}
+
+func (m *Container) GetLogDriver() string {
+	if m != nil {
+		return m.LogDriver
+	}
+	return ""
+}
+
+func (m *Container) GetFiles() []*FileMount {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *Container) GetEgressRateLimit() int64 {
+	if m != nil {
+		return m.EgressRateLimit
+	}
+	return 0
+}
+
+func (m *Container) GetRestartOnNetworkRecovery() bool {
+	if m != nil {
+		return m.RestartOnNetworkRecovery
+	}
+	return false
+}
+
+func (m *Container) GetLogLevel() string {
+	if m != nil {
+		return m.LogLevel
+	}
+	return ""
+}
+
+func (m *Container) GetIdleStop() *IdleStop {
+	if m != nil {
+		return m.IdleStop
+	}
+	return nil
+}
+
+func (m *Container) GetDevices() []*Device {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+func (m *Container) GetAccelerators() []string {
+	if m != nil {
+		return m.Accelerators
+	}
+	return nil
+}
+
+func (m *Container) GetStartPriority() int32 {
+	if m != nil {
+		return m.StartPriority
+	}
+	return 0
+}
+
+func (m *Container) GetStorageQuota() int64 {
+	if m != nil {
+		return m.StorageQuota
+	}
+	return 0
+}
+
+func (m *Container) GetHostEnv() []string {
+	if m != nil {
+		return m.HostEnv
+	}
+	return nil
+}
+
+// Capability names are uppercase with CAP_ prefix, e.g. CAP_NET_BIND_SERVICE
+type Capabilities struct {
+	Effective   []string `protobuf:"bytes,1,rep,name=effective" json:"effective,omitempty"`
+	Permitted   []string `protobuf:"bytes,2,rep,name=permitted" json:"permitted,omitempty"`
+	Bounding    []string `protobuf:"bytes,3,rep,name=bounding" json:"bounding,omitempty"`
+	Inheritable []string `protobuf:"bytes,4,rep,name=inheritable" json:"inheritable,omitempty"`
+}
+
+func (m *Capabilities) Reset()                    { *m = Capabilities{} }
+func (m *Capabilities) String() string            { return proto.CompactTextString(m) }
+func (*Capabilities) ProtoMessage()               {}
+func (*Capabilities) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *Capabilities) GetEffective() []string {
+	if m != nil {
+		return m.Effective
+	}
+	return nil
+}
+
+func (m *Capabilities) GetPermitted() []string {
+	if m != nil {
+		return m.Permitted
+	}
+	return nil
+}
+
+func (m *Capabilities) GetBounding() []string {
+	if m != nil {
+		return m.Bounding
+	}
+	return nil
+}
+
+func (m *Capabilities) GetInheritable() []string {
+	if m != nil {
+		return m.Inheritable
+	}
+	return nil
+}
+
+type Probe struct {
+	// Command to execute in the container, zero exit code means success
+	Exec                []string `protobuf:"bytes,1,rep,name=exec" json:"exec,omitempty"`
+	InitialDelaySeconds int64    `protobuf:"varint,2,opt,name=initialDelaySeconds" json:"initialDelaySeconds,omitempty"`
+	// Zero values mean the defaults: 10 seconds period, 1 second timeout and 3 failures
+	PeriodSeconds    int64 `protobuf:"varint,3,opt,name=periodSeconds" json:"periodSeconds,omitempty"`
+	TimeoutSeconds   int64 `protobuf:"varint,4,opt,name=timeoutSeconds" json:"timeoutSeconds,omitempty"`
+	FailureThreshold int32 `protobuf:"varint,5,opt,name=failureThreshold" json:"failureThreshold,omitempty"`
+}
+
+func (m *Probe) Reset()                    { *m = Probe{} }
+func (m *Probe) String() string            { return proto.CompactTextString(m) }
+func (*Probe) ProtoMessage()               {}
+func (*Probe) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *Probe) GetExec() []string {
+	if m != nil {
+		return m.Exec
+	}
+	return nil
+}
+
+func (m *Probe) GetInitialDelaySeconds() int64 {
+	if m != nil {
+		return m.InitialDelaySeconds
+	}
+	return 0
 }
 
-func (m *Container) Reset()                    { *m = Container{} }
-func (m *Container) String() string            { return proto.CompactTextString(m) }
-func (*Container) ProtoMessage()               {}
-func (*Container) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (m *Probe) GetPeriodSeconds() int64 {
+	if m != nil {
+		return m.PeriodSeconds
+	}
+	return 0
+}
 
-func (m *Container) GetName() string {
+func (m *Probe) GetTimeoutSeconds() int64 {
 	if m != nil {
-		return m.Name
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+func (m *Probe) GetFailureThreshold() int32 {
+	if m != nil {
+		return m.FailureThreshold
+	}
+	return 0
+}
+
+type FileWatch struct {
+	// Absolute host file paths
+	Paths []string `protobuf:"bytes,1,rep,name=paths" json:"paths,omitempty"`
+	// How long the files must stay unchanged before the restart, zero means 5 seconds
+	DebounceSeconds int64 `protobuf:"varint,2,opt,name=debounceSeconds" json:"debounceSeconds,omitempty"`
+}
+
+func (m *FileWatch) Reset()                    { *m = FileWatch{} }
+func (m *FileWatch) String() string            { return proto.CompactTextString(m) }
+func (*FileWatch) ProtoMessage()               {}
+func (*FileWatch) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *FileWatch) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+func (m *FileWatch) GetDebounceSeconds() int64 {
+	if m != nil {
+		return m.DebounceSeconds
+	}
+	return 0
+}
+
+type Device struct {
+	// Path of the device node in the host
+	HostPath string `protobuf:"bytes,1,opt,name=hostPath" json:"hostPath,omitempty"`
+	// Path in the container, defaults to the host path
+	ContainerPath string `protobuf:"bytes,2,opt,name=containerPath" json:"containerPath,omitempty"`
+	// Combination of r, w and m, defaults to rwm
+	Permissions string `protobuf:"bytes,3,opt,name=permissions" json:"permissions,omitempty"`
+}
+
+func (m *Device) Reset()                    { *m = Device{} }
+func (m *Device) String() string            { return proto.CompactTextString(m) }
+func (*Device) ProtoMessage()               {}
+func (*Device) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *Device) GetHostPath() string {
+	if m != nil {
+		return m.HostPath
 	}
 	return ""
 }
 
-func (m *Container) GetImage() string {
+func (m *Device) GetContainerPath() string {
 	if m != nil {
-		return m.Image
+		return m.ContainerPath
 	}
 	return ""
 }
 
-func (m *Container) GetTty() bool {
+func (m *Device) GetPermissions() string {
 	if m != nil {
-		return m.Tty
+		return m.Permissions
 	}
-	return false
+	return ""
 }
 
-func (m *Container) GetWorkingDir() string {
+type IdleStop struct {
+	// How long the container must be idle before it gets stopped
+	TimeoutSeconds int64 `protobuf:"varint,1,opt,name=timeoutSeconds" json:"timeoutSeconds,omitempty"`
+	// Host TCP port what the container serves, eliotd starts the container on the first connection to it
+	Port int32 `protobuf:"varint,2,opt,name=port" json:"port,omitempty"`
+	// What tells the container is idle, "connections" (default) or "cpu"
+	Signal string `protobuf:"bytes,3,opt,name=signal" json:"signal,omitempty"`
+}
+
+func (m *IdleStop) Reset()                    { *m = IdleStop{} }
+func (m *IdleStop) String() string            { return proto.CompactTextString(m) }
+func (*IdleStop) ProtoMessage()               {}
+func (*IdleStop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *IdleStop) GetTimeoutSeconds() int64 {
 	if m != nil {
-		return m.WorkingDir
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+func (m *IdleStop) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *IdleStop) GetSignal() string {
+	if m != nil {
+		return m.Signal
 	}
 	return ""
 }
 
-func (m *Container) GetArgs() []string {
+type FileMount struct {
+	// Absolute path of the file in the container
+	Path string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	// Inline file content, replaced with the secret content if secret is given
+	Content string `protobuf:"bytes,2,opt,name=content" json:"content,omitempty"`
+	// Name of the node secret what gets written to the file
+	Secret string `protobuf:"bytes,3,opt,name=secret" json:"secret,omitempty"`
+	// File permission bits, zero means 0444
+	Mode uint32 `protobuf:"varint,4,opt,name=mode" json:"mode,omitempty"`
+}
+
+func (m *FileMount) Reset()                    { *m = FileMount{} }
+func (m *FileMount) String() string            { return proto.CompactTextString(m) }
+func (*FileMount) ProtoMessage()               {}
+func (*FileMount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *FileMount) GetPath() string {
 	if m != nil {
-		return m.Args
+		return m.Path
 	}
-	return nil
+	return ""
 }
 
-func (m *Container) GetEnv() []string {
+func (m *FileMount) GetContent() string {
 	if m != nil {
-		return m.Env
+		return m.Content
 	}
-	return nil
+	return ""
 }
 
-func (m *Container) GetMounts() []*Mount {
+func (m *FileMount) GetSecret() string {
 	if m != nil {
-		return m.Mounts
+		return m.Secret
 	}
-	return nil
+	return ""
 }
 
-func (m *Container) GetPipe() *PipeSet {
+func (m *FileMount) GetMode() uint32 {
 	if m != nil {
-		return m.Pipe
+		return m.Mode
+	}
+	return 0
+}
+
+type TmpfsMount struct {
+	Destination string `protobuf:"bytes,1,opt,name=destination" json:"destination,omitempty"`
+	// Size limit in bytes
+	SizeLimit int64    `protobuf:"varint,2,opt,name=sizeLimit" json:"sizeLimit,omitempty"`
+	Options   []string `protobuf:"bytes,3,rep,name=options" json:"options,omitempty"`
+}
+
+func (m *TmpfsMount) Reset()                    { *m = TmpfsMount{} }
+func (m *TmpfsMount) String() string            { return proto.CompactTextString(m) }
+func (*TmpfsMount) ProtoMessage()               {}
+func (*TmpfsMount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *TmpfsMount) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *TmpfsMount) GetSizeLimit() int64 {
+	if m != nil {
+		return m.SizeLimit
+	}
+	return 0
+}
+
+func (m *TmpfsMount) GetOptions() []string {
+	if m != nil {
+		return m.Options
 	}
 	return nil
 }
 
+type Ulimit struct {
+	// Limit name without RLIMIT_ prefix in lowercase, e.g. nofile
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Soft uint64 `protobuf:"varint,2,opt,name=soft" json:"soft,omitempty"`
+	Hard uint64 `protobuf:"varint,3,opt,name=hard" json:"hard,omitempty"`
+}
+
+func (m *Ulimit) Reset()                    { *m = Ulimit{} }
+func (m *Ulimit) String() string            { return proto.CompactTextString(m) }
+func (*Ulimit) ProtoMessage()               {}
+func (*Ulimit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *Ulimit) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Ulimit) GetSoft() uint64 {
+	if m != nil {
+		return m.Soft
+	}
+	return 0
+}
+
+func (m *Ulimit) GetHard() uint64 {
+	if m != nil {
+		return m.Hard
+	}
+	return 0
+}
+
+type Resources struct {
+	// Memory limit in bytes, zero means no limit
+	MemoryLimit int64 `protobuf:"varint,1,opt,name=memoryLimit" json:"memoryLimit,omitempty"`
+	// CPU limit in millicores (i.e. 1000 = one full CPU), zero means no limit
+	CpuLimit int64 `protobuf:"varint,2,opt,name=cpuLimit" json:"cpuLimit,omitempty"`
+	// Memory plus swap limit in bytes, equal to the memory limit disables swap and -1 allows unlimited swap
+	// Zero means the default what disables swap on cgroup v1 and leaves the host default on cgroup v2
+	MemorySwapLimit int64 `protobuf:"varint,3,opt,name=memorySwapLimit" json:"memorySwapLimit,omitempty"`
+}
+
+func (m *Resources) Reset()                    { *m = Resources{} }
+func (m *Resources) String() string            { return proto.CompactTextString(m) }
+func (*Resources) ProtoMessage()               {}
+func (*Resources) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *Resources) GetMemoryLimit() int64 {
+	if m != nil {
+		return m.MemoryLimit
+	}
+	return 0
+}
+
+func (m *Resources) GetCpuLimit() int64 {
+	if m != nil {
+		return m.CpuLimit
+	}
+	return 0
+}
+
+func (m *Resources) GetMemorySwapLimit() int64 {
+	if m != nil {
+		return m.MemorySwapLimit
+	}
+	return 0
+}
+
 type PipeSet struct {
 	Stdout *PipeFromStdout `protobuf:"bytes,1,opt,name=stdout" json:"stdout,omitempty"`
 }
@@ -202,7 +1325,7 @@ type PipeSet struct {
 func (m *PipeSet) Reset()                    { *m = PipeSet{} }
 func (m *PipeSet) String() string            { return proto.CompactTextString(m) }
 func (*PipeSet) ProtoMessage()               {}
-func (*PipeSet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*PipeSet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *PipeSet) GetStdout() *PipeFromStdout {
 	if m != nil {
@@ -218,7 +1341,7 @@ type PipeFromStdout struct {
 func (m *PipeFromStdout) Reset()                    { *m = PipeFromStdout{} }
 func (m *PipeFromStdout) String() string            { return proto.CompactTextString(m) }
 func (*PipeFromStdout) ProtoMessage()               {}
-func (*PipeFromStdout) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*PipeFromStdout) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *PipeFromStdout) GetStdin() *PipeToStdin {
 	if m != nil {
@@ -234,7 +1357,7 @@ type PipeToStdin struct {
 func (m *PipeToStdin) Reset()                    { *m = PipeToStdin{} }
 func (m *PipeToStdin) String() string            { return proto.CompactTextString(m) }
 func (*PipeToStdin) ProtoMessage()               {}
-func (*PipeToStdin) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*PipeToStdin) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 func (m *PipeToStdin) GetName() string {
 	if m != nil {
@@ -248,85 +1371,272 @@ type Mount struct {
 	Source      string   `protobuf:"bytes,2,opt,name=source" json:"source,omitempty"`
 	Destination string   `protobuf:"bytes,3,opt,name=destination" json:"destination,omitempty"`
 	Options     []string `protobuf:"bytes,4,rep,name=options" json:"options,omitempty"`
+	// Mount propagation mode, one of shared, slave, private, rshared, rslave, rprivate
+	Propagation string `protobuf:"bytes,5,opt,name=propagation" json:"propagation,omitempty"`
 }
 
 func (m *Mount) Reset()                    { *m = Mount{} }
 func (m *Mount) String() string            { return proto.CompactTextString(m) }
 func (*Mount) ProtoMessage()               {}
-func (*Mount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*Mount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *Mount) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Mount) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Mount) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *Mount) GetOptions() []string {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *Mount) GetPropagation() string {
+	if m != nil {
+		return m.Propagation
+	}
+	return ""
+}
+
+type ContainerStatus struct {
+	ContainerID  string `protobuf:"bytes,1,opt,name=containerID" json:"containerID,omitempty"`
+	Name         string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Image        string `protobuf:"bytes,3,opt,name=image" json:"image,omitempty"`
+	State        string `protobuf:"bytes,4,opt,name=state" json:"state,omitempty"`
+	RestartCount int32  `protobuf:"varint,5,opt,name=restartCount" json:"restartCount,omitempty"`
+	// Exit code and reason (Completed, Error, OOMKilled) of the previous run
+	LastExitCode   int32  `protobuf:"varint,6,opt,name=lastExitCode" json:"lastExitCode,omitempty"`
+	LastExitReason string `protobuf:"bytes,7,opt,name=lastExitReason" json:"lastExitReason,omitempty"`
+	// True when the container is running and its readiness probe, if any, succeeds
+	Ready bool `protobuf:"varint,8,opt,name=ready" json:"ready,omitempty"`
+	// Unix time in seconds when the container was started the last time, zero if never
+	LastStartedAt int64 `protobuf:"varint,9,opt,name=lastStartedAt" json:"lastStartedAt,omitempty"`
+}
+
+func (m *ContainerStatus) Reset()                    { *m = ContainerStatus{} }
+func (m *ContainerStatus) String() string            { return proto.CompactTextString(m) }
+func (*ContainerStatus) ProtoMessage()               {}
+func (*ContainerStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *ContainerStatus) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+func (m *ContainerStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ContainerStatus) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *ContainerStatus) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ContainerStatus) GetRestartCount() int32 {
+	if m != nil {
+		return m.RestartCount
+	}
+	return 0
+}
+
+func (m *ContainerStatus) GetLastExitCode() int32 {
+	if m != nil {
+		return m.LastExitCode
+	}
+	return 0
+}
+
+func (m *ContainerStatus) GetLastExitReason() string {
+	if m != nil {
+		return m.LastExitReason
+	}
+	return ""
+}
+
+func (m *ContainerStatus) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+func (m *ContainerStatus) GetLastStartedAt() int64 {
+	if m != nil {
+		return m.LastStartedAt
+	}
+	return 0
+}
+
+type ContainerStatsRequest struct {
+	Namespace   string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	ContainerID string `protobuf:"bytes,2,opt,name=containerID" json:"containerID,omitempty"`
+	// Interval between the samples in milliseconds, defaults to one second
+	Interval int64 `protobuf:"varint,3,opt,name=interval" json:"interval,omitempty"`
+}
+
+func (m *ContainerStatsRequest) Reset()                    { *m = ContainerStatsRequest{} }
+func (m *ContainerStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ContainerStatsRequest) ProtoMessage()               {}
+func (*ContainerStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *ContainerStatsRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ContainerStatsRequest) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+func (m *ContainerStatsRequest) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+type ContainerStatsResponse struct {
+	Stats *ContainerStats `protobuf:"bytes,1,opt,name=stats" json:"stats,omitempty"`
+}
 
-func (m *Mount) GetType() string {
+func (m *ContainerStatsResponse) Reset()                    { *m = ContainerStatsResponse{} }
+func (m *ContainerStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ContainerStatsResponse) ProtoMessage()               {}
+func (*ContainerStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *ContainerStatsResponse) GetStats() *ContainerStats {
 	if m != nil {
-		return m.Type
+		return m.Stats
 	}
-	return ""
+	return nil
 }
 
-func (m *Mount) GetSource() string {
+type ContainerStats struct {
+	ContainerID string `protobuf:"bytes,1,opt,name=containerID" json:"containerID,omitempty"`
+	// Unix time of the sample in milliseconds
+	Time int64 `protobuf:"varint,2,opt,name=time" json:"time,omitempty"`
+	// CPU usage since the previous sample as percentage of single CPU, zero in the first sample
+	CpuPercent float64 `protobuf:"fixed64,3,opt,name=cpuPercent" json:"cpuPercent,omitempty"`
+	// Memory usage and limit in bytes
+	MemoryUsage uint64 `protobuf:"varint,4,opt,name=memoryUsage" json:"memoryUsage,omitempty"`
+	MemoryLimit uint64 `protobuf:"varint,5,opt,name=memoryLimit" json:"memoryLimit,omitempty"`
+	// True if the runtime reports the network counters, the network fields are zero otherwise
+	NetworkAvailable bool `protobuf:"varint,6,opt,name=networkAvailable" json:"networkAvailable,omitempty"`
+	// Total bytes received and transmitted
+	NetworkRxBytes uint64 `protobuf:"varint,7,opt,name=networkRxBytes" json:"networkRxBytes,omitempty"`
+	NetworkTxBytes uint64 `protobuf:"varint,8,opt,name=networkTxBytes" json:"networkTxBytes,omitempty"`
+	// Bytes per second received and transmitted since the previous sample, zero in the first sample
+	NetworkRxRate float64 `protobuf:"fixed64,9,opt,name=networkRxRate" json:"networkRxRate,omitempty"`
+	NetworkTxRate float64 `protobuf:"fixed64,10,opt,name=networkTxRate" json:"networkTxRate,omitempty"`
+}
+
+func (m *ContainerStats) Reset()                    { *m = ContainerStats{} }
+func (m *ContainerStats) String() string            { return proto.CompactTextString(m) }
+func (*ContainerStats) ProtoMessage()               {}
+func (*ContainerStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *ContainerStats) GetContainerID() string {
 	if m != nil {
-		return m.Source
+		return m.ContainerID
 	}
 	return ""
 }
 
-func (m *Mount) GetDestination() string {
+func (m *ContainerStats) GetTime() int64 {
 	if m != nil {
-		return m.Destination
+		return m.Time
 	}
-	return ""
+	return 0
 }
 
-func (m *Mount) GetOptions() []string {
+func (m *ContainerStats) GetCpuPercent() float64 {
 	if m != nil {
-		return m.Options
+		return m.CpuPercent
 	}
-	return nil
+	return 0
 }
 
-type ContainerStatus struct {
-	ContainerID  string `protobuf:"bytes,1,opt,name=containerID" json:"containerID,omitempty"`
-	Name         string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	Image        string `protobuf:"bytes,3,opt,name=image" json:"image,omitempty"`
-	State        string `protobuf:"bytes,4,opt,name=state" json:"state,omitempty"`
-	RestartCount int32  `protobuf:"varint,5,opt,name=restartCount" json:"restartCount,omitempty"`
+func (m *ContainerStats) GetMemoryUsage() uint64 {
+	if m != nil {
+		return m.MemoryUsage
+	}
+	return 0
 }
 
-func (m *ContainerStatus) Reset()                    { *m = ContainerStatus{} }
-func (m *ContainerStatus) String() string            { return proto.CompactTextString(m) }
-func (*ContainerStatus) ProtoMessage()               {}
-func (*ContainerStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (m *ContainerStats) GetMemoryLimit() uint64 {
+	if m != nil {
+		return m.MemoryLimit
+	}
+	return 0
+}
 
-func (m *ContainerStatus) GetContainerID() string {
+func (m *ContainerStats) GetNetworkAvailable() bool {
 	if m != nil {
-		return m.ContainerID
+		return m.NetworkAvailable
 	}
-	return ""
+	return false
 }
 
-func (m *ContainerStatus) GetName() string {
+func (m *ContainerStats) GetNetworkRxBytes() uint64 {
 	if m != nil {
-		return m.Name
+		return m.NetworkRxBytes
 	}
-	return ""
+	return 0
 }
 
-func (m *ContainerStatus) GetImage() string {
+func (m *ContainerStats) GetNetworkTxBytes() uint64 {
 	if m != nil {
-		return m.Image
+		return m.NetworkTxBytes
 	}
-	return ""
+	return 0
 }
 
-func (m *ContainerStatus) GetState() string {
+func (m *ContainerStats) GetNetworkRxRate() float64 {
 	if m != nil {
-		return m.State
+		return m.NetworkRxRate
 	}
-	return ""
+	return 0
 }
 
-func (m *ContainerStatus) GetRestartCount() int32 {
+func (m *ContainerStats) GetNetworkTxRate() float64 {
 	if m != nil {
-		return m.RestartCount
+		return m.NetworkTxRate
 	}
 	return 0
 }
@@ -336,12 +1646,42 @@ func init() {
 	proto.RegisterType((*StdoutStreamResponse)(nil), "eliot.services.containers.v1.StdoutStreamResponse")
 	proto.RegisterType((*SignalRequest)(nil), "eliot.services.containers.v1.SignalRequest")
 	proto.RegisterType((*SignalResponse)(nil), "eliot.services.containers.v1.SignalResponse")
+	proto.RegisterType((*ContainerStatusesRequest)(nil), "eliot.services.containers.v1.ContainerStatusesRequest")
+	proto.RegisterType((*ContainerStatusesResponse)(nil), "eliot.services.containers.v1.ContainerStatusesResponse")
+	proto.RegisterType((*WaitRequest)(nil), "eliot.services.containers.v1.WaitRequest")
+	proto.RegisterType((*WaitResponse)(nil), "eliot.services.containers.v1.WaitResponse")
+	proto.RegisterType((*InspectContainerRequest)(nil), "eliot.services.containers.v1.InspectContainerRequest")
+	proto.RegisterType((*InspectContainerResponse)(nil), "eliot.services.containers.v1.InspectContainerResponse")
+	proto.RegisterType((*DiffContainerRequest)(nil), "eliot.services.containers.v1.DiffContainerRequest")
+	proto.RegisterType((*DiffContainerResponse)(nil), "eliot.services.containers.v1.DiffContainerResponse")
+	proto.RegisterType((*CommitContainerRequest)(nil), "eliot.services.containers.v1.CommitContainerRequest")
+	proto.RegisterType((*CommitContainerResponse)(nil), "eliot.services.containers.v1.CommitContainerResponse")
+	proto.RegisterType((*MoveContainerRequest)(nil), "eliot.services.containers.v1.MoveContainerRequest")
+	proto.RegisterType((*MoveContainerResponse)(nil), "eliot.services.containers.v1.MoveContainerResponse")
+	proto.RegisterType((*TopRequest)(nil), "eliot.services.containers.v1.TopRequest")
+	proto.RegisterType((*TopResponse)(nil), "eliot.services.containers.v1.TopResponse")
+	proto.RegisterType((*LogsRequest)(nil), "eliot.services.containers.v1.LogsRequest")
+	proto.RegisterType((*LogsResponse)(nil), "eliot.services.containers.v1.LogsResponse")
+	proto.RegisterType((*Process)(nil), "eliot.services.containers.v1.Process")
+	proto.RegisterType((*FileChange)(nil), "eliot.services.containers.v1.FileChange")
 	proto.RegisterType((*Container)(nil), "eliot.services.containers.v1.Container")
+	proto.RegisterType((*Capabilities)(nil), "eliot.services.containers.v1.Capabilities")
+	proto.RegisterType((*Probe)(nil), "eliot.services.containers.v1.Probe")
+	proto.RegisterType((*FileWatch)(nil), "eliot.services.containers.v1.FileWatch")
+	proto.RegisterType((*Device)(nil), "eliot.services.containers.v1.Device")
+	proto.RegisterType((*IdleStop)(nil), "eliot.services.containers.v1.IdleStop")
+	proto.RegisterType((*FileMount)(nil), "eliot.services.containers.v1.FileMount")
+	proto.RegisterType((*TmpfsMount)(nil), "eliot.services.containers.v1.TmpfsMount")
+	proto.RegisterType((*Ulimit)(nil), "eliot.services.containers.v1.Ulimit")
+	proto.RegisterType((*Resources)(nil), "eliot.services.containers.v1.Resources")
 	proto.RegisterType((*PipeSet)(nil), "eliot.services.containers.v1.PipeSet")
 	proto.RegisterType((*PipeFromStdout)(nil), "eliot.services.containers.v1.PipeFromStdout")
 	proto.RegisterType((*PipeToStdin)(nil), "eliot.services.containers.v1.PipeToStdin")
 	proto.RegisterType((*Mount)(nil), "eliot.services.containers.v1.Mount")
 	proto.RegisterType((*ContainerStatus)(nil), "eliot.services.containers.v1.ContainerStatus")
+	proto.RegisterType((*ContainerStatsRequest)(nil), "eliot.services.containers.v1.ContainerStatsRequest")
+	proto.RegisterType((*ContainerStatsResponse)(nil), "eliot.services.containers.v1.ContainerStatsResponse")
+	proto.RegisterType((*ContainerStats)(nil), "eliot.services.containers.v1.ContainerStats")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -358,6 +1698,15 @@ type ContainersClient interface {
 	Attach(ctx context.Context, opts ...grpc.CallOption) (Containers_AttachClient, error)
 	Exec(ctx context.Context, opts ...grpc.CallOption) (Containers_ExecClient, error)
 	Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*SignalResponse, error)
+	Statuses(ctx context.Context, in *ContainerStatusesRequest, opts ...grpc.CallOption) (*ContainerStatusesResponse, error)
+	Wait(ctx context.Context, in *WaitRequest, opts ...grpc.CallOption) (*WaitResponse, error)
+	Inspect(ctx context.Context, in *InspectContainerRequest, opts ...grpc.CallOption) (*InspectContainerResponse, error)
+	Diff(ctx context.Context, in *DiffContainerRequest, opts ...grpc.CallOption) (*DiffContainerResponse, error)
+	Commit(ctx context.Context, in *CommitContainerRequest, opts ...grpc.CallOption) (*CommitContainerResponse, error)
+	Move(ctx context.Context, in *MoveContainerRequest, opts ...grpc.CallOption) (*MoveContainerResponse, error)
+	Top(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (*TopResponse, error)
+	ContainerStats(ctx context.Context, in *ContainerStatsRequest, opts ...grpc.CallOption) (Containers_ContainerStatsClient, error)
+	Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (*LogsResponse, error)
 }
 
 type containersClient struct {
@@ -439,12 +1788,125 @@ func (c *containersClient) Signal(ctx context.Context, in *SignalRequest, opts .
 	return out, nil
 }
 
+func (c *containersClient) Statuses(ctx context.Context, in *ContainerStatusesRequest, opts ...grpc.CallOption) (*ContainerStatusesResponse, error) {
+	out := new(ContainerStatusesResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Containers/Statuses", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containersClient) Wait(ctx context.Context, in *WaitRequest, opts ...grpc.CallOption) (*WaitResponse, error) {
+	out := new(WaitResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Containers/Wait", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containersClient) Inspect(ctx context.Context, in *InspectContainerRequest, opts ...grpc.CallOption) (*InspectContainerResponse, error) {
+	out := new(InspectContainerResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Containers/Inspect", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containersClient) Diff(ctx context.Context, in *DiffContainerRequest, opts ...grpc.CallOption) (*DiffContainerResponse, error) {
+	out := new(DiffContainerResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Containers/Diff", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containersClient) Commit(ctx context.Context, in *CommitContainerRequest, opts ...grpc.CallOption) (*CommitContainerResponse, error) {
+	out := new(CommitContainerResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Containers/Commit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containersClient) Move(ctx context.Context, in *MoveContainerRequest, opts ...grpc.CallOption) (*MoveContainerResponse, error) {
+	out := new(MoveContainerResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Containers/Move", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containersClient) Top(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (*TopResponse, error) {
+	out := new(TopResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Containers/Top", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containersClient) ContainerStats(ctx context.Context, in *ContainerStatsRequest, opts ...grpc.CallOption) (Containers_ContainerStatsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Containers_serviceDesc.Streams[2], c.cc, "/eliot.services.containers.v1.Containers/ContainerStats", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &containersContainerStatsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Containers_ContainerStatsClient interface {
+	Recv() (*ContainerStatsResponse, error)
+	grpc.ClientStream
+}
+
+type containersContainerStatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *containersContainerStatsClient) Recv() (*ContainerStatsResponse, error) {
+	m := new(ContainerStatsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *containersClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (*LogsResponse, error) {
+	out := new(LogsResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Containers/Logs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Containers service
 
 type ContainersServer interface {
 	Attach(Containers_AttachServer) error
 	Exec(Containers_ExecServer) error
 	Signal(context.Context, *SignalRequest) (*SignalResponse, error)
+	Statuses(context.Context, *ContainerStatusesRequest) (*ContainerStatusesResponse, error)
+	Wait(context.Context, *WaitRequest) (*WaitResponse, error)
+	Inspect(context.Context, *InspectContainerRequest) (*InspectContainerResponse, error)
+	Diff(context.Context, *DiffContainerRequest) (*DiffContainerResponse, error)
+	Commit(context.Context, *CommitContainerRequest) (*CommitContainerResponse, error)
+	Move(context.Context, *MoveContainerRequest) (*MoveContainerResponse, error)
+	Top(context.Context, *TopRequest) (*TopResponse, error)
+	ContainerStats(*ContainerStatsRequest, Containers_ContainerStatsServer) error
+	Logs(context.Context, *LogsRequest) (*LogsResponse, error)
 }
 
 func RegisterContainersServer(s *grpc.Server, srv ContainersServer) {
@@ -521,6 +1983,171 @@ func _Containers_Signal_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Containers_Statuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainerStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainersServer).Statuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Containers/Statuses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainersServer).Statuses(ctx, req.(*ContainerStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Containers_Wait_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainersServer).Wait(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Containers/Wait",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainersServer).Wait(ctx, req.(*WaitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Containers_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainersServer).Inspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Containers/Inspect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainersServer).Inspect(ctx, req.(*InspectContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Containers_Diff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainersServer).Diff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Containers/Diff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainersServer).Diff(ctx, req.(*DiffContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Containers_Commit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainersServer).Commit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Containers/Commit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainersServer).Commit(ctx, req.(*CommitContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Containers_Move_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainersServer).Move(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Containers/Move",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainersServer).Move(ctx, req.(*MoveContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Containers_Top_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainersServer).Top(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Containers/Top",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainersServer).Top(ctx, req.(*TopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Containers_ContainerStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ContainerStatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContainersServer).ContainerStats(m, &containersContainerStatsServer{stream})
+}
+
+type Containers_ContainerStatsServer interface {
+	Send(*ContainerStatsResponse) error
+	grpc.ServerStream
+}
+
+type containersContainerStatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *containersContainerStatsServer) Send(m *ContainerStatsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Containers_Logs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainersServer).Logs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Containers/Logs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainersServer).Logs(ctx, req.(*LogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Containers_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "eliot.services.containers.v1.Containers",
 	HandlerType: (*ContainersServer)(nil),
@@ -529,6 +2156,38 @@ var _Containers_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Signal",
 			Handler:    _Containers_Signal_Handler,
 		},
+		{
+			MethodName: "Statuses",
+			Handler:    _Containers_Statuses_Handler,
+		},
+		{
+			MethodName: "Wait",
+			Handler:    _Containers_Wait_Handler,
+		},
+		{
+			MethodName: "Inspect",
+			Handler:    _Containers_Inspect_Handler,
+		},
+		{
+			MethodName: "Diff",
+			Handler:    _Containers_Diff_Handler,
+		},
+		{
+			MethodName: "Commit",
+			Handler:    _Containers_Commit_Handler,
+		},
+		{
+			MethodName: "Move",
+			Handler:    _Containers_Move_Handler,
+		},
+		{
+			MethodName: "Top",
+			Handler:    _Containers_Top_Handler,
+		},
+		{
+			MethodName: "Logs",
+			Handler:    _Containers_Logs_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -543,6 +2202,11 @@ var _Containers_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ContainerStats",
+			Handler:       _Containers_ContainerStats_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "services/containers/v1/containers.proto",
 }
@@ -550,43 +2214,153 @@ var _Containers_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("services/containers/v1/containers.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 596 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x54, 0xdd, 0x6e, 0xd4, 0x3c,
-	0x10, 0x55, 0xf6, 0xaf, 0xdd, 0xd9, 0x7e, 0xfd, 0x2a, 0xab, 0x42, 0x51, 0x55, 0xa1, 0x10, 0x84,
-	0x58, 0xa0, 0xda, 0xb4, 0xcb, 0x15, 0xea, 0x05, 0x82, 0xfe, 0x48, 0x5c, 0x20, 0xc0, 0xcb, 0x15,
-	0x77, 0x6e, 0xd6, 0x4a, 0xad, 0x36, 0xb6, 0xb1, 0x27, 0x0b, 0x7d, 0x00, 0x9e, 0x81, 0x47, 0xe3,
-	0x75, 0x90, 0x1d, 0x67, 0x9b, 0xd2, 0xaa, 0xcb, 0x15, 0x77, 0x73, 0x8e, 0x67, 0xce, 0x38, 0x9e,
-	0x93, 0x81, 0xa7, 0x96, 0x9b, 0x85, 0xc8, 0xb9, 0xcd, 0x72, 0x25, 0x91, 0x09, 0xc9, 0x8d, 0xcd,
-	0x16, 0x07, 0x2d, 0x34, 0xd1, 0x46, 0xa1, 0x22, 0xbb, 0xfc, 0x52, 0x28, 0x9c, 0x34, 0xe9, 0x93,
-	0x56, 0xc2, 0xe2, 0x20, 0x7d, 0x0e, 0x64, 0x86, 0x73, 0x21, 0x67, 0x68, 0x38, 0x2b, 0x29, 0xff,
-	0x5a, 0x71, 0x8b, 0x64, 0x1b, 0xfa, 0x42, 0xea, 0x0a, 0xe3, 0x28, 0x89, 0xc6, 0x1b, 0xb4, 0x06,
-	0xe9, 0x29, 0x6c, 0xcf, 0x70, 0xae, 0x2a, 0x6c, 0x92, 0xad, 0x56, 0xd2, 0x72, 0xf2, 0x00, 0x06,
-	0xaa, 0xc2, 0xeb, 0xf4, 0x80, 0x1c, 0x6f, 0x71, 0xce, 0x8d, 0x89, 0x3b, 0x49, 0x34, 0x5e, 0xa7,
-	0x01, 0xa5, 0x05, 0xfc, 0x37, 0x13, 0x85, 0x64, 0x97, 0x4d, 0xbb, 0x5d, 0x18, 0x4a, 0x56, 0x72,
-	0xab, 0x59, 0xce, 0xbd, 0xc6, 0x90, 0x5e, 0x13, 0x24, 0x81, 0xd1, 0xf2, 0xce, 0xef, 0x8e, 0xbd,
-	0xd6, 0x90, 0xb6, 0x29, 0xdf, 0xc8, 0x0b, 0xc6, 0xdd, 0x24, 0x1a, 0xf7, 0x69, 0x40, 0xe9, 0x16,
-	0x6c, 0x36, 0x8d, 0xea, 0xab, 0xa6, 0x3f, 0x3a, 0x30, 0x3c, 0x6a, 0x2a, 0x09, 0x81, 0x9e, 0x6b,
-	0x13, 0x5a, 0xfa, 0xd8, 0x7f, 0x7a, 0xc9, 0x0a, 0x1e, 0xfa, 0xd4, 0x80, 0x6c, 0x41, 0x17, 0xf1,
-	0xca, 0xcb, 0xaf, 0x53, 0x17, 0x92, 0x87, 0x00, 0xdf, 0x94, 0xb9, 0x10, 0xb2, 0x38, 0x16, 0x26,
-	0xee, 0xf9, 0xe4, 0x16, 0xe3, 0xb4, 0x99, 0x29, 0x6c, 0xdc, 0x4f, 0xba, 0x4e, 0xdb, 0xc5, 0x4e,
-	0x85, 0xcb, 0x45, 0x3c, 0xf0, 0x94, 0x0b, 0xc9, 0x21, 0x0c, 0x4a, 0x55, 0x49, 0xb4, 0xf1, 0x5a,
-	0xd2, 0x1d, 0x8f, 0xa6, 0x8f, 0x27, 0xf7, 0x4d, 0x6b, 0xf2, 0xde, 0xe5, 0xd2, 0x50, 0x42, 0x5e,
-	0x41, 0x4f, 0x0b, 0xcd, 0xe3, 0xf5, 0x24, 0x1a, 0x8f, 0xa6, 0x4f, 0xee, 0x2f, 0xfd, 0x28, 0x34,
-	0x9f, 0x71, 0xa4, 0xbe, 0x24, 0xfd, 0x00, 0x6b, 0x81, 0x20, 0xc7, 0x7e, 0x4a, 0x2a, 0x4c, 0x6f,
-	0x34, 0xdd, 0x5b, 0xad, 0x73, 0x6a, 0x54, 0x59, 0x3b, 0x81, 0x86, 0xda, 0xf4, 0x13, 0x6c, 0xde,
-	0x3c, 0x21, 0xaf, 0xa1, 0x6f, 0x9d, 0xb3, 0x82, 0xec, 0xb3, 0xd5, 0xb2, 0x9f, 0x95, 0xb7, 0x22,
-	0xad, 0xeb, 0xd2, 0x47, 0x30, 0x6a, 0xb1, 0x77, 0x0d, 0x2b, 0x55, 0xd0, 0xf7, 0x4f, 0xe2, 0x0e,
-	0xf1, 0x4a, 0x2f, 0x0f, 0x5d, 0xec, 0x5d, 0xa1, 0x2a, 0x93, 0x37, 0xa3, 0x0c, 0xc8, 0xf9, 0x69,
-	0xce, 0x2d, 0x0a, 0xc9, 0x50, 0x28, 0xe9, 0x67, 0x3a, 0xa4, 0x6d, 0x8a, 0xc4, 0xb0, 0xa6, 0xb4,
-	0x8b, 0x6c, 0xdc, 0xf3, 0xb3, 0x6a, 0x60, 0xfa, 0x33, 0x82, 0xff, 0x97, 0xfe, 0x99, 0x21, 0xc3,
-	0xca, 0xfe, 0xe9, 0xcf, 0xe8, 0xb6, 0x3f, 0x9b, 0xab, 0x77, 0xee, 0xf2, 0x59, 0xb7, 0xed, 0xb3,
-	0x6d, 0xf7, 0x68, 0x0c, 0x79, 0x30, 0x54, 0x0d, 0x48, 0x0a, 0x1b, 0x86, 0x5b, 0x64, 0x06, 0x8f,
-	0xdc, 0xd7, 0xc6, 0x7d, 0xef, 0xf2, 0x1b, 0xdc, 0xf4, 0x57, 0x07, 0x60, 0x79, 0x33, 0x4b, 0x0c,
-	0x0c, 0xde, 0x20, 0xb2, 0xfc, 0x9c, 0xec, 0xdf, 0xff, 0xf0, 0xb7, 0xff, 0xfe, 0x9d, 0xe9, 0xca,
-	0x8a, 0x5b, 0x3b, 0x60, 0x1c, 0xed, 0x47, 0x44, 0x43, 0xef, 0xe4, 0x3b, 0xcf, 0xff, 0x61, 0xc7,
-	0x1c, 0x06, 0xf5, 0x0f, 0x4e, 0x5e, 0xac, 0x50, 0x68, 0xef, 0x9b, 0x9d, 0xbd, 0xbf, 0x4b, 0xae,
-	0x1b, 0xbd, 0x3d, 0xf9, 0x72, 0x54, 0x08, 0x3c, 0xaf, 0xce, 0x26, 0xb9, 0x2a, 0x33, 0x6e, 0xa4,
-	0x62, 0x4c, 0xb3, 0xcc, 0x4b, 0x64, 0xfa, 0xa2, 0xc8, 0x98, 0x16, 0xd9, 0xdd, 0xdb, 0xf8, 0xf0,
-	0x1a, 0x9d, 0x0d, 0xfc, 0x3a, 0x7e, 0xf9, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x5f, 0x21, 0xd8, 0x14,
-	0xb9, 0x05, 0x00, 0x00,
+	// 2354 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0x5b, 0x73, 0x1b, 0xb7,
+	0x15, 0x9e, 0x15, 0x2f, 0x12, 0x8f, 0x2e, 0x56, 0x11, 0x3b, 0x41, 0xd8, 0x34, 0x55, 0x36, 0x8e,
+	0xad, 0xb8, 0xa9, 0xe4, 0xd8, 0x4e, 0x9a, 0xd8, 0x53, 0x77, 0x64, 0x49, 0x9e, 0x7a, 0x7c, 0x89,
+	0xbc, 0x54, 0x9a, 0x89, 0x9b, 0x66, 0x06, 0xda, 0x85, 0x48, 0xc4, 0xcb, 0xc5, 0x16, 0x00, 0x19,
+	0xb3, 0x9d, 0x4e, 0xfb, 0xd8, 0xd7, 0xfe, 0x82, 0xfe, 0x90, 0xbe, 0xf4, 0xad, 0x3f, 0xa2, 0xff,
+	0xa2, 0x4f, 0x7d, 0xec, 0x1c, 0x00, 0xbb, 0x5c, 0x52, 0xb2, 0x48, 0x65, 0x34, 0x7d, 0xc3, 0xf9,
+	0xf6, 0x9c, 0x83, 0x83, 0x73, 0xc3, 0x65, 0xe1, 0xba, 0xe6, 0x6a, 0x28, 0x62, 0xae, 0xb7, 0x63,
+	0x99, 0x19, 0x26, 0x32, 0xae, 0xf4, 0xf6, 0xf0, 0xe3, 0x0a, 0xb5, 0x95, 0x2b, 0x69, 0x24, 0x79,
+	0x87, 0xa7, 0x42, 0x9a, 0xad, 0x82, 0x7d, 0xab, 0xc2, 0x30, 0xfc, 0x38, 0xbc, 0x01, 0xa4, 0x63,
+	0x12, 0x91, 0x75, 0x8c, 0xe2, 0xac, 0x1f, 0xf1, 0xdf, 0x0f, 0xb8, 0x36, 0xe4, 0x32, 0x34, 0x44,
+	0x96, 0x0f, 0x0c, 0x0d, 0x36, 0x82, 0xcd, 0x95, 0xc8, 0x11, 0xe1, 0x43, 0xb8, 0xdc, 0x31, 0x89,
+	0x1c, 0x98, 0x82, 0x59, 0xe7, 0x32, 0xd3, 0x9c, 0xbc, 0x09, 0x4d, 0x39, 0x30, 0x63, 0x76, 0x4f,
+	0x21, 0xae, 0x4d, 0xc2, 0x95, 0xa2, 0x0b, 0x1b, 0xc1, 0xe6, 0x52, 0xe4, 0xa9, 0xb0, 0x0b, 0xab,
+	0x1d, 0xd1, 0xcd, 0x58, 0x5a, 0x4c, 0xf7, 0x0e, 0xb4, 0x32, 0xd6, 0xe7, 0x3a, 0x67, 0x31, 0xb7,
+	0x3a, 0x5a, 0xd1, 0x18, 0x20, 0x1b, 0xb0, 0x5c, 0xda, 0xfc, 0x68, 0xcf, 0xea, 0x6a, 0x45, 0x55,
+	0xc8, 0x4e, 0x64, 0x15, 0xd2, 0xda, 0x46, 0xb0, 0xd9, 0x88, 0x3c, 0x15, 0xae, 0xc3, 0x5a, 0x31,
+	0x91, 0x33, 0x35, 0xfc, 0x06, 0xe8, 0x6e, 0x21, 0xd8, 0x31, 0xcc, 0x0c, 0x34, 0xd7, 0xf3, 0x59,
+	0x11, 0xc2, 0x4a, 0x65, 0x4a, 0x4d, 0x17, 0x36, 0x6a, 0x9b, 0xad, 0x68, 0x02, 0x0b, 0xff, 0x11,
+	0xc0, 0xdb, 0xa7, 0xa8, 0xf7, 0x6e, 0x62, 0xb0, 0xa4, 0x3d, 0x46, 0x83, 0x8d, 0xda, 0xe6, 0xf2,
+	0xad, 0xfd, 0xad, 0xb3, 0x62, 0xb3, 0xf5, 0x5a, 0x55, 0x5b, 0x05, 0xb0, 0x9f, 0x19, 0x35, 0x8a,
+	0x4a, 0xb5, 0xed, 0x7b, 0xb0, 0x3a, 0xf1, 0x89, 0xac, 0x43, 0xed, 0x25, 0x1f, 0xf9, 0xd5, 0xe0,
+	0x10, 0x43, 0x3b, 0x64, 0xe9, 0x80, 0x7b, 0x3f, 0x3a, 0xe2, 0xee, 0xc2, 0x67, 0x41, 0xf8, 0x67,
+	0x58, 0xfe, 0x8a, 0x09, 0x73, 0x91, 0x41, 0xb1, 0xb6, 0xd8, 0xa0, 0xb4, 0x22, 0x4f, 0x11, 0x0a,
+	0x8b, 0x46, 0xf4, 0xb9, 0x1c, 0x18, 0x5a, 0xdf, 0x08, 0x36, 0x6b, 0x51, 0x41, 0x86, 0x6b, 0xb0,
+	0xe2, 0x0c, 0xf0, 0xc1, 0xfa, 0x1a, 0xde, 0x7a, 0x94, 0xe9, 0x9c, 0xc7, 0xa6, 0xf4, 0xc4, 0x05,
+	0x19, 0x17, 0xfe, 0x7b, 0x01, 0xe8, 0x49, 0xdd, 0x3e, 0x50, 0x53, 0xe2, 0xc1, 0xc9, 0xb5, 0x61,
+	0x7d, 0xf4, 0x59, 0xb7, 0x74, 0xa2, 0x25, 0xc8, 0x0b, 0x68, 0xa6, 0xec, 0x88, 0xa7, 0xb8, 0x62,
+	0x0c, 0xef, 0x83, 0xb3, 0xc3, 0xfb, 0xba, 0xf9, 0xb7, 0x9e, 0x58, 0x25, 0x2e, 0xb6, 0x5e, 0x23,
+	0x7a, 0x4d, 0x0d, 0x32, 0xf4, 0x94, 0xf5, 0x5a, 0x2b, 0x2a, 0x48, 0xb4, 0x56, 0x67, 0x2c, 0xd7,
+	0x3d, 0x69, 0x0c, 0x57, 0xb4, 0xe1, 0xac, 0xad, 0x40, 0x55, 0x8e, 0xc7, 0x7c, 0x44, 0x9b, 0x93,
+	0x1c, 0x8f, 0xf9, 0x88, 0x10, 0xa8, 0xa3, 0x2d, 0x74, 0xd1, 0xd6, 0xaf, 0x1d, 0xb7, 0x3f, 0x87,
+	0xe5, 0x8a, 0x21, 0xe7, 0xca, 0xa4, 0xdf, 0xc0, 0xe5, 0x3d, 0x71, 0x7c, 0x7c, 0xe1, 0x51, 0xfb,
+	0x2d, 0x5c, 0x99, 0xd2, 0xeb, 0x23, 0xf6, 0x00, 0x16, 0xe3, 0x1e, 0xcb, 0xba, 0x65, 0x65, 0x6d,
+	0x9e, 0xed, 0xfa, 0x87, 0x22, 0xe5, 0xbb, 0x56, 0x20, 0x2a, 0x04, 0xc3, 0xef, 0xe0, 0xcd, 0x5d,
+	0xd9, 0xef, 0x8b, 0x0b, 0x4f, 0x36, 0x74, 0x9d, 0xe2, 0xc7, 0xbe, 0x0c, 0x70, 0x18, 0xee, 0xc2,
+	0x5b, 0x27, 0xe6, 0xf2, 0x4b, 0xf1, 0xcc, 0x41, 0xc9, 0x8c, 0x85, 0x94, 0x88, 0x2e, 0xd7, 0xc6,
+	0xeb, 0xf6, 0x54, 0xf8, 0x97, 0x00, 0x2e, 0x3f, 0x95, 0x43, 0x7e, 0xe1, 0xf6, 0x6e, 0xc2, 0x25,
+	0xc3, 0x54, 0x97, 0x9b, 0x67, 0xa5, 0x16, 0x67, 0xfb, 0x34, 0x1c, 0x7e, 0x0b, 0x57, 0xa6, 0x2c,
+	0xf0, 0xab, 0xd8, 0x2f, 0x8b, 0x1f, 0xe7, 0x5f, 0xbe, 0xf5, 0xf3, 0x73, 0x75, 0xba, 0xa2, 0x57,
+	0x84, 0x4f, 0x00, 0x0e, 0x65, 0x7e, 0x51, 0xe9, 0x13, 0xc1, 0xb2, 0xd5, 0xe6, 0x6d, 0xdc, 0x85,
+	0x56, 0xae, 0x64, 0xcc, 0xf5, 0xb8, 0x21, 0x7f, 0x70, 0xb6, 0x99, 0x07, 0x8e, 0x3d, 0x1a, 0xcb,
+	0x85, 0x4f, 0x61, 0xf9, 0x89, 0xec, 0xea, 0x8b, 0x32, 0xf1, 0x1a, 0xac, 0x38, 0x75, 0x67, 0x6f,
+	0xad, 0xe1, 0xd7, 0xb0, 0xe8, 0x8d, 0xc1, 0x84, 0xc9, 0x45, 0x62, 0xbf, 0x37, 0x22, 0x1c, 0x62,
+	0x35, 0xe7, 0x08, 0x2d, 0x58, 0xc8, 0x8e, 0xb1, 0x58, 0xd1, 0xa7, 0x45, 0x24, 0x1d, 0x81, 0x9c,
+	0x4c, 0x75, 0x35, 0xad, 0xdb, 0xcd, 0xcc, 0x8e, 0xc3, 0x3b, 0x00, 0xe3, 0xf2, 0x40, 0x8e, 0x97,
+	0x22, 0x4b, 0xfc, 0x5a, 0xec, 0xd8, 0xea, 0x67, 0xa6, 0xe7, 0xed, 0xb7, 0xe3, 0xf0, 0xbf, 0x6b,
+	0xd0, 0x2a, 0xa3, 0x88, 0x1c, 0xb8, 0xea, 0x42, 0x0a, 0xc7, 0xaf, 0xe9, 0x99, 0xeb, 0x50, 0x33,
+	0x66, 0x64, 0xad, 0x5a, 0x8a, 0x70, 0x48, 0xde, 0x05, 0xf8, 0x5e, 0xaa, 0x97, 0x22, 0xeb, 0xee,
+	0x09, 0xe5, 0x9b, 0x5d, 0x05, 0x29, 0x6d, 0x6e, 0x8c, 0x6d, 0x46, 0x2d, 0x3c, 0x1b, 0xd2, 0xa6,
+	0x85, 0x70, 0x48, 0xee, 0x41, 0xb3, 0x2f, 0x07, 0x99, 0xd1, 0x74, 0xd1, 0x46, 0xf6, 0xfd, 0xb3,
+	0x23, 0xfb, 0x14, 0x79, 0x23, 0x2f, 0x42, 0x3e, 0x87, 0x7a, 0x2e, 0x72, 0x4e, 0x97, 0x36, 0x82,
+	0x39, 0x92, 0x42, 0xe4, 0xbc, 0xc3, 0x4d, 0x64, 0x45, 0xd0, 0x92, 0x24, 0xd3, 0xb4, 0xe5, 0x2c,
+	0x49, 0x32, 0x8d, 0xeb, 0xe1, 0xaf, 0x8c, 0x62, 0xbf, 0x96, 0xda, 0x68, 0x0a, 0xf6, 0x43, 0x05,
+	0x21, 0x6b, 0xb0, 0x20, 0x12, 0xba, 0x6c, 0xd7, 0xb9, 0x20, 0x12, 0xb2, 0x0f, 0x2d, 0xc5, 0xb5,
+	0x1c, 0xa8, 0x98, 0x6b, 0xba, 0x62, 0x2d, 0xb8, 0x7e, 0xb6, 0x05, 0x51, 0xc1, 0x1e, 0x8d, 0x25,
+	0x49, 0x1b, 0x96, 0x7a, 0x52, 0x1b, 0x1b, 0x86, 0x55, 0xab, 0xbc, 0xa4, 0xd1, 0xa4, 0x44, 0xf6,
+	0x99, 0xc8, 0xec, 0xd7, 0x35, 0xe7, 0xe2, 0x31, 0x62, 0xcf, 0x3a, 0x5d, 0x25, 0x07, 0xf9, 0x01,
+	0x53, 0x3c, 0x33, 0xf4, 0x92, 0xe5, 0x98, 0xc0, 0xc8, 0x7d, 0x58, 0x1c, 0xa4, 0xa2, 0x2f, 0x8c,
+	0xa6, 0xeb, 0xd6, 0xc3, 0x57, 0xcf, 0x36, 0xf2, 0x4b, 0xcb, 0x1c, 0x15, 0x42, 0xe4, 0x05, 0x2c,
+	0xb3, 0x2c, 0x93, 0x86, 0x19, 0x21, 0x33, 0x4d, 0x7f, 0x64, 0x75, 0x7c, 0x36, 0x67, 0x9b, 0xd8,
+	0xda, 0x19, 0x8b, 0xba, 0x7d, 0xb2, 0xaa, 0x0c, 0xeb, 0x0c, 0xd7, 0xfa, 0x8c, 0x1b, 0xcc, 0x1b,
+	0x4a, 0x6c, 0x72, 0x55, 0x21, 0x72, 0x1f, 0x1a, 0xa6, 0x9f, 0x1f, 0x6b, 0xfa, 0xc6, 0x3c, 0xdb,
+	0xc5, 0x21, 0xb2, 0xba, 0x14, 0x71, 0x62, 0xe4, 0x11, 0xac, 0xa6, 0x62, 0xc8, 0x33, 0xae, 0xf5,
+	0x81, 0x92, 0x47, 0x9c, 0x5e, 0xde, 0x08, 0x66, 0x67, 0x99, 0x65, 0x8d, 0x26, 0x25, 0xc9, 0x63,
+	0x58, 0x53, 0x9c, 0x25, 0x62, 0xac, 0xeb, 0xca, 0xfc, 0xba, 0xa6, 0x44, 0xb1, 0xff, 0xe0, 0xe6,
+	0x7d, 0xc0, 0x4c, 0xdc, 0xa3, 0x6f, 0xba, 0xfe, 0x53, 0x02, 0xe4, 0x19, 0x2c, 0xea, 0x91, 0x8e,
+	0x4d, 0xaa, 0xe9, 0x5b, 0x76, 0xdd, 0x77, 0xe6, 0xf5, 0x77, 0xc7, 0x89, 0x39, 0x5f, 0x17, 0x4a,
+	0xc8, 0x33, 0x58, 0x89, 0x59, 0xce, 0x8e, 0x44, 0x2a, 0x8c, 0xe0, 0x9a, 0x52, 0x6b, 0xf8, 0x8d,
+	0x19, 0x4a, 0x2b, 0x12, 0xd1, 0x84, 0x3c, 0xc6, 0x4d, 0xca, 0x7e, 0x27, 0x96, 0x8a, 0xef, 0x24,
+	0xdf, 0xd1, 0xb7, 0x6d, 0xff, 0xaa, 0x42, 0x58, 0xfc, 0x22, 0x13, 0x86, 0xb6, 0x6d, 0x48, 0xed,
+	0x98, 0x3c, 0x87, 0x4b, 0x8a, 0x6b, 0xc3, 0x94, 0xf9, 0x22, 0x73, 0x5d, 0x8b, 0xfe, 0x78, 0x9e,
+	0xb2, 0xc1, 0x2e, 0xf7, 0x15, 0xfa, 0x25, 0x9a, 0x96, 0xc7, 0x1d, 0x90, 0xe5, 0xf9, 0x8e, 0xea,
+	0x4b, 0x75, 0xa0, 0xe4, 0xb1, 0x48, 0x39, 0x7d, 0xc7, 0xed, 0x80, 0x53, 0x30, 0x96, 0x99, 0x8e,
+	0x7b, 0x3c, 0x19, 0xa4, 0x9c, 0xfe, 0xc4, 0x95, 0x59, 0x41, 0x63, 0x30, 0x52, 0xd9, 0xdd, 0x53,
+	0x62, 0xc8, 0x15, 0x7d, 0xd7, 0x05, 0xa3, 0x04, 0xc8, 0x2f, 0xa1, 0x81, 0x1a, 0x34, 0xfd, 0xe9,
+	0x46, 0x6d, 0x3e, 0x63, 0x7d, 0x06, 0x5a, 0x29, 0x34, 0x91, 0x77, 0x15, 0xee, 0x46, 0xcc, 0xf0,
+	0x27, 0x58, 0x53, 0x74, 0xc3, 0x1e, 0xa7, 0xa7, 0x61, 0x72, 0x17, 0x68, 0xb9, 0x3e, 0x9f, 0xff,
+	0x11, 0x8f, 0xe5, 0x90, 0xab, 0x11, 0x7d, 0xcf, 0xfa, 0xf1, 0xb5, 0xdf, 0x71, 0x79, 0xa9, 0xec,
+	0x3e, 0xe1, 0x43, 0x9e, 0xd2, 0xd0, 0x2d, 0xaf, 0xa0, 0xc9, 0x03, 0x58, 0x12, 0x49, 0xca, 0x3b,
+	0x46, 0xe6, 0xf4, 0x7d, 0xeb, 0xf0, 0x6b, 0x33, 0x0e, 0xbc, 0x9e, 0x3b, 0x2a, 0xe5, 0xb0, 0x8b,
+	0x24, 0xdc, 0xf2, 0xd2, 0xab, 0xf3, 0x74, 0x91, 0x3d, 0xcb, 0x1c, 0x15, 0x42, 0xd8, 0xa9, 0x58,
+	0x1c, 0xf3, 0x94, 0x2b, 0x66, 0xa4, 0xd2, 0xf4, 0x03, 0x77, 0x2b, 0xab, 0x62, 0xe4, 0x2a, 0xac,
+	0xda, 0xd5, 0x1d, 0x28, 0x21, 0x95, 0x30, 0x23, 0x7a, 0xcd, 0xe6, 0xd5, 0x24, 0x88, 0x9a, 0xb4,
+	0x91, 0x8a, 0x75, 0xf9, 0xf3, 0x81, 0x34, 0x8c, 0x5e, 0xb7, 0xce, 0x9c, 0xc0, 0xf0, 0x10, 0x8e,
+	0x4d, 0x64, 0x3f, 0x1b, 0xd2, 0x4d, 0x3b, 0x51, 0x41, 0xb6, 0xef, 0xc3, 0xfa, 0x74, 0x4b, 0x3a,
+	0xcf, 0x89, 0xb9, 0x7d, 0x17, 0x56, 0xaa, 0x25, 0x76, 0xae, 0xd3, 0xf6, 0x5f, 0x03, 0x58, 0xa9,
+	0x16, 0x15, 0xe6, 0x1d, 0x3f, 0x3e, 0xe6, 0xb1, 0x11, 0x43, 0x6e, 0x0f, 0x36, 0xad, 0x68, 0x0c,
+	0xe0, 0xd7, 0x9c, 0xab, 0xbe, 0x30, 0x86, 0x27, 0xfe, 0x16, 0x3b, 0x06, 0x30, 0xe0, 0x47, 0x72,
+	0x90, 0x25, 0x22, 0xeb, 0xda, 0x5b, 0x4c, 0x2b, 0x2a, 0x69, 0x2c, 0x4f, 0x91, 0xf5, 0xb8, 0x12,
+	0x86, 0x1d, 0xa5, 0xdc, 0x1f, 0x1a, 0xaa, 0x50, 0xf8, 0xaf, 0x00, 0x1a, 0xae, 0x11, 0x11, 0xa8,
+	0xf3, 0x57, 0x3c, 0xf6, 0xd3, 0xdb, 0x31, 0xb9, 0x09, 0x6f, 0x60, 0xc1, 0x0a, 0x96, 0xee, 0xf1,
+	0x94, 0x8d, 0x3a, 0x3c, 0x96, 0x59, 0xa2, 0xed, 0x82, 0x6a, 0xd1, 0x69, 0x9f, 0x30, 0x74, 0x39,
+	0x57, 0x42, 0x26, 0x05, 0x6f, 0xcd, 0xf2, 0x4e, 0x82, 0xe4, 0x1a, 0xac, 0xf9, 0x2b, 0x64, 0xc1,
+	0xe6, 0x2e, 0x96, 0x53, 0x28, 0xb9, 0x01, 0xeb, 0xc7, 0x4c, 0xa4, 0x03, 0xc5, 0x0f, 0x7b, 0x8a,
+	0xeb, 0x9e, 0x4c, 0x13, 0x7b, 0x5d, 0x6a, 0x44, 0x27, 0xf0, 0xf0, 0x31, 0xb4, 0xca, 0xfe, 0x80,
+	0xbe, 0xc7, 0x43, 0x8e, 0xf6, 0xab, 0x71, 0x04, 0x56, 0x60, 0xc2, 0xd1, 0x39, 0x31, 0x9f, 0x5c,
+	0xca, 0x34, 0x1c, 0xa6, 0xd0, 0x74, 0x89, 0x5b, 0xec, 0xca, 0x07, 0x78, 0x7c, 0x0a, 0xc6, 0xbb,
+	0x32, 0xd2, 0xb8, 0xd8, 0x32, 0xd9, 0x0f, 0xc6, 0xe7, 0xab, 0x49, 0x10, 0x83, 0x60, 0xa3, 0xa5,
+	0xb5, 0xdd, 0x37, 0xdd, 0x71, 0xae, 0x0a, 0x85, 0xdf, 0xc2, 0x52, 0x51, 0x69, 0xa7, 0xb8, 0x26,
+	0x38, 0xd5, 0x35, 0x78, 0xa4, 0x93, 0xca, 0x94, 0x47, 0x46, 0xa9, 0xcc, 0xd4, 0xab, 0x4a, 0xab,
+	0x7c, 0x55, 0xe1, 0xd0, 0x2a, 0xbb, 0x51, 0x79, 0x16, 0x0c, 0xc6, 0x67, 0x41, 0x2c, 0x13, 0xb4,
+	0x99, 0x67, 0x4e, 0x5f, 0x2b, 0x2a, 0x48, 0xab, 0x92, 0xc7, 0x8a, 0x9b, 0x52, 0xa5, 0xa5, 0x50,
+	0x4b, 0x5f, 0x26, 0xee, 0x6a, 0xbb, 0x1a, 0xd9, 0x71, 0x78, 0x0c, 0x30, 0xde, 0x77, 0x71, 0xd9,
+	0x09, 0xd7, 0x46, 0x64, 0xb6, 0xc2, 0x8a, 0x3b, 0x79, 0x05, 0xb2, 0x5b, 0x9f, 0xf8, 0x83, 0x6f,
+	0x85, 0x2e, 0x10, 0x63, 0x00, 0x6d, 0x92, 0xb9, 0xf1, 0x2e, 0xb3, 0xa5, 0xeb, 0xc9, 0x70, 0x0f,
+	0x9a, 0xee, 0x6c, 0x72, 0xea, 0xa9, 0x15, 0x6f, 0xc6, 0xf2, 0xd8, 0x29, 0xac, 0x47, 0x76, 0x8c,
+	0x58, 0x8f, 0xa9, 0xc4, 0xae, 0xa1, 0x1e, 0xd9, 0x71, 0xa8, 0xa1, 0x55, 0x1e, 0xc3, 0xd0, 0xd8,
+	0x3e, 0xef, 0x4b, 0x35, 0x72, 0xc6, 0x38, 0x97, 0x57, 0x21, 0xcc, 0x83, 0x38, 0x1f, 0x54, 0x6d,
+	0x2d, 0x69, 0xcc, 0x2b, 0xc7, 0xda, 0xf9, 0x9e, 0xe5, 0x8e, 0xc5, 0xa5, 0xfd, 0x34, 0x1c, 0x7e,
+	0x01, 0x8b, 0xfe, 0xf4, 0x49, 0xf6, 0xec, 0x5b, 0x9b, 0xf4, 0x17, 0x85, 0xe5, 0x5b, 0x1f, 0xcd,
+	0x3e, 0xb4, 0x3e, 0x54, 0xb2, 0xef, 0xde, 0xf3, 0x22, 0x2f, 0x1b, 0x3e, 0x87, 0xb5, 0xc9, 0x2f,
+	0xe4, 0x57, 0x78, 0x6f, 0x48, 0x44, 0xe6, 0xd5, 0x7e, 0x38, 0x5b, 0xed, 0xa1, 0xb4, 0x0f, 0x8a,
+	0x91, 0x93, 0x0b, 0xdf, 0x83, 0xe5, 0x0a, 0x7a, 0x9a, 0x8f, 0xc3, 0xbf, 0x05, 0xd0, 0x28, 0xb3,
+	0xc9, 0x8c, 0xf2, 0xf2, 0x2b, 0x8e, 0x6d, 0xce, 0x58, 0xbf, 0x16, 0xd7, 0x5f, 0x47, 0x4d, 0x67,
+	0x44, 0xed, 0x64, 0x46, 0x54, 0x62, 0x5e, 0x9f, 0x88, 0xb9, 0x2d, 0x22, 0x25, 0x73, 0xd6, 0x75,
+	0xb2, 0xfe, 0xcd, 0xa4, 0x02, 0x85, 0x7f, 0x5f, 0x80, 0x4b, 0x53, 0xb7, 0xd2, 0x39, 0xde, 0x85,
+	0x8a, 0xd5, 0x2d, 0x9c, 0x76, 0xef, 0xa9, 0x55, 0xef, 0x3d, 0xe5, 0x7d, 0xac, 0x5e, 0xbd, 0x8f,
+	0x85, 0xb0, 0xe2, 0xb7, 0xe2, 0x5d, 0xf4, 0x87, 0xef, 0x4e, 0x13, 0x18, 0xf2, 0xa4, 0x4c, 0x9b,
+	0xfd, 0x57, 0xf8, 0x7a, 0x90, 0x70, 0xfb, 0x9c, 0xd3, 0x88, 0x26, 0x30, 0x2c, 0xfb, 0x82, 0x8e,
+	0x38, 0xd3, 0x32, 0xb3, 0x2f, 0x3b, 0xad, 0x68, 0x0a, 0x45, 0x2b, 0xf0, 0x00, 0x39, 0xb2, 0x37,
+	0x9d, 0xa5, 0xc8, 0x11, 0xd8, 0x88, 0x90, 0xaf, 0x83, 0x73, 0xf2, 0x64, 0xc7, 0xd0, 0x96, 0xeb,
+	0xba, 0x13, 0x60, 0xa8, 0xe1, 0xca, 0x84, 0x83, 0x2e, 0xea, 0x0e, 0x8c, 0xb5, 0x21, 0x32, 0xc3,
+	0xd5, 0xd0, 0x77, 0x9e, 0x5a, 0x54, 0xd2, 0xe1, 0x37, 0xf8, 0x48, 0x33, 0x39, 0x69, 0xf9, 0x04,
+	0x64, 0x7d, 0xa8, 0xe7, 0xcb, 0xff, 0x29, 0x25, 0x4e, 0x34, 0xfc, 0xcf, 0x02, 0xac, 0x4d, 0x7e,
+	0x99, 0x2f, 0xe6, 0xf6, 0x59, 0xce, 0x95, 0xb1, 0x1d, 0xe3, 0x05, 0x2b, 0xce, 0x07, 0x07, 0x5c,
+	0xc5, 0xd8, 0x04, 0x71, 0x11, 0x41, 0x54, 0x41, 0xc6, 0x0d, 0xe2, 0x4b, 0x8d, 0x99, 0x51, 0xb7,
+	0x8d, 0xa4, 0x0a, 0x4d, 0xb7, 0x90, 0x46, 0x95, 0xc3, 0x42, 0xb8, 0x9b, 0x65, 0xee, 0xb4, 0xb6,
+	0x33, 0x64, 0x22, 0xb5, 0x5b, 0x72, 0xd3, 0x86, 0xf1, 0x04, 0x8e, 0xf9, 0xe0, 0xb1, 0xe8, 0xd5,
+	0x83, 0x91, 0xe1, 0xda, 0xe6, 0x43, 0x3d, 0x9a, 0x42, 0x2b, 0x7c, 0x87, 0x9e, 0x6f, 0x69, 0x82,
+	0xcf, 0xa3, 0x98, 0x21, 0xa5, 0x64, 0x84, 0x59, 0xdc, 0xb2, 0x4b, 0x9c, 0x04, 0x2b, 0x5c, 0x87,
+	0x8e, 0x0b, 0x26, 0xb8, 0x1c, 0x78, 0xeb, 0x9f, 0x00, 0x50, 0x3a, 0x5d, 0x13, 0x05, 0xcd, 0x1d,
+	0x63, 0x58, 0xdc, 0x23, 0x37, 0xcf, 0x0e, 0xe1, 0xc9, 0xdf, 0x16, 0xed, 0x5b, 0x33, 0x25, 0x4e,
+	0xfc, 0xbc, 0xd8, 0x0c, 0x6e, 0x06, 0x24, 0x87, 0xfa, 0xbe, 0x3d, 0xa0, 0xfc, 0xdf, 0x66, 0x8c,
+	0xa1, 0xe9, 0xfe, 0x4c, 0x90, 0x9f, 0xcd, 0xd0, 0x50, 0xfd, 0x51, 0xd2, 0xfe, 0x68, 0x3e, 0x66,
+	0x5f, 0x12, 0x7f, 0x84, 0xa5, 0xe2, 0x6f, 0x00, 0xf9, 0xf4, 0xdc, 0xbf, 0x1a, 0xdc, 0x8c, 0xbf,
+	0xf8, 0x81, 0xbf, 0x28, 0xc8, 0xef, 0xa0, 0x8e, 0x8f, 0xf9, 0x64, 0xc6, 0x8e, 0x51, 0xf9, 0xe3,
+	0xd0, 0xbe, 0x31, 0x0f, 0xab, 0x57, 0xff, 0x0a, 0x16, 0xfd, 0xfb, 0x39, 0xf9, 0xe4, 0xbc, 0xcf,
+	0xec, 0x6e, 0xb6, 0x4f, 0x7f, 0xd8, 0xeb, 0x3c, 0x91, 0x50, 0xc7, 0x47, 0x68, 0x32, 0x23, 0xf4,
+	0xa7, 0x3d, 0x80, 0xb7, 0x6f, 0x9f, 0x4b, 0xc6, 0x4f, 0x38, 0x80, 0xa6, 0x7b, 0x2c, 0x26, 0x33,
+	0xaf, 0xeb, 0xa7, 0x3d, 0x5f, 0xb7, 0x3f, 0x39, 0xa7, 0xd4, 0x78, 0x9d, 0xf8, 0xb6, 0x3b, 0x6b,
+	0x9d, 0xa7, 0xbd, 0x40, 0xb7, 0x6f, 0x9f, 0x4b, 0xc6, 0x4f, 0xf8, 0x02, 0x6a, 0x87, 0x32, 0x27,
+	0xb3, 0xde, 0x62, 0xca, 0xf7, 0xe0, 0xf6, 0x87, 0x73, 0x70, 0x7a, 0xdd, 0x7f, 0x3a, 0xd1, 0xd8,
+	0x6f, 0x9f, 0x6b, 0x83, 0xf0, 0x33, 0xde, 0x39, 0x9f, 0x90, 0x9b, 0xfc, 0x66, 0x80, 0xc5, 0x80,
+	0xcf, 0xba, 0xb3, 0x8a, 0xa1, 0xf2, 0x92, 0xdc, 0xbe, 0x31, 0x0f, 0xab, 0x9b, 0xe0, 0xc1, 0xfe,
+	0x8b, 0xdd, 0xae, 0x30, 0xbd, 0xc1, 0xd1, 0x56, 0x2c, 0xfb, 0xdb, 0x5c, 0x65, 0x92, 0xb1, 0x9c,
+	0x6d, 0x5b, 0x05, 0xdb, 0xf9, 0xcb, 0xee, 0x36, 0xcb, 0xc5, 0xf6, 0xe9, 0xff, 0x8b, 0xef, 0x8d,
+	0xa9, 0xa3, 0xa6, 0xfd, 0x61, 0x7c, 0xfb, 0x7f, 0x03, 0x00, 0x3b, 0x56, 0xf3, 0x2e, 0x5b, 0x1e,
+	0x00, 0x00,
 }
@@ -0,0 +1,1290 @@
+// Code generated by protoc-gen-go.
+// source: services/images/v1/images.proto
+// DO NOT EDIT!
+
+/*
+Package images is a generated protocol buffer package.
+
+It is generated from these files:
+	services/images/v1/images.proto
+
+It has these top-level messages:
+	ImportImageRequest
+	ImportImageResponse
+	ExportImageRequest
+	ExportImageResponse
+	PrePullRequest
+	PrePullResponse
+	PrePullResult
+	CancelPullRequest
+	CancelPullResponse
+	PullSecret
+	PutPullSecretRequest
+	PutPullSecretResponse
+	DeletePullSecretRequest
+	DeletePullSecretResponse
+	PutSecretRequest
+	PutSecretResponse
+	DeleteSecretRequest
+	DeleteSecretResponse
+	TagImageRequest
+	TagImageResponse
+	ListImagesRequest
+	ListImagesResponse
+	Image
+	ImageConfigRequest
+	ImageConfigResponse
+	ImageConfig
+*/
+package images
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Import labels are read from 'labels' metadata values in format 'key=value'
+type ImportImageRequest struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *ImportImageRequest) Reset()                    { *m = ImportImageRequest{} }
+func (m *ImportImageRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportImageRequest) ProtoMessage()               {}
+func (*ImportImageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *ImportImageRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ImportImageResponse struct {
+	Images []string `protobuf:"bytes,1,rep,name=images" json:"images,omitempty"`
+}
+
+func (m *ImportImageResponse) Reset()                    { *m = ImportImageResponse{} }
+func (m *ImportImageResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportImageResponse) ProtoMessage()               {}
+func (*ImportImageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *ImportImageResponse) GetImages() []string {
+	if m != nil {
+		return m.Images
+	}
+	return nil
+}
+
+type ExportImageRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	Ref       string `protobuf:"bytes,2,opt,name=ref" json:"ref,omitempty"`
+}
+
+func (m *ExportImageRequest) Reset()                    { *m = ExportImageRequest{} }
+func (m *ExportImageRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportImageRequest) ProtoMessage()               {}
+func (*ExportImageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *ExportImageRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ExportImageRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+type ExportImageResponse struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *ExportImageResponse) Reset()                    { *m = ExportImageResponse{} }
+func (m *ExportImageResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportImageResponse) ProtoMessage()               {}
+func (*ExportImageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ExportImageResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type PrePullRequest struct {
+	Namespace string   `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	Refs      []string `protobuf:"bytes,2,rep,name=refs" json:"refs,omitempty"`
+	// Names of the pull secrets to authenticate with
+	ImagePullSecrets []string `protobuf:"bytes,3,rep,name=imagePullSecrets" json:"imagePullSecrets,omitempty"`
+	// Labels added to the pulled images, e.g. deploy batch or approver
+	Labels map[string]string `protobuf:"bytes,4,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *PrePullRequest) Reset()                    { *m = PrePullRequest{} }
+func (m *PrePullRequest) String() string            { return proto.CompactTextString(m) }
+func (*PrePullRequest) ProtoMessage()               {}
+func (*PrePullRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *PrePullRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *PrePullRequest) GetRefs() []string {
+	if m != nil {
+		return m.Refs
+	}
+	return nil
+}
+
+func (m *PrePullRequest) GetImagePullSecrets() []string {
+	if m != nil {
+		return m.ImagePullSecrets
+	}
+	return nil
+}
+
+func (m *PrePullRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type PrePullResponse struct {
+	// Results in the same order as the requested refs
+	Results []*PrePullResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *PrePullResponse) Reset()                    { *m = PrePullResponse{} }
+func (m *PrePullResponse) String() string            { return proto.CompactTextString(m) }
+func (*PrePullResponse) ProtoMessage()               {}
+func (*PrePullResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *PrePullResponse) GetResults() []*PrePullResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type PrePullResult struct {
+	// Normalized image reference
+	Ref string `protobuf:"bytes,1,opt,name=ref" json:"ref,omitempty"`
+	// Error message if the pull failed, empty on success
+	Error string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *PrePullResult) Reset()                    { *m = PrePullResult{} }
+func (m *PrePullResult) String() string            { return proto.CompactTextString(m) }
+func (*PrePullResult) ProtoMessage()               {}
+func (*PrePullResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *PrePullResult) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *PrePullResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type CancelPullRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	Ref       string `protobuf:"bytes,2,opt,name=ref" json:"ref,omitempty"`
+}
+
+func (m *CancelPullRequest) Reset()                    { *m = CancelPullRequest{} }
+func (m *CancelPullRequest) String() string            { return proto.CompactTextString(m) }
+func (*CancelPullRequest) ProtoMessage()               {}
+func (*CancelPullRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *CancelPullRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *CancelPullRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+type CancelPullResponse struct {
+	// Normalized image reference
+	Ref string `protobuf:"bytes,1,opt,name=ref" json:"ref,omitempty"`
+}
+
+func (m *CancelPullResponse) Reset()                    { *m = CancelPullResponse{} }
+func (m *CancelPullResponse) String() string            { return proto.CompactTextString(m) }
+func (*CancelPullResponse) ProtoMessage()               {}
+func (*CancelPullResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *CancelPullResponse) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+type PullSecret struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Registry hostname, optionally with port, e.g. registry.example.com:5000
+	Registry string `protobuf:"bytes,2,opt,name=registry" json:"registry,omitempty"`
+	Username string `protobuf:"bytes,3,opt,name=username" json:"username,omitempty"`
+	Password string `protobuf:"bytes,4,opt,name=password" json:"password,omitempty"`
+}
+
+func (m *PullSecret) Reset()                    { *m = PullSecret{} }
+func (m *PullSecret) String() string            { return proto.CompactTextString(m) }
+func (*PullSecret) ProtoMessage()               {}
+func (*PullSecret) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *PullSecret) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PullSecret) GetRegistry() string {
+	if m != nil {
+		return m.Registry
+	}
+	return ""
+}
+
+func (m *PullSecret) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *PullSecret) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type PutPullSecretRequest struct {
+	Secret *PullSecret `protobuf:"bytes,1,opt,name=secret" json:"secret,omitempty"`
+	// The namespace where the pods can use the secret
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *PutPullSecretRequest) Reset()                    { *m = PutPullSecretRequest{} }
+func (m *PutPullSecretRequest) String() string            { return proto.CompactTextString(m) }
+func (*PutPullSecretRequest) ProtoMessage()               {}
+func (*PutPullSecretRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *PutPullSecretRequest) GetSecret() *PullSecret {
+	if m != nil {
+		return m.Secret
+	}
+	return nil
+}
+
+func (m *PutPullSecretRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type PutPullSecretResponse struct {
+}
+
+func (m *PutPullSecretResponse) Reset()                    { *m = PutPullSecretResponse{} }
+func (m *PutPullSecretResponse) String() string            { return proto.CompactTextString(m) }
+func (*PutPullSecretResponse) ProtoMessage()               {}
+func (*PutPullSecretResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+type DeletePullSecretRequest struct {
+	Name      string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *DeletePullSecretRequest) Reset()                    { *m = DeletePullSecretRequest{} }
+func (m *DeletePullSecretRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeletePullSecretRequest) ProtoMessage()               {}
+func (*DeletePullSecretRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *DeletePullSecretRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeletePullSecretRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type DeletePullSecretResponse struct {
+}
+
+func (m *DeletePullSecretResponse) Reset()                    { *m = DeletePullSecretResponse{} }
+func (m *DeletePullSecretResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeletePullSecretResponse) ProtoMessage()               {}
+func (*DeletePullSecretResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+type PutSecretRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// The namespace where the pods can mount the secret
+	Namespace string `protobuf:"bytes,3,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *PutSecretRequest) Reset()                    { *m = PutSecretRequest{} }
+func (m *PutSecretRequest) String() string            { return proto.CompactTextString(m) }
+func (*PutSecretRequest) ProtoMessage()               {}
+func (*PutSecretRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *PutSecretRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PutSecretRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *PutSecretRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type PutSecretResponse struct {
+}
+
+func (m *PutSecretResponse) Reset()                    { *m = PutSecretResponse{} }
+func (m *PutSecretResponse) String() string            { return proto.CompactTextString(m) }
+func (*PutSecretResponse) ProtoMessage()               {}
+func (*PutSecretResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+type DeleteSecretRequest struct {
+	Name      string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *DeleteSecretRequest) Reset()                    { *m = DeleteSecretRequest{} }
+func (m *DeleteSecretRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteSecretRequest) ProtoMessage()               {}
+func (*DeleteSecretRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *DeleteSecretRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeleteSecretRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type DeleteSecretResponse struct {
+}
+
+func (m *DeleteSecretResponse) Reset()                    { *m = DeleteSecretResponse{} }
+func (m *DeleteSecretResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteSecretResponse) ProtoMessage()               {}
+func (*DeleteSecretResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+type TagImageRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	// Existing image reference
+	Ref string `protobuf:"bytes,2,opt,name=ref" json:"ref,omitempty"`
+	// New reference for the image
+	NewRef string `protobuf:"bytes,3,opt,name=newRef" json:"newRef,omitempty"`
+}
+
+func (m *TagImageRequest) Reset()                    { *m = TagImageRequest{} }
+func (m *TagImageRequest) String() string            { return proto.CompactTextString(m) }
+func (*TagImageRequest) ProtoMessage()               {}
+func (*TagImageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *TagImageRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *TagImageRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *TagImageRequest) GetNewRef() string {
+	if m != nil {
+		return m.NewRef
+	}
+	return ""
+}
+
+type TagImageResponse struct {
+	// Normalized new image reference
+	Ref string `protobuf:"bytes,1,opt,name=ref" json:"ref,omitempty"`
+}
+
+func (m *TagImageResponse) Reset()                    { *m = TagImageResponse{} }
+func (m *TagImageResponse) String() string            { return proto.CompactTextString(m) }
+func (*TagImageResponse) ProtoMessage()               {}
+func (*TagImageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *TagImageResponse) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+type ListImagesRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	// If given, return only the images what have all the labels with the same values
+	Labels map[string]string `protobuf:"bytes,2,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ListImagesRequest) Reset()                    { *m = ListImagesRequest{} }
+func (m *ListImagesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListImagesRequest) ProtoMessage()               {}
+func (*ListImagesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *ListImagesRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ListImagesRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type ListImagesResponse struct {
+	Images []*Image `protobuf:"bytes,1,rep,name=images" json:"images,omitempty"`
+}
+
+func (m *ListImagesResponse) Reset()                    { *m = ListImagesResponse{} }
+func (m *ListImagesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListImagesResponse) ProtoMessage()               {}
+func (*ListImagesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *ListImagesResponse) GetImages() []*Image {
+	if m != nil {
+		return m.Images
+	}
+	return nil
+}
+
+type Image struct {
+	Name   string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Digest string            `protobuf:"bytes,2,opt,name=digest" json:"digest,omitempty"`
+	Labels map[string]string `protobuf:"bytes,3,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Unix timestamp in seconds
+	CreatedAt int64 `protobuf:"varint,4,opt,name=createdAt" json:"createdAt,omitempty"`
+}
+
+func (m *Image) Reset()                    { *m = Image{} }
+func (m *Image) String() string            { return proto.CompactTextString(m) }
+func (*Image) ProtoMessage()               {}
+func (*Image) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *Image) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Image) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *Image) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Image) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+type ImageConfigRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	Ref       string `protobuf:"bytes,2,opt,name=ref" json:"ref,omitempty"`
+	// Names of the pull secrets to authenticate with if the image needs to be pulled
+	ImagePullSecrets []string `protobuf:"bytes,3,rep,name=imagePullSecrets" json:"imagePullSecrets,omitempty"`
+}
+
+func (m *ImageConfigRequest) Reset()                    { *m = ImageConfigRequest{} }
+func (m *ImageConfigRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImageConfigRequest) ProtoMessage()               {}
+func (*ImageConfigRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ImageConfigRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ImageConfigRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *ImageConfigRequest) GetImagePullSecrets() []string {
+	if m != nil {
+		return m.ImagePullSecrets
+	}
+	return nil
+}
+
+type ImageConfigResponse struct {
+	// Normalized image reference
+	Ref    string       `protobuf:"bytes,1,opt,name=ref" json:"ref,omitempty"`
+	Config *ImageConfig `protobuf:"bytes,2,opt,name=config" json:"config,omitempty"`
+}
+
+func (m *ImageConfigResponse) Reset()                    { *m = ImageConfigResponse{} }
+func (m *ImageConfigResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImageConfigResponse) ProtoMessage()               {}
+func (*ImageConfigResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ImageConfigResponse) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *ImageConfigResponse) GetConfig() *ImageConfig {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+type ImageConfig struct {
+	User       string   `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
+	Entrypoint []string `protobuf:"bytes,2,rep,name=entrypoint" json:"entrypoint,omitempty"`
+	Cmd        []string `protobuf:"bytes,3,rep,name=cmd" json:"cmd,omitempty"`
+	Env        []string `protobuf:"bytes,4,rep,name=env" json:"env,omitempty"`
+	WorkingDir string   `protobuf:"bytes,5,opt,name=workingDir" json:"workingDir,omitempty"`
+	// Ports what the image declares, e.g. 80/tcp
+	ExposedPorts []string          `protobuf:"bytes,6,rep,name=exposedPorts" json:"exposedPorts,omitempty"`
+	Volumes      []string          `protobuf:"bytes,7,rep,name=volumes" json:"volumes,omitempty"`
+	Labels       map[string]string `protobuf:"bytes,8,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StopSignal   string            `protobuf:"bytes,9,opt,name=stopSignal" json:"stopSignal,omitempty"`
+	Os           string            `protobuf:"bytes,10,opt,name=os" json:"os,omitempty"`
+	Architecture string            `protobuf:"bytes,11,opt,name=architecture" json:"architecture,omitempty"`
+}
+
+func (m *ImageConfig) Reset()                    { *m = ImageConfig{} }
+func (m *ImageConfig) String() string            { return proto.CompactTextString(m) }
+func (*ImageConfig) ProtoMessage()               {}
+func (*ImageConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *ImageConfig) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *ImageConfig) GetEntrypoint() []string {
+	if m != nil {
+		return m.Entrypoint
+	}
+	return nil
+}
+
+func (m *ImageConfig) GetCmd() []string {
+	if m != nil {
+		return m.Cmd
+	}
+	return nil
+}
+
+func (m *ImageConfig) GetEnv() []string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *ImageConfig) GetWorkingDir() string {
+	if m != nil {
+		return m.WorkingDir
+	}
+	return ""
+}
+
+func (m *ImageConfig) GetExposedPorts() []string {
+	if m != nil {
+		return m.ExposedPorts
+	}
+	return nil
+}
+
+func (m *ImageConfig) GetVolumes() []string {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+func (m *ImageConfig) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *ImageConfig) GetStopSignal() string {
+	if m != nil {
+		return m.StopSignal
+	}
+	return ""
+}
+
+func (m *ImageConfig) GetOs() string {
+	if m != nil {
+		return m.Os
+	}
+	return ""
+}
+
+func (m *ImageConfig) GetArchitecture() string {
+	if m != nil {
+		return m.Architecture
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ImportImageRequest)(nil), "eliot.services.images.v1.ImportImageRequest")
+	proto.RegisterType((*ImportImageResponse)(nil), "eliot.services.images.v1.ImportImageResponse")
+	proto.RegisterType((*ExportImageRequest)(nil), "eliot.services.images.v1.ExportImageRequest")
+	proto.RegisterType((*ExportImageResponse)(nil), "eliot.services.images.v1.ExportImageResponse")
+	proto.RegisterType((*PrePullRequest)(nil), "eliot.services.images.v1.PrePullRequest")
+	proto.RegisterType((*PrePullResponse)(nil), "eliot.services.images.v1.PrePullResponse")
+	proto.RegisterType((*PrePullResult)(nil), "eliot.services.images.v1.PrePullResult")
+	proto.RegisterType((*CancelPullRequest)(nil), "eliot.services.images.v1.CancelPullRequest")
+	proto.RegisterType((*CancelPullResponse)(nil), "eliot.services.images.v1.CancelPullResponse")
+	proto.RegisterType((*PullSecret)(nil), "eliot.services.images.v1.PullSecret")
+	proto.RegisterType((*PutPullSecretRequest)(nil), "eliot.services.images.v1.PutPullSecretRequest")
+	proto.RegisterType((*PutPullSecretResponse)(nil), "eliot.services.images.v1.PutPullSecretResponse")
+	proto.RegisterType((*DeletePullSecretRequest)(nil), "eliot.services.images.v1.DeletePullSecretRequest")
+	proto.RegisterType((*DeletePullSecretResponse)(nil), "eliot.services.images.v1.DeletePullSecretResponse")
+	proto.RegisterType((*PutSecretRequest)(nil), "eliot.services.images.v1.PutSecretRequest")
+	proto.RegisterType((*PutSecretResponse)(nil), "eliot.services.images.v1.PutSecretResponse")
+	proto.RegisterType((*DeleteSecretRequest)(nil), "eliot.services.images.v1.DeleteSecretRequest")
+	proto.RegisterType((*DeleteSecretResponse)(nil), "eliot.services.images.v1.DeleteSecretResponse")
+	proto.RegisterType((*TagImageRequest)(nil), "eliot.services.images.v1.TagImageRequest")
+	proto.RegisterType((*TagImageResponse)(nil), "eliot.services.images.v1.TagImageResponse")
+	proto.RegisterType((*ListImagesRequest)(nil), "eliot.services.images.v1.ListImagesRequest")
+	proto.RegisterType((*ListImagesResponse)(nil), "eliot.services.images.v1.ListImagesResponse")
+	proto.RegisterType((*Image)(nil), "eliot.services.images.v1.Image")
+	proto.RegisterType((*ImageConfigRequest)(nil), "eliot.services.images.v1.ImageConfigRequest")
+	proto.RegisterType((*ImageConfigResponse)(nil), "eliot.services.images.v1.ImageConfigResponse")
+	proto.RegisterType((*ImageConfig)(nil), "eliot.services.images.v1.ImageConfig")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Images service
+
+type ImagesClient interface {
+	Import(ctx context.Context, opts ...grpc.CallOption) (Images_ImportClient, error)
+	Export(ctx context.Context, in *ExportImageRequest, opts ...grpc.CallOption) (Images_ExportClient, error)
+	PrePull(ctx context.Context, in *PrePullRequest, opts ...grpc.CallOption) (*PrePullResponse, error)
+	CancelPull(ctx context.Context, in *CancelPullRequest, opts ...grpc.CallOption) (*CancelPullResponse, error)
+	PutPullSecret(ctx context.Context, in *PutPullSecretRequest, opts ...grpc.CallOption) (*PutPullSecretResponse, error)
+	DeletePullSecret(ctx context.Context, in *DeletePullSecretRequest, opts ...grpc.CallOption) (*DeletePullSecretResponse, error)
+	PutSecret(ctx context.Context, in *PutSecretRequest, opts ...grpc.CallOption) (*PutSecretResponse, error)
+	DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error)
+	Tag(ctx context.Context, in *TagImageRequest, opts ...grpc.CallOption) (*TagImageResponse, error)
+	ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ListImagesResponse, error)
+	ImageConfig(ctx context.Context, in *ImageConfigRequest, opts ...grpc.CallOption) (*ImageConfigResponse, error)
+}
+
+type imagesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewImagesClient(cc *grpc.ClientConn) ImagesClient {
+	return &imagesClient{cc}
+}
+
+func (c *imagesClient) Import(ctx context.Context, opts ...grpc.CallOption) (Images_ImportClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Images_serviceDesc.Streams[0], c.cc, "/eliot.services.images.v1.Images/Import", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &imagesImportClient{stream}
+	return x, nil
+}
+
+type Images_ImportClient interface {
+	Send(*ImportImageRequest) error
+	CloseAndRecv() (*ImportImageResponse, error)
+	grpc.ClientStream
+}
+
+type imagesImportClient struct {
+	grpc.ClientStream
+}
+
+func (x *imagesImportClient) Send(m *ImportImageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *imagesImportClient) CloseAndRecv() (*ImportImageResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportImageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *imagesClient) Export(ctx context.Context, in *ExportImageRequest, opts ...grpc.CallOption) (Images_ExportClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Images_serviceDesc.Streams[1], c.cc, "/eliot.services.images.v1.Images/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &imagesExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Images_ExportClient interface {
+	Recv() (*ExportImageResponse, error)
+	grpc.ClientStream
+}
+
+type imagesExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *imagesExportClient) Recv() (*ExportImageResponse, error) {
+	m := new(ExportImageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *imagesClient) PrePull(ctx context.Context, in *PrePullRequest, opts ...grpc.CallOption) (*PrePullResponse, error) {
+	out := new(PrePullResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.images.v1.Images/PrePull", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imagesClient) CancelPull(ctx context.Context, in *CancelPullRequest, opts ...grpc.CallOption) (*CancelPullResponse, error) {
+	out := new(CancelPullResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.images.v1.Images/CancelPull", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imagesClient) PutPullSecret(ctx context.Context, in *PutPullSecretRequest, opts ...grpc.CallOption) (*PutPullSecretResponse, error) {
+	out := new(PutPullSecretResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.images.v1.Images/PutPullSecret", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imagesClient) DeletePullSecret(ctx context.Context, in *DeletePullSecretRequest, opts ...grpc.CallOption) (*DeletePullSecretResponse, error) {
+	out := new(DeletePullSecretResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.images.v1.Images/DeletePullSecret", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imagesClient) PutSecret(ctx context.Context, in *PutSecretRequest, opts ...grpc.CallOption) (*PutSecretResponse, error) {
+	out := new(PutSecretResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.images.v1.Images/PutSecret", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imagesClient) DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error) {
+	out := new(DeleteSecretResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.images.v1.Images/DeleteSecret", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imagesClient) Tag(ctx context.Context, in *TagImageRequest, opts ...grpc.CallOption) (*TagImageResponse, error) {
+	out := new(TagImageResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.images.v1.Images/Tag", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imagesClient) ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ListImagesResponse, error) {
+	out := new(ListImagesResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.images.v1.Images/ListImages", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imagesClient) ImageConfig(ctx context.Context, in *ImageConfigRequest, opts ...grpc.CallOption) (*ImageConfigResponse, error) {
+	out := new(ImageConfigResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.images.v1.Images/ImageConfig", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Images service
+
+type ImagesServer interface {
+	Import(Images_ImportServer) error
+	Export(*ExportImageRequest, Images_ExportServer) error
+	PrePull(context.Context, *PrePullRequest) (*PrePullResponse, error)
+	CancelPull(context.Context, *CancelPullRequest) (*CancelPullResponse, error)
+	PutPullSecret(context.Context, *PutPullSecretRequest) (*PutPullSecretResponse, error)
+	DeletePullSecret(context.Context, *DeletePullSecretRequest) (*DeletePullSecretResponse, error)
+	PutSecret(context.Context, *PutSecretRequest) (*PutSecretResponse, error)
+	DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error)
+	Tag(context.Context, *TagImageRequest) (*TagImageResponse, error)
+	ListImages(context.Context, *ListImagesRequest) (*ListImagesResponse, error)
+	ImageConfig(context.Context, *ImageConfigRequest) (*ImageConfigResponse, error)
+}
+
+func RegisterImagesServer(s *grpc.Server, srv ImagesServer) {
+	s.RegisterService(&_Images_serviceDesc, srv)
+}
+
+func _Images_Import_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ImagesServer).Import(&imagesImportServer{stream})
+}
+
+type Images_ImportServer interface {
+	SendAndClose(*ImportImageResponse) error
+	Recv() (*ImportImageRequest, error)
+	grpc.ServerStream
+}
+
+type imagesImportServer struct {
+	grpc.ServerStream
+}
+
+func (x *imagesImportServer) SendAndClose(m *ImportImageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *imagesImportServer) Recv() (*ImportImageRequest, error) {
+	m := new(ImportImageRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Images_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportImageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ImagesServer).Export(m, &imagesExportServer{stream})
+}
+
+type Images_ExportServer interface {
+	Send(*ExportImageResponse) error
+	grpc.ServerStream
+}
+
+type imagesExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *imagesExportServer) Send(m *ExportImageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Images_PrePull_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrePullRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).PrePull(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.images.v1.Images/PrePull",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).PrePull(ctx, req.(*PrePullRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_CancelPull_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPullRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).CancelPull(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.images.v1.Images/CancelPull",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).CancelPull(ctx, req.(*CancelPullRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_PutPullSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutPullSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).PutPullSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.images.v1.Images/PutPullSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).PutPullSecret(ctx, req.(*PutPullSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_DeletePullSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePullSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).DeletePullSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.images.v1.Images/DeletePullSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).DeletePullSecret(ctx, req.(*DeletePullSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_PutSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).PutSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.images.v1.Images/PutSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).PutSecret(ctx, req.(*PutSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_DeleteSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).DeleteSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.images.v1.Images/DeleteSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).DeleteSecret(ctx, req.(*DeleteSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_Tag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).Tag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.images.v1.Images/Tag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).Tag(ctx, req.(*TagImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_ListImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).ListImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.images.v1.Images/ListImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).ListImages(ctx, req.(*ListImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_ImageConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImageConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).ImageConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.images.v1.Images/ImageConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).ImageConfig(ctx, req.(*ImageConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Images_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "eliot.services.images.v1.Images",
+	HandlerType: (*ImagesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PrePull",
+			Handler:    _Images_PrePull_Handler,
+		},
+		{
+			MethodName: "CancelPull",
+			Handler:    _Images_CancelPull_Handler,
+		},
+		{
+			MethodName: "PutPullSecret",
+			Handler:    _Images_PutPullSecret_Handler,
+		},
+		{
+			MethodName: "DeletePullSecret",
+			Handler:    _Images_DeletePullSecret_Handler,
+		},
+		{
+			MethodName: "PutSecret",
+			Handler:    _Images_PutSecret_Handler,
+		},
+		{
+			MethodName: "DeleteSecret",
+			Handler:    _Images_DeleteSecret_Handler,
+		},
+		{
+			MethodName: "Tag",
+			Handler:    _Images_Tag_Handler,
+		},
+		{
+			MethodName: "ListImages",
+			Handler:    _Images_ListImages_Handler,
+		},
+		{
+			MethodName: "ImageConfig",
+			Handler:    _Images_ImageConfig_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Import",
+			Handler:       _Images_Import_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _Images_Export_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "services/images/v1/images.proto",
+}
+
+func init() { proto.RegisterFile("services/images/v1/images.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 1049 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x5f, 0x6f, 0xe3, 0x44,
+	0x10, 0x97, 0xe3, 0x5e, 0x7a, 0x99, 0xf4, 0xee, 0xda, 0x4d, 0xe9, 0x59, 0x16, 0xba, 0xab, 0xac,
+	0x03, 0x72, 0xbd, 0x36, 0xa1, 0x01, 0xa9, 0xc0, 0x71, 0x0f, 0x47, 0x1b, 0xa1, 0x8a, 0x22, 0xa2,
+	0x5c, 0x1f, 0x00, 0x21, 0x24, 0xd7, 0x99, 0xf8, 0x4c, 0x1d, 0xdb, 0xec, 0xae, 0xd3, 0xf4, 0x9b,
+	0xf0, 0xc2, 0x47, 0xe1, 0x43, 0xf0, 0x69, 0x78, 0x45, 0xbb, 0x5e, 0xc7, 0x71, 0x9c, 0x3f, 0x2e,
+	0xe5, 0x6d, 0x76, 0x76, 0x76, 0x7e, 0xbf, 0x9d, 0x19, 0xef, 0x8c, 0xe1, 0x39, 0x43, 0x3a, 0xf6,
+	0x1c, 0x64, 0x6d, 0x6f, 0x64, 0xbb, 0xc8, 0xda, 0xe3, 0x63, 0x25, 0xb5, 0x22, 0x1a, 0xf2, 0x90,
+	0x18, 0xe8, 0x7b, 0x21, 0x6f, 0xa5, 0x66, 0x2d, 0xb5, 0x39, 0x3e, 0xb6, 0x9a, 0x40, 0xce, 0x47,
+	0x51, 0x48, 0xf9, 0xb9, 0x50, 0xf5, 0xf1, 0xf7, 0x18, 0x19, 0x27, 0x04, 0x36, 0x06, 0x36, 0xb7,
+	0x0d, 0x6d, 0x5f, 0x6b, 0x6e, 0xf5, 0xa5, 0x6c, 0x1d, 0x41, 0x23, 0x67, 0xc9, 0xa2, 0x30, 0x60,
+	0x48, 0xf6, 0xa0, 0x9a, 0x78, 0x33, 0xb4, 0x7d, 0xbd, 0x59, 0xeb, 0xab, 0x95, 0x75, 0x06, 0xa4,
+	0x3b, 0x29, 0x38, 0xfe, 0x10, 0x6a, 0x81, 0x3d, 0x42, 0x16, 0xd9, 0x0e, 0x4a, 0xef, 0xb5, 0x7e,
+	0xa6, 0x20, 0xdb, 0xa0, 0x53, 0x1c, 0x1a, 0x15, 0xa9, 0x17, 0xa2, 0xf5, 0x12, 0x1a, 0xdd, 0x49,
+	0x11, 0x74, 0x11, 0xbf, 0x7f, 0x34, 0x78, 0xdc, 0xa3, 0xd8, 0x8b, 0x7d, 0xbf, 0x1c, 0x1a, 0x81,
+	0x0d, 0x8a, 0x43, 0x66, 0x54, 0x24, 0x6f, 0x29, 0x93, 0x03, 0xd8, 0x96, 0xfc, 0x85, 0x97, 0x77,
+	0xe8, 0x50, 0xe4, 0xcc, 0xd0, 0xe5, 0x7e, 0x41, 0x4f, 0x2e, 0xa0, 0xea, 0xdb, 0x57, 0xe8, 0x33,
+	0x63, 0x63, 0x5f, 0x6f, 0xd6, 0x3b, 0x9f, 0xb7, 0x96, 0x45, 0xb9, 0x95, 0xe7, 0xd5, 0xba, 0x90,
+	0xc7, 0xba, 0x01, 0xa7, 0xb7, 0x7d, 0xe5, 0xc3, 0xfc, 0x12, 0xea, 0x33, 0x6a, 0x11, 0x8a, 0x6b,
+	0xbc, 0x55, 0xa4, 0x85, 0x48, 0x76, 0xe1, 0xc1, 0xd8, 0xf6, 0x63, 0x54, 0xe1, 0x49, 0x16, 0x5f,
+	0x55, 0xbe, 0xd0, 0xac, 0x4b, 0x78, 0x32, 0x05, 0x50, 0x01, 0x7a, 0x0b, 0x9b, 0x14, 0x59, 0xec,
+	0xf3, 0x24, 0x2d, 0xf5, 0xce, 0x27, 0x25, 0xc8, 0x09, 0xfb, 0x7e, 0x7a, 0xce, 0x3a, 0x81, 0x47,
+	0xb9, 0x9d, 0x34, 0x3b, 0xda, 0x34, 0x3b, 0x82, 0x12, 0x52, 0x1a, 0xd2, 0x94, 0x92, 0x5c, 0x58,
+	0xa7, 0xb0, 0x73, 0x6a, 0x07, 0x0e, 0xfa, 0xe5, 0x53, 0x51, 0x4c, 0xfc, 0xc7, 0x40, 0x66, 0x9d,
+	0xa8, 0x6b, 0x15, 0x28, 0x58, 0x1c, 0x20, 0xcb, 0x89, 0x48, 0xa9, 0x70, 0xaa, 0x0c, 0xa4, 0x4c,
+	0x4c, 0x78, 0x48, 0xd1, 0xf5, 0x18, 0xa7, 0xb7, 0x0a, 0x60, 0xba, 0x16, 0x7b, 0x31, 0x43, 0x2a,
+	0xcf, 0xe8, 0xc9, 0x5e, 0xba, 0x16, 0x7b, 0x91, 0xcd, 0xd8, 0x4d, 0x48, 0x07, 0xc6, 0x46, 0xb2,
+	0x97, 0xae, 0x2d, 0x0a, 0xbb, 0xbd, 0x98, 0x67, 0xc0, 0xe9, 0x2d, 0xbf, 0x86, 0x2a, 0x93, 0x0a,
+	0xc9, 0xa0, 0xde, 0x79, 0xb1, 0x22, 0xea, 0xd9, 0x61, 0x75, 0x26, 0x1f, 0xa3, 0xca, 0x5c, 0x8c,
+	0xac, 0xa7, 0xf0, 0xc1, 0x1c, 0x66, 0x12, 0x14, 0xeb, 0x3b, 0x78, 0x7a, 0x86, 0x3e, 0x72, 0x2c,
+	0xf2, 0x59, 0x14, 0x8f, 0xd5, 0x28, 0x26, 0x18, 0x45, 0x67, 0x0a, 0xe8, 0x47, 0xd8, 0xee, 0xc5,
+	0x7c, 0x3d, 0x42, 0xfa, 0x75, 0x56, 0xb2, 0xaf, 0x33, 0x8f, 0xaa, 0xcf, 0xa3, 0x36, 0x60, 0x67,
+	0xc6, 0xb3, 0x82, 0xfb, 0x16, 0x1a, 0x09, 0x95, 0xfb, 0xde, 0x69, 0x0f, 0x76, 0xf3, 0x8e, 0x14,
+	0xc0, 0x4f, 0xf0, 0xe4, 0xd2, 0x76, 0xef, 0xf3, 0x3e, 0x89, 0xd7, 0x2f, 0xc0, 0x9b, 0x3e, 0x0e,
+	0xd5, 0x9d, 0xd4, 0xca, 0x7a, 0x01, 0xdb, 0x99, 0xeb, 0xa5, 0xc5, 0xfb, 0x97, 0x06, 0x3b, 0x17,
+	0x1e, 0x4b, 0x1e, 0x37, 0x56, 0x8e, 0xc3, 0x0f, 0xd3, 0x57, 0xa7, 0x22, 0x3f, 0xec, 0x93, 0xe5,
+	0x25, 0x56, 0x70, 0xfd, 0x7f, 0x3f, 0x3c, 0xdf, 0x03, 0x99, 0xc5, 0x50, 0xf7, 0x3c, 0xc9, 0x75,
+	0x84, 0x7a, 0xe7, 0xf9, 0x72, 0x86, 0x49, 0x80, 0xd2, 0x96, 0xf1, 0xb7, 0x06, 0x0f, 0xa4, 0x66,
+	0x61, 0x8e, 0xf7, 0xa0, 0x3a, 0xf0, 0x5c, 0x64, 0x5c, 0xf1, 0x50, 0x2b, 0x72, 0x3a, 0x0d, 0x88,
+	0x2e, 0xe1, 0x5e, 0xad, 0x81, 0x5b, 0x14, 0x04, 0x11, 0x73, 0x87, 0xa2, 0xcd, 0x71, 0xf0, 0x96,
+	0xcb, 0xaf, 0x5d, 0xef, 0x67, 0x8a, 0xfb, 0x84, 0x28, 0x12, 0xfd, 0xd5, 0x76, 0xf1, 0x34, 0x0c,
+	0x86, 0x9e, 0xfb, 0x5f, 0xcb, 0xec, 0x0e, 0x6d, 0xc9, 0x1a, 0x42, 0x23, 0x87, 0xb8, 0xac, 0xfa,
+	0xc8, 0x1b, 0xa8, 0x3a, 0xd2, 0x46, 0x22, 0xd5, 0x3b, 0x1f, 0xad, 0x09, 0x9c, 0x72, 0xa8, 0x0e,
+	0x59, 0x7f, 0xea, 0x50, 0x9f, 0xd1, 0x8b, 0x9c, 0x89, 0xb7, 0x33, 0xcd, 0x99, 0x90, 0xc9, 0x33,
+	0x00, 0x14, 0x21, 0x8b, 0x42, 0x2f, 0xe0, 0xaa, 0xd1, 0xce, 0x68, 0x04, 0x29, 0x67, 0x34, 0x50,
+	0x57, 0x11, 0xa2, 0xd0, 0x60, 0x30, 0x96, 0x1d, 0xb5, 0xd6, 0x17, 0xa2, 0xf0, 0x71, 0x13, 0xd2,
+	0x6b, 0x2f, 0x70, 0xcf, 0x3c, 0x6a, 0x3c, 0x90, 0xde, 0x67, 0x34, 0xc4, 0x82, 0x2d, 0x9c, 0x44,
+	0x21, 0xc3, 0x41, 0x2f, 0xa4, 0x9c, 0x19, 0x55, 0x79, 0x34, 0xa7, 0x23, 0x06, 0x6c, 0x8e, 0x43,
+	0x3f, 0x1e, 0x21, 0x33, 0x36, 0xe5, 0x76, 0xba, 0x24, 0xe7, 0xd3, 0xea, 0x79, 0x28, 0xab, 0xe7,
+	0xb8, 0x54, 0x10, 0x16, 0xd6, 0xd0, 0x33, 0x00, 0xc6, 0xc3, 0xe8, 0x9d, 0xe7, 0x06, 0xb6, 0x6f,
+	0xd4, 0x12, 0xa2, 0x99, 0x86, 0x3c, 0x86, 0x4a, 0xc8, 0x0c, 0x90, 0xfa, 0x4a, 0xc8, 0x04, 0x71,
+	0x9b, 0x3a, 0xef, 0x3d, 0x8e, 0x0e, 0x8f, 0x29, 0x1a, 0x75, 0xb9, 0x93, 0xd3, 0xdd, 0xa3, 0xf2,
+	0x3a, 0x7f, 0xd4, 0xa0, 0x9a, 0x7c, 0x99, 0xc4, 0x15, 0x92, 0x98, 0xa2, 0xc8, 0xe1, 0xaa, 0xeb,
+	0xcd, 0x4f, 0x6b, 0xe6, 0x51, 0x49, 0xeb, 0xa4, 0xc4, 0x9a, 0x9a, 0x00, 0xea, 0x4e, 0xd6, 0x01,
+	0x75, 0x27, 0x77, 0x01, 0x5a, 0x30, 0xfe, 0x7d, 0xaa, 0x91, 0x5f, 0x61, 0x53, 0x0d, 0x27, 0xa4,
+	0x59, 0x76, 0xec, 0x32, 0x5f, 0x96, 0xb0, 0x54, 0x5f, 0x8b, 0x0b, 0x90, 0x8d, 0x1f, 0x64, 0xc5,
+	0x93, 0x52, 0x98, 0x74, 0xcc, 0xc3, 0x72, 0xc6, 0x0a, 0x28, 0x82, 0x47, 0xb9, 0xae, 0x4e, 0x5a,
+	0xab, 0x46, 0x86, 0xe2, 0xc8, 0x61, 0xb6, 0x4b, 0xdb, 0x2b, 0xc4, 0x5b, 0xd8, 0x9e, 0xef, 0xf0,
+	0x64, 0x45, 0xd5, 0x2f, 0x19, 0x2d, 0xcc, 0xce, 0x5d, 0x8e, 0x28, 0xe8, 0x01, 0xd4, 0xa6, 0x6d,
+	0x9e, 0x1c, 0xac, 0x24, 0x9e, 0x07, 0x7b, 0x55, 0xca, 0x56, 0xa1, 0x8c, 0x60, 0x6b, 0xb6, 0xdd,
+	0x93, 0xa3, 0x75, 0x4c, 0xf3, 0x58, 0xad, 0xb2, 0xe6, 0x0a, 0xee, 0x17, 0xd0, 0x2f, 0x6d, 0x97,
+	0xac, 0x28, 0xae, 0xb9, 0x21, 0xc3, 0x3c, 0x28, 0x63, 0x9a, 0x15, 0x62, 0xd6, 0x62, 0x57, 0x15,
+	0x62, 0xa1, 0xd9, 0x9b, 0x87, 0xe5, 0x8c, 0x15, 0xd0, 0x6f, 0xf9, 0xd7, 0xfc, 0xb0, 0x5c, 0x33,
+	0x28, 0xf3, 0x50, 0x14, 0x7a, 0xd1, 0x37, 0x6f, 0x7e, 0x7e, 0xed, 0x7a, 0xfc, 0x7d, 0x7c, 0xd5,
+	0x72, 0xc2, 0x51, 0x1b, 0x69, 0x10, 0xda, 0x76, 0x64, 0xb7, 0xa5, 0x8f, 0x76, 0x74, 0xed, 0xb6,
+	0xed, 0xc8, 0x6b, 0x17, 0xff, 0x69, 0x5f, 0x27, 0xd2, 0x55, 0x55, 0xfe, 0xd4, 0x7e, 0xf6, 0xef,
+	0x00, 0xeb, 0x99, 0xb2, 0x67, 0xf7, 0x0e, 0x00, 0x00,
+}
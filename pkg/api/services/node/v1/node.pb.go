@@ -12,14 +12,66 @@ It has these top-level messages:
 	InfoRequest
 	InfoResponse
 	Info
+	NetworkInterface
+	Fault
+	StatsRequest
+	StatsResponse
+	Stats
+	IdentityRequest
+	IdentityResponse
+	Identity
 	Label
 	Filesystem
+	DiskUsageRequest
+	DiskUsageResponse
+	DiskUsage
+	ContainerDiskUsage
+	ResourceSummaryRequest
+	ResourceSummaryResponse
+	NamespaceSummary
+	RebootRequest
+	RebootResponse
+	PoweroffRequest
+	PoweroffResponse
+	PauseReconcileRequest
+	PauseReconcileResponse
+	ResumeReconcileRequest
+	ResumeReconcileResponse
+	SetSnapshotterRequest
+	SetSnapshotterResponse
+	MigrateSnapshotterRequest
+	MigrateSnapshotterResponse
+	SnapshotterMigrationStep
+	ReconcileHistoryRequest
+	ReconcileHistoryResponse
+	ReconcileRecord
+	SetLabelsRequest
+	SetLabelsResponse
+	WatchLabelsRequest
+	WatchLabelsResponse
+	CapabilitiesRequest
+	CapabilitiesResponse
+	DescribeDeviceRequest
+	DescribeDeviceResponse
+	ExportStateRequest
+	ExportStateResponse
+	RuntimeInfo
+	PluginStatus
+	RuntimeEventsRequest
+	RuntimeEventsResponse
+	RuntimeEvent
+	MaintenanceRequest
+	MaintenanceResponse
+	SetMaintenanceRequest
+	SetMaintenanceResponse
+	Maintenance
 */
 package node
 
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import eliot_services_pods_v1 "github.com/ernoaapa/eliot/pkg/api/services/pods/v1"
 
 import (
 	context "golang.org/x/net/context"
@@ -88,6 +140,18 @@ type Info struct {
 	Filesystems []*Filesystem `protobuf:"bytes,11,rep,name=filesystems" json:"filesystems,omitempty"`
 	// Seconds since node boot up
 	Uptime uint64 `protobuf:"varint,12,opt,name=uptime" json:"uptime,omitempty"`
+	// The oldest client version the server supports
+	MinClientVersion string `protobuf:"bytes,13,opt,name=minClientVersion" json:"minClientVersion,omitempty"`
+	// Fields what failed to resolve and contain fallback value
+	Faults []*Fault `protobuf:"bytes,14,rep,name=faults" json:"faults,omitempty"`
+	// Node current time in seconds since the epoch
+	Time int64 `protobuf:"varint,15,opt,name=time" json:"time,omitempty"`
+	// True if the system clock appears synchronized, e.g. by NTP
+	ClockSynchronized bool `protobuf:"varint,16,opt,name=clockSynchronized" json:"clockSynchronized,omitempty"`
+	// Groups the node belongs to, e.g. deployment=prod
+	Groups []*Label `protobuf:"bytes,17,rep,name=groups" json:"groups,omitempty"`
+	// Network interfaces what the node is reachable from
+	Interfaces []*NetworkInterface `protobuf:"bytes,18,rep,name=interfaces" json:"interfaces,omitempty"`
 }
 
 func (m *Info) Reset()                    { *m = Info{} }
@@ -179,6 +243,290 @@ func (m *Info) GetUptime() uint64 {
 	return 0
 }
 
+func (m *Info) GetMinClientVersion() string {
+	if m != nil {
+		return m.MinClientVersion
+	}
+	return ""
+}
+
+func (m *Info) GetFaults() []*Fault {
+	if m != nil {
+		return m.Faults
+	}
+	return nil
+}
+
+func (m *Info) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Info) GetClockSynchronized() bool {
+	if m != nil {
+		return m.ClockSynchronized
+	}
+	return false
+}
+
+func (m *Info) GetGroups() []*Label {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+func (m *Info) GetInterfaces() []*NetworkInterface {
+	if m != nil {
+		return m.Interfaces
+	}
+	return nil
+}
+
+type NetworkInterface struct {
+	// Interface name, e.g. eth0
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Hardware address, e.g. b8:27:eb:12:34:56
+	Mac       string   `protobuf:"bytes,2,opt,name=mac" json:"mac,omitempty"`
+	Addresses []string `protobuf:"bytes,3,rep,name=addresses" json:"addresses,omitempty"`
+}
+
+func (m *NetworkInterface) Reset()                    { *m = NetworkInterface{} }
+func (m *NetworkInterface) String() string            { return proto.CompactTextString(m) }
+func (*NetworkInterface) ProtoMessage()               {}
+func (*NetworkInterface) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *NetworkInterface) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NetworkInterface) GetMac() string {
+	if m != nil {
+		return m.Mac
+	}
+	return ""
+}
+
+func (m *NetworkInterface) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type Fault struct {
+	// Name of the field what failed to resolve
+	Field string `protobuf:"bytes,1,opt,name=field" json:"field,omitempty"`
+	// Why the resolving failed
+	Reason string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *Fault) Reset()                    { *m = Fault{} }
+func (m *Fault) String() string            { return proto.CompactTextString(m) }
+func (*Fault) ProtoMessage()               {}
+func (*Fault) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *Fault) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *Fault) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type StatsRequest struct {
+	// Interval between the stats in milliseconds, defaults to one second
+	Interval int64 `protobuf:"varint,1,opt,name=interval" json:"interval,omitempty"`
+}
+
+func (m *StatsRequest) Reset()                    { *m = StatsRequest{} }
+func (m *StatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*StatsRequest) ProtoMessage()               {}
+func (*StatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *StatsRequest) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+type StatsResponse struct {
+	Stats *Stats `protobuf:"bytes,1,opt,name=stats" json:"stats,omitempty"`
+}
+
+func (m *StatsResponse) Reset()                    { *m = StatsResponse{} }
+func (m *StatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*StatsResponse) ProtoMessage()               {}
+func (*StatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *StatsResponse) GetStats() *Stats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type Stats struct {
+	// System load averages over 1, 5 and 15 minutes
+	Load1  float64 `protobuf:"fixed64,1,opt,name=load1" json:"load1,omitempty"`
+	Load5  float64 `protobuf:"fixed64,2,opt,name=load5" json:"load5,omitempty"`
+	Load15 float64 `protobuf:"fixed64,3,opt,name=load15" json:"load15,omitempty"`
+	// Total and free memory in bytes
+	MemoryTotal uint64 `protobuf:"varint,4,opt,name=memoryTotal" json:"memoryTotal,omitempty"`
+	MemoryFree  uint64 `protobuf:"varint,5,opt,name=memoryFree" json:"memoryFree,omitempty"`
+	// CPU temperature in millidegrees Celsius, zero if not available
+	Temperature int64 `protobuf:"varint,6,opt,name=temperature" json:"temperature,omitempty"`
+	// Filesystem infos
+	Filesystems []*Filesystem `protobuf:"bytes,7,rep,name=filesystems" json:"filesystems,omitempty"`
+	// Seconds since node boot up
+	Uptime uint64 `protobuf:"varint,8,opt,name=uptime" json:"uptime,omitempty"`
+}
+
+func (m *Stats) Reset()                    { *m = Stats{} }
+func (m *Stats) String() string            { return proto.CompactTextString(m) }
+func (*Stats) ProtoMessage()               {}
+func (*Stats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *Stats) GetLoad1() float64 {
+	if m != nil {
+		return m.Load1
+	}
+	return 0
+}
+
+func (m *Stats) GetLoad5() float64 {
+	if m != nil {
+		return m.Load5
+	}
+	return 0
+}
+
+func (m *Stats) GetLoad15() float64 {
+	if m != nil {
+		return m.Load15
+	}
+	return 0
+}
+
+func (m *Stats) GetMemoryTotal() uint64 {
+	if m != nil {
+		return m.MemoryTotal
+	}
+	return 0
+}
+
+func (m *Stats) GetMemoryFree() uint64 {
+	if m != nil {
+		return m.MemoryFree
+	}
+	return 0
+}
+
+func (m *Stats) GetTemperature() int64 {
+	if m != nil {
+		return m.Temperature
+	}
+	return 0
+}
+
+func (m *Stats) GetFilesystems() []*Filesystem {
+	if m != nil {
+		return m.Filesystems
+	}
+	return nil
+}
+
+func (m *Stats) GetUptime() uint64 {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+type IdentityRequest struct {
+}
+
+func (m *IdentityRequest) Reset()                    { *m = IdentityRequest{} }
+func (m *IdentityRequest) String() string            { return proto.CompactTextString(m) }
+func (*IdentityRequest) ProtoMessage()               {}
+func (*IdentityRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+type IdentityResponse struct {
+	Identity *Identity `protobuf:"bytes,1,opt,name=identity" json:"identity,omitempty"`
+}
+
+func (m *IdentityResponse) Reset()                    { *m = IdentityResponse{} }
+func (m *IdentityResponse) String() string            { return proto.CompactTextString(m) }
+func (*IdentityResponse) ProtoMessage()               {}
+func (*IdentityResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *IdentityResponse) GetIdentity() *Identity {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+// Identity contains the node identifiers.
+// machineID and systemUUID are stable across reboots, bootID changes on every boot
+type Identity struct {
+	// The machine id is an ID identifying a specific Linux/Unix installation.
+	// Stable across reboots, changes if the OS gets reinstalled
+	MachineID string `protobuf:"bytes,1,opt,name=machineID" json:"machineID,omitempty"`
+	// The system uuid is the main board product UUID.
+	// Stable across reboots and OS reinstalls
+	SystemUUID string `protobuf:"bytes,2,opt,name=systemUUID" json:"systemUUID,omitempty"`
+	// A random ID that is regenerated on each boot
+	BootID string `protobuf:"bytes,3,opt,name=bootID" json:"bootID,omitempty"`
+	// Labels for the node
+	Labels []*Label `protobuf:"bytes,4,rep,name=labels" json:"labels,omitempty"`
+}
+
+func (m *Identity) Reset()                    { *m = Identity{} }
+func (m *Identity) String() string            { return proto.CompactTextString(m) }
+func (*Identity) ProtoMessage()               {}
+func (*Identity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *Identity) GetMachineID() string {
+	if m != nil {
+		return m.MachineID
+	}
+	return ""
+}
+
+func (m *Identity) GetSystemUUID() string {
+	if m != nil {
+		return m.SystemUUID
+	}
+	return ""
+}
+
+func (m *Identity) GetBootID() string {
+	if m != nil {
+		return m.BootID
+	}
+	return ""
+}
+
+func (m *Identity) GetLabels() []*Label {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 type Label struct {
 	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
 	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
@@ -187,7 +535,7 @@ type Label struct {
 func (m *Label) Reset()                    { *m = Label{} }
 func (m *Label) String() string            { return proto.CompactTextString(m) }
 func (*Label) ProtoMessage()               {}
-func (*Label) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*Label) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *Label) GetKey() string {
 	if m != nil {
@@ -221,7 +569,7 @@ type Filesystem struct {
 func (m *Filesystem) Reset()                    { *m = Filesystem{} }
 func (m *Filesystem) String() string            { return proto.CompactTextString(m) }
 func (*Filesystem) ProtoMessage()               {}
-func (*Filesystem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*Filesystem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *Filesystem) GetFilesystem() string {
 	if m != nil {
@@ -265,119 +613,2103 @@ func (m *Filesystem) GetAvailable() uint64 {
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*InfoRequest)(nil), "eliot.services.containers.v1.InfoRequest")
-	proto.RegisterType((*InfoResponse)(nil), "eliot.services.containers.v1.InfoResponse")
-	proto.RegisterType((*Info)(nil), "eliot.services.containers.v1.Info")
-	proto.RegisterType((*Label)(nil), "eliot.services.containers.v1.Label")
-	proto.RegisterType((*Filesystem)(nil), "eliot.services.containers.v1.Filesystem")
+type DiskUsageRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *DiskUsageRequest) Reset()                    { *m = DiskUsageRequest{} }
+func (m *DiskUsageRequest) String() string            { return proto.CompactTextString(m) }
+func (*DiskUsageRequest) ProtoMessage()               {}
+func (*DiskUsageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *DiskUsageRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
 
-// Client API for Node service
+type DiskUsageResponse struct {
+	Usage *DiskUsage `protobuf:"bytes,1,opt,name=usage" json:"usage,omitempty"`
+}
 
-type NodeClient interface {
-	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+func (m *DiskUsageResponse) Reset()                    { *m = DiskUsageResponse{} }
+func (m *DiskUsageResponse) String() string            { return proto.CompactTextString(m) }
+func (*DiskUsageResponse) ProtoMessage()               {}
+func (*DiskUsageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *DiskUsageResponse) GetUsage() *DiskUsage {
+	if m != nil {
+		return m.Usage
+	}
+	return nil
 }
 
-type nodeClient struct {
-	cc *grpc.ClientConn
+type DiskUsage struct {
+	// Total size of the content store (images) in bytes
+	ContentSize int64 `protobuf:"varint,1,opt,name=contentSize" json:"contentSize,omitempty"`
+	// Usage of each container snapshot
+	Containers []*ContainerDiskUsage `protobuf:"bytes,2,rep,name=containers" json:"containers,omitempty"`
 }
 
-func NewNodeClient(cc *grpc.ClientConn) NodeClient {
-	return &nodeClient{cc}
+func (m *DiskUsage) Reset()                    { *m = DiskUsage{} }
+func (m *DiskUsage) String() string            { return proto.CompactTextString(m) }
+func (*DiskUsage) ProtoMessage()               {}
+func (*DiskUsage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *DiskUsage) GetContentSize() int64 {
+	if m != nil {
+		return m.ContentSize
+	}
+	return 0
 }
 
-func (c *nodeClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
-	out := new(InfoResponse)
-	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/Info", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *DiskUsage) GetContainers() []*ContainerDiskUsage {
+	if m != nil {
+		return m.Containers
 	}
-	return out, nil
+	return nil
 }
 
-// Server API for Node service
+type ContainerDiskUsage struct {
+	ContainerID string `protobuf:"bytes,1,opt,name=containerID" json:"containerID,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Pod         string `protobuf:"bytes,3,opt,name=pod" json:"pod,omitempty"`
+	// Bytes used by the container snapshot
+	Size int64 `protobuf:"varint,4,opt,name=size" json:"size,omitempty"`
+	// Number of inodes used by the container snapshot
+	Inodes int64 `protobuf:"varint,5,opt,name=inodes" json:"inodes,omitempty"`
+}
 
-type NodeServer interface {
-	Info(context.Context, *InfoRequest) (*InfoResponse, error)
+func (m *ContainerDiskUsage) Reset()                    { *m = ContainerDiskUsage{} }
+func (m *ContainerDiskUsage) String() string            { return proto.CompactTextString(m) }
+func (*ContainerDiskUsage) ProtoMessage()               {}
+func (*ContainerDiskUsage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *ContainerDiskUsage) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
 }
 
-func RegisterNodeServer(s *grpc.Server, srv NodeServer) {
-	s.RegisterService(&_Node_serviceDesc, srv)
+func (m *ContainerDiskUsage) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-func _Node_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InfoRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ContainerDiskUsage) GetPod() string {
+	if m != nil {
+		return m.Pod
 	}
-	if interceptor == nil {
-		return srv.(NodeServer).Info(ctx, in)
+	return ""
+}
+
+func (m *ContainerDiskUsage) GetSize() int64 {
+	if m != nil {
+		return m.Size
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/eliot.services.containers.v1.Node/Info",
+	return 0
+}
+
+func (m *ContainerDiskUsage) GetInodes() int64 {
+	if m != nil {
+		return m.Inodes
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServer).Info(ctx, req.(*InfoRequest))
+	return 0
+}
+
+type ResourceSummaryRequest struct {
+}
+
+func (m *ResourceSummaryRequest) Reset()                    { *m = ResourceSummaryRequest{} }
+func (m *ResourceSummaryRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResourceSummaryRequest) ProtoMessage()               {}
+func (*ResourceSummaryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+type ResourceSummaryResponse struct {
+	Namespaces []*NamespaceSummary `protobuf:"bytes,1,rep,name=namespaces" json:"namespaces,omitempty"`
+}
+
+func (m *ResourceSummaryResponse) Reset()                    { *m = ResourceSummaryResponse{} }
+func (m *ResourceSummaryResponse) String() string            { return proto.CompactTextString(m) }
+func (*ResourceSummaryResponse) ProtoMessage()               {}
+func (*ResourceSummaryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *ResourceSummaryResponse) GetNamespaces() []*NamespaceSummary {
+	if m != nil {
+		return m.Namespaces
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-var _Node_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "eliot.services.containers.v1.Node",
-	HandlerType: (*NodeServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
+type NamespaceSummary struct {
+	Namespace         string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	Containers        int32  `protobuf:"varint,2,opt,name=containers" json:"containers,omitempty"`
+	RunningContainers int32  `protobuf:"varint,3,opt,name=runningContainers" json:"runningContainers,omitempty"`
+	// Total CPU time consumed by the running containers in nanoseconds
+	CpuUsage uint64 `protobuf:"varint,4,opt,name=cpuUsage" json:"cpuUsage,omitempty"`
+	// Memory used by the running containers in bytes
+	MemoryUsage uint64 `protobuf:"varint,5,opt,name=memoryUsage" json:"memoryUsage,omitempty"`
+	// Bytes used by the images in the content store
+	ImageSize int64 `protobuf:"varint,6,opt,name=imageSize" json:"imageSize,omitempty"`
+	// Bytes used by the container writable snapshots
+	SnapshotSize int64 `protobuf:"varint,7,opt,name=snapshotSize" json:"snapshotSize,omitempty"`
+}
+
+func (m *NamespaceSummary) Reset()                    { *m = NamespaceSummary{} }
+func (m *NamespaceSummary) String() string            { return proto.CompactTextString(m) }
+func (*NamespaceSummary) ProtoMessage()               {}
+func (*NamespaceSummary) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *NamespaceSummary) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *NamespaceSummary) GetContainers() int32 {
+	if m != nil {
+		return m.Containers
+	}
+	return 0
+}
+
+func (m *NamespaceSummary) GetRunningContainers() int32 {
+	if m != nil {
+		return m.RunningContainers
+	}
+	return 0
+}
+
+func (m *NamespaceSummary) GetCpuUsage() uint64 {
+	if m != nil {
+		return m.CpuUsage
+	}
+	return 0
+}
+
+func (m *NamespaceSummary) GetMemoryUsage() uint64 {
+	if m != nil {
+		return m.MemoryUsage
+	}
+	return 0
+}
+
+func (m *NamespaceSummary) GetImageSize() int64 {
+	if m != nil {
+		return m.ImageSize
+	}
+	return 0
+}
+
+func (m *NamespaceSummary) GetSnapshotSize() int64 {
+	if m != nil {
+		return m.SnapshotSize
+	}
+	return 0
+}
+
+type RebootRequest struct {
+	// How long the containers have time to stop, zero means the pod stop grace period
+	GracePeriodSeconds int64 `protobuf:"varint,1,opt,name=gracePeriodSeconds" json:"gracePeriodSeconds,omitempty"`
+}
+
+func (m *RebootRequest) Reset()                    { *m = RebootRequest{} }
+func (m *RebootRequest) String() string            { return proto.CompactTextString(m) }
+func (*RebootRequest) ProtoMessage()               {}
+func (*RebootRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *RebootRequest) GetGracePeriodSeconds() int64 {
+	if m != nil {
+		return m.GracePeriodSeconds
+	}
+	return 0
+}
+
+type RebootResponse struct {
+	// Number of the containers what got stopped before the reboot
+	StoppedContainers int32 `protobuf:"varint,1,opt,name=stoppedContainers" json:"stoppedContainers,omitempty"`
+}
+
+func (m *RebootResponse) Reset()                    { *m = RebootResponse{} }
+func (m *RebootResponse) String() string            { return proto.CompactTextString(m) }
+func (*RebootResponse) ProtoMessage()               {}
+func (*RebootResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *RebootResponse) GetStoppedContainers() int32 {
+	if m != nil {
+		return m.StoppedContainers
+	}
+	return 0
+}
+
+type PoweroffRequest struct {
+	// How long the containers have time to stop, zero means the pod stop grace period
+	GracePeriodSeconds int64 `protobuf:"varint,1,opt,name=gracePeriodSeconds" json:"gracePeriodSeconds,omitempty"`
+}
+
+func (m *PoweroffRequest) Reset()                    { *m = PoweroffRequest{} }
+func (m *PoweroffRequest) String() string            { return proto.CompactTextString(m) }
+func (*PoweroffRequest) ProtoMessage()               {}
+func (*PoweroffRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *PoweroffRequest) GetGracePeriodSeconds() int64 {
+	if m != nil {
+		return m.GracePeriodSeconds
+	}
+	return 0
+}
+
+type PoweroffResponse struct {
+	// Number of the containers what got stopped before the shutdown
+	StoppedContainers int32 `protobuf:"varint,1,opt,name=stoppedContainers" json:"stoppedContainers,omitempty"`
+}
+
+func (m *PoweroffResponse) Reset()                    { *m = PoweroffResponse{} }
+func (m *PoweroffResponse) String() string            { return proto.CompactTextString(m) }
+func (*PoweroffResponse) ProtoMessage()               {}
+func (*PoweroffResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *PoweroffResponse) GetStoppedContainers() int32 {
+	if m != nil {
+		return m.StoppedContainers
+	}
+	return 0
+}
+
+type PauseReconcileRequest struct {
+	// How long to pause, zero or longer than eliotd --reconcile-pause-max-timeout means the max timeout
+	TimeoutSeconds int64 `protobuf:"varint,1,opt,name=timeoutSeconds" json:"timeoutSeconds,omitempty"`
+}
+
+func (m *PauseReconcileRequest) Reset()                    { *m = PauseReconcileRequest{} }
+func (m *PauseReconcileRequest) String() string            { return proto.CompactTextString(m) }
+func (*PauseReconcileRequest) ProtoMessage()               {}
+func (*PauseReconcileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *PauseReconcileRequest) GetTimeoutSeconds() int64 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+type PauseReconcileResponse struct {
+	// Unix timestamp in seconds when reconciling resumes automatically
+	ResumeAt int64 `protobuf:"varint,1,opt,name=resumeAt" json:"resumeAt,omitempty"`
+}
+
+func (m *PauseReconcileResponse) Reset()                    { *m = PauseReconcileResponse{} }
+func (m *PauseReconcileResponse) String() string            { return proto.CompactTextString(m) }
+func (*PauseReconcileResponse) ProtoMessage()               {}
+func (*PauseReconcileResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *PauseReconcileResponse) GetResumeAt() int64 {
+	if m != nil {
+		return m.ResumeAt
+	}
+	return 0
+}
+
+type ResumeReconcileRequest struct {
+}
+
+func (m *ResumeReconcileRequest) Reset()                    { *m = ResumeReconcileRequest{} }
+func (m *ResumeReconcileRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResumeReconcileRequest) ProtoMessage()               {}
+func (*ResumeReconcileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+type ResumeReconcileResponse struct {
+}
+
+func (m *ResumeReconcileResponse) Reset()                    { *m = ResumeReconcileResponse{} }
+func (m *ResumeReconcileResponse) String() string            { return proto.CompactTextString(m) }
+func (*ResumeReconcileResponse) ProtoMessage()               {}
+func (*ResumeReconcileResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+type SetSnapshotterRequest struct {
+	Snapshotter string `protobuf:"bytes,1,opt,name=snapshotter" json:"snapshotter,omitempty"`
+}
+
+func (m *SetSnapshotterRequest) Reset()                    { *m = SetSnapshotterRequest{} }
+func (m *SetSnapshotterRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetSnapshotterRequest) ProtoMessage()               {}
+func (*SetSnapshotterRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *SetSnapshotterRequest) GetSnapshotter() string {
+	if m != nil {
+		return m.Snapshotter
+	}
+	return ""
+}
+
+type SetSnapshotterResponse struct {
+	// The runtime capabilities with the changed snapshotter
+	Runtime *RuntimeInfo `protobuf:"bytes,1,opt,name=runtime" json:"runtime,omitempty"`
+}
+
+func (m *SetSnapshotterResponse) Reset()                    { *m = SetSnapshotterResponse{} }
+func (m *SetSnapshotterResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetSnapshotterResponse) ProtoMessage()               {}
+func (*SetSnapshotterResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *SetSnapshotterResponse) GetRuntime() *RuntimeInfo {
+	if m != nil {
+		return m.Runtime
+	}
+	return nil
+}
+
+type MigrateSnapshotterRequest struct {
+	Snapshotter string `protobuf:"bytes,1,opt,name=snapshotter" json:"snapshotter,omitempty"`
+	// Recreate the containers with the snapshotter, the recreated containers get fresh filesystem from the image
+	RecreateContainers bool `protobuf:"varint,2,opt,name=recreateContainers" json:"recreateContainers,omitempty"`
+}
+
+func (m *MigrateSnapshotterRequest) Reset()                    { *m = MigrateSnapshotterRequest{} }
+func (m *MigrateSnapshotterRequest) String() string            { return proto.CompactTextString(m) }
+func (*MigrateSnapshotterRequest) ProtoMessage()               {}
+func (*MigrateSnapshotterRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *MigrateSnapshotterRequest) GetSnapshotter() string {
+	if m != nil {
+		return m.Snapshotter
+	}
+	return ""
+}
+
+func (m *MigrateSnapshotterRequest) GetRecreateContainers() bool {
+	if m != nil {
+		return m.RecreateContainers
+	}
+	return false
+}
+
+type MigrateSnapshotterResponse struct {
+	Step *SnapshotterMigrationStep `protobuf:"bytes,1,opt,name=step" json:"step,omitempty"`
+}
+
+func (m *MigrateSnapshotterResponse) Reset()                    { *m = MigrateSnapshotterResponse{} }
+func (m *MigrateSnapshotterResponse) String() string            { return proto.CompactTextString(m) }
+func (*MigrateSnapshotterResponse) ProtoMessage()               {}
+func (*MigrateSnapshotterResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *MigrateSnapshotterResponse) GetStep() *SnapshotterMigrationStep {
+	if m != nil {
+		return m.Step
+	}
+	return nil
+}
+
+type SnapshotterMigrationStep struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	// "image" or "container"
+	Kind string `protobuf:"bytes,2,opt,name=kind" json:"kind,omitempty"`
+	// Image name or container ID
+	Name string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	// Step number and the number of steps in the namespace
+	Current int32 `protobuf:"varint,4,opt,name=current" json:"current,omitempty"`
+	Total   int32 `protobuf:"varint,5,opt,name=total" json:"total,omitempty"`
+	// True if the image or container was already in the snapshotter
+	Skipped bool `protobuf:"varint,6,opt,name=skipped" json:"skipped,omitempty"`
+	// Error message if the step failed, empty on success
+	Error string `protobuf:"bytes,7,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *SnapshotterMigrationStep) Reset()                    { *m = SnapshotterMigrationStep{} }
+func (m *SnapshotterMigrationStep) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotterMigrationStep) ProtoMessage()               {}
+func (*SnapshotterMigrationStep) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *SnapshotterMigrationStep) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *SnapshotterMigrationStep) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *SnapshotterMigrationStep) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SnapshotterMigrationStep) GetCurrent() int32 {
+	if m != nil {
+		return m.Current
+	}
+	return 0
+}
+
+func (m *SnapshotterMigrationStep) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *SnapshotterMigrationStep) GetSkipped() bool {
+	if m != nil {
+		return m.Skipped
+	}
+	return false
+}
+
+func (m *SnapshotterMigrationStep) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ReconcileHistoryRequest struct {
+}
+
+func (m *ReconcileHistoryRequest) Reset()                    { *m = ReconcileHistoryRequest{} }
+func (m *ReconcileHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReconcileHistoryRequest) ProtoMessage()               {}
+func (*ReconcileHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+type ReconcileHistoryResponse struct {
+	Records []*ReconcileRecord `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+}
+
+func (m *ReconcileHistoryResponse) Reset()                    { *m = ReconcileHistoryResponse{} }
+func (m *ReconcileHistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReconcileHistoryResponse) ProtoMessage()               {}
+func (*ReconcileHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *ReconcileHistoryResponse) GetRecords() []*ReconcileRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+type ReconcileRecord struct {
+	// Unix timestamp in seconds when the action was taken
+	Time        int64  `protobuf:"varint,1,opt,name=time" json:"time,omitempty"`
+	Namespace   string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	Pod         string `protobuf:"bytes,3,opt,name=pod" json:"pod,omitempty"`
+	ContainerID string `protobuf:"bytes,4,opt,name=containerID" json:"containerID,omitempty"`
+	// Either restarted or failed
+	Action string `protobuf:"bytes,5,opt,name=action" json:"action,omitempty"`
+	// The error message if the action failed
+	Error string `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *ReconcileRecord) Reset()                    { *m = ReconcileRecord{} }
+func (m *ReconcileRecord) String() string            { return proto.CompactTextString(m) }
+func (*ReconcileRecord) ProtoMessage()               {}
+func (*ReconcileRecord) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *ReconcileRecord) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ReconcileRecord) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ReconcileRecord) GetPod() string {
+	if m != nil {
+		return m.Pod
+	}
+	return ""
+}
+
+func (m *ReconcileRecord) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+func (m *ReconcileRecord) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *ReconcileRecord) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type SetLabelsRequest struct {
+	// Labels to add or change
+	Labels []*Label `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty"`
+	// Keys of the labels to remove
+	Remove []string `protobuf:"bytes,2,rep,name=remove" json:"remove,omitempty"`
+}
+
+func (m *SetLabelsRequest) Reset()                    { *m = SetLabelsRequest{} }
+func (m *SetLabelsRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetLabelsRequest) ProtoMessage()               {}
+func (*SetLabelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *SetLabelsRequest) GetLabels() []*Label {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *SetLabelsRequest) GetRemove() []string {
+	if m != nil {
+		return m.Remove
+	}
+	return nil
+}
+
+type SetLabelsResponse struct {
+	// The node labels after the change
+	Labels []*Label `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty"`
+}
+
+func (m *SetLabelsResponse) Reset()                    { *m = SetLabelsResponse{} }
+func (m *SetLabelsResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetLabelsResponse) ProtoMessage()               {}
+func (*SetLabelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *SetLabelsResponse) GetLabels() []*Label {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type WatchLabelsRequest struct {
+}
+
+func (m *WatchLabelsRequest) Reset()                    { *m = WatchLabelsRequest{} }
+func (m *WatchLabelsRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchLabelsRequest) ProtoMessage()               {}
+func (*WatchLabelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+type WatchLabelsResponse struct {
+	Labels []*Label `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty"`
+}
+
+func (m *WatchLabelsResponse) Reset()                    { *m = WatchLabelsResponse{} }
+func (m *WatchLabelsResponse) String() string            { return proto.CompactTextString(m) }
+func (*WatchLabelsResponse) ProtoMessage()               {}
+func (*WatchLabelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *WatchLabelsResponse) GetLabels() []*Label {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type CapabilitiesRequest struct {
+}
+
+func (m *CapabilitiesRequest) Reset()                    { *m = CapabilitiesRequest{} }
+func (m *CapabilitiesRequest) String() string            { return proto.CompactTextString(m) }
+func (*CapabilitiesRequest) ProtoMessage()               {}
+func (*CapabilitiesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+type CapabilitiesResponse struct {
+	// Server version
+	Version string `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	// The oldest client version the server supports
+	MinClientVersion string `protobuf:"bytes,2,opt,name=minClientVersion" json:"minClientVersion,omitempty"`
+	// Full names of the API methods the server implements, e.g. /eliot.services.pods.v1.Pods/Run
+	Methods []string `protobuf:"bytes,3,rep,name=methods" json:"methods,omitempty"`
+	// The optional features what are enabled in the server configuration, e.g. power-control
+	Features []string `protobuf:"bytes,4,rep,name=features" json:"features,omitempty"`
+	// The snapshotters what containers can be created with
+	Snapshotters []string `protobuf:"bytes,5,rep,name=snapshotters" json:"snapshotters,omitempty"`
+}
+
+func (m *CapabilitiesResponse) Reset()                    { *m = CapabilitiesResponse{} }
+func (m *CapabilitiesResponse) String() string            { return proto.CompactTextString(m) }
+func (*CapabilitiesResponse) ProtoMessage()               {}
+func (*CapabilitiesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *CapabilitiesResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *CapabilitiesResponse) GetMinClientVersion() string {
+	if m != nil {
+		return m.MinClientVersion
+	}
+	return ""
+}
+
+func (m *CapabilitiesResponse) GetMethods() []string {
+	if m != nil {
+		return m.Methods
+	}
+	return nil
+}
+
+func (m *CapabilitiesResponse) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *CapabilitiesResponse) GetSnapshotters() []string {
+	if m != nil {
+		return m.Snapshotters
+	}
+	return nil
+}
+
+type DescribeDeviceRequest struct {
+}
+
+func (m *DescribeDeviceRequest) Reset()                    { *m = DescribeDeviceRequest{} }
+func (m *DescribeDeviceRequest) String() string            { return proto.CompactTextString(m) }
+func (*DescribeDeviceRequest) ProtoMessage()               {}
+func (*DescribeDeviceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+type DescribeDeviceResponse struct {
+	Info       *Info               `protobuf:"bytes,1,opt,name=info" json:"info,omitempty"`
+	Runtime    *RuntimeInfo        `protobuf:"bytes,2,opt,name=runtime" json:"runtime,omitempty"`
+	Namespaces []*NamespaceSummary `protobuf:"bytes,3,rep,name=namespaces" json:"namespaces,omitempty"`
+	// Pods in all namespaces with the container statuses
+	Pods []*eliot_services_pods_v1.Pod `protobuf:"bytes,4,rep,name=pods" json:"pods,omitempty"`
+}
+
+func (m *DescribeDeviceResponse) Reset()                    { *m = DescribeDeviceResponse{} }
+func (m *DescribeDeviceResponse) String() string            { return proto.CompactTextString(m) }
+func (*DescribeDeviceResponse) ProtoMessage()               {}
+func (*DescribeDeviceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *DescribeDeviceResponse) GetInfo() *Info {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *DescribeDeviceResponse) GetRuntime() *RuntimeInfo {
+	if m != nil {
+		return m.Runtime
+	}
+	return nil
+}
+
+func (m *DescribeDeviceResponse) GetNamespaces() []*NamespaceSummary {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
+func (m *DescribeDeviceResponse) GetPods() []*eliot_services_pods_v1.Pod {
+	if m != nil {
+		return m.Pods
+	}
+	return nil
+}
+
+type ExportStateRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	// Export pods from all namespaces, ignores the namespace
+	AllNamespaces bool `protobuf:"varint,2,opt,name=allNamespaces" json:"allNamespaces,omitempty"`
+}
+
+func (m *ExportStateRequest) Reset()                    { *m = ExportStateRequest{} }
+func (m *ExportStateRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportStateRequest) ProtoMessage()               {}
+func (*ExportStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *ExportStateRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ExportStateRequest) GetAllNamespaces() bool {
+	if m != nil {
+		return m.AllNamespaces
+	}
+	return false
+}
+
+type ExportStateResponse struct {
+	// Pods without status, in format what can be created again
+	Pods []*eliot_services_pods_v1.Pod `protobuf:"bytes,1,rep,name=pods" json:"pods,omitempty"`
+}
+
+func (m *ExportStateResponse) Reset()                    { *m = ExportStateResponse{} }
+func (m *ExportStateResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportStateResponse) ProtoMessage()               {}
+func (*ExportStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *ExportStateResponse) GetPods() []*eliot_services_pods_v1.Pod {
+	if m != nil {
+		return m.Pods
+	}
+	return nil
+}
+
+type RuntimeInfo struct {
+	ContainerdVersion  string `protobuf:"bytes,1,opt,name=containerdVersion" json:"containerdVersion,omitempty"`
+	ContainerdRevision string `protobuf:"bytes,2,opt,name=containerdRevision" json:"containerdRevision,omitempty"`
+	// The snapshotter what eliot creates the containers with
+	Snapshotter string `protobuf:"bytes,3,opt,name=snapshotter" json:"snapshotter,omitempty"`
+	// The snapshotters what containerd has available, e.g. overlayfs
+	Snapshotters []string `protobuf:"bytes,4,rep,name=snapshotters" json:"snapshotters,omitempty"`
+	// The runtimes what containerd has available, e.g. io.containerd.runtime.v1.linux
+	Runtimes []string `protobuf:"bytes,5,rep,name=runtimes" json:"runtimes,omitempty"`
+	// All the containerd plugins with their status
+	Plugins []*PluginStatus `protobuf:"bytes,6,rep,name=plugins" json:"plugins,omitempty"`
+	// The number of containers in all namespaces, running or stopped
+	Containers int64 `protobuf:"varint,7,opt,name=containers" json:"containers,omitempty"`
+	// The maximum number of containers, running or stopped, zero means no limit
+	MaxContainers int64 `protobuf:"varint,8,opt,name=maxContainers" json:"maxContainers,omitempty"`
+}
+
+func (m *RuntimeInfo) Reset()                    { *m = RuntimeInfo{} }
+func (m *RuntimeInfo) String() string            { return proto.CompactTextString(m) }
+func (*RuntimeInfo) ProtoMessage()               {}
+func (*RuntimeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *RuntimeInfo) GetContainerdVersion() string {
+	if m != nil {
+		return m.ContainerdVersion
+	}
+	return ""
+}
+
+func (m *RuntimeInfo) GetContainerdRevision() string {
+	if m != nil {
+		return m.ContainerdRevision
+	}
+	return ""
+}
+
+func (m *RuntimeInfo) GetSnapshotter() string {
+	if m != nil {
+		return m.Snapshotter
+	}
+	return ""
+}
+
+func (m *RuntimeInfo) GetSnapshotters() []string {
+	if m != nil {
+		return m.Snapshotters
+	}
+	return nil
+}
+
+func (m *RuntimeInfo) GetRuntimes() []string {
+	if m != nil {
+		return m.Runtimes
+	}
+	return nil
+}
+
+func (m *RuntimeInfo) GetPlugins() []*PluginStatus {
+	if m != nil {
+		return m.Plugins
+	}
+	return nil
+}
+
+func (m *RuntimeInfo) GetContainers() int64 {
+	if m != nil {
+		return m.Containers
+	}
+	return 0
+}
+
+func (m *RuntimeInfo) GetMaxContainers() int64 {
+	if m != nil {
+		return m.MaxContainers
+	}
+	return 0
+}
+
+type PluginStatus struct {
+	// The plugin type, e.g. io.containerd.snapshotter.v1
+	Type string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Id   string `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	// The plugin initialisation error, empty if the plugin is working
+	Error string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *PluginStatus) Reset()                    { *m = PluginStatus{} }
+func (m *PluginStatus) String() string            { return proto.CompactTextString(m) }
+func (*PluginStatus) ProtoMessage()               {}
+func (*PluginStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *PluginStatus) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *PluginStatus) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PluginStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type RuntimeEventsRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	// Stream events from all namespaces, ignores the namespace
+	AllNamespaces bool `protobuf:"varint,2,opt,name=allNamespaces" json:"allNamespaces,omitempty"`
+	// containerd event filters, e.g. topic~="/tasks/", event gets streamed if it matches any of them
+	Filters []string `protobuf:"bytes,3,rep,name=filters" json:"filters,omitempty"`
+}
+
+func (m *RuntimeEventsRequest) Reset()                    { *m = RuntimeEventsRequest{} }
+func (m *RuntimeEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*RuntimeEventsRequest) ProtoMessage()               {}
+func (*RuntimeEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *RuntimeEventsRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *RuntimeEventsRequest) GetAllNamespaces() bool {
+	if m != nil {
+		return m.AllNamespaces
+	}
+	return false
+}
+
+func (m *RuntimeEventsRequest) GetFilters() []string {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
+type RuntimeEventsResponse struct {
+	Event *RuntimeEvent `protobuf:"bytes,1,opt,name=event" json:"event,omitempty"`
+}
+
+func (m *RuntimeEventsResponse) Reset()                    { *m = RuntimeEventsResponse{} }
+func (m *RuntimeEventsResponse) String() string            { return proto.CompactTextString(m) }
+func (*RuntimeEventsResponse) ProtoMessage()               {}
+func (*RuntimeEventsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *RuntimeEventsResponse) GetEvent() *RuntimeEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type RuntimeEvent struct {
+	// Unix timestamp in nanoseconds when containerd published the event
+	Timestamp int64  `protobuf:"varint,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	// Event topic, e.g. /tasks/exit
+	Topic string `protobuf:"bytes,3,opt,name=topic" json:"topic,omitempty"`
+	// Container ID, image name or other name what the event is about, empty if not known
+	Subject string `protobuf:"bytes,4,opt,name=subject" json:"subject,omitempty"`
+	// Type URL and the protobuf encoded event
+	TypeUrl string `protobuf:"bytes,5,opt,name=typeUrl" json:"typeUrl,omitempty"`
+	Payload []byte `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (m *RuntimeEvent) Reset()                    { *m = RuntimeEvent{} }
+func (m *RuntimeEvent) String() string            { return proto.CompactTextString(m) }
+func (*RuntimeEvent) ProtoMessage()               {}
+func (*RuntimeEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *RuntimeEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *RuntimeEvent) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *RuntimeEvent) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *RuntimeEvent) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *RuntimeEvent) GetTypeUrl() string {
+	if m != nil {
+		return m.TypeUrl
+	}
+	return ""
+}
+
+func (m *RuntimeEvent) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type MaintenanceRequest struct {
+}
+
+func (m *MaintenanceRequest) Reset()                    { *m = MaintenanceRequest{} }
+func (m *MaintenanceRequest) String() string            { return proto.CompactTextString(m) }
+func (*MaintenanceRequest) ProtoMessage()               {}
+func (*MaintenanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+type MaintenanceResponse struct {
+	Maintenance *Maintenance `protobuf:"bytes,1,opt,name=maintenance" json:"maintenance,omitempty"`
+}
+
+func (m *MaintenanceResponse) Reset()                    { *m = MaintenanceResponse{} }
+func (m *MaintenanceResponse) String() string            { return proto.CompactTextString(m) }
+func (*MaintenanceResponse) ProtoMessage()               {}
+func (*MaintenanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *MaintenanceResponse) GetMaintenance() *Maintenance {
+	if m != nil {
+		return m.Maintenance
+	}
+	return nil
+}
+
+type SetMaintenanceRequest struct {
+	Enabled bool `protobuf:"varint,1,opt,name=enabled" json:"enabled,omitempty"`
+	// Why the node is in maintenance, returned in the rejected calls errors
+	Reason string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *SetMaintenanceRequest) Reset()                    { *m = SetMaintenanceRequest{} }
+func (m *SetMaintenanceRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetMaintenanceRequest) ProtoMessage()               {}
+func (*SetMaintenanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *SetMaintenanceRequest) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *SetMaintenanceRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type SetMaintenanceResponse struct {
+	Maintenance *Maintenance `protobuf:"bytes,1,opt,name=maintenance" json:"maintenance,omitempty"`
+}
+
+func (m *SetMaintenanceResponse) Reset()                    { *m = SetMaintenanceResponse{} }
+func (m *SetMaintenanceResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetMaintenanceResponse) ProtoMessage()               {}
+func (*SetMaintenanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *SetMaintenanceResponse) GetMaintenance() *Maintenance {
+	if m != nil {
+		return m.Maintenance
+	}
+	return nil
+}
+
+type Maintenance struct {
+	Enabled bool   `protobuf:"varint,1,opt,name=enabled" json:"enabled,omitempty"`
+	Reason  string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+	// Unix timestamp in seconds when the maintenance mode was enabled, zero if not enabled
+	Since int64 `protobuf:"varint,3,opt,name=since" json:"since,omitempty"`
+}
+
+func (m *Maintenance) Reset()                    { *m = Maintenance{} }
+func (m *Maintenance) String() string            { return proto.CompactTextString(m) }
+func (*Maintenance) ProtoMessage()               {}
+func (*Maintenance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *Maintenance) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *Maintenance) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Maintenance) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*InfoRequest)(nil), "eliot.services.containers.v1.InfoRequest")
+	proto.RegisterType((*InfoResponse)(nil), "eliot.services.containers.v1.InfoResponse")
+	proto.RegisterType((*Info)(nil), "eliot.services.containers.v1.Info")
+	proto.RegisterType((*NetworkInterface)(nil), "eliot.services.containers.v1.NetworkInterface")
+	proto.RegisterType((*Fault)(nil), "eliot.services.containers.v1.Fault")
+	proto.RegisterType((*StatsRequest)(nil), "eliot.services.containers.v1.StatsRequest")
+	proto.RegisterType((*StatsResponse)(nil), "eliot.services.containers.v1.StatsResponse")
+	proto.RegisterType((*Stats)(nil), "eliot.services.containers.v1.Stats")
+	proto.RegisterType((*IdentityRequest)(nil), "eliot.services.containers.v1.IdentityRequest")
+	proto.RegisterType((*IdentityResponse)(nil), "eliot.services.containers.v1.IdentityResponse")
+	proto.RegisterType((*Identity)(nil), "eliot.services.containers.v1.Identity")
+	proto.RegisterType((*Label)(nil), "eliot.services.containers.v1.Label")
+	proto.RegisterType((*Filesystem)(nil), "eliot.services.containers.v1.Filesystem")
+	proto.RegisterType((*DiskUsageRequest)(nil), "eliot.services.containers.v1.DiskUsageRequest")
+	proto.RegisterType((*DiskUsageResponse)(nil), "eliot.services.containers.v1.DiskUsageResponse")
+	proto.RegisterType((*DiskUsage)(nil), "eliot.services.containers.v1.DiskUsage")
+	proto.RegisterType((*ContainerDiskUsage)(nil), "eliot.services.containers.v1.ContainerDiskUsage")
+	proto.RegisterType((*ResourceSummaryRequest)(nil), "eliot.services.containers.v1.ResourceSummaryRequest")
+	proto.RegisterType((*ResourceSummaryResponse)(nil), "eliot.services.containers.v1.ResourceSummaryResponse")
+	proto.RegisterType((*NamespaceSummary)(nil), "eliot.services.containers.v1.NamespaceSummary")
+	proto.RegisterType((*RebootRequest)(nil), "eliot.services.containers.v1.RebootRequest")
+	proto.RegisterType((*RebootResponse)(nil), "eliot.services.containers.v1.RebootResponse")
+	proto.RegisterType((*PoweroffRequest)(nil), "eliot.services.containers.v1.PoweroffRequest")
+	proto.RegisterType((*PoweroffResponse)(nil), "eliot.services.containers.v1.PoweroffResponse")
+	proto.RegisterType((*PauseReconcileRequest)(nil), "eliot.services.containers.v1.PauseReconcileRequest")
+	proto.RegisterType((*PauseReconcileResponse)(nil), "eliot.services.containers.v1.PauseReconcileResponse")
+	proto.RegisterType((*ResumeReconcileRequest)(nil), "eliot.services.containers.v1.ResumeReconcileRequest")
+	proto.RegisterType((*ResumeReconcileResponse)(nil), "eliot.services.containers.v1.ResumeReconcileResponse")
+	proto.RegisterType((*SetSnapshotterRequest)(nil), "eliot.services.containers.v1.SetSnapshotterRequest")
+	proto.RegisterType((*SetSnapshotterResponse)(nil), "eliot.services.containers.v1.SetSnapshotterResponse")
+	proto.RegisterType((*MigrateSnapshotterRequest)(nil), "eliot.services.containers.v1.MigrateSnapshotterRequest")
+	proto.RegisterType((*MigrateSnapshotterResponse)(nil), "eliot.services.containers.v1.MigrateSnapshotterResponse")
+	proto.RegisterType((*SnapshotterMigrationStep)(nil), "eliot.services.containers.v1.SnapshotterMigrationStep")
+	proto.RegisterType((*ReconcileHistoryRequest)(nil), "eliot.services.containers.v1.ReconcileHistoryRequest")
+	proto.RegisterType((*ReconcileHistoryResponse)(nil), "eliot.services.containers.v1.ReconcileHistoryResponse")
+	proto.RegisterType((*ReconcileRecord)(nil), "eliot.services.containers.v1.ReconcileRecord")
+	proto.RegisterType((*SetLabelsRequest)(nil), "eliot.services.containers.v1.SetLabelsRequest")
+	proto.RegisterType((*SetLabelsResponse)(nil), "eliot.services.containers.v1.SetLabelsResponse")
+	proto.RegisterType((*WatchLabelsRequest)(nil), "eliot.services.containers.v1.WatchLabelsRequest")
+	proto.RegisterType((*WatchLabelsResponse)(nil), "eliot.services.containers.v1.WatchLabelsResponse")
+	proto.RegisterType((*CapabilitiesRequest)(nil), "eliot.services.containers.v1.CapabilitiesRequest")
+	proto.RegisterType((*CapabilitiesResponse)(nil), "eliot.services.containers.v1.CapabilitiesResponse")
+	proto.RegisterType((*DescribeDeviceRequest)(nil), "eliot.services.containers.v1.DescribeDeviceRequest")
+	proto.RegisterType((*DescribeDeviceResponse)(nil), "eliot.services.containers.v1.DescribeDeviceResponse")
+	proto.RegisterType((*ExportStateRequest)(nil), "eliot.services.containers.v1.ExportStateRequest")
+	proto.RegisterType((*ExportStateResponse)(nil), "eliot.services.containers.v1.ExportStateResponse")
+	proto.RegisterType((*RuntimeInfo)(nil), "eliot.services.containers.v1.RuntimeInfo")
+	proto.RegisterType((*PluginStatus)(nil), "eliot.services.containers.v1.PluginStatus")
+	proto.RegisterType((*RuntimeEventsRequest)(nil), "eliot.services.containers.v1.RuntimeEventsRequest")
+	proto.RegisterType((*RuntimeEventsResponse)(nil), "eliot.services.containers.v1.RuntimeEventsResponse")
+	proto.RegisterType((*RuntimeEvent)(nil), "eliot.services.containers.v1.RuntimeEvent")
+	proto.RegisterType((*MaintenanceRequest)(nil), "eliot.services.containers.v1.MaintenanceRequest")
+	proto.RegisterType((*MaintenanceResponse)(nil), "eliot.services.containers.v1.MaintenanceResponse")
+	proto.RegisterType((*SetMaintenanceRequest)(nil), "eliot.services.containers.v1.SetMaintenanceRequest")
+	proto.RegisterType((*SetMaintenanceResponse)(nil), "eliot.services.containers.v1.SetMaintenanceResponse")
+	proto.RegisterType((*Maintenance)(nil), "eliot.services.containers.v1.Maintenance")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Node service
+
+type NodeClient interface {
+	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+	DiskUsage(ctx context.Context, in *DiskUsageRequest, opts ...grpc.CallOption) (*DiskUsageResponse, error)
+	ResourceSummary(ctx context.Context, in *ResourceSummaryRequest, opts ...grpc.CallOption) (*ResourceSummaryResponse, error)
+	Identity(ctx context.Context, in *IdentityRequest, opts ...grpc.CallOption) (*IdentityResponse, error)
+	SetLabels(ctx context.Context, in *SetLabelsRequest, opts ...grpc.CallOption) (*SetLabelsResponse, error)
+	WatchLabels(ctx context.Context, in *WatchLabelsRequest, opts ...grpc.CallOption) (Node_WatchLabelsClient, error)
+	Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error)
+	DescribeDevice(ctx context.Context, in *DescribeDeviceRequest, opts ...grpc.CallOption) (*DescribeDeviceResponse, error)
+	ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (*ExportStateResponse, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (Node_StatsClient, error)
+	Reboot(ctx context.Context, in *RebootRequest, opts ...grpc.CallOption) (*RebootResponse, error)
+	Poweroff(ctx context.Context, in *PoweroffRequest, opts ...grpc.CallOption) (*PoweroffResponse, error)
+	PauseReconcile(ctx context.Context, in *PauseReconcileRequest, opts ...grpc.CallOption) (*PauseReconcileResponse, error)
+	ResumeReconcile(ctx context.Context, in *ResumeReconcileRequest, opts ...grpc.CallOption) (*ResumeReconcileResponse, error)
+	ReconcileHistory(ctx context.Context, in *ReconcileHistoryRequest, opts ...grpc.CallOption) (*ReconcileHistoryResponse, error)
+	SetSnapshotter(ctx context.Context, in *SetSnapshotterRequest, opts ...grpc.CallOption) (*SetSnapshotterResponse, error)
+	MigrateSnapshotter(ctx context.Context, in *MigrateSnapshotterRequest, opts ...grpc.CallOption) (Node_MigrateSnapshotterClient, error)
+	RuntimeEvents(ctx context.Context, in *RuntimeEventsRequest, opts ...grpc.CallOption) (Node_RuntimeEventsClient, error)
+	Maintenance(ctx context.Context, in *MaintenanceRequest, opts ...grpc.CallOption) (*MaintenanceResponse, error)
+	SetMaintenance(ctx context.Context, in *SetMaintenanceRequest, opts ...grpc.CallOption) (*SetMaintenanceResponse, error)
+}
+
+type nodeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNodeClient(cc *grpc.ClientConn) NodeClient {
+	return &nodeClient{cc}
+}
+
+func (c *nodeClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	out := new(InfoResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/Info", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) DiskUsage(ctx context.Context, in *DiskUsageRequest, opts ...grpc.CallOption) (*DiskUsageResponse, error) {
+	out := new(DiskUsageResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/DiskUsage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) ResourceSummary(ctx context.Context, in *ResourceSummaryRequest, opts ...grpc.CallOption) (*ResourceSummaryResponse, error) {
+	out := new(ResourceSummaryResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/ResourceSummary", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) Identity(ctx context.Context, in *IdentityRequest, opts ...grpc.CallOption) (*IdentityResponse, error) {
+	out := new(IdentityResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/Identity", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) SetLabels(ctx context.Context, in *SetLabelsRequest, opts ...grpc.CallOption) (*SetLabelsResponse, error) {
+	out := new(SetLabelsResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/SetLabels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) WatchLabels(ctx context.Context, in *WatchLabelsRequest, opts ...grpc.CallOption) (Node_WatchLabelsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Node_serviceDesc.Streams[0], c.cc, "/eliot.services.containers.v1.Node/WatchLabels", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeWatchLabelsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Node_WatchLabelsClient interface {
+	Recv() (*WatchLabelsResponse, error)
+	grpc.ClientStream
+}
+
+type nodeWatchLabelsClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeWatchLabelsClient) Recv() (*WatchLabelsResponse, error) {
+	m := new(WatchLabelsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nodeClient) Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error) {
+	out := new(CapabilitiesResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/Capabilities", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) DescribeDevice(ctx context.Context, in *DescribeDeviceRequest, opts ...grpc.CallOption) (*DescribeDeviceResponse, error) {
+	out := new(DescribeDeviceResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/DescribeDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (*ExportStateResponse, error) {
+	out := new(ExportStateResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/ExportState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (Node_StatsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Node_serviceDesc.Streams[1], c.cc, "/eliot.services.containers.v1.Node/Stats", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeStatsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Node_StatsClient interface {
+	Recv() (*StatsResponse, error)
+	grpc.ClientStream
+}
+
+type nodeStatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeStatsClient) Recv() (*StatsResponse, error) {
+	m := new(StatsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nodeClient) Reboot(ctx context.Context, in *RebootRequest, opts ...grpc.CallOption) (*RebootResponse, error) {
+	out := new(RebootResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/Reboot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) Poweroff(ctx context.Context, in *PoweroffRequest, opts ...grpc.CallOption) (*PoweroffResponse, error) {
+	out := new(PoweroffResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/Poweroff", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) PauseReconcile(ctx context.Context, in *PauseReconcileRequest, opts ...grpc.CallOption) (*PauseReconcileResponse, error) {
+	out := new(PauseReconcileResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/PauseReconcile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) ResumeReconcile(ctx context.Context, in *ResumeReconcileRequest, opts ...grpc.CallOption) (*ResumeReconcileResponse, error) {
+	out := new(ResumeReconcileResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/ResumeReconcile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) ReconcileHistory(ctx context.Context, in *ReconcileHistoryRequest, opts ...grpc.CallOption) (*ReconcileHistoryResponse, error) {
+	out := new(ReconcileHistoryResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/ReconcileHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) SetSnapshotter(ctx context.Context, in *SetSnapshotterRequest, opts ...grpc.CallOption) (*SetSnapshotterResponse, error) {
+	out := new(SetSnapshotterResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/SetSnapshotter", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) MigrateSnapshotter(ctx context.Context, in *MigrateSnapshotterRequest, opts ...grpc.CallOption) (Node_MigrateSnapshotterClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Node_serviceDesc.Streams[2], c.cc, "/eliot.services.containers.v1.Node/MigrateSnapshotter", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeMigrateSnapshotterClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Node_MigrateSnapshotterClient interface {
+	Recv() (*MigrateSnapshotterResponse, error)
+	grpc.ClientStream
+}
+
+type nodeMigrateSnapshotterClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeMigrateSnapshotterClient) Recv() (*MigrateSnapshotterResponse, error) {
+	m := new(MigrateSnapshotterResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nodeClient) RuntimeEvents(ctx context.Context, in *RuntimeEventsRequest, opts ...grpc.CallOption) (Node_RuntimeEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Node_serviceDesc.Streams[3], c.cc, "/eliot.services.containers.v1.Node/RuntimeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeRuntimeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Node_RuntimeEventsClient interface {
+	Recv() (*RuntimeEventsResponse, error)
+	grpc.ClientStream
+}
+
+type nodeRuntimeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeRuntimeEventsClient) Recv() (*RuntimeEventsResponse, error) {
+	m := new(RuntimeEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nodeClient) Maintenance(ctx context.Context, in *MaintenanceRequest, opts ...grpc.CallOption) (*MaintenanceResponse, error) {
+	out := new(MaintenanceResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/Maintenance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) SetMaintenance(ctx context.Context, in *SetMaintenanceRequest, opts ...grpc.CallOption) (*SetMaintenanceResponse, error) {
+	out := new(SetMaintenanceResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.containers.v1.Node/SetMaintenance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Node service
+
+type NodeServer interface {
+	Info(context.Context, *InfoRequest) (*InfoResponse, error)
+	DiskUsage(context.Context, *DiskUsageRequest) (*DiskUsageResponse, error)
+	ResourceSummary(context.Context, *ResourceSummaryRequest) (*ResourceSummaryResponse, error)
+	Identity(context.Context, *IdentityRequest) (*IdentityResponse, error)
+	SetLabels(context.Context, *SetLabelsRequest) (*SetLabelsResponse, error)
+	WatchLabels(*WatchLabelsRequest, Node_WatchLabelsServer) error
+	Capabilities(context.Context, *CapabilitiesRequest) (*CapabilitiesResponse, error)
+	DescribeDevice(context.Context, *DescribeDeviceRequest) (*DescribeDeviceResponse, error)
+	ExportState(context.Context, *ExportStateRequest) (*ExportStateResponse, error)
+	Stats(*StatsRequest, Node_StatsServer) error
+	Reboot(context.Context, *RebootRequest) (*RebootResponse, error)
+	Poweroff(context.Context, *PoweroffRequest) (*PoweroffResponse, error)
+	PauseReconcile(context.Context, *PauseReconcileRequest) (*PauseReconcileResponse, error)
+	ResumeReconcile(context.Context, *ResumeReconcileRequest) (*ResumeReconcileResponse, error)
+	ReconcileHistory(context.Context, *ReconcileHistoryRequest) (*ReconcileHistoryResponse, error)
+	SetSnapshotter(context.Context, *SetSnapshotterRequest) (*SetSnapshotterResponse, error)
+	MigrateSnapshotter(*MigrateSnapshotterRequest, Node_MigrateSnapshotterServer) error
+	RuntimeEvents(*RuntimeEventsRequest, Node_RuntimeEventsServer) error
+	Maintenance(context.Context, *MaintenanceRequest) (*MaintenanceResponse, error)
+	SetMaintenance(context.Context, *SetMaintenanceRequest) (*SetMaintenanceResponse, error)
+}
+
+func RegisterNodeServer(s *grpc.Server, srv NodeServer) {
+	s.RegisterService(&_Node_serviceDesc, srv)
+}
+
+func _Node_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/Info",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Info(ctx, req.(*InfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_DiskUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiskUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).DiskUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/DiskUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).DiskUsage(ctx, req.(*DiskUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_ResourceSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResourceSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ResourceSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/ResourceSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ResourceSummary(ctx, req.(*ResourceSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_Identity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Identity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/Identity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Identity(ctx, req.(*IdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_SetLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).SetLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/SetLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).SetLabels(ctx, req.(*SetLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_WatchLabels_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLabelsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeServer).WatchLabels(m, &nodeWatchLabelsServer{stream})
+}
+
+type Node_WatchLabelsServer interface {
+	Send(*WatchLabelsResponse) error
+	grpc.ServerStream
+}
+
+type nodeWatchLabelsServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeWatchLabelsServer) Send(m *WatchLabelsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Node_Capabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Capabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/Capabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Capabilities(ctx, req.(*CapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_DescribeDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).DescribeDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/DescribeDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).DescribeDevice(ctx, req.(*DescribeDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_ExportState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ExportState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/ExportState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ExportState(ctx, req.(*ExportStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_Stats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeServer).Stats(m, &nodeStatsServer{stream})
+}
+
+type Node_StatsServer interface {
+	Send(*StatsResponse) error
+	grpc.ServerStream
+}
+
+type nodeStatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeStatsServer) Send(m *StatsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Node_Reboot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Reboot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/Reboot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Reboot(ctx, req.(*RebootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_Poweroff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoweroffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Poweroff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/Poweroff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Poweroff(ctx, req.(*PoweroffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_PauseReconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseReconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).PauseReconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/PauseReconcile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).PauseReconcile(ctx, req.(*PauseReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_ResumeReconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeReconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ResumeReconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/ResumeReconcile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ResumeReconcile(ctx, req.(*ResumeReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_ReconcileHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ReconcileHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/ReconcileHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ReconcileHistory(ctx, req.(*ReconcileHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_SetSnapshotter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSnapshotterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).SetSnapshotter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/SetSnapshotter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).SetSnapshotter(ctx, req.(*SetSnapshotterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_MigrateSnapshotter_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MigrateSnapshotterRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeServer).MigrateSnapshotter(m, &nodeMigrateSnapshotterServer{stream})
+}
+
+type Node_MigrateSnapshotterServer interface {
+	Send(*MigrateSnapshotterResponse) error
+	grpc.ServerStream
+}
+
+type nodeMigrateSnapshotterServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeMigrateSnapshotterServer) Send(m *MigrateSnapshotterResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Node_RuntimeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RuntimeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeServer).RuntimeEvents(m, &nodeRuntimeEventsServer{stream})
+}
+
+type Node_RuntimeEventsServer interface {
+	Send(*RuntimeEventsResponse) error
+	grpc.ServerStream
+}
+
+type nodeRuntimeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeRuntimeEventsServer) Send(m *RuntimeEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Node_Maintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Maintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/Maintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Maintenance(ctx, req.(*MaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_SetMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).SetMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.containers.v1.Node/SetMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).SetMaintenance(ctx, req.(*SetMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Node_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "eliot.services.containers.v1.Node",
+	HandlerType: (*NodeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
 			MethodName: "Info",
 			Handler:    _Node_Info_Handler,
 		},
+		{
+			MethodName: "DiskUsage",
+			Handler:    _Node_DiskUsage_Handler,
+		},
+		{
+			MethodName: "ResourceSummary",
+			Handler:    _Node_ResourceSummary_Handler,
+		},
+		{
+			MethodName: "Identity",
+			Handler:    _Node_Identity_Handler,
+		},
+		{
+			MethodName: "SetLabels",
+			Handler:    _Node_SetLabels_Handler,
+		},
+		{
+			MethodName: "Capabilities",
+			Handler:    _Node_Capabilities_Handler,
+		},
+		{
+			MethodName: "DescribeDevice",
+			Handler:    _Node_DescribeDevice_Handler,
+		},
+		{
+			MethodName: "ExportState",
+			Handler:    _Node_ExportState_Handler,
+		},
+		{
+			MethodName: "Reboot",
+			Handler:    _Node_Reboot_Handler,
+		},
+		{
+			MethodName: "Poweroff",
+			Handler:    _Node_Poweroff_Handler,
+		},
+		{
+			MethodName: "PauseReconcile",
+			Handler:    _Node_PauseReconcile_Handler,
+		},
+		{
+			MethodName: "ResumeReconcile",
+			Handler:    _Node_ResumeReconcile_Handler,
+		},
+		{
+			MethodName: "ReconcileHistory",
+			Handler:    _Node_ReconcileHistory_Handler,
+		},
+		{
+			MethodName: "SetSnapshotter",
+			Handler:    _Node_SetSnapshotter_Handler,
+		},
+		{
+			MethodName: "Maintenance",
+			Handler:    _Node_Maintenance_Handler,
+		},
+		{
+			MethodName: "SetMaintenance",
+			Handler:    _Node_SetMaintenance_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchLabels",
+			Handler:       _Node_WatchLabels_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Stats",
+			Handler:       _Node_Stats_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "MigrateSnapshotter",
+			Handler:       _Node_MigrateSnapshotter_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RuntimeEvents",
+			Handler:       _Node_RuntimeEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/node/v1/node.proto",
 }
 
 func init() { proto.RegisterFile("services/node/v1/node.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 489 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x41, 0x8b, 0xdb, 0x3c,
-	0x10, 0xc5, 0xb1, 0x93, 0xdd, 0x4c, 0xf6, 0xfb, 0x28, 0xa2, 0x14, 0xb1, 0x5d, 0x8a, 0x71, 0x2f,
-	0x6e, 0x0f, 0x36, 0x9b, 0x42, 0x4b, 0xd9, 0x5b, 0x09, 0x0b, 0x29, 0x65, 0x29, 0x82, 0xbd, 0x14,
-	0x7a, 0x50, 0x9c, 0x49, 0x22, 0xd6, 0x96, 0x5c, 0x49, 0x31, 0xe4, 0x67, 0xf4, 0x7f, 0xf4, 0x47,
-	0x16, 0xc9, 0x76, 0x1c, 0x7a, 0x08, 0x7b, 0xb2, 0xde, 0x9b, 0x79, 0x33, 0xe6, 0x3d, 0x09, 0x5e,
-	0x1b, 0xd4, 0x8d, 0x28, 0xd0, 0xe4, 0x52, 0xad, 0x31, 0x6f, 0x6e, 0xfd, 0x37, 0xab, 0xb5, 0xb2,
-	0x8a, 0xdc, 0x60, 0x29, 0x94, 0xcd, 0xfa, 0x96, 0xac, 0x50, 0xd2, 0x72, 0x21, 0x51, 0x9b, 0xac,
-	0xb9, 0x4d, 0xfe, 0x83, 0xd9, 0x52, 0x6e, 0x14, 0xc3, 0x5f, 0x7b, 0x34, 0x36, 0xb9, 0x87, 0xab,
-	0x16, 0x9a, 0x5a, 0x49, 0x83, 0xe4, 0x23, 0x44, 0x42, 0x6e, 0x14, 0x0d, 0xe2, 0x20, 0x9d, 0xcd,
-	0x93, 0xec, 0xdc, 0xac, 0xcc, 0x2b, 0x7d, 0x7f, 0xf2, 0x3b, 0x84, 0xc8, 0x41, 0x72, 0x07, 0x93,
-	0x92, 0xaf, 0xb0, 0x34, 0x34, 0x88, 0xc3, 0x74, 0x36, 0x7f, 0x7b, 0x7e, 0xc4, 0x37, 0xd7, 0xcb,
-	0x3a, 0x09, 0xb9, 0x86, 0xcb, 0x9d, 0x32, 0x56, 0xf2, 0x0a, 0xe9, 0x28, 0x0e, 0xd2, 0x29, 0x3b,
-	0x62, 0x72, 0x03, 0x53, 0xbe, 0x5e, 0x6b, 0x34, 0x06, 0x0d, 0x0d, 0xe3, 0x30, 0x9d, 0xb2, 0x81,
-	0x70, 0xca, 0xad, 0xae, 0x8b, 0xef, 0x4a, 0x5b, 0x1a, 0xc5, 0x41, 0x1a, 0xb2, 0x23, 0x76, 0xca,
-	0x8a, 0x17, 0x3b, 0x21, 0x71, 0xb9, 0xa0, 0x63, 0x3f, 0x76, 0x20, 0xc8, 0x1b, 0x00, 0x73, 0x30,
-	0x16, 0xab, 0xc7, 0xc7, 0xe5, 0x82, 0x4e, 0x7c, 0xf9, 0x84, 0x21, 0xaf, 0x60, 0xb2, 0x52, 0xca,
-	0x2e, 0x17, 0xf4, 0xc2, 0xd7, 0x3a, 0x44, 0x08, 0x44, 0x5c, 0x17, 0x3b, 0x7a, 0xe9, 0x59, 0x7f,
-	0x26, 0xff, 0xc3, 0x48, 0x19, 0x3a, 0xf5, 0xcc, 0x48, 0x19, 0x42, 0xe1, 0xa2, 0x41, 0x6d, 0x84,
-	0x92, 0x14, 0x3c, 0xd9, 0x43, 0xf2, 0x15, 0x66, 0x1b, 0x51, 0x62, 0xbb, 0xc7, 0xd0, 0x99, 0xf7,
-	0x2a, 0x3d, 0xef, 0xd5, 0xfd, 0x51, 0xc0, 0x4e, 0xc5, 0xee, 0x0f, 0xf7, 0xb5, 0x15, 0x15, 0xd2,
-	0xab, 0x38, 0x48, 0x23, 0xd6, 0xa1, 0x24, 0x87, 0xb1, 0xb7, 0x97, 0xbc, 0x80, 0xf0, 0x09, 0x0f,
-	0x3e, 0xd3, 0x29, 0x73, 0x47, 0xf2, 0x12, 0xc6, 0x0d, 0x2f, 0xf7, 0xbd, 0xcb, 0x2d, 0x48, 0xfe,
-	0x04, 0x00, 0xc3, 0x12, 0xe7, 0xcc, 0xb0, 0xa6, 0x53, 0x9f, 0x30, 0xce, 0x73, 0x7b, 0xa8, 0xf1,
-	0xe1, 0x24, 0xad, 0x1e, 0xbb, 0x5a, 0xa5, 0xf6, 0xd2, 0x2e, 0x84, 0xa6, 0x61, 0x5b, 0xeb, 0xb1,
-	0x5b, 0x6e, 0x95, 0xe5, 0xa5, 0x0f, 0x2a, 0x62, 0x2d, 0x70, 0x7e, 0x6e, 0x34, 0xa2, 0x0f, 0x28,
-	0x62, 0xfe, 0xec, 0x33, 0x6f, 0xb8, 0x28, 0xf9, 0xaa, 0x44, 0x1f, 0x4d, 0xc4, 0x06, 0x62, 0x8e,
-	0x10, 0x3d, 0xa8, 0x35, 0x92, 0x9f, 0xdd, 0xd5, 0x7b, 0xf7, 0x8c, 0xdb, 0xda, 0x5e, 0xfb, 0xeb,
-	0xf7, 0xcf, 0x69, 0x6d, 0x9f, 0xc4, 0x97, 0xcf, 0x3f, 0x3e, 0x6d, 0x85, 0xdd, 0xed, 0x57, 0x59,
-	0xa1, 0xaa, 0x1c, 0xb5, 0x54, 0x9c, 0xd7, 0x3c, 0xf7, 0x03, 0xf2, 0xfa, 0x69, 0x9b, 0xf3, 0x5a,
-	0xe4, 0xff, 0x3e, 0xc8, 0x3b, 0xf7, 0x5d, 0x4d, 0xfc, 0x8b, 0xfc, 0xf0, 0x37, 0x00, 0x00, 0xff,
-	0xff, 0x38, 0x85, 0x94, 0xff, 0xb0, 0x03, 0x00, 0x00,
+	// 2314 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3a, 0xcd, 0x6f, 0xdb, 0xc8,
+	0xf5, 0xa0, 0x3e, 0x6c, 0xf9, 0xc9, 0x4e, 0x6c, 0x26, 0x71, 0xb8, 0xda, 0xe0, 0x07, 0x83, 0xbf,
+	0x62, 0xeb, 0xf5, 0x66, 0xa5, 0x7c, 0xd8, 0xd9, 0x06, 0x8b, 0x76, 0x3f, 0xec, 0x4d, 0xd7, 0x69,
+	0xd7, 0x30, 0xe8, 0x3a, 0xfd, 0x00, 0xf6, 0x40, 0x53, 0x23, 0x79, 0x6a, 0x92, 0xc3, 0xce, 0x0c,
+	0xb5, 0xeb, 0x14, 0x68, 0xd1, 0x5b, 0x7b, 0x2e, 0xd0, 0x5b, 0x8f, 0xbd, 0x15, 0xbd, 0xb5, 0xe7,
+	0x5e, 0xfb, 0x27, 0xf4, 0x9f, 0x29, 0x8a, 0x19, 0xce, 0x90, 0x43, 0x4a, 0x91, 0xa8, 0x24, 0x3d,
+	0x89, 0xef, 0xcd, 0xfb, 0x98, 0x79, 0xf3, 0xbe, 0xe6, 0x41, 0xf0, 0x2e, 0x43, 0x74, 0x82, 0x03,
+	0xc4, 0x06, 0x31, 0x19, 0xa2, 0xc1, 0xe4, 0xa1, 0xfc, 0xed, 0x27, 0x94, 0x70, 0x62, 0xdf, 0x43,
+	0x21, 0x26, 0xbc, 0xaf, 0x49, 0xfa, 0x01, 0x89, 0xb9, 0x8f, 0x63, 0x44, 0x59, 0x7f, 0xf2, 0xb0,
+	0x57, 0xb0, 0x26, 0x64, 0xc8, 0x04, 0xab, 0xf8, 0xcd, 0x58, 0xdd, 0x0d, 0xe8, 0x1e, 0xc7, 0x23,
+	0xe2, 0xa1, 0x5f, 0xa5, 0x88, 0x71, 0xf7, 0x19, 0xac, 0x67, 0x20, 0x4b, 0x48, 0xcc, 0x90, 0xfd,
+	0x04, 0x5a, 0x38, 0x1e, 0x11, 0xc7, 0xda, 0xb1, 0x76, 0xbb, 0x8f, 0xdc, 0xfe, 0x3c, 0x45, 0x7d,
+	0xc9, 0x29, 0xe9, 0xdd, 0x7f, 0xb5, 0xa1, 0x25, 0x40, 0xfb, 0x63, 0x58, 0x09, 0xfd, 0x0b, 0x14,
+	0x32, 0xc7, 0xda, 0x69, 0xee, 0x76, 0x1f, 0xfd, 0xff, 0x7c, 0x11, 0x3f, 0x16, 0xb4, 0x9e, 0x62,
+	0xb1, 0x7b, 0xd0, 0xb9, 0x24, 0x8c, 0xc7, 0x7e, 0x84, 0x9c, 0xc6, 0x8e, 0xb5, 0xbb, 0xe6, 0xe5,
+	0xb0, 0x7d, 0x0f, 0xd6, 0xfc, 0xe1, 0x90, 0x22, 0xc6, 0x10, 0x73, 0x9a, 0x3b, 0xcd, 0xdd, 0x35,
+	0xaf, 0x40, 0x08, 0xce, 0x31, 0x4d, 0x82, 0x53, 0x42, 0xb9, 0xd3, 0xda, 0xb1, 0x76, 0x9b, 0x5e,
+	0x0e, 0x0b, 0xce, 0xc8, 0x0f, 0x2e, 0x71, 0x8c, 0x8e, 0x8f, 0x9c, 0xb6, 0x14, 0x5b, 0x20, 0xec,
+	0xff, 0x03, 0x60, 0xd7, 0x8c, 0xa3, 0xe8, 0xfc, 0xfc, 0xf8, 0xc8, 0x59, 0x91, 0xcb, 0x06, 0xc6,
+	0xde, 0x86, 0x95, 0x0b, 0x42, 0xf8, 0xf1, 0x91, 0xb3, 0x2a, 0xd7, 0x14, 0x64, 0xdb, 0xd0, 0xf2,
+	0x69, 0x70, 0xe9, 0x74, 0x24, 0x56, 0x7e, 0xdb, 0x37, 0xa0, 0x41, 0x98, 0xb3, 0x26, 0x31, 0x0d,
+	0xc2, 0x6c, 0x07, 0x56, 0x27, 0x88, 0x32, 0x4c, 0x62, 0x07, 0x24, 0x52, 0x83, 0xf6, 0x73, 0xe8,
+	0x8e, 0x70, 0x88, 0x32, 0x3d, 0xcc, 0xe9, 0x4a, 0x5b, 0xed, 0xce, 0xb7, 0xd5, 0xb3, 0x9c, 0xc1,
+	0x33, 0x99, 0xc5, 0x0e, 0xd3, 0x84, 0xe3, 0x08, 0x39, 0xeb, 0x3b, 0xd6, 0x6e, 0xcb, 0x53, 0x90,
+	0xbd, 0x07, 0x9b, 0x11, 0x8e, 0x0f, 0x43, 0x8c, 0x62, 0xfe, 0x42, 0x6d, 0x63, 0x43, 0x6e, 0x63,
+	0x0a, 0x2f, 0xae, 0x6d, 0xe4, 0xa7, 0x21, 0x67, 0xce, 0x8d, 0x3a, 0xd7, 0xf6, 0x4c, 0xd0, 0x7a,
+	0x8a, 0x45, 0x98, 0x42, 0xaa, 0xbf, 0x29, 0x0d, 0x2f, 0xbf, 0xed, 0xfb, 0xb0, 0x15, 0x84, 0x24,
+	0xb8, 0x3a, 0xbb, 0x8e, 0x83, 0x4b, 0x4a, 0x62, 0xfc, 0x12, 0x0d, 0x9d, 0xcd, 0x1d, 0x6b, 0xb7,
+	0xe3, 0x4d, 0x2f, 0x08, 0xf5, 0x63, 0x4a, 0xd2, 0x84, 0x39, 0x5b, 0x4b, 0x78, 0x4d, 0xc6, 0x62,
+	0x9f, 0x00, 0xe0, 0x98, 0x23, 0x3a, 0xf2, 0x03, 0xc4, 0x1c, 0x5b, 0x0a, 0xe8, 0xcf, 0x17, 0x70,
+	0x82, 0xf8, 0x37, 0x84, 0x5e, 0x1d, 0x6b, 0x36, 0xcf, 0x90, 0xe0, 0xbe, 0x80, 0xcd, 0xea, 0xba,
+	0x38, 0xa2, 0xf4, 0x4a, 0x2b, 0xbb, 0x6d, 0xf1, 0x6d, 0x6f, 0x42, 0x33, 0xf2, 0x03, 0xe5, 0xa8,
+	0xe2, 0x73, 0xbe, 0x8f, 0xba, 0x07, 0xd0, 0x96, 0x76, 0xb3, 0x6f, 0x43, 0x7b, 0x84, 0x51, 0x38,
+	0x54, 0xd2, 0x32, 0x40, 0x5c, 0x23, 0x45, 0x3e, 0x23, 0xb1, 0x92, 0xa8, 0x20, 0x77, 0x0f, 0xd6,
+	0xcf, 0xb8, 0xcf, 0x99, 0x0a, 0x59, 0xe1, 0xea, 0x72, 0xb3, 0x13, 0x3f, 0x94, 0x02, 0x9a, 0x5e,
+	0x0e, 0xbb, 0xcf, 0x61, 0x43, 0xd1, 0xaa, 0x78, 0x7e, 0x0a, 0x6d, 0x26, 0x10, 0x2a, 0xa0, 0x17,
+	0xd8, 0x35, 0xe3, 0xcd, 0x38, 0xdc, 0x3f, 0x36, 0xa0, 0x2d, 0x11, 0x62, 0xbf, 0x21, 0xf1, 0x87,
+	0x0f, 0xa5, 0x10, 0xcb, 0xcb, 0x00, 0x8d, 0x3d, 0x70, 0x1a, 0x05, 0xf6, 0x40, 0x9c, 0x42, 0x2e,
+	0x1f, 0x38, 0x4d, 0x89, 0x56, 0x90, 0xbd, 0x03, 0xdd, 0x08, 0x45, 0x84, 0x5e, 0xff, 0x84, 0x70,
+	0x3f, 0x94, 0x31, 0xda, 0xf2, 0x4c, 0x94, 0x08, 0xc4, 0x0c, 0x7c, 0x46, 0x11, 0x92, 0x71, 0xda,
+	0xf2, 0x0c, 0x8c, 0x90, 0xc0, 0x51, 0x94, 0x20, 0xea, 0xf3, 0x94, 0x22, 0x19, 0xa9, 0x4d, 0xcf,
+	0x44, 0x55, 0x83, 0x6a, 0xf5, 0xed, 0x04, 0x55, 0xc7, 0x0c, 0x2a, 0x77, 0x0b, 0x6e, 0x1e, 0x0f,
+	0x51, 0xcc, 0x31, 0xbf, 0xd6, 0x39, 0xf4, 0x05, 0x6c, 0x16, 0x28, 0x65, 0xf7, 0xcf, 0xa1, 0x83,
+	0x15, 0x4e, 0x99, 0xfe, 0xbd, 0x05, 0xb9, 0x54, 0x4b, 0xc8, 0xf9, 0xdc, 0x3f, 0x5b, 0xd0, 0xd1,
+	0xe8, 0x72, 0x12, 0xb3, 0xe6, 0x27, 0xb1, 0xc6, 0x9c, 0x24, 0xd6, 0x2c, 0x25, 0xb1, 0x22, 0x5b,
+	0xb7, 0x96, 0xce, 0xd6, 0xee, 0x00, 0xda, 0x12, 0x21, 0x02, 0xe1, 0x0a, 0x5d, 0xab, 0x5d, 0x89,
+	0x4f, 0xe1, 0x1b, 0x13, 0x3f, 0x4c, 0x75, 0x16, 0xcf, 0x00, 0xf7, 0xaf, 0x16, 0x40, 0x61, 0x6f,
+	0xb1, 0xe9, 0xc2, 0xe2, 0x8a, 0xdb, 0xc0, 0x08, 0x47, 0xe7, 0xd7, 0x09, 0x3a, 0x31, 0xaa, 0x81,
+	0x86, 0xc5, 0x5a, 0x44, 0xd2, 0x98, 0x1f, 0x61, 0xaa, 0x8e, 0x94, 0xc3, 0x42, 0x39, 0x37, 0x9c,
+	0x2c, 0x03, 0x44, 0x04, 0x8f, 0x0a, 0xc7, 0x92, 0xdf, 0x32, 0x5e, 0x27, 0x3e, 0x0e, 0xfd, 0x8b,
+	0x30, 0x73, 0xa8, 0x96, 0x57, 0x20, 0xdc, 0x07, 0xb0, 0x79, 0x84, 0xd9, 0xd5, 0x39, 0xf3, 0xc7,
+	0x48, 0x07, 0xdf, 0x3d, 0x58, 0x13, 0xb1, 0xcf, 0x12, 0x3f, 0xd0, 0xc9, 0xa0, 0x40, 0xb8, 0x1e,
+	0x6c, 0x19, 0x1c, 0xca, 0x15, 0xbe, 0x0f, 0xed, 0x54, 0x20, 0x94, 0x1f, 0x7c, 0x77, 0xbe, 0x89,
+	0x0b, 0xfe, 0x8c, 0xcb, 0xfd, 0x2d, 0xac, 0xe5, 0x38, 0x11, 0x03, 0x82, 0x1c, 0xc5, 0xfc, 0x0c,
+	0xbf, 0x44, 0x2a, 0xfc, 0x4d, 0x94, 0x7d, 0x0a, 0x50, 0x08, 0x74, 0x1a, 0xf2, 0x56, 0x1f, 0xcc,
+	0x57, 0x79, 0xa8, 0xa1, 0x42, 0xb7, 0x21, 0xc3, 0xfd, 0xbd, 0x05, 0xf6, 0x34, 0x89, 0xde, 0x8a,
+	0xc4, 0xe6, 0x2e, 0x69, 0xa2, 0xf2, 0x9c, 0xd9, 0x28, 0xe7, 0xcc, 0x84, 0x0c, 0xd5, 0x95, 0x89,
+	0x4f, 0x41, 0xc5, 0xc4, 0x59, 0xb2, 0xaa, 0x2d, 0xbf, 0x85, 0xbb, 0x62, 0xd1, 0xee, 0x30, 0x79,
+	0x5b, 0x4d, 0x4f, 0x41, 0xae, 0x03, 0xdb, 0x1e, 0x62, 0x24, 0xa5, 0x01, 0x3a, 0x4b, 0xa3, 0xc8,
+	0xa7, 0x79, 0x0c, 0x62, 0xb8, 0x3b, 0xb5, 0xa2, 0xec, 0x7f, 0x02, 0x90, 0xdf, 0x90, 0xee, 0x4a,
+	0x16, 0x95, 0x07, 0x4d, 0xaf, 0x65, 0x19, 0x12, 0xdc, 0xff, 0x58, 0xb0, 0x59, 0x25, 0x98, 0xef,
+	0x17, 0xc2, 0xd3, 0x4b, 0x97, 0x62, 0xed, 0xb6, 0x4d, 0x13, 0x8b, 0x62, 0x49, 0xd3, 0x38, 0xc6,
+	0xf1, 0xf8, 0xb0, 0x20, 0x6b, 0x4a, 0xb2, 0xe9, 0x05, 0xe1, 0xfb, 0x41, 0x92, 0xca, 0x5b, 0x50,
+	0x2e, 0x9e, 0xc3, 0x45, 0x9a, 0xcd, 0x96, 0xdb, 0x66, 0x9a, 0xcd, 0x28, 0xee, 0xc1, 0x1a, 0x8e,
+	0xfc, 0x31, 0x92, 0x0e, 0x94, 0x25, 0xd1, 0x02, 0x61, 0xbb, 0xb0, 0xce, 0x62, 0x3f, 0x61, 0x97,
+	0x24, 0xf3, 0xb0, 0x55, 0x49, 0x50, 0xc2, 0xb9, 0x9f, 0xc0, 0x86, 0x87, 0x44, 0x02, 0xd1, 0x41,
+	0xd1, 0x07, 0x7b, 0x4c, 0xfd, 0x00, 0x9d, 0x22, 0x8a, 0xc9, 0xf0, 0x0c, 0x05, 0x24, 0x1e, 0x32,
+	0xe5, 0x9c, 0x33, 0x56, 0xdc, 0x1f, 0xc0, 0x0d, 0x2d, 0x40, 0xdd, 0xd1, 0x7d, 0xd8, 0x62, 0x9c,
+	0x24, 0x09, 0x1a, 0x1a, 0x06, 0xb0, 0x32, 0x03, 0x4c, 0x2d, 0xb8, 0x9f, 0xc1, 0xcd, 0x53, 0xf2,
+	0x0d, 0xa2, 0x64, 0x34, 0x7a, 0xdd, 0x2d, 0x7c, 0x0a, 0x9b, 0x85, 0x88, 0xd7, 0xda, 0xc4, 0x27,
+	0x70, 0xe7, 0xd4, 0x4f, 0x19, 0xf2, 0x84, 0xc4, 0x00, 0x87, 0x79, 0x8a, 0x78, 0x0f, 0x6e, 0x88,
+	0x4a, 0x41, 0x52, 0x5e, 0xde, 0x46, 0x05, 0xeb, 0xee, 0xc3, 0x76, 0x55, 0x80, 0xda, 0x48, 0x0f,
+	0x3a, 0x14, 0xb1, 0x34, 0x42, 0x9f, 0x71, 0x5d, 0xe1, 0x35, 0xac, 0x42, 0x20, 0x8d, 0xa6, 0xf4,
+	0xba, 0xef, 0xc0, 0xdd, 0xa9, 0x95, 0x4c, 0xa0, 0xfb, 0x14, 0xee, 0x9c, 0x21, 0x7e, 0xa6, 0x2e,
+	0x91, 0x23, 0xaa, 0xf7, 0xba, 0x03, 0x5d, 0x56, 0x60, 0x75, 0x10, 0x1b, 0x28, 0xf7, 0x6b, 0xd8,
+	0xae, 0xb2, 0xaa, 0x5d, 0x1e, 0xc2, 0x2a, 0x4d, 0x63, 0x59, 0x22, 0xb3, 0xcc, 0xf6, 0xfe, 0xfc,
+	0xa0, 0xf2, 0x32, 0x62, 0xf9, 0x68, 0xd0, 0x9c, 0x6e, 0x04, 0xef, 0x7c, 0x85, 0xc7, 0xd4, 0xe7,
+	0xe8, 0x75, 0x76, 0x27, 0xae, 0x9d, 0xa2, 0x80, 0x22, 0x9f, 0xa3, 0xc3, 0x72, 0x80, 0x75, 0xbc,
+	0x19, 0x2b, 0xee, 0x25, 0xf4, 0x66, 0xa9, 0x53, 0x27, 0x7a, 0x0e, 0x2d, 0xc6, 0x51, 0xa2, 0x8e,
+	0xf3, 0x64, 0x41, 0xaf, 0x54, 0x08, 0xc8, 0x44, 0x62, 0x12, 0x9f, 0x71, 0x94, 0x78, 0x52, 0x86,
+	0xfb, 0x4f, 0x0b, 0x9c, 0x57, 0x91, 0x2c, 0xc8, 0x16, 0x36, 0xb4, 0xae, 0x70, 0x3c, 0xd4, 0x79,
+	0x53, 0x7c, 0xe7, 0xb9, 0xb4, 0x69, 0xe4, 0x52, 0x07, 0x56, 0x83, 0x94, 0x52, 0x14, 0x67, 0x4f,
+	0x9e, 0xb6, 0xa7, 0xc1, 0xa2, 0x02, 0xb6, 0x25, 0x3e, 0x03, 0x04, 0x3d, 0xbb, 0xc2, 0xc2, 0x8d,
+	0x65, 0xdc, 0x77, 0x3c, 0x0d, 0x0a, 0x7a, 0x44, 0x29, 0xa1, 0xea, 0x89, 0x93, 0x01, 0x99, 0x43,
+	0x29, 0x57, 0xfa, 0x12, 0x33, 0x4e, 0x8a, 0x74, 0x1b, 0x80, 0x33, 0xbd, 0xa4, 0xac, 0xf8, 0x43,
+	0x58, 0xa5, 0x28, 0x20, 0x74, 0xa8, 0x93, 0xed, 0x87, 0x0b, 0xfc, 0xa2, 0x70, 0x57, 0xc1, 0xe5,
+	0x69, 0x6e, 0xf7, 0x2f, 0x16, 0xdc, 0xac, 0x2c, 0xe6, 0x4f, 0x0d, 0xcb, 0x78, 0x6a, 0x94, 0xac,
+	0xd9, 0xa8, 0x5a, 0x73, 0xba, 0xe2, 0x54, 0x2a, 0x57, 0x6b, 0xba, 0x72, 0x6d, 0xc3, 0x8a, 0x1f,
+	0x88, 0xdb, 0x52, 0xcf, 0x45, 0x05, 0x15, 0x76, 0x5a, 0x31, 0xed, 0x34, 0x86, 0xcd, 0x33, 0xc4,
+	0x65, 0x2b, 0x94, 0x37, 0xe9, 0x6f, 0xf4, 0x0c, 0x96, 0x2f, 0x81, 0x88, 0x4c, 0x90, 0xac, 0xdf,
+	0x6b, 0x9e, 0x82, 0xdc, 0x53, 0xd8, 0x32, 0x14, 0x29, 0x73, 0xbf, 0x89, 0x26, 0xf7, 0x36, 0xd8,
+	0x3f, 0xf5, 0x79, 0x70, 0x59, 0xda, 0xbc, 0xeb, 0xc1, 0xad, 0x12, 0xf6, 0x6d, 0x68, 0xba, 0x03,
+	0xb7, 0x0e, 0xfd, 0xc4, 0xbf, 0xc0, 0x21, 0xe6, 0x18, 0xe5, 0xaa, 0xfe, 0x61, 0xc1, 0xed, 0x32,
+	0x5e, 0x29, 0x33, 0x9e, 0xce, 0x56, 0xf9, 0xe9, 0x3c, 0xeb, 0x59, 0xdb, 0x78, 0xc5, 0xb3, 0xd6,
+	0x81, 0xd5, 0x08, 0xf1, 0x4b, 0x32, 0xd4, 0xcf, 0x31, 0x0d, 0x8a, 0x1c, 0x3b, 0x42, 0xf2, 0xd9,
+	0x90, 0xf5, 0xbe, 0x6b, 0x5e, 0x0e, 0x9b, 0x45, 0x90, 0x8b, 0x7c, 0xd2, 0x96, 0xeb, 0x25, 0x9c,
+	0x7b, 0x17, 0xee, 0x1c, 0x21, 0x16, 0x50, 0x7c, 0x81, 0x8e, 0x90, 0x38, 0xbe, 0x3e, 0xd1, 0x9f,
+	0x1a, 0xb0, 0x5d, 0x5d, 0x79, 0xb3, 0xe1, 0x8a, 0x99, 0x69, 0x1b, 0xaf, 0x9b, 0x69, 0x2b, 0x6d,
+	0x50, 0xf3, 0x4d, 0xdb, 0x20, 0x7b, 0x00, 0x2d, 0x31, 0x56, 0x52, 0x0f, 0x87, 0x77, 0xab, 0x92,
+	0xc4, 0x9a, 0x90, 0x71, 0x4a, 0x86, 0x9e, 0x24, 0x74, 0x7f, 0x06, 0xf6, 0x17, 0xdf, 0x26, 0x84,
+	0x72, 0xf1, 0xa8, 0xac, 0xd7, 0x50, 0xdb, 0xdf, 0x81, 0x0d, 0x3f, 0x0c, 0x4f, 0x8a, 0x7d, 0x67,
+	0xa9, 0xbd, 0x8c, 0x74, 0x9f, 0xc1, 0xad, 0x92, 0x64, 0x65, 0x6e, 0xbd, 0x43, 0xab, 0xee, 0x0e,
+	0xff, 0xdd, 0x80, 0xae, 0x61, 0x3b, 0x39, 0xc3, 0xd0, 0x06, 0x19, 0xbe, 0x28, 0x79, 0xe3, 0xf4,
+	0x82, 0xa8, 0x45, 0x05, 0xd2, 0x43, 0x13, 0x6c, 0x78, 0xe6, 0x8c, 0x95, 0x6a, 0x75, 0x6b, 0x4e,
+	0x57, 0xb7, 0xaa, 0x1f, 0xb6, 0xa6, 0xfd, 0x50, 0xf6, 0x0a, 0xd9, 0x96, 0xb5, 0x9f, 0xe6, 0xb0,
+	0x7d, 0x04, 0xab, 0x49, 0x98, 0x8e, 0x71, 0xcc, 0x9c, 0x15, 0x69, 0x83, 0xbd, 0xf9, 0xf7, 0x7d,
+	0x2a, 0x89, 0x85, 0x11, 0x53, 0xe6, 0x69, 0xd6, 0x4a, 0xf3, 0x9a, 0x35, 0x84, 0x06, 0x46, 0xdc,
+	0x51, 0xe4, 0x7f, 0x6b, 0x94, 0xdf, 0x8e, 0x24, 0x29, 0x23, 0xdd, 0x2f, 0x61, 0xdd, 0x14, 0x2f,
+	0x13, 0xf9, 0x75, 0x92, 0x0f, 0x54, 0xc4, 0xb7, 0x18, 0x9f, 0x61, 0x5d, 0xf6, 0x1a, 0xd8, 0x28,
+	0x4b, 0x4d, 0x33, 0xdd, 0x72, 0xb8, 0xad, 0x2e, 0xe9, 0x8b, 0x09, 0x8a, 0x39, 0x7b, 0x8b, 0x9e,
+	0x24, 0xf2, 0xc5, 0x08, 0x87, 0x3c, 0x6b, 0xbf, 0x65, 0xbe, 0x50, 0xa0, 0xfb, 0x73, 0xb8, 0x53,
+	0xd1, 0xaa, 0xbc, 0xec, 0x53, 0x68, 0x23, 0x81, 0x51, 0x51, 0xbd, 0x57, 0x2b, 0x34, 0xa5, 0x0c,
+	0x2f, 0x63, 0x74, 0xff, 0x66, 0xc1, 0xba, 0x89, 0x17, 0x27, 0x11, 0x00, 0xe3, 0x7e, 0x94, 0xa8,
+	0x4a, 0x57, 0x20, 0x16, 0x94, 0x3b, 0x59, 0xfa, 0x13, 0x1c, 0x68, 0x9b, 0x49, 0x40, 0x96, 0xfe,
+	0xf4, 0xe2, 0x97, 0x28, 0xe0, 0xaa, 0xdc, 0x69, 0x50, 0xac, 0x08, 0xdb, 0x9f, 0xd3, 0x50, 0xd5,
+	0x3a, 0x0d, 0x8a, 0x95, 0xc4, 0xbf, 0x16, 0xe3, 0x1b, 0x59, 0xee, 0xd6, 0x3d, 0x0d, 0x8a, 0xaa,
+	0xf1, 0x95, 0x8f, 0x63, 0x8e, 0x62, 0x3f, 0x2e, 0x12, 0xdf, 0x05, 0xdc, 0x2a, 0x61, 0x95, 0x7d,
+	0x7e, 0x04, 0xdd, 0xa8, 0x40, 0xd7, 0x6b, 0x15, 0x4d, 0x39, 0x26, 0xb7, 0x7b, 0x2c, 0x1b, 0xd9,
+	0x69, 0xe5, 0x62, 0xb3, 0x28, 0x16, 0xaf, 0xf6, 0x6c, 0xa8, 0xd6, 0xf1, 0x34, 0xf8, 0xca, 0xb1,
+	0x1a, 0x82, 0xed, 0xaa, 0xa8, 0xff, 0xc5, 0x8e, 0xcf, 0xa1, 0x6b, 0xac, 0x2d, 0xbf, 0x4f, 0x71,
+	0xa1, 0x0c, 0x8b, 0x7d, 0x34, 0xa5, 0x23, 0x64, 0xc0, 0xa3, 0xbf, 0xdb, 0xd0, 0x3a, 0x21, 0x43,
+	0x64, 0x7f, 0xad, 0xe6, 0xee, 0xef, 0xd7, 0xa8, 0x26, 0x99, 0xad, 0x7a, 0x7b, 0x75, 0x48, 0x95,
+	0x2d, 0x42, 0x73, 0xfa, 0xd0, 0xaf, 0x3b, 0xba, 0x50, 0x8a, 0x06, 0xb5, 0xe9, 0x95, 0xb6, 0xdf,
+	0xc0, 0xcd, 0xca, 0x2b, 0xde, 0xde, 0x5f, 0xd4, 0x3c, 0xce, 0x1a, 0x07, 0xf4, 0x0e, 0x96, 0xe4,
+	0x52, 0xfa, 0xb1, 0x31, 0x70, 0xfb, 0xb0, 0xe6, 0xbc, 0x4e, 0x69, 0xec, 0xd7, 0x25, 0x2f, 0x0c,
+	0x9b, 0xf7, 0x72, 0x8b, 0x0c, 0x5b, 0xed, 0x2e, 0x7b, 0x83, 0xda, 0xf4, 0x4a, 0x1b, 0x87, 0xae,
+	0xd1, 0xd1, 0xd9, 0x0b, 0x06, 0x42, 0xd3, 0x2d, 0x61, 0xef, 0xe1, 0x12, 0x1c, 0x99, 0xce, 0x07,
+	0x96, 0x9d, 0xc2, 0xba, 0xd9, 0xdb, 0xd9, 0x0b, 0x84, 0xcc, 0xe8, 0x0f, 0x7b, 0x8f, 0x96, 0x61,
+	0x51, 0x87, 0xfd, 0x35, 0xdc, 0x28, 0x37, 0x60, 0xf6, 0xe3, 0x05, 0x8e, 0x38, 0xab, 0x91, 0xeb,
+	0xed, 0x2f, 0xc7, 0xa4, 0x94, 0x53, 0xe8, 0x1a, 0xbd, 0xc8, 0x22, 0x4b, 0x4f, 0x37, 0x44, 0xbd,
+	0x87, 0x4b, 0x70, 0x28, 0x9d, 0x17, 0x7a, 0x50, 0xbf, 0x57, 0x67, 0xbc, 0xaf, 0xf4, 0x7c, 0x50,
+	0x8b, 0x36, 0xbf, 0xcb, 0x00, 0x56, 0xb2, 0x99, 0x8d, 0xfd, 0xc1, 0xa2, 0xd8, 0x32, 0x46, 0x43,
+	0xbd, 0xfb, 0xf5, 0x88, 0x8b, 0xf8, 0xd3, 0x53, 0x99, 0x45, 0xf1, 0x57, 0x19, 0x00, 0xf5, 0xfa,
+	0x75, 0xc9, 0x0b, 0x27, 0x29, 0x4f, 0x5f, 0x16, 0x39, 0xc9, 0xcc, 0x61, 0x4f, 0x6f, 0x7f, 0x39,
+	0xa6, 0x52, 0x9e, 0x33, 0x47, 0x35, 0x35, 0xf2, 0xdc, 0x8c, 0x99, 0x4f, 0xef, 0x60, 0x49, 0x2e,
+	0xa5, 0xff, 0x77, 0x16, 0x6c, 0x56, 0xdf, 0xef, 0xf6, 0x41, 0xcd, 0x67, 0x7a, 0x79, 0x14, 0xd0,
+	0x7b, 0xb2, 0x2c, 0x5b, 0x71, 0x01, 0xe5, 0xc1, 0xd2, 0xa2, 0x0b, 0x98, 0x39, 0xc1, 0xea, 0xed,
+	0x2f, 0xc7, 0xa4, 0x94, 0xff, 0xc1, 0x02, 0x7b, 0x7a, 0x10, 0x64, 0x7f, 0xb4, 0xa0, 0xc8, 0xbf,
+	0x6a, 0x52, 0xd5, 0xfb, 0xde, 0xf2, 0x8c, 0x79, 0x64, 0xbd, 0x84, 0x8d, 0x52, 0x67, 0x69, 0x3f,
+	0xaa, 0xdf, 0x42, 0xe6, 0xd1, 0xfc, 0x78, 0x29, 0x9e, 0x5c, 0x37, 0x2d, 0x77, 0x27, 0x0f, 0xea,
+	0x37, 0x39, 0xf5, 0xb2, 0xd5, 0xac, 0xf6, 0x2a, 0xbb, 0x78, 0x53, 0xed, 0xe2, 0x8b, 0x9f, 0xa1,
+	0x79, 0x7f, 0x39, 0xa6, 0x4c, 0xf9, 0xe7, 0x4f, 0x7f, 0xf1, 0xd1, 0x18, 0xf3, 0xcb, 0xf4, 0xa2,
+	0x1f, 0x90, 0x68, 0x80, 0x68, 0x4c, 0x7c, 0x3f, 0xf1, 0x07, 0x52, 0xd4, 0x20, 0xb9, 0x1a, 0x0f,
+	0xfc, 0x04, 0x0f, 0xaa, 0x7f, 0xbd, 0xf8, 0x58, 0xfc, 0x5e, 0xac, 0xc8, 0x3f, 0x50, 0x3c, 0xfe,
+	0xef, 0x00, 0x70, 0xfc, 0xf9, 0xf3, 0x9a, 0x21, 0x00, 0x00,
 }
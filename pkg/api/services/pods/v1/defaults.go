@@ -15,9 +15,7 @@ func Defaults(pods []*Pod) (result []*Pod) {
 
 // Default set default values to Pod model
 func Default(pod *Pod) *Pod {
-	if pod.Metadata.Namespace == "" {
-		pod.Metadata.Namespace = model.DefaultNamespace
-	}
+	pod.Metadata.Namespace = model.ResolveNamespace(pod.Metadata.Namespace)
 
 	pod.Spec.Containers = containers.Defaults(pod.Spec.Containers)
 	return pod
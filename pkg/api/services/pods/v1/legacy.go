@@ -0,0 +1,16 @@
+package pods
+
+import (
+	"google.golang.org/grpc"
+)
+
+// LegacyServiceName is the name what the Pods service had before the proto package was renamed
+// from cand to eliot, the clients built before the rename still call the methods with it
+const LegacyServiceName = "cand.services.pods.v1.Pods"
+
+// RegisterLegacyPodsServer registers the Pods server also with the legacy service name
+func RegisterLegacyPodsServer(s *grpc.Server, srv PodsServer) {
+	desc := _Pods_serviceDesc
+	desc.ServiceName = LegacyServiceName
+	s.RegisterService(&desc, srv)
+}
@@ -9,14 +9,27 @@ It is generated from these files:
 	services/pods/v1/pods.proto
 
 It has these top-level messages:
+	ValidateManifestRequest
+	ValidateManifestResponse
+	ValidationError
 	CreatePodRequest
 	CreatePodStreamResponse
+	CreatePhase
 	ImageFetch
+	PullStats
 	ImageLayerStatus
+	RunPodRequest
+	RunPodResponse
+	RunResult
+	ApplyPodsRequest
+	ApplyPodsResponse
+	ApplyAction
 	StartPodRequest
 	StartPodResponse
 	DeletePodRequest
 	DeletePodResponse
+	DeleteNamespaceRequest
+	DeleteNamespaceResponse
 	ListPodsRequest
 	ListPodsResponse
 	Pod
@@ -28,8 +41,8 @@ package pods
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
-import cand_core "github.com/ernoaapa/eliot/pkg/api/core"
-import cand_services_containers_v1 "github.com/ernoaapa/eliot/pkg/api/services/containers/v1"
+import eliot_core "github.com/ernoaapa/eliot/pkg/api/core"
+import eliot_services_containers_v1 "github.com/ernoaapa/eliot/pkg/api/services/containers/v1"
 
 import (
 	context "golang.org/x/net/context"
@@ -47,15 +60,85 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type ValidateManifestRequest struct {
+	Pods []*Pod `protobuf:"bytes,1,rep,name=pods" json:"pods,omitempty"`
+}
+
+func (m *ValidateManifestRequest) Reset()                    { *m = ValidateManifestRequest{} }
+func (m *ValidateManifestRequest) String() string            { return proto.CompactTextString(m) }
+func (*ValidateManifestRequest) ProtoMessage()               {}
+func (*ValidateManifestRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *ValidateManifestRequest) GetPods() []*Pod {
+	if m != nil {
+		return m.Pods
+	}
+	return nil
+}
+
+type ValidateManifestResponse struct {
+	// Empty if the pods are valid
+	Errors []*ValidationError `protobuf:"bytes,1,rep,name=errors" json:"errors,omitempty"`
+}
+
+func (m *ValidateManifestResponse) Reset()                    { *m = ValidateManifestResponse{} }
+func (m *ValidateManifestResponse) String() string            { return proto.CompactTextString(m) }
+func (*ValidateManifestResponse) ProtoMessage()               {}
+func (*ValidateManifestResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *ValidateManifestResponse) GetErrors() []*ValidationError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type ValidationError struct {
+	// Name of the pod what the error is about
+	Pod string `protobuf:"bytes,1,opt,name=pod" json:"pod,omitempty"`
+	// Path of the invalid field, e.g. Spec.Containers[0].Image
+	Field   string `protobuf:"bytes,2,opt,name=field" json:"field,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *ValidationError) Reset()                    { *m = ValidationError{} }
+func (m *ValidationError) String() string            { return proto.CompactTextString(m) }
+func (*ValidationError) ProtoMessage()               {}
+func (*ValidationError) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *ValidationError) GetPod() string {
+	if m != nil {
+		return m.Pod
+	}
+	return ""
+}
+
+func (m *ValidationError) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *ValidationError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
 type CreatePodRequest struct {
 	Pod *Pod `protobuf:"bytes,1,opt,name=pod" json:"pod,omitempty"`
 	Tty bool `protobuf:"varint,2,opt,name=tty" json:"tty,omitempty"`
+	// Start the containers after creating them, if any container fails to get created or started
+	// all the created containers get removed so that no created but never started containers are left behind
+	Start bool `protobuf:"varint,3,opt,name=start" json:"start,omitempty"`
 }
 
 func (m *CreatePodRequest) Reset()                    { *m = CreatePodRequest{} }
 func (m *CreatePodRequest) String() string            { return proto.CompactTextString(m) }
 func (*CreatePodRequest) ProtoMessage()               {}
-func (*CreatePodRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+func (*CreatePodRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 func (m *CreatePodRequest) GetPod() *Pod {
 	if m != nil {
@@ -71,14 +154,25 @@ func (m *CreatePodRequest) GetTty() bool {
 	return false
 }
 
+func (m *CreatePodRequest) GetStart() bool {
+	if m != nil {
+		return m.Start
+	}
+	return false
+}
+
 type CreatePodStreamResponse struct {
 	Images []*ImageFetch `protobuf:"bytes,1,rep,name=images" json:"images,omitempty"`
+	// Statuses of the created or started containers, sent in the last message
+	ContainerStatuses []*eliot_services_containers_v1.ContainerStatus `protobuf:"bytes,2,rep,name=containerStatuses" json:"containerStatuses,omitempty"`
+	// Container phase changes after the previous message
+	Phases []*CreatePhase `protobuf:"bytes,3,rep,name=phases" json:"phases,omitempty"`
 }
 
 func (m *CreatePodStreamResponse) Reset()                    { *m = CreatePodStreamResponse{} }
 func (m *CreatePodStreamResponse) String() string            { return proto.CompactTextString(m) }
 func (*CreatePodStreamResponse) ProtoMessage()               {}
-func (*CreatePodStreamResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (*CreatePodStreamResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *CreatePodStreamResponse) GetImages() []*ImageFetch {
 	if m != nil {
@@ -87,18 +181,78 @@ func (m *CreatePodStreamResponse) GetImages() []*ImageFetch {
 	return nil
 }
 
+func (m *CreatePodStreamResponse) GetContainerStatuses() []*eliot_services_containers_v1.ContainerStatus {
+	if m != nil {
+		return m.ContainerStatuses
+	}
+	return nil
+}
+
+func (m *CreatePodStreamResponse) GetPhases() []*CreatePhase {
+	if m != nil {
+		return m.Phases
+	}
+	return nil
+}
+
+type CreatePhase struct {
+	// Container name
+	Container string `protobuf:"bytes,1,opt,name=container" json:"container,omitempty"`
+	// One of resolving, pulling, unpacking, creating, creating-task, starting, running or failed
+	Phase string `protobuf:"bytes,2,opt,name=phase" json:"phase,omitempty"`
+	// Error message if the phase is failed
+	Error string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	// Unix timestamp in milliseconds when the phase started
+	Time int64 `protobuf:"varint,4,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *CreatePhase) Reset()                    { *m = CreatePhase{} }
+func (m *CreatePhase) String() string            { return proto.CompactTextString(m) }
+func (*CreatePhase) ProtoMessage()               {}
+func (*CreatePhase) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *CreatePhase) GetContainer() string {
+	if m != nil {
+		return m.Container
+	}
+	return ""
+}
+
+func (m *CreatePhase) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
+}
+
+func (m *CreatePhase) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CreatePhase) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
 type ImageFetch struct {
 	ContainerID string              `protobuf:"bytes,1,opt,name=containerID" json:"containerID,omitempty"`
 	Image       string              `protobuf:"bytes,2,opt,name=image" json:"image,omitempty"`
 	Resolved    bool                `protobuf:"varint,3,opt,name=resolved" json:"resolved,omitempty"`
 	Failed      bool                `protobuf:"varint,4,opt,name=failed" json:"failed,omitempty"`
 	Layers      []*ImageLayerStatus `protobuf:"bytes,5,rep,name=layers" json:"layers,omitempty"`
+	// Set when the pull is completed
+	Stats *PullStats `protobuf:"bytes,6,opt,name=stats" json:"stats,omitempty"`
 }
 
 func (m *ImageFetch) Reset()                    { *m = ImageFetch{} }
 func (m *ImageFetch) String() string            { return proto.CompactTextString(m) }
 func (*ImageFetch) ProtoMessage()               {}
-func (*ImageFetch) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*ImageFetch) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *ImageFetch) GetContainerID() string {
 	if m != nil {
@@ -135,6 +289,64 @@ func (m *ImageFetch) GetLayers() []*ImageLayerStatus {
 	return nil
 }
 
+func (m *ImageFetch) GetStats() *PullStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type PullStats struct {
+	// True if all the image content was already on the node
+	Cached bool `protobuf:"varint,1,opt,name=cached" json:"cached,omitempty"`
+	// Pull and unpack duration in milliseconds
+	Duration int64 `protobuf:"varint,2,opt,name=duration" json:"duration,omitempty"`
+	// Bytes fetched from the registry
+	BytesFetched  int64 `protobuf:"varint,3,opt,name=bytesFetched" json:"bytesFetched,omitempty"`
+	Layers        int32 `protobuf:"varint,4,opt,name=layers" json:"layers,omitempty"`
+	FetchedLayers int32 `protobuf:"varint,5,opt,name=fetchedLayers" json:"fetchedLayers,omitempty"`
+}
+
+func (m *PullStats) Reset()                    { *m = PullStats{} }
+func (m *PullStats) String() string            { return proto.CompactTextString(m) }
+func (*PullStats) ProtoMessage()               {}
+func (*PullStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *PullStats) GetCached() bool {
+	if m != nil {
+		return m.Cached
+	}
+	return false
+}
+
+func (m *PullStats) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *PullStats) GetBytesFetched() int64 {
+	if m != nil {
+		return m.BytesFetched
+	}
+	return 0
+}
+
+func (m *PullStats) GetLayers() int32 {
+	if m != nil {
+		return m.Layers
+	}
+	return 0
+}
+
+func (m *PullStats) GetFetchedLayers() int32 {
+	if m != nil {
+		return m.FetchedLayers
+	}
+	return 0
+}
+
 type ImageLayerStatus struct {
 	Ref    string `protobuf:"bytes,1,opt,name=ref" json:"ref,omitempty"`
 	Digest string `protobuf:"bytes,2,opt,name=digest" json:"digest,omitempty"`
@@ -146,7 +358,7 @@ type ImageLayerStatus struct {
 func (m *ImageLayerStatus) Reset()                    { *m = ImageLayerStatus{} }
 func (m *ImageLayerStatus) String() string            { return proto.CompactTextString(m) }
 func (*ImageLayerStatus) ProtoMessage()               {}
-func (*ImageLayerStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*ImageLayerStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *ImageLayerStatus) GetRef() string {
 	if m != nil {
@@ -183,6 +395,223 @@ func (m *ImageLayerStatus) GetTotal() int64 {
 	return 0
 }
 
+type RunPodRequest struct {
+	Pod *Pod `protobuf:"bytes,1,opt,name=pod" json:"pod,omitempty"`
+	// How long the containers can run before they get killed, zero means no timeout
+	TimeoutSeconds int64 `protobuf:"varint,2,opt,name=timeoutSeconds" json:"timeoutSeconds,omitempty"`
+}
+
+func (m *RunPodRequest) Reset()                    { *m = RunPodRequest{} }
+func (m *RunPodRequest) String() string            { return proto.CompactTextString(m) }
+func (*RunPodRequest) ProtoMessage()               {}
+func (*RunPodRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *RunPodRequest) GetPod() *Pod {
+	if m != nil {
+		return m.Pod
+	}
+	return nil
+}
+
+func (m *RunPodRequest) GetTimeoutSeconds() int64 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+type RunPodResponse struct {
+	Results []*RunResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *RunPodResponse) Reset()                    { *m = RunPodResponse{} }
+func (m *RunPodResponse) String() string            { return proto.CompactTextString(m) }
+func (*RunPodResponse) ProtoMessage()               {}
+func (*RunPodResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *RunPodResponse) GetResults() []*RunResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type RunResult struct {
+	// Container name
+	Name        string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	ContainerID string `protobuf:"bytes,2,opt,name=containerID" json:"containerID,omitempty"`
+	ExitCode    int32  `protobuf:"varint,3,opt,name=exitCode" json:"exitCode,omitempty"`
+	// One of Completed, Error or OOMKilled
+	ExitReason string `protobuf:"bytes,4,opt,name=exitReason" json:"exitReason,omitempty"`
+	// True if the container got killed because it didn't exit within the timeout
+	TimedOut bool   `protobuf:"varint,5,opt,name=timedOut" json:"timedOut,omitempty"`
+	Stdout   []byte `protobuf:"bytes,6,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr   []byte `protobuf:"bytes,7,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	// True if only the end of the output is included
+	Truncated bool `protobuf:"varint,8,opt,name=truncated" json:"truncated,omitempty"`
+	// Unix timestamps in seconds
+	StartedAt  int64 `protobuf:"varint,9,opt,name=startedAt" json:"startedAt,omitempty"`
+	FinishedAt int64 `protobuf:"varint,10,opt,name=finishedAt" json:"finishedAt,omitempty"`
+}
+
+func (m *RunResult) Reset()                    { *m = RunResult{} }
+func (m *RunResult) String() string            { return proto.CompactTextString(m) }
+func (*RunResult) ProtoMessage()               {}
+func (*RunResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *RunResult) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RunResult) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+func (m *RunResult) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *RunResult) GetExitReason() string {
+	if m != nil {
+		return m.ExitReason
+	}
+	return ""
+}
+
+func (m *RunResult) GetTimedOut() bool {
+	if m != nil {
+		return m.TimedOut
+	}
+	return false
+}
+
+func (m *RunResult) GetStdout() []byte {
+	if m != nil {
+		return m.Stdout
+	}
+	return nil
+}
+
+func (m *RunResult) GetStderr() []byte {
+	if m != nil {
+		return m.Stderr
+	}
+	return nil
+}
+
+func (m *RunResult) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+func (m *RunResult) GetStartedAt() int64 {
+	if m != nil {
+		return m.StartedAt
+	}
+	return 0
+}
+
+func (m *RunResult) GetFinishedAt() int64 {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return 0
+}
+
+type ApplyPodsRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	// The complete desired set of pods in the namespace
+	Pods []*Pod `protobuf:"bytes,2,rep,name=pods" json:"pods,omitempty"`
+}
+
+func (m *ApplyPodsRequest) Reset()                    { *m = ApplyPodsRequest{} }
+func (m *ApplyPodsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ApplyPodsRequest) ProtoMessage()               {}
+func (*ApplyPodsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *ApplyPodsRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ApplyPodsRequest) GetPods() []*Pod {
+	if m != nil {
+		return m.Pods
+	}
+	return nil
+}
+
+type ApplyPodsResponse struct {
+	Actions []*ApplyAction `protobuf:"bytes,1,rep,name=actions" json:"actions,omitempty"`
+}
+
+func (m *ApplyPodsResponse) Reset()                    { *m = ApplyPodsResponse{} }
+func (m *ApplyPodsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ApplyPodsResponse) ProtoMessage()               {}
+func (*ApplyPodsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *ApplyPodsResponse) GetActions() []*ApplyAction {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+type ApplyAction struct {
+	Pod         string `protobuf:"bytes,1,opt,name=pod" json:"pod,omitempty"`
+	ContainerID string `protobuf:"bytes,2,opt,name=containerID" json:"containerID,omitempty"`
+	// One of created, updated, removed, rolled-back or failed
+	Action string `protobuf:"bytes,3,opt,name=action" json:"action,omitempty"`
+	// The error message if the action failed
+	Error string `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *ApplyAction) Reset()                    { *m = ApplyAction{} }
+func (m *ApplyAction) String() string            { return proto.CompactTextString(m) }
+func (*ApplyAction) ProtoMessage()               {}
+func (*ApplyAction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *ApplyAction) GetPod() string {
+	if m != nil {
+		return m.Pod
+	}
+	return ""
+}
+
+func (m *ApplyAction) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+func (m *ApplyAction) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *ApplyAction) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type StartPodRequest struct {
 	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
 	Name      string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
@@ -191,7 +620,7 @@ type StartPodRequest struct {
 func (m *StartPodRequest) Reset()                    { *m = StartPodRequest{} }
 func (m *StartPodRequest) String() string            { return proto.CompactTextString(m) }
 func (*StartPodRequest) ProtoMessage()               {}
-func (*StartPodRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*StartPodRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *StartPodRequest) GetNamespace() string {
 	if m != nil {
@@ -214,7 +643,7 @@ type StartPodResponse struct {
 func (m *StartPodResponse) Reset()                    { *m = StartPodResponse{} }
 func (m *StartPodResponse) String() string            { return proto.CompactTextString(m) }
 func (*StartPodResponse) ProtoMessage()               {}
-func (*StartPodResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*StartPodResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *StartPodResponse) GetPod() *Pod {
 	if m != nil {
@@ -231,7 +660,7 @@ type DeletePodRequest struct {
 func (m *DeletePodRequest) Reset()                    { *m = DeletePodRequest{} }
 func (m *DeletePodRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeletePodRequest) ProtoMessage()               {}
-func (*DeletePodRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*DeletePodRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *DeletePodRequest) GetNamespace() string {
 	if m != nil {
@@ -254,7 +683,7 @@ type DeletePodResponse struct {
 func (m *DeletePodResponse) Reset()                    { *m = DeletePodResponse{} }
 func (m *DeletePodResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeletePodResponse) ProtoMessage()               {}
-func (*DeletePodResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*DeletePodResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *DeletePodResponse) GetPod() *Pod {
 	if m != nil {
@@ -263,14 +692,58 @@ func (m *DeletePodResponse) GetPod() *Pod {
 	return nil
 }
 
+type DeleteNamespaceRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	// Remove also the images and the namespace itself
+	RemoveNamespace bool `protobuf:"varint,2,opt,name=removeNamespace" json:"removeNamespace,omitempty"`
+}
+
+func (m *DeleteNamespaceRequest) Reset()                    { *m = DeleteNamespaceRequest{} }
+func (m *DeleteNamespaceRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteNamespaceRequest) ProtoMessage()               {}
+func (*DeleteNamespaceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *DeleteNamespaceRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *DeleteNamespaceRequest) GetRemoveNamespace() bool {
+	if m != nil {
+		return m.RemoveNamespace
+	}
+	return false
+}
+
+type DeleteNamespaceResponse struct {
+	// Statuses of the deleted containers
+	ContainerStatuses []*eliot_services_containers_v1.ContainerStatus `protobuf:"bytes,1,rep,name=containerStatuses" json:"containerStatuses,omitempty"`
+}
+
+func (m *DeleteNamespaceResponse) Reset()                    { *m = DeleteNamespaceResponse{} }
+func (m *DeleteNamespaceResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteNamespaceResponse) ProtoMessage()               {}
+func (*DeleteNamespaceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *DeleteNamespaceResponse) GetContainerStatuses() []*eliot_services_containers_v1.ContainerStatus {
+	if m != nil {
+		return m.ContainerStatuses
+	}
+	return nil
+}
+
 type ListPodsRequest struct {
 	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	// List pods from all namespaces, namespace is ignored if true
+	AllNamespaces bool `protobuf:"varint,2,opt,name=allNamespaces" json:"allNamespaces,omitempty"`
 }
 
 func (m *ListPodsRequest) Reset()                    { *m = ListPodsRequest{} }
 func (m *ListPodsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListPodsRequest) ProtoMessage()               {}
-func (*ListPodsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*ListPodsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *ListPodsRequest) GetNamespace() string {
 	if m != nil {
@@ -279,6 +752,13 @@ func (m *ListPodsRequest) GetNamespace() string {
 	return ""
 }
 
+func (m *ListPodsRequest) GetAllNamespaces() bool {
+	if m != nil {
+		return m.AllNamespaces
+	}
+	return false
+}
+
 type ListPodsResponse struct {
 	Pods []*Pod `protobuf:"bytes,1,rep,name=pods" json:"pods,omitempty"`
 }
@@ -286,7 +766,7 @@ type ListPodsResponse struct {
 func (m *ListPodsResponse) Reset()                    { *m = ListPodsResponse{} }
 func (m *ListPodsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListPodsResponse) ProtoMessage()               {}
-func (*ListPodsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*ListPodsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *ListPodsResponse) GetPods() []*Pod {
 	if m != nil {
@@ -296,17 +776,17 @@ func (m *ListPodsResponse) GetPods() []*Pod {
 }
 
 type Pod struct {
-	Metadata *cand_core.ResourceMetadata `protobuf:"bytes,1,opt,name=metadata" json:"metadata,omitempty"`
-	Spec     *PodSpec                    `protobuf:"bytes,2,opt,name=spec" json:"spec,omitempty"`
-	Status   *PodStatus                  `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
+	Metadata *eliot_core.ResourceMetadata `protobuf:"bytes,1,opt,name=metadata" json:"metadata,omitempty"`
+	Spec     *PodSpec                     `protobuf:"bytes,2,opt,name=spec" json:"spec,omitempty"`
+	Status   *PodStatus                   `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
 }
 
 func (m *Pod) Reset()                    { *m = Pod{} }
 func (m *Pod) String() string            { return proto.CompactTextString(m) }
 func (*Pod) ProtoMessage()               {}
-func (*Pod) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*Pod) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
-func (m *Pod) GetMetadata() *cand_core.ResourceMetadata {
+func (m *Pod) GetMetadata() *eliot_core.ResourceMetadata {
 	if m != nil {
 		return m.Metadata
 	}
@@ -328,18 +808,29 @@ func (m *Pod) GetStatus() *PodStatus {
 }
 
 type PodSpec struct {
-	Containers    []*cand_services_containers_v1.Container `protobuf:"bytes,1,rep,name=containers" json:"containers,omitempty"`
-	HostNetwork   bool                                     `protobuf:"varint,2,opt,name=hostNetwork" json:"hostNetwork,omitempty"`
-	HostPID       bool                                     `protobuf:"varint,3,opt,name=hostPID" json:"hostPID,omitempty"`
-	RestartPolicy string                                   `protobuf:"bytes,4,opt,name=restartPolicy" json:"restartPolicy,omitempty"`
+	Containers    []*eliot_services_containers_v1.Container `protobuf:"bytes,1,rep,name=containers" json:"containers,omitempty"`
+	HostNetwork   bool                                      `protobuf:"varint,2,opt,name=hostNetwork" json:"hostNetwork,omitempty"`
+	HostPID       bool                                      `protobuf:"varint,3,opt,name=hostPID" json:"hostPID,omitempty"`
+	RestartPolicy string                                    `protobuf:"bytes,4,opt,name=restartPolicy" json:"restartPolicy,omitempty"`
+	// Seconds the containers have time to exit after SIGTERM before all get killed, zero means default
+	StopGracePeriodSeconds int64 `protobuf:"varint,5,opt,name=stopGracePeriodSeconds" json:"stopGracePeriodSeconds,omitempty"`
+	// Limits for all pod containers in total, enforced with shared cgroup parent
+	Resources *eliot_services_containers_v1.Resources `protobuf:"bytes,6,opt,name=resources" json:"resources,omitempty"`
+	// Names of the pull secrets used for pulling the pod images
+	ImagePullSecrets []string `protobuf:"bytes,7,rep,name=imagePullSecrets" json:"imagePullSecrets,omitempty"`
+	// Log level injected to all pod containers as environment variable, e.g. debug
+	// Value "node" uses the eliotd log level
+	LogLevel string `protobuf:"bytes,8,opt,name=logLevel" json:"logLevel,omitempty"`
+	// Environment variable name for the log level, defaults to LOG_LEVEL
+	LogLevelEnv string `protobuf:"bytes,9,opt,name=logLevelEnv" json:"logLevelEnv,omitempty"`
 }
 
 func (m *PodSpec) Reset()                    { *m = PodSpec{} }
 func (m *PodSpec) String() string            { return proto.CompactTextString(m) }
 func (*PodSpec) ProtoMessage()               {}
-func (*PodSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*PodSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
-func (m *PodSpec) GetContainers() []*cand_services_containers_v1.Container {
+func (m *PodSpec) GetContainers() []*eliot_services_containers_v1.Container {
 	if m != nil {
 		return m.Containers
 	}
@@ -367,17 +858,52 @@ func (m *PodSpec) GetRestartPolicy() string {
 	return ""
 }
 
+func (m *PodSpec) GetStopGracePeriodSeconds() int64 {
+	if m != nil {
+		return m.StopGracePeriodSeconds
+	}
+	return 0
+}
+
+func (m *PodSpec) GetResources() *eliot_services_containers_v1.Resources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *PodSpec) GetImagePullSecrets() []string {
+	if m != nil {
+		return m.ImagePullSecrets
+	}
+	return nil
+}
+
+func (m *PodSpec) GetLogLevel() string {
+	if m != nil {
+		return m.LogLevel
+	}
+	return ""
+}
+
+func (m *PodSpec) GetLogLevelEnv() string {
+	if m != nil {
+		return m.LogLevelEnv
+	}
+	return ""
+}
+
 type PodStatus struct {
-	ContainerStatuses []*cand_services_containers_v1.ContainerStatus `protobuf:"bytes,1,rep,name=containerStatuses" json:"containerStatuses,omitempty"`
-	Hostname          string                                         `protobuf:"bytes,2,opt,name=hostname" json:"hostname,omitempty"`
+	ContainerStatuses []*eliot_services_containers_v1.ContainerStatus `protobuf:"bytes,1,rep,name=containerStatuses" json:"containerStatuses,omitempty"`
+	Hostname          string                                          `protobuf:"bytes,2,opt,name=hostname" json:"hostname,omitempty"`
 }
 
 func (m *PodStatus) Reset()                    { *m = PodStatus{} }
 func (m *PodStatus) String() string            { return proto.CompactTextString(m) }
 func (*PodStatus) ProtoMessage()               {}
-func (*PodStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*PodStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
-func (m *PodStatus) GetContainerStatuses() []*cand_services_containers_v1.ContainerStatus {
+func (m *PodStatus) GetContainerStatuses() []*eliot_services_containers_v1.ContainerStatus {
 	if m != nil {
 		return m.ContainerStatuses
 	}
@@ -392,19 +918,32 @@ func (m *PodStatus) GetHostname() string {
 }
 
 func init() {
-	proto.RegisterType((*CreatePodRequest)(nil), "cand.services.pods.v1.CreatePodRequest")
-	proto.RegisterType((*CreatePodStreamResponse)(nil), "cand.services.pods.v1.CreatePodStreamResponse")
-	proto.RegisterType((*ImageFetch)(nil), "cand.services.pods.v1.ImageFetch")
-	proto.RegisterType((*ImageLayerStatus)(nil), "cand.services.pods.v1.ImageLayerStatus")
-	proto.RegisterType((*StartPodRequest)(nil), "cand.services.pods.v1.StartPodRequest")
-	proto.RegisterType((*StartPodResponse)(nil), "cand.services.pods.v1.StartPodResponse")
-	proto.RegisterType((*DeletePodRequest)(nil), "cand.services.pods.v1.DeletePodRequest")
-	proto.RegisterType((*DeletePodResponse)(nil), "cand.services.pods.v1.DeletePodResponse")
-	proto.RegisterType((*ListPodsRequest)(nil), "cand.services.pods.v1.ListPodsRequest")
-	proto.RegisterType((*ListPodsResponse)(nil), "cand.services.pods.v1.ListPodsResponse")
-	proto.RegisterType((*Pod)(nil), "cand.services.pods.v1.Pod")
-	proto.RegisterType((*PodSpec)(nil), "cand.services.pods.v1.PodSpec")
-	proto.RegisterType((*PodStatus)(nil), "cand.services.pods.v1.PodStatus")
+	proto.RegisterType((*ValidateManifestRequest)(nil), "eliot.services.pods.v1.ValidateManifestRequest")
+	proto.RegisterType((*ValidateManifestResponse)(nil), "eliot.services.pods.v1.ValidateManifestResponse")
+	proto.RegisterType((*ValidationError)(nil), "eliot.services.pods.v1.ValidationError")
+	proto.RegisterType((*CreatePodRequest)(nil), "eliot.services.pods.v1.CreatePodRequest")
+	proto.RegisterType((*CreatePodStreamResponse)(nil), "eliot.services.pods.v1.CreatePodStreamResponse")
+	proto.RegisterType((*CreatePhase)(nil), "eliot.services.pods.v1.CreatePhase")
+	proto.RegisterType((*ImageFetch)(nil), "eliot.services.pods.v1.ImageFetch")
+	proto.RegisterType((*PullStats)(nil), "eliot.services.pods.v1.PullStats")
+	proto.RegisterType((*ImageLayerStatus)(nil), "eliot.services.pods.v1.ImageLayerStatus")
+	proto.RegisterType((*RunPodRequest)(nil), "eliot.services.pods.v1.RunPodRequest")
+	proto.RegisterType((*RunPodResponse)(nil), "eliot.services.pods.v1.RunPodResponse")
+	proto.RegisterType((*RunResult)(nil), "eliot.services.pods.v1.RunResult")
+	proto.RegisterType((*ApplyPodsRequest)(nil), "eliot.services.pods.v1.ApplyPodsRequest")
+	proto.RegisterType((*ApplyPodsResponse)(nil), "eliot.services.pods.v1.ApplyPodsResponse")
+	proto.RegisterType((*ApplyAction)(nil), "eliot.services.pods.v1.ApplyAction")
+	proto.RegisterType((*StartPodRequest)(nil), "eliot.services.pods.v1.StartPodRequest")
+	proto.RegisterType((*StartPodResponse)(nil), "eliot.services.pods.v1.StartPodResponse")
+	proto.RegisterType((*DeletePodRequest)(nil), "eliot.services.pods.v1.DeletePodRequest")
+	proto.RegisterType((*DeletePodResponse)(nil), "eliot.services.pods.v1.DeletePodResponse")
+	proto.RegisterType((*DeleteNamespaceRequest)(nil), "eliot.services.pods.v1.DeleteNamespaceRequest")
+	proto.RegisterType((*DeleteNamespaceResponse)(nil), "eliot.services.pods.v1.DeleteNamespaceResponse")
+	proto.RegisterType((*ListPodsRequest)(nil), "eliot.services.pods.v1.ListPodsRequest")
+	proto.RegisterType((*ListPodsResponse)(nil), "eliot.services.pods.v1.ListPodsResponse")
+	proto.RegisterType((*Pod)(nil), "eliot.services.pods.v1.Pod")
+	proto.RegisterType((*PodSpec)(nil), "eliot.services.pods.v1.PodSpec")
+	proto.RegisterType((*PodStatus)(nil), "eliot.services.pods.v1.PodStatus")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -421,7 +960,11 @@ type PodsClient interface {
 	Create(ctx context.Context, in *CreatePodRequest, opts ...grpc.CallOption) (Pods_CreateClient, error)
 	Start(ctx context.Context, in *StartPodRequest, opts ...grpc.CallOption) (*StartPodResponse, error)
 	Delete(ctx context.Context, in *DeletePodRequest, opts ...grpc.CallOption) (*DeletePodResponse, error)
+	DeleteNamespace(ctx context.Context, in *DeleteNamespaceRequest, opts ...grpc.CallOption) (*DeleteNamespaceResponse, error)
 	List(ctx context.Context, in *ListPodsRequest, opts ...grpc.CallOption) (*ListPodsResponse, error)
+	ValidateManifest(ctx context.Context, in *ValidateManifestRequest, opts ...grpc.CallOption) (*ValidateManifestResponse, error)
+	Run(ctx context.Context, in *RunPodRequest, opts ...grpc.CallOption) (*RunPodResponse, error)
+	Apply(ctx context.Context, in *ApplyPodsRequest, opts ...grpc.CallOption) (*ApplyPodsResponse, error)
 }
 
 type podsClient struct {
@@ -433,7 +976,7 @@ func NewPodsClient(cc *grpc.ClientConn) PodsClient {
 }
 
 func (c *podsClient) Create(ctx context.Context, in *CreatePodRequest, opts ...grpc.CallOption) (Pods_CreateClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Pods_serviceDesc.Streams[0], c.cc, "/cand.services.pods.v1.Pods/Create", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Pods_serviceDesc.Streams[0], c.cc, "/eliot.services.pods.v1.Pods/Create", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -466,7 +1009,7 @@ func (x *podsCreateClient) Recv() (*CreatePodStreamResponse, error) {
 
 func (c *podsClient) Start(ctx context.Context, in *StartPodRequest, opts ...grpc.CallOption) (*StartPodResponse, error) {
 	out := new(StartPodResponse)
-	err := grpc.Invoke(ctx, "/cand.services.pods.v1.Pods/Start", in, out, c.cc, opts...)
+	err := grpc.Invoke(ctx, "/eliot.services.pods.v1.Pods/Start", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -475,7 +1018,16 @@ func (c *podsClient) Start(ctx context.Context, in *StartPodRequest, opts ...grp
 
 func (c *podsClient) Delete(ctx context.Context, in *DeletePodRequest, opts ...grpc.CallOption) (*DeletePodResponse, error) {
 	out := new(DeletePodResponse)
-	err := grpc.Invoke(ctx, "/cand.services.pods.v1.Pods/Delete", in, out, c.cc, opts...)
+	err := grpc.Invoke(ctx, "/eliot.services.pods.v1.Pods/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podsClient) DeleteNamespace(ctx context.Context, in *DeleteNamespaceRequest, opts ...grpc.CallOption) (*DeleteNamespaceResponse, error) {
+	out := new(DeleteNamespaceResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.pods.v1.Pods/DeleteNamespace", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -484,7 +1036,34 @@ func (c *podsClient) Delete(ctx context.Context, in *DeletePodRequest, opts ...g
 
 func (c *podsClient) List(ctx context.Context, in *ListPodsRequest, opts ...grpc.CallOption) (*ListPodsResponse, error) {
 	out := new(ListPodsResponse)
-	err := grpc.Invoke(ctx, "/cand.services.pods.v1.Pods/List", in, out, c.cc, opts...)
+	err := grpc.Invoke(ctx, "/eliot.services.pods.v1.Pods/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podsClient) ValidateManifest(ctx context.Context, in *ValidateManifestRequest, opts ...grpc.CallOption) (*ValidateManifestResponse, error) {
+	out := new(ValidateManifestResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.pods.v1.Pods/ValidateManifest", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podsClient) Run(ctx context.Context, in *RunPodRequest, opts ...grpc.CallOption) (*RunPodResponse, error) {
+	out := new(RunPodResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.pods.v1.Pods/Run", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podsClient) Apply(ctx context.Context, in *ApplyPodsRequest, opts ...grpc.CallOption) (*ApplyPodsResponse, error) {
+	out := new(ApplyPodsResponse)
+	err := grpc.Invoke(ctx, "/eliot.services.pods.v1.Pods/Apply", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -497,7 +1076,11 @@ type PodsServer interface {
 	Create(*CreatePodRequest, Pods_CreateServer) error
 	Start(context.Context, *StartPodRequest) (*StartPodResponse, error)
 	Delete(context.Context, *DeletePodRequest) (*DeletePodResponse, error)
+	DeleteNamespace(context.Context, *DeleteNamespaceRequest) (*DeleteNamespaceResponse, error)
 	List(context.Context, *ListPodsRequest) (*ListPodsResponse, error)
+	ValidateManifest(context.Context, *ValidateManifestRequest) (*ValidateManifestResponse, error)
+	Run(context.Context, *RunPodRequest) (*RunPodResponse, error)
+	Apply(context.Context, *ApplyPodsRequest) (*ApplyPodsResponse, error)
 }
 
 func RegisterPodsServer(s *grpc.Server, srv PodsServer) {
@@ -535,7 +1118,7 @@ func _Pods_Start_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cand.services.pods.v1.Pods/Start",
+		FullMethod: "/eliot.services.pods.v1.Pods/Start",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(PodsServer).Start(ctx, req.(*StartPodRequest))
@@ -553,7 +1136,7 @@ func _Pods_Delete_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cand.services.pods.v1.Pods/Delete",
+		FullMethod: "/eliot.services.pods.v1.Pods/Delete",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(PodsServer).Delete(ctx, req.(*DeletePodRequest))
@@ -561,6 +1144,24 @@ func _Pods_Delete_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Pods_DeleteNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodsServer).DeleteNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.pods.v1.Pods/DeleteNamespace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodsServer).DeleteNamespace(ctx, req.(*DeleteNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Pods_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListPodsRequest)
 	if err := dec(in); err != nil {
@@ -571,7 +1172,7 @@ func _Pods_List_Handler(srv interface{}, ctx context.Context, dec func(interface
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cand.services.pods.v1.Pods/List",
+		FullMethod: "/eliot.services.pods.v1.Pods/List",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(PodsServer).List(ctx, req.(*ListPodsRequest))
@@ -579,8 +1180,62 @@ func _Pods_List_Handler(srv interface{}, ctx context.Context, dec func(interface
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Pods_ValidateManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodsServer).ValidateManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.pods.v1.Pods/ValidateManifest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodsServer).ValidateManifest(ctx, req.(*ValidateManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pods_Run_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunPodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodsServer).Run(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.pods.v1.Pods/Run",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodsServer).Run(ctx, req.(*RunPodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pods_Apply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyPodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodsServer).Apply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/eliot.services.pods.v1.Pods/Apply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodsServer).Apply(ctx, req.(*ApplyPodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Pods_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "cand.services.pods.v1.Pods",
+	ServiceName: "eliot.services.pods.v1.Pods",
 	HandlerType: (*PodsServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -591,10 +1246,26 @@ var _Pods_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _Pods_Delete_Handler,
 		},
+		{
+			MethodName: "DeleteNamespace",
+			Handler:    _Pods_DeleteNamespace_Handler,
+		},
 		{
 			MethodName: "List",
 			Handler:    _Pods_List_Handler,
 		},
+		{
+			MethodName: "ValidateManifest",
+			Handler:    _Pods_ValidateManifest_Handler,
+		},
+		{
+			MethodName: "Run",
+			Handler:    _Pods_Run_Handler,
+		},
+		{
+			MethodName: "Apply",
+			Handler:    _Pods_Apply_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -609,50 +1280,92 @@ var _Pods_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("services/pods/v1/pods.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 719 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xcd, 0x6f, 0xd3, 0x4e,
-	0x10, 0x95, 0x9b, 0x8f, 0x36, 0x13, 0xfd, 0xd4, 0x74, 0x7f, 0x7c, 0x58, 0x29, 0x42, 0xc1, 0x42,
-	0x6d, 0x0e, 0x95, 0x4d, 0x83, 0x50, 0x41, 0x1c, 0x80, 0x36, 0xaa, 0x54, 0xa9, 0xa0, 0x6a, 0x83,
-	0x04, 0x2a, 0xa7, 0xad, 0x3d, 0x49, 0xad, 0x26, 0x59, 0xb3, 0xbb, 0x09, 0xea, 0x15, 0xfe, 0x1d,
-	0x6e, 0x9c, 0xf8, 0xdb, 0xb8, 0xa0, 0x5d, 0x6f, 0x9c, 0xc4, 0xe0, 0x7e, 0x70, 0xb2, 0xdf, 0xf8,
-	0xcd, 0xcc, 0x5b, 0x7b, 0xde, 0x18, 0x36, 0x25, 0x8a, 0x69, 0x1c, 0xa2, 0x0c, 0x12, 0x1e, 0xc9,
-	0x60, 0xba, 0x6b, 0xae, 0x7e, 0x22, 0xb8, 0xe2, 0xe4, 0x6e, 0xc8, 0xc6, 0x91, 0x3f, 0x63, 0xf8,
-	0xe6, 0xc9, 0x74, 0xb7, 0xf9, 0x7f, 0xc8, 0x05, 0x06, 0x23, 0x54, 0x2c, 0x62, 0x8a, 0xa5, 0xdc,
-	0xe6, 0x76, 0x56, 0x28, 0xe4, 0x63, 0xc5, 0xe2, 0x31, 0x0a, 0x53, 0x6e, 0x8e, 0x52, 0xa2, 0x47,
-	0xa1, 0x71, 0x20, 0x90, 0x29, 0x3c, 0xe1, 0x11, 0xc5, 0xcf, 0x13, 0x94, 0x8a, 0xec, 0x40, 0x29,
-	0xe1, 0x91, 0xeb, 0xb4, 0x9c, 0x76, 0xbd, 0xd3, 0xf4, 0xff, 0xda, 0xd6, 0xd7, 0x7c, 0x4d, 0x23,
-	0x0d, 0x28, 0x29, 0x75, 0xe9, 0xae, 0xb4, 0x9c, 0xf6, 0x1a, 0xd5, 0xb7, 0xde, 0x7b, 0xb8, 0x9f,
-	0xd5, 0xec, 0x29, 0x81, 0x6c, 0x44, 0x51, 0x26, 0x7c, 0x2c, 0x91, 0xbc, 0x80, 0x6a, 0x3c, 0x62,
-	0x03, 0x94, 0xae, 0xd3, 0x2a, 0xb5, 0xeb, 0x9d, 0x47, 0x05, 0xd5, 0x8f, 0x34, 0xe9, 0x10, 0x55,
-	0x78, 0x4e, 0x6d, 0x82, 0xf7, 0xd3, 0x01, 0x98, 0x87, 0x49, 0x0b, 0xea, 0xd9, 0x61, 0x8e, 0xba,
-	0x46, 0x6c, 0x8d, 0x2e, 0x86, 0xc8, 0x1d, 0xa8, 0x98, 0x54, 0x23, 0xad, 0x46, 0x53, 0x40, 0x9a,
-	0xb0, 0x26, 0x50, 0xf2, 0xe1, 0x14, 0x23, 0xb7, 0x64, 0x34, 0x67, 0x98, 0xdc, 0x83, 0x6a, 0x9f,
-	0xc5, 0x43, 0x8c, 0xdc, 0xb2, 0x79, 0x62, 0x11, 0x79, 0x05, 0xd5, 0x21, 0xbb, 0x44, 0x21, 0xdd,
-	0x8a, 0x51, 0xbd, 0x7d, 0x95, 0xea, 0x63, 0xcd, 0xec, 0x29, 0xa6, 0x26, 0x92, 0xda, 0x34, 0xef,
-	0xab, 0x03, 0x8d, 0xfc, 0x43, 0xfd, 0xe2, 0x04, 0xf6, 0xad, 0x72, 0x7d, 0xab, 0xfb, 0x47, 0xf1,
-	0x00, 0xa5, 0xb2, 0x92, 0x2d, 0xd2, 0x71, 0x69, 0x72, 0x8c, 0xe2, 0x1a, 0xb5, 0x48, 0xc7, 0x79,
-	0xbf, 0x2f, 0x51, 0x19, 0xbd, 0x25, 0x6a, 0x91, 0x3e, 0xb9, 0xe2, 0x8a, 0x0d, 0xdd, 0x8a, 0x09,
-	0xa7, 0xc0, 0x3b, 0x80, 0xf5, 0x9e, 0x62, 0x42, 0x2d, 0x7c, 0xe9, 0x07, 0x50, 0x1b, 0xb3, 0x11,
-	0xca, 0x84, 0x85, 0x68, 0x85, 0xcc, 0x03, 0x84, 0x40, 0x59, 0x03, 0x2b, 0xc6, 0xdc, 0x7b, 0xaf,
-	0xa1, 0x31, 0x2f, 0x62, 0x3f, 0xea, 0xad, 0xe6, 0xc5, 0xeb, 0x42, 0xa3, 0x8b, 0x43, 0x5c, 0x9a,
-	0xb8, 0xdb, 0xeb, 0x78, 0x03, 0x1b, 0x0b, 0x55, 0xfe, 0x49, 0x48, 0x00, 0xeb, 0xc7, 0xb1, 0xd4,
-	0x27, 0x91, 0x37, 0xd2, 0xe1, 0xed, 0x43, 0x63, 0x9e, 0x60, 0x5b, 0xfa, 0x50, 0xd6, 0x85, 0xed,
-	0x38, 0x5f, 0xd5, 0xd3, 0xf0, 0xbc, 0xef, 0x0e, 0x94, 0x4e, 0x78, 0x44, 0xf6, 0x60, 0x6d, 0x66,
-	0x59, 0xab, 0x77, 0x33, 0xcd, 0xd5, 0x6e, 0xf6, 0x29, 0x4a, 0x3e, 0x11, 0x21, 0xbe, 0xb5, 0x14,
-	0x9a, 0x91, 0x49, 0x07, 0xca, 0x32, 0xc1, 0xd0, 0xbc, 0x8c, 0x7a, 0xe7, 0x61, 0x71, 0xc3, 0x5e,
-	0x82, 0x21, 0x35, 0x5c, 0xf2, 0x7c, 0x69, 0x7e, 0xea, 0x9d, 0xd6, 0x15, 0x59, 0x76, 0x70, 0x53,
-	0xbe, 0xf7, 0xc3, 0x81, 0x55, 0x5b, 0x8b, 0x1c, 0x02, 0xcc, 0xd7, 0x87, 0x3d, 0xf0, 0x56, 0xae,
-	0xd2, 0xc2, 0x7e, 0x99, 0xee, 0xfa, 0x07, 0x33, 0x44, 0x17, 0x32, 0xb5, 0x73, 0xcf, 0xb9, 0x54,
-	0xef, 0x50, 0x7d, 0xe1, 0xe2, 0xc2, 0x2e, 0x8e, 0xc5, 0x10, 0x71, 0x61, 0x55, 0xc3, 0x93, 0xa3,
-	0xae, 0xb5, 0xe8, 0x0c, 0x92, 0xc7, 0xf0, 0x9f, 0x40, 0x99, 0x0e, 0xe0, 0x30, 0x0e, 0x2f, 0xcd,
-	0xe0, 0xd7, 0xe8, 0x72, 0xd0, 0xfb, 0xe6, 0x40, 0x2d, 0x3b, 0x0b, 0x39, 0x85, 0x8d, 0xac, 0x7b,
-	0x1a, 0xca, 0xd6, 0xcf, 0xce, 0xcd, 0xe4, 0xdb, 0x97, 0xf2, 0x67, 0x19, 0xbd, 0x4d, 0xb4, 0xb4,
-	0x85, 0xf1, 0xcc, 0x70, 0xe7, 0xd7, 0x0a, 0x94, 0xf5, 0xac, 0x90, 0x10, 0xaa, 0xe9, 0x3e, 0x24,
-	0x45, 0x8b, 0x23, 0xbf, 0x82, 0x9b, 0xfe, 0x75, 0xc4, 0xe5, 0xbd, 0xfa, 0xc4, 0x21, 0x1f, 0xa1,
-	0x62, 0x8c, 0x49, 0xb6, 0x0a, 0x52, 0x73, 0xde, 0x6f, 0x6e, 0x5f, 0xcb, 0xb3, 0x23, 0xfe, 0x09,
-	0xaa, 0xa9, 0xd5, 0x0a, 0xe5, 0xe7, 0xfd, 0xdc, 0x6c, 0x5f, 0x4f, 0xb4, 0xc5, 0x3f, 0x40, 0x59,
-	0x7b, 0xaa, 0x50, 0x75, 0xce, 0xa1, 0x85, 0xaa, 0xf3, 0xc6, 0xdc, 0xdf, 0x3b, 0x7d, 0x36, 0x88,
-	0xd5, 0xf9, 0xe4, 0xcc, 0x0f, 0xf9, 0x28, 0x40, 0x31, 0xe6, 0x8c, 0x25, 0x2c, 0x08, 0xd9, 0x38,
-	0x48, 0x2e, 0x06, 0x01, 0x4b, 0xe2, 0x20, 0xff, 0xb3, 0x7d, 0xa9, 0xaf, 0x67, 0x55, 0xf3, 0x63,
-	0x7c, 0xfa, 0x3b, 0x00, 0x00, 0xff, 0xff, 0xd8, 0xc6, 0x22, 0x3c, 0x8c, 0x07, 0x00, 0x00,
+	// 1391 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5f, 0x6f, 0x1b, 0x45,
+	0x10, 0x97, 0x63, 0xc7, 0xb1, 0x27, 0x6d, 0xe3, 0x2e, 0x28, 0x3d, 0xb9, 0x15, 0x84, 0xa3, 0xb4,
+	0x2e, 0x52, 0xed, 0xfe, 0x91, 0x28, 0xa5, 0x42, 0x90, 0x26, 0xa5, 0x2a, 0x4a, 0x4b, 0xb4, 0x01,
+	0x24, 0x5a, 0x90, 0xd8, 0xde, 0x8d, 0x93, 0x53, 0xcf, 0xb7, 0xc7, 0xee, 0x9e, 0x21, 0xaf, 0x88,
+	0xaf, 0xc1, 0x03, 0x48, 0x3c, 0xf3, 0xd1, 0x78, 0xe0, 0x0b, 0xa0, 0xdd, 0xdb, 0xbd, 0x3b, 0x5f,
+	0xea, 0xd8, 0x2d, 0xf0, 0xe4, 0x9b, 0xdf, 0xcd, 0x9f, 0x9d, 0x99, 0x9d, 0x3f, 0x67, 0xb8, 0x28,
+	0x51, 0x4c, 0xa3, 0x00, 0xe5, 0x28, 0xe5, 0xa1, 0x1c, 0x4d, 0x6f, 0x9a, 0xdf, 0x61, 0x2a, 0xb8,
+	0xe2, 0x64, 0x13, 0xe3, 0x88, 0xab, 0xa1, 0x63, 0x19, 0x9a, 0x57, 0xd3, 0x9b, 0xfd, 0x37, 0x02,
+	0x2e, 0x70, 0x34, 0x41, 0xc5, 0x42, 0xa6, 0x58, 0xce, 0xdc, 0xbf, 0x5a, 0x68, 0x0a, 0x78, 0xa2,
+	0x58, 0x94, 0xa0, 0x30, 0xfa, 0x4a, 0x2a, 0x67, 0xf4, 0x3f, 0x87, 0x0b, 0x5f, 0xb3, 0x38, 0x0a,
+	0x99, 0xc2, 0xc7, 0x2c, 0x89, 0xc6, 0x28, 0x15, 0xc5, 0x1f, 0x32, 0x94, 0x8a, 0x8c, 0xa0, 0xa5,
+	0x6d, 0x78, 0x8d, 0xad, 0xe6, 0x60, 0xfd, 0xd6, 0xc5, 0xe1, 0xcb, 0xed, 0x0f, 0xf7, 0x79, 0x48,
+	0x0d, 0xa3, 0xff, 0x0c, 0xbc, 0x93, 0xba, 0x64, 0xca, 0x13, 0x89, 0xe4, 0x13, 0x68, 0xa3, 0x10,
+	0x5c, 0x38, 0x75, 0x57, 0xe7, 0xa9, 0xb3, 0x1a, 0x22, 0x9e, 0x3c, 0xd0, 0xfc, 0xd4, 0x8a, 0xf9,
+	0x07, 0xb0, 0x51, 0x7b, 0x45, 0x7a, 0xd0, 0x4c, 0x79, 0xe8, 0x35, 0xb6, 0x1a, 0x83, 0x2e, 0xd5,
+	0x8f, 0xe4, 0x4d, 0x58, 0x1d, 0x47, 0x18, 0x87, 0xde, 0x8a, 0xc1, 0x72, 0x82, 0x78, 0xb0, 0x36,
+	0x41, 0x29, 0xd9, 0x21, 0x7a, 0x4d, 0x83, 0x3b, 0xd2, 0x8f, 0xa0, 0xb7, 0x23, 0x90, 0x29, 0xd4,
+	0x4e, 0x58, 0xb7, 0xaf, 0x97, 0x5a, 0x17, 0x78, 0x6d, 0x4c, 0xf6, 0xa0, 0xa9, 0xd4, 0xb1, 0x31,
+	0xd8, 0xa1, 0xfa, 0x51, 0x1f, 0x42, 0x2a, 0x26, 0x94, 0x31, 0xd6, 0xa1, 0x39, 0xe1, 0xff, 0xd5,
+	0x80, 0x0b, 0x85, 0xad, 0x03, 0x25, 0x90, 0x4d, 0x8a, 0xe0, 0x7c, 0x04, 0xed, 0x68, 0xc2, 0x0e,
+	0xd1, 0x05, 0xc7, 0x9f, 0x67, 0xf5, 0x91, 0xe6, 0xfa, 0x0c, 0x55, 0x70, 0x44, 0xad, 0x04, 0x79,
+	0x06, 0xe7, 0x8b, 0xa4, 0x1e, 0x28, 0xa6, 0x32, 0x89, 0xd2, 0x5b, 0x31, 0x6a, 0xae, 0xd7, 0xd5,
+	0x54, 0xb2, 0x3f, 0xbd, 0x39, 0xdc, 0x99, 0x15, 0xa3, 0x27, 0xf5, 0x90, 0x7b, 0xd0, 0x4e, 0x8f,
+	0x98, 0xd6, 0xd8, 0x34, 0x1a, 0xdf, 0x9d, 0x77, 0x30, 0xeb, 0x99, 0xe6, 0xa5, 0x56, 0xc4, 0x7f,
+	0x01, 0xeb, 0x15, 0x98, 0x5c, 0x82, 0x6e, 0x61, 0xc0, 0xe6, 0xac, 0x04, 0x74, 0xd0, 0x8c, 0x98,
+	0xcb, 0x9c, 0x21, 0x34, 0x6a, 0xd2, 0x6f, 0xf3, 0x96, 0x13, 0x84, 0x40, 0x4b, 0x45, 0x13, 0xf4,
+	0x5a, 0x5b, 0x8d, 0x41, 0x93, 0x9a, 0x67, 0xff, 0xef, 0x06, 0x40, 0x19, 0x1d, 0xb2, 0x05, 0xeb,
+	0x85, 0xee, 0x47, 0xbb, 0xd6, 0x5c, 0x15, 0xd2, 0xaa, 0x4d, 0x04, 0x9d, 0x41, 0x43, 0x90, 0x3e,
+	0x74, 0x04, 0x4a, 0x1e, 0x4f, 0x31, 0xb4, 0xe9, 0x2b, 0x68, 0xb2, 0x09, 0xed, 0x31, 0x8b, 0x62,
+	0x0c, 0x8d, 0xe1, 0x0e, 0xb5, 0x14, 0xf9, 0x14, 0xda, 0x31, 0x3b, 0x46, 0x21, 0xbd, 0x55, 0x13,
+	0xa4, 0xc1, 0xa9, 0xd9, 0xdb, 0x63, 0xc7, 0x2e, 0xc0, 0xd4, 0xca, 0x91, 0x3b, 0xe6, 0xc6, 0x28,
+	0xe9, 0xb5, 0xcd, 0xa5, 0x7b, 0x67, 0xee, 0xa5, 0xcb, 0xe2, 0x58, 0x8b, 0x4a, 0x9a, 0xf3, 0xfb,
+	0xbf, 0x35, 0xa0, 0x5b, 0x80, 0xfa, 0x80, 0x01, 0x0b, 0x8e, 0x30, 0xbf, 0xbc, 0x1d, 0x6a, 0x29,
+	0xed, 0x54, 0x98, 0x09, 0x53, 0x38, 0xc6, 0xdb, 0x26, 0x2d, 0x68, 0xe2, 0xc3, 0x99, 0xe7, 0xc7,
+	0x0a, 0xa5, 0x09, 0x9b, 0x75, 0xba, 0x49, 0x67, 0x30, 0xad, 0xd7, 0x3a, 0xa8, 0x1d, 0x5f, 0x2d,
+	0x8e, 0x7d, 0x19, 0xce, 0x8e, 0x73, 0x96, 0x3d, 0xe7, 0xbf, 0x7e, 0x3d, 0x0b, 0xfa, 0x3f, 0x37,
+	0xa0, 0x57, 0xf7, 0x5c, 0x57, 0x8d, 0xc0, 0xb1, 0x2b, 0x5d, 0x81, 0x63, 0x6d, 0x24, 0x8c, 0x0e,
+	0x51, 0x2a, 0x9b, 0x10, 0x4b, 0x69, 0x5c, 0x1a, 0x19, 0x7b, 0x07, 0x2c, 0xa5, 0x71, 0x3e, 0x1e,
+	0x4b, 0x54, 0xf6, 0x1a, 0x58, 0x4a, 0xe7, 0x55, 0x71, 0xc5, 0x62, 0x73, 0x98, 0x26, 0xcd, 0x09,
+	0x7f, 0x0c, 0x67, 0x69, 0x96, 0xbc, 0x7e, 0x95, 0x5f, 0x81, 0x73, 0xfa, 0x9a, 0xf1, 0x4c, 0x1d,
+	0x60, 0xc0, 0x93, 0x50, 0xda, 0x40, 0xd6, 0x50, 0xff, 0x31, 0x9c, 0x73, 0x76, 0x6c, 0x6d, 0xdf,
+	0x83, 0x35, 0x81, 0x32, 0x8b, 0x95, 0x2b, 0xee, 0xb9, 0xd9, 0xa5, 0x59, 0x42, 0x0d, 0x27, 0x75,
+	0x12, 0xfe, 0x1f, 0x2b, 0xd0, 0x2d, 0x60, 0x7d, 0xef, 0x13, 0x36, 0x41, 0x1b, 0x35, 0xf3, 0x5c,
+	0xbf, 0xe8, 0x2b, 0x27, 0x2f, 0x7a, 0x1f, 0x3a, 0xf8, 0x53, 0xa4, 0x76, 0x78, 0x98, 0xb7, 0xbf,
+	0x55, 0x5a, 0xd0, 0xe4, 0x2d, 0x00, 0xfd, 0x4c, 0x91, 0x49, 0x9e, 0x98, 0x40, 0x76, 0x69, 0x05,
+	0xd1, 0xb2, 0xda, 0xc1, 0xf0, 0x8b, 0x4c, 0x99, 0x78, 0x76, 0x68, 0x41, 0xe7, 0x89, 0x09, 0x79,
+	0xa6, 0xcc, 0xad, 0x3d, 0x43, 0x2d, 0x65, 0x71, 0x14, 0xc2, 0x5b, 0x2b, 0x70, 0x14, 0x42, 0xd7,
+	0xbf, 0x12, 0x59, 0x12, 0x30, 0x85, 0xa1, 0xd7, 0x31, 0xca, 0x4a, 0x40, 0xbf, 0x35, 0x7d, 0x12,
+	0xc3, 0x6d, 0xe5, 0x75, 0x4d, 0x6c, 0x4b, 0x40, 0x9f, 0x73, 0x1c, 0x25, 0x91, 0x3c, 0x32, 0xaf,
+	0xc1, 0xbc, 0xae, 0x20, 0x3e, 0x83, 0xde, 0x76, 0x9a, 0xc6, 0xc7, 0xfb, 0x3c, 0x94, 0x2e, 0xc3,
+	0x97, 0xa0, 0xab, 0x23, 0x24, 0x53, 0x16, 0xb8, 0x90, 0x95, 0x40, 0x31, 0xdc, 0x56, 0x96, 0x1d,
+	0x6e, 0x14, 0xce, 0x57, 0x4c, 0xd8, 0xe4, 0x7e, 0x0c, 0x6b, 0x2c, 0xd0, 0x75, 0xe4, 0x92, 0x3b,
+	0xb7, 0x41, 0x1a, 0xd9, 0x6d, 0xc3, 0x4b, 0x9d, 0x8c, 0xcf, 0x61, 0xbd, 0x82, 0xbf, 0x64, 0x9e,
+	0x2d, 0xce, 0xee, 0x26, 0xb4, 0x73, 0x6d, 0xae, 0x3c, 0x72, 0xaa, 0xec, 0x9c, 0xad, 0x4a, 0xe7,
+	0xf4, 0x77, 0x60, 0xe3, 0x40, 0x07, 0xb5, 0x52, 0x08, 0xa7, 0x87, 0xc9, 0x5d, 0xb9, 0x95, 0xf2,
+	0xca, 0xf9, 0xdb, 0xd0, 0x2b, 0x95, 0xd8, 0x40, 0xbc, 0x5a, 0x39, 0xf9, 0xbb, 0xd0, 0xdb, 0xc5,
+	0x18, 0x15, 0xfe, 0xab, 0x83, 0xdc, 0x87, 0xf3, 0x15, 0x2d, 0xaf, 0x77, 0x92, 0xef, 0x61, 0x33,
+	0xd7, 0xf1, 0xc4, 0x99, 0x5a, 0xee, 0x3c, 0x03, 0xd8, 0x10, 0x38, 0xe1, 0xd3, 0x52, 0xce, 0xae,
+	0x00, 0x75, 0xd8, 0x9f, 0xc2, 0x85, 0x13, 0x16, 0xec, 0x59, 0x5f, 0x3a, 0xbb, 0x1b, 0xff, 0xcd,
+	0xec, 0xf6, 0xbf, 0x82, 0x8d, 0xbd, 0x48, 0xaa, 0xe5, 0x4b, 0xe2, 0x32, 0x9c, 0x65, 0x71, 0x5c,
+	0x9c, 0x52, 0x5a, 0x87, 0x66, 0x41, 0x7f, 0x07, 0x7a, 0xa5, 0x5a, 0xeb, 0xc7, 0x2b, 0x6f, 0x8a,
+	0x7f, 0x36, 0xa0, 0xb9, 0xcf, 0x43, 0xf2, 0x21, 0x74, 0xdc, 0xe2, 0x6a, 0x33, 0x76, 0xc9, 0x0a,
+	0x07, 0x5c, 0xe0, 0x90, 0xa2, 0xe4, 0x99, 0x08, 0xf0, 0xb1, 0xe5, 0xa1, 0x05, 0x37, 0xb9, 0x0d,
+	0x2d, 0x99, 0x62, 0x60, 0xce, 0xb8, 0x7e, 0xeb, 0xed, 0x53, 0x4c, 0x1e, 0xa4, 0x18, 0x50, 0xc3,
+	0x4c, 0xee, 0xce, 0xcc, 0x92, 0xd3, 0x06, 0xad, 0x5e, 0xd1, 0xf2, 0x11, 0x9d, 0x0b, 0xf8, 0xbf,
+	0x36, 0x61, 0xcd, 0x2a, 0x23, 0x0f, 0x01, 0xca, 0x6c, 0xcc, 0xdb, 0x67, 0xe7, 0xe4, 0x8b, 0x56,
+	0x44, 0x75, 0x79, 0x1f, 0x71, 0xa9, 0x9e, 0xa0, 0xfa, 0x91, 0x8b, 0x17, 0x36, 0xde, 0x55, 0x48,
+	0xaf, 0xae, 0x9a, 0xdc, 0x7f, 0xb4, 0x6b, 0xd7, 0x11, 0x47, 0xea, 0x6c, 0x09, 0x94, 0x79, 0x1d,
+	0xc6, 0x51, 0x70, 0x6c, 0x0b, 0x7d, 0x16, 0x24, 0x1f, 0xc0, 0xa6, 0x54, 0x3c, 0x7d, 0x28, 0x58,
+	0x80, 0xfb, 0x28, 0x22, 0x1e, 0xba, 0xf9, 0x95, 0x8f, 0xc7, 0x39, 0x6f, 0xc9, 0x03, 0xe8, 0x0a,
+	0x1b, 0x7c, 0xb7, 0x95, 0x2c, 0xf0, 0xd0, 0xe5, 0x4a, 0xd2, 0x52, 0x92, 0xbc, 0x0f, 0x3d, 0xb3,
+	0x57, 0x99, 0x1d, 0x05, 0x03, 0x81, 0x4a, 0x7a, 0x6b, 0x5b, 0xcd, 0x41, 0x97, 0x9e, 0xc0, 0xf5,
+	0xac, 0x89, 0xf9, 0xe1, 0x1e, 0x4e, 0x31, 0x36, 0xe3, 0xa1, 0x4b, 0x0b, 0x5a, 0x07, 0xca, 0x3d,
+	0x3f, 0x48, 0xa6, 0x66, 0x3e, 0x74, 0x69, 0x15, 0xf2, 0x7f, 0xd1, 0x9b, 0x90, 0xcb, 0xda, 0xff,
+	0x5a, 0x58, 0xfa, 0xa0, 0x3a, 0x09, 0x95, 0x76, 0x54, 0xd0, 0xb7, 0x7e, 0x6f, 0x43, 0x4b, 0x97,
+	0x06, 0x41, 0x68, 0xe7, 0xcb, 0x2f, 0x19, 0x2c, 0xd8, 0x99, 0x8b, 0x0e, 0xd8, 0x1f, 0x2d, 0xe4,
+	0x9c, 0xfd, 0x6e, 0xb8, 0xd1, 0x20, 0x4f, 0x61, 0xd5, 0xf4, 0x62, 0x32, 0xf7, 0x7b, 0xaa, 0xd6,
+	0xef, 0xfb, 0x83, 0xc5, 0x8c, 0xb6, 0xaa, 0xbf, 0x83, 0x76, 0xde, 0xb8, 0xe6, 0xbb, 0x50, 0x6f,
+	0xe2, 0xfd, 0x6b, 0x4b, 0x70, 0x5a, 0xf5, 0x02, 0x36, 0x6a, 0x7d, 0x91, 0x0c, 0x4f, 0x97, 0xae,
+	0xb7, 0xe8, 0xfe, 0x68, 0x69, 0x7e, 0x6b, 0xf3, 0x1b, 0x68, 0xe9, 0xe6, 0x35, 0x3f, 0x5a, 0xb5,
+	0x8e, 0xd9, 0x1f, 0x2c, 0x66, 0xb4, 0xaa, 0x33, 0xe8, 0xd5, 0x3f, 0x7e, 0xc9, 0x68, 0xc1, 0x47,
+	0x6e, 0xfd, 0x93, 0xbb, 0x7f, 0x63, 0x79, 0x01, 0x6b, 0xf6, 0x4b, 0x68, 0xd2, 0x2c, 0x21, 0xef,
+	0x9d, 0xb2, 0x54, 0x56, 0xd2, 0x73, 0x65, 0x11, 0x9b, 0xd5, 0xfa, 0x2d, 0xac, 0x9a, 0xc5, 0x64,
+	0x7e, 0xe6, 0xeb, 0xeb, 0x56, 0xff, 0xda, 0x12, 0x9c, 0xb9, 0xf6, 0xfb, 0x77, 0x9f, 0xde, 0x39,
+	0x8c, 0xd4, 0x51, 0xf6, 0x7c, 0x18, 0xf0, 0xc9, 0x08, 0x45, 0xc2, 0x19, 0x4b, 0xd9, 0xc8, 0xc8,
+	0x8f, 0xd2, 0x17, 0x87, 0x23, 0x96, 0x46, 0xa3, 0xfa, 0x5f, 0x21, 0xf7, 0xf4, 0xef, 0xf3, 0xb6,
+	0xf9, 0xd7, 0xe2, 0xf6, 0x3f, 0x03, 0x00, 0xa4, 0x66, 0x2e, 0x41, 0x2a, 0x11, 0x00, 0x00,
 }
@@ -124,3 +124,44 @@ func TestUnmarshalListJSON(t *testing.T) {
 	assert.Equal(t, "foo", pods[0].Metadata.Name, "Should unmarshal name")
 	assert.Equal(t, 2, len(pods[0].Spec.Containers), "Should have one container spec")
 }
+
+func TestUnmarshalContainerHostNetwork(t *testing.T) {
+	pods, err := UnmarshalYaml([]byte(`
+metadata:
+  name: "foo"
+spec:
+  containers:
+    - name: "mdns"
+      image: "docker.io/library/avahi:latest"
+      hostNetwork: true
+    - name: "app"
+      image: "docker.io/library/hello-world:latest"
+`))
+
+	assert.NoError(t, err, "Unable unmarshal test yaml")
+	assert.False(t, pods[0].Spec.HostNetwork, "Should not have pod host network")
+	assert.True(t, pods[0].Spec.Containers[0].HostNetwork, "Should have container host network")
+	assert.False(t, pods[0].Spec.Containers[1].HostNetwork, "Should not have container host network")
+}
+
+func TestUnmarshalYamlProbes(t *testing.T) {
+	pods, err := UnmarshalYaml([]byte(`
+metadata:
+  name: "foo"
+spec:
+  containers:
+    - name: "foo"
+      image: "docker.io/library/nginx:latest"
+      livenessProbe:
+        exec: ["pgrep", "nginx"]
+        failureThreshold: 5
+      readinessProbe:
+        exec: ["test", "-f", "/tmp/ready"]
+        initialDelaySeconds: 10
+`))
+
+	assert.NoError(t, err, "Unable unmarshal test yaml")
+	assert.Equal(t, []string{"pgrep", "nginx"}, pods[0].Spec.Containers[0].LivenessProbe.Exec)
+	assert.Equal(t, int32(5), pods[0].Spec.Containers[0].LivenessProbe.FailureThreshold)
+	assert.Equal(t, int64(10), pods[0].Spec.Containers[0].ReadinessProbe.InitialDelaySeconds)
+}
@@ -0,0 +1,60 @@
+package stream
+
+import (
+	"bytes"
+
+	images "github.com/ernoaapa/eliot/pkg/api/services/images/v1"
+)
+
+// ArchiveReader is io.Reader implementation what reads image archive bytes from RPC stream
+type ArchiveReader struct {
+	buffer bytes.Buffer
+	stream ImportStreamServer
+}
+
+// ImportStreamServer interface for the endpoint what takes image archive stream in
+type ImportStreamServer interface {
+	Recv() (*images.ImportImageRequest, error)
+}
+
+// NewArchiveReader creates new ArchiveReader instance
+func NewArchiveReader(stream ImportStreamServer) *ArchiveReader {
+	return &ArchiveReader{stream: stream}
+}
+
+// Read reads bytes from given RPC stream
+func (r *ArchiveReader) Read(p []byte) (n int, err error) {
+	for r.buffer.Len() == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buffer.Write(req.GetData())
+	}
+	return r.buffer.Read(p)
+}
+
+// ArchiveWriter is io.Writer implementation what writes image archive bytes to RPC stream
+type ArchiveWriter struct {
+	stream ExportStreamServer
+}
+
+// ExportStreamServer interface for the endpoint what returns stream of image archive bytes
+type ExportStreamServer interface {
+	Send(*images.ExportImageResponse) error
+}
+
+// NewArchiveWriter creates new ArchiveWriter instance
+func NewArchiveWriter(stream ExportStreamServer) *ArchiveWriter {
+	return &ArchiveWriter{stream: stream}
+}
+
+// Write writes bytes to given RPC stream
+func (w *ArchiveWriter) Write(p []byte) (n int, err error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+	if err := w.stream.Send(&images.ExportImageResponse{Data: data}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
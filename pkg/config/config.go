@@ -22,6 +22,8 @@ type Config struct {
 type Endpoint struct {
 	Name string `yaml:"name"`
 	URL  string `yaml:"url"`
+	// Token is passed to the node for the authentication, if the node requires it
+	Token string `yaml:"token,omitempty"`
 }
 
 // GetHost return just hostname/ip of endpoint URL
@@ -33,3 +33,11 @@ func TestSetValue(t *testing.T) {
 
 	assert.Equal(t, "foobar", config.Namespace)
 }
+
+func TestProviderOverrideToken(t *testing.T) {
+	provider := NewProvider(&Config{Endpoints: []Endpoint{{Name: "device", URL: "localhost:5000", Token: "from-config"}}})
+	assert.Equal(t, "from-config", provider.GetEndpoints()[0].Token)
+
+	provider.OverrideToken("from-flag")
+	assert.Equal(t, "from-flag", provider.GetEndpoints()[0].Token, "should override the endpoint token")
+}
@@ -5,6 +5,7 @@ type Provider struct {
 	config            *Config
 	namespaceOverride string
 	endpointsOverride []Endpoint
+	tokenOverride     string
 }
 
 // NewProvider creates new Provider around config instance
@@ -42,10 +43,25 @@ func (c *Provider) OverrideEndpoints(endpoints []Endpoint) {
 	c.endpointsOverride = endpoints
 }
 
-// GetEndpoints return current namespace
+// OverrideToken set the token of every endpoint to be overrided with given value
+func (c *Provider) OverrideToken(token string) {
+	c.tokenOverride = token
+}
+
+// GetEndpoints return current endpoints
 func (c *Provider) GetEndpoints() []Endpoint {
+	endpoints := c.config.Endpoints
 	if len(c.endpointsOverride) != 0 {
-		return c.endpointsOverride
+		endpoints = c.endpointsOverride
+	}
+	if c.tokenOverride == "" {
+		return endpoints
+	}
+
+	result := make([]Endpoint, len(endpoints))
+	for i, endpoint := range endpoints {
+		endpoint.Token = c.tokenOverride
+		result[i] = endpoint
 	}
-	return c.config.Endpoints
+	return result
 }
@@ -0,0 +1,344 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// PrepareFunc returns the container ready to be created, e.g. with the image pulled and the secret files resolved
+type PrepareFunc func(pod model.Pod, container model.Container) (model.Container, error)
+
+// Converger makes the namespace pods match the desired set of pods, it creates the missing pods, recreates
+// the pods whose spec has changed and removes the pods what are not desired. The pods with unchanged spec are
+// left for the Lifecycle controller to keep running. The spec change is detected from the spec hash what gets
+// recorded when the containers get created, so the pods created before eliot recorded it get recreated once
+type Converger struct {
+	client   runtime.Client
+	history  *ReconcileHistory
+	now      func() time.Time
+	newIOSet func(id string) (*runtime.IOSet, error)
+	// mutex makes the applies run one by one so that the changes don't get computed from stale pods
+	mutex sync.Mutex
+}
+
+// convergePlan is the difference between the current and the desired pods
+type convergePlan struct {
+	create []model.Pod
+	update []podUpdate
+	remove []model.Pod
+}
+
+type podUpdate struct {
+	current model.Pod
+	desired model.Pod
+}
+
+// appliedChange is created or recreated pod what the rollback undoes
+type appliedChange struct {
+	pod     model.Pod
+	created []model.ContainerStatus
+	// previous is the replaced pod spec if the pod was recreated
+	previous *model.Pod
+}
+
+// NewConverger creates new Converger what records the actions to the same history as the controllers
+func NewConverger(client runtime.Client, history *ReconcileHistory) *Converger {
+	return &Converger{
+		client:   client,
+		history:  history,
+		now:      time.Now,
+		newIOSet: runtime.NewIOSet,
+	}
+}
+
+// Converge applies the desired pods to the namespace and returns the actions taken
+// All the containers get prepared and the capacity checked before changing anything. If creating or recreating
+// some pod fails, the containers created so far get removed and the recreated pods get restored from their
+// previous spec. The pods get removed last, once everything else has succeeded, so failing removal doesn't roll back
+func (c *Converger) Converge(namespace string, desired []model.Pod, prepare PrepareFunc) ([]model.ReconcileRecord, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	current, err := c.client.GetPods(namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list pods in namespace [%s]", namespace)
+	}
+	plan := planConvergence(current, desired)
+
+	if err := c.checkCapacity(plan); err != nil {
+		return nil, err
+	}
+
+	prepared := map[string][]model.Container{}
+	for _, pod := range plan.getCreatedPods() {
+		containers, err := preparePod(pod, prepare)
+		if err != nil {
+			return nil, err
+		}
+		prepared[pod.Metadata.Name] = containers
+	}
+
+	previous := map[string]model.Pod{}
+	if len(plan.update) > 0 {
+		exported, err := c.client.ExportPods(namespace)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to export the current pods in namespace [%s] for rollback", namespace)
+		}
+		for _, pod := range exported {
+			previous[pod.Metadata.Name] = pod
+		}
+	}
+
+	var (
+		records []model.ReconcileRecord
+		applied []appliedChange
+	)
+	for _, pod := range plan.create {
+		statuses, err := c.createPod(pod, prepared[pod.Metadata.Name])
+		if err != nil {
+			records = append(records, c.newRecord(pod, "", model.ReconcileFailed, err))
+			records = append(records, c.rollback(namespace, applied, prepare)...)
+			return records, errors.Wrapf(err, "Failed to create pod [%s], rolled back the apply", pod.Metadata.Name)
+		}
+		applied = append(applied, appliedChange{pod: pod, created: statuses})
+		records = append(records, c.newRecords(pod, statuses, model.ReconcileCreated)...)
+	}
+
+	for _, update := range plan.update {
+		name := update.desired.Metadata.Name
+		change := appliedChange{pod: update.desired}
+		if pod, ok := previous[name]; ok {
+			change.previous = &pod
+		}
+
+		if _, err := c.client.StopContainers(namespace, getPodContainerIDs(update.current), update.current.Spec.StopGracePeriod); err != nil {
+			records = append(records, c.newRecord(update.desired, "", model.ReconcileFailed, err))
+			records = append(records, c.rollback(namespace, append(applied, change), prepare)...)
+			return records, errors.Wrapf(err, "Failed to remove the pod [%s] containers for recreating it, rolled back the apply", name)
+		}
+
+		statuses, err := c.createPod(update.desired, prepared[name])
+		if err != nil {
+			records = append(records, c.newRecord(update.desired, "", model.ReconcileFailed, err))
+			records = append(records, c.rollback(namespace, append(applied, change), prepare)...)
+			return records, errors.Wrapf(err, "Failed to recreate pod [%s], rolled back the apply", name)
+		}
+		change.created = statuses
+		applied = append(applied, change)
+		records = append(records, c.newRecords(update.desired, statuses, model.ReconcileUpdated)...)
+	}
+
+	failures := []string{}
+	for _, pod := range plan.remove {
+		statuses, err := c.client.StopContainers(namespace, getPodContainerIDs(pod), pod.Spec.StopGracePeriod)
+		if err != nil {
+			records = append(records, c.newRecord(pod, "", model.ReconcileFailed, err))
+			failures = append(failures, fmt.Sprintf("pod [%s]: %s", pod.Metadata.Name, err))
+			continue
+		}
+		records = append(records, c.newRecords(pod, statuses, model.ReconcileRemoved)...)
+	}
+	if len(failures) > 0 {
+		return records, fmt.Errorf("Failed to remove %d pod(s) from namespace [%s]: %s", len(failures), namespace, strings.Join(failures, ", "))
+	}
+	return records, nil
+}
+
+// planConvergence returns the changes what make the current pods match the desired pods
+func planConvergence(current, desired []model.Pod) (plan convergePlan) {
+	existing := map[string]model.Pod{}
+	for _, pod := range current {
+		existing[pod.Metadata.Name] = pod
+	}
+
+	wanted := map[string]bool{}
+	for _, pod := range desired {
+		wanted[pod.Metadata.Name] = true
+		pod.Status = model.PodStatus{}
+
+		currentPod, ok := existing[pod.Metadata.Name]
+		switch {
+		case !ok:
+			plan.create = append(plan.create, pod)
+		case currentPod.Status.SpecHash != pod.GetSpecHash():
+			plan.update = append(plan.update, podUpdate{current: currentPod, desired: pod})
+		}
+	}
+
+	for _, pod := range current {
+		if !wanted[pod.Metadata.Name] {
+			plan.remove = append(plan.remove, pod)
+		}
+	}
+	return plan
+}
+
+// getCreatedPods returns the pods what get new containers
+func (p convergePlan) getCreatedPods() []model.Pod {
+	result := append([]model.Pod{}, p.create...)
+	for _, update := range p.update {
+		result = append(result, update.desired)
+	}
+	return result
+}
+
+// checkCapacity checks the container limit and the namespace quota before creating anything,
+// the recreated pods release their current containers before the new ones get created
+func (c *Converger) checkCapacity(plan convergePlan) error {
+	added := 0
+	for _, pod := range plan.create {
+		added += len(pod.Spec.Containers)
+	}
+	for _, update := range plan.update {
+		added += len(update.desired.Spec.Containers) - len(update.current.Status.ContainerStatuses)
+	}
+	if added > 0 {
+		if err := c.client.CheckContainerLimit(added); err != nil {
+			return err
+		}
+	}
+	for _, pod := range plan.create {
+		if err := c.client.CheckNamespaceQuota(pod, nil); err != nil {
+			return err
+		}
+	}
+	for _, update := range plan.update {
+		if err := c.client.CheckNamespaceQuota(update.desired, getPodContainerIDs(update.current)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func preparePod(pod model.Pod, prepare PrepareFunc) ([]model.Container, error) {
+	result := []model.Container{}
+	for _, container := range pod.Spec.Containers {
+		prepared, err := prepare(pod, container)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to prepare pod [%s] container [%s]", pod.Metadata.Name, container.Name)
+		}
+		result = append(result, prepared)
+	}
+	return result, nil
+}
+
+// createPod creates and starts the pod containers in the start priority order, the scheduled containers
+// are left for the Scheduler controller to start. Removes the created containers if any of the steps fails
+func (c *Converger) createPod(pod model.Pod, containers []model.Container) ([]model.ContainerStatus, error) {
+	created := []model.ContainerStatus{}
+	for _, container := range containers {
+		status, err := c.client.CreateContainer(pod, container)
+		if err != nil {
+			c.removeContainers(pod, created)
+			return nil, errors.Wrapf(err, "Failed to create container [%s]", container.Name)
+		}
+		created = append(created, status)
+	}
+
+	started := map[string]model.ContainerStatus{}
+	for _, status := range pod.SortByStartPriority(created) {
+		if isScheduled(pod, status.Name) {
+			continue
+		}
+		ioset, err := c.newIOSet(fmt.Sprintf("%s.%s", pod.Metadata.Name, status.Name))
+		if err != nil {
+			c.removeContainers(pod, created)
+			return nil, errors.Wrapf(err, "Error while creating container [%s] ioset", status.Name)
+		}
+		result, err := c.client.StartContainer(pod.Metadata.Namespace, status.ContainerID, *ioset)
+		if err != nil {
+			c.removeContainers(pod, created)
+			return nil, errors.Wrapf(err, "Failed to start container [%s]", status.Name)
+		}
+		started[status.ContainerID] = result
+	}
+
+	result := []model.ContainerStatus{}
+	for _, status := range created {
+		if startedStatus, ok := started[status.ContainerID]; ok {
+			status = startedStatus
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}
+
+func (c *Converger) removeContainers(pod model.Pod, statuses []model.ContainerStatus) {
+	if len(statuses) == 0 {
+		return
+	}
+	ids := []string{}
+	for _, status := range statuses {
+		ids = append(ids, status.ContainerID)
+	}
+	if _, err := c.client.StopContainers(pod.Metadata.Namespace, ids, pod.Spec.StopGracePeriod); err != nil {
+		log.Warnf("Failed to remove the pod [%s] containers after failed apply, containers might be left behind: %s", pod.Metadata.Name, err)
+	}
+}
+
+// rollback removes the containers what the apply created and restores the recreated pods from their previous spec,
+// the latest change gets undone first
+func (c *Converger) rollback(namespace string, applied []appliedChange, prepare PrepareFunc) (records []model.ReconcileRecord) {
+	for i := len(applied) - 1; i >= 0; i-- {
+		change := applied[i]
+		c.removeContainers(change.pod, change.created)
+
+		if change.previous == nil {
+			records = append(records, c.newRecords(change.pod, change.created, model.ReconcileRolledBack)...)
+			continue
+		}
+
+		containers, err := preparePod(*change.previous, prepare)
+		if err == nil {
+			_, err = c.createPod(*change.previous, containers)
+		}
+		if err != nil {
+			log.Warnf("Failed to restore pod [%s] in namespace [%s] after failed apply: %s", change.pod.Metadata.Name, namespace, err)
+			records = append(records, c.newRecord(change.pod, "", model.ReconcileFailed, errors.Wrap(err, "Failed to restore the previous pod")))
+			continue
+		}
+		records = append(records, c.newRecord(change.pod, "", model.ReconcileRolledBack, nil))
+	}
+	return records
+}
+
+// newRecords returns record of each container and adds them to the history
+func (c *Converger) newRecords(pod model.Pod, statuses []model.ContainerStatus, action string) (records []model.ReconcileRecord) {
+	if len(statuses) == 0 {
+		return []model.ReconcileRecord{c.newRecord(pod, "", action, nil)}
+	}
+	for _, status := range statuses {
+		records = append(records, c.newRecord(pod, status.ContainerID, action, nil))
+	}
+	return records
+}
+
+// newRecord returns the record of the pod action and adds it to the history
+func (c *Converger) newRecord(pod model.Pod, containerID, action string, err error) model.ReconcileRecord {
+	record := model.ReconcileRecord{
+		Time:        c.now(),
+		Namespace:   pod.Metadata.Namespace,
+		Pod:         pod.Metadata.Name,
+		ContainerID: containerID,
+		Action:      action,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	c.history.Add(record)
+	return record
+}
+
+func getPodContainerIDs(pod model.Pod) (ids []string) {
+	for _, status := range pod.Status.ContainerStatuses {
+		ids = append(ids, status.ContainerID)
+	}
+	return ids
+}
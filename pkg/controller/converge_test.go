@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeConvergeClient struct {
+	runtime.Client
+	pods      []model.Pod
+	created   []string
+	started   []string
+	stopped   []string
+	failStart string
+	// quotaFull is the pod what doesn't fit to the namespace quota, the released are the checked releases
+	quotaFull string
+	released  map[string][]string
+}
+
+func (c *fakeConvergeClient) GetPods(namespace string) ([]model.Pod, error) {
+	return c.pods, nil
+}
+
+func (c *fakeConvergeClient) ExportPods(namespace string) ([]model.Pod, error) {
+	result := []model.Pod{}
+	for _, pod := range c.pods {
+		pod.Status = model.PodStatus{}
+		result = append(result, pod)
+	}
+	return result, nil
+}
+
+func (c *fakeConvergeClient) CheckContainerLimit(count int) error {
+	return nil
+}
+
+func (c *fakeConvergeClient) CheckNamespaceQuota(pod model.Pod, released []string) error {
+	if c.released != nil {
+		c.released[pod.Metadata.Name] = released
+	}
+	if pod.Metadata.Name == c.quotaFull {
+		return runtime.ErrWithMessagef(runtime.ErrLimitExceeded, "Namespace [%s] quota exceeded", pod.Metadata.Namespace)
+	}
+	return nil
+}
+
+func (c *fakeConvergeClient) CreateContainer(pod model.Pod, container model.Container) (model.ContainerStatus, error) {
+	id := fmt.Sprintf("%s-%s", pod.Metadata.Name, container.Name)
+	c.created = append(c.created, id+":"+container.Image)
+	return model.ContainerStatus{ContainerID: id, Name: container.Name, State: "stopped"}, nil
+}
+
+func (c *fakeConvergeClient) StartContainer(namespace, id string, io runtime.IOSet) (model.ContainerStatus, error) {
+	if id == c.failStart {
+		c.failStart = ""
+		return model.ContainerStatus{}, fmt.Errorf("failed to start")
+	}
+	c.started = append(c.started, id)
+	return model.ContainerStatus{ContainerID: id, State: "running"}, nil
+}
+
+func (c *fakeConvergeClient) StopContainers(namespace string, ids []string, gracePeriod time.Duration) (result []model.ContainerStatus, err error) {
+	for _, id := range ids {
+		c.stopped = append(c.stopped, id)
+		result = append(result, model.ContainerStatus{ContainerID: id, State: "stopped"})
+	}
+	return result, nil
+}
+
+func newConvergePod(name, image string) model.Pod {
+	return model.Pod{
+		Metadata: model.NewMetadata("default", name),
+		Spec: model.PodSpec{
+			Containers: []model.Container{{Name: "app", Image: image}},
+		},
+	}
+}
+
+func newRunningPod(desired model.Pod) model.Pod {
+	desired.Status = model.PodStatus{
+		SpecHash:          desired.GetSpecHash(),
+		ContainerStatuses: []model.ContainerStatus{{ContainerID: desired.Metadata.Name + "-app", Name: "app", State: "running"}},
+	}
+	return desired
+}
+
+func newTestConverger(client runtime.Client) *Converger {
+	converger := NewConverger(client, NewReconcileHistory(10))
+	converger.newIOSet = func(string) (*runtime.IOSet, error) { return &runtime.IOSet{}, nil }
+	return converger
+}
+
+func prepareAsIs(pod model.Pod, container model.Container) (model.Container, error) {
+	return container, nil
+}
+
+func getRecordActions(records []model.ReconcileRecord) (result []string) {
+	for _, record := range records {
+		result = append(result, fmt.Sprintf("%s %s", record.Pod, record.Action))
+	}
+	return result
+}
+
+func TestPlanConvergence(t *testing.T) {
+	unchanged := newConvergePod("unchanged", "nginx:1")
+	current := []model.Pod{
+		newRunningPod(unchanged),
+		newRunningPod(newConvergePod("changed", "nginx:1")),
+		newRunningPod(newConvergePod("removed", "nginx:1")),
+	}
+	desired := []model.Pod{unchanged, newConvergePod("changed", "nginx:2"), newConvergePod("added", "nginx:1")}
+
+	plan := planConvergence(current, desired)
+	assert.Len(t, plan.create, 1)
+	assert.Equal(t, "added", plan.create[0].Metadata.Name)
+	assert.Len(t, plan.update, 1)
+	assert.Equal(t, "changed", plan.update[0].desired.Metadata.Name)
+	assert.Len(t, plan.remove, 1)
+	assert.Equal(t, "removed", plan.remove[0].Metadata.Name)
+}
+
+func TestPlanConvergenceRecreatesPodWithoutSpecHash(t *testing.T) {
+	pod := newConvergePod("legacy", "nginx:1")
+	current := newRunningPod(pod)
+	current.Status.SpecHash = ""
+
+	plan := planConvergence([]model.Pod{current}, []model.Pod{pod})
+	assert.Len(t, plan.update, 1, "should recreate the pod whose spec is unknown")
+}
+
+func TestConverge(t *testing.T) {
+	client := &fakeConvergeClient{pods: []model.Pod{
+		newRunningPod(newConvergePod("changed", "nginx:1")),
+		newRunningPod(newConvergePod("removed", "nginx:1")),
+	}}
+	converger := newTestConverger(client)
+
+	records, err := converger.Converge("default", []model.Pod{newConvergePod("changed", "nginx:2"), newConvergePod("added", "nginx:1")}, prepareAsIs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"added created", "changed updated", "removed removed"}, getRecordActions(records))
+	assert.Equal(t, []string{"added-app:nginx:1", "changed-app:nginx:2"}, client.created)
+	assert.Equal(t, []string{"added-app", "changed-app"}, client.started)
+	assert.Equal(t, []string{"changed-app", "removed-app"}, client.stopped)
+	assert.Len(t, converger.history.List(), 3, "should record the actions to the history")
+}
+
+func TestConvergeRollsBackOnFailure(t *testing.T) {
+	client := &fakeConvergeClient{
+		pods:      []model.Pod{newRunningPod(newConvergePod("changed", "nginx:1"))},
+		failStart: "changed-app",
+	}
+	converger := newTestConverger(client)
+
+	records, err := converger.Converge("default", []model.Pod{newConvergePod("changed", "nginx:2"), newConvergePod("added", "nginx:1")}, prepareAsIs)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"added created", "changed failed", "changed rolled-back", "added rolled-back"}, getRecordActions(records))
+	assert.Equal(t, []string{"added-app:nginx:1", "changed-app:nginx:2", "changed-app:nginx:1"}, client.created, "should restore the previous spec of the recreated pod")
+	assert.Equal(t, []string{"changed-app", "changed-app", "added-app"}, client.stopped, "should remove the containers what the apply created")
+}
+
+func TestConvergeChecksRecreatedPodQuota(t *testing.T) {
+	client := &fakeConvergeClient{
+		pods:      []model.Pod{newRunningPod(newConvergePod("changed", "nginx:1"))},
+		quotaFull: "changed",
+		released:  map[string][]string{},
+	}
+	converger := newTestConverger(client)
+
+	_, err := converger.Converge("default", []model.Pod{newConvergePod("changed", "nginx:2"), newConvergePod("added", "nginx:1")}, prepareAsIs)
+	assert.True(t, runtime.IsLimitExceeded(err), "should reject the recreated pod what doesn't fit to the quota")
+	assert.Equal(t, map[string][]string{"added": nil, "changed": {"changed-app"}}, client.released, "should not count the containers what the recreated pod releases")
+	assert.Empty(t, client.created)
+	assert.Empty(t, client.stopped, "should not stop the current containers if the quota is exceeded")
+}
+
+func TestConvergePreparesBeforeChanges(t *testing.T) {
+	client := &fakeConvergeClient{pods: []model.Pod{newRunningPod(newConvergePod("removed", "nginx:1"))}}
+	converger := newTestConverger(client)
+
+	_, err := converger.Converge("default", []model.Pod{newConvergePod("added", "nginx:1")}, func(pod model.Pod, container model.Container) (model.Container, error) {
+		return container, fmt.Errorf("pull failed")
+	})
+	assert.Error(t, err)
+	assert.Empty(t, client.created)
+	assert.Empty(t, client.stopped, "should not change anything if preparing fails")
+}
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	log "github.com/sirupsen/logrus"
+)
+
+// FileWatcher is controller which stops the containers when their watched host files change
+// so that the Lifecycle controller starts them again with the new files
+// The files get polled, the restart happens once the files have stayed unchanged the debounce time
+type FileWatcher struct {
+	client   runtime.Client
+	pods     *PodWatcher
+	interval time.Duration
+	serving  bool
+	pause    *ReconcilePause
+	now      func() time.Time
+	stat     func(string) (os.FileInfo, error)
+	states   map[string]*watchState
+}
+
+// watchState tracks the watched files of one container
+type watchState struct {
+	files     map[string]fileState
+	changedAt time.Time
+}
+
+// fileState is the file modification time and size, zero for missing file
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewFileWatcher creates new FileWatcher controller instance
+// The containers don't get restarted while the pause is active
+func NewFileWatcher(client runtime.Client, pods *PodWatcher, pause *ReconcilePause) *FileWatcher {
+	return &FileWatcher{
+		client:   client,
+		pods:     pods,
+		interval: 1 * time.Second,
+		pause:    pause,
+		now:      time.Now,
+		stat:     os.Stat,
+		states:   map[string]*watchState{},
+	}
+}
+
+// Serve starts the controller to watch the files
+func (w *FileWatcher) Serve() {
+	log.Infof("Start file watcher controller...")
+	w.serving = true
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !w.serving {
+			return
+		}
+
+		w.checkAll()
+	}
+}
+
+// Stop the file watcher running
+func (w *FileWatcher) Stop() {
+	log.Infof("Stop file watcher controller...")
+	w.serving = false
+}
+
+func (w *FileWatcher) checkAll() {
+	namespaces, err := w.pods.List()
+	if err != nil {
+		log.Warnf("File watcher controller cannot check files, error while fetching namespaces: %s", err)
+		return
+	}
+
+	var (
+		seen = map[string]bool{}
+		now  = w.now()
+	)
+	for _, listed := range namespaces {
+		namespace, pods, err := listed.Namespace, listed.Pods, listed.Err
+		if err != nil {
+			log.Warnf("File watcher controller cannot check files, error while fetching pods: %s", err)
+			continue
+		}
+
+		for _, pod := range pods {
+			for _, container := range pod.Spec.Containers {
+				status, ok := findContainerStatus(pod, container.Name)
+				if !ok || container.RestartOnChange == nil {
+					continue
+				}
+
+				key := fmt.Sprintf("%s/%s", namespace, status.ContainerID)
+				seen[key] = true
+				if w.check(key, *container.RestartOnChange, status.State == "running", now) {
+					w.restart(namespace, pod, status)
+				}
+			}
+		}
+	}
+
+	for key := range w.states {
+		if !seen[key] {
+			delete(w.states, key)
+		}
+	}
+}
+
+// check compares the files to the previous check and returns true when the changed files
+// have stayed unchanged the debounce time and the running container should get restarted
+func (w *FileWatcher) check(key string, watch model.FileWatch, running bool, now time.Time) bool {
+	files := w.statFiles(watch.Paths)
+	state, ok := w.states[key]
+	if !ok {
+		w.states[key] = &watchState{files: files}
+		return false
+	}
+
+	changed := !equalFiles(state.files, files)
+	state.files = files
+	if !running {
+		// Stopped container reads the changed files anyway when it gets started again
+		state.changedAt = time.Time{}
+		return false
+	}
+	if changed {
+		log.Debugf("Watched files of container [%s] changed, restart after %s", key, getDebounce(watch))
+		state.changedAt = now
+		return false
+	}
+	if state.changedAt.IsZero() || now.Sub(state.changedAt) < getDebounce(watch) {
+		return false
+	}
+	if w.pause.IsPaused() {
+		log.Debugf("Watched files of container [%s] changed but reconcile is paused, don't restart the container", key)
+		return false
+	}
+	state.changedAt = time.Time{}
+	return true
+}
+
+func (w *FileWatcher) restart(namespace string, pod model.Pod, status model.ContainerStatus) {
+	log.Infof("Watched files of container [%s] changed, restart the container", status.ContainerID)
+	if err := w.client.TerminateContainers(namespace, []string{status.ContainerID}, pod.Spec.StopGracePeriod); err != nil {
+		log.Warnf("File watcher controller failed to stop container [%s]: %s", status.ContainerID, err)
+	}
+}
+
+func (w *FileWatcher) statFiles(paths []string) map[string]fileState {
+	result := map[string]fileState{}
+	for _, path := range paths {
+		info, err := w.stat(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				log.Debugf("Failed to check watched file [%s]: %s", path, err)
+			}
+			result[path] = fileState{}
+			continue
+		}
+		result[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+	return result
+}
+
+func equalFiles(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, state := range a {
+		other, ok := b[path]
+		if !ok || !state.modTime.Equal(other.modTime) || state.size != other.size {
+			return false
+		}
+	}
+	return true
+}
+
+func getDebounce(watch model.FileWatch) time.Duration {
+	if watch.Debounce > 0 {
+		return watch.Debounce
+	}
+	return model.DefaultFileWatchDebounce
+}
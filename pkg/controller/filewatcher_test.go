@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeWatchClient struct {
+	runtime.Client
+	pods       []model.Pod
+	terminated []string
+}
+
+func (c *fakeWatchClient) GetNamespaces() ([]string, error) {
+	return []string{"default"}, nil
+}
+
+func (c *fakeWatchClient) GetPods(namespace string) ([]model.Pod, error) {
+	return c.pods, nil
+}
+
+func (c *fakeWatchClient) TerminateContainers(namespace string, ids []string, gracePeriod time.Duration) error {
+	c.terminated = append(c.terminated, ids...)
+	return nil
+}
+
+type fakeFileInfo struct {
+	os.FileInfo
+	modTime time.Time
+}
+
+func (i fakeFileInfo) ModTime() time.Time { return i.modTime }
+func (i fakeFileInfo) Size() int64        { return 10 }
+
+func newWatchedPod(state string) model.Pod {
+	return model.Pod{
+		Metadata: model.NewMetadata("default", "foo"),
+		Spec: model.PodSpec{
+			Containers: []model.Container{
+				{Name: "bar", RestartOnChange: &model.FileWatch{Paths: []string{"/etc/foo.conf"}, Debounce: 5 * time.Second}},
+			},
+		},
+		Status: model.PodStatus{
+			ContainerStatuses: []model.ContainerStatus{{ContainerID: "foo-bar", Name: "bar", State: state}},
+		},
+	}
+}
+
+func newTestFileWatcher(client runtime.Client) (*FileWatcher, *time.Time, *time.Time) {
+	now := time.Now()
+	modTime := now.Add(-time.Hour)
+	watcher := NewFileWatcher(client, NewPodWatcher(client), nil)
+	watcher.now = func() time.Time { return now }
+	watcher.stat = func(string) (os.FileInfo, error) { return fakeFileInfo{modTime: modTime}, nil }
+	return watcher, &now, &modTime
+}
+
+func TestFileWatcherRestartsAfterDebounce(t *testing.T) {
+	client := &fakeWatchClient{pods: []model.Pod{newWatchedPod("running")}}
+	watcher, now, modTime := newTestFileWatcher(client)
+
+	watcher.checkAll()
+	assert.Empty(t, client.terminated, "should not restart on first check")
+
+	*modTime = *now
+	*now = now.Add(time.Second)
+	watcher.checkAll()
+	assert.Empty(t, client.terminated, "should not restart before debounce")
+
+	*modTime = *now
+	*now = now.Add(4 * time.Second)
+	watcher.checkAll()
+	assert.Empty(t, client.terminated, "should wait debounce after the latest change")
+
+	*now = now.Add(5 * time.Second)
+	watcher.checkAll()
+	assert.Equal(t, []string{"foo-bar"}, client.terminated)
+
+	*now = now.Add(5 * time.Second)
+	watcher.checkAll()
+	assert.Equal(t, []string{"foo-bar"}, client.terminated, "should restart only once per change")
+}
+
+func TestFileWatcherDoesNotRestartStoppedContainer(t *testing.T) {
+	client := &fakeWatchClient{pods: []model.Pod{newWatchedPod("stopped")}}
+	watcher, now, modTime := newTestFileWatcher(client)
+
+	watcher.checkAll()
+	*modTime = *now
+	watcher.checkAll()
+
+	client.pods = []model.Pod{newWatchedPod("running")}
+	*now = now.Add(10 * time.Second)
+	watcher.checkAll()
+	assert.Empty(t, client.terminated, "should not restart container what was stopped when files changed")
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"sync"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+)
+
+// ReconcileHistory keeps the most recent lifecycle controller actions in memory so that
+// operator can find out afterwards why and when container got restarted
+type ReconcileHistory struct {
+	mutex   sync.Mutex
+	records []model.ReconcileRecord
+	next    int
+	full    bool
+}
+
+// NewReconcileHistory creates new ReconcileHistory what keeps at most size records
+func NewReconcileHistory(size int) *ReconcileHistory {
+	if size < 0 {
+		size = 0
+	}
+	return &ReconcileHistory{
+		records: make([]model.ReconcileRecord, size),
+	}
+}
+
+// Add stores the record, overwriting the oldest one when the history is full
+// Does nothing for nil or zero size history
+func (h *ReconcileHistory) Add(record model.ReconcileRecord) {
+	if h == nil || len(h.records) == 0 {
+		return
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.records[h.next] = record
+	h.next = (h.next + 1) % len(h.records)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// List returns the records oldest first
+func (h *ReconcileHistory) List() []model.ReconcileRecord {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.full {
+		return append([]model.ReconcileRecord{}, h.records[:h.next]...)
+	}
+	return append(append([]model.ReconcileRecord{}, h.records[h.next:]...), h.records[:h.next]...)
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func getContainerIDs(records []model.ReconcileRecord) (result []string) {
+	for _, record := range records {
+		result = append(result, record.ContainerID)
+	}
+	return result
+}
+
+func TestReconcileHistoryKeepsMostRecentRecords(t *testing.T) {
+	history := NewReconcileHistory(3)
+	assert.Empty(t, history.List())
+
+	history.Add(model.ReconcileRecord{ContainerID: "a"})
+	history.Add(model.ReconcileRecord{ContainerID: "b"})
+	assert.Equal(t, []string{"a", "b"}, getContainerIDs(history.List()))
+
+	history.Add(model.ReconcileRecord{ContainerID: "c"})
+	history.Add(model.ReconcileRecord{ContainerID: "d"})
+	assert.Equal(t, []string{"b", "c", "d"}, getContainerIDs(history.List()), "should drop the oldest record")
+}
+
+func TestReconcileHistoryWithoutSize(t *testing.T) {
+	var history *ReconcileHistory
+	history.Add(model.ReconcileRecord{ContainerID: "a"})
+
+	history = NewReconcileHistory(0)
+	history.Add(model.ReconcileRecord{ContainerID: "a"})
+	assert.Empty(t, history.List())
+}
@@ -0,0 +1,411 @@
+package controller
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// activationTimeout is how long the activated container has time to start serving the port
+	activationTimeout = 30 * time.Second
+	// activationDialInterval is how often the activator tries to connect to the started container
+	activationDialInterval = 100 * time.Millisecond
+	// tcpEstablished is the ESTABLISHED socket state in /proc/net/tcp
+	tcpEstablished = "01"
+)
+
+// tcpTables are the kernel socket tables where the established connections get counted from
+var tcpTables = []string{"/proc/net/tcp", "/proc/net/tcp6"}
+
+// IdleStopper is controller which stops the on-demand containers when they have been idle the
+// idle stop timeout and starts them again when a connection arrives to the container port
+// While the container is stopped the controller listens the port and the first connection starts
+// the container and gets proxied to it, the following connections go directly to the container
+// The Lifecycle controller leaves the idle stop containers to this controller
+type IdleStopper struct {
+	client      runtime.Client
+	pods        *PodWatcher
+	interval    time.Duration
+	serving     bool
+	pause       *ReconcilePause
+	maintenance *Maintenance
+	history     *ReconcileHistory
+	now         func() time.Time
+	connections func(port int) (int, error)
+	listen      func(port int) (net.Listener, error)
+	newIOSet    func(id string) (*runtime.IOSet, error)
+	states      map[string]*idleState
+
+	mutex      sync.Mutex
+	activators map[string]net.Listener
+}
+
+// idleState tracks when one running container was active the last time
+type idleState struct {
+	activeAt time.Time
+	cpuUsage uint64
+	cpuAt    time.Time
+}
+
+// NewIdleStopper creates new IdleStopper controller instance
+// The containers don't get stopped or activated while the pause is active, the connections during
+// the maintenance mode don't activate the containers, the stops and the activations get recorded to the history
+func NewIdleStopper(client runtime.Client, pods *PodWatcher, pause *ReconcilePause, maintenance *Maintenance, history *ReconcileHistory) *IdleStopper {
+	return &IdleStopper{
+		client:      client,
+		pods:        pods,
+		interval:    5 * time.Second,
+		pause:       pause,
+		maintenance: maintenance,
+		history:     history,
+		now:         time.Now,
+		connections: countConnections,
+		listen:      listenPort,
+		newIOSet:    runtime.NewIOSet,
+		states:      map[string]*idleState{},
+		activators:  map[string]net.Listener{},
+	}
+}
+
+// Serve starts the controller to monitor the idle containers
+func (s *IdleStopper) Serve() {
+	log.Infof("Start idle stopper controller...")
+	s.serving = true
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !s.serving {
+			return
+		}
+
+		s.checkAll()
+	}
+}
+
+// Stop the idle stopper running and closes the activation listeners
+func (s *IdleStopper) Stop() {
+	log.Infof("Stop idle stopper controller...")
+	s.serving = false
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for key, listener := range s.activators {
+		listener.Close()
+		delete(s.activators, key)
+	}
+}
+
+func (s *IdleStopper) checkAll() {
+	namespaces, err := s.pods.List()
+	if err != nil {
+		log.Warnf("Idle stopper controller cannot check containers, error while fetching namespaces: %s", err)
+		return
+	}
+
+	var (
+		seen = map[string]bool{}
+		now  = s.now()
+	)
+	for _, listed := range namespaces {
+		namespace, pods, err := listed.Namespace, listed.Pods, listed.Err
+		if err != nil {
+			log.Warnf("Idle stopper controller cannot check containers, error while fetching pods: %s", err)
+			continue
+		}
+
+		for _, pod := range pods {
+			for _, container := range pod.Spec.Containers {
+				status, ok := findContainerStatus(pod, container.Name)
+				if !ok || container.IdleStop == nil {
+					continue
+				}
+
+				key := fmt.Sprintf("%s/%s", namespace, status.ContainerID)
+				seen[key] = true
+				if status.State == "running" {
+					s.closeActivator(key)
+					if s.isIdle(key, namespace, status.ContainerID, *container.IdleStop, now) {
+						s.stop(namespace, pod, status, now)
+					}
+					continue
+				}
+
+				delete(s.states, key)
+				if !s.pause.IsPaused() {
+					s.startActivator(key, namespace, pod, status, *container.IdleStop)
+				}
+			}
+		}
+	}
+
+	for key := range s.states {
+		if !seen[key] {
+			delete(s.states, key)
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for key, listener := range s.activators {
+		if !seen[key] {
+			listener.Close()
+			delete(s.activators, key)
+		}
+	}
+}
+
+// isIdle updates the container activity and returns true when the container has been idle the timeout
+func (s *IdleStopper) isIdle(key, namespace, containerID string, idleStop model.IdleStop, now time.Time) bool {
+	state, ok := s.states[key]
+	if !ok {
+		state = &idleState{activeAt: now}
+		s.states[key] = state
+	}
+
+	active, err := s.isActive(state, namespace, containerID, idleStop)
+	if err != nil {
+		log.Debugf("Failed to check container [%s] activity, consider it active: %s", containerID, err)
+		active = true
+	}
+	if active {
+		state.activeAt = now
+		return false
+	}
+	if now.Sub(state.activeAt) < idleStop.Timeout {
+		return false
+	}
+	if s.pause.IsPaused() {
+		log.Debugf("Container [%s] is idle but reconcile is paused, don't stop the container", key)
+		return false
+	}
+	return true
+}
+
+// isActive returns true if the container shows activity by the idle stop signal
+func (s *IdleStopper) isActive(state *idleState, namespace, containerID string, idleStop model.IdleStop) (bool, error) {
+	if idleStop.Signal == model.IdleSignalCPU {
+		metric, err := s.client.GetContainerMetric(namespace, containerID)
+		if err != nil {
+			return false, err
+		}
+		previous, previousAt := state.cpuUsage, state.cpuAt
+		state.cpuUsage, state.cpuAt = metric.CPUUsage, metric.Timestamp
+		if previousAt.IsZero() || !metric.Timestamp.After(previousAt) || metric.CPUUsage < previous {
+			// Need two samples to tell the usage
+			return true, nil
+		}
+		millicores := float64(metric.CPUUsage-previous) / float64(metric.Timestamp.Sub(previousAt)) * 1000
+		return millicores >= model.IdleCPUThreshold, nil
+	}
+
+	count, err := s.connections(idleStop.Port)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (s *IdleStopper) stop(namespace string, pod model.Pod, status model.ContainerStatus, now time.Time) {
+	log.Infof("Container [%s] has been idle, stop the container", status.ContainerID)
+	record := model.ReconcileRecord{
+		Time:        now,
+		Namespace:   namespace,
+		Pod:         pod.Metadata.Name,
+		ContainerID: status.ContainerID,
+		Action:      model.ReconcileIdleStopped,
+	}
+	if err := s.client.TerminateContainers(namespace, []string{status.ContainerID}, pod.Spec.StopGracePeriod); err != nil {
+		log.Warnf("Idle stopper controller failed to stop container [%s]: %s", status.ContainerID, err)
+		record.Action = model.ReconcileFailed
+		record.Error = err.Error()
+	}
+	s.history.Add(record)
+	delete(s.states, fmt.Sprintf("%s/%s", namespace, status.ContainerID))
+}
+
+// startActivator starts listening the container port if not listening already
+func (s *IdleStopper) startActivator(key, namespace string, pod model.Pod, status model.ContainerStatus, idleStop model.IdleStop) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.activators[key]; ok {
+		return
+	}
+
+	listener, err := s.listen(idleStop.Port)
+	if err != nil {
+		log.Warnf("Idle stopper controller cannot listen port %d for container [%s]: %s", idleStop.Port, status.ContainerID, err)
+		return
+	}
+	log.Debugf("Container [%s] is stopped, start it on the next connection to port %d", status.ContainerID, idleStop.Port)
+	s.activators[key] = listener
+	go s.activate(key, listener, namespace, pod, status, idleStop.Port)
+}
+
+func (s *IdleStopper) closeActivator(key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if listener, ok := s.activators[key]; ok {
+		listener.Close()
+		delete(s.activators, key)
+	}
+}
+
+// activate waits the first connection, starts the container and proxies the connection to it
+func (s *IdleStopper) activate(key string, listener net.Listener, namespace string, pod model.Pod, status model.ContainerStatus, port int) {
+	conn, err := listener.Accept()
+	// The container cannot bind the port while the activator listens it
+	listener.Close()
+	if err != nil {
+		// Closed because the container got started or removed
+		return
+	}
+	defer conn.Close()
+
+	log.Infof("Connection to port %d, start idle container [%s]", port, status.ContainerID)
+	record := model.ReconcileRecord{
+		Time:        s.now(),
+		Namespace:   namespace,
+		Pod:         pod.Metadata.Name,
+		ContainerID: status.ContainerID,
+		Action:      model.ReconcileActivated,
+	}
+	err = s.maintenance.Check()
+	if err == nil {
+		err = s.start(namespace, pod, status)
+	}
+	if err != nil {
+		log.Warnf("Idle stopper controller failed to start container [%s]: %s", status.ContainerID, err)
+		record.Action = model.ReconcileFailed
+		record.Error = err.Error()
+		s.history.Add(record)
+		// Listen again on the next check
+		s.removeActivator(key, listener)
+		return
+	}
+	s.history.Add(record)
+
+	target, err := dialUntil(fmt.Sprintf("127.0.0.1:%d", port), activationTimeout)
+	if err != nil {
+		log.Warnf("Activated container [%s] didn't start serving port %d: %s", status.ContainerID, port, err)
+		return
+	}
+	defer target.Close()
+	proxy(conn, target)
+}
+
+func (s *IdleStopper) start(namespace string, pod model.Pod, status model.ContainerStatus) error {
+	ioset, err := s.newIOSet(fmt.Sprintf("%s.%s", pod.Metadata.Name, status.Name))
+	if err != nil {
+		return err
+	}
+	_, err = s.client.StartContainer(namespace, status.ContainerID, *ioset)
+	return err
+}
+
+// removeActivator removes the activator unless it has been replaced already
+func (s *IdleStopper) removeActivator(key string, listener net.Listener) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.activators[key] == listener {
+		delete(s.activators, key)
+	}
+}
+
+// getActivator returns the listener what waits the connections to the container, nil if not listening
+func (s *IdleStopper) getActivator(key string) net.Listener {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.activators[key]
+}
+
+// isIdleStop returns true if the pod container gets stopped when idle
+func isIdleStop(pod model.Pod, name string) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return container.IdleStop != nil
+		}
+	}
+	return false
+}
+
+func listenPort(port int) (net.Listener, error) {
+	return net.Listen("tcp", fmt.Sprintf(":%d", port))
+}
+
+// dialUntil connects to the address, retrying until the timeout passes
+func dialUntil(address string, timeout time.Duration) (net.Conn, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		conn, err := net.DialTimeout("tcp", address, activationDialInterval)
+		if err == nil || time.Now().After(deadline) {
+			return conn, err
+		}
+		time.Sleep(activationDialInterval)
+	}
+}
+
+// proxy copies the data in both directions until either side closes the connection
+func proxy(a, b net.Conn) {
+	done := make(chan struct{}, 2)
+	pipe := func(dst, src net.Conn) {
+		io.Copy(dst, src)
+		done <- struct{}{}
+	}
+	go pipe(a, b)
+	go pipe(b, a)
+	<-done
+}
+
+// countConnections returns the number of established TCP connections to the local port
+func countConnections(port int) (count int, err error) {
+	for _, table := range tcpTables {
+		file, err := os.Open(table)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return 0, err
+		}
+		n, err := parseEstablished(file, port)
+		file.Close()
+		if err != nil {
+			return 0, err
+		}
+		count += n
+	}
+	return count, nil
+}
+
+// parseEstablished counts the established connections to the local port from /proc/net/tcp formatted table
+func parseEstablished(r io.Reader, port int) (count int, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || fields[3] != tcpEstablished {
+			continue
+		}
+		parts := strings.Split(fields[1], ":")
+		local, err := strconv.ParseUint(parts[len(parts)-1], 16, 16)
+		if err != nil {
+			continue
+		}
+		if int(local) == port {
+			count++
+		}
+	}
+	return count, scanner.Err()
+}
@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeIdleClient struct {
+	runtime.Client
+	pods       []model.Pod
+	metric     model.ContainerMetrics
+	terminated []string
+	started    chan string
+	serve      func()
+}
+
+func (c *fakeIdleClient) GetNamespaces() ([]string, error) {
+	return []string{"default"}, nil
+}
+
+func (c *fakeIdleClient) GetPods(namespace string) ([]model.Pod, error) {
+	return c.pods, nil
+}
+
+func (c *fakeIdleClient) GetContainerMetric(namespace, containerID string) (model.ContainerMetrics, error) {
+	return c.metric, nil
+}
+
+func (c *fakeIdleClient) TerminateContainers(namespace string, ids []string, gracePeriod time.Duration) error {
+	c.terminated = append(c.terminated, ids...)
+	return nil
+}
+
+func (c *fakeIdleClient) StartContainer(namespace, id string, io runtime.IOSet) (model.ContainerStatus, error) {
+	if c.serve != nil {
+		c.serve()
+	}
+	c.started <- id
+	return model.ContainerStatus{ContainerID: id, State: "running"}, nil
+}
+
+func newIdlePod(state string, idleStop model.IdleStop) model.Pod {
+	return model.Pod{
+		Metadata: model.NewMetadata("default", "foo"),
+		Spec: model.PodSpec{
+			Containers: []model.Container{{Name: "bar", IdleStop: &idleStop}},
+		},
+		Status: model.PodStatus{
+			ContainerStatuses: []model.ContainerStatus{{ContainerID: "foo-bar", Name: "bar", State: state}},
+		},
+	}
+}
+
+func newTestIdleStopper(client runtime.Client, connections *int) (*IdleStopper, *time.Time) {
+	now := time.Now()
+	stopper := NewIdleStopper(client, NewPodWatcher(client), nil, nil, NewReconcileHistory(10))
+	stopper.now = func() time.Time { return now }
+	stopper.connections = func(int) (int, error) { return *connections, nil }
+	stopper.listen = func(int) (net.Listener, error) { return net.Listen("tcp", "127.0.0.1:0") }
+	stopper.newIOSet = func(string) (*runtime.IOSet, error) { return &runtime.IOSet{}, nil }
+	return stopper, &now
+}
+
+func TestIdleStopperStopsAfterTimeoutWithoutConnections(t *testing.T) {
+	connections := 1
+	client := &fakeIdleClient{pods: []model.Pod{newIdlePod("running", model.IdleStop{Timeout: time.Minute, Port: 8080})}}
+	stopper, now := newTestIdleStopper(client, &connections)
+
+	stopper.checkAll()
+	*now = now.Add(2 * time.Minute)
+	stopper.checkAll()
+	assert.Empty(t, client.terminated, "should not stop while there's connections")
+
+	connections = 0
+	*now = now.Add(30 * time.Second)
+	stopper.checkAll()
+	assert.Empty(t, client.terminated, "should not stop before the timeout")
+
+	*now = now.Add(time.Minute)
+	stopper.checkAll()
+	assert.Equal(t, []string{"foo-bar"}, client.terminated)
+	assert.Equal(t, model.ReconcileIdleStopped, stopper.history.List()[0].Action)
+}
+
+func TestIdleStopperStopsAfterTimeoutWithoutCPUUsage(t *testing.T) {
+	connections := 0
+	client := &fakeIdleClient{pods: []model.Pod{newIdlePod("running", model.IdleStop{Timeout: time.Minute, Port: 8080, Signal: model.IdleSignalCPU})}}
+	stopper, now := newTestIdleStopper(client, &connections)
+
+	sample := func(millicores uint64, elapsed time.Duration) {
+		*now = now.Add(elapsed)
+		client.metric.Timestamp = *now
+		client.metric.CPUUsage += millicores * uint64(elapsed) / 1000
+		stopper.checkAll()
+	}
+
+	sample(0, 0)
+	sample(500, 2*time.Minute)
+	assert.Empty(t, client.terminated, "should not stop while the container uses CPU")
+
+	sample(1, 30*time.Second)
+	assert.Empty(t, client.terminated, "should not stop before the timeout")
+
+	sample(1, time.Minute)
+	assert.Equal(t, []string{"foo-bar"}, client.terminated)
+}
+
+func TestIdleStopperActivatesStoppedContainer(t *testing.T) {
+	backend, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer backend.Close()
+	port := backend.Addr().(*net.TCPAddr).Port
+
+	connections := 0
+	client := &fakeIdleClient{
+		pods:    []model.Pod{newIdlePod("stopped", model.IdleStop{Timeout: time.Minute, Port: port})},
+		started: make(chan string, 1),
+		serve: func() {
+			go func() {
+				conn, err := backend.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				line, _ := bufio.NewReader(conn).ReadString('\n')
+				fmt.Fprintf(conn, "echo %s", line)
+			}()
+		},
+	}
+	stopper, _ := newTestIdleStopper(client, &connections)
+	defer stopper.Stop()
+
+	stopper.checkAll()
+	listener := stopper.getActivator("default/foo-bar")
+	if assert.NotNil(t, listener, "should listen the port while the container is stopped") {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		select {
+		case id := <-client.started:
+			assert.Equal(t, "foo-bar", id)
+		case <-time.After(5 * time.Second):
+			t.Fatal("should start the container on connection")
+		}
+
+		fmt.Fprintln(conn, "hello")
+		response, _ := bufio.NewReader(conn).ReadString('\n')
+		assert.Equal(t, "echo hello\n", response, "should proxy the first connection to the container")
+	}
+
+	client.pods = []model.Pod{newIdlePod("running", model.IdleStop{Timeout: time.Minute, Port: port})}
+	stopper.checkAll()
+	assert.Nil(t, stopper.getActivator("default/foo-bar"), "should stop listening once the container runs")
+}
+
+func TestIdleStopperDoesNotActivateInMaintenance(t *testing.T) {
+	connections := 0
+	client := &fakeIdleClient{
+		pods:    []model.Pod{newIdlePod("stopped", model.IdleStop{Timeout: time.Minute, Port: 8080})},
+		started: make(chan string, 1),
+	}
+	stopper, _ := newTestIdleStopper(client, &connections)
+	stopper.maintenance = NewMaintenance()
+	stopper.maintenance.Set(true, "OS upgrade")
+	defer stopper.Stop()
+
+	stopper.checkAll()
+	listener := stopper.getActivator("default/foo-bar")
+	if assert.NotNil(t, listener, "should listen the port while the container is stopped") {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		_, err = bufio.NewReader(conn).ReadString('\n')
+		assert.Error(t, err, "should close the connection")
+	}
+	assert.Empty(t, client.started, "should not start the container in maintenance")
+
+	records := stopper.history.List()
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, model.ReconcileFailed, records[0].Action)
+		assert.Contains(t, records[0].Error, "OS upgrade")
+	}
+}
+
+func TestParseEstablished(t *testing.T) {
+	table := `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:1F90 0100007F:D2F4 01 00000000:00000000 00:00000000 00000000     0        0 2 1 0000000000000000 20 4 30 10 -1
+   2: 0100007F:D2F4 0100007F:1F90 01 00000000:00000000 00:00000000 00000000     0        0 3 1 0000000000000000 20 4 30 10 -1
+   3: 0100007F:1F90 0100007F:D2F6 06 00000000:00000000 03:00000000 00000000     0        0 0 3 0000000000000000
+`
+	count, err := parseEstablished(strings.NewReader(table), 8080)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count, "should count only established connections to the local port")
+}
@@ -6,23 +6,59 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/ernoaapa/eliot/pkg/model"
 	"github.com/ernoaapa/eliot/pkg/runtime"
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	// restartBackoffInitial is the delay before the second restart, the first restart happens immediately
+	restartBackoffInitial = 10 * time.Second
+	// restartBackoffMax caps the exponentially growing restart delay
+	restartBackoffMax = 5 * time.Minute
+)
+
 // Lifecycle is controller which monitors containers and if container stops,
 // restart it based on restart policy
+// Repeatedly crashing container gets restarted with exponentially growing delay
+// The scheduled containers are left to the Scheduler controller and the idle stop containers to the IdleStopper controller
 type Lifecycle struct {
-	client   runtime.Client
-	interval time.Duration
-	serving  bool
+	client    runtime.Client
+	pods      *PodWatcher
+	interval  time.Duration
+	serving   bool
+	pause     *ReconcilePause
+	history   *ReconcileHistory
+	readiness *Readiness
+	now       func() time.Time
+	backoffs  map[string]*restartBackoff
+	// backoffReset is how long the container must run after restart before the restart delay resets
+	backoffReset time.Duration
+}
+
+// restartBackoff tracks the restart delay of one container
+type restartBackoff struct {
+	delay        time.Duration
+	nextRestart  time.Time
+	runningSince time.Time
 }
 
 // NewLifecycle creates new Lifecycle controller instance
-func NewLifecycle(client runtime.Client) *Lifecycle {
+// The controller doesn't restart containers while the pause is active and resets
+// the container restart delay once the container has been running the backoff reset time
+// The restarts and failed restart attempts get recorded to the history and the readiness
+// gets set once all the containers are running and ready
+func NewLifecycle(client runtime.Client, pods *PodWatcher, pause *ReconcilePause, history *ReconcileHistory, readiness *Readiness, backoffReset time.Duration) *Lifecycle {
 	return &Lifecycle{
-		client:   client,
-		interval: 5 * time.Second,
+		client:       client,
+		pods:         pods,
+		interval:     5 * time.Second,
+		pause:        pause,
+		history:      history,
+		readiness:    readiness,
+		now:          time.Now,
+		backoffs:     map[string]*restartBackoff{},
+		backoffReset: backoffReset,
 	}
 }
 
@@ -40,6 +76,11 @@ func (l *Lifecycle) Serve() {
 			return
 		}
 
+		if l.pause.IsPaused() {
+			log.Debugf("Lifecycle controller paused, skip checking containers")
+			continue
+		}
+
 		err := l.checkAll()
 		if err != nil {
 			log.Panicf("Lifecycle controller stopped with fatal error: %s", err)
@@ -54,36 +95,121 @@ func (l *Lifecycle) Stop() {
 }
 
 func (l *Lifecycle) checkAll() error {
-	namespaces, err := l.client.GetNamespaces()
+	namespaces, err := l.pods.List()
 	if err != nil {
 		log.Warnf("Lifecycle controller cannot validate container statuses, error while fetching namespaces: %s", err)
 		return nil
 	}
 
-	for _, namespace := range namespaces {
-		pods, err := l.client.GetPods(namespace)
+	var (
+		seen = map[string]bool{}
+		now  = l.now()
+		// converged is true if all the containers were checked and are running and ready
+		converged = true
+	)
+	for _, listed := range namespaces {
+		namespace, pods, err := listed.Namespace, listed.Pods, listed.Err
 		if err != nil {
 			log.Warnf("Lifecycle controller cannot validate container statuses, error while fetching pods: %s", err)
+			converged = false
 			continue
 		}
 
 		for _, pod := range pods {
-			for _, status := range pod.Status.ContainerStatuses {
+			// Restart in the start priority order so that e.g. logging agent comes up before the app
+			for _, status := range pod.SortByStartPriority(pod.Status.ContainerStatuses) {
+				if isScheduled(pod, status.Name) || isIdleStop(pod, status.Name) || pod.Spec.RestartPolicy == model.RestartPolicyNever {
+					continue
+				}
+
+				key := fmt.Sprintf("%s/%s", namespace, status.ContainerID)
+				seen[key] = true
+				backoff, hasBackoff := l.backoffs[key]
+				if !status.Ready {
+					converged = false
+				}
+
+				if status.State == "running" {
+					if hasBackoff && backoff.running(now, l.backoffReset) {
+						log.Debugf("Container [%s] has been running %s, reset restart backoff", status.ContainerID, l.backoffReset)
+						delete(l.backoffs, key)
+					}
+					continue
+				}
+
 				if status.State == "stopped" || status.State == "unknown" && pod.Spec.RestartPolicy == "always" {
+					if !hasBackoff {
+						backoff = &restartBackoff{}
+						l.backoffs[key] = backoff
+					}
+					backoff.runningSince = time.Time{}
+					if !backoff.canRestart(now) {
+						log.Debugf("Container [%s] in namespace [%s] keeps stopping, restart delayed until %s", status.ContainerID, namespace, backoff.nextRestart.Format(time.RFC3339))
+						continue
+					}
+					backoff.restarted(now)
+
 					log.Debugf("Detected [%s] container [%s] in namespace [%s] with 'always' restart policy", status.State, status.ContainerID, pod.Metadata.Name)
 					ioset, err := runtime.NewIOSet(fmt.Sprintf("%s.%s", pod.Metadata.Name, status.Name))
 					if err != nil {
 						return errors.Wrapf(err, "Error while creating container ioset, cannot run lifecycle controller")
 					}
+					record := model.ReconcileRecord{
+						Time:        now,
+						Namespace:   namespace,
+						Pod:         pod.Metadata.Name,
+						ContainerID: status.ContainerID,
+						Action:      model.ReconcileRestarted,
+					}
 					status, err := l.client.StartContainer(namespace, status.ContainerID, *ioset)
 					if err != nil {
 						log.Warnf("Lifecycle controller failed to start container: %s", err)
+						record.Action = model.ReconcileFailed
+						record.Error = err.Error()
+						l.history.Add(record)
 						continue
 					}
+					l.history.Add(record)
 					log.Debugf("Restarted container [%s] in namespace [%s]", status.ContainerID, pod.Metadata.Name)
 				}
 			}
 		}
 	}
+
+	for key := range l.backoffs {
+		if !seen[key] {
+			delete(l.backoffs, key)
+		}
+	}
+
+	if converged {
+		l.readiness.SetReady(now)
+	}
 	return nil
 }
+
+// canRestart returns true when the delay since the previous restart has passed
+func (b *restartBackoff) canRestart(now time.Time) bool {
+	return !now.Before(b.nextRestart)
+}
+
+// restarted records the restart and doubles the delay before the next restart
+func (b *restartBackoff) restarted(now time.Time) {
+	switch {
+	case b.delay == 0:
+		b.delay = restartBackoffInitial
+	case b.delay*2 > restartBackoffMax:
+		b.delay = restartBackoffMax
+	default:
+		b.delay *= 2
+	}
+	b.nextRestart = now.Add(b.delay)
+}
+
+// running records that the container runs and returns true once it has been running the reset time
+func (b *restartBackoff) running(now time.Time, reset time.Duration) bool {
+	if b.runningSince.IsZero() {
+		b.runningSince = now
+	}
+	return now.Sub(b.runningSince) >= reset
+}
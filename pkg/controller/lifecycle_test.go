@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeLifecycleClient struct {
+	runtime.Client
+	pods []model.Pod
+}
+
+func (c *fakeLifecycleClient) GetNamespaces() ([]string, error) {
+	return []string{"default"}, nil
+}
+
+func (c *fakeLifecycleClient) GetPods(namespace string) ([]model.Pod, error) {
+	return c.pods, nil
+}
+
+func TestRestartBackoffGrowsExponentially(t *testing.T) {
+	now := time.Now()
+	backoff := &restartBackoff{}
+
+	assert.True(t, backoff.canRestart(now), "first restart should happen immediately")
+	backoff.restarted(now)
+	assert.False(t, backoff.canRestart(now.Add(5*time.Second)))
+	assert.True(t, backoff.canRestart(now.Add(restartBackoffInitial)))
+
+	backoff.restarted(now)
+	assert.Equal(t, 2*restartBackoffInitial, backoff.delay)
+
+	for i := 0; i < 10; i++ {
+		backoff.restarted(now)
+	}
+	assert.Equal(t, restartBackoffMax, backoff.delay, "should not grow over the max delay")
+}
+
+func TestLifecycleResetsBackoffAfterRunningResetTime(t *testing.T) {
+	now := time.Now()
+	client := &fakeLifecycleClient{
+		pods: []model.Pod{{
+			Metadata: model.NewMetadata("default", "foo"),
+			Status: model.PodStatus{
+				ContainerStatuses: []model.ContainerStatus{{ContainerID: "foo-bar", Name: "bar", State: "running"}},
+			},
+		}},
+	}
+	lifecycle := NewLifecycle(client, NewPodWatcher(client), nil, nil, nil, 10*time.Minute)
+	lifecycle.now = func() time.Time { return now }
+	backoff := &restartBackoff{delay: restartBackoffMax, nextRestart: now.Add(restartBackoffMax)}
+	lifecycle.backoffs["default/foo-bar"] = backoff
+
+	assert.NoError(t, lifecycle.checkAll())
+	assert.Contains(t, lifecycle.backoffs, "default/foo-bar", "should not reset until the container has run the reset time")
+
+	now = now.Add(10 * time.Minute)
+	assert.NoError(t, lifecycle.checkAll())
+	assert.NotContains(t, lifecycle.backoffs, "default/foo-bar", "should reset after the container has run the reset time")
+}
+
+func TestLifecycleDelaysRestart(t *testing.T) {
+	now := time.Now()
+	client := &fakeLifecycleClient{
+		pods: []model.Pod{{
+			Metadata: model.NewMetadata("default", "foo"),
+			Status: model.PodStatus{
+				ContainerStatuses: []model.ContainerStatus{{ContainerID: "foo-bar", Name: "bar", State: "stopped"}},
+			},
+		}},
+	}
+	lifecycle := NewLifecycle(client, NewPodWatcher(client), nil, nil, nil, 10*time.Minute)
+	lifecycle.now = func() time.Time { return now }
+	lifecycle.backoffs["default/foo-bar"] = &restartBackoff{delay: restartBackoffInitial, nextRestart: now.Add(restartBackoffInitial)}
+
+	// The fake client doesn't implement StartContainer, so restart attempt would panic
+	assert.NoError(t, lifecycle.checkAll())
+}
+
+func TestLifecycleDoesNotRestartNeverRestartPolicy(t *testing.T) {
+	client := &fakeLifecycleClient{
+		pods: []model.Pod{{
+			Metadata: model.NewMetadata("default", "foo"),
+			Spec:     model.PodSpec{RestartPolicy: model.RestartPolicyNever},
+			Status: model.PodStatus{
+				ContainerStatuses: []model.ContainerStatus{{ContainerID: "foo-bar", Name: "bar", State: "stopped"}},
+			},
+		}},
+	}
+	lifecycle := NewLifecycle(client, NewPodWatcher(client), nil, nil, nil, 10*time.Minute)
+
+	// The fake client doesn't implement StartContainer, so restart attempt would panic
+	assert.NoError(t, lifecycle.checkAll())
+}
+
+func TestLifecycleSetsReadyWhenAllContainersReady(t *testing.T) {
+	pod := func(statuses ...model.ContainerStatus) []model.Pod {
+		return []model.Pod{{
+			Metadata: model.NewMetadata("default", "foo"),
+			Spec:     model.PodSpec{RestartPolicy: "always"},
+			Status:   model.PodStatus{ContainerStatuses: statuses},
+		}}
+	}
+	client := &fakeLifecycleClient{pods: pod(
+		model.ContainerStatus{ContainerID: "foo-bar", Name: "bar", State: "running", Ready: true},
+		model.ContainerStatus{ContainerID: "foo-baz", Name: "baz", State: "running"},
+	)}
+	readiness := NewReadiness()
+	lifecycle := NewLifecycle(client, NewPodWatcher(client), nil, nil, readiness, 10*time.Minute)
+
+	assert.NoError(t, lifecycle.checkAll())
+	assert.False(t, readiness.IsReady(), "should not be ready while some container is not ready")
+
+	client.pods = pod(
+		model.ContainerStatus{ContainerID: "foo-bar", Name: "bar", State: "running", Ready: true},
+		model.ContainerStatus{ContainerID: "foo-baz", Name: "baz", State: "running", Ready: true},
+	)
+	assert.NoError(t, lifecycle.checkAll())
+	assert.True(t, readiness.IsReady())
+
+	client.pods = pod(model.ContainerStatus{ContainerID: "foo-bar", Name: "bar", State: "running"})
+	assert.NoError(t, lifecycle.checkAll())
+	assert.True(t, readiness.IsReady(), "should stay ready after the first successful reconcile")
+}
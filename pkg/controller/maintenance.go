@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultMaintenanceReason is the reason when the maintenance mode gets enabled without one
+const DefaultMaintenanceReason = "Node is in maintenance"
+
+// Maintenance holds the node maintenance mode state what the API and the controllers share
+// While enabled the API rejects new workloads and the controllers don't start the scheduled or the idle containers
+type Maintenance struct {
+	mutex sync.RWMutex
+	state model.Maintenance
+}
+
+// NewMaintenance creates new Maintenance what is not enabled
+func NewMaintenance() *Maintenance {
+	return &Maintenance{}
+}
+
+// Set enables or disables the maintenance mode and returns the new state
+func (m *Maintenance) Set(enabled bool, reason string) model.Maintenance {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if !enabled {
+		if m.state.Enabled {
+			log.Infof("Maintenance mode disabled, accepting new workloads")
+		}
+		m.state = model.Maintenance{}
+		return m.state
+	}
+
+	if reason == "" {
+		reason = DefaultMaintenanceReason
+	}
+	if !m.state.Enabled {
+		m.state.Since = time.Now()
+	}
+	m.state.Enabled = true
+	m.state.Reason = reason
+	log.Infof("Maintenance mode enabled, rejecting new workloads: %s", reason)
+	return m.state
+}
+
+// Get returns the maintenance mode state, never enabled for nil maintenance
+func (m *Maintenance) Get() model.Maintenance {
+	if m == nil {
+		return model.Maintenance{}
+	}
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.state
+}
+
+// Check returns error with the reason if the maintenance mode is enabled
+func (m *Maintenance) Check() error {
+	state := m.Get()
+	if state.Enabled {
+		return fmt.Errorf("Node is in maintenance mode since %s, not accepting new workloads: %s", state.Since.Format(time.RFC3339), state.Reason)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// routeTablePath is the kernel IPv4 routing table
+	routeTablePath = "/proc/net/route"
+	// netClassPath is where the network interface states are
+	netClassPath = "/sys/class/net"
+)
+
+// rtfUp is the route flag for usable route
+const rtfUp = 0x1
+
+// NetworkWatcher is controller which stops the containers what have RestartOnNetworkRecovery when the host
+// network comes back online so that the Lifecycle controller starts them again with the working network
+// The network is online when there's default route through interface what is up, the restart happens once
+// the network has stayed online the settle time, e.g. so that DHCP client has time to configure DNS
+type NetworkWatcher struct {
+	client      runtime.Client
+	pods        *PodWatcher
+	interval    time.Duration
+	settle      time.Duration
+	serving     bool
+	pause       *ReconcilePause
+	now         func() time.Time
+	online      func() (bool, error)
+	wasOffline  bool
+	onlineSince time.Time
+}
+
+// NewNetworkWatcher creates new NetworkWatcher controller instance
+// The containers don't get restarted while the pause is active
+func NewNetworkWatcher(client runtime.Client, pods *PodWatcher, pause *ReconcilePause) *NetworkWatcher {
+	return &NetworkWatcher{
+		client:   client,
+		pods:     pods,
+		interval: 2 * time.Second,
+		settle:   5 * time.Second,
+		pause:    pause,
+		now:      time.Now,
+		online:   hasDefaultRoute,
+	}
+}
+
+// Serve starts the controller to watch the network state
+func (w *NetworkWatcher) Serve() {
+	log.Infof("Start network watcher controller...")
+	w.serving = true
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !w.serving {
+			return
+		}
+
+		if w.check(w.now()) {
+			w.restartAll()
+		}
+	}
+}
+
+// Stop the network watcher running
+func (w *NetworkWatcher) Stop() {
+	log.Infof("Stop network watcher controller...")
+	w.serving = false
+}
+
+// check returns true when the network has come back online and stayed online the settle time
+// The network state when eliotd starts is the initial state, i.e. starting online is not recovery
+func (w *NetworkWatcher) check(now time.Time) bool {
+	online, err := w.online()
+	if err != nil {
+		log.Debugf("Network watcher controller cannot check network state: %s", err)
+		return false
+	}
+
+	if !online {
+		if !w.wasOffline {
+			log.Infof("Host network is offline")
+		}
+		w.wasOffline = true
+		w.onlineSince = time.Time{}
+		return false
+	}
+	if !w.wasOffline {
+		return false
+	}
+	if w.onlineSince.IsZero() {
+		log.Infof("Host network is back online, restart the containers after %s", w.settle)
+		w.onlineSince = now
+	}
+	if now.Sub(w.onlineSince) < w.settle {
+		return false
+	}
+	if w.pause.IsPaused() {
+		log.Debugf("Host network is back online but reconcile is paused, don't restart the containers")
+		return false
+	}
+	w.wasOffline = false
+	w.onlineSince = time.Time{}
+	return true
+}
+
+func (w *NetworkWatcher) restartAll() {
+	namespaces, err := w.pods.List()
+	if err != nil {
+		log.Warnf("Network watcher controller cannot restart containers, error while fetching namespaces: %s", err)
+		return
+	}
+
+	for _, listed := range namespaces {
+		namespace, pods, err := listed.Namespace, listed.Pods, listed.Err
+		if err != nil {
+			log.Warnf("Network watcher controller cannot restart containers, error while fetching pods: %s", err)
+			continue
+		}
+
+		for _, pod := range pods {
+			for _, container := range pod.Spec.Containers {
+				status, ok := findContainerStatus(pod, container.Name)
+				if !ok || !container.RestartOnNetworkRecovery || status.State != "running" {
+					continue
+				}
+				w.restart(namespace, pod, status)
+			}
+		}
+	}
+}
+
+func (w *NetworkWatcher) restart(namespace string, pod model.Pod, status model.ContainerStatus) {
+	log.Infof("Host network recovered, restart the container [%s]", status.ContainerID)
+	if err := w.client.TerminateContainers(namespace, []string{status.ContainerID}, pod.Spec.StopGracePeriod); err != nil {
+		log.Warnf("Network watcher controller failed to stop container [%s]: %s", status.ContainerID, err)
+	}
+}
+
+// hasDefaultRoute returns true if the routing table has usable default route through interface what is up
+func hasDefaultRoute() (bool, error) {
+	file, err := os.Open(routeTablePath)
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to read routing table [%s]", routeTablePath)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // Skip the header line
+	for scanner.Scan() {
+		// Iface Destination Gateway Flags RefCnt Use Metric Mask ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 || fields[1] != "00000000" || fields[7] != "00000000" {
+			continue
+		}
+		flags, err := strconv.ParseUint(fields[3], 16, 32)
+		if err != nil || flags&rtfUp == 0 {
+			continue
+		}
+		if isInterfaceUp(fields[0]) {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// isInterfaceUp returns true if the interface operational state is up
+// Some drivers, e.g. tun and ppp, report unknown state even when they work so that counts as up
+func isInterfaceUp(name string) bool {
+	state, err := ioutil.ReadFile(filepath.Join(netClassPath, name, "operstate"))
+	if err != nil {
+		return false
+	}
+	switch strings.TrimSpace(string(state)) {
+	case "up", "unknown":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRecoveringPod(state string) model.Pod {
+	return model.Pod{
+		Metadata: model.NewMetadata("default", "foo"),
+		Spec: model.PodSpec{
+			Containers: []model.Container{
+				{Name: "bar", RestartOnNetworkRecovery: true},
+				{Name: "baz"},
+			},
+		},
+		Status: model.PodStatus{
+			ContainerStatuses: []model.ContainerStatus{
+				{ContainerID: "foo-bar", Name: "bar", State: state},
+				{ContainerID: "foo-baz", Name: "baz", State: state},
+			},
+		},
+	}
+}
+
+func TestNetworkWatcherRestartsAfterRecovery(t *testing.T) {
+	client := &fakeWatchClient{pods: []model.Pod{newRecoveringPod("running")}}
+	online := true
+	now := time.Now()
+	watcher := NewNetworkWatcher(client, NewPodWatcher(client), nil)
+	watcher.online = func() (bool, error) { return online, nil }
+
+	assert.False(t, watcher.check(now), "should not restart when starting online")
+
+	online = false
+	assert.False(t, watcher.check(now.Add(2*time.Second)))
+
+	online = true
+	assert.False(t, watcher.check(now.Add(4*time.Second)), "should wait the settle time")
+	assert.True(t, watcher.check(now.Add(10*time.Second)))
+	assert.False(t, watcher.check(now.Add(12*time.Second)), "should restart only once per recovery")
+
+	watcher.restartAll()
+	assert.Equal(t, []string{"foo-bar"}, client.terminated)
+}
+
+func TestNetworkWatcherDoesNotRestartStoppedContainer(t *testing.T) {
+	client := &fakeWatchClient{pods: []model.Pod{newRecoveringPod("stopped")}}
+	watcher := NewNetworkWatcher(client, NewPodWatcher(client), nil)
+
+	watcher.restartAll()
+	assert.Empty(t, client.terminated)
+}
+
+func TestNetworkWatcherWaitsPause(t *testing.T) {
+	pause := NewReconcilePause(time.Hour)
+	pause.Pause(time.Minute)
+	online := false
+	now := time.Now()
+	watcher := NewNetworkWatcher(&fakeWatchClient{}, nil, pause)
+	watcher.online = func() (bool, error) { return online, nil }
+
+	watcher.check(now)
+	online = true
+	watcher.check(now)
+	assert.False(t, watcher.check(now.Add(10*time.Second)), "should not restart while paused")
+
+	pause.Resume()
+	assert.True(t, watcher.check(now.Add(12*time.Second)), "should restart after the pause")
+}
+
+func TestHasDefaultRoute(t *testing.T) {
+	dir, err := ioutil.TempDir("", "network")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	defer func(route, net string) { routeTablePath, netClassPath = route, net }(routeTablePath, netClassPath)
+	routeTablePath = filepath.Join(dir, "route")
+	netClassPath = dir
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "eth0"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "eth0", "operstate"), []byte("up\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(routeTablePath, []byte(
+		"Iface\tDestination\tGateway\tFlags\tRefCnt\tUse\tMetric\tMask\tMTU\tWindow\tIRTT\n"+
+			"eth0\t0001A8C0\t00000000\t0001\t0\t0\t0\t00FFFFFF\t0\t0\t0\n"), 0644))
+
+	online, err := hasDefaultRoute()
+	assert.NoError(t, err)
+	assert.False(t, online, "should require default route")
+
+	assert.NoError(t, ioutil.WriteFile(routeTablePath, []byte(
+		"Iface\tDestination\tGateway\tFlags\tRefCnt\tUse\tMetric\tMask\tMTU\tWindow\tIRTT\n"+
+			"eth0\t00000000\t0101A8C0\t0003\t0\t0\t0\t00000000\t0\t0\t0\n"), 0644))
+
+	online, err = hasDefaultRoute()
+	assert.NoError(t, err)
+	assert.True(t, online)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "eth0", "operstate"), []byte("down\n"), 0644))
+	online, err = hasDefaultRoute()
+	assert.NoError(t, err)
+	assert.False(t, online, "should require the interface to be up")
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ReconcilePause suspends the controllers temporarily so that operator can work on the containers
+// by hand without the controllers undoing the changes. The pause ends automatically after the timeout
+// so forgotten pause doesn't disable the controllers forever
+type ReconcilePause struct {
+	mutex      sync.Mutex
+	maxTimeout time.Duration
+	until      time.Time
+	now        func() time.Time
+}
+
+// NewReconcilePause creates new ReconcilePause what allows pausing at most for the max timeout
+func NewReconcilePause(maxTimeout time.Duration) *ReconcilePause {
+	return &ReconcilePause{
+		maxTimeout: maxTimeout,
+		now:        time.Now,
+	}
+}
+
+// Pause suspends reconciling for the timeout, zero or longer than the max timeout means the max timeout
+// Returns the time when reconciling resumes automatically
+func (p *ReconcilePause) Pause(timeout time.Duration) time.Time {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if timeout <= 0 || timeout > p.maxTimeout {
+		timeout = p.maxTimeout
+	}
+	p.until = p.now().Add(timeout)
+	log.Infof("Reconcile paused until %s", p.until.Format(time.RFC3339))
+	return p.until
+}
+
+// Resume ends the pause, the controllers reconcile back to the desired state on next check
+func (p *ReconcilePause) Resume() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !p.until.IsZero() {
+		log.Infof("Reconcile resumed")
+	}
+	p.until = time.Time{}
+}
+
+// IsPaused returns true if reconciling is paused, false always for nil pause
+func (p *ReconcilePause) IsPaused() bool {
+	if p == nil {
+		return false
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.until.IsZero() {
+		return false
+	}
+	if !p.now().Before(p.until) {
+		log.Infof("Reconcile pause timed out, resume reconciling")
+		p.until = time.Time{}
+		return false
+	}
+	return true
+}
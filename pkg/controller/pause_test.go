@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcilePauseTimesOut(t *testing.T) {
+	now := time.Now()
+	pause := NewReconcilePause(time.Hour)
+	pause.now = func() time.Time { return now }
+
+	until := pause.Pause(10 * time.Minute)
+	assert.Equal(t, now.Add(10*time.Minute), until)
+	assert.True(t, pause.IsPaused())
+
+	now = now.Add(10 * time.Minute)
+	assert.False(t, pause.IsPaused(), "should resume automatically after timeout")
+}
+
+func TestReconcilePauseLimitsTimeout(t *testing.T) {
+	now := time.Now()
+	pause := NewReconcilePause(time.Hour)
+	pause.now = func() time.Time { return now }
+
+	assert.Equal(t, now.Add(time.Hour), pause.Pause(0), "zero timeout should mean the max timeout")
+	assert.Equal(t, now.Add(time.Hour), pause.Pause(24*time.Hour), "should not pause longer than the max timeout")
+}
+
+func TestReconcilePauseResume(t *testing.T) {
+	pause := NewReconcilePause(time.Hour)
+	pause.Pause(0)
+	pause.Resume()
+	assert.False(t, pause.IsPaused())
+
+	var nilPause *ReconcilePause
+	assert.False(t, nilPause.IsPaused(), "nil pause should never be paused")
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// podWatcherMaxAge is how long the pod list gets reused at most, even if no event changes it
+	podWatcherMaxAge = 10 * time.Second
+	// podWatcherReconnectInterval is how long to wait before watching the events again after the stream breaks
+	podWatcherReconnectInterval = 5 * time.Second
+)
+
+// podWatcherFilters are the containerd events what change the pod list or the container statuses
+var podWatcherFilters = []string{`topic~="/containers/"`, `topic~="/tasks/"`, `topic~="/namespaces/"`}
+
+// NamespacePods is the pods of one namespace, the Err is set if listing the namespace pods failed
+type NamespacePods struct {
+	Namespace string
+	Pods      []model.Pod
+	Err       error
+}
+
+// PodWatcher lists the pods of all namespaces for the controllers, so that every controller tick doesn't
+// query containerd. The list gets reused until containerd reports container, task or namespace event or
+// the list gets older than podWatcherMaxAge. When the events are not watched, every call lists the pods again
+// The container statuses get listed with the pods, so the task events make the next call list them again
+type PodWatcher struct {
+	client runtime.Client
+	now    func() time.Time
+
+	mutex    sync.Mutex
+	watching bool
+	changed  bool
+	listedAt time.Time
+	pods     []NamespacePods
+
+	cancel context.CancelFunc
+}
+
+// NewPodWatcher creates new PodWatcher what lists the pods with the client
+func NewPodWatcher(client runtime.Client) *PodWatcher {
+	return &PodWatcher{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Serve watches the containerd events to know when the pod list must be resolved again
+func (w *PodWatcher) Serve() {
+	log.Infof("Start pod watcher...")
+	ctx, cancel := context.WithCancel(context.Background())
+	w.mutex.Lock()
+	w.cancel = cancel
+	w.mutex.Unlock()
+
+	for {
+		w.setWatching(true)
+		err := w.client.StreamEvents(ctx, "", podWatcherFilters, func(model.RuntimeEvent) error {
+			w.setChanged()
+			return nil
+		})
+		w.setWatching(false)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Warnf("Pod watcher lost containerd event stream, listing pods on every call and reconnect in %s: %s", podWatcherReconnectInterval, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(podWatcherReconnectInterval):
+		}
+	}
+}
+
+// Stop the pod watcher running
+func (w *PodWatcher) Stop() {
+	log.Infof("Stop pod watcher...")
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.cancel != nil {
+		w.cancel()
+	}
+}
+
+// setWatching marks the events watched or not, either way the next call lists the pods again
+// because the events might have been missed
+func (w *PodWatcher) setWatching(watching bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.watching = watching
+	w.changed = true
+}
+
+func (w *PodWatcher) setChanged() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.changed = true
+}
+
+// List returns the pods of all namespaces, the caller must not modify the returned pods
+// Returns error only if the namespaces cannot be resolved, the namespace pods have own error
+func (w *PodWatcher) List() ([]NamespacePods, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	now := w.now()
+	if w.watching && !w.changed && now.Sub(w.listedAt) < podWatcherMaxAge {
+		return w.pods, nil
+	}
+
+	// The events what arrive during the listing wait for the lock, so they mark the new list changed
+	namespaces, err := w.client.GetNamespaces()
+	if err != nil {
+		return nil, err
+	}
+
+	listed := []NamespacePods{}
+	failed := false
+	for _, namespace := range namespaces {
+		pods, err := w.client.GetPods(namespace)
+		failed = failed || err != nil
+		listed = append(listed, NamespacePods{Namespace: namespace, Pods: pods, Err: err})
+	}
+	w.pods, w.listedAt, w.changed = listed, now, failed
+	return listed, nil
+}
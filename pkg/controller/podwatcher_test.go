@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePodListClient struct {
+	runtime.Client
+	lists   int
+	failed  map[string]bool
+	events  chan model.RuntimeEvent
+	handled chan struct{}
+}
+
+func (c *fakePodListClient) GetNamespaces() ([]string, error) {
+	c.lists++
+	return []string{"default", "dev"}, nil
+}
+
+func (c *fakePodListClient) GetPods(namespace string) ([]model.Pod, error) {
+	if c.failed[namespace] {
+		return nil, fmt.Errorf("Failed to list pods")
+	}
+	return []model.Pod{{Metadata: model.Metadata{Name: "foo", Namespace: namespace}}}, nil
+}
+
+func (c *fakePodListClient) StreamEvents(ctx context.Context, namespace string, filters []string, handler func(model.RuntimeEvent) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event := <-c.events:
+			if err := handler(event); err != nil {
+				return err
+			}
+			c.handled <- struct{}{}
+		}
+	}
+}
+
+func TestPodWatcherListsOnEveryCallWithoutEvents(t *testing.T) {
+	client := &fakePodListClient{}
+	watcher := NewPodWatcher(client)
+
+	namespaces, err := watcher.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []NamespacePods{
+		{Namespace: "default", Pods: []model.Pod{{Metadata: model.Metadata{Name: "foo", Namespace: "default"}}}},
+		{Namespace: "dev", Pods: []model.Pod{{Metadata: model.Metadata{Name: "foo", Namespace: "dev"}}}},
+	}, namespaces)
+
+	_, err = watcher.List()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, client.lists, "should list again when the events are not watched")
+}
+
+func TestPodWatcherReusesListUntilChanged(t *testing.T) {
+	now := time.Now()
+	client := &fakePodListClient{failed: map[string]bool{}}
+	watcher := NewPodWatcher(client)
+	watcher.now = func() time.Time { return now }
+	watcher.setWatching(true)
+
+	watcher.List()
+	watcher.List()
+	assert.Equal(t, 1, client.lists, "should reuse the list while nothing changes")
+
+	watcher.setChanged()
+	watcher.List()
+	assert.Equal(t, 2, client.lists, "should list again after an event")
+
+	now = now.Add(podWatcherMaxAge)
+	watcher.List()
+	assert.Equal(t, 3, client.lists, "should list again when the list gets old")
+
+	client.failed["dev"] = true
+	watcher.setChanged()
+	namespaces, err := watcher.List()
+	assert.NoError(t, err)
+	assert.Error(t, namespaces[1].Err)
+	watcher.List()
+	assert.Equal(t, 5, client.lists, "should list again when namespace listing failed")
+}
+
+func TestPodWatcherServe(t *testing.T) {
+	client := &fakePodListClient{events: make(chan model.RuntimeEvent), handled: make(chan struct{})}
+	watcher := NewPodWatcher(client)
+	notify := func() {
+		client.events <- model.RuntimeEvent{Topic: "/tasks/exit"}
+		<-client.handled
+	}
+
+	done := make(chan struct{})
+	go func() {
+		watcher.Serve()
+		close(done)
+	}()
+
+	notify()
+	watcher.List()
+	watcher.List()
+	assert.Equal(t, 1, client.lists, "should reuse the list while watching the events")
+
+	notify()
+	watcher.List()
+	assert.Equal(t, 2, client.lists, "should list again after an event")
+
+	watcher.Stop()
+	<-done
+}
@@ -0,0 +1,239 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	log "github.com/sirupsen/logrus"
+)
+
+// Prober is controller which executes the containers liveness and readiness probes.
+// Failing liveness probe kills the container so the Lifecycle controller restarts it
+// based on restart policy, failing readiness probe only marks the container not ready
+type Prober struct {
+	client   runtime.Client
+	pods     *PodWatcher
+	interval time.Duration
+	serving  bool
+	now      func() time.Time
+	states   map[string]*probeState
+	pause    *ReconcilePause
+}
+
+// probeState tracks one probe of one container run
+type probeState struct {
+	restartCount int
+	startedAt    time.Time
+	lastRun      time.Time
+	failures     int
+}
+
+// NewProber creates new Prober controller instance
+// Failing liveness probes don't kill containers while the pause is active
+func NewProber(client runtime.Client, pods *PodWatcher, pause *ReconcilePause) *Prober {
+	return &Prober{
+		client:   client,
+		pods:     pods,
+		interval: 1 * time.Second,
+		now:      time.Now,
+		states:   map[string]*probeState{},
+		pause:    pause,
+	}
+}
+
+// Serve starts the controller to probe containers
+func (p *Prober) Serve() {
+	log.Infof("Start prober controller...")
+	p.serving = true
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !p.serving {
+			return
+		}
+
+		p.checkAll()
+	}
+}
+
+// Stop the prober running
+func (p *Prober) Stop() {
+	log.Infof("Stop prober controller...")
+	p.serving = false
+}
+
+func (p *Prober) checkAll() {
+	namespaces, err := p.pods.List()
+	if err != nil {
+		log.Warnf("Prober controller cannot probe containers, error while fetching namespaces: %s", err)
+		return
+	}
+
+	var (
+		wg   sync.WaitGroup
+		seen = map[string]bool{}
+		now  = p.now()
+	)
+	for _, listed := range namespaces {
+		namespace, pods, err := listed.Namespace, listed.Pods, listed.Err
+		if err != nil {
+			log.Warnf("Prober controller cannot probe containers, error while fetching pods: %s", err)
+			continue
+		}
+
+		for _, pod := range pods {
+			for _, container := range pod.Spec.Containers {
+				status, ok := findContainerStatus(pod, container.Name)
+				if !ok || status.State != "running" {
+					continue
+				}
+
+				if container.LivenessProbe != nil {
+					key := getProbeKey(namespace, status.ContainerID, "liveness")
+					seen[key] = true
+					if state := p.getState(key, status.RestartCount, now); state.isDue(*container.LivenessProbe, now) {
+						wg.Add(1)
+						go func(probe model.Probe, status model.ContainerStatus, namespace string) {
+							defer wg.Done()
+							p.probeLiveness(namespace, status, probe, state, now)
+						}(*container.LivenessProbe, status, namespace)
+					}
+				}
+
+				if container.ReadinessProbe != nil {
+					key := getProbeKey(namespace, status.ContainerID, "readiness")
+					seen[key] = true
+					if state := p.getState(key, status.RestartCount, now); state.isDue(*container.ReadinessProbe, now) {
+						wg.Add(1)
+						go func(probe model.Probe, status model.ContainerStatus, namespace string) {
+							defer wg.Done()
+							p.probeReadiness(namespace, status, probe, state, now)
+						}(*container.ReadinessProbe, status, namespace)
+					}
+				}
+			}
+		}
+	}
+	wg.Wait()
+
+	for key := range p.states {
+		if !seen[key] {
+			delete(p.states, key)
+		}
+	}
+}
+
+func (p *Prober) probeLiveness(namespace string, status model.ContainerStatus, probe model.Probe, state *probeState, now time.Time) {
+	if !state.record(probe, p.execProbe(namespace, status.ContainerID, probe), now) {
+		return
+	}
+	if p.pause.IsPaused() {
+		log.Debugf("Container [%s] liveness probe failed but reconcile is paused, don't kill the container", status.ContainerID)
+		return
+	}
+
+	log.Infof("Container [%s] liveness probe failed %d times, kill the container", status.ContainerID, state.failures)
+	state.failures = 0
+	if err := p.client.Signal(namespace, status.ContainerID, syscall.SIGKILL); err != nil {
+		log.Warnf("Prober controller failed to kill container [%s]: %s", status.ContainerID, err)
+	}
+}
+
+func (p *Prober) probeReadiness(namespace string, status model.ContainerStatus, probe model.Probe, state *probeState, now time.Time) {
+	failed := state.record(probe, p.execProbe(namespace, status.ContainerID, probe), now)
+	ready := state.failures == 0
+	if ready == status.Ready || !ready && !failed {
+		return
+	}
+
+	log.Infof("Container [%s] readiness changed to %t", status.ContainerID, ready)
+	if err := p.client.SetContainerReady(namespace, status.ContainerID, ready); err != nil {
+		log.Warnf("Prober controller failed to update container [%s] readiness: %s", status.ContainerID, err)
+	}
+}
+
+// execProbe runs the probe command and returns true if the command exited with zero exit code
+func (p *Prober) execProbe(namespace, id string, probe model.Probe) bool {
+	code, err := p.client.ExecProbe(namespace, id, probe.Exec, getProbeTimeout(probe))
+	if err != nil {
+		log.Debugf("Probe in container [%s] failed: %s", id, err)
+		return false
+	}
+	if code != 0 {
+		log.Debugf("Probe in container [%s] exited with code %d", id, code)
+		return false
+	}
+	return true
+}
+
+// getState returns the probe state, the state gets reset when the container restarts
+func (p *Prober) getState(key string, restartCount int, now time.Time) *probeState {
+	state, ok := p.states[key]
+	if !ok || state.restartCount != restartCount {
+		state = &probeState{
+			restartCount: restartCount,
+			startedAt:    now,
+		}
+		p.states[key] = state
+	}
+	return state
+}
+
+// isDue returns true when the initial delay and the period since the previous run have passed
+func (s *probeState) isDue(probe model.Probe, now time.Time) bool {
+	if now.Before(s.startedAt.Add(probe.InitialDelay)) {
+		return false
+	}
+	return s.lastRun.IsZero() || now.Sub(s.lastRun) >= getProbePeriod(probe)
+}
+
+// record stores the probe result and returns true if the failure threshold is reached
+func (s *probeState) record(probe model.Probe, success bool, now time.Time) bool {
+	s.lastRun = now
+	if success {
+		s.failures = 0
+		return false
+	}
+	s.failures++
+	return s.failures >= getProbeFailureThreshold(probe)
+}
+
+func findContainerStatus(pod model.Pod, name string) (model.ContainerStatus, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == name {
+			return status, true
+		}
+	}
+	return model.ContainerStatus{}, false
+}
+
+func getProbeKey(namespace, id, probe string) string {
+	return fmt.Sprintf("%s/%s/%s", namespace, id, probe)
+}
+
+func getProbePeriod(probe model.Probe) time.Duration {
+	if probe.Period > 0 {
+		return probe.Period
+	}
+	return model.DefaultProbePeriod
+}
+
+func getProbeTimeout(probe model.Probe) time.Duration {
+	if probe.Timeout > 0 {
+		return probe.Timeout
+	}
+	return model.DefaultProbeTimeout
+}
+
+func getProbeFailureThreshold(probe model.Probe) int {
+	if probe.FailureThreshold > 0 {
+		return probe.FailureThreshold
+	}
+	return model.DefaultProbeFailureThreshold
+}
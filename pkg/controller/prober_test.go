@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProbeClient struct {
+	runtime.Client
+	mutex     sync.Mutex
+	pods      []model.Pod
+	exitCodes map[string]int
+	killed    []string
+	ready     map[string]bool
+}
+
+func (c *fakeProbeClient) GetNamespaces() ([]string, error) {
+	return []string{"default"}, nil
+}
+
+func (c *fakeProbeClient) GetPods(namespace string) ([]model.Pod, error) {
+	return c.pods, nil
+}
+
+func (c *fakeProbeClient) ExecProbe(namespace, name string, args []string, timeout time.Duration) (int, error) {
+	return c.exitCodes[args[0]], nil
+}
+
+func (c *fakeProbeClient) Signal(namespace, name string, signal syscall.Signal) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.killed = append(c.killed, name)
+	return nil
+}
+
+func (c *fakeProbeClient) SetContainerReady(namespace, name string, ready bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.ready[name] = ready
+	return nil
+}
+
+func newProbedPod(liveness, readiness *model.Probe, ready bool) model.Pod {
+	return model.Pod{
+		Metadata: model.NewMetadata("default", "foo"),
+		Spec: model.PodSpec{
+			Containers: []model.Container{
+				{Name: "bar", LivenessProbe: liveness, ReadinessProbe: readiness},
+			},
+		},
+		Status: model.PodStatus{
+			ContainerStatuses: []model.ContainerStatus{
+				{ContainerID: "foo-bar", Name: "bar", State: "running", Ready: ready},
+			},
+		},
+	}
+}
+
+func newTestProber(client runtime.Client) (*Prober, *time.Time) {
+	now := time.Now()
+	prober := NewProber(client, NewPodWatcher(client), nil)
+	prober.now = func() time.Time { return now }
+	return prober, &now
+}
+
+func TestProberKillsContainerWhenLivenessFails(t *testing.T) {
+	client := &fakeProbeClient{
+		pods:      []model.Pod{newProbedPod(&model.Probe{Exec: []string{"fail"}, FailureThreshold: 2}, nil, true)},
+		exitCodes: map[string]int{"fail": 1},
+		ready:     map[string]bool{},
+	}
+	prober, now := newTestProber(client)
+
+	prober.checkAll()
+	assert.Empty(t, client.killed, "should not kill before failure threshold")
+
+	prober.checkAll()
+	assert.Empty(t, client.killed, "should not probe again before period")
+
+	*now = now.Add(model.DefaultProbePeriod)
+	prober.checkAll()
+	assert.Equal(t, []string{"foo-bar"}, client.killed)
+	assert.Empty(t, client.ready, "liveness probe should not change readiness")
+}
+
+func TestProberUpdatesReadinessWithoutKilling(t *testing.T) {
+	client := &fakeProbeClient{
+		pods:      []model.Pod{newProbedPod(nil, &model.Probe{Exec: []string{"ok"}}, false)},
+		exitCodes: map[string]int{"ok": 0, "fail": 1},
+		ready:     map[string]bool{},
+	}
+	prober, now := newTestProber(client)
+
+	prober.checkAll()
+	assert.Equal(t, map[string]bool{"foo-bar": true}, client.ready)
+
+	client.pods = []model.Pod{newProbedPod(nil, &model.Probe{Exec: []string{"fail"}, FailureThreshold: 1}, true)}
+	*now = now.Add(model.DefaultProbePeriod)
+	prober.checkAll()
+	assert.Equal(t, map[string]bool{"foo-bar": false}, client.ready)
+	assert.Empty(t, client.killed, "readiness probe should not kill the container")
+}
+
+func TestProbeStateWaitsInitialDelay(t *testing.T) {
+	now := time.Now()
+	probe := model.Probe{Exec: []string{"true"}, InitialDelay: 5 * time.Second, Period: time.Second}
+	state := &probeState{startedAt: now}
+
+	assert.False(t, state.isDue(probe, now.Add(time.Second)), "should wait initial delay")
+	assert.True(t, state.isDue(probe, now.Add(5*time.Second)), "should run after initial delay")
+
+	state.record(probe, true, now.Add(5*time.Second))
+	assert.False(t, state.isDue(probe, now.Add(5500*time.Millisecond)), "should wait period")
+	assert.True(t, state.isDue(probe, now.Add(6*time.Second)), "should run after period")
+}
+
+func TestProbeStateResetsOnRestart(t *testing.T) {
+	prober, now := newTestProber(&fakeProbeClient{})
+	probe := model.Probe{Exec: []string{"false"}}
+
+	state := prober.getState("key", 0, *now)
+	state.record(probe, false, *now)
+	assert.Equal(t, 1, prober.getState("key", 0, *now).failures)
+	assert.Equal(t, 0, prober.getState("key", 1, *now).failures, "should reset after container restart")
+}
+
+func TestProberDoesNotKillWhilePaused(t *testing.T) {
+	client := &fakeProbeClient{
+		pods:      []model.Pod{newProbedPod(&model.Probe{Exec: []string{"fail"}, FailureThreshold: 1}, nil, true)},
+		exitCodes: map[string]int{"fail": 1},
+		ready:     map[string]bool{},
+	}
+	prober, _ := newTestProber(client)
+	prober.pause = NewReconcilePause(time.Hour)
+	prober.pause.Pause(0)
+
+	prober.checkAll()
+	assert.Empty(t, client.killed, "should not kill while reconcile is paused")
+}
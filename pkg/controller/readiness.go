@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Readiness tells when the Lifecycle controller has converged the containers to the desired state
+// after boot, i.e. all managed containers are running and ready. Once ready, the device stays ready
+// so that the later container restarts don't flap the device readiness
+type Readiness struct {
+	mutex   sync.Mutex
+	readyAt time.Time
+}
+
+// NewReadiness creates new Readiness what is not ready until the first successful reconcile
+func NewReadiness() *Readiness {
+	return &Readiness{}
+}
+
+// SetReady marks the first successful reconcile completed, does nothing for nil readiness
+func (r *Readiness) SetReady(now time.Time) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.readyAt.IsZero() {
+		log.Infof("All containers running and ready, device is ready")
+		r.readyAt = now
+	}
+}
+
+// IsReady returns true once the first reconcile has completed successfully
+func (r *Readiness) IsReady() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return !r.readyAt.IsZero()
+}
@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/cron"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Scheduler is controller which starts the scheduled containers at the scheduled time
+// The run gets skipped if the previous run of the container is still running
+type Scheduler struct {
+	client      runtime.Client
+	pods        *PodWatcher
+	interval    time.Duration
+	serving     bool
+	pause       *ReconcilePause
+	maintenance *Maintenance
+	history     *ReconcileHistory
+	now         func() time.Time
+	schedules   map[string]*scheduleState
+}
+
+// scheduleState tracks the next run of one container
+type scheduleState struct {
+	expression string
+	schedule   *cron.Schedule
+	next       time.Time
+}
+
+// NewScheduler creates new Scheduler controller instance
+// The containers don't get started while the pause or the maintenance mode is active,
+// the started runs get recorded to the history
+func NewScheduler(client runtime.Client, pods *PodWatcher, pause *ReconcilePause, maintenance *Maintenance, history *ReconcileHistory) *Scheduler {
+	return &Scheduler{
+		client:      client,
+		pods:        pods,
+		interval:    10 * time.Second,
+		pause:       pause,
+		maintenance: maintenance,
+		history:     history,
+		now:         time.Now,
+		schedules:   map[string]*scheduleState{},
+	}
+}
+
+// Serve starts the controller to start the scheduled containers
+func (s *Scheduler) Serve() {
+	log.Infof("Start scheduler controller...")
+	s.serving = true
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !s.serving {
+			return
+		}
+
+		if err := s.checkAll(); err != nil {
+			log.Panicf("Scheduler controller stopped with fatal error: %s", err)
+		}
+	}
+}
+
+// Stop the scheduler running
+func (s *Scheduler) Stop() {
+	log.Infof("Stop scheduler controller...")
+	s.serving = false
+}
+
+func (s *Scheduler) checkAll() error {
+	namespaces, err := s.pods.List()
+	if err != nil {
+		log.Warnf("Scheduler controller cannot check schedules, error while fetching namespaces: %s", err)
+		return nil
+	}
+
+	var (
+		seen = map[string]bool{}
+		now  = s.now()
+	)
+	for _, listed := range namespaces {
+		namespace, pods, err := listed.Namespace, listed.Pods, listed.Err
+		if err != nil {
+			log.Warnf("Scheduler controller cannot check schedules, error while fetching pods: %s", err)
+			continue
+		}
+
+		for _, pod := range pods {
+			for _, container := range pod.Spec.Containers {
+				status, ok := findContainerStatus(pod, container.Name)
+				if !ok || container.Schedule == "" {
+					continue
+				}
+
+				key := fmt.Sprintf("%s/%s", namespace, status.ContainerID)
+				seen[key] = true
+				if !s.check(key, container.Schedule, status.State == "running", now) {
+					continue
+				}
+				if err := s.start(namespace, pod, status, now); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for key := range s.schedules {
+		if !seen[key] {
+			delete(s.schedules, key)
+		}
+	}
+	return nil
+}
+
+// check returns true when the container run is due and the container should get started
+// The runs what were due while the previous run was still running, reconcile was paused or
+// the node was in maintenance get skipped
+func (s *Scheduler) check(key, expression string, running bool, now time.Time) bool {
+	state, ok := s.schedules[key]
+	if !ok || state.expression != expression {
+		schedule, err := cron.Parse(expression)
+		if err != nil {
+			log.Warnf("Container [%s] has invalid schedule: %s", key, err)
+			return false
+		}
+		state = &scheduleState{expression: expression, schedule: schedule, next: schedule.Next(now)}
+		s.schedules[key] = state
+		return false
+	}
+
+	if state.next.IsZero() || now.Before(state.next) {
+		return false
+	}
+	state.next = state.schedule.Next(now)
+
+	if running {
+		log.Infof("Previous run of scheduled container [%s] is still running, skip the run", key)
+		return false
+	}
+	if s.pause.IsPaused() {
+		log.Infof("Reconcile is paused, skip the scheduled container [%s] run", key)
+		return false
+	}
+	if err := s.maintenance.Check(); err != nil {
+		log.Infof("Skip the scheduled container [%s] run: %s", key, err)
+		return false
+	}
+	return true
+}
+
+func (s *Scheduler) start(namespace string, pod model.Pod, status model.ContainerStatus, now time.Time) error {
+	ioset, err := runtime.NewIOSet(fmt.Sprintf("%s.%s", pod.Metadata.Name, status.Name))
+	if err != nil {
+		return errors.Wrapf(err, "Error while creating container ioset, cannot run scheduler controller")
+	}
+
+	record := model.ReconcileRecord{
+		Time:        now,
+		Namespace:   namespace,
+		Pod:         pod.Metadata.Name,
+		ContainerID: status.ContainerID,
+		Action:      model.ReconcileScheduled,
+	}
+	if _, err := s.client.StartContainer(namespace, status.ContainerID, *ioset); err != nil {
+		log.Warnf("Scheduler controller failed to start container [%s]: %s", status.ContainerID, err)
+		record.Action = model.ReconcileFailed
+		record.Error = err.Error()
+	} else {
+		log.Infof("Started scheduled container [%s] in namespace [%s]", status.ContainerID, namespace)
+	}
+	s.history.Add(record)
+	return nil
+}
+
+// isScheduled returns true if the pod container with the name has schedule
+func isScheduled(pod model.Pod, name string) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return container.Schedule != ""
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerStartsContainerAtScheduledTime(t *testing.T) {
+	now := time.Date(2018, time.March, 14, 2, 59, 30, 0, time.UTC)
+	scheduler := NewScheduler(nil, nil, nil, nil, nil)
+
+	assert.False(t, scheduler.check("default/foo", "0 3 * * *", false, now), "should not start when first seen")
+	assert.False(t, scheduler.check("default/foo", "0 3 * * *", false, now.Add(20*time.Second)))
+	assert.True(t, scheduler.check("default/foo", "0 3 * * *", false, now.Add(40*time.Second)), "should start at the scheduled time")
+	assert.False(t, scheduler.check("default/foo", "0 3 * * *", false, now.Add(50*time.Second)), "should start only once per scheduled time")
+	assert.True(t, scheduler.check("default/foo", "0 3 * * *", false, now.Add(24*time.Hour+40*time.Second)), "should start again the next day")
+}
+
+func TestSchedulerSkipsOverlappingRun(t *testing.T) {
+	now := time.Date(2018, time.March, 14, 2, 59, 30, 0, time.UTC)
+	scheduler := NewScheduler(nil, nil, nil, nil, nil)
+
+	scheduler.check("default/foo", "@hourly", false, now)
+	assert.False(t, scheduler.check("default/foo", "@hourly", true, now.Add(time.Minute)), "should skip while the previous run is running")
+	assert.False(t, scheduler.check("default/foo", "@hourly", false, now.Add(2*time.Minute)), "should not run the skipped run later")
+	assert.True(t, scheduler.check("default/foo", "@hourly", false, now.Add(time.Hour+time.Minute)))
+}
+
+func TestSchedulerSkipsRunWhilePaused(t *testing.T) {
+	now := time.Date(2018, time.March, 14, 2, 59, 30, 0, time.UTC)
+	pause := NewReconcilePause(time.Hour)
+	pause.Pause(time.Hour)
+	scheduler := NewScheduler(nil, nil, pause, nil, nil)
+
+	scheduler.check("default/foo", "@hourly", false, now)
+	assert.False(t, scheduler.check("default/foo", "@hourly", false, now.Add(time.Minute)))
+}
+
+func TestSchedulerSkipsRunInMaintenance(t *testing.T) {
+	now := time.Date(2018, time.March, 14, 2, 59, 30, 0, time.UTC)
+	maintenance := NewMaintenance()
+	maintenance.Set(true, "OS upgrade")
+	scheduler := NewScheduler(nil, nil, nil, maintenance, nil)
+
+	scheduler.check("default/foo", "@hourly", false, now)
+	assert.False(t, scheduler.check("default/foo", "@hourly", false, now.Add(time.Minute)))
+
+	maintenance.Set(false, "")
+	assert.True(t, scheduler.check("default/foo", "@hourly", false, now.Add(time.Hour+time.Minute)), "should run again after the maintenance")
+}
+
+func TestLifecycleDoesNotRestartScheduledContainer(t *testing.T) {
+	client := &fakeLifecycleClient{
+		pods: []model.Pod{{
+			Metadata: model.NewMetadata("default", "foo"),
+			Spec:     model.PodSpec{Containers: []model.Container{{Name: "bar", Schedule: "@daily"}}},
+			Status: model.PodStatus{
+				ContainerStatuses: []model.ContainerStatus{{ContainerID: "foo-bar", Name: "bar", State: "stopped"}},
+			},
+		}},
+	}
+	lifecycle := NewLifecycle(client, NewPodWatcher(client), nil, nil, nil, 10*time.Minute)
+
+	// The fake client doesn't implement StartContainer, so restart attempt would panic
+	assert.NoError(t, lifecycle.checkAll())
+	assert.Empty(t, lifecycle.backoffs)
+}
@@ -0,0 +1,184 @@
+// Package cron parses the standard five field cron schedule expressions
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears limits how far the next run gets searched, e.g. 30th of February never matches
+const maxSearchYears = 5
+
+// Schedule is parsed cron expression
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domRestricted and dowRestricted tell if the field is not '*', when both are restricted
+	// the day matches if either of them matches
+	domRestricted, dowRestricted bool
+}
+
+type bounds struct {
+	min, max int
+}
+
+var (
+	minuteBounds = bounds{0, 59}
+	hourBounds   = bounds{0, 23}
+	domBounds    = bounds{1, 31}
+	monthBounds  = bounds{1, 12}
+	// Sunday is both 0 and 7
+	dowBounds = bounds{0, 7}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses cron expression with five fields: minute, hour, day of month, month and day of week
+// Each field can be '*', number, range (1-5), list (1,3,5) or any of them with step (*/15, 1-10/2)
+// Also the macros @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly are supported
+func Parse(expression string) (*Schedule, error) {
+	if macro, ok := macros[strings.TrimSpace(expression)]; ok {
+		expression = macro
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("Invalid cron expression [%s], must have five fields: minute hour day-of-month month day-of-week", expression)
+	}
+
+	var (
+		schedule = &Schedule{}
+		err      error
+	)
+	if schedule.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, fmt.Errorf("Invalid cron expression [%s] minute: %s", expression, err)
+	}
+	if schedule.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, fmt.Errorf("Invalid cron expression [%s] hour: %s", expression, err)
+	}
+	if schedule.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, fmt.Errorf("Invalid cron expression [%s] day of month: %s", expression, err)
+	}
+	if schedule.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, fmt.Errorf("Invalid cron expression [%s] month: %s", expression, err)
+	}
+	if schedule.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, fmt.Errorf("Invalid cron expression [%s] day of week: %s", expression, err)
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1 << 0
+	}
+	schedule.domRestricted = fields[2] != "*"
+	schedule.dowRestricted = fields[4] != "*"
+	return schedule, nil
+}
+
+func parseField(field string, b bounds) (result uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		bits, err := parsePart(part, b)
+		if err != nil {
+			return 0, err
+		}
+		result |= bits
+	}
+	return result, nil
+}
+
+// parsePart parses single list item, e.g. '*', '5', '1-5' or '*/15'
+func parsePart(part string, b bounds) (result uint64, err error) {
+	var (
+		rangePart = part
+		step      = 1
+	)
+	if i := strings.Index(part, "/"); i >= 0 {
+		rangePart = part[:i]
+		if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step in [%s]", part)
+		}
+	}
+
+	start, end := b.min, b.max
+	switch {
+	case rangePart == "*":
+	case strings.Contains(rangePart, "-"):
+		values := strings.SplitN(rangePart, "-", 2)
+		if start, err = parseValue(values[0], b); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(values[1], b); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, fmt.Errorf("invalid range [%s], start is after end", rangePart)
+		}
+	default:
+		if start, err = parseValue(rangePart, b); err != nil {
+			return 0, err
+		}
+		// Single value with step, e.g. 5/15, runs from the value to the end
+		if step == 1 {
+			end = start
+		}
+	}
+
+	for value := start; value <= end; value += step {
+		result |= 1 << uint(value)
+	}
+	return result, nil
+}
+
+func parseValue(value string, b bounds) (int, error) {
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value [%s]", value)
+	}
+	if number < b.min || number > b.max {
+		return 0, fmt.Errorf("value [%d] out of range %d-%d", number, b.min, b.max)
+	}
+	return number, nil
+}
+
+// Next returns the first time after the given time what matches the schedule,
+// zero time if nothing matches within next five years
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + maxSearchYears
+
+	for t.Year() <= limit {
+		switch {
+		case !matches(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !matches(s.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !matches(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := matches(s.dom, t.Day())
+	dowMatch := matches(s.dow, int(t.Weekday()))
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+func matches(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
@@ -0,0 +1,58 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParse(t *testing.T, expression string) *Schedule {
+	schedule, err := Parse(expression)
+	assert.NoError(t, err)
+	return schedule
+}
+
+func TestNext(t *testing.T) {
+	start := time.Date(2018, time.March, 14, 10, 25, 30, 0, time.UTC)
+
+	for _, tc := range []struct {
+		expression string
+		expected   time.Time
+	}{
+		{"* * * * *", time.Date(2018, time.March, 14, 10, 26, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2018, time.March, 14, 10, 30, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2018, time.March, 15, 3, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2018, time.March, 14, 11, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2018, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 8 * * 1-5", time.Date(2018, time.March, 15, 8, 30, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2018, time.March, 18, 12, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// Either day of month or day of week when both restricted
+		{"0 0 20 * 5", time.Date(2018, time.March, 16, 0, 0, 0, 0, time.UTC)},
+	} {
+		assert.Equal(t, tc.expected, mustParse(t, tc.expression).Next(start), tc.expression)
+	}
+}
+
+func TestNextReturnsZeroIfNeverMatches(t *testing.T) {
+	assert.True(t, mustParse(t, "0 0 30 2 *").Next(time.Now()).IsZero())
+}
+
+func TestParseRejectsInvalidExpressions(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"foo * * * *",
+	} {
+		_, err := Parse(expression)
+		assert.Error(t, err, "should reject [%s]", expression)
+	}
+}
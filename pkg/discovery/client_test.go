@@ -4,11 +4,12 @@ import (
 	"testing"
 	"time"
 
+	node "github.com/ernoaapa/eliot/pkg/api/services/node/v1"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestClientNodes(t *testing.T) {
-	server := NewServer("testing", 1234, "v1.0")
+	server := NewServer("testing", 1234, "v1.0", map[string]string{"region": "eu"}, nil, nil)
 	go server.Serve()
 	defer server.Stop()
 
@@ -17,4 +18,5 @@ func TestClientNodes(t *testing.T) {
 	assert.Len(t, nodes, 1)
 	assert.Equal(t, int64(1234), nodes[0].GrpcPort)
 	assert.Equal(t, "v1.0", nodes[0].Version)
+	assert.Equal(t, []*node.Label{{Key: "region", Value: "eu"}}, nodes[0].Groups)
 }
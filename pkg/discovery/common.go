@@ -2,3 +2,16 @@ package discovery
 
 // ZeroConfServiceName is the service name for discovering the eliotd
 var ZeroConfServiceName = "_eliot._tcp"
+
+// groupTextPrefix is the zeroconf TXT record key prefix for the node groups, e.g. group.region=eu
+const groupTextPrefix = "group."
+
+// labelTextPrefix is the zeroconf TXT record key prefix for the node labels, e.g. label.location=helsinki
+const labelTextPrefix = "label."
+
+// interfaceTextPrefix is the zeroconf TXT record key prefix for the advertised network interfaces,
+// e.g. iface.eth0.mac=b8:27:eb:12:34:56 and iface.eth0.ip=192.168.1.2,fe80::1
+const interfaceTextPrefix = "iface."
+
+// maxTextLength is the maximum length of single TXT record string
+const maxTextLength = 255
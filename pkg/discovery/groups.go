@@ -0,0 +1,56 @@
+package discovery
+
+import (
+	"time"
+
+	node "github.com/ernoaapa/eliot/pkg/api/services/node/v1"
+)
+
+// NodesInGroup discovers the nodes synchronously with given timeout and returns the ones in the group
+func NodesInGroup(timeout time.Duration, key, value string) ([]*node.Info, error) {
+	nodes, err := Nodes(timeout)
+	if err != nil {
+		return nodes, err
+	}
+	return FilterByGroup(nodes, key, value), nil
+}
+
+// NodesByGroup discovers the nodes synchronously with given timeout and returns them grouped by the group key value
+func NodesByGroup(timeout time.Duration, key string) (map[string][]*node.Info, error) {
+	nodes, err := Nodes(timeout)
+	if err != nil {
+		return nil, err
+	}
+	return GroupBy(nodes, key), nil
+}
+
+// FilterByGroup returns the nodes what belong to the group, e.g. key region and value eu
+func FilterByGroup(nodes []*node.Info, key, value string) (result []*node.Info) {
+	for _, info := range nodes {
+		if group, ok := GetGroup(info, key); ok && group == value {
+			result = append(result, info)
+		}
+	}
+	return result
+}
+
+// GroupBy returns the nodes grouped by the group key value, the nodes without the group key are left out
+func GroupBy(nodes []*node.Info, key string) map[string][]*node.Info {
+	result := map[string][]*node.Info{}
+	for _, info := range nodes {
+		if group, ok := GetGroup(info, key); ok {
+			result[group] = append(result[group], info)
+		}
+	}
+	return result
+}
+
+// GetGroup returns the node group value for the key and true if the node has the group key
+func GetGroup(info *node.Info, key string) (string, bool) {
+	for _, group := range info.Groups {
+		if group.Key == key {
+			return group.Value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,34 @@
+package discovery
+
+import (
+	"testing"
+
+	node "github.com/ernoaapa/eliot/pkg/api/services/node/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func newGroupNodes() []*node.Info {
+	return []*node.Info{
+		{Hostname: "foo", Groups: []*node.Label{{Key: "region", Value: "eu"}, {Key: "customer", Value: "acme"}}},
+		{Hostname: "bar", Groups: []*node.Label{{Key: "region", Value: "us"}}},
+		{Hostname: "baz", Groups: []*node.Label{{Key: "region", Value: "eu"}}},
+		{Hostname: "qux"},
+	}
+}
+
+func TestFilterByGroup(t *testing.T) {
+	nodes := newGroupNodes()
+
+	assert.Equal(t, []*node.Info{nodes[0], nodes[2]}, FilterByGroup(nodes, "region", "eu"))
+	assert.Equal(t, []*node.Info{nodes[0]}, FilterByGroup(nodes, "customer", "acme"))
+	assert.Empty(t, FilterByGroup(nodes, "region", "asia"))
+}
+
+func TestGroupBy(t *testing.T) {
+	nodes := newGroupNodes()
+
+	result := GroupBy(nodes, "region")
+	assert.Len(t, result, 2, "should leave out the nodes without the group")
+	assert.Equal(t, []*node.Info{nodes[0], nodes[2]}, result["eu"])
+	assert.Equal(t, []*node.Info{nodes[1]}, result["us"])
+}
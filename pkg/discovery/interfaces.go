@@ -0,0 +1,106 @@
+package discovery
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	node "github.com/ernoaapa/eliot/pkg/api/services/node/v1"
+)
+
+// FindInterface returns the advertised network interface what has the address,
+// e.g. to tell which interface the node got discovered from
+func FindInterface(info *node.Info, address string) (*node.NetworkInterface, bool) {
+	for _, iface := range info.Interfaces {
+		for _, addr := range iface.Addresses {
+			if addr == address {
+				return iface, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// multicastInterfaces returns the interfaces what are up and support multicast,
+// the same interfaces what zeroconf advertises the service on by default
+func multicastInterfaces() (result []net.Interface) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return result
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp != 0 && iface.Flags&net.FlagMulticast != 0 {
+			result = append(result, iface)
+		}
+	}
+	return result
+}
+
+// interfaceAddresses returns the interface addresses without the loopback addresses
+func interfaceAddresses(iface net.Interface) (result []net.IP) {
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return result
+	}
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
+			result = append(result, ipnet.IP)
+		}
+	}
+	return result
+}
+
+// interfaceText returns the TXT records for the interface MAC and addresses
+// The addresses what don't fit to single TXT record get left out
+func interfaceText(name string, mac net.HardwareAddr, addresses []net.IP) (text []string) {
+	if len(mac) > 0 {
+		text = append(text, fmt.Sprintf("%s%s.mac=%s", interfaceTextPrefix, name, mac))
+	}
+
+	record := fmt.Sprintf("%s%s.ip=", interfaceTextPrefix, name)
+	ips := []string{}
+	for _, ip := range addresses {
+		if len(record)+len(strings.Join(append(ips, ip.String()), ",")) > maxTextLength {
+			break
+		}
+		ips = append(ips, ip.String())
+	}
+	if len(ips) > 0 {
+		text = append(text, record+strings.Join(ips, ","))
+	}
+	return text
+}
+
+// parseInterfaceText parses the interface TXT record key without the prefix and the value to the interfaces
+// The interface name can contain dots, e.g. eth0.100 VLAN interface, so the field is after the last dot
+func parseInterfaceText(key, value string, interfaces map[string]*node.NetworkInterface) {
+	index := strings.LastIndex(key, ".")
+	if index <= 0 {
+		return
+	}
+	name, field := key[:index], key[index+1:]
+
+	iface, ok := interfaces[name]
+	if !ok {
+		iface = &node.NetworkInterface{Name: name}
+	}
+	switch field {
+	case "mac":
+		iface.Mac = value
+	case "ip":
+		iface.Addresses = append(iface.Addresses, strings.Split(value, ",")...)
+	default:
+		return
+	}
+	interfaces[name] = iface
+}
+
+// sortedInterfaces returns the interfaces sorted by the name
+func sortedInterfaces(interfaces map[string]*node.NetworkInterface) (result []*node.NetworkInterface) {
+	for _, iface := range interfaces {
+		result = append(result, iface)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
@@ -0,0 +1,45 @@
+package discovery
+
+import (
+	"net"
+	"testing"
+
+	node "github.com/ernoaapa/eliot/pkg/api/services/node/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterfaceText(t *testing.T) {
+	mac, _ := net.ParseMAC("b8:27:eb:12:34:56")
+
+	assert.Equal(t, []string{
+		"iface.eth0.mac=b8:27:eb:12:34:56",
+		"iface.eth0.ip=192.168.1.2,fe80::1",
+	}, interfaceText("eth0", mac, []net.IP{net.ParseIP("192.168.1.2"), net.ParseIP("fe80::1")}))
+
+	assert.Equal(t, []string{"iface.tun0.ip=10.8.0.1"}, interfaceText("tun0", nil, []net.IP{net.ParseIP("10.8.0.1")}), "should leave out missing MAC")
+}
+
+func TestInterfaceTextFitsToRecord(t *testing.T) {
+	addresses := []net.IP{}
+	for i := 0; i < 20; i++ {
+		addresses = append(addresses, net.ParseIP("2001:db8:1234:5678:9abc:def0:1234:5678"))
+	}
+
+	text := interfaceText("eth0", nil, addresses)
+	assert.Len(t, text, 1)
+	assert.True(t, len(text[0]) <= maxTextLength, "should leave out the addresses what don't fit")
+}
+
+func TestFindInterface(t *testing.T) {
+	info := &node.Info{Interfaces: []*node.NetworkInterface{
+		{Name: "eth0", Addresses: []string{"10.0.0.2"}},
+		{Name: "wlan0", Addresses: []string{"192.168.1.3", "fe80::1"}},
+	}}
+
+	iface, ok := FindInterface(info, "fe80::1")
+	assert.True(t, ok)
+	assert.Equal(t, "wlan0", iface.Name)
+
+	_, ok = FindInterface(info, "1.2.3.4")
+	assert.False(t, ok)
+}
@@ -9,12 +9,32 @@ import (
 )
 
 func MapToAPIModel(entry *zeroconf.ServiceEntry) *node.Info {
-	version := "unknown"
+	var (
+		version          = "unknown"
+		minClientVersion = ""
+		groups           []*node.Label
+		labels           []*node.Label
+		interfaces       = map[string]*node.NetworkInterface{}
+	)
 
 	for _, val := range entry.Text {
 		parts := strings.SplitN(val, "=", 2)
-		if len(parts) == 2 && parts[0] == "v" {
+		if len(parts) != 2 {
+			continue
+		}
+		switch parts[0] {
+		case "v":
 			version = parts[1]
+		case "min-client":
+			minClientVersion = parts[1]
+		default:
+			if strings.HasPrefix(parts[0], groupTextPrefix) {
+				groups = append(groups, &node.Label{Key: strings.TrimPrefix(parts[0], groupTextPrefix), Value: parts[1]})
+			} else if strings.HasPrefix(parts[0], labelTextPrefix) {
+				labels = append(labels, &node.Label{Key: strings.TrimPrefix(parts[0], labelTextPrefix), Value: parts[1]})
+			} else if strings.HasPrefix(parts[0], interfaceTextPrefix) {
+				parseInterfaceText(strings.TrimPrefix(parts[0], interfaceTextPrefix), parts[1], interfaces)
+			}
 		}
 	}
 
@@ -23,6 +43,11 @@ func MapToAPIModel(entry *zeroconf.ServiceEntry) *node.Info {
 		Addresses: addressesToString(append(entry.AddrIPv4, entry.AddrIPv6...)),
 		GrpcPort:  int64(entry.Port),
 		Version:   version,
+		Groups:    groups,
+		Labels:    labels,
+
+		MinClientVersion: minClientVersion,
+		Interfaces:       sortedInterfaces(interfaces),
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"net"
 	"testing"
 
+	node "github.com/ernoaapa/eliot/pkg/api/services/node/v1"
 	"github.com/grandcat/zeroconf"
 	"github.com/stretchr/testify/assert"
 )
@@ -13,12 +14,22 @@ func TestMapToInternalModel(t *testing.T) {
 		HostName: "hostname",
 		AddrIPv4: []net.IP{net.IPv4zero},
 		AddrIPv6: []net.IP{net.IPv6loopback},
-		Text:     []string{"v=1.2.3-abcd"},
+		Text: []string{
+			"v=1.2.3-abcd", "min-client=0.2.0", "group.region=eu", "label.location=helsinki", "other=value",
+			"iface.wlan0.ip=192.168.1.3", "iface.eth0.100.mac=b8:27:eb:12:34:56", "iface.eth0.100.ip=10.0.0.2,fe80::1",
+		},
 	})
 
 	assert.Equal(t, "hostname", result.Hostname)
 	assert.Equal(t, "1.2.3-abcd", result.Version)
+	assert.Equal(t, "0.2.0", result.MinClientVersion)
+	assert.Equal(t, []*node.Label{{Key: "region", Value: "eu"}}, result.Groups)
+	assert.Equal(t, []*node.Label{{Key: "location", Value: "helsinki"}}, result.Labels)
 	assert.Equal(t, addressesToString([]net.IP{net.IPv4zero, net.IPv6loopback}), result.Addresses)
+	assert.Equal(t, []*node.NetworkInterface{
+		{Name: "eth0.100", Mac: "b8:27:eb:12:34:56", Addresses: []string{"10.0.0.2", "fe80::1"}},
+		{Name: "wlan0", Addresses: []string{"192.168.1.3"}},
+	}, result.Interfaces)
 }
 
 func TestAddressesToString(t *testing.T) {
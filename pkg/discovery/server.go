@@ -2,7 +2,9 @@ package discovery
 
 import (
 	"fmt"
+	"net"
 
+	"github.com/ernoaapa/eliot/pkg/version"
 	"github.com/grandcat/zeroconf"
 	log "github.com/sirupsen/logrus"
 )
@@ -13,17 +15,24 @@ type Server struct {
 	Domain   string
 	Port     int
 	Version  string
+	Groups   map[string]string
+	Labels   map[string]string
+	changes  <-chan map[string]string
 	server   *zeroconf.Server
 	shutdown chan bool
 }
 
-// NewServer creates new discovery server
-func NewServer(name string, port int, version string) *Server {
+// NewServer creates new discovery server what advertises the node version, groups, labels and network interfaces
+// The labels received from the changes channel get advertised again, nil channel means static labels
+func NewServer(name string, port int, version string, groups, labels map[string]string, changes <-chan map[string]string) *Server {
 	return &Server{
 		Name:     name,
 		Domain:   "local.",
 		Port:     port,
 		Version:  version,
+		Groups:   groups,
+		Labels:   labels,
+		changes:  changes,
 		shutdown: make(chan bool),
 	}
 }
@@ -32,19 +41,49 @@ func NewServer(name string, port int, version string) *Server {
 func (s *Server) Serve() {
 	log.Infof("Start discovery server...")
 	log.Debugf("Exposing %s in port %d", s.Name, s.Port)
-	server, err := zeroconf.Register(s.Name, ZeroConfServiceName, s.Domain, s.Port, []string{
-		fmt.Sprintf("v=%s", s.Version),
-	}, nil)
+	// Advertise on the same interfaces what the TXT records describe
+	ifaces := multicastInterfaces()
+	server, err := zeroconf.Register(s.Name, ZeroConfServiceName, s.Domain, s.Port, s.getText(ifaces), ifaces)
 	if err != nil {
 		log.Fatalf("Failed to create zeroconf server: %s", err)
 	}
 
 	s.server = server
 
-	select {
-	case <-s.shutdown:
-		s.server.Shutdown()
+	for {
+		select {
+		case labels := <-s.changes:
+			log.Debugf("Node labels changed, advertise %d labels", len(labels))
+			s.Labels = labels
+			s.server.SetText(s.getText(ifaces))
+		case <-s.shutdown:
+			s.server.Shutdown()
+			return
+		}
+	}
+}
+
+// getText returns the TXT records what describe the node
+func (s *Server) getText(ifaces []net.Interface) []string {
+	text := []string{
+		fmt.Sprintf("v=%s", s.Version),
+		fmt.Sprintf("min-client=%s", version.MinClientVersion),
+	}
+	for key, value := range s.Groups {
+		text = append(text, fmt.Sprintf("%s%s=%s", groupTextPrefix, key, value))
+	}
+	for key, value := range s.Labels {
+		record := fmt.Sprintf("%s%s=%s", labelTextPrefix, key, value)
+		if len(record) > maxTextLength {
+			log.Warnf("Label [%s] is too long to advertise, max TXT record length is %d", key, maxTextLength)
+			continue
+		}
+		text = append(text, record)
+	}
+	for _, iface := range ifaces {
+		text = append(text, interfaceText(iface.Name, iface.HardwareAddr, interfaceAddresses(iface))...)
 	}
+	return text
 }
 
 // Stop server to be discoverable
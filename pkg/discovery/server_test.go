@@ -1,13 +1,16 @@
 package discovery
 
 import (
+	"strings"
 	"sync"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestServerServeStop(t *testing.T) {
 	var wg sync.WaitGroup
-	server := NewServer("testing", 1234, "v1.0")
+	server := NewServer("testing", 1234, "v1.0", nil, nil, nil)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -17,3 +20,16 @@ func TestServerServeStop(t *testing.T) {
 	server.Stop()
 	wg.Wait()
 }
+
+func TestServerText(t *testing.T) {
+	server := NewServer("testing", 1234, "v1.0", map[string]string{"region": "eu"}, map[string]string{
+		"location": "helsinki",
+		"too-long": strings.Repeat("x", maxTextLength),
+	}, nil)
+
+	text := server.getText(nil)
+	assert.Contains(t, text, "v=v1.0")
+	assert.Contains(t, text, "group.region=eu")
+	assert.Contains(t, text, "label.location=helsinki")
+	assert.Len(t, text, 4, "should leave out too long label")
+}
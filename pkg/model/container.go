@@ -1,7 +1,11 @@
 package model
 
+import "time"
+
 // Container defines what image should be running
 type Container struct {
+	// ID is optional unique container identifier, generated if not given
+	ID         string `validate:"omitempty,containerID"`
 	Name       string `validate:"required,gt=0,alphanumOrDash"`
 	Image      string `validate:"required,gt=0,imageRef"`
 	Tty        bool
@@ -10,6 +14,226 @@ type Container struct {
 	WorkingDir string   `validate:"omitempty,gt=0"`
 	Mounts     []Mount  `validate:"dive"`
 	Pipe       *PipeSet
+	DNS        []string `validate:"dive,ip"`
+	ExtraHosts []string `validate:"dive,hostIPPair"`
+	Resources  *Resources
+	Hostname   string `validate:"omitempty,hostname"`
+	Domainname string `validate:"omitempty,fqdn"`
+	// CgroupParent is the cgroup under what the container cgroup gets created, e.g. /eliot.slice
+	CgroupParent string   `validate:"omitempty,cgroupParent"`
+	Ulimits      []Ulimit `validate:"dive"`
+	// Annotations are written to the OCI spec, e.g. to configure sandboxed runtime (Kata, gVisor)
+	Annotations map[string]string `validate:"dive,keys,gt=0,endkeys"`
+	// HostNetwork runs the container in the host network namespace even if the pod doesn't use host network
+	HostNetwork bool
+	// Tmpfs are size limited in-memory mounts, e.g. writable /tmp what doesn't wear out the flash storage
+	Tmpfs []TmpfsMount `validate:"dive"`
+	// LivenessProbe failures restart the container per the pod restart policy
+	LivenessProbe *Probe
+	// ReadinessProbe failures only mark the container not ready, the container keeps running
+	ReadinessProbe *Probe
+	// SpecPatch is JSON merge patch (RFC 7386) what gets applied to the generated OCI runtime spec
+	// It's escape hatch for the runtime features what the model doesn't support
+	SpecPatch string `validate:"omitempty,jsonObject"`
+	// Sysctls are kernel parameters set in the container namespaces, e.g. net.core.somaxconn
+	// Only namespaced sysctls are allowed so that the container cannot change the host settings
+	Sysctls map[string]string `validate:"dive,keys,namespacedSysctl,endkeys"`
+	// Capabilities replaces the default process capabilities entirely, e.g. to run with audited minimal set
+	Capabilities *Capabilities
+	// OOMScoreAdj adjusts how likely the kernel OOM killer picks the container processes under memory
+	// pressure, -1000 protects the container entirely and 1000 makes it the first victim, zero is the default
+	OOMScoreAdj int `validate:"min=-1000,max=1000"`
+	// Init runs the eliotd --init-path binary as PID 1 what forwards signals and reaps the zombie
+	// processes, for the applications what spawn subprocesses but don't reap them
+	Init bool
+	// AppArmorProfile confines the container with the named AppArmor profile, the profile must be loaded on the host
+	AppArmorProfile string `validate:"omitempty,noSpaces"`
+	// RestartOnChange restarts the container when the watched host files change, e.g. when
+	// provisioning agent renews a certificate or updates a configuration file
+	RestartOnChange *FileWatch
+	// Schedule is cron expression when to start the container, e.g. "0 3 * * *" for nightly job
+	// Scheduled container doesn't get started with the pod nor restarted when it exits
+	Schedule string `validate:"omitempty,cronSchedule"`
+	// StartPriority orders the containers when the pod gets started, higher priority starts first
+	// and the containers with the same priority start concurrently
+	StartPriority int
+	// LogDriver is where the container stdout/stderr goes, one of LogDrivers, defaults to LogDriverFile
+	LogDriver string `validate:"omitempty,logDriver"`
+	// Files are written to in-memory storage on the host and mounted read-only to the container,
+	// e.g. credentials and configuration what shouldn't be baked into the image or visible in the environment
+	Files []FileMount `validate:"dive"`
+	// EgressRateLimit limits the container outgoing network traffic in bits per second, zero means no limit
+	// Only host network containers have external traffic, the limit requires eliotd --bandwidth-interface
+	EgressRateLimit int64 `validate:"gte=0"`
+	// RestartOnNetworkRecovery restarts the running container when the host network comes back online,
+	// e.g. for the services what cache failed DNS lookup at boot and never retry
+	RestartOnNetworkRecovery bool
+	// LogLevel overrides the pod log level for the container, see PodSpec.LogLevel
+	LogLevel string `validate:"omitempty,noSpaces"`
+	// IdleStop stops the container when it has been idle and starts it again on the next connection,
+	// the Lifecycle controller leaves the container to the IdleStopper controller
+	IdleStop *IdleStop
+	// Devices are the host device nodes what get passed through to the container
+	Devices []Device `validate:"dive"`
+	// Accelerators are the host GPUs or other accelerators what get passed through to the container
+	// with their driver libraries, each one of Accelerators
+	Accelerators []string `validate:"dive,accelerator"`
+	// StorageQuota limits how much the container writable layer can grow in bytes, zero means no limit
+	// Requires snapshotter with quota support, overlayfs on XFS with project quotas or btrfs with quotas enabled
+	StorageQuota int64 `validate:"gte=0"`
+	// HostEnv are the names of eliotd environment variables what get forwarded to the container, e.g. the values
+	// what the provisioning system sets. Each must be in eliotd --host-env-allowlist and Env overrides them
+	HostEnv []string `validate:"dive,alphanumOrDash"`
+}
+
+// Supported container log drivers
+const (
+	// LogDriverFile keeps the output in the container FIFO files where the clients attach to
+	LogDriverFile = "file"
+	// LogDriverJournald forwards the output lines to the host systemd journal
+	LogDriverJournald = "journald"
+)
+
+// LogDrivers are the supported container log drivers
+var LogDrivers = []string{LogDriverFile, LogDriverJournald}
+
+// Capabilities defines the process capability sets explicitly, empty set means no capabilities
+// Effective capabilities must be also in the permitted set
+type Capabilities struct {
+	Effective   []string `validate:"dive,capability"`
+	Permitted   []string `validate:"dive,capability"`
+	Bounding    []string `validate:"dive,capability"`
+	Inheritable []string `validate:"dive,capability"`
+}
+
+// Probe defines a command what gets executed periodically in the container to check its health
+// The probe succeeds when the command exits with zero exit code, zero values mean the defaults
+type Probe struct {
+	Exec         []string      `validate:"required,gt=0,dive,gt=0"`
+	InitialDelay time.Duration `validate:"gte=0"`
+	Period       time.Duration `validate:"gte=0"`
+	Timeout      time.Duration `validate:"gte=0"`
+	// FailureThreshold is how many consecutive failures are needed before the probe is considered failed
+	FailureThreshold int `validate:"gte=0"`
+}
+
+// Probe default values, used when the value is not given
+const (
+	DefaultProbePeriod           = 10 * time.Second
+	DefaultProbeTimeout          = 1 * time.Second
+	DefaultProbeFailureThreshold = 3
+)
+
+// FileWatch defines the host files what trigger the container restart when they get modified
+type FileWatch struct {
+	Paths []string `validate:"required,gt=0,dive,absolutePath"`
+	// Debounce is how long the files must stay unchanged before the restart, so that
+	// multiple writes trigger only single restart. Zero means the default
+	Debounce time.Duration `validate:"gte=0"`
+}
+
+// DefaultFileWatchDebounce is used when the FileWatch debounce is not given
+const DefaultFileWatchDebounce = 5 * time.Second
+
+// IdleStop defines when on-demand container gets stopped to save power and how it gets started again
+// eliotd listens the port while the container is stopped and starts the container on the first connection,
+// so the container must serve the port in the host network
+type IdleStop struct {
+	// Timeout is how long the container must be idle before it gets stopped
+	Timeout time.Duration `validate:"gt=0"`
+	// Port is the host TCP port what the container serves
+	Port int `validate:"gt=0,lte=65535"`
+	// Signal tells when the container is idle, one of IdleSignals, defaults to IdleSignalConnections
+	Signal string `validate:"omitempty,idleSignal"`
+}
+
+const (
+	// IdleSignalConnections means the container is idle when there's no established connections to the port
+	IdleSignalConnections = "connections"
+	// IdleSignalCPU means the container is idle when it uses less than IdleCPUThreshold
+	IdleSignalCPU = "cpu"
+)
+
+// IdleSignals are the supported signals what tell the container is idle
+var IdleSignals = []string{IdleSignalConnections, IdleSignalCPU}
+
+// IdleCPUThreshold is the CPU usage in millicores below what the container is idle with IdleSignalCPU
+const IdleCPUThreshold = 10
+
+// Device defines host device node what gets passed through to the container
+type Device struct {
+	HostPath string `validate:"required,absolutePath"`
+	// ContainerPath defaults to the HostPath
+	ContainerPath string `validate:"omitempty,absolutePath"`
+	// Permissions are combination of r (read), w (write) and m (mknod), defaults to DefaultDevicePermissions
+	Permissions string `validate:"omitempty,devicePermissions"`
+}
+
+// DefaultDevicePermissions is used when the Device permissions are not given
+const DefaultDevicePermissions = "rwm"
+
+// Supported accelerators, the runtime knows the device nodes and the driver libraries of each
+const (
+	// AcceleratorCoral is Coral Edge TPU in PCIe or M.2 slot
+	AcceleratorCoral = "coral"
+	// AcceleratorCoralUSB is Coral USB Accelerator
+	AcceleratorCoralUSB = "coral-usb"
+	// AcceleratorJetson is NVIDIA Jetson integrated GPU
+	AcceleratorJetson = "jetson"
+	// AcceleratorNvidia is NVIDIA discrete GPU
+	AcceleratorNvidia = "nvidia"
+)
+
+// Accelerators are all the supported accelerators
+var Accelerators = []string{AcceleratorCoral, AcceleratorCoralUSB, AcceleratorJetson, AcceleratorNvidia}
+
+// TmpfsMount defines in-memory filesystem mount
+type TmpfsMount struct {
+	Destination string `validate:"required,absolutePath"`
+	// Size limit in bytes, required so that the mount cannot exhaust the memory
+	SizeLimit int64    `validate:"gt=0"`
+	Options   []string `validate:"dive,gt=0"`
+}
+
+// FileMount defines file what gets mounted read-only to the container
+// The content is either given inline or read from the named secret in the node secret store
+type FileMount struct {
+	Path string `validate:"required,absolutePath"`
+	// Content is the file content, the secret content replaces it if Secret is given
+	Content string
+	// Secret is name of the secret what gets written to the file
+	Secret string `validate:"omitempty,alphanumOrDash"`
+	// Mode is the file permission bits, zero means DefaultFileMountMode
+	Mode uint32 `validate:"lte=511"`
+}
+
+// DefaultFileMountMode is used when the FileMount mode is not given
+const DefaultFileMountMode = 0444
+
+// Ulimit defines the container process resource limit, e.g. nofile for max open files
+type Ulimit struct {
+	Name string `validate:"required,ulimitName"`
+	Soft uint64
+	Hard uint64 `validate:"gtefield=Soft"`
+}
+
+// Resources defines the container CPU and memory limits
+type Resources struct {
+	// Memory limit in bytes, zero means no limit
+	MemoryLimit int64 `validate:"gte=0"`
+	// CPU limit in millicores (i.e. 1000 = one full CPU), zero means no limit
+	CPULimit int64 `validate:"gte=0"`
+	// MemorySwapLimit is the memory plus swap limit in bytes on both cgroup v1 and v2, requires the memory limit
+	// Zero (the default) and the memory limit disable swap, -1 allows unlimited swap
+	MemorySwapLimit int64 `validate:"swapLimit"`
+}
+
+// GetMemorySwapLimit returns the memory plus swap limit, the memory limit if the swap limit is not defined
+func (r Resources) GetMemorySwapLimit() int64 {
+	if r.MemorySwapLimit == 0 {
+		return r.MemoryLimit
+	}
+	return r.MemorySwapLimit
 }
 
 // PipeSet allows defining pipe from some source(s) to another container
@@ -33,6 +257,46 @@ type Mount struct {
 	Source      string   `validate:"omitempty,gt=0"`
 	Destination string   `validate:"omitempty,gt=0"`
 	Options     []string `validate:"dive,gt=0"`
+	// Propagation is the mount propagation mode, one of MountPropagationModes, e.g. rslave for host sub-mounts
+	Propagation string `validate:"omitempty,mountPropagation"`
+}
+
+// MountPropagationModes are the supported mount propagation modes
+var MountPropagationModes = []string{"shared", "slave", "private", "rshared", "rslave", "rprivate"}
+
+// ContainerInspect is the container as it actually exists in the runtime
+type ContainerInspect struct {
+	ID          string
+	Image       string
+	Labels      map[string]string
+	Runtime     string
+	Snapshotter string
+	SnapshotKey string
+	// Spec is the resolved OCI runtime spec in JSON format
+	Spec []byte
+}
+
+// FileChange is a path what the container has added, modified or deleted compared to the image
+type FileChange struct {
+	Kind string
+	Path string
+}
+
+// File change kinds
+const (
+	FileAdded    = "added"
+	FileModified = "modified"
+	FileDeleted  = "deleted"
+)
+
+// Process is a process running in the container
+type Process struct {
+	// PID and PPID are the host pids
+	PID  int
+	PPID int
+	// State is the process state letter, e.g. R running, S sleeping or Z zombie
+	State string
+	Args  []string
 }
 
 // ContainerStatus represents one container status
@@ -42,4 +306,37 @@ type ContainerStatus struct {
 	Image        string `validate:"required,gt=0,imageRef"`
 	State        string `validate:"required,gt=0"`
 	RestartCount int    `validate:"required,gte=0"`
+	// Exit code and reason of the previous run, empty reason if the container haven't exited
+	LastExitCode   int
+	LastExitReason string
+	// Ready is true when the container is running and its readiness probe, if any, succeeds
+	Ready bool
+	// LastStartedAt is when the container was started the last time, zero if never
+	LastStartedAt time.Time
+}
+
+// Container exit reasons
+const (
+	// ExitReasonCompleted means the process exited with zero exit code
+	ExitReasonCompleted = "Completed"
+	// ExitReasonError means the process exited with non-zero exit code
+	ExitReasonError = "Error"
+	// ExitReasonOOMKilled means the kernel killed the process because the container run out of memory
+	ExitReasonOOMKilled = "OOMKilled"
+)
+
+// RunResult is the result of one-shot container run
+type RunResult struct {
+	Name        string
+	ContainerID string
+	ExitCode    int
+	ExitReason  string
+	// TimedOut is true if the container got killed because it didn't exit in time
+	TimedOut bool
+	// Stdout and Stderr are the container output, only the end is kept if the output is too long
+	Stdout     string
+	Stderr     string
+	Truncated  bool
+	StartedAt  time.Time
+	FinishedAt time.Time
 }
@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -46,3 +47,234 @@ func TestValidationRequiresContainerFields(t *testing.T) {
 		Image: "/foo",
 	}), "should return error if container image reference is invalid")
 }
+
+func TestValidationContainerTmpfs(t *testing.T) {
+	assert.NoError(t, getValidator().Struct(Container{
+		Name:  "foo-1",
+		Image: "docker.io/library/foobar",
+		Tmpfs: []TmpfsMount{{Destination: "/tmp", SizeLimit: 64 * 1024 * 1024}},
+	}), "should be valid")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:  "foo-1",
+		Image: "docker.io/library/foobar",
+		Tmpfs: []TmpfsMount{{Destination: "/tmp"}},
+	}), "should return error if tmpfs size is not limited")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:  "foo-1",
+		Image: "docker.io/library/foobar",
+		Tmpfs: []TmpfsMount{{Destination: "tmp", SizeLimit: 1024}},
+	}), "should return error if tmpfs destination is not absolute path")
+}
+
+func TestValidationContainerAnnotations(t *testing.T) {
+	assert.NoError(t, getValidator().Struct(Container{
+		Name:        "foo-1",
+		Image:       "docker.io/library/foobar",
+		Annotations: map[string]string{"io.kubernetes.cri.untrusted-workload": "true"},
+	}), "should be valid")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:        "foo-1",
+		Image:       "docker.io/library/foobar",
+		Annotations: map[string]string{"": "true"},
+	}), "should return error if annotation key is empty")
+}
+
+func TestValidationContainerSysctls(t *testing.T) {
+	assert.NoError(t, getValidator().Struct(Container{
+		Name:    "foo-1",
+		Image:   "docker.io/library/foobar",
+		Sysctls: map[string]string{"net.core.somaxconn": "1024", "kernel.shmmax": "68719476736"},
+	}), "should be valid")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:    "foo-1",
+		Image:   "docker.io/library/foobar",
+		Sysctls: map[string]string{"vm.swappiness": "10"},
+	}), "should return error if sysctl is not namespaced")
+}
+
+func TestValidationContainerCapabilities(t *testing.T) {
+	assert.NoError(t, getValidator().Struct(Container{
+		Name:  "foo-1",
+		Image: "docker.io/library/foobar",
+		Capabilities: &Capabilities{
+			Effective: []string{"CAP_NET_BIND_SERVICE"},
+			Permitted: []string{"CAP_NET_BIND_SERVICE"},
+			Bounding:  []string{"CAP_NET_BIND_SERVICE", "CAP_CHOWN"},
+		},
+	}), "should be valid")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:         "foo-1",
+		Image:        "docker.io/library/foobar",
+		Capabilities: &Capabilities{Bounding: []string{"net_raw"}},
+	}), "should return error if capability name is not in CAP_ format")
+}
+
+func TestValidationContainerOOMScoreAdj(t *testing.T) {
+	assert.NoError(t, getValidator().Struct(Container{
+		Name:        "foo-1",
+		Image:       "docker.io/library/foobar",
+		OOMScoreAdj: -1000,
+	}), "should be valid")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:        "foo-1",
+		Image:       "docker.io/library/foobar",
+		OOMScoreAdj: 1001,
+	}), "should return error if score adjustment is out of range")
+}
+
+func TestValidationContainerRestartOnChange(t *testing.T) {
+	assert.NoError(t, getValidator().Struct(Container{
+		Name:            "foo-1",
+		Image:           "docker.io/library/foobar",
+		RestartOnChange: &FileWatch{Paths: []string{"/etc/foo/cert.pem"}},
+	}), "should be valid")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:            "foo-1",
+		Image:           "docker.io/library/foobar",
+		RestartOnChange: &FileWatch{Paths: []string{"cert.pem"}},
+	}), "should return error if path is not absolute")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:            "foo-1",
+		Image:           "docker.io/library/foobar",
+		RestartOnChange: &FileWatch{},
+	}), "should return error if no paths given")
+}
+
+func TestValidationContainerIdleStop(t *testing.T) {
+	assert.NoError(t, getValidator().Struct(Container{
+		Name:     "foo-1",
+		Image:    "docker.io/library/foobar",
+		IdleStop: &IdleStop{Timeout: 10 * time.Minute, Port: 8080, Signal: IdleSignalCPU},
+	}), "should be valid")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:     "foo-1",
+		Image:    "docker.io/library/foobar",
+		IdleStop: &IdleStop{Timeout: 10 * time.Minute},
+	}), "should return error if no port given")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:     "foo-1",
+		Image:    "docker.io/library/foobar",
+		IdleStop: &IdleStop{Timeout: 10 * time.Minute, Port: 8080, Signal: "memory"},
+	}), "should return error if signal is not supported")
+}
+
+func TestValidationContainerDevices(t *testing.T) {
+	assert.NoError(t, getValidator().Struct(Container{
+		Name:         "foo-1",
+		Image:        "docker.io/library/foobar",
+		Devices:      []Device{{HostPath: "/dev/ttyUSB0", ContainerPath: "/dev/ttyS0", Permissions: "rw"}},
+		Accelerators: []string{AcceleratorCoral},
+	}), "should be valid")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:    "foo-1",
+		Image:   "docker.io/library/foobar",
+		Devices: []Device{{HostPath: "/dev/ttyUSB0", Permissions: "rwx"}},
+	}), "should return error if permissions are invalid")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:         "foo-1",
+		Image:        "docker.io/library/foobar",
+		Accelerators: []string{"tpu"},
+	}), "should return error if accelerator is not supported")
+}
+
+func TestIsValidDevicePermissions(t *testing.T) {
+	assert.True(t, IsValidDevicePermissions("rwm"))
+	assert.True(t, IsValidDevicePermissions("r"))
+	assert.False(t, IsValidDevicePermissions(""))
+	assert.False(t, IsValidDevicePermissions("rr"), "should not allow duplicates")
+}
+
+func TestValidationContainerProbes(t *testing.T) {
+	assert.NoError(t, getValidator().Struct(Container{
+		Name:           "foo-1",
+		Image:          "docker.io/library/foobar",
+		LivenessProbe:  &Probe{Exec: []string{"pgrep", "foobar"}, Period: DefaultProbePeriod},
+		ReadinessProbe: &Probe{Exec: []string{"test", "-f", "/tmp/ready"}},
+	}), "should be valid")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:          "foo-1",
+		Image:         "docker.io/library/foobar",
+		LivenessProbe: &Probe{},
+	}), "should return error if probe don't have command")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:           "foo-1",
+		Image:          "docker.io/library/foobar",
+		ReadinessProbe: &Probe{Exec: []string{"true"}, Period: -1 * time.Second},
+	}), "should return error if probe period is negative")
+}
+
+func TestValidationContainerAppArmorProfile(t *testing.T) {
+	assert.Error(t, getValidator().Struct(Container{
+		Name:            "foo-1",
+		Image:           "docker.io/library/foobar",
+		AppArmorProfile: "docker default",
+	}), "should return error if profile name has spaces")
+}
+
+func TestValidationContainerSchedule(t *testing.T) {
+	assert.NoError(t, getValidator().Struct(Container{
+		Name:     "foo-1",
+		Image:    "docker.io/library/foobar",
+		Schedule: "*/15 * * * *",
+	}), "should be valid")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:     "foo-1",
+		Image:    "docker.io/library/foobar",
+		Schedule: "every hour",
+	}), "should return error if schedule is not cron expression")
+}
+
+func TestValidationContainerLogDriver(t *testing.T) {
+	assert.NoError(t, getValidator().Struct(Container{
+		Name:      "foo-1",
+		Image:     "docker.io/library/foobar",
+		LogDriver: LogDriverJournald,
+	}), "should be valid")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:      "foo-1",
+		Image:     "docker.io/library/foobar",
+		LogDriver: "syslog",
+	}), "should return error if log driver is not supported")
+}
+
+func TestValidationContainerFiles(t *testing.T) {
+	assert.NoError(t, getValidator().Struct(Container{
+		Name:  "foo-1",
+		Image: "docker.io/library/foobar",
+		Files: []FileMount{{Path: "/run/secrets/api-key", Secret: "api-key", Mode: 0400}},
+	}), "should be valid")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:  "foo-1",
+		Image: "docker.io/library/foobar",
+		Files: []FileMount{{Path: "secrets/api-key", Secret: "api-key"}},
+	}), "should return error if path is not absolute")
+
+	assert.Error(t, getValidator().Struct(Container{
+		Name:  "foo-1",
+		Image: "docker.io/library/foobar",
+		Files: []FileMount{{Path: "/foo", Mode: 01777}},
+	}), "should return error if mode has more than permission bits")
+}
+
+func TestGetMemorySwapLimit(t *testing.T) {
+	assert.Equal(t, int64(1024), Resources{MemoryLimit: 1024}.GetMemorySwapLimit(), "should disable swap by default")
+	assert.Equal(t, int64(4096), Resources{MemoryLimit: 1024, MemorySwapLimit: 4096}.GetMemorySwapLimit())
+	assert.Equal(t, int64(-1), Resources{MemoryLimit: 1024, MemorySwapLimit: -1}.GetMemorySwapLimit())
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// Image is container image stored in the node
+type Image struct {
+	Name   string
+	Digest string
+	// Labels are custom metadata given at pull or import, e.g. deploy batch or approver
+	Labels    map[string]string
+	CreatedAt time.Time
+}
+
+// ImageConfig is the image default configuration what the containers created from the image get
+type ImageConfig struct {
+	User       string
+	Entrypoint []string
+	Cmd        []string
+	Env        []string
+	WorkingDir string
+	// ExposedPorts are the ports what the image declares, e.g. 80/tcp
+	ExposedPorts []string
+	Volumes      []string
+	Labels       map[string]string
+	StopSignal   string
+	OS           string
+	Architecture string
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"net"
+	"time"
 )
 
 // NodeInfo contains information about current node
@@ -9,12 +10,19 @@ type NodeInfo struct {
 	// Labels for the node, provided through cli
 	Labels map[string]string
 
+	// Groups the node belongs to, e.g. deployment=prod or region=eu, provided through cli
+	// Groups get advertised in the discovery so that tooling can operate on all nodes in a group
+	Groups map[string]string
+
 	// Node hostname
 	Hostname string `validate:"required,gt=0"`
 
 	// IPs
 	Addresses []net.IP
 
+	// Network interfaces what are up and have addresses, tell e.g. which address belongs to which interface
+	Interfaces []NetworkInterface
+
 	// Port
 	GrpcPort int
 
@@ -38,6 +46,72 @@ type NodeInfo struct {
 	// Server version
 	Version string
 
+	// The oldest client version the server supports
+	MinClientVersion string
+
+	// Filesystems
+	Filesystems []Filesystem
+
+	// Seconds since node boot up
+	Uptime uint64
+
+	// Faults lists the fields what failed to resolve and contain fallback value
+	Faults []NodeFault
+
+	// Node current time
+	Time time.Time
+
+	// True if the system clock appears synchronized, e.g. by NTP.
+	// Devices without RTC have wrong clock until synced, which breaks TLS
+	ClockSynchronized bool
+}
+
+// NetworkInterface describes node network interface
+type NetworkInterface struct {
+	Name      string
+	MAC       string
+	Addresses []net.IP
+}
+
+// NodeFault describes node info field what failed to resolve
+type NodeFault struct {
+	Field  string
+	Reason string
+}
+
+// NodeIdentity contains the identifiers of the node
+// MachineID and SystemUUID are stable across reboots and should be used to key data to the device,
+// BootID is regenerated on every boot
+type NodeIdentity struct {
+	// The machine id is an ID identifying a specific Linux/Unix installation.
+	// Stable across reboots, changes if the OS gets reinstalled
+	MachineID string `validate:"required,gt=0"`
+
+	// The system uuid is the main board product UUID.
+	// Stable across reboots and OS reinstalls
+	SystemUUID string `validate:"required,gt=0"`
+
+	// A random ID that is regenerated on each boot
+	BootID string `validate:"required,gt=0"`
+
+	// Labels for the node, provided through cli
+	Labels map[string]string
+}
+
+// NodeStats contains the node dynamic metrics
+type NodeStats struct {
+	// System load averages over 1, 5 and 15 minutes
+	Load1  float64
+	Load5  float64
+	Load15 float64
+
+	// Total and free memory in bytes
+	MemoryTotal uint64
+	MemoryFree  uint64
+
+	// CPU temperature in millidegrees Celsius, zero if not available
+	Temperature int64
+
 	// Filesystems
 	Filesystems []Filesystem
 
@@ -70,3 +144,198 @@ type Filesystem struct {
 	// Free blocks available to unprivileged user
 	Available uint64
 }
+
+// DiskUsage represents disk space used by the images and containers in a namespace
+type DiskUsage struct {
+	// Total size of the content store in bytes
+	ContentSize int64
+	// Usage of each container snapshot
+	Containers []ContainerDiskUsage
+}
+
+// ContainerDiskUsage represents disk space used by single container snapshot
+type ContainerDiskUsage struct {
+	ContainerID string
+	Name        string
+	Pod         string
+	// Bytes used by the snapshot
+	Size int64
+	// Number of inodes used by the snapshot
+	Inodes int64
+}
+
+// ContainerMetrics represents resource usage of single running container
+type ContainerMetrics struct {
+	ContainerID string
+	// Time when the runtime sampled the metrics
+	Timestamp time.Time
+	// Total CPU time consumed in nanoseconds
+	CPUUsage uint64
+	// Memory usage and limit in bytes
+	MemoryUsage uint64
+	MemoryLimit uint64
+	// NetworkAvailable is true if the runtime reports the network counters
+	NetworkAvailable bool
+	// Total bytes received and transmitted over all interfaces
+	NetworkRxBytes uint64
+	NetworkTxBytes uint64
+}
+
+// ContainerStats represents resource usage of single running container computed from two consecutive metrics samples
+type ContainerStats struct {
+	ContainerID string
+	Time        time.Time
+	// CPU usage since the previous sample as percentage of single CPU, can exceed 100 with multiple CPUs
+	CPUPercent float64
+	// Memory usage and limit in bytes
+	MemoryUsage uint64
+	MemoryLimit uint64
+	// NetworkAvailable is true if the runtime reports the network counters
+	NetworkAvailable bool
+	// Total bytes received and transmitted
+	NetworkRxBytes uint64
+	NetworkTxBytes uint64
+	// Bytes per second received and transmitted since the previous sample
+	NetworkRxRate float64
+	NetworkTxRate float64
+}
+
+// NamespaceSummary represents resources used by the containers and images in single namespace
+type NamespaceSummary struct {
+	Namespace         string
+	Containers        int
+	RunningContainers int
+	// Total CPU time consumed by the running containers in nanoseconds
+	CPUUsage uint64
+	// Memory used by the running containers in bytes
+	MemoryUsage uint64
+	// Bytes used by the images in the content store
+	ImageSize int64
+	// Bytes used by the container writable snapshots
+	SnapshotSize int64
+}
+
+// NamespaceQuota limits the resources what the containers in single namespace can use, zero means no limit
+type NamespaceQuota struct {
+	MaxContainers int
+	// MaxMemory is the maximum total of the container memory limits in bytes, the containers
+	// in namespace with memory quota must have memory limit
+	MaxMemory int64
+	// MaxImageSize is the maximum size of the namespace content store in bytes
+	MaxImageSize int64
+}
+
+// Reconcile actions what the controllers and the pod apply record
+const (
+	ReconcileRestarted   = "restarted"
+	ReconcileScheduled   = "scheduled"
+	ReconcileIdleStopped = "idle-stopped"
+	ReconcileActivated   = "activated"
+	ReconcileCreated     = "created"
+	ReconcileUpdated     = "updated"
+	ReconcileRemoved     = "removed"
+	ReconcileRolledBack  = "rolled-back"
+	ReconcileFailed      = "failed"
+)
+
+// ReconcileRecord represents single action what the lifecycle controller took on container
+type ReconcileRecord struct {
+	Time        time.Time
+	Namespace   string
+	Pod         string
+	ContainerID string
+	// Either restarted, scheduled or failed
+	Action string
+	// The error message if the action failed
+	Error string
+}
+
+// RuntimeEvent is raw event from the container runtime event stream
+type RuntimeEvent struct {
+	Timestamp time.Time
+	Namespace string
+	// Topic is the event topic, e.g. /tasks/exit or /images/create
+	Topic string
+	// Subject is the container ID, the image name or other name what the event is about, empty if not known
+	Subject string
+	// TypeURL and Payload are the protobuf encoded event
+	TypeURL string
+	Payload []byte
+}
+
+// Maintenance is the node maintenance mode state, while enabled the API rejects new workloads and the controllers
+// don't start the scheduled or the idle containers, but the existing containers keep running
+type Maintenance struct {
+	Enabled bool
+	// Reason tells the clients why the node is in maintenance, e.g. "OS upgrade"
+	Reason string
+	// Since is when the maintenance mode was enabled, zero if not enabled
+	Since time.Time
+}
+
+// RuntimeInfo describes the container runtime capabilities of the node
+type RuntimeInfo struct {
+	ContainerdVersion  string
+	ContainerdRevision string
+	// The snapshotter what eliot creates the containers with
+	Snapshotter string
+	// The snapshotters what containerd has available, e.g. overlayfs
+	Snapshotters []string
+	// The runtimes what containerd has available, e.g. io.containerd.runtime.v1.linux
+	Runtimes []string
+	// Plugins are all the containerd plugins with their status
+	Plugins []PluginStatus
+	// Containers is the number of containers in all namespaces
+	Containers int
+	// MaxContainers is the maximum number of containers, running or stopped, zero means no limit
+	MaxContainers int
+	// Features are the runtime features what are enabled in the eliotd configuration, sorted
+	Features []string
+}
+
+// The optional features what depend on the eliotd configuration, reported in the node capabilities
+// so that the clients can tell is the feature available without calling the API
+const (
+	FeaturePowerControl     = "power-control"
+	FeatureReconcilePause   = "reconcile-pause"
+	FeatureReconcileHistory = "reconcile-history"
+	FeatureAuthentication   = "authentication"
+	FeaturePullSecrets      = "pull-secrets"
+	FeatureInit             = "init"
+	FeatureEgressRateLimit  = "egress-rate-limit"
+	FeatureImageSignatures  = "image-signatures"
+	FeatureNamespaceQuota   = "namespace-quota"
+	FeatureSnapshotCleanup  = "snapshot-cleanup"
+	FeatureDebugEvents      = "debug-events"
+)
+
+// PluginStatus describes single containerd plugin state
+type PluginStatus struct {
+	// Type is the plugin type, e.g. io.containerd.snapshotter.v1
+	Type string
+	ID   string
+	// Error is the plugin initialisation error, empty if the plugin is working
+	Error string
+}
+
+// Snapshotter migration step kinds
+const (
+	MigrationImage     = "image"
+	MigrationContainer = "container"
+)
+
+// SnapshotterMigrationStep is the result of migrating one image or container to the new snapshotter
+type SnapshotterMigrationStep struct {
+	Namespace string
+	// Kind is MigrationImage or MigrationContainer
+	Kind string
+	// Name is the image name or the container ID
+	Name string
+	// Current is the step number and Total the number of steps in the namespace
+	Current int
+	Total   int
+	// Skipped is true if the image or container was already in the snapshotter, e.g. migrated earlier
+	Skipped bool
+	// The error message if the step failed
+	Error string
+}
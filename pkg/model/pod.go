@@ -1,8 +1,38 @@
 package model
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/containerd/containerd/identifiers"
+	"github.com/pkg/errors"
+)
+
 // DefaultNamespace is namespace what each pod get if there is no metadata.namespace
 var DefaultNamespace = "eliot"
 
+// SetDefaultNamespace overrides the DefaultNamespace, e.g. to isolate all eliot workloads into custom namespace
+func SetDefaultNamespace(namespace string) error {
+	if err := identifiers.Validate(namespace); err != nil {
+		return errors.Wrapf(err, "Invalid default namespace [%s]", namespace)
+	}
+	DefaultNamespace = namespace
+	return nil
+}
+
+// ResolveNamespace returns the namespace what the operation targets, the explicit namespace takes
+// precedence over the DefaultNamespace. All namespace defaulting must go through this so that
+// the authorization, the secrets and the runtime always resolve the same namespace
+func ResolveNamespace(namespace string) string {
+	if namespace == "" {
+		return DefaultNamespace
+	}
+	return namespace
+}
+
 // Pod is set of containers
 type Pod struct {
 	Metadata Metadata `validate:"required"`
@@ -16,12 +46,47 @@ type PodSpec struct {
 	HostPID       bool
 	Containers    []Container `validate:"required,gt=0,dive"`
 	RestartPolicy string
+	// StopGracePeriod is how long the pod containers have time to exit after SIGTERM before SIGKILL, zero means default
+	StopGracePeriod time.Duration `validate:"gte=0"`
+	// Resources limits the total CPU and memory of all pod containers together
+	Resources *Resources
+	// ImagePullSecrets are names of the pull secrets used for pulling the pod images
+	ImagePullSecrets []string `validate:"dive,alphanumOrDash"`
+	// LogLevel is injected to the containers as LogLevelEnv environment variable so that the whole pod can be
+	// switched to e.g. debug logging at once, LogLevelNode uses the eliotd log level
+	// Environment variable what the container defines explicitly is not overridden
+	LogLevel string `validate:"omitempty,noSpaces"`
+	// LogLevelEnv is the log level environment variable name, defaults to DefaultLogLevelEnv
+	LogLevelEnv string `validate:"omitempty,noSpaces"`
 }
 
+// RestartPolicyNever means the pod containers don't get restarted when they stop
+const RestartPolicyNever = "never"
+
+const (
+	// DefaultLogLevelEnv is the environment variable name for the log level if the pod doesn't define it
+	DefaultLogLevelEnv = "LOG_LEVEL"
+	// LogLevelNode is the log level value what means the eliotd log level
+	LogLevelNode = "node"
+)
+
 // PodStatus represents latest known state of pod
 type PodStatus struct {
 	Hostname          string
 	ContainerStatuses []ContainerStatus `validate:"dive"`
+	// SpecHash is the GetSpecHash of the pod what the containers were created from,
+	// empty if the containers were created before eliot recorded it
+	SpecHash string
+}
+
+// GetSpecHash returns hash of the pod spec, the pods with the same spec have the same hash
+func (p Pod) GetSpecHash() string {
+	data, err := json.Marshal(p.Spec)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
 }
 
 // AppendContainer adds container to the pod information
@@ -29,3 +94,36 @@ func (p *Pod) AppendContainer(container Container, status ContainerStatus) {
 	p.Spec.Containers = append(p.Spec.Containers, container)
 	p.Status.ContainerStatuses = append(p.Status.ContainerStatuses, status)
 }
+
+// SortByStartPriority returns the container statuses sorted by the container start priority, highest priority first
+// The statuses with the same priority keep their order
+func (p Pod) SortByStartPriority(statuses []ContainerStatus) []ContainerStatus {
+	priorities := p.getStartPriorities()
+	sorted := append([]ContainerStatus{}, statuses...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return priorities[sorted[i].Name] > priorities[sorted[j].Name]
+	})
+	return sorted
+}
+
+// GroupByStartPriority groups the container statuses by the container start priority, highest priority first
+// The statuses keep their order within the group
+func (p Pod) GroupByStartPriority(statuses []ContainerStatus) (result [][]ContainerStatus) {
+	priorities := p.getStartPriorities()
+	sorted := p.SortByStartPriority(statuses)
+	for i, status := range sorted {
+		if i == 0 || priorities[status.Name] != priorities[sorted[i-1].Name] {
+			result = append(result, nil)
+		}
+		result[len(result)-1] = append(result[len(result)-1], status)
+	}
+	return result
+}
+
+func (p Pod) getStartPriorities() map[string]int {
+	priorities := map[string]int{}
+	for _, container := range p.Spec.Containers {
+		priorities[container.Name] = container.StartPriority
+	}
+	return priorities
+}
@@ -26,6 +26,26 @@ func TestPodGetNamespace(t *testing.T) {
 	assert.Equal(t, pod.Metadata.Namespace, "foobar", "should return namespace from metadata")
 }
 
+func TestSetDefaultNamespace(t *testing.T) {
+	original := DefaultNamespace
+	defer func() { DefaultNamespace = original }()
+
+	assert.NoError(t, SetDefaultNamespace("workloads"))
+	assert.Equal(t, "workloads", DefaultNamespace)
+
+	assert.Error(t, SetDefaultNamespace("invalid/namespace"), "should reject invalid namespace")
+	assert.Equal(t, "workloads", DefaultNamespace, "should not change on invalid namespace")
+}
+
+func TestResolveNamespace(t *testing.T) {
+	original := DefaultNamespace
+	defer func() { DefaultNamespace = original }()
+
+	assert.Equal(t, "tenant-a", ResolveNamespace("tenant-a"))
+	assert.NoError(t, SetDefaultNamespace("workloads"))
+	assert.Equal(t, "workloads", ResolveNamespace(""), "should use the configured default namespace")
+}
+
 func TestValidationRequiresSpec(t *testing.T) {
 	assert.Error(t, getValidator().Struct(Pod{
 		Metadata: Metadata{Name: "foo"},
@@ -101,3 +121,25 @@ func TestValidationNamespaceMetadata(t *testing.T) {
 		},
 	}), "should return error if not alphanumeric namespace")
 }
+
+func TestGroupByStartPriority(t *testing.T) {
+	pod := Pod{
+		Spec: PodSpec{
+			Containers: []Container{
+				{Name: "app"},
+				{Name: "logging", StartPriority: 10},
+				{Name: "metrics"},
+				{Name: "cleanup", StartPriority: -1},
+			},
+		},
+	}
+	statuses := []ContainerStatus{{Name: "app"}, {Name: "logging"}, {Name: "metrics"}, {Name: "cleanup"}}
+
+	assert.Equal(t, [][]ContainerStatus{
+		{{Name: "logging"}},
+		{{Name: "app"}, {Name: "metrics"}},
+		{{Name: "cleanup"}},
+	}, pod.GroupByStartPriority(statuses), "should group by priority, highest first")
+	assert.Equal(t, []ContainerStatus{{Name: "logging"}, {Name: "app"}, {Name: "metrics"}, {Name: "cleanup"}}, pod.SortByStartPriority(statuses))
+	assert.Equal(t, "app", statuses[0].Name, "should not modify the given statuses")
+}
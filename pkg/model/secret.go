@@ -0,0 +1,28 @@
+package model
+
+// PullSecret is registry credential what pods can reference by name to pull private images
+type PullSecret struct {
+	Name string `validate:"required,alphanumOrDash"`
+	// Registry hostname, optionally with port, e.g. registry.example.com:5000
+	Registry string `validate:"required,noSpaces"`
+	// Username can be empty, then the password is used as long lived token
+	Username string
+	Password string `validate:"required"`
+}
+
+// Secret is named data what containers can get mounted as file, e.g. credentials or certificate
+type Secret struct {
+	Name string `validate:"required,alphanumOrDash"`
+	Data []byte
+}
+
+// dockerHubHost is the host what the docker.io image references get pulled from
+const dockerHubHost = "registry-1.docker.io"
+
+// MatchesHost returns true if the secret credentials are for the registry host
+func (s PullSecret) MatchesHost(host string) bool {
+	if s.Registry == "docker.io" {
+		return host == dockerHubHost || host == s.Registry
+	}
+	return host == s.Registry
+}
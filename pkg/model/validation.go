@@ -1,18 +1,46 @@
 package model
 
 import (
+	"encoding/json"
 	"log"
+	"net"
+	"path"
 	"regexp"
 	"strings"
 	"sync"
 
+	"github.com/containerd/containerd/identifiers"
 	imageref "github.com/containerd/containerd/reference"
+	"github.com/ernoaapa/eliot/pkg/cron"
 	validator "gopkg.in/go-playground/validator.v9"
 )
 
 var (
 	validate *validator.Validate
 	once     sync.Once
+
+	// ulimitNames are the supported process resource limits, see: man setrlimit
+	ulimitNames = []string{
+		"as", "core", "cpu", "data", "fsize", "locks", "memlock", "msgqueue",
+		"nice", "nofile", "nproc", "rss", "rtprio", "rttime", "sigpending", "stack",
+	}
+
+	// capabilityNames are the Linux capabilities, see: man capabilities
+	capabilityNames = []string{
+		"CAP_AUDIT_CONTROL", "CAP_AUDIT_READ", "CAP_AUDIT_WRITE", "CAP_BLOCK_SUSPEND", "CAP_BPF",
+		"CAP_CHECKPOINT_RESTORE", "CAP_CHOWN", "CAP_DAC_OVERRIDE", "CAP_DAC_READ_SEARCH", "CAP_FOWNER",
+		"CAP_FSETID", "CAP_IPC_LOCK", "CAP_IPC_OWNER", "CAP_KILL", "CAP_LEASE", "CAP_LINUX_IMMUTABLE",
+		"CAP_MAC_ADMIN", "CAP_MAC_OVERRIDE", "CAP_MKNOD", "CAP_NET_ADMIN", "CAP_NET_BIND_SERVICE",
+		"CAP_NET_BROADCAST", "CAP_NET_RAW", "CAP_PERFMON", "CAP_SETFCAP", "CAP_SETGID", "CAP_SETPCAP",
+		"CAP_SETUID", "CAP_SYS_ADMIN", "CAP_SYS_BOOT", "CAP_SYS_CHROOT", "CAP_SYS_MODULE", "CAP_SYS_NICE",
+		"CAP_SYS_PACCT", "CAP_SYS_PTRACE", "CAP_SYS_RAWIO", "CAP_SYS_RESOURCE", "CAP_SYS_TIME",
+		"CAP_SYS_TTY_CONFIG", "CAP_SYSLOG", "CAP_WAKE_ALARM",
+	}
+
+	// namespacedSysctls are the sysctls what the IPC namespace isolates, see: man ipc_namespaces
+	namespacedSysctls = []string{"kernel.msgmax", "kernel.msgmnb", "kernel.msgmni", "kernel.sem", "kernel.shmall", "kernel.shmmax", "kernel.shmmni", "kernel.shm_rmid_forced"}
+	// namespacedSysctlPrefixes are the sysctl groups what the IPC and network namespaces isolate
+	namespacedSysctlPrefixes = []string{"fs.mqueue.", "net."}
 )
 
 func getValidator() *validator.Validate {
@@ -37,6 +65,51 @@ func getValidator() *validator.Validate {
 		validate.RegisterValidation("envKeyValuePair", func(fl validator.FieldLevel) bool {
 			return IsValidEnvKeyValuePair(fl.Field().Interface().(string))
 		})
+		validate.RegisterValidation("containerID", func(fl validator.FieldLevel) bool {
+			return IsValidContainerID(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("cgroupParent", func(fl validator.FieldLevel) bool {
+			return IsValidCgroupParent(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("absolutePath", func(fl validator.FieldLevel) bool {
+			return IsValidAbsolutePath(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("hostIPPair", func(fl validator.FieldLevel) bool {
+			return IsValidHostIPPair(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("mountPropagation", func(fl validator.FieldLevel) bool {
+			return IsValidMountPropagation(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("ulimitName", func(fl validator.FieldLevel) bool {
+			return IsValidUlimitName(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("jsonObject", func(fl validator.FieldLevel) bool {
+			return IsValidJSONObject(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("capability", func(fl validator.FieldLevel) bool {
+			return IsValidCapability(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("namespacedSysctl", func(fl validator.FieldLevel) bool {
+			return IsNamespacedSysctl(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("cronSchedule", func(fl validator.FieldLevel) bool {
+			return IsValidCronSchedule(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("logDriver", func(fl validator.FieldLevel) bool {
+			return IsValidLogDriver(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("devicePermissions", func(fl validator.FieldLevel) bool {
+			return IsValidDevicePermissions(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("accelerator", func(fl validator.FieldLevel) bool {
+			return IsValidAccelerator(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("idleSignal", func(fl validator.FieldLevel) bool {
+			return IsValidIdleSignal(fl.Field().Interface().(string))
+		})
+		validate.RegisterValidation("swapLimit", func(fl validator.FieldLevel) bool {
+			return IsValidMemorySwapLimit(fl.Parent().Interface().(Resources))
+		})
 	})
 	return validate
 }
@@ -88,6 +161,103 @@ func IsValidEnvKeyValuePair(value string) bool {
 	return true
 }
 
+// IsValidContainerID return true if value can be used as container identifier
+// I.e. alphanumeric separated with dots, dashes or underscores and max 76 characters
+func IsValidContainerID(value string) bool {
+	return identifiers.Validate(value) == nil
+}
+
+// IsValidCgroupParent return true if value is absolute, clean cgroup path (e.g. /eliot.slice)
+func IsValidCgroupParent(value string) bool {
+	return IsValidAbsolutePath(value)
+}
+
+// IsValidAbsolutePath return true if value is absolute, clean path (e.g. /tmp)
+func IsValidAbsolutePath(value string) bool {
+	return strings.HasPrefix(value, "/") && path.Clean(value) == value
+}
+
+// IsValidHostIPPair return true if value is valid formated extra host entry (e.g. hostname:192.168.1.1)
+func IsValidHostIPPair(value string) bool {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || containsSpaces(parts[0]) {
+		return false
+	}
+	return net.ParseIP(parts[1]) != nil
+}
+
+// IsValidUlimitName return true if value is supported process resource limit name (e.g. nofile)
+func IsValidUlimitName(value string) bool {
+	for _, name := range ulimitNames {
+		if value == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidMountPropagation return true if value is supported mount propagation mode (e.g. rslave)
+func IsValidMountPropagation(value string) bool {
+	for _, mode := range MountPropagationModes {
+		if value == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidJSONObject return true if value is JSON object, e.g. {"process":{"noNewPrivileges":true}}
+func IsValidJSONObject(value string) bool {
+	var object map[string]interface{}
+	return json.Unmarshal([]byte(value), &object) == nil && object != nil
+}
+
+// IsValidCapability return true if value is Linux capability name in uppercase with CAP_ prefix (e.g. CAP_NET_RAW)
+func IsValidCapability(value string) bool {
+	for _, name := range capabilityNames {
+		if value == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNamespacedSysctl return true if value is sysctl what affects only the container namespaces (e.g. net.core.somaxconn)
+func IsNamespacedSysctl(value string) bool {
+	for _, name := range namespacedSysctls {
+		if value == name {
+			return true
+		}
+	}
+	for _, prefix := range namespacedSysctlPrefixes {
+		if strings.HasPrefix(value, prefix) && len(value) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNetworkSysctl return true if value is network namespace sysctl, those change the host settings
+// if the container runs in the host network namespace
+func IsNetworkSysctl(value string) bool {
+	return strings.HasPrefix(value, "net.")
+}
+
+// IsValidPullSecretName return true if value can be used as pull secret name, i.e. alphanumeric or dash
+func IsValidPullSecretName(value string) bool {
+	return isAlphanumericOrDash(value)
+}
+
+// ValidatePullSecret validates given pull secret
+func ValidatePullSecret(secret PullSecret) error {
+	return getValidator().Struct(secret)
+}
+
+// ValidateSecret validates given secret
+func ValidateSecret(secret Secret) error {
+	return getValidator().Struct(secret)
+}
+
 // Validate validates given pod definitions
 func Validate(pods []Pod) error {
 	validate := getValidator()
@@ -108,3 +278,64 @@ func Validate(pods []Pod) error {
 
 	return nil
 }
+
+// IsValidCronSchedule return true if value is five field cron expression (e.g. "*/15 * * * *") or macro (e.g. @daily)
+func IsValidCronSchedule(value string) bool {
+	_, err := cron.Parse(value)
+	return err == nil
+}
+
+// IsValidLogDriver return true if value is one of the supported LogDrivers
+func IsValidLogDriver(value string) bool {
+	for _, driver := range LogDrivers {
+		if value == driver {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidIdleSignal return true if value is one of the supported IdleSignals
+func IsValidIdleSignal(value string) bool {
+	for _, signal := range IdleSignals {
+		if value == signal {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidDevicePermissions return true if value is non-empty combination of r, w and m without duplicates
+func IsValidDevicePermissions(value string) bool {
+	if value == "" {
+		return false
+	}
+	for i, c := range value {
+		if !strings.ContainsRune(DefaultDevicePermissions, c) || strings.ContainsRune(value[i+1:], c) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidAccelerator return true if value is one of the supported Accelerators
+func IsValidAccelerator(value string) bool {
+	for _, accelerator := range Accelerators {
+		if value == accelerator {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidMemorySwapLimit return true if the swap limit is not set, or the memory limit is set and the swap limit
+// is -1 (unlimited) or at least the memory limit
+func IsValidMemorySwapLimit(resources Resources) bool {
+	if resources.MemorySwapLimit == 0 {
+		return true
+	}
+	if resources.MemoryLimit == 0 {
+		return false
+	}
+	return resources.MemorySwapLimit == -1 || resources.MemorySwapLimit >= resources.MemoryLimit
+}
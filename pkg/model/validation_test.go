@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,3 +46,85 @@ func TestEnvKeyValuePairs(t *testing.T) {
 
 	assert.False(t, IsValidEnvKeyValuePair("%&%,foo"), "Should be invalid env key/value pair")
 }
+
+func TestHostIPPairs(t *testing.T) {
+	assert.True(t, IsValidHostIPPair("foo.local:192.168.1.10"), "Should be valid host ip pair")
+	assert.True(t, IsValidHostIPPair("foo:fe80::1"), "Should be valid host ip pair with IPv6 address")
+
+	assert.False(t, IsValidHostIPPair("foo"), "Should be invalid host ip pair without ip")
+	assert.False(t, IsValidHostIPPair(":192.168.1.10"), "Should be invalid host ip pair without hostname")
+	assert.False(t, IsValidHostIPPair("foo:bar"), "Should be invalid host ip pair with invalid ip")
+}
+
+func TestUlimitNameValidation(t *testing.T) {
+	assert.True(t, IsValidUlimitName("nofile"), "Should be valid ulimit name")
+	assert.True(t, IsValidUlimitName("nproc"), "Should be valid ulimit name")
+
+	assert.False(t, IsValidUlimitName("NOFILE"), "Should be invalid uppercase ulimit name")
+	assert.False(t, IsValidUlimitName("foo"), "Should be invalid unknown ulimit name")
+}
+
+func TestNamespacedSysctlValidation(t *testing.T) {
+	assert.True(t, IsNamespacedSysctl("net.ipv4.ip_unprivileged_port_start"), "Should be valid network sysctl")
+	assert.True(t, IsNamespacedSysctl("kernel.msgmax"), "Should be valid IPC sysctl")
+	assert.True(t, IsNamespacedSysctl("fs.mqueue.msg_max"), "Should be valid IPC sysctl")
+
+	assert.False(t, IsNamespacedSysctl("kernel.hostname"), "Should be invalid host-wide sysctl")
+	assert.False(t, IsNamespacedSysctl("net."), "Should be invalid sysctl without name")
+	assert.False(t, IsNamespacedSysctl("kernel.shm"), "Should be invalid partial sysctl name")
+}
+
+func TestMountPropagationValidation(t *testing.T) {
+	assert.True(t, IsValidMountPropagation("rslave"), "Should be valid mount propagation")
+	assert.True(t, IsValidMountPropagation("private"), "Should be valid mount propagation")
+
+	assert.False(t, IsValidMountPropagation("rbind"), "Should be invalid mount propagation")
+	assert.False(t, IsValidMountPropagation(""), "Should be invalid empty mount propagation")
+}
+
+func TestContainerIDValidation(t *testing.T) {
+	assert.True(t, IsValidContainerID("my-pod-foo-bcs2dtuv4a5b6cde7f80"), "Should be valid container id")
+	assert.True(t, IsValidContainerID("foo.bar_baz"), "Should be valid container id")
+
+	assert.False(t, IsValidContainerID("foo/bar"), "Should be invalid container id with slash")
+	assert.False(t, IsValidContainerID("-foo"), "Should be invalid container id starting with dash")
+	assert.False(t, IsValidContainerID(strings.Repeat("a", 77)), "Should be invalid too long container id")
+}
+
+func TestCgroupParentValidation(t *testing.T) {
+	assert.True(t, IsValidCgroupParent("/eliot.slice"), "Should be valid cgroup parent")
+	assert.True(t, IsValidCgroupParent("/system.slice/eliot"), "Should be valid cgroup parent")
+
+	assert.False(t, IsValidCgroupParent("eliot.slice"), "Should be invalid relative cgroup parent")
+	assert.False(t, IsValidCgroupParent("/eliot/../other"), "Should be invalid cgroup parent with parent reference")
+	assert.False(t, IsValidCgroupParent("/eliot/"), "Should be invalid cgroup parent with trailing slash")
+}
+
+func TestJSONObjectValidation(t *testing.T) {
+	assert.True(t, IsValidJSONObject(`{"process":{"noNewPrivileges":true}}`), "Should be valid JSON object")
+	assert.True(t, IsValidJSONObject(`{}`), "Should be valid empty JSON object")
+
+	assert.False(t, IsValidJSONObject(`[]`), "Should be invalid JSON array")
+	assert.False(t, IsValidJSONObject(`null`), "Should be invalid JSON null")
+	assert.False(t, IsValidJSONObject(`{"process":`), "Should be invalid malformed JSON")
+}
+
+func TestMemorySwapLimitValidation(t *testing.T) {
+	pod := func(resources Resources) []Pod {
+		return []Pod{{
+			Metadata: Metadata{Name: "foo"},
+			Spec: PodSpec{
+				Containers: []Container{{Name: "foo-1", Image: "docker.io/library/foobar", Resources: &resources}},
+			},
+		}}
+	}
+	assert.NoError(t, Validate(pod(Resources{MemoryLimit: 1024})), "should allow default swap")
+	assert.NoError(t, Validate(pod(Resources{MemoryLimit: 1024, MemorySwapLimit: 1024})), "should allow disabling swap")
+	assert.NoError(t, Validate(pod(Resources{MemoryLimit: 1024, MemorySwapLimit: 4096})), "should allow swap limit")
+	assert.NoError(t, Validate(pod(Resources{MemoryLimit: 1024, MemorySwapLimit: -1})), "should allow unlimited swap")
+	assert.Error(t, Validate(pod(Resources{MemoryLimit: 1024, MemorySwapLimit: 512})), "should not allow swap limit less than memory limit")
+	assert.Error(t, Validate(pod(Resources{MemoryLimit: 1024, MemorySwapLimit: -2})), "should not allow negative swap limit")
+	assert.Error(t, Validate(pod(Resources{MemorySwapLimit: 4096})), "should require memory limit")
+	assert.Error(t, Validate(pod(Resources{MemorySwapLimit: -1})), "should require memory limit for unlimited swap")
+	assert.NoError(t, Validate(pod(Resources{})), "should allow no limits")
+}
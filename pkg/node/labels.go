@@ -0,0 +1,89 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GetLabels returns copy of the current node labels
+func (r *Resolver) GetLabels() map[string]string {
+	r.labelsMu.RLock()
+	defer r.labelsMu.RUnlock()
+	return copyLabels(r.labels)
+}
+
+// UpdateLabels sets and removes the node labels and sends the changed labels to the subscribers,
+// e.g. when provisioning agent resolves the device location after boot. The eliot.io/ labels
+// describe the host and cannot be changed. Returns the labels after the update
+func (r *Resolver) UpdateLabels(set map[string]string, remove []string) (map[string]string, error) {
+	for key := range set {
+		if err := validateLabelKey(key); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range remove {
+		if err := validateLabelKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	r.labelsMu.Lock()
+	defer r.labelsMu.Unlock()
+
+	labels := copyLabels(r.labels)
+	for _, key := range remove {
+		delete(labels, key)
+	}
+	for key, value := range set {
+		labels[key] = value
+	}
+	r.labels = labels
+
+	for subscriber := range r.subscribers {
+		publishLabels(subscriber, copyLabels(labels))
+	}
+	return copyLabels(labels), nil
+}
+
+// SubscribeLabels returns channel what receives the node labels after every change and function
+// what ends the subscription. Subscriber what doesn't keep up receives only the latest labels
+func (r *Resolver) SubscribeLabels() (<-chan map[string]string, func()) {
+	r.labelsMu.Lock()
+	defer r.labelsMu.Unlock()
+
+	subscriber := make(chan map[string]string, 1)
+	r.subscribers[subscriber] = true
+	return subscriber, func() {
+		r.labelsMu.Lock()
+		defer r.labelsMu.Unlock()
+		delete(r.subscribers, subscriber)
+	}
+}
+
+// publishLabels replaces the labels what the subscriber haven't received yet so that the update never blocks
+func publishLabels(subscriber chan map[string]string, labels map[string]string) {
+	select {
+	case <-subscriber:
+	default:
+	}
+	subscriber <- labels
+}
+
+// validateLabelKey checks that the label can be advertised as key=value pair and is not host label
+func validateLabelKey(key string) error {
+	if key == "" || strings.ContainsAny(key, "=, ") {
+		return fmt.Errorf("Invalid label key [%s], must be non-empty and cannot contain '=', ',' or spaces", key)
+	}
+	if strings.HasPrefix(key, eliotLabelPrefix+"/") {
+		return fmt.Errorf("Label [%s] is host label, labels with %s/ prefix cannot be changed", key, eliotLabelPrefix)
+	}
+	return nil
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	result := make(map[string]string, len(labels))
+	for key, value := range labels {
+		result[key] = value
+	}
+	return result
+}
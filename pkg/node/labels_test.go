@@ -0,0 +1,47 @@
+package node
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateLabels(t *testing.T) {
+	resolver := NewResolver(5000, "test", map[string]string{"location": "unknown", "model": "pi3"}, map[string]string{})
+	changes, cancel := resolver.SubscribeLabels()
+	defer cancel()
+
+	labels, err := resolver.UpdateLabels(map[string]string{"location": "helsinki"}, []string{"model"})
+	assert.NoError(t, err)
+	assert.Equal(t, "helsinki", labels["location"])
+	assert.NotContains(t, labels, "model")
+	assert.Equal(t, labels, resolver.GetLabels())
+	assert.Equal(t, labels, <-changes, "should publish the changed labels")
+}
+
+func TestUpdateLabelsPublishesLatest(t *testing.T) {
+	resolver := NewResolver(5000, "test", map[string]string{}, map[string]string{})
+	changes, cancel := resolver.SubscribeLabels()
+	defer cancel()
+
+	_, err := resolver.UpdateLabels(map[string]string{"location": "helsinki"}, nil)
+	assert.NoError(t, err)
+	_, err = resolver.UpdateLabels(map[string]string{"location": "tampere"}, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "tampere", (<-changes)["location"], "should not block and keep only the latest labels")
+	assert.Len(t, changes, 0)
+}
+
+func TestUpdateLabelsRejectsInvalidKeys(t *testing.T) {
+	resolver := NewResolver(5000, "test", map[string]string{}, map[string]string{})
+
+	_, err := resolver.UpdateLabels(map[string]string{"eliot.io/arch": "mips"}, nil)
+	assert.Error(t, err, "should not allow changing host labels")
+	_, err = resolver.UpdateLabels(nil, []string{"eliot.io/os"})
+	assert.Error(t, err, "should not allow removing host labels")
+	_, err = resolver.UpdateLabels(map[string]string{"foo=bar": "baz"}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, runtime.GOOS, resolver.GetLabels()["eliot.io/os"])
+}
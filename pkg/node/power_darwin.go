@@ -0,0 +1,13 @@
+package node
+
+import "errors"
+
+// Reboot is not supported in Darwin (OSX), implemented just for development purpose
+func Reboot() error {
+	return errors.New("Reboot is not supported in darwin")
+}
+
+// Poweroff is not supported in Darwin (OSX), implemented just for development purpose
+func Poweroff() error {
+	return errors.New("Poweroff is not supported in darwin")
+}
@@ -0,0 +1,15 @@
+package node
+
+import "syscall"
+
+// Reboot flushes the filesystem buffers and restarts the node
+func Reboot() error {
+	syscall.Sync()
+	return syscall.Reboot(syscall.LINUX_REBOOT_CMD_RESTART)
+}
+
+// Poweroff flushes the filesystem buffers and shuts down the node
+func Poweroff() error {
+	syscall.Sync()
+	return syscall.Reboot(syscall.LINUX_REBOOT_CMD_POWER_OFF)
+}
@@ -7,7 +7,9 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 
+	"github.com/ernoaapa/eliot/pkg/model"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -17,15 +19,33 @@ var eliotLabelPrefix = "eliot.io"
 type Resolver struct {
 	grpcPort int
 	version  string
-	labels   map[string]string
+	groups   map[string]string
+
+	labelsMu    sync.RWMutex
+	labels      map[string]string
+	subscribers map[chan map[string]string]bool
 }
 
-// NewResolver creates new resolver with static node labels
-func NewResolver(grpcPort int, version string, labels map[string]string) *Resolver {
+// NewResolver creates new resolver with initial node labels and static groups
+// The labels can be changed at runtime with UpdateLabels
+func NewResolver(grpcPort int, version string, labels, groups map[string]string) *Resolver {
 	return &Resolver{
-		grpcPort: grpcPort,
-		version:  version,
-		labels:   withHostLabels(labels),
+		grpcPort:    grpcPort,
+		version:     version,
+		labels:      withHostLabels(labels),
+		groups:      groups,
+		subscribers: map[chan map[string]string]bool{},
+	}
+}
+
+// GetIdentity resolves the node identifiers and labels
+func (r *Resolver) GetIdentity() *model.NodeIdentity {
+	info := r.GetInfo()
+	return &model.NodeIdentity{
+		MachineID:  info.MachineID,
+		SystemUUID: info.SystemUUID,
+		BootID:     info.BootID,
+		Labels:     info.Labels,
 	}
 }
 
@@ -69,6 +89,42 @@ func getAddresses() (addresses []net.IP) {
 	return addresses
 }
 
+// getInterfaces returns the network interfaces what are up and have other than loopback addresses
+func getInterfaces() (result []model.NetworkInterface) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		log.Errorf("Unable to resolve network interfaces, cannot expose interfaces information: %s", err)
+		return result
+	}
+
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			log.Errorf("Error while resolving interface [%s] addresses: %s", iface.Name, err)
+			continue
+		}
+
+		addresses := []net.IP{}
+		for _, addr := range addrs {
+			if v, ok := addr.(*net.IPNet); ok {
+				addresses = append(addresses, v.IP)
+			}
+		}
+		if len(addresses) == 0 {
+			continue
+		}
+		result = append(result, model.NetworkInterface{
+			Name:      iface.Name,
+			MAC:       iface.HardwareAddr.String(),
+			Addresses: addresses,
+		})
+	}
+	return result
+}
+
 func resolveFirst(name string, resolvers ...func() string) string {
 	for _, resolver := range resolvers {
 		result := resolver()
@@ -81,6 +137,23 @@ func resolveFirst(name string, resolvers ...func() string) string {
 	return ""
 }
 
+// resolveOrFault resolves the value like resolveFirst, but instead of fallback resolver
+// records a fault when none of the resolvers could resolve the value and returns the fallback
+func resolveOrFault(faults *[]model.NodeFault, name, fallback string, resolvers ...func() string) string {
+	for _, resolver := range resolvers {
+		if result := resolver(); result != "" {
+			return result
+		}
+	}
+
+	log.Warnf("Failed to resolve %s, fallback to %q", name, fallback)
+	*faults = append(*faults, model.NodeFault{
+		Field:  name,
+		Reason: fmt.Sprintf("None of the sources provided value, using fallback %q", fallback),
+	})
+	return fallback
+}
+
 func fromEnv(name string) func() string {
 	return func() string {
 		return os.Getenv(name)
@@ -7,10 +7,12 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/version"
 )
 
 // GetInfo resolves information about the node
@@ -21,20 +23,36 @@ func (r *Resolver) GetInfo() *model.NodeInfo {
 	hostname, _ := os.Hostname()
 
 	return &model.NodeInfo{
-		Version:   r.version,
-		Uptime:    0,
-		Labels:    r.labels,
-		Arch:      runtime.GOARCH,
-		OS:        runtime.GOOS,
-		Hostname:  hostname,
-		Addresses: getAddresses(),
-		GrpcPort:  r.grpcPort,
+		Version:    r.version,
+		Uptime:     0,
+		Labels:     r.GetLabels(),
+		Groups:     r.groups,
+		Arch:       runtime.GOARCH,
+		OS:         runtime.GOOS,
+		Hostname:   hostname,
+		Addresses:  getAddresses(),
+		Interfaces: getInterfaces(),
+		GrpcPort:   r.grpcPort,
+
+		MinClientVersion: version.MinClientVersion,
 
 		MachineID:  parseFieldFromIoregOutput(ioregOutput, "IOPlatformSerialNumber"),
 		SystemUUID: parseFieldFromIoregOutput(ioregOutput, "IOPlatformUUID"),
 		BootID:     runCommandOrFail("/usr/bin/uuidgen"),
 
 		Filesystems: resolveFilesystems(),
+
+		// Development machines are expected to keep the clock in sync
+		Time:              time.Now(),
+		ClockSynchronized: true,
+	}
+}
+
+// GetStats resolves the node dynamic metrics
+// Note: Darwin (OSX) implementation is just for development purpose and returns only filesystems
+func (r *Resolver) GetStats() *model.NodeStats {
+	return &model.NodeStats{
+		Filesystems: resolveFilesystems(),
 	}
 }
 
@@ -1,60 +1,141 @@
 package node
 
 import (
+	"io/ioutil"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/version"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
-var mountTableFile = "/etc/mtab"
+var (
+	mountTableFile = "/etc/mtab"
+	// thermalZoneFile contains the CPU temperature in millidegrees Celsius
+	thermalZoneFile = "/sys/class/thermal/thermal_zone0/temp"
+)
+
+const (
+	// staUnsync is the adjtimex status bit what kernel sets when the clock is not synchronized
+	staUnsync = 0x0040
+	// timeError is the adjtimex clock state when the clock is not synchronized
+	timeError = 5
+	// maxClockError is the maximum estimated clock error in microseconds, same as the kernel NTP_PHASE_LIMIT
+	maxClockError = 16000000
+)
+
+// sysinfoLoadScale is the fixed point scale of the sysinfo load averages
+const sysinfoLoadScale = float64(1 << 16)
 
 // GetInfo resolves information about the node
 func (r *Resolver) GetInfo() *model.NodeInfo {
-	hostname, _ := os.Hostname()
-	return &model.NodeInfo{
-		Version:   r.version,
-		Uptime:    resolveUptime(),
-		Labels:    r.labels,
-		Arch:      runtime.GOARCH,
-		OS:        runtime.GOOS,
-		Hostname:  hostname,
-		Addresses: getAddresses(),
-		GrpcPort:  r.grpcPort,
-
-		MachineID: resolveFirst(
+	faults := []model.NodeFault{}
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Warnf("Failed to resolve hostname: %s", err)
+		faults = append(faults, model.NodeFault{Field: "Hostname", Reason: err.Error()})
+	}
+
+	info := &model.NodeInfo{
+		Version:    r.version,
+		Uptime:     resolveUptime(),
+		Labels:     r.GetLabels(),
+		Groups:     r.groups,
+		Arch:       runtime.GOARCH,
+		OS:         runtime.GOOS,
+		Hostname:   hostname,
+		Addresses:  getAddresses(),
+		Interfaces: getInterfaces(),
+		GrpcPort:   r.grpcPort,
+
+		MinClientVersion: version.MinClientVersion,
+
+		MachineID: resolveOrFault(
+			&faults,
 			"MachineID",
+			"unknown",
 			fromEnv("MACHINE_ID"),
 			fromFiles([]string{
 				"/etc/machine-id",
 				"/var/lib/dbus/machine-id",
 			}),
-			static("unknown"),
 		),
 
-		SystemUUID: resolveFirst(
+		SystemUUID: resolveOrFault(
+			&faults,
 			"SystemUUID",
+			"unknown",
 			fromFiles([]string{
 				"/sys/class/dmi/id/product_uuid",
 				"/proc/device-tree/system-id",
 				"/proc/device-tree/vm,uuid",
 				"/etc/machine-id",
 			}),
-			static("unknown"),
 		),
 
-		BootID: resolveFirst(
+		BootID: resolveOrFault(
+			&faults,
 			"BootID",
+			"unknown",
 			fromFiles([]string{
 				"/proc/sys/kernel/random/boot_id",
 			}),
-			static("unknown"),
 		),
 		Filesystems: resolveFilesystems(),
+		Time:        time.Now(),
+	}
+
+	synchronized, err := resolveClockSynchronized()
+	if err != nil {
+		log.Warnf("Failed to resolve clock synchronization status: %s", err)
+		faults = append(faults, model.NodeFault{Field: "ClockSynchronized", Reason: err.Error()})
+	}
+	info.ClockSynchronized = synchronized
+	info.Faults = faults
+	return info
+}
+
+// GetStats resolves the node dynamic metrics
+func (r *Resolver) GetStats() *model.NodeStats {
+	stats := &model.NodeStats{
+		Temperature: resolveTemperature(),
+		Filesystems: resolveFilesystems(),
+	}
+
+	sysinfo := syscall.Sysinfo_t{}
+	if err := syscall.Sysinfo(&sysinfo); err != nil {
+		log.Warnf("Cannot resolve load and memory stats. Error: %s", err)
+		return stats
 	}
+
+	stats.Uptime = uint64(sysinfo.Uptime)
+	stats.Load1 = float64(sysinfo.Loads[0]) / sysinfoLoadScale
+	stats.Load5 = float64(sysinfo.Loads[1]) / sysinfoLoadScale
+	stats.Load15 = float64(sysinfo.Loads[2]) / sysinfoLoadScale
+	stats.MemoryTotal = uint64(sysinfo.Totalram) * uint64(sysinfo.Unit)
+	stats.MemoryFree = uint64(sysinfo.Freeram) * uint64(sysinfo.Unit)
+	return stats
+}
+
+// resolveTemperature reads the CPU temperature, returns zero if not available (e.g. in VM)
+func resolveTemperature() int64 {
+	content, err := ioutil.ReadFile(thermalZoneFile)
+	if err != nil {
+		return 0
+	}
+
+	value, err := strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		log.Warnf("Invalid temperature value in %s: %s", thermalZoneFile, err)
+		return 0
+	}
+	return value
 }
 
 func resolveUptime() uint64 {
@@ -111,3 +192,17 @@ func getFilesystemUsage(path string) (total uint64, free uint64, available uint6
 
 	return uint64(stat.Blocks) * uint64(stat.Bsize), uint64(stat.Bfree) * uint64(stat.Bsize), uint64(stat.Bavail) * uint64(stat.Bsize), nil
 }
+
+// resolveClockSynchronized reads the kernel clock synchronization status with adjtimex
+func resolveClockSynchronized() (bool, error) {
+	timex := &syscall.Timex{}
+	state, err := syscall.Adjtimex(timex)
+	if err != nil {
+		return false, errors.Wrap(err, "adjtimex failed")
+	}
+	return isClockSynchronized(state, timex.Status, int64(timex.Maxerror)), nil
+}
+
+func isClockSynchronized(state int, status int32, maxError int64) bool {
+	return state != timeError && status&staUnsync == 0 && maxError < maxClockError
+}
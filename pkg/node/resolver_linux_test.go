@@ -1,6 +1,8 @@
 package node
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,7 +13,7 @@ func TestGetInfo(t *testing.T) {
 		"foo": "bar",
 	}
 
-	info := NewResolver(5000, "test-version", labels).GetInfo()
+	info := NewResolver(5000, "test-version", labels, nil).GetInfo()
 
 	assert.NotEmpty(t, info.BootID, "should resolve BootID")
 	assert.NotEmpty(t, info.MachineID, "should resolve MachineID")
@@ -30,3 +32,38 @@ func TestResolveUptime(t *testing.T) {
 	// Warning: we're assuming that we run in environment where is uptime info is available
 	assert.True(t, resolveUptime() > 0)
 }
+
+func TestGetStats(t *testing.T) {
+	stats := NewResolver(5000, "test-version", map[string]string{}, nil).GetStats()
+
+	assert.True(t, stats.MemoryTotal > 0, "should resolve total memory")
+	assert.True(t, stats.MemoryFree <= stats.MemoryTotal, "free memory should not exceed total")
+	assert.True(t, stats.Uptime > 0, "should resolve uptime")
+	assert.True(t, len(stats.Filesystems) > 0, "should have at least one disk")
+}
+
+func TestResolveTemperature(t *testing.T) {
+	file, err := ioutil.TempFile("", "temp")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString("48312\n")
+	assert.NoError(t, err)
+	file.Close()
+
+	original := thermalZoneFile
+	defer func() { thermalZoneFile = original }()
+
+	thermalZoneFile = file.Name()
+	assert.Equal(t, int64(48312), resolveTemperature())
+
+	thermalZoneFile = "/non/existing/temp"
+	assert.Equal(t, int64(0), resolveTemperature(), "should fallback to zero when temperature not available")
+}
+
+func TestIsClockSynchronized(t *testing.T) {
+	assert.True(t, isClockSynchronized(0, 0x2001, 500000))
+	assert.False(t, isClockSynchronized(0, 0x2001|staUnsync, 500000), "should not be synchronized if kernel have set STA_UNSYNC")
+	assert.False(t, isClockSynchronized(timeError, 0x2001, 500000), "should not be synchronized if clock state is TIME_ERROR")
+	assert.False(t, isClockSynchronized(0, 0x2001, maxClockError), "should not be synchronized if estimated error is too big")
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/ernoaapa/eliot/pkg/model"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,6 +24,22 @@ func TestResolveFirst(t *testing.T) {
 	}))
 }
 
+func TestResolveOrFault(t *testing.T) {
+	faults := []model.NodeFault{}
+
+	assert.Equal(t, "first", resolveOrFault(&faults, "foofield", "unknown", func() string {
+		return "first"
+	}))
+	assert.Empty(t, faults, "should not record fault when value resolved")
+
+	assert.Equal(t, "unknown", resolveOrFault(&faults, "foofield", "unknown", func() string {
+		return "" // Mimic the case that cannot resolve
+	}))
+	assert.Len(t, faults, 1)
+	assert.Equal(t, "foofield", faults[0].Field)
+	assert.NotEmpty(t, faults[0].Reason)
+}
+
 func TestFromEnv(t *testing.T) {
 	os.Setenv("TESTING", "foo")
 	assert.Equal(t, "foo", fromEnv("TESTING")())
@@ -40,3 +57,19 @@ func TestFromFiles(t *testing.T) {
 	assert.Equal(t, "foobar", fromFiles([]string{filePath})())
 
 }
+
+func TestGetIdentity(t *testing.T) {
+	labels := map[string]string{
+		"foo": "bar",
+	}
+	resolver := NewResolver(5000, "test-version", labels, nil)
+
+	identity := resolver.GetIdentity()
+	info := resolver.GetInfo()
+
+	assert.Equal(t, info.MachineID, identity.MachineID, "should match node info MachineID")
+	assert.Equal(t, info.SystemUUID, identity.SystemUUID, "should match node info SystemUUID")
+	assert.Equal(t, info.BootID, identity.BootID, "should match node info BootID")
+	assert.Equal(t, labels, identity.Labels, "should have given node labels")
+	assert.Equal(t, identity.MachineID, resolver.GetIdentity().MachineID, "should be stable between calls")
+}
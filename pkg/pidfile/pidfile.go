@@ -0,0 +1,102 @@
+package pidfile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// File is the pidfile what the current process holds locked until it gets removed
+type File struct {
+	path string
+	file *os.File
+}
+
+// Write locks the file and writes the current process PID to it for the init scripts and the monitoring tools
+// Fails if another process holds the lock, the kernel releases the lock when the process exits so
+// the file left by crashed process gets replaced even if its PID has been reused
+func Write(path string) (*File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, errors.Wrapf(err, "Failed to create pidfile directory")
+	}
+	file, err := lock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if pid, err := Read(path); err == nil && pid != os.Getpid() {
+		log.Warnf("Replacing stale pidfile [%s], process %d doesn't hold it anymore", path, pid)
+	}
+	// The file gets written in place because replacing it would leave the lock to the old file
+	if err := file.Truncate(0); err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "Failed to write pidfile [%s]", path)
+	}
+	if _, err := file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0); err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "Failed to write pidfile [%s]", path)
+	}
+	return &File{path: path, file: file}, nil
+}
+
+// lock opens and locks the file, the open gets retried if the process what held the lock removed the file
+// in the meantime, because the lock of the removed file doesn't prevent another process locking the path
+func lock(path string) (*os.File, error) {
+	for {
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to open pidfile [%s]", path)
+		}
+		if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+			file.Close()
+			if err == syscall.EWOULDBLOCK {
+				return nil, fmt.Errorf("Pidfile [%s] is locked by another process, is another eliotd running?", path)
+			}
+			return nil, errors.Wrapf(err, "Failed to lock pidfile [%s]", path)
+		}
+
+		locked, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, errors.Wrapf(err, "Failed to lock pidfile [%s]", path)
+		}
+		current, err := os.Stat(path)
+		if err == nil && os.SameFile(locked, current) {
+			return file, nil
+		}
+		file.Close()
+		if err != nil && !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "Failed to lock pidfile [%s]", path)
+		}
+	}
+}
+
+// Read returns the PID from the file
+func Read(path string) (int, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("Pidfile [%s] doesn't contain valid pid", path)
+	}
+	return pid, nil
+}
+
+// Remove removes the file and releases the lock, the file gets removed while locked
+// so that another process cannot lock the file what is about to be removed
+func (f *File) Remove() error {
+	defer f.file.Close()
+	if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "Failed to remove pidfile [%s]", f.path)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package pidfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteAndRemove(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pidfile")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "run", "eliotd.pid")
+
+	file, err := Write(path)
+	assert.NoError(t, err)
+	pid, err := Read(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.Getpid(), pid)
+
+	assert.NoError(t, file.Remove())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "should remove the pidfile")
+}
+
+func TestWriteReplacesStalePidfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pidfile")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "eliotd.pid")
+
+	// The pid of running process, e.g. reused after reboot, but nothing holds the lock
+	assert.NoError(t, ioutil.WriteFile(path, []byte(strconv.Itoa(os.Getppid())+"\n"), 0644))
+	file, err := Write(path)
+	assert.NoError(t, err, "should replace pidfile what no process holds")
+	assert.NoError(t, file.Remove())
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("a much longer garbage content"), 0644))
+	file, err = Write(path)
+	assert.NoError(t, err, "should replace invalid pidfile")
+	defer file.Remove()
+
+	pid, err := Read(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.Getpid(), pid)
+}
+
+func TestWriteFailsIfLocked(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pidfile")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "eliotd.pid")
+
+	file, err := Write(path)
+	assert.NoError(t, err)
+
+	// The lock belongs to the open file, so the second open doesn't get it even in the same process
+	_, err = Write(path)
+	assert.Error(t, err, "should not replace pidfile what another process holds")
+
+	assert.NoError(t, file.Remove())
+	file, err = Write(path)
+	assert.NoError(t, err, "should write after the lock got released")
+	assert.NoError(t, file.Remove())
+}
@@ -89,11 +89,11 @@ func (p *HumanReadablePrinter) PrintNodes(nodes []*node.Info, writer io.Writer)
 		fmt.Fprintf(writer, "\n\t(No nodes)\n\n")
 		return nil
 	}
-	fmt.Fprintln(writer, "\nHOSTNAME\tENDPOINT\tVERSION")
+	fmt.Fprintln(writer, "\nHOSTNAME\tENDPOINT\tVERSION\tGROUPS")
 
 	for _, node := range nodes {
 		endpoint := fmt.Sprintf("%s:%d", utils.GetFirst(node.Addresses, ""), node.GrpcPort)
-		_, err := fmt.Fprintf(writer, "%s\t%s\t%s\n", node.Hostname, endpoint, node.Version)
+		_, err := fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", node.Hostname, endpoint, node.Version, formatGroups(node.Groups))
 		if err != nil {
 			return errors.Wrapf(err, "Error while writing node row")
 		}
@@ -107,12 +107,16 @@ func (p *HumanReadablePrinter) PrintNode(info *node.Info, writer io.Writer) erro
 	t := template.New("node-details").Funcs(template.FuncMap{
 		"FormatPercent": formatPercent,
 		"FormatUptime":  formatUptime,
+		"FormatUnixTime": func(seconds int64) string {
+			return time.Unix(seconds, 0).Format(time.RFC3339)
+		},
 		"Subtract": func(a, b uint64) uint64 {
 			return a - b
 		},
 		"FormatBytes": func(v uint64) string {
 			return datasize.ByteSize(v).HumanReadable()
 		},
+		"Join": strings.Join,
 	})
 	t, err := t.Parse(humanreadable.NodeDetailsTemplate)
 	if err != nil {
@@ -149,6 +153,19 @@ func formatUptime(uptime uint64) string {
 	return durafmt.Parse(duration).String()
 }
 
+// formatGroups returns the node groups as sorted comma separated key=value list
+func formatGroups(groups []*node.Label) string {
+	if len(groups) == 0 {
+		return "-"
+	}
+	result := []string{}
+	for _, group := range groups {
+		result = append(result, fmt.Sprintf("%s=%s", group.Key, group.Value))
+	}
+	sort.Strings(result)
+	return strings.Join(result, ",")
+}
+
 // PrintPod writes a pod in human readable detailed format to the writer
 func (p *HumanReadablePrinter) PrintPod(pod *pods.Pod, writer io.Writer) error {
 	t := template.New("pod-details").Funcs(template.FuncMap{
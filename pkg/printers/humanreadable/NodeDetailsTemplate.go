@@ -5,16 +5,35 @@ const NodeDetailsTemplate = `Hostname:	{{.Hostname}}
 Uptime:	{{FormatUptime .Uptime}}
 Arch/OS:	{{.Os}}/{{.Arch}}
 Version:	{{.Version}}
+Time:	{{FormatUnixTime .Time}}{{if not .ClockSynchronized}} (clock not synchronized){{end}}
 Labels:{{range .Labels}}
 	{{.Key}}={{.Value}}
 {{- end}}
+{{- if .Groups }}
+Groups:{{range .Groups}}
+	{{.Key}}={{.Value}}
+{{- end}}
+{{- end}}
 Addresses:{{range .Addresses}}
 	{{.}}
 {{- end}}
+{{- if .Interfaces }}
+Interfaces:
+	Name	MAC	Addresses
+	----	---	---------
+{{- range .Interfaces}}
+	{{.Name}}	{{.Mac}}	{{Join .Addresses ","}}
+{{- end}}
+{{- end}}
 GrpcPort:	{{.GrpcPort}}
 MachineID:	{{.MachineID}}
 SystemUUID:	{{.SystemUUID}}
 BootID:	{{.BootID}}
+{{- if .Faults }}
+Faults:{{range .Faults}}
+	{{.Field}}: {{.Reason}}
+{{- end}}
+{{- end}}
 {{- if .Filesystems }}
 Filesystems:
 	Filesystem	Type	Size	Used	Available	Use%	Mounted on
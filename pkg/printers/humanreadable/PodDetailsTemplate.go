@@ -16,7 +16,11 @@ Containers:{{range .Pod.Spec.Containers}}
     {{- if $status }}
 		ContainerID:	{{$status.ContainerID}}
 		State:	{{$status.State}}
+		Ready:	{{$status.Ready}}
 		Restart Count:	{{$status.RestartCount}}
+		{{- if $status.LastExitReason}}
+		Last Exit:	{{$status.LastExitReason}} (exit code {{$status.LastExitCode}})
+		{{- end}}
 		Working Dir:	{{.WorkingDir}}
 		{{- end}}
 		Args:{{range .Args}}
@@ -26,7 +30,13 @@ Containers:{{range .Pod.Spec.Containers}}
 			- {{.}}
 		{{- end}}
 		Mounts:{{range .Mounts}}
-			- type={{.Type}},source={{.Source}},destination={{.Destination}},options={{StringsJoin .Options ":"}}
+			- type={{.Type}},source={{.Source}},destination={{.Destination}},options={{StringsJoin .Options ":"}}{{if .Propagation}},propagation={{.Propagation}}{{end}}
+		{{- end}}
+    {{- if .LivenessProbe}}
+		Liveness:	exec [{{StringsJoin .LivenessProbe.Exec " "}}] delay={{.LivenessProbe.InitialDelaySeconds}}s period={{.LivenessProbe.PeriodSeconds}}s timeout={{.LivenessProbe.TimeoutSeconds}}s failures={{.LivenessProbe.FailureThreshold}}
+		{{- end}}
+    {{- if .ReadinessProbe}}
+		Readiness:	exec [{{StringsJoin .ReadinessProbe.Exec " "}}] delay={{.ReadinessProbe.InitialDelaySeconds}}s period={{.ReadinessProbe.PeriodSeconds}}s timeout={{.ReadinessProbe.TimeoutSeconds}}s failures={{.ReadinessProbe.FailureThreshold}}
 		{{- end}}
     {{- if .Pipe}}
 		Pipe:
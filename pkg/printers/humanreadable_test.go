@@ -3,6 +3,7 @@ package printers
 import (
 	"testing"
 
+	node "github.com/ernoaapa/eliot/pkg/api/services/node/v1"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,3 +16,8 @@ func TestFormatDuration(t *testing.T) {
 	assert.Equal(t, "292 years 24 weeks 3 days 23 hours 47 minutes 16 seconds", formatUptime(9223372036), "should format large value (maximum Nanosecond duration in seconds)")
 	assert.Equal(t, "18446744073709551615 seconds", formatUptime(18446744073709551615), "Should not break if goes above int64 (e.g. if maximum uint64)")
 }
+
+func TestFormatGroups(t *testing.T) {
+	assert.Equal(t, "-", formatGroups(nil))
+	assert.Equal(t, "customer=acme,region=eu", formatGroups([]*node.Label{{Key: "region", Value: "eu"}, {Key: "customer", Value: "acme"}}))
+}
@@ -80,6 +80,7 @@ func testPrintNode(t *testing.T, printer ResourcePrinter) {
 		Labels:     []*node.Label{{Key: "foo", Value: "bar"}},
 		Hostname:   "foo-bar",
 		Addresses:  []string{"1.2.3.4"},
+		Interfaces: []*node.NetworkInterface{{Name: "eth0", Mac: "b8:27:eb:12:34:56", Addresses: []string{"1.2.3.4"}}},
 		GrpcPort:   5000,
 		MachineID:  "1234-5678",
 		SystemUUID: "asdf-jklö",
@@ -2,6 +2,7 @@ package progress
 
 import (
 	"sync"
+	"time"
 )
 
 // ImageFetch stores container pull status
@@ -10,10 +11,25 @@ type ImageFetch struct {
 	Image       string
 	Resolved    bool
 	Failed      bool
+	Stats       *PullStats
+	phases      *Phases
 	layers      map[string]*Status
 	mu          sync.Mutex
 }
 
+// PullStats summarises the completed pull
+type PullStats struct {
+	// Cached is true if all the image content was already in the content store
+	Cached bool
+	// Duration is how long the pull and unpack took
+	Duration time.Duration
+	// BytesFetched is the size of the content what wasn't in the content store before the pull
+	BytesFetched int64
+	// Layers is the number of the image layers and FetchedLayers how many of them got fetched
+	Layers        int
+	FetchedLayers int
+}
+
 // Status represents single layer ref current progress
 type Status struct {
 	Ref    string
@@ -137,6 +153,34 @@ func (s *ImageFetch) SetToFailed() {
 	s.Failed = true
 }
 
+// TrackPhases makes the fetch record the pull phases to the phases with the ContainerID
+func (s *ImageFetch) TrackPhases(phases *Phases) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.phases = phases
+}
+
+// SetToUnpacking records that all layers are fetched and the image is being unpacked
+func (s *ImageFetch) SetToUnpacking() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.phases.Set(s.ContainerID, PhaseUnpacking)
+}
+
+// SetStats records the completed pull stats
+func (s *ImageFetch) SetStats(stats PullStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Stats = &stats
+}
+
+// GetStats returns the completed pull stats or nil if the pull is not completed
+func (s *ImageFetch) GetStats() *PullStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Stats
+}
+
 // AllDone marks all layers downloaded
 func (s *ImageFetch) AllDone() {
 	s.mu.Lock()
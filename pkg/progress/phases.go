@@ -0,0 +1,67 @@
+package progress
+
+import (
+	"sync"
+	"time"
+)
+
+// Container create and start phases in the order they happen
+const (
+	PhaseResolving    = "resolving"
+	PhasePulling      = "pulling"
+	PhaseUnpacking    = "unpacking"
+	PhaseCreating     = "creating"
+	PhaseCreatingTask = "creating-task"
+	PhaseStarting     = "starting"
+	PhaseRunning      = "running"
+	PhaseFailed       = "failed"
+)
+
+// Phase is single container create or start phase change
+type Phase struct {
+	Container string
+	Phase     string
+	Error     string
+	Time      time.Time
+}
+
+// Phases collects the container phase changes until they get sent
+type Phases struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	pending []Phase
+}
+
+// NewPhases creates new empty Phases
+func NewPhases() *Phases {
+	return &Phases{now: time.Now}
+}
+
+// Set records the container phase change, nil Phases ignores the change
+func (p *Phases) Set(container, phase string) {
+	p.add(Phase{Container: container, Phase: phase})
+}
+
+// Fail records the container failure
+func (p *Phases) Fail(container string, err error) {
+	p.add(Phase{Container: container, Phase: PhaseFailed, Error: err.Error()})
+}
+
+func (p *Phases) add(phase Phase) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	phase.Time = p.now()
+	p.pending = append(p.pending, phase)
+}
+
+// Take returns the phase changes recorded after the previous Take
+func (p *Phases) Take() []Phase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	result := p.pending
+	p.pending = nil
+	return result
+}
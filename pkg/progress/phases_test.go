@@ -0,0 +1,28 @@
+package progress
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhasesTake(t *testing.T) {
+	phases := NewPhases()
+	phases.Set("foo", PhasePulling)
+	phases.Fail("foo", errors.New("not found"))
+
+	result := phases.Take()
+	assert.Len(t, result, 2)
+	assert.Equal(t, PhasePulling, result[0].Phase)
+	assert.Equal(t, PhaseFailed, result[1].Phase)
+	assert.Equal(t, "not found", result[1].Error)
+	assert.False(t, result[0].Time.IsZero())
+
+	assert.Empty(t, phases.Take(), "should return only the new phases")
+}
+
+func TestNilPhasesIgnoresChanges(t *testing.T) {
+	var phases *Phases
+	phases.Set("foo", PhasePulling)
+}
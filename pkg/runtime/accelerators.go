@@ -0,0 +1,133 @@
+package runtime
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+)
+
+// accelerator lists the host files what the accelerator needs in the container
+type accelerator struct {
+	// required device node globs, each must match for the accelerator to be present
+	required []string
+	// optional device node globs get passed through if present
+	optional []string
+	// usbVendors are the USB vendor IDs of the accelerator, one must be connected if given
+	usbVendors []string
+	// libraries are the driver library globs what get bind mounted read-only to the same path if present
+	libraries []string
+}
+
+var (
+	// acceleratorRoot is prefixed to the paths when looking up the accelerator files
+	acceleratorRoot = ""
+
+	accelerators = map[string]accelerator{
+		model.AcceleratorCoral: {
+			required:  []string{"/dev/apex_*"},
+			libraries: []string{"/usr/lib/*/libedgetpu.so*"},
+		},
+		model.AcceleratorCoralUSB: {
+			// The accelerator changes the vendor ID once the runtime has loaded the firmware
+			// so the whole bus gets passed through
+			required:   []string{"/dev/bus/usb/*/*"},
+			usbVendors: []string{"1a6e", "18d1"},
+			libraries:  []string{"/usr/lib/*/libedgetpu.so*"},
+		},
+		model.AcceleratorJetson: {
+			required:  []string{"/dev/nvhost-ctrl", "/dev/nvmap"},
+			optional:  []string{"/dev/nvhost-*", "/dev/nvgpu/igpu0/*"},
+			libraries: []string{"/usr/lib/aarch64-linux-gnu/tegra", "/usr/local/cuda"},
+		},
+		model.AcceleratorNvidia: {
+			required:  []string{"/dev/nvidiactl", "/dev/nvidia[0-9]*"},
+			optional:  []string{"/dev/nvidia-uvm", "/dev/nvidia-uvm-tools", "/dev/nvidia-modeset"},
+			libraries: []string{"/usr/lib/*/libcuda.so*", "/usr/lib/*/libnvidia-*.so*", "/usr/bin/nvidia-smi"},
+		},
+	}
+)
+
+// resolveAccelerators returns the device nodes and the driver library paths of the accelerators
+// Returns ErrNotFound if some of the accelerators is not present on the host so that the container
+// doesn't get created without the accelerator it needs
+func resolveAccelerators(names []string) (devices []model.Device, libraries []string, err error) {
+	seen := map[string]bool{}
+	add := func(paths []string, result []string) []string {
+		for _, path := range paths {
+			if !seen[path] {
+				seen[path] = true
+				result = append(result, path)
+			}
+		}
+		return result
+	}
+
+	for _, name := range names {
+		spec, ok := accelerators[name]
+		if !ok {
+			return nil, nil, ErrWithMessagef(ErrNotSupported, "Unknown accelerator [%s], must be one of %s", name, strings.Join(model.Accelerators, ", "))
+		}
+
+		if len(spec.usbVendors) > 0 && !hasUSBVendor(spec.usbVendors) {
+			return nil, nil, ErrWithMessagef(ErrNotFound, "Accelerator [%s] is not present on the device, no USB device with vendor %s connected", name, strings.Join(spec.usbVendors, " or "))
+		}
+
+		var paths []string
+		for _, pattern := range spec.required {
+			matches := globHost(pattern)
+			if len(matches) == 0 {
+				return nil, nil, ErrWithMessagef(ErrNotFound, "Accelerator [%s] is not present on the device, [%s] not found, check that the driver is loaded", name, pattern)
+			}
+			paths = append(paths, matches...)
+		}
+		for _, pattern := range spec.optional {
+			paths = append(paths, globHost(pattern)...)
+		}
+		for _, path := range add(paths, nil) {
+			devices = append(devices, model.Device{HostPath: path})
+		}
+
+		for _, pattern := range spec.libraries {
+			libraries = add(globHost(pattern), libraries)
+		}
+	}
+	return devices, libraries, nil
+}
+
+// globHost returns the host paths what match the pattern
+func globHost(pattern string) (result []string) {
+	matches, _ := filepath.Glob(acceleratorRoot + pattern)
+	for _, match := range matches {
+		result = append(result, strings.TrimPrefix(match, acceleratorRoot))
+	}
+	return result
+}
+
+// hasUSBVendor returns true if USB device with some of the vendor IDs is connected
+func hasUSBVendor(vendors []string) bool {
+	for _, path := range globHost("/sys/bus/usb/devices/*/idVendor") {
+		vendor, err := ioutil.ReadFile(acceleratorRoot + path)
+		if err != nil {
+			continue
+		}
+		if contains(vendors, strings.TrimSpace(string(vendor))) {
+			return true
+		}
+	}
+	return false
+}
+
+// getLibraryMounts returns read-only bind mounts of the driver libraries to the same path
+func getLibraryMounts(libraries []string) (result []model.Mount) {
+	for _, library := range libraries {
+		result = append(result, model.Mount{
+			Type:        "bind",
+			Source:      library,
+			Destination: library,
+			Options:     []string{"rbind", "ro"},
+		})
+	}
+	return result
+}
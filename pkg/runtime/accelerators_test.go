@@ -0,0 +1,63 @@
+package runtime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func withAcceleratorRoot(t *testing.T, files ...string) func() {
+	dir, err := ioutil.TempDir("", "accelerators")
+	assert.NoError(t, err)
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte("18d1\n"), 0644))
+	}
+
+	original := acceleratorRoot
+	acceleratorRoot = dir
+	return func() {
+		acceleratorRoot = original
+		os.RemoveAll(dir)
+	}
+}
+
+func TestResolveAccelerators(t *testing.T) {
+	defer withAcceleratorRoot(t, "/dev/nvhost-ctrl", "/dev/nvhost-gpu", "/dev/nvmap", "/usr/lib/aarch64-linux-gnu/tegra/libcuda.so")()
+
+	devices, libraries, err := resolveAccelerators([]string{model.AcceleratorJetson})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Device{
+		{HostPath: "/dev/nvhost-ctrl"},
+		{HostPath: "/dev/nvmap"},
+		{HostPath: "/dev/nvhost-gpu"},
+	}, devices, "should list each device once")
+	assert.Equal(t, []string{"/usr/lib/aarch64-linux-gnu/tegra"}, libraries)
+}
+
+func TestResolveAcceleratorsNotPresent(t *testing.T) {
+	defer withAcceleratorRoot(t, "/dev/nvmap")()
+
+	_, _, err := resolveAccelerators([]string{model.AcceleratorJetson})
+	assert.True(t, IsNotFound(err), "should return not found if device is missing")
+
+	_, _, err = resolveAccelerators([]string{model.AcceleratorCoral})
+	assert.True(t, IsNotFound(err))
+}
+
+func TestResolveAcceleratorsUSB(t *testing.T) {
+	defer withAcceleratorRoot(t, "/dev/bus/usb/001/002")()
+
+	_, _, err := resolveAccelerators([]string{model.AcceleratorCoralUSB})
+	assert.True(t, IsNotFound(err), "should require the accelerator USB vendor")
+
+	defer withAcceleratorRoot(t, "/dev/bus/usb/001/002", "/sys/bus/usb/devices/1-1/idVendor")()
+	devices, _, err := resolveAccelerators([]string{model.AcceleratorCoralUSB})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Device{{HostPath: "/dev/bus/usb/001/002"}}, devices)
+}
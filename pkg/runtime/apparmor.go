@@ -0,0 +1,53 @@
+package runtime
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// appArmorEnabledPath tells if the kernel has AppArmor enabled
+	appArmorEnabledPath = "/sys/module/apparmor/parameters/enabled"
+	// appArmorProfilesPath lists the loaded profiles, one "<name> (<mode>)" per line
+	appArmorProfilesPath = "/sys/kernel/security/apparmor/profiles"
+)
+
+// unconfinedProfile is the built-in profile what doesn't need to be loaded
+const unconfinedProfile = "unconfined"
+
+// checkAppArmorProfile returns error if AppArmor is not enabled or the profile is not loaded,
+// otherwise runc would fail to start the container with much less clear error
+func checkAppArmorProfile(profile string) error {
+	enabled, err := ioutil.ReadFile(appArmorEnabledPath)
+	if err != nil || strings.TrimSpace(string(enabled)) != "Y" {
+		return ErrWithMessagef(ErrNotSupported, "AppArmor is not enabled on the host, cannot apply profile [%s]", profile)
+	}
+	if profile == unconfinedProfile {
+		return nil
+	}
+
+	file, err := os.Open(appArmorProfilesPath)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to read loaded AppArmor profiles from [%s]", appArmorProfilesPath)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.LastIndex(line, " ("); i >= 0 {
+			line = line[:i]
+		}
+		if line == profile {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrapf(err, "Failed to read loaded AppArmor profiles from [%s]", appArmorProfilesPath)
+	}
+	return ErrWithMessagef(ErrNotFound, "AppArmor profile [%s] is not loaded on the host, load it with apparmor_parser first", profile)
+}
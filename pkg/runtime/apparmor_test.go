@@ -0,0 +1,43 @@
+package runtime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func withAppArmorFiles(t *testing.T, enabled, profiles string) func() {
+	dir, err := ioutil.TempDir("", "apparmor")
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "enabled"), []byte(enabled), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "profiles"), []byte(profiles), 0644))
+
+	originalEnabled, originalProfiles := appArmorEnabledPath, appArmorProfilesPath
+	appArmorEnabledPath, appArmorProfilesPath = filepath.Join(dir, "enabled"), filepath.Join(dir, "profiles")
+	return func() {
+		appArmorEnabledPath, appArmorProfilesPath = originalEnabled, originalProfiles
+		os.RemoveAll(dir)
+	}
+}
+
+func TestCheckAppArmorProfile(t *testing.T) {
+	defer withAppArmorFiles(t, "Y\n", "docker-default (enforce)\n/usr/sbin/ntpd (complain)\n")()
+
+	assert.NoError(t, checkAppArmorProfile("docker-default"))
+	assert.NoError(t, checkAppArmorProfile("/usr/sbin/ntpd"))
+	assert.NoError(t, checkAppArmorProfile("unconfined"), "should not require loading unconfined profile")
+
+	err := checkAppArmorProfile("eliot-default")
+	assert.Equal(t, ErrNotFound, errors.Cause(err), "should fail if the profile is not loaded")
+}
+
+func TestCheckAppArmorProfileRequiresAppArmor(t *testing.T) {
+	defer withAppArmorFiles(t, "N\n", "")()
+
+	err := checkAppArmorProfile("docker-default")
+	assert.Equal(t, ErrNotSupported, errors.Cause(err))
+}
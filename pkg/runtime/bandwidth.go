@@ -0,0 +1,131 @@
+package runtime
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/extensions"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// bandwidthQdiscMajor is the tc htb qdisc handle what the container classes get created under
+	bandwidthQdiscMajor = 0x10
+	// bandwidthMinMinor and bandwidthMaxMinor limit the container class minor numbers,
+	// the low minors are left for the host own classes
+	bandwidthMinMinor = 0x100
+	bandwidthMaxMinor = 0xfffe
+)
+
+// bandwidthMu serialises the class allocation so that concurrent creates don't get the same class
+var bandwidthMu sync.Mutex
+
+// runTC executes the tc command and returns the output
+var runTC = func(args ...string) (string, error) {
+	output, err := exec.Command("tc", args...).CombinedOutput()
+	if err != nil {
+		return string(output), errors.Wrapf(err, "tc %s failed: %s", strings.Join(args, " "), strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
+
+// allocateBandwidthClass returns net_cls class ID for the container, the class ID gets derived from
+// the container ID and the next free one is used if some other container already has the class
+func allocateBandwidthClass(device, namespace, id string) (uint32, error) {
+	output, err := runTC("class", "show", "dev", device)
+	if err != nil {
+		return 0, err
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(namespace + "/" + id))
+	size := uint32(bandwidthMaxMinor - bandwidthMinMinor + 1)
+	start := hash.Sum32() % size
+	for i := uint32(0); i < size; i++ {
+		classID := uint32(bandwidthQdiscMajor)<<16 | (bandwidthMinMinor + (start+i)%size)
+		if !strings.Contains(output, fmt.Sprintf("class htb %s ", formatClassID(classID))) {
+			return classID, nil
+		}
+	}
+	return 0, fmt.Errorf("No free traffic classes left in device [%s]", device)
+}
+
+// ensureBandwidthClass creates the htb qdisc and the cgroup filter to the device if they don't exist
+// and sets the class rate, the tc configuration doesn't survive reboot so this runs at every container start
+func ensureBandwidthClass(device string, classID uint32, rate int64) error {
+	qdiscs, err := runTC("qdisc", "show", "dev", device)
+	if err != nil {
+		return err
+	}
+	parent := fmt.Sprintf("%x:", bandwidthQdiscMajor)
+	if !strings.Contains(qdiscs, "qdisc htb "+parent+" root") {
+		if _, err := runTC("qdisc", "add", "dev", device, "root", "handle", parent, "htb"); err != nil {
+			return err
+		}
+		log.Infof("Added htb qdisc to device [%s] for container bandwidth limits", device)
+	}
+
+	filters, err := runTC("filter", "show", "dev", device, "parent", parent)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(filters, "cgroup") {
+		if _, err := runTC("filter", "add", "dev", device, "parent", parent, "protocol", "all", "prio", "10", "handle", "1:", "cgroup"); err != nil {
+			return err
+		}
+	}
+
+	_, err = runTC("class", "replace", "dev", device, "parent", parent, "classid", formatClassID(classID), "htb", "rate", fmt.Sprintf("%dbit", rate))
+	return err
+}
+
+// removeBandwidthClass removes the container class, missing class is not an error
+func removeBandwidthClass(device string, classID uint32) {
+	if _, err := runTC("class", "del", "dev", device, "classid", formatClassID(classID)); err != nil {
+		log.Debugf("Failed to remove traffic class [%s] from device [%s]: %s", formatClassID(classID), device, err)
+	}
+}
+
+// formatClassID formats the net_cls class ID in tc major:minor format
+func formatClassID(classID uint32) string {
+	return fmt.Sprintf("%x:%x", classID>>16, classID&0xffff)
+}
+
+// allocateBandwidth checks that the container egress rate limit can be applied and creates traffic class for it
+func (c *ContainerdClient) allocateBandwidth(pod model.Pod, container model.Container, id string) (*extensions.Bandwidth, error) {
+	if c.bandwidthDevice == "" {
+		return nil, ErrWithMessagef(ErrNotSupported, "Container egress rate limit requires eliotd --bandwidth-interface")
+	}
+	if c.cgroupV2 {
+		return nil, ErrWithMessagef(ErrNotSupported, "Container egress rate limit requires cgroup v1 net_cls controller")
+	}
+	if !pod.Spec.HostNetwork && !container.HostNetwork {
+		return nil, ErrWithMessagef(ErrInvalid, "Container egress rate limit requires host network, other containers have no external traffic")
+	}
+
+	bandwidthMu.Lock()
+	defer bandwidthMu.Unlock()
+
+	classID, err := allocateBandwidthClass(c.bandwidthDevice, pod.Metadata.Namespace, id)
+	if err != nil {
+		return nil, err
+	}
+	// Create the class right away so that the next allocation sees it reserved
+	if err := ensureBandwidthClass(c.bandwidthDevice, classID, container.EgressRateLimit); err != nil {
+		return nil, err
+	}
+	return &extensions.Bandwidth{ClassID: classID, EgressRate: container.EgressRateLimit}, nil
+}
+
+// applyBandwidth sets the container traffic class rate to the bandwidth device
+func (c *ContainerdClient) applyBandwidth(bandwidth extensions.Bandwidth) error {
+	if c.bandwidthDevice == "" {
+		return ErrWithMessagef(ErrNotSupported, "Container egress rate limit requires eliotd --bandwidth-interface")
+	}
+	return ensureBandwidthClass(c.bandwidthDevice, bandwidth.ClassID, bandwidth.EgressRate)
+}
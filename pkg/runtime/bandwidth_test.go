@@ -0,0 +1,80 @@
+package runtime
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTC records the tc commands and returns the outputs by the command prefix
+func fakeTC(outputs map[string]string) (*[]string, func()) {
+	commands := []string{}
+	original := runTC
+	runTC = func(args ...string) (string, error) {
+		command := strings.Join(args, " ")
+		commands = append(commands, command)
+		for prefix, output := range outputs {
+			if strings.HasPrefix(command, prefix) {
+				return output, nil
+			}
+		}
+		return "", nil
+	}
+	return &commands, func() { runTC = original }
+}
+
+func TestAllocateBandwidthClassSkipsUsedClasses(t *testing.T) {
+	_, restore := fakeTC(nil)
+	first, err := allocateBandwidthClass("eth0", "default", "foo")
+	restore()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0x10), first>>16)
+
+	_, restore = fakeTC(map[string]string{"class show": "class htb " + formatClassID(first) + " root prio 0 rate 1Mbit\n"})
+	defer restore()
+	second, err := allocateBandwidthClass("eth0", "default", "foo")
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second, "should not reuse the class of another container")
+}
+
+func TestEnsureBandwidthClass(t *testing.T) {
+	commands, restore := fakeTC(nil)
+	defer restore()
+
+	assert.NoError(t, ensureBandwidthClass("eth0", 0x100123, 1000000))
+	assert.Equal(t, []string{
+		"qdisc show dev eth0",
+		"qdisc add dev eth0 root handle 10: htb",
+		"filter show dev eth0 parent 10:",
+		"filter add dev eth0 parent 10: protocol all prio 10 handle 1: cgroup",
+		"class replace dev eth0 parent 10: classid 10:123 htb rate 1000000bit",
+	}, *commands)
+}
+
+func TestEnsureBandwidthClassKeepsExistingQdisc(t *testing.T) {
+	commands, restore := fakeTC(map[string]string{
+		"qdisc show":  "qdisc htb 10: root refcnt 2 r2q 10 default 0\n",
+		"filter show": "filter parent 10: protocol all pref 10 cgroup chain 0 handle 0x1\n",
+	})
+	defer restore()
+
+	assert.NoError(t, ensureBandwidthClass("eth0", 0x100123, 1000000))
+	assert.Equal(t, []string{
+		"qdisc show dev eth0",
+		"filter show dev eth0 parent 10:",
+		"class replace dev eth0 parent 10: classid 10:123 htb rate 1000000bit",
+	}, *commands)
+}
+
+func TestAllocateBandwidthRequiresHostNetwork(t *testing.T) {
+	client := &ContainerdClient{bandwidthDevice: "eth0"}
+	_, err := client.allocateBandwidth(model.Pod{}, model.Container{EgressRateLimit: 1000}, "foo")
+	assert.True(t, strings.Contains(err.Error(), "requires host network"))
+
+	client = &ContainerdClient{}
+	_, err = client.allocateBandwidth(model.Pod{}, model.Container{EgressRateLimit: 1000, HostNetwork: true}, "foo")
+	assert.Equal(t, ErrNotSupported, errors.Cause(err), "should require bandwidth interface")
+}
@@ -0,0 +1,167 @@
+package runtime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/containerd/oci"
+	"github.com/ernoaapa/eliot/pkg/model"
+	opts "github.com/ernoaapa/eliot/pkg/runtime/containerd"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// podCgroupRoot is the cgroup under what the pod level cgroups get created
+const podCgroupRoot = "/eliot"
+
+// getPodCgroupParent returns the cgroup what is shared parent for all pod containers
+func getPodCgroupParent(namespace, podName string) string {
+	return path.Join(podCgroupRoot, namespace, podName)
+}
+
+// isPodCgroupParent returns true if the cgroup is pod level cgroup created by eliot
+func isPodCgroupParent(cgroup string) bool {
+	return strings.HasPrefix(cgroup, podCgroupRoot+"/")
+}
+
+// withContainerCgroup returns the spec option what places the container cgroup under the cgroup parent, nil if
+// there's no parent. The pod with resource limits gets the pod cgroup as parent unless the container defines own
+func (c *ContainerdClient) withContainerCgroup(pod model.Pod, container model.Container, id string) (oci.SpecOpts, error) {
+	cgroupParent := container.CgroupParent
+	if pod.Spec.Resources != nil {
+		if cgroupParent != "" {
+			log.Warnf("Container [%s] defines cgroup parent [%s], pod level resource limits don't apply to it", id, cgroupParent)
+		} else {
+			cgroupParent = getPodCgroupParent(pod.Metadata.Namespace, pod.Metadata.Name)
+			if err := ensurePodCgroup(cgroupParent, *pod.Spec.Resources, c.cgroupV2); err != nil {
+				return nil, errors.Wrapf(err, "Error while setting up pod [%s] cgroup", pod.Metadata.Name)
+			}
+		}
+	}
+
+	if cgroupParent == "" {
+		return nil, nil
+	}
+	return oci.WithCgroup(path.Join(cgroupParent, id)), nil
+}
+
+// ensurePodCgroup creates the pod cgroup and writes the pod level limits to it.
+// The limits get enforced for the container cgroups what runtime creates under it
+func ensurePodCgroup(cgroup string, resources model.Resources, cgroupV2 bool) error {
+	if cgroupV2 {
+		return ensurePodCgroupV2(cgroup, resources)
+	}
+	return ensurePodCgroupV1(cgroup, resources)
+}
+
+func ensurePodCgroupV1(cgroup string, resources model.Resources) error {
+	if resources.MemoryLimit > 0 {
+		limit := fmt.Sprintf("%d", resources.MemoryLimit)
+		if err := writeCgroupFile(filepath.Join(cgroupRoot, "memory", cgroup), "memory.limit_in_bytes", limit); err != nil {
+			return err
+		}
+		// memsw limit is memory+swap total, the file exists only if the kernel has swap accounting enabled
+		swap := fmt.Sprintf("%d", resources.GetMemorySwapLimit())
+		if err := writeSwapLimit(resources, filepath.Join(cgroupRoot, "memory", cgroup), "memory.memsw.limit_in_bytes", swap); err != nil {
+			return err
+		}
+	}
+
+	if resources.CPULimit > 0 {
+		dir := filepath.Join(cgroupRoot, "cpu", cgroup)
+		if err := writeCgroupFile(dir, "cpu.cfs_period_us", fmt.Sprintf("%d", opts.CPUPeriod)); err != nil {
+			return err
+		}
+		if err := writeCgroupFile(dir, "cpu.cfs_quota_us", fmt.Sprintf("%d", getCPUQuota(resources.CPULimit))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ensurePodCgroupV2(cgroup string, resources model.Resources) error {
+	// Controllers must be enabled in every ancestor so the pod and container cgroups get them
+	dir := cgroupRoot
+	for _, part := range strings.Split(strings.Trim(cgroup, "/"), "/") {
+		if err := writeCgroupFile(dir, "cgroup.subtree_control", "+cpu +memory"); err != nil {
+			return err
+		}
+		dir = filepath.Join(dir, part)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "Failed to create pod cgroup [%s]", cgroup)
+	}
+	if err := writeCgroupFile(dir, "cgroup.subtree_control", "+cpu +memory"); err != nil {
+		return err
+	}
+
+	if resources.MemoryLimit > 0 {
+		if err := writeCgroupFile(dir, "memory.max", fmt.Sprintf("%d", resources.MemoryLimit)); err != nil {
+			return err
+		}
+		if err := writeSwapLimit(resources, dir, "memory.swap.max", getSwapMax(resources)); err != nil {
+			return err
+		}
+	}
+
+	if resources.CPULimit > 0 {
+		if err := writeCgroupFile(dir, "cpu.max", fmt.Sprintf("%d %d", getCPUQuota(resources.CPULimit), opts.CPUPeriod)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removePodCgroup removes the pod cgroup if there's no containers left in it
+func removePodCgroup(cgroup string, cgroupV2 bool) {
+	dirs := []string{filepath.Join(cgroupRoot, cgroup)}
+	if !cgroupV2 {
+		// runtime creates the cgroup to every v1 hierarchy
+		dirs, _ = filepath.Glob(filepath.Join(cgroupRoot, "*", cgroup))
+	}
+	for _, dir := range dirs {
+		if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+			log.Debugf("Pod cgroup [%s] not removed, might still contain containers: %s", dir, err)
+		}
+	}
+}
+
+// writeSwapLimit writes the swap limit to the cgroup file. If the kernel doesn't account the swap, the file
+// doesn't exist and the default limit what disables the swap gets skipped, the explicit limit fails
+func writeSwapLimit(resources model.Resources, dir, file, value string) error {
+	if resources.MemorySwapLimit == 0 {
+		if _, err := os.Stat(filepath.Join(dir, file)); os.IsNotExist(err) {
+			log.Debugf("Cgroup [%s] doesn't have swap limit file [%s], swap accounting is not enabled", dir, file)
+			return nil
+		}
+	}
+	return writeCgroupFile(dir, file, value)
+}
+
+// getSwapMax returns the cgroup v2 memory.swap.max value, v2 limits only the swap
+// while the model has the memory+swap total like cgroup v1
+func getSwapMax(resources model.Resources) string {
+	swap := resources.GetMemorySwapLimit()
+	if swap < 0 {
+		return "max"
+	}
+	return fmt.Sprintf("%d", swap-resources.MemoryLimit)
+}
+
+func getCPUQuota(millicores int64) int64 {
+	return millicores * int64(opts.CPUPeriod) / 1000
+}
+
+func writeCgroupFile(dir, file, value string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "Failed to create cgroup directory [%s]", dir)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, file), []byte(value), 0644); err != nil {
+		return errors.Wrapf(err, "Failed to write [%s] to cgroup file [%s]", value, filepath.Join(dir, file))
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package runtime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func readCgroupFile(t *testing.T, elem ...string) string {
+	content, err := ioutil.ReadFile(filepath.Join(elem...))
+	assert.NoError(t, err)
+	return string(content)
+}
+
+func TestEnsurePodCgroupV1(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	original := cgroupRoot
+	defer func() { cgroupRoot = original }()
+	cgroupRoot = root
+
+	cgroup := getPodCgroupParent("eliot", "my-pod")
+	assert.Equal(t, "/eliot/eliot/my-pod", cgroup)
+	assert.True(t, isPodCgroupParent(cgroup))
+
+	assert.NoError(t, ensurePodCgroup(cgroup, model.Resources{MemoryLimit: 268435456, CPULimit: 500}, false))
+	assert.Equal(t, "268435456", readCgroupFile(t, root, "memory", cgroup, "memory.limit_in_bytes"))
+	assert.Equal(t, "100000", readCgroupFile(t, root, "cpu", cgroup, "cpu.cfs_period_us"))
+	assert.Equal(t, "50000", readCgroupFile(t, root, "cpu", cgroup, "cpu.cfs_quota_us"))
+
+	removePodCgroup(cgroup, false)
+	_, err = os.Stat(filepath.Join(root, "memory", cgroup))
+	assert.NoError(t, err, "should not remove cgroup what still have files")
+}
+
+func TestEnsurePodCgroupV2(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	original := cgroupRoot
+	defer func() { cgroupRoot = original }()
+	cgroupRoot = root
+
+	cgroup := getPodCgroupParent("eliot", "my-pod")
+	assert.NoError(t, ensurePodCgroup(cgroup, model.Resources{MemoryLimit: 268435456, CPULimit: 1500}, true))
+	assert.Equal(t, "+cpu +memory", readCgroupFile(t, root, "cgroup.subtree_control"))
+	assert.Equal(t, "+cpu +memory", readCgroupFile(t, root, "eliot", "eliot", "cgroup.subtree_control"))
+	assert.Equal(t, "268435456", readCgroupFile(t, root, cgroup, "memory.max"))
+	assert.Equal(t, "150000 100000", readCgroupFile(t, root, cgroup, "cpu.max"))
+}
+
+func TestEnsurePodCgroupSwapLimit(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	original := cgroupRoot
+	defer func() { cgroupRoot = original }()
+	cgroupRoot = root
+
+	cgroup := getPodCgroupParent("eliot", "my-pod")
+	assert.NoError(t, ensurePodCgroup(cgroup, model.Resources{MemoryLimit: 1024, MemorySwapLimit: 4096}, false))
+	assert.Equal(t, "4096", readCgroupFile(t, root, "memory", cgroup, "memory.memsw.limit_in_bytes"))
+
+	assert.NoError(t, ensurePodCgroup(cgroup, model.Resources{MemoryLimit: 1024, MemorySwapLimit: 4096}, true))
+	assert.Equal(t, "3072", readCgroupFile(t, root, cgroup, "memory.swap.max"), "v2 should limit only the swap")
+
+	assert.NoError(t, ensurePodCgroup(cgroup, model.Resources{MemoryLimit: 1024, MemorySwapLimit: -1}, true))
+	assert.Equal(t, "max", readCgroupFile(t, root, cgroup, "memory.swap.max"))
+}
+
+func TestEnsurePodCgroupDefaultSwapLimit(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	original := cgroupRoot
+	defer func() { cgroupRoot = original }()
+	cgroupRoot = root
+
+	cgroup := getPodCgroupParent("eliot", "my-pod")
+	assert.NoError(t, ensurePodCgroup(cgroup, model.Resources{MemoryLimit: 1024}, false))
+	_, err = os.Stat(filepath.Join(root, "memory", cgroup, "memory.memsw.limit_in_bytes"))
+	assert.True(t, os.IsNotExist(err), "should skip the default when the kernel doesn't account swap")
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "memory", cgroup, "memory.memsw.limit_in_bytes"), []byte("max"), 0644))
+	assert.NoError(t, ensurePodCgroup(cgroup, model.Resources{MemoryLimit: 1024}, false))
+	assert.Equal(t, "1024", readCgroupFile(t, root, "memory", cgroup, "memory.memsw.limit_in_bytes"), "v1 should disable swap by default")
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, cgroup), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, cgroup, "memory.swap.max"), []byte("max"), 0644))
+	assert.NoError(t, ensurePodCgroup(cgroup, model.Resources{MemoryLimit: 1024}, true))
+	assert.Equal(t, "0", readCgroupFile(t, root, cgroup, "memory.swap.max"), "v2 should disable swap by default")
+}
+
+func TestWithContainerCgroup(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	original := cgroupRoot
+	defer func() { cgroupRoot = original }()
+	cgroupRoot = root
+
+	client := NewContainerdClient(nil, 0, "overlayfs", "", "")
+	pod := model.Pod{
+		Metadata: model.NewMetadata("eliot", "my-pod"),
+		Spec:     model.PodSpec{Resources: &model.Resources{MemoryLimit: 268435456}},
+	}
+
+	opt, err := client.withContainerCgroup(pod, model.Container{Name: "foo"}, "my-pod-foo")
+	assert.NoError(t, err)
+	spec := &specs.Spec{}
+	assert.NoError(t, opt(nil, nil, nil, spec))
+	assert.Equal(t, "/eliot/eliot/my-pod/my-pod-foo", spec.Linux.CgroupsPath, "should place the container under the pod cgroup")
+	assert.Equal(t, "268435456", readCgroupFile(t, root, "memory", "/eliot/eliot/my-pod", "memory.limit_in_bytes"))
+
+	opt, err = client.withContainerCgroup(pod, model.Container{Name: "foo", CgroupParent: "/custom"}, "my-pod-foo")
+	assert.NoError(t, err)
+	spec = &specs.Spec{}
+	assert.NoError(t, opt(nil, nil, nil, spec))
+	assert.Equal(t, "/custom/my-pod-foo", spec.Linux.CgroupsPath, "should use the container own cgroup parent")
+
+	opt, err = client.withContainerCgroup(model.Pod{Metadata: model.NewMetadata("eliot", "other")}, model.Container{Name: "foo"}, "other-foo")
+	assert.NoError(t, err)
+	assert.Nil(t, opt, "should leave the cgroup to the runtime without parent")
+}
@@ -0,0 +1,113 @@
+package runtime
+
+import (
+	"crypto"
+	"time"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+)
+
+// ClientOpts is optional configuration for the containerd client
+type ClientOpts func(client *ContainerdClient)
+
+// WithUnpackTimeout sets separate timeout for image unpack because unpacking large images to slow flash
+// can take long, zero means the unpack uses the same timeout as the other requests
+func WithUnpackTimeout(timeout time.Duration) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.unpackTimeout = timeout
+	}
+}
+
+// WithPullTimeout sets separate timeout for image pull because pulling large images over slow network
+// can take long, zero means the pull uses the same timeout as the other requests
+func WithPullTimeout(timeout time.Duration) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.pullTimeout = timeout
+	}
+}
+
+// WithPullLease protects the pulled images from garbage collection for the duration or until used by container
+func WithPullLease(duration time.Duration) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.pullLease = duration
+	}
+}
+
+// WithMaxImageSize rejects the images larger than the size in bytes before pulling, zero means no limit
+func WithMaxImageSize(size int64) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.maxImageSize = size
+	}
+}
+
+// WithUnpackSnapshotter sets the snapshotter where the images get unpacked and the containers get created from them,
+// if not set the configured snapshotter gets used, also after it gets changed with SetSnapshotter
+func WithUnpackSnapshotter(snapshotter string) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.unpackSnapshotter = snapshotter
+	}
+}
+
+// WithDeviceInfo sets the device info what resolves the ${device.*} references in container environment variables
+func WithDeviceInfo(deviceInfo DeviceInfo) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.deviceInfo = deviceInfo
+	}
+}
+
+// WithRegistryTLS configures the registry certificate verification when pulling images
+func WithRegistryTLS(config RegistryTLS) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.registryClient = newRegistryClient(config)
+	}
+}
+
+// WithInitPath sets the init binary for the containers with init enabled, if not set there's no init support
+func WithInitPath(path string) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.initPath = path
+	}
+}
+
+// WithBandwidthDevice sets the network interface where the container egress rate limits get applied,
+// if not set there's no bandwidth limit support
+func WithBandwidthDevice(device string) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.bandwidthDevice = device
+	}
+}
+
+// WithMaxContainers limits how many containers can exist in all namespaces, running or stopped, zero means no limit
+func WithMaxContainers(max int) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.maxContainers = max
+	}
+}
+
+// WithTrustedKeys sets the public keys what the images must be signed with, empty means no signature verification
+func WithTrustedKeys(keys []crypto.PublicKey) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.trustedKeys = keys
+	}
+}
+
+// WithQuotas limits the resources per namespace, the namespaces without quota are limited only by WithMaxContainers
+func WithQuotas(quotas map[string]model.NamespaceQuota) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.quotas = quotas
+	}
+}
+
+// WithSnapshotCleanup sets how long StopContainer waits the container snapshot to be removed, zero means no waiting
+func WithSnapshotCleanup(timeout time.Duration) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.snapshotCleanup = timeout
+	}
+}
+
+// WithHostEnv sets the eliotd environment variable names what the containers can forward with HostEnv
+func WithHostEnv(names []string) ClientOpts {
+	return func(client *ContainerdClient) {
+		client.hostEnv = names
+	}
+}
@@ -0,0 +1,279 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/diff"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/ernoaapa/eliot/pkg/model"
+	digest "github.com/opencontainers/go-digest"
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/rs/xid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// uncompressedLabel is the content label what the differ sets to the uncompressed layer digest
+	uncompressedLabel = "containerd.io/uncompressed"
+	// commitContainerLabel is the committed image label what tells the container the image got created from
+	commitContainerLabel = "io.eliot.commit.container"
+)
+
+// CommitContainer creates new image from the container filesystem, i.e. the container image with the container
+// filesystem changes as new layer on top of it, and unpacks it so that new containers can be created from it
+// Running container gets paused while the changes get captured so that the layer is consistent
+func (c *ContainerdClient) CommitContainer(namespace, id, newRef string) (result model.Image, err error) {
+	namespace = c.resolveNamespace(namespace)
+	// Diff of big filesystem can take as long as unpacking it
+	ctx, cancel := c.getContextWithTimeout(c.unpackTimeout)
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return result, err
+	}
+
+	container, err := client.LoadContainer(ctx, id)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return result, ErrWithMessagef(ErrNotFound, "Container [%s] not found in namespace [%s]", id, namespace)
+		}
+		return result, errors.Wrapf(err, "Failed to load container [%s], cannot commit it", id)
+	}
+
+	info, err := container.Info(ctx)
+	if err != nil {
+		return result, errors.Wrap(err, "Error while fetching container info")
+	}
+	if info.SnapshotKey == "" {
+		return result, ErrWithMessagef(ErrNotSupported, "Container [%s] doesn't have snapshot", id)
+	}
+
+	image, err := container.Image(ctx)
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to resolve container [%s] image", id)
+	}
+
+	// Lease protects the new content from garbage collection until the image references it
+	ctx, done, err := client.WithLease(ctx)
+	if err != nil {
+		return result, errors.Wrap(err, "Failed to create lease for the commit")
+	}
+	defer done(ctx)
+
+	resume, err := pauseTask(ctx, container)
+	if err != nil {
+		return result, err
+	}
+	layer, err := diffContainerSnapshot(ctx, client, info)
+	resume()
+	if err != nil {
+		return result, errors.Wrapf(err, "Error while creating layer from container [%s] filesystem", id)
+	}
+
+	layerInfo, err := client.ContentStore().Info(ctx, layer.Digest)
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to resolve container [%s] layer", id)
+	}
+	diffID, err := digest.Parse(layerInfo.Labels[uncompressedLabel])
+	if err != nil {
+		return result, errors.Wrapf(err, "Container [%s] layer doesn't have valid uncompressed digest", id)
+	}
+
+	target, err := writeCommitManifest(ctx, client.ContentStore(), image.Target(), layer, diffID, id)
+	if err != nil {
+		return result, errors.Wrapf(err, "Error while writing image [%s] manifest", newRef)
+	}
+
+	committed := images.Image{
+		Name:   newRef,
+		Target: target,
+		Labels: map[string]string{commitContainerLabel: id},
+	}
+	store := client.ImageService()
+	created, err := store.Create(ctx, committed)
+	if err != nil {
+		if !errdefs.IsAlreadyExists(err) {
+			return result, errors.Wrapf(err, "Error while creating image [%s]", newRef)
+		}
+		if created, err = store.Update(ctx, committed, "target", "labels"); err != nil {
+			return result, errors.Wrapf(err, "Error while updating image [%s]", newRef)
+		}
+	}
+
+	if err := c.unpackImageTo(containerd.NewImage(client, created), c.getUnpackSnapshotter(), ""); err != nil {
+		return result, errors.Wrapf(err, "Error while unpacking image [%s]", newRef)
+	}
+	log.Infof("Committed container [%s] to image [%s] in namespace [%s]", id, newRef, namespace)
+
+	return model.Image{
+		Name:      created.Name,
+		Digest:    created.Target.Digest.String(),
+		Labels:    created.Labels,
+		CreatedAt: created.CreatedAt,
+	}, nil
+}
+
+// pauseTask pauses the running container task, the returned function resumes the task
+func pauseTask(ctx context.Context, container containerd.Container) (func(), error) {
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return func() {}, nil
+		}
+		return nil, errors.Wrapf(err, "Failed to fetch container [%s] task", container.ID())
+	}
+	status, err := task.Status(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to resolve container [%s] task status", container.ID())
+	}
+	if status.Status != containerd.Running {
+		return func() {}, nil
+	}
+
+	if err := task.Pause(ctx); err != nil {
+		return nil, errors.Wrapf(err, "Failed to pause container [%s]", container.ID())
+	}
+	return func() {
+		if err := task.Resume(ctx); err != nil {
+			log.Errorf("Failed to resume container [%s] after commit: %s", container.ID(), err)
+		}
+	}, nil
+}
+
+// diffContainerSnapshot writes the container snapshot changes as gzipped layer to the content store
+// Both snapshots get mounted read-only like in DiffContainer, so it's safe to diff running container
+func diffContainerSnapshot(ctx context.Context, client *containerd.Client, info containers.Container) (imagespecs.Descriptor, error) {
+	snapshotter := client.SnapshotService(info.Snapshotter)
+	snapshot, err := snapshotter.Stat(ctx, info.SnapshotKey)
+	if err != nil {
+		return imagespecs.Descriptor{}, errors.Wrap(err, "Failed to resolve the snapshot")
+	}
+
+	upper, err := snapshotter.Mounts(ctx, info.SnapshotKey)
+	if err != nil {
+		return imagespecs.Descriptor{}, errors.Wrap(err, "Failed to resolve the snapshot mounts")
+	}
+
+	viewKey := fmt.Sprintf("%s-commit-%s", info.ID, xid.New().String())
+	lower, err := snapshotter.View(ctx, viewKey, snapshot.Parent)
+	if err != nil {
+		return imagespecs.Descriptor{}, errors.Wrap(err, "Failed to create view of the image snapshot")
+	}
+	defer func() {
+		if err := snapshotter.Remove(ctx, viewKey); err != nil {
+			log.Warnf("Failed to remove commit view snapshot [%s]: %s", viewKey, err)
+		}
+	}()
+
+	return client.DiffService().Compare(ctx, readonlyMounts(lower), readonlyMounts(upper),
+		diff.WithMediaType(imagespecs.MediaTypeImageLayerGzip),
+		diff.WithReference(viewKey),
+	)
+}
+
+// writeCommitManifest writes the image config and manifest where the layer is on top of the image layers
+// Docker image gets Docker manifest so that the committed image can be pushed to the same registries
+func writeCommitManifest(ctx context.Context, store content.Store, target, layer imagespecs.Descriptor, diffID digest.Digest, id string) (imagespecs.Descriptor, error) {
+	manifest, err := images.Manifest(ctx, store, target, platforms.Default())
+	if err != nil {
+		return imagespecs.Descriptor{}, errors.Wrap(err, "Failed to read the image manifest")
+	}
+
+	configBlob, err := content.ReadBlob(ctx, store, manifest.Config.Digest)
+	if err != nil {
+		return imagespecs.Descriptor{}, errors.Wrap(err, "Failed to read the image config")
+	}
+	// The config gets modified as raw JSON so that the fields what the OCI spec doesn't know are kept
+	var config map[string]json.RawMessage
+	if err := json.Unmarshal(configBlob, &config); err != nil {
+		return imagespecs.Descriptor{}, errors.Wrap(err, "Failed to parse the image config")
+	}
+	if err := appendCommitToConfig(config, diffID, id); err != nil {
+		return imagespecs.Descriptor{}, err
+	}
+
+	manifestMediaType := imagespecs.MediaTypeImageManifest
+	if manifest.Config.MediaType == images.MediaTypeDockerSchema2Config {
+		manifestMediaType = images.MediaTypeDockerSchema2Manifest
+		layer.MediaType = images.MediaTypeDockerSchema2LayerGzip
+	}
+
+	configDesc, err := writeJSONBlob(ctx, store, manifest.Config.MediaType, config, nil)
+	if err != nil {
+		return imagespecs.Descriptor{}, err
+	}
+	manifest.Config = configDesc
+	manifest.Layers = append(manifest.Layers, layer)
+
+	// GC labels keep the config and the layers as long as the manifest exists
+	labels := map[string]string{"containerd.io/gc.ref.content.config": configDesc.Digest.String()}
+	for i, l := range manifest.Layers {
+		labels[fmt.Sprintf("containerd.io/gc.ref.content.l.%d", i)] = l.Digest.String()
+	}
+	return writeJSONBlob(ctx, store, manifestMediaType, struct {
+		MediaType string `json:"mediaType,omitempty"`
+		imagespecs.Manifest
+	}{manifestMediaType, manifest}, labels)
+}
+
+// appendCommitToConfig adds the layer diff ID to the config rootfs and the commit to the history
+func appendCommitToConfig(config map[string]json.RawMessage, diffID digest.Digest, id string) error {
+	var rootfs imagespecs.RootFS
+	if err := json.Unmarshal(config["rootfs"], &rootfs); err != nil {
+		return errors.Wrap(err, "Failed to parse the image config rootfs")
+	}
+	rootfs.DiffIDs = append(rootfs.DiffIDs, diffID)
+
+	var history []imagespecs.History
+	if raw, ok := config["history"]; ok {
+		if err := json.Unmarshal(raw, &history); err != nil {
+			return errors.Wrap(err, "Failed to parse the image config history")
+		}
+	}
+	now := time.Now().UTC()
+	history = append(history, imagespecs.History{
+		Created:   &now,
+		CreatedBy: "eliot commit",
+		Comment:   fmt.Sprintf("Committed from container %s", id),
+	})
+
+	for key, value := range map[string]interface{}{"rootfs": rootfs, "history": history, "created": now} {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		config[key] = encoded
+	}
+	return nil
+}
+
+func writeJSONBlob(ctx context.Context, store content.Store, mediaType string, value interface{}, labels map[string]string) (imagespecs.Descriptor, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return imagespecs.Descriptor{}, err
+	}
+	desc := imagespecs.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+	opts := []content.Opt{}
+	if labels != nil {
+		opts = append(opts, content.WithLabels(labels))
+	}
+	if err := content.WriteBlob(ctx, store, "commit-"+desc.Digest.String(), bytes.NewReader(data), desc.Size, desc.Digest, opts...); err != nil {
+		return desc, errors.Wrapf(err, "Failed to write %s", mediaType)
+	}
+	return desc, nil
+}
@@ -0,0 +1,40 @@
+package runtime
+
+import (
+	"encoding/json"
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendCommitToConfig(t *testing.T) {
+	config := map[string]json.RawMessage{}
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"architecture": "arm64",
+		"container_config": {"Hostname": "foo"},
+		"rootfs": {"type": "layers", "diff_ids": ["sha256:0000000000000000000000000000000000000000000000000000000000000000"]},
+		"history": [{"created_by": "ADD file"}]
+	}`), &config))
+
+	diffID := digest.FromString("layer")
+	assert.NoError(t, appendCommitToConfig(config, diffID, "foo-bar"))
+
+	var rootfs imagespecs.RootFS
+	assert.NoError(t, json.Unmarshal(config["rootfs"], &rootfs))
+	assert.Equal(t, "layers", rootfs.Type)
+	assert.Len(t, rootfs.DiffIDs, 2)
+	assert.Equal(t, diffID, rootfs.DiffIDs[1])
+
+	var history []imagespecs.History
+	assert.NoError(t, json.Unmarshal(config["history"], &history))
+	assert.Len(t, history, 2)
+	assert.Equal(t, "Committed from container foo-bar", history[1].Comment)
+
+	assert.JSONEq(t, `{"Hostname": "foo"}`, string(config["container_config"]), "should keep the fields what OCI spec doesn't have")
+}
+
+func TestAppendCommitToConfigRequiresRootfs(t *testing.T) {
+	assert.Error(t, appendCommitToConfig(map[string]json.RawMessage{}, digest.FromString("layer"), "foo"))
+}
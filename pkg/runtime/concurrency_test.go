@@ -0,0 +1,118 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// servingHealth is containerd health service what always reports serving
+type servingHealth struct{}
+
+func (servingHealth) Check(context.Context, *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+// startFakeContainerd starts gRPC server in unix socket what serves the health service and the given services,
+// so the client connects like to real containerd but the other calls fail as unimplemented
+func startFakeContainerd(t *testing.T, services ...func(*grpc.Server)) (address string, stop func()) {
+	dir, err := ioutil.TempDir("", "containerd")
+	assert.NoError(t, err)
+	address = filepath.Join(dir, "containerd.sock")
+	listener, err := net.Listen("unix", address)
+	assert.NoError(t, err)
+
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, servingHealth{})
+	for _, register := range services {
+		register(server)
+	}
+	go server.Serve(listener)
+	return address, func() {
+		server.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+// TestConcurrentAccess calls the client from many goroutines like the concurrent API handlers and
+// the event watcher do, run with -race to detect unsynchronized access
+func TestConcurrentAccess(t *testing.T) {
+	address, stop := startFakeContainerd(t)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := NewContainerdClient(ctx, time.Second, "overlayfs", address, "hostname")
+	client.OnConnectionChange(func(connected bool) {})
+
+	var (
+		wg      sync.WaitGroup
+		changes = make(chan bool, 1000)
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			namespace := fmt.Sprintf("namespace-%d", i%3)
+			id := fmt.Sprintf("container-%d", i)
+			ref := fmt.Sprintf("docker.io/library/image-%d:latest", i%3)
+
+			client.connection.subscribe(func(connected bool) {
+				select {
+				case changes <- connected:
+				default:
+				}
+			})
+			for j := 0; j < 100; j++ {
+				client.connection.set(j%2 == 0)
+				client.IsConnected()
+				client.statuses.setConnected(j%2 == 0)
+
+				client.statuses.add(namespace, id, "RUNNING", uint64(j))
+				client.statuses.get(namespace, id)
+				client.statuses.isOOMKilled(namespace, id)
+
+				remove := client.pulls.add(namespace, ref, func() {})
+				client.pulls.cancel(namespace, ref)
+				remove()
+
+				assert.Equal(t, "overlayfs", client.getSnapshotter())
+				assert.Equal(t, namespace, client.resolveNamespace(namespace))
+
+				if j%10 == 0 {
+					_, err := client.getConnection(namespace)
+					assert.NoError(t, err)
+					_, err = client.GetNamespaces()
+					assert.Error(t, err, "fake containerd doesn't implement the namespace service")
+					_, err = client.GetContainerMetrics(namespace)
+					assert.Error(t, err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	client.connection.set(false)
+	assert.False(t, client.IsConnected())
+	assert.NotEmpty(t, changes, "should notify the listeners about the connection changes")
+
+	first, err := client.getConnection("namespace-0")
+	assert.NoError(t, err)
+	second, err := client.getConnection("namespace-0")
+	assert.NoError(t, err)
+	assert.True(t, first == second, "should reuse the namespace connection")
+
+	assert.NoError(t, client.Close())
+	_, err = client.getConnection("namespace-0")
+	assert.Equal(t, errConnectionsClosed, err, "should not connect after close")
+}
@@ -0,0 +1,69 @@
+package runtime
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/containerd/containerd"
+)
+
+// errConnectionsClosed is returned when the client gets called after Close
+var errConnectionsClosed = errors.New("Connection to containerd is closed")
+
+// connectionPool keeps one containerd client per namespace so that the calls reuse the connection instead of
+// dialing containerd on every call. The gRPC connection reconnects by itself when containerd restarts
+type connectionPool struct {
+	mutex   sync.Mutex
+	clients map[string]*containerd.Client
+	closed  bool
+}
+
+func newConnectionPool() *connectionPool {
+	return &connectionPool{clients: map[string]*containerd.Client{}}
+}
+
+// get returns the client of the namespace, nil if there's no connection yet
+func (p *connectionPool) get(namespace string) (*containerd.Client, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		return nil, errConnectionsClosed
+	}
+	return p.clients[namespace], nil
+}
+
+// add stores the new client of the namespace and returns the client what the calls should use
+// If other call connected the namespace at the same time, the new client gets closed and the existing returned
+func (p *connectionPool) add(namespace string, client *containerd.Client) (*containerd.Client, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		client.Close()
+		return nil, errConnectionsClosed
+	}
+	if existing, ok := p.clients[namespace]; ok {
+		client.Close()
+		return existing, nil
+	}
+	p.clients[namespace] = client
+	return client, nil
+}
+
+// close closes all clients, the pool cannot be used after it
+func (p *connectionPool) close() (err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.closed = true
+	for namespace, client := range p.clients {
+		if closeErr := client.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		delete(p.clients, namespace)
+	}
+	return err
+}
+
+// Close closes the containerd connections, the client cannot be used after it
+func (c *ContainerdClient) Close() error {
+	return c.clients.close()
+}
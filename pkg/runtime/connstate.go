@@ -0,0 +1,79 @@
+package runtime
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ConnectionListener gets called with true when containerd connection comes up and with false when it goes down
+type ConnectionListener func(connected bool)
+
+// connectionState tracks whether containerd is reachable and notifies the listeners when it changes
+type connectionState struct {
+	// notify keeps the notifications in order, it's held while the listeners get called
+	notify    sync.Mutex
+	mutex     sync.Mutex
+	known     bool
+	connected bool
+	listeners []ConnectionListener
+}
+
+func (s *connectionState) set(connected bool) {
+	s.notify.Lock()
+	defer s.notify.Unlock()
+
+	s.mutex.Lock()
+	changed := !s.known || s.connected != connected
+	s.known = true
+	s.connected = connected
+	listeners := append([]ConnectionListener{}, s.listeners...)
+	s.mutex.Unlock()
+
+	if !changed {
+		return
+	}
+	if connected {
+		log.Infoln("containerd connection up")
+	} else {
+		log.Warnln("containerd connection down")
+	}
+	for _, listener := range listeners {
+		listener(connected)
+	}
+}
+
+func (s *connectionState) get() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.connected
+}
+
+func (s *connectionState) subscribe(listener ConnectionListener) {
+	s.notify.Lock()
+	defer s.notify.Unlock()
+
+	s.mutex.Lock()
+	s.listeners = append(s.listeners, listener)
+	known, connected := s.known, s.connected
+	s.mutex.Unlock()
+
+	if known {
+		listener(connected)
+	}
+}
+
+// OnConnectionChange registers the listener what gets called when containerd connection goes up or down
+// The listener gets called right away with the current state if it's known. The listener gets called
+// from the connection handling, so it must not block or call the client, only IsConnected is safe
+// Registering starts watching the containerd event stream, so connection loss gets noticed without requests
+func (c *ContainerdClient) OnConnectionChange(listener ConnectionListener) {
+	c.connection.subscribe(listener)
+	c.ensureWatchingEvents()
+}
+
+// IsConnected returns true if the latest connection to containerd succeeded and the event stream, if watched,
+// has not broken after it
+func (c *ContainerdClient) IsConnected() bool {
+	return c.connection.get()
+}
@@ -0,0 +1,35 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionStateNotifiesChanges(t *testing.T) {
+	state := &connectionState{}
+	changes := []bool{}
+	state.subscribe(func(connected bool) {
+		changes = append(changes, connected)
+	})
+	assert.Empty(t, changes, "should not notify before the state is known")
+
+	state.set(true)
+	state.set(true)
+	state.set(false)
+	state.set(true)
+	assert.Equal(t, []bool{true, false, true}, changes, "should notify only changes")
+	assert.True(t, state.get())
+}
+
+func TestConnectionStateNotifiesCurrentStateOnSubscribe(t *testing.T) {
+	state := &connectionState{}
+	state.set(false)
+
+	changes := []bool{}
+	state.subscribe(func(connected bool) {
+		changes = append(changes, connected)
+		assert.False(t, state.get(), "listener should be able to read the state")
+	})
+	assert.Equal(t, []bool{false}, changes)
+}
@@ -2,28 +2,42 @@ package runtime
 
 import (
 	"context"
+	"crypto"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/containerd/containerd"
 	tasks "github.com/containerd/containerd/api/services/tasks/v1"
+	types "github.com/containerd/containerd/api/types/task"
 	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/leases"
 	"github.com/containerd/containerd/namespaces"
 	namespaceutils "github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/oci"
 	"github.com/containerd/containerd/platforms"
 	"github.com/containerd/containerd/plugin"
 	"github.com/containerd/containerd/remotes"
+	"github.com/ernoaapa/eliot/pkg/fs"
 	"github.com/ernoaapa/eliot/pkg/model"
 	"github.com/ernoaapa/eliot/pkg/progress"
 	opts "github.com/ernoaapa/eliot/pkg/runtime/containerd"
 	"github.com/ernoaapa/eliot/pkg/runtime/containerd/extensions"
 	"github.com/ernoaapa/eliot/pkg/runtime/containerd/mapping"
+	digest "github.com/opencontainers/go-digest"
 	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
@@ -33,32 +47,78 @@ import (
 
 // ContainerdClient is containerd client wrapper
 type ContainerdClient struct {
-	context     context.Context
-	timeout     time.Duration
-	snapshotter string
-	address     string
-	hostname    string
+	context           context.Context
+	timeout           time.Duration
+	unpackTimeout     time.Duration
+	pullTimeout       time.Duration
+	pullLease         time.Duration
+	maxImageSize      int64
+	snapshotter       string
+	snapshotterMu     sync.RWMutex
+	unpackSnapshotter string
+	address           string
+	hostname          string
+	deviceInfo        DeviceInfo
+	registryClient    *http.Client
+	initPath          string
+	bandwidthDevice   string
+	maxContainers     int
+	containerLimitMu  sync.Mutex
+	trustedKeys       []crypto.PublicKey
+	quotas            map[string]model.NamespaceQuota
+	hostEnv           []string
+	snapshotCleanup   time.Duration
+	cgroupV2          bool
+	statuses          *statusCache
+	pulls             *pullTracker
+	watchStatuses     sync.Once
+	connection        *connectionState
+	clients           *connectionPool
+	secretsMu         sync.RWMutex
+	secrets           SecretResolver
 }
 
-// NewContainerdClient creates new containerd client with given timeout
-func NewContainerdClient(context context.Context, timeout time.Duration, snapshotter, address, hostname string) *ContainerdClient {
-	return &ContainerdClient{
-		context:     context,
-		timeout:     timeout,
-		address:     address,
-		snapshotter: snapshotter,
-		hostname:    hostname,
-	}
+// NewContainerdClient creates new containerd client with given timeout, the opts configure the optional features
+func NewContainerdClient(context context.Context, timeout time.Duration, snapshotter, address, hostname string, opts ...ClientOpts) *ContainerdClient {
+	client := &ContainerdClient{
+		context:        context,
+		timeout:        timeout,
+		address:        address,
+		snapshotter:    snapshotter,
+		hostname:       hostname,
+		registryClient: newRegistryClient(RegistryTLS{}),
+		cgroupV2:       isCgroupV2(),
+		statuses:       newStatusCache(),
+		pulls:          newPullTracker(),
+		connection:     &connectionState{},
+		clients:        newConnectionPool(),
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
 }
 
 func (c *ContainerdClient) getContext() (context.Context, context.CancelFunc) {
+	return c.getContextWithTimeout(c.timeout)
+}
+
+// getPullTimeout returns the image pull timeout, the request timeout if pull timeout is not set
+func (c *ContainerdClient) getPullTimeout() time.Duration {
+	if c.pullTimeout > 0 {
+		return c.pullTimeout
+	}
+	return c.timeout
+}
+
+func (c *ContainerdClient) getContextWithTimeout(timeout time.Duration) (context.Context, context.CancelFunc) {
 	var (
 		ctx    = c.context
 		cancel context.CancelFunc
 	)
 
-	if c.timeout > 0 {
-		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
 	} else {
 		ctx, cancel = context.WithCancel(ctx)
 	}
@@ -66,16 +126,94 @@ func (c *ContainerdClient) getContext() (context.Context, context.CancelFunc) {
 	return ctx, cancel
 }
 
+// resolveNamespace returns the namespace what the call targets, see model.ResolveNamespace
+func (c *ContainerdClient) resolveNamespace(namespace string) string {
+	return model.ResolveNamespace(namespace)
+}
+
+// getConnection returns the shared connection what has the namespace as the default namespace
+// The connection must not be closed, the client closes the connections in Close
 func (c *ContainerdClient) getConnection(namespace string) (*containerd.Client, error) {
-	client, err := containerd.New(c.address, containerd.WithDefaultNamespace(namespace))
+	return c.connect(c.resolveNamespace(namespace))
+}
+
+// getGlobalConnection returns connection without default namespace for the calls what
+// span all namespaces, e.g. listing the namespaces and subscribing the events
+func (c *ContainerdClient) getGlobalConnection() (*containerd.Client, error) {
+	return c.connect("")
+}
+
+func (c *ContainerdClient) connect(namespace string) (*containerd.Client, error) {
+	if client, err := c.clients.get(namespace); client != nil || err != nil {
+		return client, err
+	}
+
+	var opts []containerd.ClientOpt
+	if namespace != "" {
+		opts = append(opts, containerd.WithDefaultNamespace(namespace))
+	}
+	client, err := containerd.New(c.address, opts...)
+	c.connection.set(err == nil)
 	if err != nil {
 		return client, errors.Wrapf(err, "Unable to create connection to containerd")
 	}
-	return client, nil
+	return c.clients.add(namespace, client)
+}
+
+// WaitForReady blocks until containerd accepts connections or the timeout expires.
+// On boot containerd might start after eliotd, so the socket might not exist yet
+func (c *ContainerdClient) WaitForReady(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := c.checkServing()
+		if err == nil {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return ErrWithMessagef(ErrTimeout, "containerd not available at [%s] in %s: %s", c.address, timeout, err)
+		}
+		log.Infof("Waiting for containerd to become available: %s", err)
+		time.Sleep(readyCheckInterval)
+	}
+}
+
+func (c *ContainerdClient) checkServing() (err error) {
+	defer func() {
+		c.connection.set(err == nil)
+	}()
+
+	if !fs.FileExist(c.address) {
+		return fmt.Errorf("socket [%s] not found", c.address)
+	}
+
+	// Connect directly so the connection state doesn't go up before containerd is serving
+	client, err := containerd.New(c.address)
+	if err != nil {
+		return errors.Wrapf(err, "Unable to create connection to containerd")
+	}
+	defer client.Close()
+
+	return c.isServing(client)
+}
+
+// isServing checks that containerd behind the connection serves the requests
+func (c *ContainerdClient) isServing(client *containerd.Client) error {
+	ctx, cancel := c.getContextWithTimeout(readyCheckInterval)
+	defer cancel()
+
+	serving, err := client.IsServing(ctx)
+	if err != nil {
+		return errors.Wrap(err, "Health check failed")
+	}
+	if !serving {
+		return errors.New("containerd is not serving yet")
+	}
+	return nil
 }
 
 // GetPods return all containers active in containerd grouped by pods
 func (c *ContainerdClient) GetPods(namespace string) ([]model.Pod, error) {
+	namespace = c.resolveNamespace(namespace)
 	pods := map[string]*model.Pod{}
 	ctx, cancel := c.getContext()
 	defer cancel()
@@ -85,7 +223,7 @@ func (c *ContainerdClient) GetPods(namespace string) ([]model.Pod, error) {
 		return nil, err
 	}
 
-	containers, err := client.Containers(ctx)
+	containers, err := client.Containers(ctx, mapping.ContainerFilter())
 	if err != nil {
 		return nil, errors.Wrap(err, "Error while getting list of containers")
 	}
@@ -103,13 +241,58 @@ func (c *ContainerdClient) GetPods(namespace string) ([]model.Pod, error) {
 
 		pods[podName].AppendContainer(
 			mapping.MapContainerToInternalModel(info),
-			mapping.MapContainerStatusToInternalModel(info, resolveContainerStatus(ctx, container)),
+			c.mapContainerStatus(namespace, info, resolveContainerStatus(ctx, container)),
 		)
 	}
 
 	return getValues(pods), nil
 }
 
+// maxConcurrentNamespaces limits how many namespaces GetAllPods fetches in parallel
+const maxConcurrentNamespaces = 4
+
+// GetAllPods return pods from all namespaces
+func (c *ContainerdClient) GetAllPods() ([]model.Pod, error) {
+	namespaces, err := c.GetNamespaces()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error while getting list of namespaces")
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		sem    = make(chan struct{}, maxConcurrentNamespaces)
+		result = []model.Pod{}
+		errs   = []error{}
+	)
+	for _, namespace := range namespaces {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(namespace string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			pods, err := c.GetPods(namespace)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "Error while getting pods in namespace [%s]", namespace))
+				return
+			}
+			result = append(result, pods...)
+		}(namespace)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return result, nil
+}
+
 func resolveContainerStatus(ctx context.Context, container containerd.Container) containerd.Status {
 	status := containerd.Status{}
 	task, err := container.Task(ctx, nil)
@@ -127,6 +310,7 @@ func resolveContainerStatus(ctx context.Context, container containerd.Container)
 
 // GetPod return pod by name
 func (c *ContainerdClient) GetPod(namespace, podName string) (model.Pod, error) {
+	namespace = c.resolveNamespace(namespace)
 	pods, err := c.GetPods(namespace)
 	if err != nil {
 		return model.Pod{}, err
@@ -141,6 +325,12 @@ func (c *ContainerdClient) GetPod(namespace, podName string) (model.Pod, error)
 
 // CreateContainer creates given container
 func (c *ContainerdClient) CreateContainer(pod model.Pod, container model.Container) (status model.ContainerStatus, err error) {
+	pod.Metadata.Namespace = c.resolveNamespace(pod.Metadata.Namespace)
+	id, err := resolveContainerID(pod, container)
+	if err != nil {
+		return status, err
+	}
+
 	ctx, cancel := c.getContext()
 	defer cancel()
 
@@ -154,6 +344,28 @@ func (c *ContainerdClient) CreateContainer(pod model.Pod, container model.Contai
 		return status, imageErr
 	}
 
+	if len(c.trustedKeys) > 0 {
+		record, err := client.ImageService().Get(ctx, container.Image)
+		if err != nil {
+			return status, errors.Wrapf(err, "Failed to fetch image [%s]", container.Image)
+		}
+		if err := checkImageVerified(record); err != nil {
+			return status, err
+		}
+	}
+
+	// Resolve once so that the container gets created with the snapshotter the image was unpacked to,
+	// unpacking again to another snapshotter would fetch all the content what e.g. lazy pulling snapshotter defers
+	snapshotter := c.getUnpackSnapshotter()
+	if container.StorageQuota > 0 {
+		if err := checkStorageQuota(snapshotter); err != nil {
+			return status, errors.Wrapf(err, "Cannot create container [%s]", id)
+		}
+	}
+	if err := c.ensureUnpacked(ctx, image, snapshotter); err != nil {
+		return status, errors.Wrapf(err, "Error while unpacking image [%s] for container [%s]", container.Image, id)
+	}
+
 	specOpts := []oci.SpecOpts{
 		oci.WithImageConfig(image),
 	}
@@ -170,9 +382,16 @@ func (c *ContainerdClient) CreateContainer(pod model.Pod, container model.Contai
 		specOpts = append(specOpts, opts.WithCwd(container.WorkingDir))
 	}
 
-	if len(container.Env) > 0 {
-		log.Debugf("Adding %d environment variables", len(container.Env))
-		specOpts = append(specOpts, opts.WithEnv(container.Env))
+	if env := withLogLevelEnv(pod.Spec, container, log.GetLevel().String()); len(env) > 0 || len(container.HostEnv) > 0 {
+		env, err := c.resolveEnv(env)
+		if err != nil {
+			return status, errors.Wrapf(err, "Failed to resolve container [%s] environment variables", id)
+		}
+		if env, err = withHostEnv(env, container.HostEnv, c.hostEnv, os.LookupEnv); err != nil {
+			return status, errors.Wrapf(err, "Failed to resolve container [%s] environment variables", id)
+		}
+		log.Debugf("Adding %d environment variables", len(env))
+		specOpts = append(specOpts, opts.WithEnv(env))
 	}
 
 	if len(container.Mounts) > 0 {
@@ -184,42 +403,247 @@ func (c *ContainerdClient) CreateContainer(pod model.Pod, container model.Contai
 		specOpts = append(specOpts, opts.WithMounts(container.Mounts))
 	}
 
-	if pod.Spec.HostNetwork {
-		specOpts = append(specOpts,
-			oci.WithHostNamespace(specs.NetworkNamespace),
-			oci.WithHostHostsFile,
-			oci.WithHostResolvconf,
-		)
+	if len(container.Tmpfs) > 0 {
+		log.Debugf("Adding %d tmpfs mounts to container", len(container.Tmpfs))
+		specOpts = append(specOpts, opts.WithTmpfs(container.Tmpfs))
+	}
+
+	devices := container.Devices
+	var libraries []string
+	if len(container.Accelerators) > 0 {
+		var acceleratorDevices []model.Device
+		if acceleratorDevices, libraries, err = resolveAccelerators(container.Accelerators); err != nil {
+			return status, errors.Wrapf(err, "Cannot create container [%s]", id)
+		}
+		log.Debugf("Adding %d accelerator devices and %d driver libraries to container", len(acceleratorDevices), len(libraries))
+		devices = append(devices, acceleratorDevices...)
+		specOpts = append(specOpts, opts.WithMounts(getLibraryMounts(libraries)))
+	}
+
+	if len(devices) > 0 {
+		specOpts = append(specOpts, opts.WithDevices(devices))
+	}
+
+	stateDir := &containerStateDir{path: getContainerStateDir(pod.Metadata.Namespace, id)}
+	filesDir := filepath.Join(stateDir.path, "files")
+	if len(container.Files) > 0 {
+		log.Debugf("Adding %d files to container", len(container.Files))
+		specOpts = append(specOpts, opts.WithFiles(filesDir, container.Files))
+	}
+
+	cgroupOpt, err := c.withContainerCgroup(pod, container, id)
+	if err != nil {
+		return status, err
+	}
+	if cgroupOpt != nil {
+		specOpts = append(specOpts, cgroupOpt)
+	}
+
+	if container.Resources != nil {
+		specOpts = append(specOpts, opts.WithResources(*container.Resources, c.cgroupV2))
+	}
+
+	if len(container.Ulimits) > 0 {
+		specOpts = append(specOpts, opts.WithRlimits(container.Ulimits))
+	}
+
+	if len(container.Annotations) > 0 {
+		specOpts = append(specOpts, opts.WithAnnotations(container.Annotations))
+	}
+
+	if len(container.Sysctls) > 0 {
+		if pod.Spec.HostNetwork || container.HostNetwork {
+			for name := range container.Sysctls {
+				if model.IsNetworkSysctl(name) {
+					return status, ErrWithMessagef(ErrInvalid, "Container [%s] runs in the host network namespace, sysctl [%s] would change the host settings", id, name)
+				}
+			}
+		}
+		log.Debugf("Adding %d sysctls to container", len(container.Sysctls))
+		specOpts = append(specOpts, opts.WithSysctls(container.Sysctls))
+	}
+
+	if container.Capabilities != nil {
+		specOpts = append(specOpts, opts.WithCapabilities(*container.Capabilities))
+	}
+
+	if container.OOMScoreAdj != 0 {
+		specOpts = append(specOpts, opts.WithOOMScoreAdj(container.OOMScoreAdj))
+	}
+
+	if container.AppArmorProfile != "" {
+		if err := checkAppArmorProfile(container.AppArmorProfile); err != nil {
+			return status, errors.Wrapf(err, "Cannot create container [%s]", id)
+		}
+		specOpts = append(specOpts, opts.WithAppArmorProfile(container.AppArmorProfile))
+	}
+
+	if container.Init {
+		if c.initPath == "" {
+			return status, ErrWithMessagef(ErrNotSupported, "Container [%s] requires init but eliotd --init-path is not set", id)
+		}
+		specOpts = append(specOpts, opts.WithInit(c.initPath))
+	}
+
+	customHosts := len(container.ExtraHosts) > 0 || container.Hostname != ""
+
+	if pod.Spec.HostNetwork || container.HostNetwork {
+		specOpts = append(specOpts, oci.WithHostNamespace(specs.NetworkNamespace))
+		if !customHosts {
+			specOpts = append(specOpts, oci.WithHostHostsFile)
+		}
+		if len(container.DNS) == 0 {
+			specOpts = append(specOpts, oci.WithHostResolvconf)
+		}
+	}
+
+	// The generated files get recorded so that they can be written again when the container starts after reboot
+	stateFiles := extensions.StateFiles{Files: map[string]string{}}
+	if len(container.DNS) > 0 {
+		log.Debugf("Adding %d DNS servers to container", len(container.DNS))
+		specOpts = append(specOpts, opts.WithResolvConf(stateDir.path, container.DNS))
+		stateFiles.Files["/etc/resolv.conf"] = string(opts.RenderResolvConf(container.DNS))
+	}
+
+	if container.Hostname != "" {
+		specOpts = append(specOpts, opts.WithHostname(stateDir.path, container.Hostname))
+		stateFiles.Files["/etc/hostname"] = string(opts.RenderHostname(container.Hostname))
+	}
+
+	if customHosts {
+		log.Debugf("Adding %d extra hosts to container", len(container.ExtraHosts))
+		specOpts = append(specOpts, opts.WithHostsFile(stateDir.path, container.Hostname, container.Domainname, container.ExtraHosts))
+		stateFiles.Files["/etc/hosts"] = string(opts.RenderHosts(container.Hostname, container.Domainname, container.ExtraHosts))
+	}
+
+	var bandwidth *extensions.Bandwidth
+	if container.EgressRateLimit > 0 {
+		if bandwidth, err = c.allocateBandwidth(pod, container, id); err != nil {
+			return status, errors.Wrapf(err, "Cannot create container [%s]", id)
+		}
+		specOpts = append(specOpts, opts.WithNetworkClassID(bandwidth.ClassID))
 	}
 
 	if pod.Spec.HostPID {
 		specOpts = append(specOpts, oci.WithHostNamespace(specs.PIDNamespace))
 	}
 
-	id := xid.New()
+	if container.SpecPatch != "" {
+		log.Debugf("Applying spec patch to container [%s]", id)
+		specOpts = append(specOpts, opts.WithSpecPatch(container.SpecPatch))
+	}
+
 	containerOpts := []containerd.NewContainerOpts{
+		// Before the spec what writes the generated files to the state dir
+		stateDir.create,
 		containerd.WithContainerLabels(mapping.NewLabels(pod, container)),
 		containerd.WithNewSpec(specOpts...),
-		containerd.WithSnapshotter(c.snapshotter),
-		containerd.WithNewSnapshot(id.String(), image),
+		containerd.WithSnapshotter(snapshotter),
+		containerd.WithNewSnapshot(id, image),
 		containerd.WithRuntime(fmt.Sprintf("%s.%s", plugin.RuntimePlugin, "linux"), nil),
 		extensions.WithLifecycleExtension,
 	}
 
+	if container.Domainname != "" {
+		containerOpts = append(containerOpts, opts.WithDomainname(container.Domainname))
+	}
+
 	if container.Pipe != nil {
 		containerOpts = append(containerOpts, extensions.WithPipeExtension(
 			mapping.MapPipeToContainerdModel(*container.Pipe),
 		))
 	}
 
+	if container.RestartOnChange != nil {
+		containerOpts = append(containerOpts, extensions.WithFileWatchExtension(
+			mapping.MapFileWatchToContainerdModel(*container.RestartOnChange),
+		))
+	}
+
+	if container.IdleStop != nil {
+		containerOpts = append(containerOpts, extensions.WithIdleStopExtension(
+			mapping.MapIdleStopToContainerdModel(*container.IdleStop),
+		))
+	}
+
+	if len(container.Devices) > 0 || len(container.Accelerators) > 0 {
+		containerOpts = append(containerOpts, extensions.WithDevicesExtension(
+			mapping.MapDevicesToContainerdModel(container.Devices, container.Accelerators, libraries),
+		))
+	}
+
+	if container.Schedule != "" {
+		containerOpts = append(containerOpts, extensions.WithScheduleExtension(extensions.Schedule{Cron: container.Schedule}))
+	}
+
+	if container.LogDriver == model.LogDriverJournald {
+		if err := checkJournal(); err != nil {
+			return status, errors.Wrapf(err, "Cannot create container [%s]", id)
+		}
+		containerOpts = append(containerOpts, extensions.WithLoggingExtension(extensions.Logging{Driver: container.LogDriver}))
+	}
+
+	if bandwidth != nil {
+		containerOpts = append(containerOpts, extensions.WithBandwidthExtension(*bandwidth))
+	}
+
+	if pod.Spec.RestartPolicy == model.RestartPolicyNever {
+		containerOpts = append(containerOpts, extensions.WithRestartPolicy(extensions.Never))
+	}
+
+	if container.RestartOnNetworkRecovery {
+		containerOpts = append(containerOpts, extensions.WithNetworkRecoveryExtension(extensions.NetworkRecovery{Restart: true}))
+	}
+
+	if len(container.Files) > 0 {
+		containerOpts = append(containerOpts, extensions.WithFilesExtension(mapping.MapFilesToContainerdModel(container.Files)))
+	}
+
+	if len(stateFiles.Files) > 0 {
+		containerOpts = append(containerOpts, extensions.WithStateFilesExtension(stateFiles))
+	}
+
+	if container.LivenessProbe != nil || container.ReadinessProbe != nil {
+		containerOpts = append(containerOpts, extensions.WithProbesExtension(
+			mapping.MapProbesToContainerdModel(container.LivenessProbe, container.ReadinessProbe),
+		))
+	}
+
 	log.Debugf("Create new container from image %s...", image.Name())
-	created, err := client.NewContainer(
-		namespaceutils.WithNamespace(ctx, pod.Metadata.Namespace),
-		id.String(),
-		containerOpts...,
-	)
+	namespaced := namespaceutils.WithNamespace(ctx, pod.Metadata.Namespace)
+	c.containerLimitMu.Lock()
+	created, err := client.NewContainer(namespaced, id, containerOpts...)
+	if err == nil {
+		err = c.withinLimits(namespaced, client, created, pod.Metadata.Namespace, container, 0)
+	}
+	c.containerLimitMu.Unlock()
 	if err != nil {
-		return status, errors.Wrapf(err, "Failed to create new container from image %s", image.Name())
+		if errdefs.IsAlreadyExists(err) {
+			return status, ErrWithMessagef(ErrAlreadyExists, "Container with id [%s] already exist in namespace [%s]", id, pod.Metadata.Namespace)
+		}
+		// Don't leave the generated files, the secret files and the traffic class behind
+		stateDir.remove()
+		if bandwidth != nil {
+			removeBandwidthClass(c.bandwidthDevice, bandwidth.ClassID)
+		}
+		if IsLimitExceeded(err) {
+			return status, err
+		}
+		return status, errors.Wrapf(withPluginError(ctx, client, err, plugin.SnapshotPlugin, snapshotter), "Failed to create new container from image %s", image.Name())
+	}
+
+	if container.StorageQuota > 0 {
+		if err := applyStorageQuota(namespaced, client.SnapshotService(snapshotter), snapshotter, id, container.StorageQuota); err != nil {
+			// Don't leave the container running without the limit
+			if err := created.Delete(namespaced, containerd.WithSnapshotCleanup); err != nil {
+				log.Warnf("Failed to remove container [%s] after storage quota failure: %s", id, err)
+			}
+			stateDir.remove()
+			if bandwidth != nil {
+				removeBandwidthClass(c.bandwidthDevice, bandwidth.ClassID)
+			}
+			return status, errors.Wrapf(err, "Cannot create container [%s]", id)
+		}
 	}
 
 	info, err := created.Info(ctx)
@@ -227,11 +651,26 @@ func (c *ContainerdClient) CreateContainer(pod model.Pod, container model.Contai
 		return status, errors.Wrap(err, "Error while fetching container info")
 	}
 
-	return mapping.MapContainerStatusToInternalModel(info, resolveContainerStatus(ctx, created)), nil
+	// The container now references the image, so the pull lease is not needed anymore
+	if err := releasePullLeases(ctx, client, image.Name()); err != nil {
+		log.Warnf("Failed to release image [%s] pull leases: %s", image.Name(), err)
+	}
+
+	return c.mapContainerStatus(pod.Metadata.Namespace, info, resolveContainerStatus(ctx, created)), nil
 }
 
 // StartContainer starts the pre-created container
 func (c *ContainerdClient) StartContainer(namespace, id string, ioSet IOSet) (result model.ContainerStatus, err error) {
+	return c.StartContainerWithProgress(namespace, id, ioSet, nil)
+}
+
+// StartContainerWithProgress starts the pre-created container and calls the phase function,
+// if not nil, when the task gets created and started
+func (c *ContainerdClient) StartContainerWithProgress(namespace, id string, ioSet IOSet, phase func(string)) (result model.ContainerStatus, err error) {
+	namespace = c.resolveNamespace(namespace)
+	if phase == nil {
+		phase = func(string) {}
+	}
 	ctx, cancel := c.getContext()
 	defer cancel()
 
@@ -250,17 +689,29 @@ func (c *ContainerdClient) StartContainer(namespace, id string, ioSet IOSet) (re
 		return result, errors.Wrap(err, "Error while fetching container info")
 	}
 
+	spec, err := container.Spec(ctx)
+	if err != nil {
+		return result, errors.Wrapf(err, "Error while fetching container [%s] spec", container.ID())
+	}
+	if err := c.restoreStateFiles(namespace, info, spec); err != nil {
+		return result, errors.Wrapf(err, "Failed to restore container [%s] files", container.ID())
+	}
+
 	log.Debugf("Create task in container: %s", container.ID())
 	io, err := opts.NewDirectIO(ctx, ioSet.Stdin, ioSet.Stdout, ioSet.Stderr, mapping.RequireTty(info))
 	if err != nil {
 		return result, errors.Wrapf(err, "Error while creating container task IO")
 	}
 
+	var lastExit []containerd.UpdateContainerOpts
 	if task, err := container.Task(ctx, nil); err != nil {
 		if !errdefs.IsNotFound(err) {
 			return result, errors.Wrapf(err, "Error while resolving container task status")
 		}
 	} else {
+		if status, err := task.Status(ctx); err == nil && status.Status == containerd.Stopped {
+			lastExit = append(lastExit, c.resolveLastExit(namespace, info, status))
+		}
 		if err := ensureTaskStopped(ctx, task); err != nil {
 			return result, errors.Wrapf(err, "Failed to ensure task is stopped")
 		}
@@ -269,23 +720,69 @@ func (c *ContainerdClient) StartContainer(namespace, id string, ioSet IOSet) (re
 		}
 	}
 
+	if bandwidth, _ := extensions.GetBandwidthExtension(info); bandwidth != nil {
+		if err := c.applyBandwidth(*bandwidth); err != nil {
+			return result, errors.Wrapf(err, "Failed to apply container [%s] bandwidth limit", container.ID())
+		}
+	}
+
+	var journals []*journalWriter
+	if logging, _ := extensions.GetLoggingExtension(info); logging != nil && logging.Driver == model.LogDriverJournald {
+		if journals, err = newContainerJournalWriters(namespace, container.ID()); err != nil {
+			return result, errors.Wrapf(err, "Cannot forward container [%s] output to journal", container.ID())
+		}
+	}
+
+	phase(progress.PhaseCreatingTask)
 	task, err := container.NewTask(ctx, io.IOCreate)
 	if err != nil {
-		return result, errors.Wrapf(err, "Error while creating task for container [%s]", container.ID())
+		closeJournalWriters(journals)
+		return result, errors.Wrapf(withPluginError(ctx, client, err, plugin.RuntimePlugin, strings.TrimPrefix(info.Runtime.Name, string(plugin.RuntimePlugin)+".")), "Error while creating task for container [%s]", container.ID())
 	}
 
 	log.Debugln("Starting task...")
+	phase(progress.PhaseStarting)
 	err = task.Start(ctx)
 	if err != nil {
+		// Don't leave the created task behind, it would block starting the container again
+		if _, deleteErr := task.Delete(ctx, containerd.WithProcessKill); deleteErr != nil {
+			log.Warnf("Failed to clean up task of container [%s] after failed start: %s", container.ID(), deleteErr)
+		}
+		closeJournalWriters(journals)
 		return result, errors.Wrapf(err, "Failed to start task in container [%s]", container.ID())
 	}
 	log.Debugf("Task started (pid %d)", task.Pid())
 
-	if err := container.Update(ctx, extensions.IncrementRestart); err != nil {
+	if journals != nil {
+		go forwardToJournal(io.Stdout, journals[0], journalPriorityInfo)
+		go forwardToJournal(io.Stderr, journals[1], journalPriorityErr)
+	}
+
+	// Started container is not ready until its readiness probe succeeds
+	if err := container.Update(ctx, append(lastExit, extensions.IncrementRestart, extensions.WithReady(false))...); err != nil {
 		return result, errors.Wrapf(err, "Failed to increment container [%s] start counter", container.ID())
 	}
 
-	return mapping.MapContainerStatusToInternalModel(info, resolveContainerStatus(ctx, container)), nil
+	return c.mapContainerStatus(namespace, info, resolveContainerStatus(ctx, container)), nil
+}
+
+// mapContainerStatus maps the container status, the exit of stopped task is the last exit although
+// the container lifecycle gets it only when the task gets deleted before the next start
+func (c *ContainerdClient) mapContainerStatus(namespace string, info containers.Container, status containerd.Status) model.ContainerStatus {
+	result := mapping.MapContainerStatusToInternalModel(info, status)
+	if status.Status == containerd.Stopped {
+		result.LastExitCode = int(status.ExitStatus)
+		result.LastExitReason = resolveExitReason(info, status.ExitStatus, c.cgroupV2, c.statuses.isOOMKilled(namespace, info.ID))
+	}
+	return result
+}
+
+// resolveLastExit resolves the stopped task exit for the container lifecycle
+// Must be resolved before deleting the task because the task cgroup gets removed with it
+func (c *ContainerdClient) resolveLastExit(namespace string, info containers.Container, status containerd.Status) containerd.UpdateContainerOpts {
+	reason := resolveExitReason(info, status.ExitStatus, c.cgroupV2, c.statuses.isOOMKilled(namespace, info.ID))
+	log.Debugf("Container [%s] previous task exited with code %d (%s)", info.ID, status.ExitStatus, reason)
+	return extensions.WithLastExit(status.ExitStatus, reason)
 }
 
 func ensureTaskStopped(ctx context.Context, task containerd.Task) error {
@@ -302,6 +799,7 @@ func ensureTaskStopped(ctx context.Context, task containerd.Task) error {
 
 // StopContainer stops given container
 func (c *ContainerdClient) StopContainer(namespace, name string) (result model.ContainerStatus, err error) {
+	namespace = c.resolveNamespace(namespace)
 	ctx, cancel := c.getContext()
 	defer cancel()
 
@@ -345,6 +843,24 @@ func (c *ContainerdClient) StopContainer(namespace, name string) (result model.C
 		}
 	}
 
+	if c.snapshotCleanup > 0 && info.SnapshotKey != "" {
+		if err := waitSnapshotRemoved(ctx, client.SnapshotService(info.Snapshotter), info.SnapshotKey, c.snapshotCleanup); err != nil {
+			return result, errors.Wrapf(err, "Container [%s] snapshot cleanup didn't complete", container.ID())
+		}
+	}
+
+	if err := os.RemoveAll(getContainerStateDir(namespace, container.ID())); err != nil {
+		log.Warnf("Failed to remove container [%s] state directory: %s", container.ID(), err)
+	}
+
+	if bandwidth, _ := extensions.GetBandwidthExtension(info); bandwidth != nil && c.bandwidthDevice != "" {
+		removeBandwidthClass(c.bandwidthDevice, bandwidth.ClassID)
+	}
+
+	if cgroup := getCgroupsPath(info); isPodCgroupParent(path.Dir(cgroup)) {
+		removePodCgroup(path.Dir(cgroup), c.cgroupV2)
+	}
+
 	return model.ContainerStatus{
 		ContainerID: info.ID,
 		Image:       info.Image,
@@ -352,8 +868,116 @@ func (c *ContainerdClient) StopContainer(namespace, name string) (result model.C
 	}, nil
 }
 
+// defaultStopGracePeriod is how long containers have time to exit after SIGTERM if the pod doesn't define it
+const defaultStopGracePeriod = 10 * time.Second
+
+// StopContainers stops the containers together, e.g. all containers of a pod.
+// All get SIGTERM at once, then after the grace period the survivors get SIGKILL together
+// and finally the containers get deleted
+func (c *ContainerdClient) StopContainers(namespace string, ids []string, gracePeriod time.Duration) ([]model.ContainerStatus, error) {
+	namespace = c.resolveNamespace(namespace)
+	if gracePeriod <= 0 {
+		gracePeriod = defaultStopGracePeriod
+	}
+
+	if err := c.terminateTasks(namespace, ids, gracePeriod); err != nil {
+		log.Warnf("Failed to terminate containers gracefully, will force kill them: %s", err)
+	}
+
+	statuses := []model.ContainerStatus{}
+	for _, id := range ids {
+		status, err := c.StopContainer(namespace, id)
+		if err != nil {
+			return statuses, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// TerminateContainers stops the container tasks like StopContainers but keeps the containers,
+// e.g. before rebooting the node so the containers get started again after the boot
+func (c *ContainerdClient) TerminateContainers(namespace string, ids []string, gracePeriod time.Duration) error {
+	namespace = c.resolveNamespace(namespace)
+	if gracePeriod <= 0 {
+		gracePeriod = defaultStopGracePeriod
+	}
+	return c.terminateTasks(namespace, ids, gracePeriod)
+}
+
+// terminateTasks sends SIGTERM to all running container tasks, waits until all exit or the grace period
+// exceeds and then sends SIGKILL to the tasks what are still running
+func (c *ContainerdClient) terminateTasks(namespace string, ids []string, gracePeriod time.Duration) error {
+	ctx, cancel := c.getContextWithTimeout(c.timeout + gracePeriod)
+	defer cancel()
+
+	// Not closed here, the other calls to the namespace share the connection
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return err
+	}
+
+	running := map[string]containerd.Task{}
+	exits := map[string]<-chan containerd.ExitStatus{}
+	for _, id := range ids {
+		container, err := client.LoadContainer(ctx, id)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to load container [%s]", id)
+		}
+		task, err := container.Task(ctx, nil)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrapf(err, "Failed to fetch container [%s] task", id)
+		}
+		// Wait must be called before Kill so the exit cannot get missed
+		exitCh, err := task.Wait(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to wait container [%s] task", id)
+		}
+		running[id] = task
+		exits[id] = exitCh
+	}
+
+	for id, task := range running {
+		if err := task.Kill(ctx, syscall.SIGTERM); err != nil && !errdefs.IsNotFound(err) {
+			log.Warnf("Failed to send SIGTERM to container [%s]: %s", id, err)
+		}
+	}
+
+	deadline := time.After(gracePeriod)
+	for id := range running {
+		select {
+		case <-exits[id]:
+			delete(running, id)
+		case <-deadline:
+			return c.killTasks(ctx, running, exits)
+		}
+	}
+	return nil
+}
+
+func (c *ContainerdClient) killTasks(ctx context.Context, tasks map[string]containerd.Task, exits map[string]<-chan containerd.ExitStatus) error {
+	for id, task := range tasks {
+		log.Infof("Container [%s] did not exit in grace period, sending SIGKILL", id)
+		if err := task.Kill(ctx, syscall.SIGKILL); err != nil && !errdefs.IsNotFound(err) {
+			log.Warnf("Failed to send SIGKILL to container [%s]: %s", id, err)
+		}
+	}
+	for id := range tasks {
+		select {
+		case <-exits[id]:
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "Container [%s] did not exit after SIGKILL", id)
+		}
+	}
+	return nil
+}
+
 // Signal will send a syscall.Signal to the container task process
 func (c *ContainerdClient) Signal(namespace, name string, signal syscall.Signal) error {
+	namespace = c.resolveNamespace(namespace)
 	ctx, cancel := c.getContext()
 	defer cancel()
 
@@ -377,34 +1001,124 @@ func (c *ContainerdClient) Signal(namespace, name string, signal syscall.Signal)
 }
 
 // PullImage ensures that given container image is pulled to the namespace
-func (c *ContainerdClient) PullImage(namespace, ref string, progress *progress.ImageFetch) error {
-	ctx, cancel := c.getContext()
+// Authenticates to the registry with the pull secret what matches the registry host, if any
+// The labels get added to the image, the labels what the image already has are kept
+func (c *ContainerdClient) PullImage(namespace, ref string, secrets []model.PullSecret, labels map[string]string, progress *progress.ImageFetch) (err error) {
+	namespace = c.resolveNamespace(namespace)
+	if err := checkUserImageLabels(labels); err != nil {
+		return err
+	}
+	started := time.Now()
+	timeout := c.getPullTimeout()
+	ctx, cancel := c.getContextWithTimeout(timeout)
 	defer cancel()
+	defer c.pulls.add(namespace, ref, cancel)()
+	defer func() {
+		switch {
+		case err == nil:
+		case ctx.Err() == context.Canceled:
+			err = ErrWithMessagef(ErrCanceled, "Pulling image [%s] was cancelled, pulling again resumes from the fetched content: %s", ref, err)
+		case ctx.Err() == context.DeadlineExceeded:
+			err = ErrWithMessagef(ErrTimeout, "Pulling image [%s] did not complete in %s, pulling again resumes from the fetched content: %s", ref, timeout, err)
+		}
+	}()
 
 	client, err := c.getConnection(namespace)
 	if err != nil {
 		return err
 	}
 
+	lease, err := c.createPullLease(ctx, client, ref)
+	if err != nil {
+		return errors.Wrapf(err, "Error while creating lease for pulling image [%s]", ref)
+	}
+	if lease != "" {
+		// Pull uses the existing lease and doesn't delete it at the end
+		ctx = leases.WithLease(ctx, lease)
+	}
+
 	done := make(chan struct{})
 	defer close(done)
 	go opts.UpdateFetchProgress(done, client, progress)
 
+	fetched := newFetchedContent()
 	handler := func(ctx context.Context, desc imagespecs.Descriptor) ([]imagespecs.Descriptor, error) {
 		if desc.MediaType != images.MediaTypeDockerSchema1Manifest {
 			progress.Add(remotes.MakeRefKey(ctx, desc), desc.Digest.String())
 		}
+		_, err := client.ContentStore().Info(ctx, desc.Digest)
+		fetched.add(desc, err == nil)
 		return nil, nil
 	}
 
-	img, err := client.Pull(
-		ctx,
-		ref,
+	resolver := newRegistryResolver(c.registryClient, secrets)
+	if err := c.ensureImageFits(ctx, client, resolver, ref); err != nil {
+		return err
+	}
+
+	// Verify before pulling so that unsigned image content doesn't get fetched at all
+	var verified digest.Digest
+	if len(c.trustedKeys) > 0 {
+		_, desc, err := resolver.Resolve(ctx, ref)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to resolve image [%s]", ref)
+		}
+		if err := verifyImageSignature(ctx, resolver, ref, desc.Digest, c.trustedKeys); err != nil {
+			return err
+		}
+		verified = desc.Digest
+	}
+
+	// Pull replaces the existing image record, so the labels given earlier must be passed again
+	imageLabels, err := getExistingImageLabels(ctx, client, ref)
+	if err != nil {
+		return err
+	}
+	for key, value := range labels {
+		imageLabels[key] = value
+	}
+	if verified != "" {
+		imageLabels[verifiedImageLabel] = verified.String()
+	}
+
+	pullOpts := []containerd.RemoteOpt{
 		containerd.WithSchema1Conversion,
 		containerd.WithImageHandler(images.HandlerFunc(handler)),
+		containerd.WithResolver(resolver),
+		containerd.WithPullLabels(imageLabels),
+	}
+
+	var (
+		img    containerd.Image
+		reused int64
 	)
-	if err != nil {
-		return errors.Wrapf(err, "Error while pulling image [%s] to namespace [%s]", ref, namespace)
+	for attempt := 1; ; attempt++ {
+		img, err = client.Pull(ctx, ref, pullOpts...)
+		if err == nil {
+			break
+		}
+		if attempt >= maxPullAttempts || ctx.Err() != nil || !isNetworkError(err) {
+			return errors.Wrapf(err, "Error while pulling image [%s] to namespace [%s]", ref, namespace)
+		}
+
+		// Failed fetch leaves the completed blobs and partial ingests to the content store,
+		// so the next attempt continues from where this one stopped
+		completed, partial := fetched.stored(ctx, client.ContentStore())
+		reused = completed + partial
+		log.Warnf("Pulling image [%s] failed (attempt %d/%d), retry with %d bytes completed and %d bytes partially fetched content: %s", ref, attempt, maxPullAttempts, completed, partial, err)
+		if err := waitRetry(ctx, reconnectInterval*time.Duration(attempt)); err != nil {
+			return errors.Wrapf(err, "Error while pulling image [%s] to namespace [%s]", ref, namespace)
+		}
+	}
+
+	// The tag could have moved to another image between the verification and the pull
+	if verified != "" && img.Target().Digest != verified {
+		return ErrWithMessagef(ErrUnverified, "Image [%s] changed to [%s] during the pull, the verified image was [%s]", ref, img.Target().Digest, verified)
+	}
+
+	if reused > 0 {
+		total := fetched.size()
+		log.Infof("Pulled image [%s] reusing %d bytes from earlier attempts, re-fetched %d of total %d bytes", ref, reused, total-reused, total)
 	}
 
 	supported, err := images.Platforms(ctx, img.ContentStore(), img.Target())
@@ -429,17 +1143,84 @@ func (c *ContainerdClient) PullImage(namespace, ref string, progress *progress.I
 		return ErrWithMessagef(ErrNotSupported, "Image [%s] does not available for [%s/%s]", ref, runtime.GOOS, runtime.GOARCH)
 	}
 
-	if err := img.Unpack(ctx, c.snapshotter); err != nil {
+	progress.SetToUnpacking()
+	if err := c.unpackImage(img, lease); err != nil {
 		return errors.Wrapf(err, "Error while unpacking image [%s] to namespace [%s]", ref, namespace)
 	}
 
 	progress.AllDone()
+	progress.SetStats(fetched.stats(time.Since(started)))
 
 	return nil
 }
 
-func platformExist(platform imagespecs.Platform, supported []imagespecs.Platform) bool {
-	matcher := platforms.NewMatcher(platform)
+// getExistingImageLabels returns the labels of the image if it's already in the namespace
+func getExistingImageLabels(ctx context.Context, client *containerd.Client, ref string) (map[string]string, error) {
+	result := map[string]string{}
+	img, err := client.ImageService().Get(ctx, ref)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return result, nil
+		}
+		return result, errors.Wrapf(err, "Error while fetching image [%s] labels", ref)
+	}
+	for key, value := range img.Labels {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// ensureImageFits checks from the image manifest before pulling that the image is not too large.
+// Failing to fetch the manifest doesn't abort, the pull reports the registry errors
+func (c *ContainerdClient) ensureImageFits(ctx context.Context, client *containerd.Client, resolver remotes.Resolver, ref string) error {
+	blobs, err := resolveImageBlobs(ctx, resolver, ref)
+	if err != nil {
+		log.Debugf("Cannot check image [%s] size before pulling: %s", ref, err)
+		return nil
+	}
+	return checkImageSize(ctx, client.ContentStore(), ref, blobs, c.maxImageSize, getAvailableDiskSpace())
+}
+
+// unpackImage unpacks the image to the unpack snapshotter with its own timeout,
+// so healthy but slow unpack doesn't get aborted by the pull timeout
+// The lease (if not empty) protects the unpacked snapshots from the garbage collection
+func (c *ContainerdClient) unpackImage(img containerd.Image, lease string) error {
+	return c.unpackImageTo(img, c.getUnpackSnapshotter(), lease)
+}
+
+// ensureUnpacked unpacks the image to the container snapshotter if the image was pulled before the snapshotter
+// got changed, the images pulled with the snapshotter are unpacked already
+// The container snapshot can be created only from the layers in the same snapshotter
+func (c *ContainerdClient) ensureUnpacked(ctx context.Context, img containerd.Image, snapshotter string) error {
+	unpacked, err := img.IsUnpacked(ctx, snapshotter)
+	if err != nil {
+		return err
+	}
+	if unpacked {
+		return nil
+	}
+	return c.unpackImageTo(img, snapshotter, "")
+}
+
+func (c *ContainerdClient) unpackImageTo(img containerd.Image, snapshotter, lease string) error {
+	ctx, cancel := c.getContextWithTimeout(c.unpackTimeout)
+	defer cancel()
+
+	if lease != "" {
+		ctx = leases.WithLease(ctx, lease)
+	}
+
+	if err := img.Unpack(ctx, snapshotter); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return ErrWithMessagef(ErrTimeout, "Unpacking image [%s] did not complete in %s", img.Name(), c.unpackTimeout)
+		}
+		return err
+	}
+	return nil
+}
+
+func platformExist(platform imagespecs.Platform, supported []imagespecs.Platform) bool {
+	matcher := platforms.NewMatcher(platform)
 	for _, platform := range supported {
 		if matcher.Match(platform) {
 			return true
@@ -448,12 +1229,257 @@ func platformExist(platform imagespecs.Platform, supported []imagespecs.Platform
 	return false
 }
 
+// ImportImage reads OCI image archive from the reader, imports all images to the namespace and unpacks them
+// The labels get added to each imported image
+func (c *ContainerdClient) ImportImage(namespace string, reader io.Reader, labels map[string]string) ([]string, error) {
+	namespace = c.resolveNamespace(namespace)
+	labels, err := getImportLabels(labels)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	imgs, err := client.Import(ctx, opts.OCIArchive{}, reader)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error while importing images to namespace [%s]", namespace)
+	}
+
+	refs := []string{}
+	for _, img := range imgs {
+		if err := c.unpackImage(img, ""); err != nil {
+			return refs, errors.Wrapf(err, "Error while unpacking image [%s] to namespace [%s]", img.Name(), namespace)
+		}
+		if err := setImageLabels(ctx, client.ImageService(), img.Name(), labels); err != nil {
+			return refs, err
+		}
+		refs = append(refs, img.Name())
+	}
+	return refs, nil
+}
+
+// checkUserImageLabels returns ErrInvalid if the labels given by the user have any of the eliot reserved labels,
+// e.g. the image signature verification label would let unsigned image pass the verification
+func checkUserImageLabels(labels map[string]string) error {
+	for key := range labels {
+		if mapping.IsReservedLabel(key) {
+			return ErrWithMessagef(ErrInvalid, "Image label [%s] is reserved for eliot", key)
+		}
+	}
+	return nil
+}
+
+// getImportLabels returns the labels what get set to the imported images. The imported images don't have
+// verified signature, so the verification label gets cleared in case the import replaced verified image
+func getImportLabels(labels map[string]string) (map[string]string, error) {
+	if err := checkUserImageLabels(labels); err != nil {
+		return nil, err
+	}
+	result := map[string]string{verifiedImageLabel: ""}
+	for key, value := range labels {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// setImageLabels adds the labels to the image, other image labels are kept, empty value removes the label
+func setImageLabels(ctx context.Context, store images.Store, ref string, labels map[string]string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	fieldpaths := []string{}
+	for key := range labels {
+		fieldpaths = append(fieldpaths, "labels."+key)
+	}
+	if _, err := store.Update(ctx, images.Image{Name: ref, Labels: labels}, fieldpaths...); err != nil {
+		return errors.Wrapf(err, "Error while labeling image [%s]", ref)
+	}
+	return nil
+}
+
+// GetImages returns the images in the namespace what have all the given labels
+func (c *ContainerdClient) GetImages(namespace string, labels map[string]string) (result []model.Image, err error) {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return result, err
+	}
+
+	filters := []string{}
+	if len(labels) > 0 {
+		filters = append(filters, getImageLabelFilter(labels))
+	}
+	imgs, err := client.ImageService().List(ctx, filters...)
+	if err != nil {
+		return result, errors.Wrapf(err, "Error while listing images in namespace [%s]", namespace)
+	}
+
+	for _, img := range imgs {
+		result = append(result, model.Image{
+			Name:      img.Name,
+			Digest:    img.Target.Digest.String(),
+			Labels:    img.Labels,
+			CreatedAt: img.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// getImageLabelFilter returns containerd filter what matches images with all the labels
+func getImageLabelFilter(labels map[string]string) string {
+	keys := []string{}
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	conditions := []string{}
+	for _, key := range keys {
+		conditions = append(conditions, fmt.Sprintf("labels.%q==%q", key, labels[key]))
+	}
+	return strings.Join(conditions, ",")
+}
+
+// ExportImage writes given image from the namespace as OCI image archive to the writer
+func (c *ContainerdClient) ExportImage(namespace, ref string, writer io.Writer) error {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return err
+	}
+
+	img, err := client.GetImage(ctx, ref)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return ErrWithMessagef(ErrNotFound, "Image [%s] not found in namespace [%s]", ref, namespace)
+		}
+		return errors.Wrapf(err, "Error while fetching image [%s]", ref)
+	}
+
+	desc := img.Target()
+	desc.Annotations = map[string]string{
+		imagespecs.AnnotationRefName: img.Name(),
+	}
+
+	r, err := client.Export(ctx, opts.OCIArchive{}, desc)
+	if err != nil {
+		return errors.Wrapf(err, "Error while exporting image [%s] from namespace [%s]", ref, namespace)
+	}
+	defer r.Close()
+
+	if _, err := io.Copy(writer, r); err != nil {
+		return errors.Wrapf(err, "Error while writing image [%s] archive", ref)
+	}
+	return nil
+}
+
+// TagImage creates new image reference what points to the same content as the existing image
+// If the new reference already exists, it gets updated to point to the image
+func (c *ContainerdClient) TagImage(namespace, ref, newRef string) error {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return err
+	}
+
+	store := client.ImageService()
+	img, err := store.Get(ctx, ref)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return ErrWithMessagef(ErrNotFound, "Image [%s] not found in namespace [%s]", ref, namespace)
+		}
+		return errors.Wrapf(err, "Error while fetching image [%s]", ref)
+	}
+
+	tagged := images.Image{
+		Name:   newRef,
+		Target: img.Target,
+		Labels: img.Labels,
+	}
+	if _, err := store.Create(ctx, tagged); err != nil {
+		if !errdefs.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "Error while tagging image [%s] as [%s]", ref, newRef)
+		}
+		if _, err := store.Update(ctx, tagged, "target"); err != nil {
+			return errors.Wrapf(err, "Error while updating image [%s] to point to [%s]", newRef, ref)
+		}
+	}
+	log.Debugf("Tagged image [%s] as [%s] in namespace [%s]", ref, newRef, namespace)
+	return nil
+}
+
+// GetDiskUsage returns the content store size and each container snapshot usage in the namespace
+func (c *ContainerdClient) GetDiskUsage(namespace string) (result model.DiskUsage, err error) {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return result, err
+	}
+
+	err = client.ContentStore().Walk(ctx, func(info content.Info) error {
+		result.ContentSize += info.Size
+		return nil
+	})
+	if err != nil {
+		return result, errors.Wrapf(err, "Error while calculating content store size in namespace [%s]", namespace)
+	}
+
+	containers, err := client.Containers(ctx, mapping.ContainerFilter())
+	if err != nil {
+		return result, errors.Wrap(err, "Error while getting list of containers")
+	}
+
+	for _, container := range containers {
+		info, err := container.Info(ctx)
+		if err != nil {
+			return result, errors.Wrap(err, "Error while fetching container info")
+		}
+		if info.SnapshotKey == "" {
+			continue
+		}
+
+		usage, err := client.SnapshotService(info.Snapshotter).Usage(ctx, info.SnapshotKey)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			return result, errors.Wrapf(err, "Error while resolving container [%s] snapshot usage", info.ID)
+		}
+
+		result.Containers = append(result.Containers, model.ContainerDiskUsage{
+			ContainerID: info.ID,
+			Name:        mapping.GetContainerName(info),
+			Pod:         mapping.GetPodName(info),
+			Size:        usage.Size,
+			Inodes:      usage.Inodes,
+		})
+	}
+	return result, nil
+}
+
 // GetNamespaces return all namespaces
 func (c *ContainerdClient) GetNamespaces() ([]string, error) {
 	ctx, cancel := c.getContext()
 	defer cancel()
 
-	client, connErr := c.getConnection(model.DefaultNamespace)
+	client, connErr := c.getGlobalConnection()
 	if connErr != nil {
 		return nil, connErr
 	}
@@ -463,12 +1489,14 @@ func (c *ContainerdClient) GetNamespaces() ([]string, error) {
 		return nil, err
 	}
 
-	return getNamespaces(resp), nil
+	return getNamespaces(resp, c.resolveNamespace("")), nil
 }
 
-func getNamespaces(namespaces []string) (result []string) {
-	for _, namespace := range namespaces {
-		if namespace != "default" {
+// getNamespaces filters out the containerd default namespace where the other containerd clients
+// create their containers, unless it is the namespace what eliot uses by default
+func getNamespaces(list []string, defaultNamespace string) (result []string) {
+	for _, namespace := range list {
+		if namespace != namespaces.Default || namespace == defaultNamespace {
 			result = append(result, namespace)
 		}
 	}
@@ -477,6 +1505,7 @@ func getNamespaces(namespaces []string) (result []string) {
 
 // IsContainerRunning returns true if container running. If cannot resolve, return false with error
 func (c *ContainerdClient) IsContainerRunning(namespace, name string) (bool, error) {
+	namespace = c.resolveNamespace(namespace)
 	ctx, cancel := c.getContext()
 	defer cancel()
 
@@ -501,7 +1530,17 @@ func (c *ContainerdClient) IsContainerRunning(namespace, name string) (bool, err
 }
 
 // GetContainerTaskStatus resolves container status or return UNKNOWN
+// The status is read from the cache what containerd task events keep up to date, the first call
+// subscribes the events and the task service gets queried only on cache miss
 func (c *ContainerdClient) GetContainerTaskStatus(namespace, name string) string {
+	namespace = c.resolveNamespace(namespace)
+	c.ensureWatchingEvents()
+
+	status, generation, ok := c.statuses.get(namespace, name)
+	if ok {
+		return status
+	}
+
 	ctx, cancel := c.getContext()
 	defer cancel()
 
@@ -519,11 +1558,299 @@ func (c *ContainerdClient) GetContainerTaskStatus(namespace, name string) string
 		return "UNKNOWN"
 	}
 
-	return resp.Process.Status.String()
+	status = resp.Process.Status.String()
+	c.statuses.add(namespace, name, status, generation)
+	return status
+}
+
+// GetContainerTaskStatuses resolves statuses of given containers with single task list call
+// If no ids given, return statuses of all containers in the namespace
+func (c *ContainerdClient) GetContainerTaskStatuses(namespace string, ids []string) (map[string]string, error) {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.TaskService().List(ctx, &tasks.ListTasksRequest{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error while listing container tasks in namespace [%s]", namespace)
+	}
+
+	if len(ids) == 0 {
+		containers, err := client.Containers(ctx, mapping.ContainerFilter())
+		if err != nil {
+			return nil, errors.Wrap(err, "Error while getting list of containers")
+		}
+		for _, container := range containers {
+			ids = append(ids, container.ID())
+		}
+	}
+
+	return mapTaskStatuses(resp.Tasks, ids), nil
+}
+
+// InspectContainer returns the container spec, labels, image and snapshot info as stored in containerd
+func (c *ContainerdClient) InspectContainer(namespace, id string) (result model.ContainerInspect, err error) {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return result, err
+	}
+
+	container, err := client.ContainerService().Get(ctx, id)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return result, ErrWithMessagef(ErrNotFound, "Container [%s] not found in namespace [%s]", id, namespace)
+		}
+		return result, errors.Wrapf(err, "Failed to load container [%s]", id)
+	}
+
+	return mapping.MapContainerInspectToInternalModel(container), nil
+}
+
+// WaitForStatus blocks until the container task reaches the target status (running or stopped)
+// Uses task wait and event subscription instead of polling the status
+func (c *ContainerdClient) WaitForStatus(namespace, id, status string, timeout time.Duration) error {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContextWithTimeout(timeout)
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return err
+	}
+
+	container, err := client.LoadContainer(ctx, id)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return ErrWithMessagef(ErrNotFound, "Container [%s] not found in namespace [%s]", id, namespace)
+		}
+		return errors.Wrapf(err, "Failed to load container [%s]", id)
+	}
+
+	switch containerd.ProcessStatus(strings.ToLower(status)) {
+	case containerd.Running:
+		err = waitForRunning(ctx, client, container)
+	case containerd.Stopped:
+		err = waitForStopped(ctx, container)
+	default:
+		return ErrWithMessagef(ErrInvalid, "Cannot wait container status [%s], must be one of [%s, %s]", status, containerd.Running, containerd.Stopped)
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return ErrWithMessagef(ErrTimeout, "Container [%s] did not reach status [%s] in %s", id, status, timeout)
+	}
+	return err
+}
+
+func waitForRunning(ctx context.Context, client *containerd.Client, container containerd.Container) error {
+	// Subscribe before checking the current status so the start event cannot get missed
+	events, errs := client.Subscribe(ctx, fmt.Sprintf(`topic=="/tasks/start",event.container_id==%q`, container.ID()))
+
+	for {
+		if resolveContainerStatus(ctx, container).Status == containerd.Running {
+			return nil
+		}
+
+		select {
+		case <-events:
+		case err := <-errs:
+			return errors.Wrapf(err, "Error while waiting container [%s] to start", container.ID())
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func waitForStopped(ctx context.Context, container containerd.Container) error {
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil // no task means not running
+		}
+		return errors.Wrapf(err, "Failed to fetch container [%s] task", container.ID())
+	}
+
+	statusC, err := task.Wait(ctx)
+	if err != nil {
+		return err
+	}
+
+	if status, err := task.Status(ctx); err == nil && status.Status == containerd.Stopped {
+		return nil
+	}
+
+	select {
+	case <-statusC:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func mapTaskStatuses(processes []*types.Process, ids []string) map[string]string {
+	statuses := make(map[string]string, len(ids))
+	for _, id := range ids {
+		statuses[id] = types.StatusUnknown.String()
+	}
+	for _, process := range processes {
+		if _, ok := statuses[process.ContainerID]; ok {
+			statuses[process.ContainerID] = process.Status.String()
+		}
+	}
+	return statuses
+}
+
+// ReapOrphans cleans up the state what unclean eliotd restart might leave to containerd:
+// tasks whose container have been deleted and stopped tasks what never got deleted.
+// Returns the number of reaped tasks
+func (c *ContainerdClient) ReapOrphans() (int, error) {
+	namespaces, err := c.GetNamespaces()
+	if err != nil {
+		return 0, errors.Wrap(err, "Error while listing namespaces for reaping orphans")
+	}
+
+	count := 0
+	for _, namespace := range namespaces {
+		reaped, err := c.reapOrphans(namespace)
+		count += reaped
+		if err != nil {
+			return count, errors.Wrapf(err, "Error while reaping orphans in namespace [%s]", namespace)
+		}
+	}
+	return count, nil
+}
+
+func (c *ContainerdClient) reapOrphans(namespace string) (int, error) {
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := client.TaskService().List(ctx, &tasks.ListTasksRequest{})
+	if err != nil {
+		return 0, errors.Wrap(err, "Error while listing container tasks")
+	}
+
+	existing, err := client.ContainerService().List(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error while listing containers")
+	}
+	managed, err := client.ContainerService().List(ctx, mapping.ContainerFilter())
+	if err != nil {
+		return 0, errors.Wrap(err, "Error while listing eliot containers")
+	}
+
+	orphans, stopped := findOrphanTasks(resp.Tasks, getContainerIDs(existing), getContainerIDs(managed))
+
+	count := 0
+	for _, id := range orphans {
+		log.Infof("Reaping task [%s/%s] whose container doesn't exist", namespace, id)
+		if err := reapTask(ctx, client.TaskService(), id); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	for _, id := range stopped {
+		log.Infof("Reaping stopped task of container [%s/%s]", namespace, id)
+		container, err := client.LoadContainer(ctx, id)
+		if err != nil {
+			return count, errors.Wrapf(err, "Failed to load container [%s]", id)
+		}
+		if err := c.reapStoppedTask(ctx, namespace, container); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// reapStoppedTask deletes the stopped container task and stores its exit to the container lifecycle
+func (c *ContainerdClient) reapStoppedTask(ctx context.Context, namespace string, container containerd.Container) error {
+	info, err := container.Info(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "Error while fetching container [%s] info", container.ID())
+	}
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to fetch container [%s] task", container.ID())
+	}
+	status, err := task.Status(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to resolve container [%s] task status", container.ID())
+	}
+	if err := container.Update(ctx, c.resolveLastExit(namespace, info, status)); err != nil {
+		return errors.Wrapf(err, "Failed to store container [%s] last exit", container.ID())
+	}
+	if _, err := task.Delete(ctx); err != nil {
+		return errors.Wrapf(err, "Failed to delete container [%s] stopped task", container.ID())
+	}
+	return nil
+}
+
+// reapTask kills and deletes the task directly through the task service because
+// without the container there's no containerd.Task to operate with
+func reapTask(ctx context.Context, service tasks.TasksClient, id string) error {
+	if _, err := service.Kill(ctx, &tasks.KillRequest{ContainerID: id, Signal: uint32(syscall.SIGKILL), All: true}); err != nil && !errdefs.IsNotFound(errdefs.FromGRPC(err)) {
+		log.Debugf("Failed to kill orphan task [%s], might be already stopped: %s", id, err)
+	}
+
+	for {
+		resp, err := service.Get(ctx, &tasks.GetRequest{ContainerID: id})
+		if err != nil {
+			return errors.Wrapf(err, "Failed to resolve orphan task [%s] status", id)
+		}
+		if resp.Process.Status == types.StatusStopped {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "Orphan task [%s] didn't stop", id)
+		case <-time.After(orphanStopCheckInterval):
+		}
+	}
+
+	if _, err := service.Delete(ctx, &tasks.DeleteTaskRequest{ContainerID: id}); err != nil {
+		return errors.Wrapf(err, "Failed to delete orphan task [%s]", id)
+	}
+	return nil
+}
+
+// findOrphanTasks returns the tasks without container and the stopped tasks of the eliot containers
+func findOrphanTasks(processes []*types.Process, existing, managed map[string]bool) (orphans, stopped []string) {
+	for _, process := range processes {
+		switch {
+		case !existing[process.ContainerID]:
+			orphans = append(orphans, process.ContainerID)
+		case managed[process.ContainerID] && process.Status == types.StatusStopped:
+			stopped = append(stopped, process.ContainerID)
+		}
+	}
+	return orphans, stopped
+}
+
+func getContainerIDs(list []containers.Container) map[string]bool {
+	result := make(map[string]bool, len(list))
+	for _, container := range list {
+		result[container.ID] = true
+	}
+	return result
 }
 
 // Exec run command in container and hook IO to the new process
 func (c *ContainerdClient) Exec(namespace, name, id string, args []string, tty bool, io AttachIO) error {
+	namespace = c.resolveNamespace(namespace)
 	ctx, cancel := c.getContext()
 	defer cancel()
 	ctx = namespaces.WithNamespace(ctx, namespace)
@@ -570,12 +1897,87 @@ func (c *ContainerdClient) Exec(namespace, name, id string, args []string, tty b
 		return err
 	}
 
-	exitStatus := <-status
-	return exitStatus.Error()
+	return waitProcessExit(ctx, process, status)
+}
+
+// ExecProbe runs the probe command in the container and returns the command exit code
+// The command gets killed if it doesn't complete within the timeout
+func (c *ContainerdClient) ExecProbe(namespace, name string, args []string, timeout time.Duration) (int, error) {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContext()
+	defer cancel()
+	ctx = namespaces.WithNamespace(ctx, namespace)
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return -1, errors.Wrapf(err, "Unable to get connection to execute probe")
+	}
+
+	container, err := client.LoadContainer(ctx, name)
+	if err != nil {
+		return -1, errors.Wrapf(err, "Cannot execute probe in container [%s] in namespace [%s]", name, namespace)
+	}
+
+	spec, err := container.Spec(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return -1, errors.Wrapf(err, "Unable to get task in container [%s], cannot execute probe", name)
+	}
+
+	pspec := spec.Process
+	pspec.Terminal = false
+	pspec.Args = args
+
+	process, err := task.Exec(ctx, fmt.Sprintf("probe-%s", xid.New().String()), pspec, cio.NullIO)
+	if err != nil {
+		return -1, errors.Wrapf(err, "Failed to execute probe in container [%s]", name)
+	}
+	defer process.Delete(ctx, containerd.WithProcessKill)
+
+	status, err := process.Wait(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	if err := process.Start(ctx); err != nil {
+		return -1, errors.Wrapf(err, "Failed to start probe in container [%s]", name)
+	}
+
+	select {
+	case exitStatus := <-status:
+		code, _, err := exitStatus.Result()
+		return int(code), err
+	case <-time.After(timeout):
+		return -1, ErrWithMessagef(ErrTimeout, "Probe in container [%s] didn't complete in %s", name, timeout)
+	}
+}
+
+// SetContainerReady stores the container readiness resolved by the readiness probe
+func (c *ContainerdClient) SetContainerReady(namespace, name string, ready bool) error {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return err
+	}
+
+	container, err := client.LoadContainer(ctx, name)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to load container [%s], cannot update readiness", name)
+	}
+
+	return container.Update(ctx, extensions.WithReady(ready))
 }
 
 // Attach hook IO to container main process
 func (c *ContainerdClient) Attach(namespace, name string, io AttachIO) error {
+	namespace = c.resolveNamespace(namespace)
 	ctx, cancel := c.getContext()
 	defer cancel()
 
@@ -601,6 +2003,29 @@ func (c *ContainerdClient) Attach(namespace, name string, io AttachIO) error {
 		return err
 	}
 
-	exitStatus := <-status
-	return exitStatus.Error()
+	return waitProcessExit(ctx, task, status)
+}
+
+// waitProcessExit waits until the process exits. If the connection to containerd
+// breaks temporarily, it keeps waiting the same process so the client stream survives
+// momentary interruptions
+func waitProcessExit(ctx context.Context, process containerd.Process, status <-chan containerd.ExitStatus) error {
+	for attempt := 0; ; attempt++ {
+		exitStatus := <-status
+		err := exitStatus.Error()
+		if err == nil || !isTransientError(err) || attempt >= maxReconnectAttempts {
+			return err
+		}
+
+		backoff := reconnectInterval << uint(attempt)
+		log.Warnf("Lost connection to containerd while waiting process [%s], retry in %s: %s", process.ID(), backoff, err)
+		if err := waitRetry(ctx, backoff); err != nil {
+			return err
+		}
+
+		status, err = process.Wait(ctx)
+		if err != nil {
+			return err
+		}
+	}
 }
@@ -0,0 +1,156 @@
+package containerd
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// imageIndexFile is the file name of the OCI image layout index
+const imageIndexFile = "index.json"
+
+// OCIArchive implements images.Importer and images.Exporter for tar archives
+// in OCI image layout format (https://github.com/opencontainers/image-spec/blob/master/image-layout.md)
+type OCIArchive struct{}
+
+// Import reads OCI image layout tar stream, writes all blobs into the content store
+// and returns image records for every manifest which have ref name annotation
+func (OCIArchive) Import(ctx context.Context, store content.Store, reader io.Reader) ([]images.Image, error) {
+	var (
+		tr    = tar.NewReader(reader)
+		index *imagespecs.Index
+	)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Error while reading image archive")
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+
+		name := path.Clean(hdr.Name)
+		if name == imageIndexFile {
+			index = &imagespecs.Index{}
+			if err := json.NewDecoder(tr).Decode(index); err != nil {
+				return nil, errors.Wrap(err, "Invalid image archive index.json")
+			}
+			continue
+		}
+
+		dgst, ok := parseBlobPath(name)
+		if !ok {
+			continue
+		}
+		if err := content.WriteBlob(ctx, store, "import-"+dgst.String(), tr, hdr.Size, dgst); err != nil {
+			return nil, errors.Wrapf(err, "Failed to import blob [%s]", dgst)
+		}
+	}
+
+	if index == nil {
+		return nil, errors.New("Invalid image archive, index.json not found")
+	}
+
+	result := []images.Image{}
+	for _, desc := range index.Manifests {
+		name := desc.Annotations[imagespecs.AnnotationRefName]
+		if name == "" {
+			continue
+		}
+		result = append(result, images.Image{
+			Name:      name,
+			Target:    desc,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+	}
+	return result, nil
+}
+
+// Export writes the image and all its child blobs as OCI image layout tar stream
+func (OCIArchive) Export(ctx context.Context, store content.Provider, desc imagespecs.Descriptor, writer io.Writer) error {
+	tw := tar.NewWriter(writer)
+	defer tw.Close()
+
+	handler := images.HandlerFunc(func(ctx context.Context, desc imagespecs.Descriptor) ([]imagespecs.Descriptor, error) {
+		ra, err := store.ReaderAt(ctx, desc.Digest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to read blob [%s]", desc.Digest)
+		}
+		defer ra.Close()
+
+		if err := writeTarFile(tw, blobPath(desc.Digest), ra.Size(), content.NewReader(ra)); err != nil {
+			return nil, err
+		}
+		return images.Children(ctx, store, desc)
+	})
+
+	if err := images.Walk(ctx, handler, desc); err != nil {
+		return errors.Wrap(err, "Error while writing image blobs to archive")
+	}
+
+	layout, err := json.Marshal(imagespecs.ImageLayout{Version: imagespecs.ImageLayoutVersion})
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, imagespecs.ImageLayoutFile, int64(len(layout)), bytes.NewReader(layout)); err != nil {
+		return err
+	}
+
+	index, err := json.Marshal(imagespecs.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Manifests: []imagespecs.Descriptor{desc},
+	})
+	if err != nil {
+		return err
+	}
+	return writeTarFile(tw, imageIndexFile, int64(len(index)), bytes.NewReader(index))
+}
+
+func writeTarFile(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0444,
+		Size:     size,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return errors.Wrapf(err, "Failed to write archive header for [%s]", name)
+	}
+	if _, err := io.CopyN(tw, r, size); err != nil {
+		return errors.Wrapf(err, "Failed to write [%s] to archive", name)
+	}
+	return nil
+}
+
+func blobPath(dgst digest.Digest) string {
+	return path.Join("blobs", dgst.Algorithm().String(), dgst.Hex())
+}
+
+// parseBlobPath resolves digest from blob path in form "blobs/<alg>/<hex>"
+func parseBlobPath(name string) (digest.Digest, bool) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 3 || parts[0] != "blobs" {
+		return "", false
+	}
+	dgst := digest.NewDigestFromHex(parts[1], parts[2])
+	if err := dgst.Validate(); err != nil {
+		return "", false
+	}
+	return dgst, true
+}
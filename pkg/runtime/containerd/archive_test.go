@@ -0,0 +1,22 @@
+package containerd
+
+import (
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBlobPath(t *testing.T) {
+	dgst := digest.FromString("foo")
+
+	result, ok := parseBlobPath(blobPath(dgst))
+	assert.True(t, ok)
+	assert.Equal(t, dgst, result)
+
+	_, ok = parseBlobPath("blobs/sha256/invalid")
+	assert.False(t, ok, "should not accept invalid digest")
+
+	_, ok = parseBlobPath("oci-layout")
+	assert.False(t, ok, "should not accept other than blob files")
+}
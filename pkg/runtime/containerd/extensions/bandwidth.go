@@ -0,0 +1,57 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/typeurl"
+	"github.com/gogo/protobuf/types"
+)
+
+var bandwidthExtensionName = "eliot.io.bandwidth"
+
+// Bandwidth contains the container traffic class and its rate limit
+type Bandwidth struct {
+	// ClassID is the net_cls class what the container traffic gets tagged with
+	ClassID uint32
+	// EgressRate is the outgoing traffic limit in bits per second
+	EgressRate int64
+}
+
+// WithBandwidthExtension appends bandwidth extension data to the container object.
+func WithBandwidthExtension(bandwidth Bandwidth) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		any, err := typeurl.MarshalAny(&bandwidth)
+		if err != nil {
+			return err
+		}
+
+		if c.Extensions == nil {
+			c.Extensions = make(map[string]types.Any)
+		}
+		c.Extensions[bandwidthExtensionName] = *any
+		return nil
+	}
+}
+
+// GetBandwidthExtension returns Bandwidth from container extensions or nil if not defined
+func GetBandwidthExtension(container containers.Container) (*Bandwidth, error) {
+	extension, ok := container.Extensions[bandwidthExtensionName]
+	if !ok {
+		return nil, nil
+	}
+
+	decoded, err := typeurl.UnmarshalAny(&extension)
+	if err != nil {
+		return nil, err
+	}
+
+	bandwidth, ok := decoded.(*Bandwidth)
+	if !ok {
+		return nil, fmt.Errorf("Failed to decode Bandwidth from container [%s] extensions", container.ID)
+	}
+
+	return bandwidth, err
+}
@@ -0,0 +1,24 @@
+package extensions
+
+import (
+	"testing"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBandwidthExtension(t *testing.T) {
+	container := &containers.Container{ID: "foo"}
+	bandwidth := Bandwidth{ClassID: 0x100123, EgressRate: 1000000}
+
+	err := WithBandwidthExtension(bandwidth)(nil, nil, container)
+	assert.NoError(t, err)
+
+	result, err := GetBandwidthExtension(*container)
+	assert.NoError(t, err)
+	assert.Equal(t, &bandwidth, result)
+
+	result, err = GetBandwidthExtension(containers.Container{})
+	assert.NoError(t, err)
+	assert.Nil(t, result, "should return nil if not defined")
+}
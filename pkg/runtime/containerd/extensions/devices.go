@@ -0,0 +1,64 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/typeurl"
+	"github.com/gogo/protobuf/types"
+)
+
+var devicesExtensionName = "eliot.io.devices"
+
+// Devices contains the container devices and accelerators as they were given, the spec has them resolved
+type Devices struct {
+	Devices      []Device
+	Accelerators []string
+	// Libraries are the accelerator driver library paths what got bind mounted to the container
+	Libraries []string
+}
+
+// Device is host device node passed through to the container
+type Device struct {
+	HostPath      string
+	ContainerPath string
+	Permissions   string
+}
+
+// WithDevicesExtension appends devices extension data to the container object.
+func WithDevicesExtension(devices Devices) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		any, err := typeurl.MarshalAny(&devices)
+		if err != nil {
+			return err
+		}
+
+		if c.Extensions == nil {
+			c.Extensions = make(map[string]types.Any)
+		}
+		c.Extensions[devicesExtensionName] = *any
+		return nil
+	}
+}
+
+// GetDevicesExtension returns Devices from container extensions or nil if not defined
+func GetDevicesExtension(container containers.Container) (*Devices, error) {
+	extension, ok := container.Extensions[devicesExtensionName]
+	if !ok {
+		return nil, nil
+	}
+
+	decoded, err := typeurl.UnmarshalAny(&extension)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, ok := decoded.(*Devices)
+	if !ok {
+		return nil, fmt.Errorf("Failed to decode Devices from container [%s] extensions", container.ID)
+	}
+
+	return devices, err
+}
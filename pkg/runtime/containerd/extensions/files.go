@@ -0,0 +1,63 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/typeurl"
+	"github.com/gogo/protobuf/types"
+)
+
+var filesExtensionName = "eliot.io.files"
+
+// Files contains the files what get mounted to the container
+// The secret content is never stored, only the secret name
+type Files struct {
+	Files []File
+}
+
+// File is single file mounted to the container
+type File struct {
+	Path    string
+	Content string
+	Secret  string
+	Mode    uint32
+}
+
+// WithFilesExtension appends files extension data to the container object.
+func WithFilesExtension(files Files) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		any, err := typeurl.MarshalAny(&files)
+		if err != nil {
+			return err
+		}
+
+		if c.Extensions == nil {
+			c.Extensions = make(map[string]types.Any)
+		}
+		c.Extensions[filesExtensionName] = *any
+		return nil
+	}
+}
+
+// GetFilesExtension returns Files from container extensions or nil if not defined
+func GetFilesExtension(container containers.Container) (*Files, error) {
+	extension, ok := container.Extensions[filesExtensionName]
+	if !ok {
+		return nil, nil
+	}
+
+	decoded, err := typeurl.UnmarshalAny(&extension)
+	if err != nil {
+		return nil, err
+	}
+
+	files, ok := decoded.(*Files)
+	if !ok {
+		return nil, fmt.Errorf("Failed to decode Files from container [%s] extensions", container.ID)
+	}
+
+	return files, err
+}
@@ -0,0 +1,43 @@
+package extensions
+
+import (
+	"testing"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFilesExtension(t *testing.T) {
+	container := &containers.Container{ID: "foo"}
+	files := Files{Files: []File{
+		{Path: "/etc/app/config.yml", Content: "debug: true", Mode: 0444},
+		{Path: "/run/secrets/api-key", Secret: "api-key", Mode: 0400},
+	}}
+
+	err := WithFilesExtension(files)(nil, nil, container)
+	assert.NoError(t, err)
+
+	result, err := GetFilesExtension(*container)
+	assert.NoError(t, err)
+	assert.Equal(t, &files, result)
+
+	result, err = GetFilesExtension(containers.Container{})
+	assert.NoError(t, err)
+	assert.Nil(t, result, "should return nil if not defined")
+}
+
+func TestGetStateFilesExtension(t *testing.T) {
+	container := &containers.Container{ID: "foo"}
+	files := StateFiles{Files: map[string]string{"/etc/resolv.conf": "nameserver 8.8.8.8\n"}}
+
+	err := WithStateFilesExtension(files)(nil, nil, container)
+	assert.NoError(t, err)
+
+	result, err := GetStateFilesExtension(*container)
+	assert.NoError(t, err)
+	assert.Equal(t, &files, result)
+
+	result, err = GetStateFilesExtension(containers.Container{})
+	assert.NoError(t, err)
+	assert.Nil(t, result, "should return nil if not defined")
+}
@@ -0,0 +1,56 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/typeurl"
+	"github.com/gogo/protobuf/types"
+)
+
+var fileWatchExtensionName = "eliot.io.filewatch"
+
+// FileWatch contains the host files what trigger the container restart when modified
+type FileWatch struct {
+	Paths    []string
+	Debounce time.Duration
+}
+
+// WithFileWatchExtension appends file watch extension data to the container object.
+func WithFileWatchExtension(watch FileWatch) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		any, err := typeurl.MarshalAny(&watch)
+		if err != nil {
+			return err
+		}
+
+		if c.Extensions == nil {
+			c.Extensions = make(map[string]types.Any)
+		}
+		c.Extensions[fileWatchExtensionName] = *any
+		return nil
+	}
+}
+
+// GetFileWatchExtension returns FileWatch from container extensions or nil if not defined
+func GetFileWatchExtension(container containers.Container) (*FileWatch, error) {
+	extension, ok := container.Extensions[fileWatchExtensionName]
+	if !ok {
+		return nil, nil
+	}
+
+	decoded, err := typeurl.UnmarshalAny(&extension)
+	if err != nil {
+		return nil, err
+	}
+
+	watch, ok := decoded.(*FileWatch)
+	if !ok {
+		return nil, fmt.Errorf("Failed to decode FileWatch from container [%s] extensions", container.ID)
+	}
+
+	return watch, err
+}
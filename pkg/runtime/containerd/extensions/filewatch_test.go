@@ -0,0 +1,25 @@
+package extensions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFileWatchExtension(t *testing.T) {
+	container := &containers.Container{ID: "foo"}
+	watch := FileWatch{Paths: []string{"/etc/foo/cert.pem"}, Debounce: 10 * time.Second}
+
+	err := WithFileWatchExtension(watch)(nil, nil, container)
+	assert.NoError(t, err)
+
+	result, err := GetFileWatchExtension(*container)
+	assert.NoError(t, err)
+	assert.Equal(t, &watch, result)
+
+	result, err = GetFileWatchExtension(containers.Container{})
+	assert.NoError(t, err)
+	assert.Nil(t, result, "should return nil if not defined")
+}
@@ -0,0 +1,57 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/typeurl"
+	"github.com/gogo/protobuf/types"
+)
+
+var idleStopExtensionName = "eliot.io.idlestop"
+
+// IdleStop contains when the container gets stopped as idle and the port what starts it again
+type IdleStop struct {
+	Timeout time.Duration
+	Port    int
+	Signal  string
+}
+
+// WithIdleStopExtension appends idle stop extension data to the container object.
+func WithIdleStopExtension(idleStop IdleStop) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		any, err := typeurl.MarshalAny(&idleStop)
+		if err != nil {
+			return err
+		}
+
+		if c.Extensions == nil {
+			c.Extensions = make(map[string]types.Any)
+		}
+		c.Extensions[idleStopExtensionName] = *any
+		return nil
+	}
+}
+
+// GetIdleStopExtension returns IdleStop from container extensions or nil if not defined
+func GetIdleStopExtension(container containers.Container) (*IdleStop, error) {
+	extension, ok := container.Extensions[idleStopExtensionName]
+	if !ok {
+		return nil, nil
+	}
+
+	decoded, err := typeurl.UnmarshalAny(&extension)
+	if err != nil {
+		return nil, err
+	}
+
+	idleStop, ok := decoded.(*IdleStop)
+	if !ok {
+		return nil, fmt.Errorf("Failed to decode IdleStop from container [%s] extensions", container.ID)
+	}
+
+	return idleStop, err
+}
@@ -3,6 +3,7 @@ package extensions
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/containers"
@@ -21,6 +22,8 @@ const (
 	Always = iota
 	// OnFailure means that only if process fails (non zero exit code) the container should be restarted
 	OnFailure
+	// Never means that the container doesn't get restarted, e.g. one-shot run container
+	Never
 )
 
 func (p RestartPolicy) String() string {
@@ -29,6 +32,8 @@ func (p RestartPolicy) String() string {
 		return "always"
 	case OnFailure:
 		return "onfailure"
+	case Never:
+		return "never"
 	default:
 		return "unknown"
 	}
@@ -40,6 +45,13 @@ type ContainerLifecycle struct {
 	// If value is zero, assumed that it's not yet created
 	StartCount    int
 	RestartPolicy RestartPolicy
+	// LastExitCode and LastExitReason describe how the previous task exited
+	LastExitCode   uint32
+	LastExitReason string
+	// Ready is the result of the latest readiness probe
+	Ready bool
+	// LastStartedAt is when the container got started the last time
+	LastStartedAt time.Time
 }
 
 // WithLifecycleExtension is containerd.NewContainerOpts implementation what add lifecycle extension data to the container object.
@@ -62,17 +74,60 @@ func updateLifecycleExtension(c *containers.Container, lifecycle ContainerLifecy
 	return nil
 }
 
+// WithRestartPolicy returns containerd.NewContainerOpts what sets the lifecycle restart policy,
+// must be after WithLifecycleExtension
+func WithRestartPolicy(policy RestartPolicy) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		lifecycle, err := GetLifecycleExtension(*c)
+		if err != nil {
+			return errors.Wrapf(err, "Cannot set container restart policy")
+		}
+		lifecycle.RestartPolicy = policy
+
+		return updateLifecycleExtension(c, lifecycle)
+	}
+}
+
 // IncrementRestart is containerd.UpdateContainerOpts implementation what increments restart counter
+// and records the start time
 func IncrementRestart(ctx context.Context, client *containerd.Client, c *containers.Container) error {
 	lifecycle, err := GetLifecycleExtension(*c)
 	if err != nil {
 		return errors.Wrapf(err, "Cannot increment container restart counter")
 	}
 	lifecycle.StartCount++
+	lifecycle.LastStartedAt = time.Now()
 
 	return updateLifecycleExtension(c, lifecycle)
 }
 
+// WithLastExit returns containerd.UpdateContainerOpts what stores the previous task exit code and reason
+func WithLastExit(code uint32, reason string) containerd.UpdateContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		lifecycle, err := GetLifecycleExtension(*c)
+		if err != nil {
+			return errors.Wrapf(err, "Cannot update container last exit status")
+		}
+		lifecycle.LastExitCode = code
+		lifecycle.LastExitReason = reason
+
+		return updateLifecycleExtension(c, lifecycle)
+	}
+}
+
+// WithReady returns containerd.UpdateContainerOpts what stores the container readiness
+func WithReady(ready bool) containerd.UpdateContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		lifecycle, err := GetLifecycleExtension(*c)
+		if err != nil {
+			return errors.Wrapf(err, "Cannot update container readiness")
+		}
+		lifecycle.Ready = ready
+
+		return updateLifecycleExtension(c, lifecycle)
+	}
+}
+
 // GetLifecycleExtension returns ContainerLifecycle from container extensions or nil if not defined
 func GetLifecycleExtension(c containers.Container) (ContainerLifecycle, error) {
 	extension, ok := c.Extensions[lifecycleExtensionName]
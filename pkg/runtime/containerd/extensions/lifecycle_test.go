@@ -28,3 +28,44 @@ func TestGetLifecycleExtensionReturnNotFoundErr(t *testing.T) {
 	_, err := GetLifecycleExtension(containers.Container{})
 	assert.True(t, IsNotFound(err))
 }
+
+func TestWithLastExit(t *testing.T) {
+	any, _ := typeurl.MarshalAny(&ContainerLifecycle{StartCount: 2})
+	container := &containers.Container{
+		Extensions: map[string]types.Any{lifecycleExtensionName: *any},
+	}
+
+	err := WithLastExit(137, "OOMKilled")(nil, nil, container)
+	assert.NoError(t, err)
+
+	result, err := GetLifecycleExtension(*container)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.StartCount, "should keep the start count")
+	assert.Equal(t, uint32(137), result.LastExitCode)
+	assert.Equal(t, "OOMKilled", result.LastExitReason)
+}
+
+func TestWithReady(t *testing.T) {
+	any, _ := typeurl.MarshalAny(&ContainerLifecycle{StartCount: 2})
+	container := &containers.Container{
+		Extensions: map[string]types.Any{lifecycleExtensionName: *any},
+	}
+
+	err := WithReady(true)(nil, nil, container)
+	assert.NoError(t, err)
+
+	result, err := GetLifecycleExtension(*container)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.StartCount, "should keep the start count")
+	assert.True(t, result.Ready)
+}
+
+func TestWithRestartPolicy(t *testing.T) {
+	container := &containers.Container{}
+	assert.NoError(t, WithLifecycleExtension(nil, nil, container))
+	assert.NoError(t, WithRestartPolicy(Never)(nil, nil, container))
+
+	result, err := GetLifecycleExtension(*container)
+	assert.NoError(t, err)
+	assert.Equal(t, "never", result.RestartPolicy.String())
+}
@@ -0,0 +1,54 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/typeurl"
+	"github.com/gogo/protobuf/types"
+)
+
+var loggingExtensionName = "eliot.io.logging"
+
+// Logging contains the log driver where the container output gets forwarded
+type Logging struct {
+	Driver string
+}
+
+// WithLoggingExtension appends logging extension data to the container object.
+func WithLoggingExtension(logging Logging) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		any, err := typeurl.MarshalAny(&logging)
+		if err != nil {
+			return err
+		}
+
+		if c.Extensions == nil {
+			c.Extensions = make(map[string]types.Any)
+		}
+		c.Extensions[loggingExtensionName] = *any
+		return nil
+	}
+}
+
+// GetLoggingExtension returns Logging from container extensions or nil if not defined
+func GetLoggingExtension(container containers.Container) (*Logging, error) {
+	extension, ok := container.Extensions[loggingExtensionName]
+	if !ok {
+		return nil, nil
+	}
+
+	decoded, err := typeurl.UnmarshalAny(&extension)
+	if err != nil {
+		return nil, err
+	}
+
+	logging, ok := decoded.(*Logging)
+	if !ok {
+		return nil, fmt.Errorf("Failed to decode Logging from container [%s] extensions", container.ID)
+	}
+
+	return logging, err
+}
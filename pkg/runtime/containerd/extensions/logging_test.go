@@ -0,0 +1,24 @@
+package extensions
+
+import (
+	"testing"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLoggingExtension(t *testing.T) {
+	container := &containers.Container{ID: "foo"}
+	logging := Logging{Driver: "journald"}
+
+	err := WithLoggingExtension(logging)(nil, nil, container)
+	assert.NoError(t, err)
+
+	result, err := GetLoggingExtension(*container)
+	assert.NoError(t, err)
+	assert.Equal(t, &logging, result)
+
+	result, err = GetLoggingExtension(containers.Container{})
+	assert.NoError(t, err)
+	assert.Nil(t, result, "should return nil if not defined")
+}
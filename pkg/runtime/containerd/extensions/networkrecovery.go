@@ -0,0 +1,54 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/typeurl"
+	"github.com/gogo/protobuf/types"
+)
+
+var networkRecoveryExtensionName = "eliot.io.network-recovery"
+
+// NetworkRecovery contains whether the container gets restarted when the host network comes back online
+type NetworkRecovery struct {
+	Restart bool
+}
+
+// WithNetworkRecoveryExtension appends network recovery extension data to the container object.
+func WithNetworkRecoveryExtension(recovery NetworkRecovery) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		any, err := typeurl.MarshalAny(&recovery)
+		if err != nil {
+			return err
+		}
+
+		if c.Extensions == nil {
+			c.Extensions = make(map[string]types.Any)
+		}
+		c.Extensions[networkRecoveryExtensionName] = *any
+		return nil
+	}
+}
+
+// GetNetworkRecoveryExtension returns NetworkRecovery from container extensions or nil if not defined
+func GetNetworkRecoveryExtension(container containers.Container) (*NetworkRecovery, error) {
+	extension, ok := container.Extensions[networkRecoveryExtensionName]
+	if !ok {
+		return nil, nil
+	}
+
+	decoded, err := typeurl.UnmarshalAny(&extension)
+	if err != nil {
+		return nil, err
+	}
+
+	recovery, ok := decoded.(*NetworkRecovery)
+	if !ok {
+		return nil, fmt.Errorf("Failed to decode NetworkRecovery from container [%s] extensions", container.ID)
+	}
+
+	return recovery, err
+}
@@ -0,0 +1,24 @@
+package extensions
+
+import (
+	"testing"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNetworkRecoveryExtension(t *testing.T) {
+	container := &containers.Container{ID: "foo"}
+	recovery := NetworkRecovery{Restart: true}
+
+	err := WithNetworkRecoveryExtension(recovery)(nil, nil, container)
+	assert.NoError(t, err)
+
+	result, err := GetNetworkRecoveryExtension(*container)
+	assert.NoError(t, err)
+	assert.Equal(t, &recovery, result)
+
+	result, err = GetNetworkRecoveryExtension(containers.Container{})
+	assert.NoError(t, err)
+	assert.Nil(t, result, "should return nil if not defined")
+}
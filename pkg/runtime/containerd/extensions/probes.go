@@ -0,0 +1,65 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/typeurl"
+	"github.com/gogo/protobuf/types"
+)
+
+var probesExtensionName = "eliot.io.probes"
+
+// Probes contains the container liveness and readiness probe definitions
+type Probes struct {
+	Liveness  *Probe
+	Readiness *Probe
+}
+
+// Probe defines a command what gets executed in the container to check its health
+type Probe struct {
+	Exec             []string
+	InitialDelay     time.Duration
+	Period           time.Duration
+	Timeout          time.Duration
+	FailureThreshold int
+}
+
+// WithProbesExtension appends probes extension data to the container object.
+func WithProbesExtension(probes Probes) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		any, err := typeurl.MarshalAny(&probes)
+		if err != nil {
+			return err
+		}
+
+		if c.Extensions == nil {
+			c.Extensions = make(map[string]types.Any)
+		}
+		c.Extensions[probesExtensionName] = *any
+		return nil
+	}
+}
+
+// GetProbesExtension returns Probes from container extensions or nil if not defined
+func GetProbesExtension(container containers.Container) (*Probes, error) {
+	extension, ok := container.Extensions[probesExtensionName]
+	if !ok {
+		return nil, nil
+	}
+
+	decoded, err := typeurl.UnmarshalAny(&extension)
+	if err != nil {
+		return nil, err
+	}
+
+	probes, ok := decoded.(*Probes)
+	if !ok {
+		return nil, fmt.Errorf("Failed to decode Probes from container [%s] extensions", container.ID)
+	}
+
+	return probes, err
+}
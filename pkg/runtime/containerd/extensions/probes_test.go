@@ -0,0 +1,29 @@
+package extensions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProbesExtension(t *testing.T) {
+	container := &containers.Container{ID: "foo"}
+	probes := Probes{
+		Liveness: &Probe{Exec: []string{"pgrep", "foo"}, Period: 10 * time.Second, FailureThreshold: 3},
+	}
+
+	err := WithProbesExtension(probes)(nil, nil, container)
+	assert.NoError(t, err)
+
+	result, err := GetProbesExtension(*container)
+	assert.NoError(t, err)
+	assert.Equal(t, &probes, result)
+}
+
+func TestGetProbesExtensionReturnNilIfNotDefined(t *testing.T) {
+	result, err := GetProbesExtension(containers.Container{})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
@@ -14,4 +14,14 @@ func init() {
 	major := strconv.Itoa(versionMajor)
 	typeurl.Register(&PipeSet{}, prefix, "containerd/extensions", major, "PipeSet")
 	typeurl.Register(&ContainerLifecycle{}, prefix, "containerd/extensions", major, "ContainerLifecycle")
+	typeurl.Register(&Probes{}, prefix, "containerd/extensions", major, "Probes")
+	typeurl.Register(&FileWatch{}, prefix, "containerd/extensions", major, "FileWatch")
+	typeurl.Register(&Schedule{}, prefix, "containerd/extensions", major, "Schedule")
+	typeurl.Register(&Logging{}, prefix, "containerd/extensions", major, "Logging")
+	typeurl.Register(&Files{}, prefix, "containerd/extensions", major, "Files")
+	typeurl.Register(&Bandwidth{}, prefix, "containerd/extensions", major, "Bandwidth")
+	typeurl.Register(&NetworkRecovery{}, prefix, "containerd/extensions", major, "NetworkRecovery")
+	typeurl.Register(&IdleStop{}, prefix, "containerd/extensions", major, "IdleStop")
+	typeurl.Register(&Devices{}, prefix, "containerd/extensions", major, "Devices")
+	typeurl.Register(&StateFiles{}, prefix, "containerd/extensions", major, "StateFiles")
 }
@@ -0,0 +1,54 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/typeurl"
+	"github.com/gogo/protobuf/types"
+)
+
+var scheduleExtensionName = "eliot.io.schedule"
+
+// Schedule contains the cron expression when the container gets started
+type Schedule struct {
+	Cron string
+}
+
+// WithScheduleExtension appends schedule extension data to the container object.
+func WithScheduleExtension(schedule Schedule) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		any, err := typeurl.MarshalAny(&schedule)
+		if err != nil {
+			return err
+		}
+
+		if c.Extensions == nil {
+			c.Extensions = make(map[string]types.Any)
+		}
+		c.Extensions[scheduleExtensionName] = *any
+		return nil
+	}
+}
+
+// GetScheduleExtension returns Schedule from container extensions or nil if not defined
+func GetScheduleExtension(container containers.Container) (*Schedule, error) {
+	extension, ok := container.Extensions[scheduleExtensionName]
+	if !ok {
+		return nil, nil
+	}
+
+	decoded, err := typeurl.UnmarshalAny(&extension)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, ok := decoded.(*Schedule)
+	if !ok {
+		return nil, fmt.Errorf("Failed to decode Schedule from container [%s] extensions", container.ID)
+	}
+
+	return schedule, err
+}
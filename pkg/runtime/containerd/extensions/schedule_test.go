@@ -0,0 +1,24 @@
+package extensions
+
+import (
+	"testing"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetScheduleExtension(t *testing.T) {
+	container := &containers.Container{ID: "foo"}
+	schedule := Schedule{Cron: "0 3 * * *"}
+
+	err := WithScheduleExtension(schedule)(nil, nil, container)
+	assert.NoError(t, err)
+
+	result, err := GetScheduleExtension(*container)
+	assert.NoError(t, err)
+	assert.Equal(t, &schedule, result)
+
+	result, err = GetScheduleExtension(containers.Container{})
+	assert.NoError(t, err)
+	assert.Nil(t, result, "should return nil if not defined")
+}
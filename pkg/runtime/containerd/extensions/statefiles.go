@@ -0,0 +1,55 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/typeurl"
+	"github.com/gogo/protobuf/types"
+)
+
+var stateFilesExtensionName = "eliot.io.state-files"
+
+// StateFiles contains the content of the generated host files, e.g. resolv.conf, by the container path
+// so that the files can be written again when they are missing, e.g. after reboot cleared /run
+type StateFiles struct {
+	Files map[string]string
+}
+
+// WithStateFilesExtension appends state files extension data to the container object.
+func WithStateFilesExtension(files StateFiles) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		any, err := typeurl.MarshalAny(&files)
+		if err != nil {
+			return err
+		}
+
+		if c.Extensions == nil {
+			c.Extensions = make(map[string]types.Any)
+		}
+		c.Extensions[stateFilesExtensionName] = *any
+		return nil
+	}
+}
+
+// GetStateFilesExtension returns StateFiles from container extensions or nil if not defined
+func GetStateFilesExtension(container containers.Container) (*StateFiles, error) {
+	extension, ok := container.Extensions[stateFilesExtensionName]
+	if !ok {
+		return nil, nil
+	}
+
+	decoded, err := typeurl.UnmarshalAny(&extension)
+	if err != nil {
+		return nil, err
+	}
+
+	files, ok := decoded.(*StateFiles)
+	if !ok {
+		return nil, fmt.Errorf("Failed to decode StateFiles from container [%s] extensions", container.ID)
+	}
+
+	return files, err
+}
@@ -0,0 +1,84 @@
+package containerd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/oci"
+	"github.com/ernoaapa/eliot/pkg/model"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+// tmpfsMagic is the tmpfs filesystem type in statfs
+const tmpfsMagic = 0x01021994
+
+// WithFiles writes the files into the dir and bind mounts them read-only to the container
+// The dir must be on tmpfs so that the file content, e.g. secrets, never gets written to persistent storage
+func WithFiles(dir string, files []model.FileMount) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return errors.Wrapf(err, "Error while creating directory for container files [%s]", dir)
+		}
+		if err := ensureTmpfs(dir); err != nil {
+			return err
+		}
+
+		for i, file := range files {
+			path := filepath.Join(dir, strconv.Itoa(i))
+			if err := writeFileMount(path, file); err != nil {
+				return err
+			}
+			s.Mounts = replaceOrAppendMount(s.Mounts, specs.Mount{
+				Type:        "bind",
+				Source:      path,
+				Destination: file.Path,
+				Options:     []string{"rbind", "ro", "nosuid", "nodev", "noexec"},
+			})
+		}
+		return nil
+	}
+}
+
+// RestoreFile writes the file again to the path where WithFiles wrote it, e.g. after reboot cleared the tmpfs
+func RestoreFile(path string, file model.FileMount) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.Wrapf(err, "Error while creating directory for container files [%s]", dir)
+	}
+	if err := ensureTmpfs(dir); err != nil {
+		return err
+	}
+	return writeFileMount(path, file)
+}
+
+func ensureTmpfs(dir string) error {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return errors.Wrapf(err, "Failed to resolve filesystem of [%s]", dir)
+	}
+	if int64(stat.Type) != tmpfsMagic {
+		return errors.Errorf("Container files directory [%s] is not on tmpfs, refuse to write the files to persistent storage", dir)
+	}
+	return nil
+}
+
+// writeFileMount writes the file content with the file mode, the mode is set explicitly so umask doesn't affect it
+func writeFileMount(path string, file model.FileMount) error {
+	mode := os.FileMode(file.Mode)
+	if mode == 0 {
+		mode = model.DefaultFileMountMode
+	}
+	if err := ioutil.WriteFile(path, []byte(file.Content), mode); err != nil {
+		return errors.Wrapf(err, "Error while writing container file [%s]", file.Path)
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		return errors.Wrapf(err, "Error while setting container file [%s] mode", file.Path)
+	}
+	return nil
+}
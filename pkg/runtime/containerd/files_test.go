@@ -0,0 +1,54 @@
+package containerd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithFiles(t *testing.T) {
+	root, err := ioutil.TempDir("/dev/shm", "files")
+	if err != nil {
+		t.Skip("Tmpfs /dev/shm not available")
+	}
+	defer os.RemoveAll(root)
+
+	spec := &specs.Spec{}
+	err = WithFiles(filepath.Join(root, "files"), []model.FileMount{
+		{Path: "/etc/app/config.yml", Content: "debug: true"},
+		{Path: "/run/secrets/api-key", Content: "s3cr3t", Mode: 0400},
+	})(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Len(t, spec.Mounts, 2)
+	assert.Equal(t, "/etc/app/config.yml", spec.Mounts[0].Destination)
+	assert.Contains(t, spec.Mounts[0].Options, "ro")
+
+	content, err := ioutil.ReadFile(spec.Mounts[1].Source)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(content))
+
+	info, err := os.Stat(spec.Mounts[0].Source)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0444), info.Mode(), "should default to read-only for all")
+	info, err = os.Stat(spec.Mounts[1].Source)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0400), info.Mode())
+}
+
+func TestWithFilesRequiresTmpfs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "files")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	if ensureTmpfs(dir) == nil {
+		t.Skip("Temp directory is on tmpfs")
+	}
+
+	err = WithFiles(dir, []model.FileMount{{Path: "/foo", Content: "bar"}})(nil, nil, nil, &specs.Spec{})
+	assert.Error(t, err, "should refuse to write files to persistent storage")
+}
@@ -2,6 +2,8 @@ package mapping
 
 import (
 	"encoding/json"
+	"path"
+	"strings"
 
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 	log "github.com/sirupsen/logrus"
@@ -12,6 +14,9 @@ import (
 	"github.com/ernoaapa/eliot/pkg/runtime/containerd/extensions"
 )
 
+// InitDestination is the path where the init binary gets mounted in the containers with init enabled
+const InitDestination = "/dev/init"
+
 // GetPodName resolves pod name where the container belongs
 func GetPodName(container containers.Container) string {
 	labels := ContainerLabels(container.Labels)
@@ -23,6 +28,11 @@ func GetPodName(container containers.Container) string {
 	return podName
 }
 
+// GetContainerName resolves container name from the container labels
+func GetContainerName(container containers.Container) string {
+	return ContainerLabels(container.Labels).getContainerName()
+}
+
 // InitialisePodModel creates new Pod struct with name and namespace metadata
 func InitialisePodModel(container containers.Container, namespace, name, hostname string) model.Pod {
 	return model.Pod{
@@ -32,10 +42,16 @@ func InitialisePodModel(container containers.Container, namespace, name, hostnam
 			HostNetwork:   !haveNamespace(container, specs.NetworkNamespace),
 			HostPID:       !haveNamespace(container, specs.PIDNamespace),
 			RestartPolicy: getRestartPolicy(container),
+
+			StopGracePeriod: ContainerLabels(container.Labels).getStopGracePeriod(),
+			Resources:       ContainerLabels(container.Labels).getPodResources(),
+
+			ImagePullSecrets: ContainerLabels(container.Labels).getPullSecrets(),
 		},
 		Status: model.PodStatus{
 			Hostname:          hostname,
 			ContainerStatuses: []model.ContainerStatus{},
+			SpecHash:          ContainerLabels(container.Labels).getSpecHash(),
 		},
 	}
 }
@@ -51,18 +67,62 @@ func MapContainersToInternalModel(containers []containers.Container) (result []m
 // MapContainerToInternalModel maps containerd model to internal model
 func MapContainerToInternalModel(container containers.Container) model.Container {
 	labels := ContainerLabels(container.Labels)
+	probes := getProbes(container)
 	return model.Container{
-		Name:       labels.getContainerName(),
-		Image:      container.Image,
-		Tty:        RequireTty(container),
-		Args:       processArgs(container),
-		Env:        processEnv(container),
-		WorkingDir: processWorkingDir(container),
-		Pipe:       mapPipeToInternalModel(container),
-		Mounts:     mapMountsToInternalModel(container),
+		ID:              container.ID,
+		Name:            labels.getContainerName(),
+		Image:           container.Image,
+		Tty:             RequireTty(container),
+		Args:            processArgs(container),
+		Env:             processEnv(container),
+		WorkingDir:      processWorkingDir(container),
+		Pipe:            mapPipeToInternalModel(container),
+		Mounts:          mapMountsToInternalModel(container),
+		Resources:       mapResourcesToInternalModel(container),
+		Hostname:        processHostname(container),
+		CgroupParent:    processCgroupParent(container),
+		Ulimits:         mapUlimitsToInternalModel(container),
+		Annotations:     processAnnotations(container),
+		Sysctls:         processSysctls(container),
+		Capabilities:    processCapabilities(container, labels),
+		OOMScoreAdj:     processOOMScoreAdj(container),
+		Init:            processInit(container),
+		AppArmorProfile: processAppArmorProfile(container),
+		HostNetwork:     !haveNamespace(container, specs.NetworkNamespace),
+
+		LivenessProbe:            mapProbeToInternalModel(probes.Liveness),
+		ReadinessProbe:           mapProbeToInternalModel(probes.Readiness),
+		RestartOnChange:          mapFileWatchToInternalModel(container),
+		IdleStop:                 mapIdleStopToInternalModel(container),
+		Devices:                  mapDevicesToInternalModel(container),
+		Accelerators:             getDevicesExtension(container).Accelerators,
+		StartPriority:            labels.getStartPriority(),
+		StorageQuota:             labels.getStorageQuota(),
+		HostEnv:                  labels.getHostEnv(),
+		Schedule:                 processSchedule(container),
+		LogDriver:                processLogDriver(container),
+		Files:                    mapFilesToInternalModel(container),
+		EgressRateLimit:          processEgressRateLimit(container),
+		RestartOnNetworkRecovery: processRestartOnNetworkRecovery(container),
 	}
 }
 
+// MapContainerInspectToInternalModel maps containerd model to internal container inspect model
+func MapContainerInspectToInternalModel(container containers.Container) model.ContainerInspect {
+	result := model.ContainerInspect{
+		ID:          container.ID,
+		Image:       container.Image,
+		Labels:      container.Labels,
+		Runtime:     container.Runtime.Name,
+		Snapshotter: container.Snapshotter,
+		SnapshotKey: container.SnapshotKey,
+	}
+	if container.Spec != nil {
+		result.Spec = container.Spec.Value
+	}
+	return result
+}
+
 // RequireTty find out is the container configured to create TTY
 func RequireTty(container containers.Container) bool {
 	spec, err := getSpec(container)
@@ -100,6 +160,141 @@ func mapPipeToInternalModel(container containers.Container) *model.PipeSet {
 	}
 }
 
+func getProbes(container containers.Container) extensions.Probes {
+	probes, err := extensions.GetProbesExtension(container)
+	if err != nil {
+		log.Errorf("Failed to read Probes extension from container [%s]: %s", container.ID, err)
+	}
+	if probes == nil {
+		return extensions.Probes{}
+	}
+	return *probes
+}
+
+func mapFileWatchToInternalModel(container containers.Container) *model.FileWatch {
+	watch, err := extensions.GetFileWatchExtension(container)
+	if err != nil {
+		log.Errorf("Failed to read FileWatch extension from container [%s]: %s", container.ID, err)
+	}
+	if watch == nil {
+		return nil
+	}
+	return &model.FileWatch{
+		Paths:    watch.Paths,
+		Debounce: watch.Debounce,
+	}
+}
+
+func mapIdleStopToInternalModel(container containers.Container) *model.IdleStop {
+	idleStop, err := extensions.GetIdleStopExtension(container)
+	if err != nil {
+		log.Errorf("Failed to read IdleStop extension from container [%s]: %s", container.ID, err)
+	}
+	if idleStop == nil {
+		return nil
+	}
+	return &model.IdleStop{
+		Timeout: idleStop.Timeout,
+		Port:    idleStop.Port,
+		Signal:  idleStop.Signal,
+	}
+}
+
+func getDevicesExtension(container containers.Container) extensions.Devices {
+	devices, err := extensions.GetDevicesExtension(container)
+	if err != nil {
+		log.Errorf("Failed to read Devices extension from container [%s]: %s", container.ID, err)
+	}
+	if devices == nil {
+		return extensions.Devices{}
+	}
+	return *devices
+}
+
+func mapDevicesToInternalModel(container containers.Container) (result []model.Device) {
+	for _, device := range getDevicesExtension(container).Devices {
+		result = append(result, model.Device{
+			HostPath:      device.HostPath,
+			ContainerPath: device.ContainerPath,
+			Permissions:   device.Permissions,
+		})
+	}
+	return result
+}
+
+func processSchedule(container containers.Container) string {
+	schedule, err := extensions.GetScheduleExtension(container)
+	if err != nil {
+		log.Errorf("Failed to read Schedule extension from container [%s]: %s", container.ID, err)
+	}
+	if schedule == nil {
+		return ""
+	}
+	return schedule.Cron
+}
+
+func mapFilesToInternalModel(container containers.Container) (result []model.FileMount) {
+	files, err := extensions.GetFilesExtension(container)
+	if err != nil {
+		log.Errorf("Failed to read Files extension from container [%s]: %s", container.ID, err)
+	}
+	if files == nil {
+		return nil
+	}
+	for _, file := range files.Files {
+		result = append(result, model.FileMount{
+			Path:    file.Path,
+			Content: file.Content,
+			Secret:  file.Secret,
+			Mode:    file.Mode,
+		})
+	}
+	return result
+}
+
+func processEgressRateLimit(container containers.Container) int64 {
+	bandwidth, err := extensions.GetBandwidthExtension(container)
+	if err != nil {
+		log.Errorf("Failed to read Bandwidth extension from container [%s]: %s", container.ID, err)
+	}
+	if bandwidth == nil {
+		return 0
+	}
+	return bandwidth.EgressRate
+}
+
+func processRestartOnNetworkRecovery(container containers.Container) bool {
+	recovery, err := extensions.GetNetworkRecoveryExtension(container)
+	if err != nil {
+		log.Errorf("Failed to read NetworkRecovery extension from container [%s]: %s", container.ID, err)
+	}
+	return recovery != nil && recovery.Restart
+}
+
+func processLogDriver(container containers.Container) string {
+	logging, err := extensions.GetLoggingExtension(container)
+	if err != nil {
+		log.Errorf("Failed to read Logging extension from container [%s]: %s", container.ID, err)
+	}
+	if logging == nil {
+		return ""
+	}
+	return logging.Driver
+}
+
+func mapProbeToInternalModel(probe *extensions.Probe) *model.Probe {
+	if probe == nil {
+		return nil
+	}
+	return &model.Probe{
+		Exec:             probe.Exec,
+		InitialDelay:     probe.InitialDelay,
+		Period:           probe.Period,
+		Timeout:          probe.Timeout,
+		FailureThreshold: probe.FailureThreshold,
+	}
+}
+
 func processArgs(container containers.Container) []string {
 	spec, err := getSpec(container)
 	if err != nil {
@@ -107,9 +302,48 @@ func processArgs(container containers.Container) []string {
 		return nil
 	}
 
+	if hasInit(spec) {
+		return spec.Process.Args[2:]
+	}
 	return spec.Process.Args
 }
 
+func processInit(container containers.Container) bool {
+	spec, err := getSpec(container)
+	if err != nil {
+		log.Fatalf("Cannot read container spec to resolve init: %s", err)
+		return false
+	}
+
+	return hasInit(spec)
+}
+
+func processAppArmorProfile(container containers.Container) string {
+	spec, err := getSpec(container)
+	if err != nil {
+		log.Fatalf("Cannot read container spec to resolve AppArmor profile: %s", err)
+		return ""
+	}
+	if spec.Process == nil {
+		return ""
+	}
+
+	return spec.Process.ApparmorProfile
+}
+
+// hasInit returns true if the init binary is mounted and runs the container process
+func hasInit(spec *specs.Spec) bool {
+	if spec.Process == nil || len(spec.Process.Args) < 2 || spec.Process.Args[0] != InitDestination || spec.Process.Args[1] != "--" {
+		return false
+	}
+	for _, mount := range spec.Mounts {
+		if mount.Destination == InitDestination {
+			return true
+		}
+	}
+	return false
+}
+
 func processEnv(container containers.Container) []string {
 	spec, err := getSpec(container)
 	if err != nil {
@@ -130,6 +364,87 @@ func processWorkingDir(container containers.Container) string {
 	return spec.Process.Cwd
 }
 
+func processHostname(container containers.Container) string {
+	spec, err := getSpec(container)
+	if err != nil {
+		log.Fatalf("Cannot read container spec to resolve hostname: %s", err)
+		return ""
+	}
+
+	return spec.Hostname
+}
+
+func processCgroupParent(container containers.Container) string {
+	spec, err := getSpec(container)
+	if err != nil {
+		log.Fatalf("Cannot read container spec to resolve cgroup parent: %s", err)
+		return ""
+	}
+	if spec.Linux == nil || spec.Linux.CgroupsPath == "" {
+		return ""
+	}
+
+	return path.Dir(spec.Linux.CgroupsPath)
+}
+
+func processAnnotations(container containers.Container) map[string]string {
+	spec, err := getSpec(container)
+	if err != nil {
+		log.Fatalf("Cannot read container spec to resolve annotations: %s", err)
+		return nil
+	}
+
+	return spec.Annotations
+}
+
+func processSysctls(container containers.Container) map[string]string {
+	spec, err := getSpec(container)
+	if err != nil {
+		log.Fatalf("Cannot read container spec to resolve sysctls: %s", err)
+		return nil
+	}
+	if spec.Linux == nil {
+		return nil
+	}
+
+	return spec.Linux.Sysctl
+}
+
+// processCapabilities returns the capability sets only if the user gave them, every spec has the default sets
+func processCapabilities(container containers.Container, labels ContainerLabels) *model.Capabilities {
+	if !labels.hasCapabilities() {
+		return nil
+	}
+	spec, err := getSpec(container)
+	if err != nil {
+		log.Fatalf("Cannot read container spec to resolve capabilities: %s", err)
+		return nil
+	}
+	if spec.Process == nil || spec.Process.Capabilities == nil {
+		return nil
+	}
+
+	return &model.Capabilities{
+		Effective:   spec.Process.Capabilities.Effective,
+		Permitted:   spec.Process.Capabilities.Permitted,
+		Bounding:    spec.Process.Capabilities.Bounding,
+		Inheritable: spec.Process.Capabilities.Inheritable,
+	}
+}
+
+func processOOMScoreAdj(container containers.Container) int {
+	spec, err := getSpec(container)
+	if err != nil {
+		log.Fatalf("Cannot read container spec to resolve OOM score adjustment: %s", err)
+		return 0
+	}
+	if spec.Process == nil || spec.Process.OOMScoreAdj == nil {
+		return 0
+	}
+
+	return *spec.Process.OOMScoreAdj
+}
+
 func mapMountsToInternalModel(container containers.Container) (result []model.Mount) {
 	spec, err := getSpec(container)
 	if err != nil {
@@ -137,35 +452,108 @@ func mapMountsToInternalModel(container containers.Container) (result []model.Mo
 		return result
 	}
 
+	init := hasInit(spec)
+	// The accelerator driver libraries get mounted again from the accelerators
+	libraries := getDevicesExtension(container).Libraries
 	for _, mount := range spec.Mounts {
+		if init && mount.Destination == InitDestination || containsString(libraries, mount.Destination) {
+			continue
+		}
+		propagation, options := splitPropagation(mount.Options)
 		result = append(result, model.Mount{
 			Type:        mount.Type,
 			Source:      mount.Source,
 			Destination: mount.Destination,
-			Options:     mount.Options,
+			Options:     options,
+			Propagation: propagation,
 		})
 	}
 	return result
 }
 
+func mapUlimitsToInternalModel(container containers.Container) (result []model.Ulimit) {
+	spec, err := getSpec(container)
+	if err != nil {
+		log.Fatalf("Cannot read container spec to resolve container ulimits: %s", err)
+		return result
+	}
+
+	for _, rlimit := range spec.Process.Rlimits {
+		result = append(result, model.Ulimit{
+			Name: strings.ToLower(strings.TrimPrefix(rlimit.Type, "RLIMIT_")),
+			Soft: rlimit.Soft,
+			Hard: rlimit.Hard,
+		})
+	}
+	return result
+}
+
+func mapResourcesToInternalModel(container containers.Container) *model.Resources {
+	spec, err := getSpec(container)
+	if err != nil {
+		log.Fatalf("Cannot read container spec to resolve container resources: %s", err)
+		return nil
+	}
+	if spec.Linux == nil || spec.Linux.Resources == nil {
+		return nil
+	}
+
+	var (
+		resources = spec.Linux.Resources
+		result    = model.Resources{}
+	)
+	if resources.Memory != nil && resources.Memory.Limit != nil {
+		result.MemoryLimit = *resources.Memory.Limit
+		// Swap equal to the memory limit is the default what disables the swap
+		if resources.Memory.Swap != nil && *resources.Memory.Swap != result.MemoryLimit {
+			result.MemorySwapLimit = *resources.Memory.Swap
+		}
+	}
+	if resources.CPU != nil && resources.CPU.Quota != nil && resources.CPU.Period != nil && *resources.CPU.Period > 0 {
+		result.CPULimit = *resources.CPU.Quota * 1000 / int64(*resources.CPU.Period)
+	}
+
+	if result.MemoryLimit == 0 && result.CPULimit == 0 {
+		return nil
+	}
+	return &result
+}
+
 // MapContainerStatusToInternalModel maps containerd model to internal container status model
 func MapContainerStatusToInternalModel(container containers.Container, status containerd.Status) model.ContainerStatus {
 	labels := ContainerLabels(container.Labels)
+	lifecycle := getLifecycle(container)
+	state := mapContainerStatus(status)
 	return model.ContainerStatus{
-		ContainerID:  container.ID,
-		Name:         labels.getContainerName(),
-		Image:        container.Image,
-		State:        mapContainerStatus(status),
-		RestartCount: getRestartCount(container),
+		ContainerID:    container.ID,
+		Name:           labels.getContainerName(),
+		Image:          container.Image,
+		State:          state,
+		RestartCount:   getRestartCount(lifecycle),
+		LastExitCode:   int(lifecycle.LastExitCode),
+		LastExitReason: lifecycle.LastExitReason,
+		Ready:          isReady(state, getProbes(container).Readiness != nil, lifecycle),
+		LastStartedAt:  lifecycle.LastStartedAt,
+	}
+}
+
+// isReady resolves the container readiness, without readiness probe the container is ready when it's running
+func isReady(state string, hasReadinessProbe bool, lifecycle extensions.ContainerLifecycle) bool {
+	if state != string(containerd.Running) {
+		return false
 	}
+	return !hasReadinessProbe || lifecycle.Ready
 }
 
-func getRestartCount(container containers.Container) int {
+func getLifecycle(container containers.Container) extensions.ContainerLifecycle {
 	lifecycle, err := extensions.GetLifecycleExtension(container)
 	if err != nil && !extensions.IsNotFound(err) {
-		log.Warnf("Error while resolving container restart count, fallback to zero: %s", err)
+		log.Warnf("Error while resolving container lifecycle, fallback to zero restart count: %s", err)
 	}
+	return lifecycle
+}
 
+func getRestartCount(lifecycle extensions.ContainerLifecycle) int {
 	if lifecycle.StartCount <= 1 {
 		return 0
 	}
@@ -202,3 +590,12 @@ func mapContainerStatus(status containerd.Status) string {
 	}
 	return string(status.Status)
 }
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
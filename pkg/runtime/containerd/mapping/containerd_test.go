@@ -0,0 +1,66 @@
+package mapping
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/extensions"
+	"github.com/gogo/protobuf/types"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsReady(t *testing.T) {
+	assert.True(t, isReady("running", false, extensions.ContainerLifecycle{}), "running container without readiness probe should be ready")
+	assert.False(t, isReady("stopped", false, extensions.ContainerLifecycle{}), "stopped container should not be ready")
+	assert.False(t, isReady("running", true, extensions.ContainerLifecycle{}), "should not be ready before readiness probe succeeds")
+	assert.True(t, isReady("running", true, extensions.ContainerLifecycle{Ready: true}), "should be ready after readiness probe succeeds")
+	assert.False(t, isReady("stopped", true, extensions.ContainerLifecycle{Ready: true}), "stopped container should not be ready")
+}
+
+func TestHasInit(t *testing.T) {
+	spec := &specs.Spec{
+		Process: &specs.Process{Args: []string{InitDestination, "--", "/bin/app"}},
+		Mounts:  []specs.Mount{{Type: "bind", Source: "/usr/bin/tini", Destination: InitDestination}},
+	}
+	assert.True(t, hasInit(spec))
+
+	spec.Mounts = nil
+	assert.False(t, hasInit(spec), "should require the init binary mount")
+
+	assert.False(t, hasInit(&specs.Spec{Process: &specs.Process{Args: []string{"/bin/app"}}}))
+}
+
+func TestProcessCapabilitiesOnlyIfGiven(t *testing.T) {
+	spec, err := json.Marshal(&specs.Spec{Process: &specs.Process{Capabilities: &specs.LinuxCapabilities{
+		Effective: []string{"CAP_CHOWN"},
+		Permitted: []string{"CAP_CHOWN"},
+	}}})
+	assert.NoError(t, err)
+	container := containers.Container{Spec: &types.Any{Value: spec}}
+
+	assert.Nil(t, processCapabilities(container, NewLabels(model.Pod{}, model.Container{})), "should not map the default capabilities back")
+
+	labels := NewLabels(model.Pod{}, model.Container{Capabilities: &model.Capabilities{Effective: []string{"CAP_CHOWN"}}})
+	assert.Equal(t, &model.Capabilities{Effective: []string{"CAP_CHOWN"}, Permitted: []string{"CAP_CHOWN"}}, processCapabilities(container, labels))
+}
+
+func TestMapResourcesDefaultSwapLimit(t *testing.T) {
+	limit, swap := int64(1024), int64(1024)
+	spec, err := json.Marshal(&specs.Spec{Linux: &specs.Linux{Resources: &specs.LinuxResources{
+		Memory: &specs.LinuxMemory{Limit: &limit, Swap: &swap},
+	}}})
+	assert.NoError(t, err)
+	container := containers.Container{Spec: &types.Any{Value: spec}}
+	assert.Equal(t, &model.Resources{MemoryLimit: 1024}, mapResourcesToInternalModel(container), "should map the default swap limit back as default")
+
+	swap = 4096
+	spec, err = json.Marshal(&specs.Spec{Linux: &specs.Linux{Resources: &specs.LinuxResources{
+		Memory: &specs.LinuxMemory{Limit: &limit, Swap: &swap},
+	}}})
+	assert.NoError(t, err)
+	container = containers.Container{Spec: &types.Any{Value: spec}}
+	assert.Equal(t, &model.Resources{MemoryLimit: 1024, MemorySwapLimit: 4096}, mapResourcesToInternalModel(container))
+}
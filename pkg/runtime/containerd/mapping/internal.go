@@ -7,13 +7,31 @@ import (
 )
 
 // MapMountToContainerdModel maps model.Mount to containerd spec struct
+// Propagation mode is written to the mount options, replacing any propagation option there
 func MapMountToContainerdModel(mount model.Mount) specs.Mount {
+	options := mount.Options
+	if mount.Propagation != "" {
+		_, options = splitPropagation(mount.Options)
+		options = append(options, mount.Propagation)
+	}
 	return specs.Mount{
 		Type:        mount.Type,
 		Source:      mount.Source,
 		Destination: mount.Destination,
-		Options:     mount.Options,
+		Options:     options,
+	}
+}
+
+// splitPropagation separates the propagation mode from the mount options, the last mode wins
+func splitPropagation(options []string) (propagation string, rest []string) {
+	for _, option := range options {
+		if model.IsValidMountPropagation(option) {
+			propagation = option
+			continue
+		}
+		rest = append(rest, option)
 	}
+	return propagation, rest
 }
 
 // MapPipeToContainerdModel maps model.PipeSet to containerd extension PipeSet
@@ -26,3 +44,77 @@ func MapPipeToContainerdModel(pipe model.PipeSet) extensions.PipeSet {
 		},
 	}
 }
+
+// MapProbesToContainerdModel maps container liveness and readiness probes to containerd extension Probes
+func MapProbesToContainerdModel(liveness, readiness *model.Probe) extensions.Probes {
+	return extensions.Probes{
+		Liveness:  mapProbeToContainerdModel(liveness),
+		Readiness: mapProbeToContainerdModel(readiness),
+	}
+}
+
+// MapFileWatchToContainerdModel maps internal file watch model to containerd extension model
+func MapFileWatchToContainerdModel(watch model.FileWatch) extensions.FileWatch {
+	return extensions.FileWatch{
+		Paths:    watch.Paths,
+		Debounce: watch.Debounce,
+	}
+}
+
+// MapIdleStopToContainerdModel maps internal idle stop model to containerd extension model
+func MapIdleStopToContainerdModel(idleStop model.IdleStop) extensions.IdleStop {
+	return extensions.IdleStop{
+		Timeout: idleStop.Timeout,
+		Port:    idleStop.Port,
+		Signal:  idleStop.Signal,
+	}
+}
+
+// MapDevicesToContainerdModel maps the container devices, accelerators and the accelerator driver
+// library paths to containerd extension model
+func MapDevicesToContainerdModel(devices []model.Device, accelerators, libraries []string) extensions.Devices {
+	result := extensions.Devices{
+		Accelerators: accelerators,
+		Libraries:    libraries,
+	}
+	for _, device := range devices {
+		result.Devices = append(result.Devices, extensions.Device{
+			HostPath:      device.HostPath,
+			ContainerPath: device.ContainerPath,
+			Permissions:   device.Permissions,
+		})
+	}
+	return result
+}
+
+// MapFilesToContainerdModel maps container files to containerd extension model,
+// the content of the secret files is left out so that the secrets don't get stored in containerd
+func MapFilesToContainerdModel(files []model.FileMount) extensions.Files {
+	result := extensions.Files{}
+	for _, file := range files {
+		content := file.Content
+		if file.Secret != "" {
+			content = ""
+		}
+		result.Files = append(result.Files, extensions.File{
+			Path:    file.Path,
+			Content: content,
+			Secret:  file.Secret,
+			Mode:    file.Mode,
+		})
+	}
+	return result
+}
+
+func mapProbeToContainerdModel(probe *model.Probe) *extensions.Probe {
+	if probe == nil {
+		return nil
+	}
+	return &extensions.Probe{
+		Exec:             probe.Exec,
+		InitialDelay:     probe.InitialDelay,
+		Period:           probe.Period,
+		Timeout:          probe.Timeout,
+		FailureThreshold: probe.FailureThreshold,
+	}
+}
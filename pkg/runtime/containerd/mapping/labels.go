@@ -2,16 +2,63 @@ package mapping
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ernoaapa/eliot/pkg/model"
+	log "github.com/sirupsen/logrus"
 )
 
+// DefaultLabelPrefix is the default prefix for the container label keys
+const DefaultLabelPrefix = "io.eliot"
+
 var (
-	labelPrefix        = "io.eliot"
-	podNameLabel       = "pod.name"
-	containerNameLabel = "container.name"
+	labelPrefix             = DefaultLabelPrefix
+	podNameLabel            = "pod.name"
+	podStopGracePeriodLabel = "pod.stopGracePeriod"
+	podMemoryLimitLabel     = "pod.memoryLimit"
+	podMemorySwapLimitLabel = "pod.memorySwapLimit"
+	podCPULimitLabel        = "pod.cpuLimit"
+	podPullSecretsLabel     = "pod.imagePullSecrets"
+	podSpecHashLabel        = "pod.specHash"
+	containerNameLabel      = "container.name"
+	startPriorityLabel      = "container.startPriority"
+	storageQuotaLabel       = "container.storageQuota"
+	hostEnvLabel            = "container.hostEnv"
+	capabilitiesLabel       = "container.capabilities"
+
+	labelPrefixPattern = regexp.MustCompile("^[a-z0-9]([a-z0-9.-]*[a-z0-9])?$")
 )
 
+// SetLabelPrefix overrides the container label key prefix,
+// e.g. to avoid label collisions when sharing containerd with other tools
+func SetLabelPrefix(prefix string) error {
+	if !labelPrefixPattern.MatchString(prefix) {
+		return fmt.Errorf("Invalid label prefix [%s], must be lowercase alphanumeric separated with dots or dashes", prefix)
+	}
+	labelPrefix = prefix
+	return nil
+}
+
+// IsReservedLabel returns true if the label key is in the namespace what eliot uses for its own labels,
+// the users must not set these because eliot trusts them, e.g. the image signature verification label
+func IsReservedLabel(key string) bool {
+	return strings.HasPrefix(key, DefaultLabelPrefix+".") || strings.HasPrefix(key, labelPrefix+".")
+}
+
+// LabelKey returns the label key for the name with the configured prefix, for the labels what eliot sets
+// on other containerd objects than containers, e.g. leases
+func LabelKey(name string) string {
+	return buildLabelKeyFor(name)
+}
+
+// ContainerFilter returns containerd filter what matches only the containers managed by eliot
+func ContainerFilter() string {
+	return fmt.Sprintf("labels.%q", buildLabelKeyFor(podNameLabel))
+}
+
 // ContainerLabels is helper type for managing container labels
 type ContainerLabels map[string]string
 
@@ -19,10 +66,82 @@ func (l ContainerLabels) getPodName() string {
 	return l.getValue(podNameLabel)
 }
 
+func (l ContainerLabels) getStopGracePeriod() time.Duration {
+	value := l.getValue(podStopGracePeriodLabel)
+	if value == "" {
+		return 0
+	}
+	period, err := time.ParseDuration(value)
+	if err != nil {
+		log.Warnf("Invalid pod stop grace period label value [%s], fallback to default: %s", value, err)
+		return 0
+	}
+	return period
+}
+
+func (l ContainerLabels) getPodResources() *model.Resources {
+	memoryLimit := l.getInt64(podMemoryLimitLabel)
+	cpuLimit := l.getInt64(podCPULimitLabel)
+	if memoryLimit == 0 && cpuLimit == 0 {
+		return nil
+	}
+	return &model.Resources{
+		MemoryLimit:     memoryLimit,
+		CPULimit:        cpuLimit,
+		MemorySwapLimit: l.getInt64(podMemorySwapLimitLabel),
+	}
+}
+
+func (l ContainerLabels) getPullSecrets() []string {
+	value := l.getValue(podPullSecretsLabel)
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func (l ContainerLabels) getSpecHash() string {
+	return l.getValue(podSpecHashLabel)
+}
+
+func (l ContainerLabels) getInt64(key string) int64 {
+	value := l.getValue(key)
+	if value == "" {
+		return 0
+	}
+	result, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid label [%s] value [%s], ignoring it: %s", key, value, err)
+		return 0
+	}
+	return result
+}
+
 func (l ContainerLabels) getContainerName() string {
 	return l.getValue(containerNameLabel)
 }
 
+func (l ContainerLabels) getStartPriority() int {
+	return int(l.getInt64(startPriorityLabel))
+}
+
+func (l ContainerLabels) getStorageQuota() int64 {
+	return l.getInt64(storageQuotaLabel)
+}
+
+func (l ContainerLabels) getHostEnv() []string {
+	value := l.getValue(hostEnvLabel)
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// hasCapabilities returns true if the capability sets were given explicitly, otherwise the spec has the defaults
+func (l ContainerLabels) hasCapabilities() bool {
+	return l.getValue(capabilitiesLabel) == "true"
+}
+
 func (l ContainerLabels) getValue(key string) string {
 	return l[buildLabelKeyFor(key)]
 }
@@ -36,5 +155,35 @@ func NewLabels(pod model.Pod, container model.Container) ContainerLabels {
 	labels := make(map[string]string)
 	labels[buildLabelKeyFor(podNameLabel)] = pod.Metadata.Name
 	labels[buildLabelKeyFor(containerNameLabel)] = container.Name
+	labels[buildLabelKeyFor(podSpecHashLabel)] = pod.GetSpecHash()
+	if container.StartPriority != 0 {
+		labels[buildLabelKeyFor(startPriorityLabel)] = strconv.Itoa(container.StartPriority)
+	}
+	if container.StorageQuota > 0 {
+		labels[buildLabelKeyFor(storageQuotaLabel)] = strconv.FormatInt(container.StorageQuota, 10)
+	}
+	if len(container.HostEnv) > 0 {
+		labels[buildLabelKeyFor(hostEnvLabel)] = strings.Join(container.HostEnv, ",")
+	}
+	if container.Capabilities != nil {
+		labels[buildLabelKeyFor(capabilitiesLabel)] = "true"
+	}
+	if pod.Spec.StopGracePeriod > 0 {
+		labels[buildLabelKeyFor(podStopGracePeriodLabel)] = pod.Spec.StopGracePeriod.String()
+	}
+	if len(pod.Spec.ImagePullSecrets) > 0 {
+		labels[buildLabelKeyFor(podPullSecretsLabel)] = strings.Join(pod.Spec.ImagePullSecrets, ",")
+	}
+	if resources := pod.Spec.Resources; resources != nil {
+		if resources.MemoryLimit > 0 {
+			labels[buildLabelKeyFor(podMemoryLimitLabel)] = strconv.FormatInt(resources.MemoryLimit, 10)
+		}
+		if resources.MemorySwapLimit != 0 {
+			labels[buildLabelKeyFor(podMemorySwapLimitLabel)] = strconv.FormatInt(resources.MemorySwapLimit, 10)
+		}
+		if resources.CPULimit > 0 {
+			labels[buildLabelKeyFor(podCPULimitLabel)] = strconv.FormatInt(resources.CPULimit, 10)
+		}
+	}
 	return labels
 }
@@ -2,7 +2,9 @@ package mapping
 
 import (
 	"testing"
+	"time"
 
+	"github.com/containerd/containerd/filters"
 	"github.com/ernoaapa/eliot/pkg/model"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,3 +23,96 @@ func TestNewContainerLabels(t *testing.T) {
 
 	assert.Equal(t, "my-pod", result["io.eliot.pod.name"])
 }
+
+func TestStartPriorityLabel(t *testing.T) {
+	labels := NewLabels(model.Pod{}, model.Container{Name: "my-container", StartPriority: 10})
+	assert.Equal(t, "10", labels["io.eliot.container.startPriority"])
+	assert.Equal(t, 10, labels.getStartPriority())
+
+	assert.NotContains(t, NewLabels(model.Pod{}, model.Container{}), "io.eliot.container.startPriority", "should not add default priority")
+}
+
+func TestStorageQuotaLabel(t *testing.T) {
+	labels := NewLabels(model.Pod{}, model.Container{Name: "my-container", StorageQuota: 512 * 1024 * 1024})
+	assert.Equal(t, int64(512*1024*1024), labels.getStorageQuota())
+
+	assert.NotContains(t, NewLabels(model.Pod{}, model.Container{}), "io.eliot.container.storageQuota", "should not add label without quota")
+}
+
+func TestSetLabelPrefix(t *testing.T) {
+	defer SetLabelPrefix(DefaultLabelPrefix)
+
+	assert.NoError(t, SetLabelPrefix("com.example.eliot"))
+	assert.Equal(t, "my-pod", NewLabels(model.Pod{Metadata: model.Metadata{Name: "my-pod"}}, model.Container{})["com.example.eliot.pod.name"])
+	assert.Equal(t, `labels."com.example.eliot.pod.name"`, ContainerFilter())
+
+	assert.Error(t, SetLabelPrefix(""), "should reject empty prefix")
+	assert.Error(t, SetLabelPrefix("io.eliot."), "should reject trailing separator")
+	assert.Equal(t, "com.example.eliot", labelPrefix, "should not change on invalid prefix")
+}
+
+func TestContainerFilter(t *testing.T) {
+	filter, err := filters.Parse(ContainerFilter())
+	assert.NoError(t, err)
+
+	assert.True(t, filter.Match(labelsAdaptor(NewLabels(model.Pod{Metadata: model.Metadata{Name: "my-pod"}}, model.Container{}))))
+	assert.False(t, filter.Match(labelsAdaptor(map[string]string{"other.tool": "foo"})))
+}
+
+func labelsAdaptor(labels map[string]string) filters.Adaptor {
+	return filters.AdapterFunc(func(fieldpath []string) (string, bool) {
+		if len(fieldpath) != 2 || fieldpath[0] != "labels" {
+			return "", false
+		}
+		value, ok := labels[fieldpath[1]]
+		return value, ok
+	})
+}
+
+func TestStopGracePeriodLabel(t *testing.T) {
+	pod := model.Pod{
+		Metadata: model.Metadata{Name: "my-pod"},
+		Spec:     model.PodSpec{StopGracePeriod: 30 * time.Second},
+	}
+	labels := NewLabels(pod, model.Container{Name: "my-container"})
+
+	assert.Equal(t, 30*time.Second, labels.getStopGracePeriod())
+	assert.Equal(t, time.Duration(0), NewLabels(model.Pod{}, model.Container{}).getStopGracePeriod(), "should default to zero when not set")
+}
+
+func TestPodResourcesLabels(t *testing.T) {
+	pod := model.Pod{
+		Metadata: model.Metadata{Name: "my-pod"},
+		Spec:     model.PodSpec{Resources: &model.Resources{MemoryLimit: 256 * 1024 * 1024, CPULimit: 500}},
+	}
+	labels := NewLabels(pod, model.Container{Name: "my-container"})
+
+	assert.Equal(t, pod.Spec.Resources, labels.getPodResources())
+	assert.Nil(t, NewLabels(model.Pod{}, model.Container{}).getPodResources(), "should be nil when not set")
+}
+
+func TestPullSecretsLabel(t *testing.T) {
+	pod := model.Pod{
+		Metadata: model.Metadata{Name: "my-pod"},
+		Spec:     model.PodSpec{ImagePullSecrets: []string{"registry-a", "registry-b"}},
+	}
+	labels := NewLabels(pod, model.Container{Name: "my-container"})
+
+	assert.Equal(t, []string{"registry-a", "registry-b"}, labels.getPullSecrets())
+	assert.Nil(t, NewLabels(model.Pod{}, model.Container{}).getPullSecrets(), "should be nil when not set")
+}
+
+func TestHostEnvLabel(t *testing.T) {
+	labels := NewLabels(model.Pod{}, model.Container{Name: "my-container", HostEnv: []string{"SITE_ID", "REGION"}})
+	assert.Equal(t, []string{"SITE_ID", "REGION"}, labels.getHostEnv())
+	assert.Nil(t, NewLabels(model.Pod{}, model.Container{}).getHostEnv(), "should be nil when not set")
+}
+
+func TestSpecHashLabel(t *testing.T) {
+	pod := model.Pod{Spec: model.PodSpec{Containers: []model.Container{{Name: "my-container", Image: "nginx"}}}}
+	labels := NewLabels(pod, model.Container{Name: "my-container"})
+	assert.Equal(t, pod.GetSpecHash(), labels.getSpecHash())
+
+	pod.Spec.Containers[0].Image = "nginx:1.15"
+	assert.NotEqual(t, pod.GetSpecHash(), labels.getSpecHash(), "should change when the spec changes")
+}
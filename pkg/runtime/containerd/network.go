@@ -0,0 +1,146 @@
+package containerd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/oci"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+// WithResolvConf renders resolv.conf with given nameservers into the dir
+// and bind mounts it to the container /etc/resolv.conf
+func WithResolvConf(dir string, nameservers []string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		path := filepath.Join(dir, "resolv.conf")
+		if err := writeNetworkFile(path, RenderResolvConf(nameservers)); err != nil {
+			return err
+		}
+		s.Mounts = append(s.Mounts, readonlyBindMount(path, "/etc/resolv.conf"))
+		return nil
+	}
+}
+
+// WithHostname sets the container hostname, renders it into the dir
+// and bind mounts it to the container /etc/hostname
+func WithHostname(dir, hostname string) oci.SpecOpts {
+	return func(ctx context.Context, client oci.Client, c *containers.Container, s *specs.Spec) error {
+		if err := oci.WithHostname(hostname)(ctx, client, c, s); err != nil {
+			return err
+		}
+		path := filepath.Join(dir, "hostname")
+		if err := writeNetworkFile(path, RenderHostname(hostname)); err != nil {
+			return err
+		}
+		s.Mounts = append(s.Mounts, readonlyBindMount(path, "/etc/hostname"))
+		return nil
+	}
+}
+
+// WithDomainname sets the container domainname to the spec, the OCI runtime sets it to the container UTS namespace
+// The vendored runtime-spec doesn't have the domainname field, so it gets set to the encoded spec after WithNewSpec
+func WithDomainname(domainname string) containerd.NewContainerOpts {
+	return func(_ context.Context, _ *containerd.Client, c *containers.Container) error {
+		if c.Spec == nil {
+			return errors.New("Container spec must be created before setting the domainname")
+		}
+		// Decode the numbers as is so that e.g. the resource limits don't lose precision
+		decoder := json.NewDecoder(bytes.NewReader(c.Spec.Value))
+		decoder.UseNumber()
+		spec := map[string]interface{}{}
+		if err := decoder.Decode(&spec); err != nil {
+			return errors.Wrap(err, "Failed to decode container spec")
+		}
+		spec["domainname"] = domainname
+
+		value, err := json.Marshal(spec)
+		if err != nil {
+			return errors.Wrap(err, "Failed to encode container spec")
+		}
+		c.Spec.Value = value
+		return nil
+	}
+}
+
+// WithHostsFile renders hosts file with localhost, the container own hostname and
+// given extra hosts into the dir and bind mounts it to the container /etc/hosts
+// extraHosts should be list of strings in format 'hostname:ip'
+func WithHostsFile(dir, hostname, domainname string, extraHosts []string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		path := filepath.Join(dir, "hosts")
+		if err := writeNetworkFile(path, RenderHosts(hostname, domainname, extraHosts)); err != nil {
+			return err
+		}
+		s.Mounts = append(s.Mounts, readonlyBindMount(path, "/etc/hosts"))
+		return nil
+	}
+}
+
+// RenderResolvConf returns the resolv.conf content what WithResolvConf writes
+func RenderResolvConf(nameservers []string) []byte {
+	var buf bytes.Buffer
+	for _, nameserver := range nameservers {
+		fmt.Fprintf(&buf, "nameserver %s\n", nameserver)
+	}
+	return buf.Bytes()
+}
+
+// RenderHostname returns the hostname file content what WithHostname writes
+func RenderHostname(hostname string) []byte {
+	return []byte(hostname + "\n")
+}
+
+// RenderHosts returns the hosts file content what WithHostsFile writes
+func RenderHosts(hostname, domainname string, extraHosts []string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("127.0.0.1\tlocalhost\n")
+	buf.WriteString("::1\tlocalhost ip6-localhost ip6-loopback\n")
+	if hostname != "" {
+		if domainname != "" {
+			fmt.Fprintf(&buf, "127.0.1.1\t%s.%s %s\n", hostname, domainname, hostname)
+		} else {
+			fmt.Fprintf(&buf, "127.0.1.1\t%s\n", hostname)
+		}
+	}
+	for _, entry := range extraHosts {
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		fmt.Fprintf(&buf, "%s\t%s\n", parts[1], parts[0])
+	}
+	return buf.Bytes()
+}
+
+// RestoreNetworkFile writes the generated network file content again to the path, e.g. after reboot cleared it
+func RestoreNetworkFile(path string, content []byte) error {
+	return writeNetworkFile(path, content)
+}
+
+func writeNetworkFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrapf(err, "Error while creating directory for [%s]", path)
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrapf(err, "Error while writing [%s]", path)
+	}
+	return nil
+}
+
+func readonlyBindMount(source, destination string) specs.Mount {
+	return specs.Mount{
+		Type:        "bind",
+		Source:      source,
+		Destination: destination,
+		Options:     []string{"rbind", "ro"},
+	}
+}
@@ -0,0 +1,55 @@
+package containerd
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/gogo/protobuf/types"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderResolvConf(t *testing.T) {
+	assert.Equal(t, "nameserver 8.8.8.8\nnameserver 1.1.1.1\n", string(RenderResolvConf([]string{"8.8.8.8", "1.1.1.1"})))
+}
+
+func TestRenderHosts(t *testing.T) {
+	result := string(RenderHosts("", "", []string{"foo.local:192.168.1.10", "bar:fe80::1"}))
+
+	assert.Contains(t, result, "127.0.0.1\tlocalhost\n")
+	assert.Contains(t, result, "192.168.1.10\tfoo.local\n")
+	assert.Contains(t, result, "fe80::1\tbar\n")
+}
+
+func TestRenderHostsWithHostname(t *testing.T) {
+	assert.Contains(t, string(RenderHosts("sensor", "", nil)), "127.0.1.1\tsensor\n")
+	assert.Contains(t, string(RenderHosts("sensor", "example.com", nil)), "127.0.1.1\tsensor.example.com sensor\n")
+}
+
+func TestWithDomainname(t *testing.T) {
+	limit := int64(math.MaxInt64)
+	encoded, err := json.Marshal(specs.Spec{
+		Hostname: "sensor",
+		Linux:    &specs.Linux{Resources: &specs.LinuxResources{Memory: &specs.LinuxMemory{Limit: &limit}}},
+	})
+	assert.NoError(t, err)
+	container := &containers.Container{Spec: &types.Any{Value: encoded}}
+
+	assert.NoError(t, WithDomainname("example.com")(context.Background(), nil, container))
+
+	result := struct {
+		specs.Spec
+		Domainname string `json:"domainname"`
+	}{}
+	assert.NoError(t, json.Unmarshal(container.Spec.Value, &result))
+	assert.Equal(t, "example.com", result.Domainname)
+	assert.Equal(t, "sensor", result.Hostname)
+	assert.Equal(t, limit, *result.Linux.Resources.Memory.Limit, "should not lose the number precision")
+}
+
+func TestWithDomainnameWithoutSpec(t *testing.T) {
+	assert.Error(t, WithDomainname("example.com")(context.Background(), nil, &containers.Container{}))
+}
@@ -2,6 +2,8 @@ package containerd
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"strings"
 
 	"github.com/containerd/containerd/containers"
@@ -9,6 +11,7 @@ import (
 	"github.com/ernoaapa/eliot/pkg/model"
 	"github.com/ernoaapa/eliot/pkg/runtime/containerd/mapping"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
 )
 
 // WithCwd spets the container current working directory (cwd)
@@ -20,7 +23,9 @@ func WithCwd(cwd string) oci.SpecOpts {
 }
 
 // WithEnv you can add or override process environment variables
-// overrides should be list of strings in format 'KEY=value'
+// overrides should be list of strings in format 'KEY=value' or 'KEY' to unset the variable.
+// Must be applied after oci.WithImageConfig so the image environment is the base and
+// the overrides are applied on top of it in the given order, i.e. the last value wins
 func WithEnv(overrides []string) oci.SpecOpts {
 	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
 		if len(overrides) > 0 {
@@ -53,6 +58,7 @@ func replaceOrAppendEnvValues(defaults, overrides []string) []string {
 		if i, exists := cache[parts[0]]; exists {
 			defaults[i] = value
 		} else {
+			cache[parts[0]] = len(defaults)
 			defaults = append(defaults, value)
 		}
 	}
@@ -69,11 +75,221 @@ func replaceOrAppendEnvValues(defaults, overrides []string) []string {
 }
 
 // WithMounts you can add mount points to the container
+// Shared and slave mount propagation require the same propagation on the container rootfs,
+// otherwise the host sub-mounts don't propagate to the container
 func WithMounts(mounts []model.Mount) oci.SpecOpts {
 	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
 		for _, mount := range mounts {
 			s.Mounts = append(s.Mounts, mapping.MapMountToContainerdModel(mount))
+
+			switch mount.Propagation {
+			case "shared", "rshared":
+				ensureLinux(s).RootfsPropagation = "rshared"
+			case "slave", "rslave":
+				if ensureLinux(s).RootfsPropagation != "rshared" {
+					s.Linux.RootfsPropagation = "rslave"
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// WithTmpfs adds size limited tmpfs mounts to the container
+// Replaces the existing mount in the same destination, e.g. the default /dev/shm
+func WithTmpfs(mounts []model.TmpfsMount) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		for _, mount := range mounts {
+			options := append([]string{"nosuid", "nodev", "mode=1777"}, mount.Options...)
+			s.Mounts = replaceOrAppendMount(s.Mounts, specs.Mount{
+				Type:        "tmpfs",
+				Source:      "tmpfs",
+				Destination: mount.Destination,
+				Options:     append(options, fmt.Sprintf("size=%d", mount.SizeLimit)),
+			})
+		}
+		return nil
+	}
+}
+
+func replaceOrAppendMount(mounts []specs.Mount, mount specs.Mount) []specs.Mount {
+	for i, existing := range mounts {
+		if existing.Destination == mount.Destination {
+			mounts[i] = mount
+			return mounts
+		}
+	}
+	return append(mounts, mount)
+}
+
+func ensureLinux(s *specs.Spec) *specs.Linux {
+	if s.Linux == nil {
+		s.Linux = &specs.Linux{}
+	}
+	return s.Linux
+}
+
+// WithAnnotations adds the annotations to the OCI spec
+// Annotations are separate from the container labels and are read by the runtime, not by containerd
+func WithAnnotations(annotations map[string]string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		if s.Annotations == nil {
+			s.Annotations = make(map[string]string, len(annotations))
+		}
+		for key, value := range annotations {
+			s.Annotations[key] = value
 		}
 		return nil
 	}
 }
+
+// WithSysctls sets the kernel parameters in the container namespaces
+func WithSysctls(sysctls map[string]string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		linux := ensureLinux(s)
+		if linux.Sysctl == nil {
+			linux.Sysctl = make(map[string]string, len(sysctls))
+		}
+		for key, value := range sysctls {
+			linux.Sysctl[key] = value
+		}
+		return nil
+	}
+}
+
+// WithCapabilities replaces the process capability sets with the given sets
+// Fails if effective capability is missing from the permitted set, the process couldn't start with it
+func WithCapabilities(capabilities model.Capabilities) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		permitted := map[string]bool{}
+		for _, capability := range capabilities.Permitted {
+			permitted[capability] = true
+		}
+		for _, capability := range capabilities.Effective {
+			if !permitted[capability] {
+				return fmt.Errorf("Effective capability [%s] is not in the permitted capabilities", capability)
+			}
+		}
+
+		if s.Process == nil {
+			s.Process = &specs.Process{}
+		}
+		s.Process.Capabilities = &specs.LinuxCapabilities{
+			Effective:   nonNil(capabilities.Effective),
+			Permitted:   nonNil(capabilities.Permitted),
+			Bounding:    nonNil(capabilities.Bounding),
+			Inheritable: nonNil(capabilities.Inheritable),
+			// Ambient capabilities survive exec as non-root, explicit set doesn't have them
+			Ambient: []string{},
+		}
+		return nil
+	}
+}
+
+// WithOOMScoreAdj sets the OOM killer score adjustment of the container process
+func WithOOMScoreAdj(score int) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		if s.Process == nil {
+			s.Process = &specs.Process{}
+		}
+		s.Process.OOMScoreAdj = &score
+		return nil
+	}
+}
+
+// WithAppArmorProfile confines the container process with the AppArmor profile
+func WithAppArmorProfile(profile string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		if s.Process == nil {
+			s.Process = &specs.Process{}
+		}
+		s.Process.ApparmorProfile = profile
+		return nil
+	}
+}
+
+// WithInit mounts the init binary to the container and runs the container process under it
+// The init must accept the command after "--" separator, e.g. tini or catatonit
+// Must be applied after the process args are set
+func WithInit(initPath string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		if s.Process == nil || len(s.Process.Args) == 0 {
+			return fmt.Errorf("Container doesn't have command to run under init")
+		}
+		s.Mounts = append(s.Mounts, specs.Mount{
+			Type:        "bind",
+			Source:      initPath,
+			Destination: mapping.InitDestination,
+			Options:     []string{"rbind", "ro"},
+		})
+		s.Process.Args = append([]string{mapping.InitDestination, "--"}, s.Process.Args...)
+		return nil
+	}
+}
+
+// WithDevices creates the host device nodes to the container and allows them in the devices cgroup
+// Fails if the host path is not a character or block device
+func WithDevices(devices []model.Device) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		linux := ensureLinux(s)
+		if linux.Resources == nil {
+			linux.Resources = &specs.LinuxResources{}
+		}
+		for _, device := range devices {
+			var stat unix.Stat_t
+			if err := unix.Stat(device.HostPath, &stat); err != nil {
+				return fmt.Errorf("Failed to read device [%s]: %s", device.HostPath, err)
+			}
+
+			var deviceType string
+			switch uint32(stat.Mode) & unix.S_IFMT {
+			case unix.S_IFCHR:
+				deviceType = "c"
+			case unix.S_IFBLK:
+				deviceType = "b"
+			default:
+				return fmt.Errorf("[%s] is not character or block device", device.HostPath)
+			}
+
+			var (
+				major       = int64(unix.Major(uint64(stat.Rdev)))
+				minor       = int64(unix.Minor(uint64(stat.Rdev)))
+				mode        = os.FileMode(stat.Mode & 0777)
+				uid, gid    = stat.Uid, stat.Gid
+				path        = device.ContainerPath
+				permissions = device.Permissions
+			)
+			if path == "" {
+				path = device.HostPath
+			}
+			if permissions == "" {
+				permissions = model.DefaultDevicePermissions
+			}
+			linux.Devices = append(linux.Devices, specs.LinuxDevice{
+				Path:     path,
+				Type:     deviceType,
+				Major:    major,
+				Minor:    minor,
+				FileMode: &mode,
+				UID:      &uid,
+				GID:      &gid,
+			})
+			linux.Resources.Devices = append(linux.Resources.Devices, specs.LinuxDeviceCgroup{
+				Allow:  true,
+				Type:   deviceType,
+				Major:  &major,
+				Minor:  &minor,
+				Access: permissions,
+			})
+		}
+		return nil
+	}
+}
+
+// nonNil returns empty slice for nil so the spec has explicitly empty capability set
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
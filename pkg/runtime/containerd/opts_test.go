@@ -3,6 +3,8 @@ package containerd
 import (
 	"testing"
 
+	"github.com/ernoaapa/eliot/pkg/model"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,3 +23,197 @@ func TestReplaceOrAppendEnvValues(t *testing.T) {
 		"OTHER=keep",
 	}, result)
 }
+
+func TestReplaceOrAppendEnvValuesLastValueWins(t *testing.T) {
+	result := replaceOrAppendEnvValues([]string{
+		"PATH=/usr/bin",
+	}, []string{
+		"FOO=first",
+		"FOO=second",
+		"PATH=/opt/bin",
+	})
+
+	assert.Equal(t, []string{
+		"PATH=/opt/bin",
+		"FOO=second",
+	}, result)
+}
+
+func TestReplaceOrAppendEnvValuesUnsetUserDefined(t *testing.T) {
+	result := replaceOrAppendEnvValues([]string{
+		"PATH=/usr/bin",
+	}, []string{
+		"FOO=bar",
+		"FOO",
+		"IMAGE_ONLY",
+	})
+
+	assert.Equal(t, []string{
+		"PATH=/usr/bin",
+	}, result)
+}
+
+func TestWithEnvOverridesImageEnv(t *testing.T) {
+	spec := &specs.Spec{
+		Process: &specs.Process{
+			Env: []string{"PATH=/usr/bin", "LANG=C.UTF-8", "DEBUG=false"},
+		},
+	}
+
+	assert.NoError(t, WithEnv([]string{"DEBUG=true", "LANG", "EXTRA=yes"})(nil, nil, nil, spec))
+
+	assert.Equal(t, []string{
+		"PATH=/usr/bin",
+		"DEBUG=true",
+		"EXTRA=yes",
+	}, spec.Process.Env)
+}
+
+func TestWithAnnotations(t *testing.T) {
+	spec := &specs.Spec{
+		Annotations: map[string]string{"existing": "value"},
+	}
+	err := WithAnnotations(map[string]string{
+		"io.katacontainers.config.hypervisor.default_memory": "256",
+	})(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"existing": "value",
+		"io.katacontainers.config.hypervisor.default_memory": "256",
+	}, spec.Annotations)
+}
+
+func TestWithSysctls(t *testing.T) {
+	spec := &specs.Spec{}
+	err := WithSysctls(map[string]string{"net.core.somaxconn": "1024"})(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"net.core.somaxconn": "1024"}, spec.Linux.Sysctl)
+}
+
+func TestWithCapabilities(t *testing.T) {
+	spec := &specs.Spec{
+		Process: &specs.Process{
+			Capabilities: &specs.LinuxCapabilities{
+				Bounding: []string{"CAP_CHOWN", "CAP_KILL"},
+				Ambient:  []string{"CAP_KILL"},
+			},
+		},
+	}
+	err := WithCapabilities(model.Capabilities{
+		Effective: []string{"CAP_NET_BIND_SERVICE"},
+		Permitted: []string{"CAP_NET_BIND_SERVICE"},
+	})(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &specs.LinuxCapabilities{
+		Effective:   []string{"CAP_NET_BIND_SERVICE"},
+		Permitted:   []string{"CAP_NET_BIND_SERVICE"},
+		Bounding:    []string{},
+		Inheritable: []string{},
+		Ambient:     []string{},
+	}, spec.Process.Capabilities, "should replace the defaults entirely")
+}
+
+func TestWithCapabilitiesRequiresEffectiveToBePermitted(t *testing.T) {
+	err := WithCapabilities(model.Capabilities{
+		Effective: []string{"CAP_NET_RAW"},
+	})(nil, nil, nil, &specs.Spec{})
+	assert.Error(t, err)
+}
+
+func TestWithOOMScoreAdj(t *testing.T) {
+	spec := &specs.Spec{}
+	err := WithOOMScoreAdj(-900)(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, -900, *spec.Process.OOMScoreAdj)
+}
+
+func TestWithAppArmorProfile(t *testing.T) {
+	spec := &specs.Spec{}
+	err := WithAppArmorProfile("eliot-default")(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "eliot-default", spec.Process.ApparmorProfile)
+}
+
+func TestWithInit(t *testing.T) {
+	spec := &specs.Spec{Process: &specs.Process{Args: []string{"/bin/app", "--foo"}}}
+	err := WithInit("/usr/bin/tini")(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"/dev/init", "--", "/bin/app", "--foo"}, spec.Process.Args)
+	assert.Equal(t, []specs.Mount{
+		{Type: "bind", Source: "/usr/bin/tini", Destination: "/dev/init", Options: []string{"rbind", "ro"}},
+	}, spec.Mounts)
+
+	assert.Error(t, WithInit("/usr/bin/tini")(nil, nil, nil, &specs.Spec{}), "should require command")
+}
+
+func TestWithTmpfs(t *testing.T) {
+	spec := &specs.Spec{
+		Mounts: []specs.Mount{
+			{Type: "tmpfs", Source: "shm", Destination: "/dev/shm", Options: []string{"size=65536k"}},
+		},
+	}
+	err := WithTmpfs([]model.TmpfsMount{
+		{Destination: "/tmp", SizeLimit: 67108864, Options: []string{"noexec"}},
+		{Destination: "/dev/shm", SizeLimit: 1048576},
+	})(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Len(t, spec.Mounts, 2, "should replace mount in the same destination")
+	assert.Equal(t, specs.Mount{
+		Type:        "tmpfs",
+		Source:      "tmpfs",
+		Destination: "/dev/shm",
+		Options:     []string{"nosuid", "nodev", "mode=1777", "size=1048576"},
+	}, spec.Mounts[0])
+	assert.Equal(t, []string{"nosuid", "nodev", "mode=1777", "noexec", "size=67108864"}, spec.Mounts[1].Options)
+}
+
+func TestWithMountsPropagation(t *testing.T) {
+	spec := &specs.Spec{}
+	err := WithMounts([]model.Mount{
+		{Type: "bind", Source: "/media", Destination: "/media", Options: []string{"rbind", "rprivate"}, Propagation: "rslave"},
+		{Type: "bind", Source: "/data", Destination: "/data", Options: []string{"rbind"}},
+	})(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"rbind", "rslave"}, spec.Mounts[0].Options, "should replace propagation option")
+	assert.Equal(t, []string{"rbind"}, spec.Mounts[1].Options, "should keep options without propagation")
+	assert.Equal(t, "rslave", spec.Linux.RootfsPropagation, "should make rootfs slave for slave mounts")
+}
+
+func TestWithMountsSharedPropagationWins(t *testing.T) {
+	spec := &specs.Spec{}
+	err := WithMounts([]model.Mount{
+		{Type: "bind", Source: "/mnt/shared", Destination: "/shared", Propagation: "rshared"},
+		{Type: "bind", Source: "/mnt/slave", Destination: "/slave", Propagation: "rslave"},
+	})(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "rshared", spec.Linux.RootfsPropagation)
+}
+
+func TestWithDevices(t *testing.T) {
+	spec := &specs.Spec{}
+	err := WithDevices([]model.Device{
+		{HostPath: "/dev/null", ContainerPath: "/dev/foo", Permissions: "rw"},
+	})(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Len(t, spec.Linux.Devices, 1)
+	assert.Equal(t, "/dev/foo", spec.Linux.Devices[0].Path)
+	assert.Equal(t, "c", spec.Linux.Devices[0].Type)
+	assert.Equal(t, int64(1), spec.Linux.Devices[0].Major)
+	assert.Equal(t, int64(3), spec.Linux.Devices[0].Minor)
+	assert.Len(t, spec.Linux.Resources.Devices, 1)
+	assert.Equal(t, "rw", spec.Linux.Resources.Devices[0].Access)
+	assert.True(t, spec.Linux.Resources.Devices[0].Allow)
+
+	assert.Error(t, WithDevices([]model.Device{{HostPath: "/etc/hosts"}})(nil, nil, nil, &specs.Spec{}), "should require device node")
+	assert.Error(t, WithDevices([]model.Device{{HostPath: "/dev/not-exist"}})(nil, nil, nil, &specs.Spec{}), "should require existing device")
+}
@@ -0,0 +1,106 @@
+package containerd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/oci"
+	"github.com/ernoaapa/eliot/pkg/model"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// CPUPeriod is the CFS period (in usecs) used for CPU limits
+const CPUPeriod = uint64(100000)
+
+// WithResources sets the container CPU and memory limits
+// Cgroup v2 hosts don't support the v1 only fields (e.g. swappiness, kernel memory)
+// and the runtime rejects the spec if those are present, so they get cleared on v2
+func WithResources(resources model.Resources, cgroupV2 bool) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		if s.Linux == nil {
+			s.Linux = &specs.Linux{}
+		}
+		if s.Linux.Resources == nil {
+			s.Linux.Resources = &specs.LinuxResources{}
+		}
+		r := s.Linux.Resources
+
+		if resources.MemoryLimit > 0 {
+			if r.Memory == nil {
+				r.Memory = &specs.LinuxMemory{}
+			}
+			limit := resources.MemoryLimit
+			r.Memory.Limit = &limit
+			// OCI swap is memory+swap total also on cgroup v2, the runtime converts it to the
+			// v2 memory.swap.max what limits only the swap, e.g. -1 to "max"
+			swap := resources.GetMemorySwapLimit()
+			r.Memory.Swap = &swap
+		}
+
+		if resources.CPULimit > 0 {
+			if r.CPU == nil {
+				r.CPU = &specs.LinuxCPU{}
+			}
+			period := CPUPeriod
+			quota := resources.CPULimit * int64(period) / 1000
+			r.CPU.Period = &period
+			r.CPU.Quota = &quota
+		}
+
+		if cgroupV2 && r.Memory != nil {
+			r.Memory.Swappiness = nil
+			r.Memory.Kernel = nil
+			r.Memory.KernelTCP = nil
+		}
+		return nil
+	}
+}
+
+// WithNetworkClassID tags the container network traffic with the net_cls class ID,
+// so that the host traffic control can shape the traffic by the class
+func WithNetworkClassID(classID uint32) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		if s.Linux == nil {
+			s.Linux = &specs.Linux{}
+		}
+		if s.Linux.Resources == nil {
+			s.Linux.Resources = &specs.LinuxResources{}
+		}
+		if s.Linux.Resources.Network == nil {
+			s.Linux.Resources.Network = &specs.LinuxNetwork{}
+		}
+		s.Linux.Resources.Network.ClassID = &classID
+		return nil
+	}
+}
+
+// WithRlimits sets the container process resource limits
+// Replaces the existing limit with same type, e.g. the default RLIMIT_NOFILE
+func WithRlimits(ulimits []model.Ulimit) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		if s.Process == nil {
+			s.Process = &specs.Process{}
+		}
+
+		for _, ulimit := range ulimits {
+			rlimit := specs.POSIXRlimit{
+				Type: "RLIMIT_" + strings.ToUpper(ulimit.Name),
+				Soft: ulimit.Soft,
+				Hard: ulimit.Hard,
+			}
+			s.Process.Rlimits = replaceOrAppendRlimit(s.Process.Rlimits, rlimit)
+		}
+		return nil
+	}
+}
+
+func replaceOrAppendRlimit(rlimits []specs.POSIXRlimit, rlimit specs.POSIXRlimit) []specs.POSIXRlimit {
+	for i := range rlimits {
+		if rlimits[i].Type == rlimit.Type {
+			rlimits[i] = rlimit
+			return rlimits
+		}
+	}
+	return append(rlimits, rlimit)
+}
@@ -0,0 +1,78 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithResourcesCgroupV1(t *testing.T) {
+	spec := &specs.Spec{}
+	err := WithResources(model.Resources{MemoryLimit: 64 * 1024 * 1024, CPULimit: 500}, false)(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(64*1024*1024), *spec.Linux.Resources.Memory.Limit)
+	assert.Equal(t, int64(64*1024*1024), *spec.Linux.Resources.Memory.Swap, "should disable swap on cgroup v1")
+	assert.Equal(t, uint64(100000), *spec.Linux.Resources.CPU.Period)
+	assert.Equal(t, int64(50000), *spec.Linux.Resources.CPU.Quota)
+}
+
+func TestWithResourcesCgroupV2(t *testing.T) {
+	swappiness := uint64(60)
+	spec := &specs.Spec{
+		Linux: &specs.Linux{
+			Resources: &specs.LinuxResources{
+				Memory: &specs.LinuxMemory{Swappiness: &swappiness},
+			},
+		},
+	}
+	err := WithResources(model.Resources{MemoryLimit: 1024}, true)(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(1024), *spec.Linux.Resources.Memory.Limit)
+	assert.Equal(t, int64(1024), *spec.Linux.Resources.Memory.Swap, "should disable swap by default also on cgroup v2")
+	assert.Nil(t, spec.Linux.Resources.Memory.Swappiness, "should clear v1 only fields on cgroup v2")
+	assert.Nil(t, spec.Linux.Resources.CPU)
+}
+
+func TestWithResourcesMemorySwapLimit(t *testing.T) {
+	for _, cgroupV2 := range []bool{false, true} {
+		spec := &specs.Spec{}
+		err := WithResources(model.Resources{MemoryLimit: 1024, MemorySwapLimit: 4096}, cgroupV2)(nil, nil, nil, spec)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4096), *spec.Linux.Resources.Memory.Swap, "should set memory+swap total on both cgroup versions")
+	}
+
+	spec := &specs.Spec{}
+	err := WithResources(model.Resources{MemoryLimit: 1024, MemorySwapLimit: -1}, false)(nil, nil, nil, spec)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), *spec.Linux.Resources.Memory.Swap, "should allow unlimited swap")
+}
+
+func TestWithRlimits(t *testing.T) {
+	spec := &specs.Spec{
+		Process: &specs.Process{
+			Rlimits: []specs.POSIXRlimit{{Type: "RLIMIT_NOFILE", Soft: 1024, Hard: 1024}},
+		},
+	}
+	err := WithRlimits([]model.Ulimit{
+		{Name: "nofile", Soft: 65536, Hard: 65536},
+		{Name: "nproc", Soft: 512, Hard: 1024},
+	})(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []specs.POSIXRlimit{
+		{Type: "RLIMIT_NOFILE", Soft: 65536, Hard: 65536},
+		{Type: "RLIMIT_NPROC", Soft: 512, Hard: 1024},
+	}, spec.Process.Rlimits, "should replace default nofile limit and append new ones")
+}
+
+func TestWithNetworkClassID(t *testing.T) {
+	spec := &specs.Spec{}
+	err := WithNetworkClassID(0x100123)(nil, nil, nil, spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint32(0x100123), *spec.Linux.Resources.Network.ClassID)
+}
@@ -0,0 +1,101 @@
+package containerd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/oci"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+// WithSpecPatch applies JSON merge patch (RFC 7386) to the OCI spec
+// Must be applied last so the patch overrides the values generated from the model
+// The patched spec must still be valid, unknown fields are not allowed so typos don't get silently ignored
+func WithSpecPatch(patch string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		patched, err := patchSpec(*s, []byte(patch))
+		if err != nil {
+			return err
+		}
+		*s = patched
+		return nil
+	}
+}
+
+func patchSpec(spec specs.Spec, patch []byte) (result specs.Spec, err error) {
+	var patchDoc interface{}
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return result, errors.Wrap(err, "Invalid spec patch JSON")
+	}
+
+	original, err := json.Marshal(spec)
+	if err != nil {
+		return result, errors.Wrap(err, "Failed to encode spec for patching")
+	}
+	var doc interface{}
+	if err := json.Unmarshal(original, &doc); err != nil {
+		return result, errors.Wrap(err, "Failed to decode spec for patching")
+	}
+
+	merged, err := json.Marshal(mergePatch(doc, patchDoc))
+	if err != nil {
+		return result, errors.Wrap(err, "Failed to encode patched spec")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		return result, errors.Wrap(err, "Patched spec is not valid OCI runtime spec")
+	}
+	return result, validateSpec(result)
+}
+
+// mergePatch merges the patch into the target as described in RFC 7386:
+// objects get merged recursively, null removes the field and other values replace the target value
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
+
+// validateSpec checks that the spec has the fields what are needed to run the container
+func validateSpec(spec specs.Spec) error {
+	if spec.Version == "" {
+		return errors.New("Invalid OCI runtime spec, ociVersion is required")
+	}
+	if spec.Root == nil || spec.Root.Path == "" {
+		return errors.New("Invalid OCI runtime spec, root path is required")
+	}
+	if spec.Process == nil || len(spec.Process.Args) == 0 {
+		return errors.New("Invalid OCI runtime spec, process args are required")
+	}
+	if spec.Process.Cwd == "" {
+		return errors.New("Invalid OCI runtime spec, process cwd is required")
+	}
+	if spec.Linux == nil {
+		return errors.New("Invalid OCI runtime spec, linux section is required")
+	}
+	for _, mount := range spec.Mounts {
+		if mount.Destination == "" {
+			return errors.New("Invalid OCI runtime spec, mount destination is required")
+		}
+	}
+	return nil
+}
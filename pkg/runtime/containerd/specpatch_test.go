@@ -0,0 +1,53 @@
+package containerd
+
+import (
+	"testing"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSpec() specs.Spec {
+	return specs.Spec{
+		Version: specs.Version,
+		Root:    &specs.Root{Path: "rootfs"},
+		Process: &specs.Process{
+			Args: []string{"/bin/sh"},
+			Cwd:  "/",
+			Env:  []string{"PATH=/bin"},
+		},
+		Hostname: "foo",
+		Linux:    &specs.Linux{},
+	}
+}
+
+func TestPatchSpec(t *testing.T) {
+	result, err := patchSpec(newTestSpec(), []byte(`{
+		"process": {"noNewPrivileges": true, "env": ["FOO=bar"]},
+		"linux": {"sysctl": {"net.ipv4.ip_forward": "1"}},
+		"hostname": null
+	}`))
+	assert.NoError(t, err)
+
+	assert.True(t, result.Process.NoNewPrivileges, "should merge new field")
+	assert.Equal(t, []string{"FOO=bar"}, result.Process.Env, "should replace arrays")
+	assert.Equal(t, []string{"/bin/sh"}, result.Process.Args, "should keep not patched fields")
+	assert.Equal(t, map[string]string{"net.ipv4.ip_forward": "1"}, result.Linux.Sysctl)
+	assert.Equal(t, "", result.Hostname, "null should remove the field")
+}
+
+func TestPatchSpecRejectsUnknownFields(t *testing.T) {
+	_, err := patchSpec(newTestSpec(), []byte(`{"process": {"noNewPrivilege": true}}`))
+	assert.Error(t, err)
+}
+
+func TestPatchSpecRejectsInvalidSpec(t *testing.T) {
+	_, err := patchSpec(newTestSpec(), []byte(`{"process": {"args": null}}`))
+	assert.Error(t, err, "should require process args")
+
+	_, err = patchSpec(newTestSpec(), []byte(`{"root": null}`))
+	assert.Error(t, err, "should require root")
+
+	_, err = patchSpec(newTestSpec(), []byte(`{"process": "foo"}`))
+	assert.Error(t, err, "should require process to be object")
+}
@@ -1,9 +1,16 @@
 package runtime
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/containerd/containerd"
+	types "github.com/containerd/containerd/api/types/task"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/filters"
 	"github.com/containerd/containerd/platforms"
+	"github.com/ernoaapa/eliot/pkg/model"
 	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,3 +27,123 @@ func TestPlatformExist(t *testing.T) {
 		{OS: "linux", Architecture: "amd64"},
 	}))
 }
+
+func TestMapTaskStatuses(t *testing.T) {
+	result := mapTaskStatuses([]*types.Process{
+		{ContainerID: "foo", Status: types.StatusRunning},
+		{ContainerID: "bar", Status: types.StatusStopped},
+		{ContainerID: "other", Status: types.StatusRunning},
+	}, []string{"foo", "bar", "missing"})
+
+	assert.Equal(t, map[string]string{
+		"foo":     "RUNNING",
+		"bar":     "STOPPED",
+		"missing": "UNKNOWN",
+	}, result)
+}
+
+func TestFindOrphanTasks(t *testing.T) {
+	orphans, stopped := findOrphanTasks([]*types.Process{
+		{ContainerID: "running", Status: types.StatusRunning},
+		{ContainerID: "exited", Status: types.StatusStopped},
+		{ContainerID: "deleted", Status: types.StatusRunning},
+		{ContainerID: "other-tool", Status: types.StatusStopped},
+	}, map[string]bool{
+		"running":    true,
+		"exited":     true,
+		"other-tool": true,
+	}, map[string]bool{
+		"running": true,
+		"exited":  true,
+	})
+
+	assert.Equal(t, []string{"deleted"}, orphans, "should reap tasks without container")
+	assert.Equal(t, []string{"exited"}, stopped, "should reap only stopped tasks of eliot containers")
+}
+
+func TestWaitForReadyTimeout(t *testing.T) {
+	client := NewContainerdClient(context.Background(), 0, "overlayfs", "/non/existing/containerd.sock", "hostname")
+
+	err := client.WaitForReady(0)
+	assert.Error(t, err)
+	assert.True(t, IsTimeout(err), "should return timeout error when socket doesn't appear")
+}
+
+func TestGetPullTimeout(t *testing.T) {
+	client := NewContainerdClient(context.Background(), 10*time.Second, "overlayfs", "", "hostname")
+	assert.Equal(t, 10*time.Second, client.getPullTimeout(), "should default to the request timeout")
+
+	client = NewContainerdClient(context.Background(), 10*time.Second, "overlayfs", "", "hostname", WithPullTimeout(20*time.Minute))
+	assert.Equal(t, 20*time.Minute, client.getPullTimeout())
+}
+
+func TestResolveNamespace(t *testing.T) {
+	original := model.DefaultNamespace
+	defer func() { model.DefaultNamespace = original }()
+
+	client := NewContainerdClient(context.Background(), 0, "overlayfs", "", "hostname")
+	assert.Equal(t, model.DefaultNamespace, client.resolveNamespace(""), "should fall back to the model default namespace")
+	assert.Equal(t, "tenant-a", client.resolveNamespace("tenant-a"))
+
+	assert.NoError(t, model.SetDefaultNamespace("workloads"))
+	assert.Equal(t, "workloads", client.resolveNamespace(""), "should use the configured default namespace")
+	assert.Equal(t, "tenant-a", client.resolveNamespace("tenant-a"), "explicit namespace should take precedence")
+}
+
+func TestGetNamespaces(t *testing.T) {
+	assert.Equal(t, []string{"eliot", "tenant-a"}, getNamespaces([]string{"default", "eliot", "tenant-a"}, "eliot"), "should hide the containerd default namespace")
+	assert.Equal(t, []string{"default", "tenant-a"}, getNamespaces([]string{"default", "tenant-a"}, "default"), "should list the default namespace if eliot uses it")
+}
+
+func TestGetImageLabelFilter(t *testing.T) {
+	filter, err := filters.Parse(getImageLabelFilter(map[string]string{
+		"approved-by": "security team",
+		"batch":       "2018-06",
+	}))
+	assert.NoError(t, err)
+
+	labels := func(values map[string]string) filters.Adaptor {
+		return filters.AdapterFunc(func(fieldpath []string) (string, bool) {
+			if len(fieldpath) != 2 || fieldpath[0] != "labels" {
+				return "", false
+			}
+			value, ok := values[fieldpath[1]]
+			return value, ok
+		})
+	}
+	assert.True(t, filter.Match(labels(map[string]string{"approved-by": "security team", "batch": "2018-06", "source": "ci"})))
+	assert.False(t, filter.Match(labels(map[string]string{"approved-by": "security team"})), "should require all labels")
+	assert.False(t, filter.Match(labels(map[string]string{"approved-by": "someone", "batch": "2018-06"})), "should require same values")
+}
+
+func TestMapContainerStatusStoppedTaskExit(t *testing.T) {
+	client := NewContainerdClient(context.Background(), 0, "overlayfs", "", "hostname")
+	info := containers.Container{ID: "foo"}
+
+	status := client.mapContainerStatus("default", info, containerd.Status{Status: containerd.Stopped, ExitStatus: 2})
+	assert.Equal(t, 2, status.LastExitCode, "should show the stopped task exit before the task gets deleted")
+	assert.Equal(t, model.ExitReasonError, status.LastExitReason)
+
+	status = client.mapContainerStatus("default", info, containerd.Status{Status: containerd.Running})
+	assert.Equal(t, 0, status.LastExitCode, "should use the container lifecycle for the running task")
+	assert.Equal(t, "", status.LastExitReason)
+}
+
+func TestTerminateTasksKeepsSharedConnection(t *testing.T) {
+	address, stop := startFakeContainerd(t)
+	defer stop()
+
+	client := NewContainerdClient(context.Background(), time.Second, "overlayfs", address, "hostname")
+	defer client.Close()
+	before, err := client.getConnection("default")
+	assert.NoError(t, err)
+
+	assert.Error(t, client.terminateTasks("default", []string{"foo"}, 0), "fake containerd doesn't implement the container service")
+
+	after, err := client.getConnection("default")
+	assert.NoError(t, err)
+	assert.True(t, before == after, "should reuse the namespace connection")
+	serving, err := after.IsServing(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, serving, "should not close the shared connection")
+}
@@ -0,0 +1,85 @@
+package runtime
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+)
+
+// DeviceInfo returns the device information what the container environment variables can reference
+type DeviceInfo func() *model.NodeInfo
+
+// deviceReference matches references like ${device.hostname} or ${device.labels.location}
+var deviceReference = regexp.MustCompile(`\$\{device\.([^}]*)\}`)
+
+const deviceLabelsPrefix = "labels."
+
+// hasDeviceReferences returns true if any of the environment variable values reference the device
+func hasDeviceReferences(env []string) bool {
+	for _, value := range env {
+		if deviceReference.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveEnv expands the device references in the container environment variables
+func (c *ContainerdClient) resolveEnv(env []string) ([]string, error) {
+	if !hasDeviceReferences(env) {
+		return env, nil
+	}
+	if c.deviceInfo == nil {
+		return env, ErrWithMessagef(ErrNotSupported, "Device information not available, cannot resolve environment variable references")
+	}
+	return expandDeviceReferences(env, c.deviceInfo())
+}
+
+// expandDeviceReferences replaces the ${device.*} references in the environment variable values
+// with the device information so that the same manifest produces device specific environment
+// Reference to unknown field or missing label is an error, so that the container doesn't start with wrong configuration
+func expandDeviceReferences(env []string, info *model.NodeInfo) (result []string, err error) {
+	for _, value := range env {
+		expanded := deviceReference.ReplaceAllStringFunc(value, func(reference string) string {
+			if err != nil {
+				return reference
+			}
+			field := deviceReference.FindStringSubmatch(reference)[1]
+			resolved, ok := resolveDeviceField(info, field)
+			if !ok {
+				err = ErrWithMessagef(ErrInvalid, "Cannot resolve environment variable reference %s in [%s]", reference, value)
+			}
+			return resolved
+		})
+		if err != nil {
+			return result, err
+		}
+		result = append(result, expanded)
+	}
+	return result, nil
+}
+
+func resolveDeviceField(info *model.NodeInfo, field string) (string, bool) {
+	if strings.HasPrefix(field, deviceLabelsPrefix) {
+		value, ok := info.Labels[strings.TrimPrefix(field, deviceLabelsPrefix)]
+		return value, ok
+	}
+
+	switch field {
+	case "hostname":
+		return info.Hostname, true
+	case "machineID":
+		return info.MachineID, true
+	case "systemUUID":
+		return info.SystemUUID, true
+	case "bootID":
+		return info.BootID, true
+	case "arch":
+		return info.Arch, true
+	case "os":
+		return info.OS, true
+	default:
+		return "", false
+	}
+}
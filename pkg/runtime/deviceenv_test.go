@@ -0,0 +1,51 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var testDeviceInfo = &model.NodeInfo{
+	Hostname:  "device-1",
+	MachineID: "1234",
+	Arch:      "arm64",
+	Labels:    map[string]string{"location": "helsinki", "eliot.io/os": "linux"},
+}
+
+func TestExpandDeviceReferences(t *testing.T) {
+	result, err := expandDeviceReferences([]string{
+		"LOCATION=${device.labels.location}",
+		"ID=${device.hostname}-${device.machineID}",
+		"OS=${device.labels.eliot.io/os}",
+		"PATH=${PATH}:/bin",
+	}, testDeviceInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"LOCATION=helsinki",
+		"ID=device-1-1234",
+		"OS=linux",
+		"PATH=${PATH}:/bin",
+	}, result, "should expand only the device references")
+}
+
+func TestExpandDeviceReferencesFailsWithUnknownReference(t *testing.T) {
+	_, err := expandDeviceReferences([]string{"FOO=${device.labels.missing}"}, testDeviceInfo)
+	assert.Error(t, err, "should fail with missing label")
+	assert.Equal(t, ErrInvalid, errors.Cause(err))
+
+	_, err = expandDeviceReferences([]string{"FOO=${device.serial}"}, testDeviceInfo)
+	assert.Error(t, err, "should fail with unknown field")
+}
+
+func TestResolveEnvWithoutDeviceInfo(t *testing.T) {
+	client := &ContainerdClient{}
+	env, err := client.resolveEnv([]string{"FOO=bar"})
+	assert.NoError(t, err, "should not require device info without references")
+	assert.Equal(t, []string{"FOO=bar"}, env)
+
+	_, err = client.resolveEnv([]string{"FOO=${device.hostname}"})
+	assert.Error(t, err)
+}
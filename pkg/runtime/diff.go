@@ -0,0 +1,157 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/continuity/fs"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/pkg/errors"
+	"github.com/rs/xid"
+	log "github.com/sirupsen/logrus"
+)
+
+// DiffContainer lists the paths what the container has added, modified or deleted in its
+// writable snapshot compared to the image
+// Both the image and the container snapshot get mounted read-only, so it's safe to diff running container
+func (c *ContainerdClient) DiffContainer(namespace, id string) (result []model.FileChange, err error) {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContext()
+	defer cancel()
+	ctx = namespaces.WithNamespace(ctx, namespace)
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return result, err
+	}
+
+	container, err := client.LoadContainer(ctx, id)
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to load container [%s], cannot diff it", id)
+	}
+
+	info, err := container.Info(ctx)
+	if err != nil {
+		return result, errors.Wrap(err, "Error while fetching container info")
+	}
+	if info.SnapshotKey == "" {
+		return result, ErrWithMessagef(ErrNotSupported, "Container [%s] doesn't have snapshot", id)
+	}
+
+	snapshotter := client.SnapshotService(info.Snapshotter)
+	snapshot, err := snapshotter.Stat(ctx, info.SnapshotKey)
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to resolve container [%s] snapshot", id)
+	}
+
+	upper, err := snapshotter.Mounts(ctx, info.SnapshotKey)
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to resolve container [%s] snapshot mounts", id)
+	}
+
+	// Lease protects the temporary view from garbage collection while diffing
+	ctx, done, err := client.WithLease(ctx)
+	if err != nil {
+		return result, errors.Wrap(err, "Failed to create lease for the diff")
+	}
+	defer done(ctx)
+
+	viewKey := fmt.Sprintf("%s-diff-%s", id, xid.New().String())
+	lower, err := snapshotter.View(ctx, viewKey, snapshot.Parent)
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to create view of container [%s] image snapshot", id)
+	}
+	defer func() {
+		if err := snapshotter.Remove(ctx, viewKey); err != nil {
+			log.Warnf("Failed to remove diff view snapshot [%s]: %s", viewKey, err)
+		}
+	}()
+
+	err = mount.WithTempMount(ctx, readonlyMounts(lower), func(lowerRoot string) error {
+		return mount.WithTempMount(ctx, readonlyMounts(upper), func(upperRoot string) error {
+			return fs.Changes(ctx, lowerRoot, upperRoot, func(kind fs.ChangeKind, path string, _ os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if change, ok := mapFileChange(kind, path); ok {
+					result = append(result, change)
+				}
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		return result, errors.Wrapf(err, "Error while resolving container [%s] filesystem changes", id)
+	}
+	return result, nil
+}
+
+func mapFileChange(kind fs.ChangeKind, path string) (model.FileChange, bool) {
+	switch kind {
+	case fs.ChangeKindAdd:
+		return model.FileChange{Kind: model.FileAdded, Path: path}, true
+	case fs.ChangeKindModify:
+		return model.FileChange{Kind: model.FileModified, Path: path}, true
+	case fs.ChangeKindDelete:
+		return model.FileChange{Kind: model.FileDeleted, Path: path}, true
+	default:
+		return model.FileChange{}, false
+	}
+}
+
+// readonlyMounts converts the snapshot mounts read-only so that the running container filesystem
+// can be mounted second time without interfering the container
+// Overlay upper directory gets mounted as the topmost lower directory because the same upper
+// directory cannot be used by two overlay mounts, the lower directories honour whiteouts as well
+func readonlyMounts(mounts []mount.Mount) (result []mount.Mount) {
+	for _, m := range mounts {
+		if m.Type != "overlay" {
+			result = append(result, mount.Mount{
+				Type:    m.Type,
+				Source:  m.Source,
+				Options: append(removeOption(m.Options, "rw"), "ro"),
+			})
+			continue
+		}
+
+		var (
+			upper   string
+			lowers  string
+			options = []string{}
+		)
+		for _, option := range m.Options {
+			switch {
+			case strings.HasPrefix(option, "upperdir="):
+				upper = strings.TrimPrefix(option, "upperdir=")
+			case strings.HasPrefix(option, "lowerdir="):
+				lowers = strings.TrimPrefix(option, "lowerdir=")
+			case strings.HasPrefix(option, "workdir="), option == "rw":
+			default:
+				options = append(options, option)
+			}
+		}
+		if upper != "" && lowers != "" {
+			lowers = upper + ":" + lowers
+		} else if upper != "" {
+			lowers = upper
+		}
+		result = append(result, mount.Mount{
+			Type:    m.Type,
+			Source:  m.Source,
+			Options: append(options, "lowerdir="+lowers),
+		})
+	}
+	return result
+}
+
+func removeOption(options []string, option string) (result []string) {
+	for _, o := range options {
+		if o != option {
+			result = append(result, o)
+		}
+	}
+	return result
+}
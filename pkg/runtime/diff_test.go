@@ -0,0 +1,51 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/continuity/fs"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadonlyMountsOverlay(t *testing.T) {
+	result := readonlyMounts([]mount.Mount{{
+		Type:   "overlay",
+		Source: "overlay",
+		Options: []string{
+			"workdir=/snapshots/3/work",
+			"upperdir=/snapshots/3/fs",
+			"lowerdir=/snapshots/2/fs:/snapshots/1/fs",
+		},
+	}})
+
+	assert.Equal(t, []mount.Mount{{
+		Type:    "overlay",
+		Source:  "overlay",
+		Options: []string{"lowerdir=/snapshots/3/fs:/snapshots/2/fs:/snapshots/1/fs"},
+	}}, result)
+}
+
+func TestReadonlyMountsBind(t *testing.T) {
+	result := readonlyMounts([]mount.Mount{{
+		Type:    "bind",
+		Source:  "/snapshots/1/fs",
+		Options: []string{"rw", "rbind"},
+	}})
+
+	assert.Equal(t, []mount.Mount{{
+		Type:    "bind",
+		Source:  "/snapshots/1/fs",
+		Options: []string{"rbind", "ro"},
+	}}, result)
+}
+
+func TestMapFileChange(t *testing.T) {
+	change, ok := mapFileChange(fs.ChangeKindDelete, "/etc/foo")
+	assert.True(t, ok)
+	assert.Equal(t, model.FileChange{Kind: model.FileDeleted, Path: "/etc/foo"}, change)
+
+	_, ok = mapFileChange(fs.ChangeKindUnmodified, "/etc")
+	assert.False(t, ok, "should skip unmodified paths")
+}
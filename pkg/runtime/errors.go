@@ -12,6 +12,11 @@ var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
 	ErrNotSupported  = errors.New("not supported")
+	ErrInvalid       = errors.New("invalid argument")
+	ErrTimeout       = errors.New("timeout")
+	ErrCanceled      = errors.New("canceled")
+	ErrLimitExceeded = errors.New("limit exceeded")
+	ErrUnverified    = errors.New("signature not verified")
 )
 
 // IsNotFound returns true if the error is due to a missing resource
@@ -19,6 +24,36 @@ func IsNotFound(err error) bool {
 	return errors.Cause(err) == ErrNotFound
 }
 
+// IsAlreadyExists returns true if the error is due to a resource what exists already
+func IsAlreadyExists(err error) bool {
+	return errors.Cause(err) == ErrAlreadyExists
+}
+
+// IsInvalid returns true if the error is due to invalid request, e.g. operation what the resource state doesn't allow
+func IsInvalid(err error) bool {
+	return errors.Cause(err) == ErrInvalid
+}
+
+// IsTimeout returns true if the error is due to operation not completed in time
+func IsTimeout(err error) bool {
+	return errors.Cause(err) == ErrTimeout
+}
+
+// IsCanceled returns true if the error is due to operation cancelled by the user
+func IsCanceled(err error) bool {
+	return errors.Cause(err) == ErrCanceled
+}
+
+// IsLimitExceeded returns true if the error is due to a resource limit, e.g. the maximum container count
+func IsLimitExceeded(err error) bool {
+	return errors.Cause(err) == ErrLimitExceeded
+}
+
+// IsUnverified returns true if the error is due to image what doesn't have valid signature
+func IsUnverified(err error) bool {
+	return errors.Cause(err) == ErrUnverified
+}
+
 // ErrWithMessagef updates error message with formated message
 // I.e. errors.WithMessage(err, fmt.Sprintf(...
 // Hopefully we can change to errors.WithMessagef some day: https://github.com/pkg/errors/pull/118
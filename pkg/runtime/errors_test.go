@@ -13,3 +13,28 @@ func TestIsNotFound(t *testing.T) {
 	assert.True(t, IsNotFound(ErrWithMessagef(ErrNotFound, "Foo bar not found")))
 	assert.False(t, IsNotFound(ErrWithMessagef(ErrAlreadyExists, "Foo bar not found")), "should not pass if not ErrNotFound")
 }
+
+func TestIsAlreadyExists(t *testing.T) {
+	assert.True(t, IsAlreadyExists(ErrWithMessagef(ErrAlreadyExists, "Container already exist")))
+	assert.False(t, IsAlreadyExists(ErrWithMessagef(ErrNotFound, "Foo bar not found")))
+}
+
+func TestIsInvalid(t *testing.T) {
+	assert.True(t, IsInvalid(ErrWithMessagef(ErrInvalid, "Container is running")))
+	assert.False(t, IsInvalid(ErrWithMessagef(ErrNotFound, "Foo bar not found")))
+}
+
+func TestIsTimeout(t *testing.T) {
+	assert.True(t, IsTimeout(ErrWithMessagef(ErrTimeout, "Unpack timed out")))
+	assert.False(t, IsTimeout(ErrWithMessagef(ErrNotFound, "Foo bar not found")))
+}
+
+func TestIsLimitExceeded(t *testing.T) {
+	assert.True(t, IsLimitExceeded(ErrWithMessagef(ErrLimitExceeded, "Too many containers")))
+	assert.False(t, IsLimitExceeded(ErrWithMessagef(ErrNotFound, "Foo bar not found")))
+}
+
+func TestIsUnverified(t *testing.T) {
+	assert.True(t, IsUnverified(ErrWithMessagef(ErrUnverified, "Image is not signed")))
+	assert.False(t, IsUnverified(ErrWithMessagef(ErrNotFound, "Foo bar not found")))
+}
@@ -0,0 +1,84 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd/events"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// StreamEvents calls the handler with the raw containerd events until the context is done or the handler fails
+// Empty namespace streams the events of all namespaces. The filters are containerd event filters,
+// e.g. topic~="/tasks/", the event matches if it matches any of them
+func (c *ContainerdClient) StreamEvents(ctx context.Context, namespace string, filters []string, handler func(model.RuntimeEvent) error) error {
+	client, err := c.getGlobalConnection()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	envelopes, errs := client.Subscribe(ctx, getNamespaceEventFilters(namespace, filters)...)
+	for {
+		select {
+		case envelope := <-envelopes:
+			if err := handler(mapEventToInternalModel(envelope)); err != nil {
+				return err
+			}
+		case err := <-errs:
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(err, "Failed to read containerd events")
+		}
+	}
+}
+
+// getNamespaceEventFilters limits each filter to the namespace because containerd matches any of the filters
+func getNamespaceEventFilters(namespace string, filters []string) []string {
+	if namespace == "" {
+		return filters
+	}
+	selector := fmt.Sprintf("namespace==%q", namespace)
+	if len(filters) == 0 {
+		return []string{selector}
+	}
+	result := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		result = append(result, selector+","+filter)
+	}
+	return result
+}
+
+func mapEventToInternalModel(envelope *events.Envelope) model.RuntimeEvent {
+	event := model.RuntimeEvent{
+		Timestamp: envelope.Timestamp,
+		Namespace: envelope.Namespace,
+		Topic:     envelope.Topic,
+	}
+	if envelope.Event != nil {
+		event.TypeURL = envelope.Event.TypeUrl
+		event.Payload = envelope.Event.Value
+		event.Subject = getEventSubject(envelope.Event.Value)
+	}
+	return event
+}
+
+// getEventSubject returns the first field of the protobuf encoded event if it is string,
+// in containerd events it is the container ID, the image name or the snapshot key
+func getEventSubject(payload []byte) string {
+	buffer := proto.NewBuffer(payload)
+	key, err := buffer.DecodeVarint()
+	if err != nil || key != 1<<3|proto.WireBytes {
+		return ""
+	}
+	value, err := buffer.DecodeStringBytes()
+	if err != nil {
+		return ""
+	}
+	return value
+}
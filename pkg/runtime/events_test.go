@@ -0,0 +1,26 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNamespaceEventFilters(t *testing.T) {
+	assert.Equal(t, []string{`topic~="/tasks/"`}, getNamespaceEventFilters("", []string{`topic~="/tasks/"`}))
+	assert.Equal(t, []string{`namespace=="default"`}, getNamespaceEventFilters("default", nil))
+	assert.Equal(t, []string{
+		`namespace=="default",topic~="/tasks/"`,
+		`namespace=="default",topic~="/images/"`,
+	}, getNamespaceEventFilters("default", []string{`topic~="/tasks/"`, `topic~="/images/"`}), "should limit every filter to the namespace")
+}
+
+func TestGetEventSubject(t *testing.T) {
+	payload, err := proto.Marshal(&taskEvent{ContainerID: "foo-id"})
+	assert.NoError(t, err)
+	assert.Equal(t, "foo-id", getEventSubject(payload))
+
+	assert.Equal(t, "", getEventSubject(nil))
+	assert.Equal(t, "", getEventSubject([]byte{0x08, 0x01}), "should ignore non-string first field")
+}
@@ -0,0 +1,173 @@
+package runtime
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/oci"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/mapping"
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// hostFiles are the host files what get bind mounted to the host network containers
+var hostFiles = []string{"/etc/hosts", "/etc/resolv.conf"}
+
+// ExportPods reconstructs the pod manifests from the containers in the namespace so that the pods can be
+// created again on the same or another device. The values what come from the image or the runtime defaults,
+// and the files what eliot generates for the container, are left out so that the manifest has only the
+// values what were given when the pod was created
+func (c *ContainerdClient) ExportPods(namespace string) ([]model.Pod, error) {
+	namespace = c.resolveNamespace(namespace)
+	pods := map[string]*model.Pod{}
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults, err := oci.GenerateSpec(namespaces.WithNamespace(ctx, namespace), nil, &containers.Container{})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to generate the default container spec")
+	}
+
+	containers, err := client.Containers(ctx, mapping.ContainerFilter())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error while getting list of containers")
+	}
+
+	for _, container := range containers {
+		info, err := container.Info(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error while fetching container info")
+		}
+		podName := mapping.GetPodName(info)
+		if _, ok := pods[podName]; !ok {
+			pod := mapping.InitialisePodModel(info, namespace, podName, c.hostname)
+			pod.Status = model.PodStatus{}
+			pods[pod.Metadata.Name] = &pod
+		}
+
+		config, err := getImageConfig(ctx, client, info.Image)
+		if err != nil {
+			log.Warnf("Failed to read container [%s] image config, the exported container includes the image defaults: %s", info.ID, err)
+		}
+		pods[podName].Spec.Containers = append(pods[podName].Spec.Containers, exportContainer(mapping.MapContainerToInternalModel(info), namespace, defaults, config))
+	}
+
+	return getValues(pods), nil
+}
+
+// getImageConfig reads the image config what the container process defaults come from
+func getImageConfig(ctx context.Context, client *containerd.Client, ref string) (config imagespecs.ImageConfig, err error) {
+	image, err := getImageSpec(ctx, client, ref)
+	if err != nil {
+		return config, err
+	}
+	return image.Config, nil
+}
+
+// exportContainer removes the container values what eliot, the image or the runtime defaults produce
+func exportContainer(container model.Container, namespace string, defaults *specs.Spec, config imagespecs.ImageConfig) model.Container {
+	container.ID = ""
+
+	if reflect.DeepEqual(container.Args, append(config.Entrypoint, config.Cmd...)) {
+		container.Args = nil
+	}
+	if container.WorkingDir == config.WorkingDir || (config.WorkingDir == "" && container.WorkingDir == defaults.Process.Cwd) {
+		container.WorkingDir = ""
+	}
+	container.Env = exportEnv(withoutHostEnv(container.Env, container.HostEnv), append(defaults.Process.Env, config.Env...))
+	container.Mounts = exportMounts(container.Mounts, defaults.Mounts)
+	container.Ulimits = exportUlimits(container.Ulimits, defaults.Process.Rlimits)
+
+	if container.CgroupParent == "/"+namespace || isPodCgroupParent(container.CgroupParent) {
+		container.CgroupParent = ""
+	}
+	if container.Capabilities != nil && reflect.DeepEqual(*container.Capabilities, model.Capabilities{
+		Effective:   defaults.Process.Capabilities.Effective,
+		Permitted:   defaults.Process.Capabilities.Permitted,
+		Bounding:    defaults.Process.Capabilities.Bounding,
+		Inheritable: defaults.Process.Capabilities.Inheritable,
+	}) {
+		container.Capabilities = nil
+	}
+	return container
+}
+
+// exportEnv returns the environment variables what are not defaults or the defaults have different value
+func exportEnv(env, defaults []string) (result []string) {
+	for _, value := range env {
+		if !contains(defaults, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// withoutHostEnv removes the forwarded host environment variables so that the export doesn't contain the host values
+func withoutHostEnv(env, names []string) (result []string) {
+	for _, value := range env {
+		if !contains(names, strings.SplitN(value, "=", 2)[0]) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// exportMounts returns the mounts what are not the runtime defaults or generated by eliot
+func exportMounts(mounts []model.Mount, defaults []specs.Mount) (result []model.Mount) {
+	for _, mount := range mounts {
+		if isDefaultMount(mount, defaults) || strings.HasPrefix(mount.Source, containerStateRoot+"/") {
+			continue
+		}
+		if mount.Source == mount.Destination && contains(hostFiles, mount.Destination) {
+			continue
+		}
+		result = append(result, mount)
+	}
+	return result
+}
+
+func isDefaultMount(mount model.Mount, defaults []specs.Mount) bool {
+	for _, d := range defaults {
+		if mount.Destination == d.Destination && mount.Type == d.Type && mount.Source == d.Source {
+			return true
+		}
+	}
+	return false
+}
+
+// exportUlimits returns the ulimits what differ from the runtime default rlimits
+func exportUlimits(ulimits []model.Ulimit, defaults []specs.POSIXRlimit) (result []model.Ulimit) {
+	for _, ulimit := range ulimits {
+		isDefault := false
+		for _, rlimit := range defaults {
+			if rlimit.Type == "RLIMIT_"+strings.ToUpper(ulimit.Name) && rlimit.Soft == ulimit.Soft && rlimit.Hard == ulimit.Hard {
+				isDefault = true
+			}
+		}
+		if !isDefault {
+			result = append(result, ulimit)
+		}
+	}
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
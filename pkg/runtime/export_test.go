@@ -0,0 +1,87 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/oci"
+	"github.com/ernoaapa/eliot/pkg/model"
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportContainer(t *testing.T) {
+	defaults, err := oci.GenerateSpec(namespaces.WithNamespace(context.Background(), "default"), nil, &containers.Container{})
+	assert.NoError(t, err)
+
+	config := imagespecs.ImageConfig{
+		Env:        []string{"NGINX_VERSION=1.13"},
+		Entrypoint: []string{"nginx"},
+		Cmd:        []string{"-g", "daemon off;"},
+		WorkingDir: "/usr/share/nginx",
+	}
+	container := model.Container{
+		ID:         "my-pod-nginx-b9nc1ulhkt4oqn1e1540",
+		Name:       "nginx",
+		Image:      "docker.io/library/nginx:latest",
+		Args:       []string{"nginx", "-g", "daemon off;"},
+		Env:        append(append([]string{}, defaults.Process.Env...), "NGINX_VERSION=1.13", "FOO=bar"),
+		WorkingDir: "/usr/share/nginx",
+		Mounts: []model.Mount{
+			{Type: "proc", Source: "proc", Destination: "/proc"},
+			{Type: "bind", Source: "/run/eliot/containers/default/my-pod-nginx/resolv.conf", Destination: "/etc/resolv.conf"},
+			{Type: "bind", Source: "/etc/hosts", Destination: "/etc/hosts"},
+			{Type: "bind", Source: "/data", Destination: "/var/lib/data"},
+		},
+		Ulimits:      []model.Ulimit{{Name: "nofile", Soft: 1024, Hard: 1024}, {Name: "nproc", Soft: 100, Hard: 100}},
+		CgroupParent: "/eliot/default/my-pod",
+		Capabilities: &model.Capabilities{
+			Effective:   defaults.Process.Capabilities.Effective,
+			Permitted:   defaults.Process.Capabilities.Permitted,
+			Bounding:    defaults.Process.Capabilities.Bounding,
+			Inheritable: defaults.Process.Capabilities.Inheritable,
+		},
+		Resources: &model.Resources{MemoryLimit: 1024},
+	}
+
+	assert.Equal(t, model.Container{
+		Name:      "nginx",
+		Image:     "docker.io/library/nginx:latest",
+		Env:       []string{"FOO=bar"},
+		Mounts:    []model.Mount{{Type: "bind", Source: "/data", Destination: "/var/lib/data"}},
+		Ulimits:   []model.Ulimit{{Name: "nproc", Soft: 100, Hard: 100}},
+		Resources: &model.Resources{MemoryLimit: 1024},
+	}, exportContainer(container, "default", defaults, config))
+}
+
+func TestExportContainerKeepsOverrides(t *testing.T) {
+	defaults, err := oci.GenerateSpec(namespaces.WithNamespace(context.Background(), "default"), nil, &containers.Container{})
+	assert.NoError(t, err)
+
+	container := exportContainer(model.Container{
+		Args:         []string{"sh", "-c", "sleep 10"},
+		Env:          []string{"NGINX_VERSION=1.14"},
+		WorkingDir:   "/tmp",
+		CgroupParent: "/default",
+	}, "default", defaults, imagespecs.ImageConfig{Env: []string{"NGINX_VERSION=1.13"}, Cmd: []string{"sh"}})
+
+	assert.Equal(t, []string{"sh", "-c", "sleep 10"}, container.Args)
+	assert.Equal(t, []string{"NGINX_VERSION=1.14"}, container.Env)
+	assert.Equal(t, "/tmp", container.WorkingDir)
+	assert.Equal(t, "", container.CgroupParent)
+}
+
+func TestExportContainerWithoutHostEnvValues(t *testing.T) {
+	defaults, err := oci.GenerateSpec(namespaces.WithNamespace(context.Background(), "default"), nil, &containers.Container{})
+	assert.NoError(t, err)
+
+	container := exportContainer(model.Container{
+		Env:     []string{"SITE_ID=helsinki-1", "FOO=bar"},
+		HostEnv: []string{"SITE_ID"},
+	}, "default", defaults, imagespecs.ImageConfig{})
+
+	assert.Equal(t, []string{"FOO=bar"}, container.Env)
+	assert.Equal(t, []string{"SITE_ID"}, container.HostEnv)
+}
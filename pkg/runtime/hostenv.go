@@ -0,0 +1,36 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// withHostEnv prepends the host environment variables to the container environment variables so that the
+// container own values override them. Each name must be in the allowlist so that the container cannot read
+// eliotd secrets from the environment, and must be set on the host so that the container doesn't start with
+// missing configuration
+func withHostEnv(env, names, allowlist []string, lookup func(string) (string, bool)) ([]string, error) {
+	if len(names) == 0 {
+		return env, nil
+	}
+
+	defined := map[string]bool{}
+	for _, value := range env {
+		defined[strings.SplitN(value, "=", 2)[0]] = true
+	}
+
+	result := []string{}
+	for _, name := range names {
+		if !contains(allowlist, name) {
+			return nil, ErrWithMessagef(ErrInvalid, "Host environment variable [%s] is not allowed, it must be in eliotd --host-env-allowlist", name)
+		}
+		value, ok := lookup(name)
+		if !ok {
+			return nil, ErrWithMessagef(ErrNotFound, "Host environment variable [%s] is not set", name)
+		}
+		if !defined[name] {
+			result = append(result, fmt.Sprintf("%s=%s", name, value))
+		}
+	}
+	return append(result, env...), nil
+}
@@ -0,0 +1,31 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithHostEnv(t *testing.T) {
+	host := map[string]string{"SITE_ID": "helsinki-1", "API_TOKEN": "secret", "REGION": "eu"}
+	lookup := func(name string) (string, bool) {
+		value, ok := host[name]
+		return value, ok
+	}
+	allowlist := []string{"SITE_ID", "REGION", "MISSING"}
+
+	env, err := withHostEnv([]string{"FOO=bar", "REGION=us"}, []string{"SITE_ID", "REGION"}, allowlist, lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SITE_ID=helsinki-1", "FOO=bar", "REGION=us"}, env, "container env should override host env")
+
+	_, err = withHostEnv(nil, []string{"API_TOKEN"}, allowlist, lookup)
+	assert.Equal(t, ErrInvalid, errors.Cause(err), "should not forward variables outside the allowlist")
+
+	_, err = withHostEnv(nil, []string{"MISSING"}, allowlist, lookup)
+	assert.Equal(t, ErrNotFound, errors.Cause(err))
+
+	env, err = withHostEnv([]string{"FOO=bar"}, nil, nil, lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"FOO=bar"}, env)
+}
@@ -0,0 +1,80 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/ernoaapa/eliot/pkg/model"
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// GetImageConfig returns the image config what the containers created from the image get as defaults,
+// e.g. the entrypoint and the exposed ports. Returns ErrNotFound if the image is not in the namespace
+func (c *ContainerdClient) GetImageConfig(namespace, ref string) (result model.ImageConfig, err error) {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return result, err
+	}
+
+	image, err := getImageSpec(ctx, client, ref)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return result, ErrWithMessagef(ErrNotFound, "Image [%s] not found in namespace [%s]", ref, namespace)
+		}
+		return result, errors.Wrapf(err, "Error while reading image [%s] config", ref)
+	}
+	return mapImageConfigToInternalModel(image), nil
+}
+
+// getImageSpec reads the image config blob of the image for the current platform
+func getImageSpec(ctx context.Context, client *containerd.Client, ref string) (result imagespecs.Image, err error) {
+	image, err := client.GetImage(ctx, ref)
+	if err != nil {
+		return result, err
+	}
+	desc, err := image.Config(ctx)
+	if err != nil {
+		return result, err
+	}
+	blob, err := content.ReadBlob(ctx, client.ContentStore(), desc.Digest)
+	if err != nil {
+		return result, err
+	}
+	if err := json.Unmarshal(blob, &result); err != nil {
+		return result, errors.Wrap(err, "Failed to parse the image config")
+	}
+	return result, nil
+}
+
+func mapImageConfigToInternalModel(image imagespecs.Image) model.ImageConfig {
+	return model.ImageConfig{
+		User:         image.Config.User,
+		Entrypoint:   image.Config.Entrypoint,
+		Cmd:          image.Config.Cmd,
+		Env:          image.Config.Env,
+		WorkingDir:   image.Config.WorkingDir,
+		ExposedPorts: getSortedKeys(image.Config.ExposedPorts),
+		Volumes:      getSortedKeys(image.Config.Volumes),
+		Labels:       image.Config.Labels,
+		StopSignal:   image.Config.StopSignal,
+		OS:           image.OS,
+		Architecture: image.Architecture,
+	}
+}
+
+func getSortedKeys(values map[string]struct{}) (result []string) {
+	for key := range values {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
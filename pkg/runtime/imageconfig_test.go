@@ -0,0 +1,25 @@
+package runtime
+
+import (
+	"testing"
+
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapImageConfigToInternalModel(t *testing.T) {
+	config := mapImageConfigToInternalModel(imagespecs.Image{
+		OS:           "linux",
+		Architecture: "arm64",
+		Config: imagespecs.ImageConfig{
+			Entrypoint:   []string{"/docker-entrypoint.sh"},
+			ExposedPorts: map[string]struct{}{"443/tcp": {}, "80/tcp": {}},
+			Labels:       map[string]string{"maintainer": "nginx"},
+		},
+	})
+	assert.Equal(t, []string{"/docker-entrypoint.sh"}, config.Entrypoint)
+	assert.Equal(t, []string{"443/tcp", "80/tcp"}, config.ExposedPorts, "should sort the exposed ports")
+	assert.Nil(t, config.Volumes)
+	assert.Equal(t, "nginx", config.Labels["maintainer"])
+	assert.Equal(t, "arm64", config.Architecture)
+}
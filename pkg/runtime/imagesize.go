@@ -0,0 +1,109 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"syscall"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/remotes"
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// containerdRoot is the directory where containerd stores the content and snapshots
+var containerdRoot = "/var/lib/containerd"
+
+// maxManifestSize limits how much is read when fetching the image manifest or index
+const maxManifestSize = 4 * 1024 * 1024
+
+// resolveImageBlobs fetches the image manifest for the current platform from the registry and
+// returns the config and layer descriptors, i.e. what the pull is going to fetch.
+// Returns nil if the manifest doesn't define the sizes (e.g. schema1) or the platform is not supported
+func resolveImageBlobs(ctx context.Context, resolver remotes.Resolver, ref string) ([]imagespecs.Descriptor, error) {
+	name, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to resolve image [%s]", ref)
+	}
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to create fetcher for image [%s]", ref)
+	}
+
+	matcher := platforms.NewMatcher(platforms.DefaultSpec())
+	for {
+		switch desc.MediaType {
+		case images.MediaTypeDockerSchema2ManifestList, imagespecs.MediaTypeImageIndex:
+			var index imagespecs.Index
+			if err := fetchJSON(ctx, fetcher, desc, &index); err != nil {
+				return nil, errors.Wrapf(err, "Failed to fetch image [%s] index", ref)
+			}
+			found := false
+			for _, manifest := range index.Manifests {
+				if manifest.Platform == nil || matcher.Match(*manifest.Platform) {
+					desc = manifest
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, nil
+			}
+		case images.MediaTypeDockerSchema2Manifest, imagespecs.MediaTypeImageManifest:
+			var manifest imagespecs.Manifest
+			if err := fetchJSON(ctx, fetcher, desc, &manifest); err != nil {
+				return nil, errors.Wrapf(err, "Failed to fetch image [%s] manifest", ref)
+			}
+			return append([]imagespecs.Descriptor{manifest.Config}, manifest.Layers...), nil
+		default:
+			return nil, nil
+		}
+	}
+}
+
+func fetchJSON(ctx context.Context, fetcher remotes.Fetcher, desc imagespecs.Descriptor, target interface{}) error {
+	reader, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return json.NewDecoder(io.LimitReader(reader, maxManifestSize)).Decode(target)
+}
+
+// checkImageSize returns error if the image total size exceeds the maximum size (if set)
+// or the blobs what are not yet in the content store don't fit to the available disk space (if known)
+// The unpacked layers need more space, so the disk check catches only the images what surely don't fit
+func checkImageSize(ctx context.Context, store content.Store, ref string, blobs []imagespecs.Descriptor, maxSize, available int64) error {
+	var total, missing int64
+	for _, blob := range blobs {
+		total += blob.Size
+		if _, err := store.Info(ctx, blob.Digest); err != nil {
+			missing += blob.Size
+		}
+	}
+
+	if maxSize > 0 && total > maxSize {
+		return ErrWithMessagef(ErrNotSupported, "Image [%s] size %s exceeds the limit [max-image-size] %s", ref, formatSize(total), formatSize(maxSize))
+	}
+	if available >= 0 && missing > available {
+		return ErrWithMessagef(ErrNotSupported, "Image [%s] needs %s disk space but the limit [available disk space] in [%s] is %s", ref, formatSize(missing), containerdRoot, formatSize(available))
+	}
+	return nil
+}
+
+// getAvailableDiskSpace returns the available bytes in the containerd root filesystem, -1 if cannot resolve
+func getAvailableDiskSpace() int64 {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(containerdRoot, &stat); err != nil {
+		return -1
+	}
+	return int64(stat.Bavail) * int64(stat.Bsize)
+}
+
+func formatSize(size int64) string {
+	return datasize.ByteSize(size).HumanReadable()
+}
@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	digest "github.com/opencontainers/go-digest"
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// existingContentStore is content store stub what knows only the given digests
+type existingContentStore struct {
+	content.Store
+	existing map[digest.Digest]bool
+}
+
+func (s existingContentStore) Info(ctx context.Context, dgst digest.Digest) (content.Info, error) {
+	if s.existing[dgst] {
+		return content.Info{Digest: dgst}, nil
+	}
+	return content.Info{}, errdefs.ErrNotFound
+}
+
+func TestCheckImageSize(t *testing.T) {
+	base := digest.FromString("base")
+	blobs := []imagespecs.Descriptor{
+		{Digest: digest.FromString("config"), Size: 1000},
+		{Digest: base, Size: 60000},
+		{Digest: digest.FromString("app"), Size: 40000},
+	}
+	store := existingContentStore{existing: map[digest.Digest]bool{base: true}}
+	ctx := context.Background()
+
+	assert.NoError(t, checkImageSize(ctx, store, "foo", blobs, 0, -1), "should pass without limits")
+	assert.NoError(t, checkImageSize(ctx, store, "foo", blobs, 200000, 50000), "should need disk only for missing blobs")
+
+	err := checkImageSize(ctx, store, "foo", blobs, 100000, -1)
+	assert.Error(t, err, "should reject image larger than max size")
+	assert.Equal(t, ErrNotSupported, errors.Cause(err))
+	assert.Contains(t, err.Error(), "exceeds the limit [max-image-size]")
+
+	err = checkImageSize(ctx, store, "foo", blobs, 0, 40000)
+	assert.Error(t, err, "should reject image what doesn't fit to the disk")
+	assert.Equal(t, ErrNotSupported, errors.Cause(err))
+	assert.Contains(t, err.Error(), "limit [available disk space]")
+}
@@ -1,8 +1,10 @@
 package runtime
 
 import (
+	"context"
 	"io"
 	"syscall"
+	"time"
 
 	"github.com/ernoaapa/eliot/pkg/model"
 	"github.com/ernoaapa/eliot/pkg/progress"
@@ -10,18 +12,55 @@ import (
 
 // Client is interface for underlying container implementation
 type Client interface {
+	WaitForReady(timeout time.Duration) error
 	GetPods(namespace string) ([]model.Pod, error)
+	GetAllPods() ([]model.Pod, error)
 	GetPod(namespace, podName string) (model.Pod, error)
-	PullImage(namespace, ref string, status *progress.ImageFetch) error
+	ExportPods(namespace string) ([]model.Pod, error)
+	CancelPull(namespace, ref string) error
+	PullImage(namespace, ref string, secrets []model.PullSecret, labels map[string]string, status *progress.ImageFetch) error
+	ImportImage(namespace string, reader io.Reader, labels map[string]string) ([]string, error)
+	GetImages(namespace string, labels map[string]string) ([]model.Image, error)
+	ExportImage(namespace, ref string, writer io.Writer) error
+	TagImage(namespace, ref, newRef string) error
+	GetImageConfig(namespace, ref string) (model.ImageConfig, error)
 	CreateContainer(pod model.Pod, container model.Container) (model.ContainerStatus, error)
 	StartContainer(namespace, id string, io IOSet) (model.ContainerStatus, error)
+	StartContainerWithProgress(namespace, id string, io IOSet, phase func(string)) (model.ContainerStatus, error)
 	StopContainer(namespace, id string) (model.ContainerStatus, error)
+	StopContainers(namespace string, ids []string, gracePeriod time.Duration) ([]model.ContainerStatus, error)
+	TerminateContainers(namespace string, ids []string, gracePeriod time.Duration) error
+	DeleteNamespaceContainers(namespace string, removeNamespace bool) ([]model.ContainerStatus, error)
 	GetNamespaces() ([]string, error)
+	GetDiskUsage(namespace string) (model.DiskUsage, error)
+	GetContainerMetrics(namespace string) ([]model.ContainerMetrics, error)
+	GetContainerMetric(namespace, containerID string) (model.ContainerMetrics, error)
 	IsContainerRunning(namespace, name string) (bool, error)
 	GetContainerTaskStatus(namespace, name string) string
+	GetContainerTaskStatuses(namespace string, ids []string) (map[string]string, error)
+	WaitForStatus(namespace, id, status string, timeout time.Duration) error
+	InspectContainer(namespace, id string) (model.ContainerInspect, error)
+	DiffContainer(namespace, id string) ([]model.FileChange, error)
+	CheckContainerLimit(count int) error
+	CheckNamespaceQuota(pod model.Pod, released []string) error
+	CommitContainer(namespace, id, newRef string) (model.Image, error)
+	MoveContainer(namespace, id, targetNamespace string) (model.ContainerStatus, error)
+	RunContainer(pod model.Pod, container model.Container, timeout time.Duration) (model.RunResult, error)
+	ListProcesses(namespace, id string) ([]model.Process, error)
 	Exec(namespace, podName, execID string, args []string, tty bool, attach AttachIO) error
+	ExecProbe(namespace, name string, args []string, timeout time.Duration) (int, error)
+	SetContainerReady(namespace, name string, ready bool) error
 	Attach(namespace, podName string, attach AttachIO) error
 	Signal(namespace, name string, signal syscall.Signal) error
+	ReapOrphans() (int, error)
+	GetRuntimeInfo() (model.RuntimeInfo, error)
+	SetSnapshotter(snapshotter string) error
+	SetSecretResolver(resolver SecretResolver)
+	MigrateSnapshotter(snapshotter string, recreate bool, report func(model.SnapshotterMigrationStep)) error
+	StreamEvents(ctx context.Context, namespace string, filters []string, handler func(model.RuntimeEvent) error) error
+	OnConnectionChange(listener ConnectionListener)
+	IsConnected() bool
+	Close() error
 }
 
 // AttachIO provides way to attach stdin,stdout and stderr to container
@@ -0,0 +1,135 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// journalSocketPath is the systemd journal native protocol socket
+var journalSocketPath = "/run/systemd/journal/socket"
+
+const (
+	// Syslog priorities what the container stdout and stderr lines get
+	journalPriorityInfo = 6
+	journalPriorityErr  = 3
+	// journalMaxLine is the max line length, longer lines get split to multiple journal entries
+	journalMaxLine = 16 * 1024
+)
+
+// checkJournal returns error if the host doesn't have the systemd journal running
+func checkJournal() error {
+	if _, err := os.Stat(journalSocketPath); err != nil {
+		return ErrWithMessagef(ErrNotSupported, "Systemd journal socket [%s] not found, cannot forward container output to journald", journalSocketPath)
+	}
+	return nil
+}
+
+// journalWriter sends entries to the host journal with the native journal protocol
+// Every entry gets the writer fields, e.g. SYSLOG_IDENTIFIER, in addition to the message and priority
+type journalWriter struct {
+	conn   *net.UnixConn
+	fields []byte
+}
+
+func newJournalWriter(fields map[string]string) (*journalWriter, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journalSocketPath, Net: "unixgram"})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to connect to journal socket [%s]", journalSocketPath)
+	}
+
+	keys := []string{}
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var encoded bytes.Buffer
+	for _, key := range keys {
+		writeJournalField(&encoded, key, fields[key])
+	}
+	return &journalWriter{conn: conn, fields: encoded.Bytes()}, nil
+}
+
+// send writes one journal entry, the message must not contain newlines
+func (w *journalWriter) send(message []byte, priority int) error {
+	var entry bytes.Buffer
+	entry.Write(w.fields)
+	writeJournalField(&entry, "PRIORITY", strconv.Itoa(priority))
+	entry.WriteString("MESSAGE=")
+	entry.Write(message)
+	entry.WriteByte('\n')
+
+	_, err := w.conn.Write(entry.Bytes())
+	return err
+}
+
+func (w *journalWriter) Close() error {
+	return w.conn.Close()
+}
+
+func writeJournalField(buf *bytes.Buffer, key, value string) {
+	buf.WriteString(key)
+	buf.WriteByte('=')
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// forwardToJournal sends each line from the reader as journal entry until the reader returns error,
+// i.e. until the container process exits and closes the output, and then closes the writer
+func forwardToJournal(reader io.Reader, writer *journalWriter, priority int) {
+	defer writer.Close()
+
+	buffered := bufio.NewReaderSize(reader, journalMaxLine)
+	for {
+		line, err := buffered.ReadSlice('\n')
+		if len(line) > 0 {
+			if sendErr := writer.send(bytes.TrimSuffix(line, []byte("\n")), priority); sendErr != nil {
+				log.Warnf("Failed to forward container output to journal: %s", sendErr)
+			}
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			if err != io.EOF {
+				log.Debugf("Stopped forwarding container output to journal: %s", err)
+			}
+			return
+		}
+	}
+}
+
+// newContainerJournalWriters creates the container stdout and stderr journal writers
+// The container ID is the syslog identifier so that "journalctl -t <id>" shows the container output
+func newContainerJournalWriters(namespace, id string) ([]*journalWriter, error) {
+	fields := map[string]string{
+		"SYSLOG_IDENTIFIER":   id,
+		"CONTAINER_ID":        id,
+		"CONTAINER_NAMESPACE": namespace,
+	}
+
+	stdout, err := newJournalWriter(fields)
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := newJournalWriter(fields)
+	if err != nil {
+		stdout.Close()
+		return nil, err
+	}
+	return []*journalWriter{stdout, stderr}, nil
+}
+
+func closeJournalWriters(writers []*journalWriter) {
+	for _, writer := range writers {
+		writer.Close()
+	}
+}
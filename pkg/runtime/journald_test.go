@@ -0,0 +1,74 @@
+package runtime
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func listenJournal(t *testing.T) (*net.UnixConn, func()) {
+	dir, err := ioutil.TempDir("", "journal")
+	assert.NoError(t, err)
+
+	original := journalSocketPath
+	journalSocketPath = filepath.Join(dir, "socket")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: journalSocketPath, Net: "unixgram"})
+	assert.NoError(t, err)
+
+	return conn, func() {
+		conn.Close()
+		journalSocketPath = original
+		os.RemoveAll(dir)
+	}
+}
+
+func receiveJournalEntry(t *testing.T, conn *net.UnixConn) string {
+	buf := make([]byte, journalMaxLine*2)
+	n, err := conn.Read(buf)
+	assert.NoError(t, err)
+	return string(buf[:n])
+}
+
+func TestCheckJournal(t *testing.T) {
+	_, cleanup := listenJournal(t)
+	assert.NoError(t, checkJournal())
+	cleanup()
+
+	journalSocketPath = "/nonexisting/journal/socket"
+	defer func() { journalSocketPath = "/run/systemd/journal/socket" }()
+	assert.Equal(t, ErrNotSupported, errors.Cause(checkJournal()), "should return not supported error if journal is not running")
+}
+
+func TestForwardToJournal(t *testing.T) {
+	conn, cleanup := listenJournal(t)
+	defer cleanup()
+
+	writers, err := newContainerJournalWriters("default", "foo-id")
+	assert.NoError(t, err)
+
+	forwardToJournal(strings.NewReader("first line\nsecond line"), writers[1], journalPriorityErr)
+	closeJournalWriters(writers[:1])
+
+	fields := "CONTAINER_ID=foo-id\nCONTAINER_NAMESPACE=default\nSYSLOG_IDENTIFIER=foo-id\nPRIORITY=3\n"
+	assert.Equal(t, fields+"MESSAGE=first line\n", receiveJournalEntry(t, conn))
+	assert.Equal(t, fields+"MESSAGE=second line\n", receiveJournalEntry(t, conn))
+}
+
+func TestForwardToJournalSplitsLongLines(t *testing.T) {
+	conn, cleanup := listenJournal(t)
+	defer cleanup()
+
+	writer, err := newJournalWriter(map[string]string{})
+	assert.NoError(t, err)
+
+	forwardToJournal(strings.NewReader(strings.Repeat("x", journalMaxLine+10)+"\n"), writer, journalPriorityInfo)
+
+	assert.Equal(t, "PRIORITY=6\nMESSAGE="+strings.Repeat("x", journalMaxLine)+"\n", receiveJournalEntry(t, conn))
+	assert.Equal(t, "PRIORITY=6\nMESSAGE=xxxxxxxxxx\n", receiveJournalEntry(t, conn))
+}
@@ -0,0 +1,106 @@
+package runtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/containerd/containerd"
+	leasesapi "github.com/containerd/containerd/api/services/leases/v1"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/mapping"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// pullLeaseExpireLabel is the lease label for the time after what eliot releases the lease
+	pullLeaseExpireLabel = "lease.expire"
+	// pullLeaseImageLabel is the lease label for the image what the lease protects
+	pullLeaseImageLabel = "lease.image"
+)
+
+// createPullLease creates lease what protects the pulled content from the garbage collection until
+// the lease duration expires or container gets created from the image. Returns empty if leases are disabled.
+// Containerd doesn't expire the leases itself, so the expired leases get released here before creating new one
+func (c *ContainerdClient) createPullLease(ctx context.Context, client *containerd.Client, ref string) (string, error) {
+	if c.pullLease <= 0 {
+		return "", nil
+	}
+
+	if err := releasePullLeases(ctx, client, ""); err != nil {
+		log.Warnf("Failed to release expired pull leases: %s", err)
+	}
+
+	resp, err := client.LeasesService().Create(ctx, &leasesapi.CreateRequest{
+		Labels: map[string]string{
+			mapping.LabelKey(pullLeaseExpireLabel): time.Now().Add(c.pullLease).Format(time.RFC3339),
+			mapping.LabelKey(pullLeaseImageLabel):  ref,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Lease.ID, nil
+}
+
+// releasePullLeases deletes the expired pull leases and the leases of the image, if image is given
+func releasePullLeases(ctx context.Context, client *containerd.Client, image string) error {
+	return deletePullLeases(ctx, client, func(lease *leasesapi.Lease) bool {
+		return isReleasablePullLease(lease, image, time.Now())
+	})
+}
+
+// deleteAllPullLeases deletes all the pull leases what eliot has created, also the ones what have not expired
+func deleteAllPullLeases(ctx context.Context, client *containerd.Client) error {
+	return deletePullLeases(ctx, client, isPullLease)
+}
+
+// deletePullLeases deletes the pull leases what the match function accepts
+func deletePullLeases(ctx context.Context, client *containerd.Client, match func(*leasesapi.Lease) bool) error {
+	resp, err := client.LeasesService().List(ctx, &leasesapi.ListRequest{})
+	if err != nil {
+		return errors.Wrap(err, "Error while listing leases")
+	}
+
+	for _, lease := range resp.Leases {
+		if !isPullLease(lease) || !match(lease) {
+			continue
+		}
+		log.Debugf("Release pull lease [%s] of image [%s]", lease.ID, getLeaseLabel(lease, pullLeaseImageLabel))
+		if _, err := client.LeasesService().Delete(ctx, &leasesapi.DeleteRequest{ID: lease.ID}); err != nil {
+			return errors.Wrapf(err, "Error while deleting lease [%s]", lease.ID)
+		}
+	}
+	return nil
+}
+
+// isPullLease returns true if eliot created the lease for pulling
+func isPullLease(lease *leasesapi.Lease) bool {
+	return getLeaseLabel(lease, pullLeaseExpireLabel) != ""
+}
+
+// isReleasablePullLease returns true if eliot created the lease for pulling and it's expired or for the image
+func isReleasablePullLease(lease *leasesapi.Lease, image string, now time.Time) bool {
+	value := getLeaseLabel(lease, pullLeaseExpireLabel)
+	if value == "" {
+		// Not created by eliot
+		return false
+	}
+	if image != "" && getLeaseLabel(lease, pullLeaseImageLabel) == image {
+		return true
+	}
+	expire, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		log.Warnf("Invalid pull lease [%s] expire time [%s], releasing it: %s", lease.ID, value, err)
+		return true
+	}
+	return now.After(expire)
+}
+
+// getLeaseLabel returns the lease label value with the configured label prefix, or with the default prefix
+// so that the leases created before changing the prefix get released too
+func getLeaseLabel(lease *leasesapi.Lease, name string) string {
+	if value, ok := lease.Labels[mapping.LabelKey(name)]; ok {
+		return value
+	}
+	return lease.Labels[mapping.DefaultLabelPrefix+"."+name]
+}
@@ -0,0 +1,37 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	leasesapi "github.com/containerd/containerd/api/services/leases/v1"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/mapping"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsReleasablePullLease(t *testing.T) {
+	now := time.Now()
+	lease := &leasesapi.Lease{
+		ID: "foo",
+		Labels: map[string]string{
+			"io.eliot.lease.expire": now.Add(time.Hour).Format(time.RFC3339),
+			"io.eliot.lease.image":  "docker.io/library/alpine:latest",
+		},
+	}
+
+	assert.False(t, isReleasablePullLease(lease, "", now), "should keep lease until it expires")
+	assert.True(t, isReleasablePullLease(lease, "", now.Add(2*time.Hour)), "should release expired lease")
+	assert.True(t, isReleasablePullLease(lease, "docker.io/library/alpine:latest", now), "should release lease when image gets used")
+	assert.False(t, isReleasablePullLease(lease, "docker.io/library/busybox:latest", now), "should keep lease of other image")
+	assert.False(t, isReleasablePullLease(&leasesapi.Lease{ID: "other"}, "", now), "should not touch leases what eliot didn't create")
+}
+
+func TestPullLeaseLabelsUseLabelPrefix(t *testing.T) {
+	defer mapping.SetLabelPrefix(mapping.DefaultLabelPrefix)
+	assert.NoError(t, mapping.SetLabelPrefix("com.example.eliot"))
+
+	now := time.Now()
+	expire := now.Add(-time.Hour).Format(time.RFC3339)
+	assert.True(t, isReleasablePullLease(&leasesapi.Lease{ID: "new", Labels: map[string]string{"com.example.eliot.lease.expire": expire}}, "", now))
+	assert.True(t, isReleasablePullLease(&leasesapi.Lease{ID: "old", Labels: map[string]string{"io.eliot.lease.expire": expire}}, "", now), "should release the leases created with the default prefix")
+}
@@ -0,0 +1,91 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/mapping"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// CountContainers returns the number of containers in all namespaces, running or not
+func (c *ContainerdClient) CountContainers() (int, error) {
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getGlobalConnection()
+	if err != nil {
+		return 0, err
+	}
+	return countContainers(ctx, client, c.resolveNamespace(""))
+}
+
+// CheckContainerLimit returns ErrLimitExceeded if creating count new containers would exceed the maximum container count,
+// the stopped containers count too because they can get started again any time
+func (c *ContainerdClient) CheckContainerLimit(count int) error {
+	if c.maxContainers <= 0 {
+		return nil
+	}
+	current, err := c.CountContainers()
+	if err != nil {
+		return err
+	}
+	return checkContainerLimit(current, count, c.maxContainers)
+}
+
+// withinLimits checks the maximum container count and the namespace quota right after the container got created
+// and deletes the container if it doesn't fit. The callers hold containerLimitMu so that concurrent creates
+// cannot exceed the limits, and recreating existing container fails with AlreadyExists from the create
+// The released is how many of the counted containers get removed after the create, e.g. the original of moved container
+func (c *ContainerdClient) withinLimits(ctx context.Context, client *containerd.Client, created containerd.Container, namespace string, container model.Container, released int) error {
+	err := c.withinContainerLimit(ctx, client, released)
+	if err == nil {
+		err = c.withinNamespaceQuota(ctx, client, namespace, created.ID(), container)
+	}
+	if err != nil {
+		if deleteErr := created.Delete(ctx, containerd.WithSnapshotCleanup); deleteErr != nil {
+			log.Warnf("Failed to remove container [%s] what exceeded the limits: %s", created.ID(), deleteErr)
+		}
+	}
+	return err
+}
+
+// withinContainerLimit checks the maximum container count when the count already includes the created container
+func (c *ContainerdClient) withinContainerLimit(ctx context.Context, client *containerd.Client, released int) error {
+	if c.maxContainers <= 0 {
+		return nil
+	}
+	current, err := countContainers(ctx, client, c.resolveNamespace(""))
+	if err != nil {
+		return err
+	}
+	return checkContainerLimit(current-1-released, 1, c.maxContainers)
+}
+
+func checkContainerLimit(current, count, max int) error {
+	if current+count > max {
+		return ErrWithMessagef(ErrLimitExceeded, "Cannot create %d container(s), the node has %d containers (running or stopped) and the maximum is %d", count, current, max)
+	}
+	return nil
+}
+
+// countContainers counts the containers in the namespaces what eliot manages, running or stopped
+func countContainers(ctx context.Context, client *containerd.Client, defaultNamespace string) (int, error) {
+	list, err := client.NamespaceService().List(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to list namespaces")
+	}
+
+	count := 0
+	for _, namespace := range getNamespaces(list, defaultNamespace) {
+		result, err := client.ContainerService().List(namespaces.WithNamespace(ctx, namespace), mapping.ContainerFilter())
+		if err != nil {
+			return 0, errors.Wrapf(err, "Failed to list containers in namespace [%s]", namespace)
+		}
+		count += len(result)
+	}
+	return count, nil
+}
@@ -0,0 +1,92 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	namespacesapi "github.com/containerd/containerd/api/services/namespaces/v1"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/mapping"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestCheckContainerLimit(t *testing.T) {
+	assert.NoError(t, checkContainerLimit(8, 2, 10))
+
+	err := checkContainerLimit(9, 2, 10)
+	assert.True(t, IsLimitExceeded(err))
+	assert.Contains(t, err.Error(), "the node has 9 containers (running or stopped) and the maximum is 10")
+}
+
+func TestCheckContainerLimitWithoutLimit(t *testing.T) {
+	client := NewContainerdClient(nil, 0, "overlayfs", "", "")
+	assert.NoError(t, client.CheckContainerLimit(100), "should not connect to containerd without limit")
+}
+
+// fakeCountContainers has one eliot managed and one other container in each namespace
+type fakeCountContainers struct {
+	containersapi.ContainersServer
+}
+
+func (fakeCountContainers) List(ctx context.Context, req *containersapi.ListContainersRequest) (*containersapi.ListContainersResponse, error) {
+	managed := containersapi.Container{ID: "managed", Labels: map[string]string{"io.eliot.pod.name": "foo"}}
+	for _, filter := range req.Filters {
+		if filter == mapping.ContainerFilter() {
+			return &containersapi.ListContainersResponse{Containers: []containersapi.Container{managed}}, nil
+		}
+	}
+	return &containersapi.ListContainersResponse{Containers: []containersapi.Container{managed, {ID: "other-tool"}}}, nil
+}
+
+func (fakeCountContainers) Get(ctx context.Context, req *containersapi.GetContainerRequest) (*containersapi.GetContainerResponse, error) {
+	if req.ID != "managed" {
+		return nil, errdefs.ToGRPC(errdefs.ErrNotFound)
+	}
+	return &containersapi.GetContainerResponse{Container: containersapi.Container{ID: req.ID}}, nil
+}
+
+type fakeCountNamespaces struct {
+	namespacesapi.NamespacesServer
+}
+
+func (fakeCountNamespaces) List(ctx context.Context, req *namespacesapi.ListNamespacesRequest) (*namespacesapi.ListNamespacesResponse, error) {
+	return &namespacesapi.ListNamespacesResponse{Namespaces: []namespacesapi.Namespace{{Name: "eliot"}, {Name: "tenant-a"}}}, nil
+}
+
+func TestCountContainersCountsOnlyManaged(t *testing.T) {
+	address, stop := startFakeContainerd(t, func(server *grpc.Server) {
+		containersapi.RegisterContainersServer(server, fakeCountContainers{})
+		namespacesapi.RegisterNamespacesServer(server, fakeCountNamespaces{})
+	})
+	defer stop()
+
+	client := NewContainerdClient(context.Background(), time.Second, "overlayfs", address, "hostname")
+	defer client.Close()
+
+	count, err := client.CountContainers()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count, "should not count the containers what other tools have created")
+}
+
+func TestWithinContainerLimitCountsCreatedContainer(t *testing.T) {
+	address, stop := startFakeContainerd(t, func(server *grpc.Server) {
+		containersapi.RegisterContainersServer(server, fakeCountContainers{})
+		namespacesapi.RegisterNamespacesServer(server, fakeCountNamespaces{})
+	})
+	defer stop()
+
+	withinLimit := func(max, released int) error {
+		client := NewContainerdClient(context.Background(), time.Second, "overlayfs", address, "hostname", WithMaxContainers(max))
+		defer client.Close()
+		connection, err := client.getGlobalConnection()
+		assert.NoError(t, err)
+		return client.withinContainerLimit(context.Background(), connection, released)
+	}
+
+	assert.NoError(t, withinLimit(2, 0), "should fit when the created container is the last one")
+	assert.True(t, IsLimitExceeded(withinLimit(1, 0)), "should reject the created container over the limit")
+	assert.NoError(t, withinLimit(1, 1), "should not count the moved container twice")
+}
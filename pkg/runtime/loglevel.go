@@ -0,0 +1,35 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+)
+
+// withLogLevelEnv returns the container environment with the pod or container log level environment variable
+// The container log level overrides the pod log level and LogLevelNode gets replaced with the node log level
+// The variable is not added if the container defines it explicitly
+func withLogLevelEnv(spec model.PodSpec, container model.Container, nodeLevel string) []string {
+	level := spec.LogLevel
+	if container.LogLevel != "" {
+		level = container.LogLevel
+	}
+	if level == "" {
+		return container.Env
+	}
+	if level == model.LogLevelNode {
+		level = nodeLevel
+	}
+
+	name := spec.LogLevelEnv
+	if name == "" {
+		name = model.DefaultLogLevelEnv
+	}
+	for _, value := range container.Env {
+		if strings.SplitN(value, "=", 2)[0] == name {
+			return container.Env
+		}
+	}
+	return append([]string{fmt.Sprintf("%s=%s", name, level)}, container.Env...)
+}
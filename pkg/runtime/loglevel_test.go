@@ -0,0 +1,25 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLogLevelEnv(t *testing.T) {
+	container := model.Container{Env: []string{"FOO=bar"}}
+
+	assert.Equal(t, []string{"FOO=bar"}, withLogLevelEnv(model.PodSpec{}, container, "info"), "should not add without log level")
+	assert.Equal(t, []string{"LOG_LEVEL=debug", "FOO=bar"}, withLogLevelEnv(model.PodSpec{LogLevel: "debug"}, container, "info"))
+	assert.Equal(t, []string{"VERBOSITY=info", "FOO=bar"}, withLogLevelEnv(model.PodSpec{LogLevel: model.LogLevelNode, LogLevelEnv: "VERBOSITY"}, container, "info"))
+
+	container.LogLevel = "warn"
+	assert.Equal(t, []string{"LOG_LEVEL=warn", "FOO=bar"}, withLogLevelEnv(model.PodSpec{LogLevel: "debug"}, container, "info"), "container log level should override pod")
+}
+
+func TestWithLogLevelEnvKeepsExplicitEnv(t *testing.T) {
+	container := model.Container{Env: []string{"LOG_LEVEL=error"}}
+
+	assert.Equal(t, []string{"LOG_LEVEL=error"}, withLogLevelEnv(model.PodSpec{LogLevel: "debug"}, container, "info"))
+}
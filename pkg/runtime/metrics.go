@@ -0,0 +1,237 @@
+package runtime
+
+import (
+	"fmt"
+	"time"
+
+	tasks "github.com/containerd/containerd/api/services/tasks/v1"
+	"github.com/containerd/containerd/api/types"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/mapping"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// cgroupsV1MetricsTypeURL is the type of the metrics what containerd reports for tasks in cgroups v1
+	cgroupsV1MetricsTypeURL = "io.containerd.cgroups.v1.Metrics"
+	// cgroupsV2MetricsTypeURL is the type of the metrics what containerd reports for tasks in cgroups v2
+	cgroupsV2MetricsTypeURL = "io.containerd.cgroups.v2.Metrics"
+)
+
+// cgroupsMetrics contains the fields what eliot uses from the containerd cgroups v1 metrics
+// The containerd/cgroups package isn't vendored so the message gets decoded with the field numbers
+type cgroupsMetrics struct {
+	CPU     *cgroupsCPUStat       `protobuf:"bytes,3,opt,name=cpu"`
+	Memory  *cgroupsMemoryStat    `protobuf:"bytes,4,opt,name=memory"`
+	Network []*cgroupsNetworkStat `protobuf:"bytes,7,rep,name=network"`
+}
+
+func (m *cgroupsMetrics) Reset()         { *m = cgroupsMetrics{} }
+func (m *cgroupsMetrics) String() string { return proto.CompactTextString(m) }
+func (*cgroupsMetrics) ProtoMessage()    {}
+
+type cgroupsCPUStat struct {
+	Usage *cgroupsCPUUsage `protobuf:"bytes,1,opt,name=usage"`
+}
+
+func (m *cgroupsCPUStat) Reset()         { *m = cgroupsCPUStat{} }
+func (m *cgroupsCPUStat) String() string { return proto.CompactTextString(m) }
+func (*cgroupsCPUStat) ProtoMessage()    {}
+
+type cgroupsCPUUsage struct {
+	// Total CPU time in nanoseconds
+	Total uint64 `protobuf:"varint,1,opt,name=total,proto3"`
+}
+
+func (m *cgroupsCPUUsage) Reset()         { *m = cgroupsCPUUsage{} }
+func (m *cgroupsCPUUsage) String() string { return proto.CompactTextString(m) }
+func (*cgroupsCPUUsage) ProtoMessage()    {}
+
+type cgroupsMemoryStat struct {
+	Usage *cgroupsMemoryEntry `protobuf:"bytes,33,opt,name=usage"`
+}
+
+func (m *cgroupsMemoryStat) Reset()         { *m = cgroupsMemoryStat{} }
+func (m *cgroupsMemoryStat) String() string { return proto.CompactTextString(m) }
+func (*cgroupsMemoryStat) ProtoMessage()    {}
+
+type cgroupsMemoryEntry struct {
+	Limit uint64 `protobuf:"varint,1,opt,name=limit,proto3"`
+	Usage uint64 `protobuf:"varint,2,opt,name=usage,proto3"`
+}
+
+func (m *cgroupsMemoryEntry) Reset()         { *m = cgroupsMemoryEntry{} }
+func (m *cgroupsMemoryEntry) String() string { return proto.CompactTextString(m) }
+func (*cgroupsMemoryEntry) ProtoMessage()    {}
+
+// cgroupsNetworkStat is the network interface counters, reported only by the runtimes what support it
+type cgroupsNetworkStat struct {
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3"`
+	RxBytes uint64 `protobuf:"varint,2,opt,name=rx_bytes,proto3"`
+	TxBytes uint64 `protobuf:"varint,6,opt,name=tx_bytes,proto3"`
+}
+
+func (m *cgroupsNetworkStat) Reset()         { *m = cgroupsNetworkStat{} }
+func (m *cgroupsNetworkStat) String() string { return proto.CompactTextString(m) }
+func (*cgroupsNetworkStat) ProtoMessage()    {}
+
+// cgroupsV2Metrics contains the fields what eliot uses from the containerd cgroups v2 metrics
+// The cgroups v2 metrics don't have network counters
+type cgroupsV2Metrics struct {
+	CPU    *cgroupsV2CPUStat    `protobuf:"bytes,2,opt,name=cpu"`
+	Memory *cgroupsV2MemoryStat `protobuf:"bytes,4,opt,name=memory"`
+}
+
+func (m *cgroupsV2Metrics) Reset()         { *m = cgroupsV2Metrics{} }
+func (m *cgroupsV2Metrics) String() string { return proto.CompactTextString(m) }
+func (*cgroupsV2Metrics) ProtoMessage()    {}
+
+type cgroupsV2CPUStat struct {
+	// Total CPU time in microseconds
+	UsageUsec uint64 `protobuf:"varint,1,opt,name=usage_usec,proto3"`
+}
+
+func (m *cgroupsV2CPUStat) Reset()         { *m = cgroupsV2CPUStat{} }
+func (m *cgroupsV2CPUStat) String() string { return proto.CompactTextString(m) }
+func (*cgroupsV2CPUStat) ProtoMessage()    {}
+
+type cgroupsV2MemoryStat struct {
+	Usage      uint64 `protobuf:"varint,32,opt,name=usage,proto3"`
+	UsageLimit uint64 `protobuf:"varint,33,opt,name=usage_limit,proto3"`
+}
+
+func (m *cgroupsV2MemoryStat) Reset()         { *m = cgroupsV2MemoryStat{} }
+func (m *cgroupsV2MemoryStat) String() string { return proto.CompactTextString(m) }
+func (*cgroupsV2MemoryStat) ProtoMessage()    {}
+
+// GetContainerMetrics returns CPU and memory usage of all running containers in the namespace
+// with single metrics request to containerd
+func (c *ContainerdClient) GetContainerMetrics(namespace string) (result []model.ContainerMetrics, err error) {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return result, err
+	}
+
+	// The task metrics filter doesn't support the container labels, so resolve the managed containers first
+	containers, err := client.Containers(ctx, mapping.ContainerFilter())
+	if err != nil {
+		return result, errors.Wrapf(err, "Error while listing containers in namespace [%s]", namespace)
+	}
+	if len(containers) == 0 {
+		return result, nil
+	}
+	filters := []string{}
+	for _, container := range containers {
+		filters = append(filters, fmt.Sprintf("id==%q", container.ID()))
+	}
+
+	resp, err := client.TaskService().Metrics(ctx, &tasks.MetricsRequest{
+		Filters: filters,
+	})
+	if err != nil {
+		return result, errors.Wrapf(err, "Error while fetching container metrics in namespace [%s]", namespace)
+	}
+
+	for _, metric := range resp.Metrics {
+		metrics, err := mapMetric(metric)
+		if err != nil {
+			log.Debugf("Skip container [%s] metrics: %s", metric.ID, err)
+			continue
+		}
+		result = append(result, metrics)
+	}
+	return result, nil
+}
+
+// GetContainerMetric returns CPU, memory and network usage of the running container
+func (c *ContainerdClient) GetContainerMetric(namespace, containerID string) (result model.ContainerMetrics, err error) {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return result, err
+	}
+
+	// Only the container store supports the label filter, the task metrics get filtered by the id
+	managed, err := client.Containers(ctx, fmt.Sprintf("id==%q,%s", containerID, mapping.ContainerFilter()))
+	if err != nil {
+		return result, errors.Wrapf(err, "Error while resolving container [%s] in namespace [%s]", containerID, namespace)
+	}
+	if len(managed) == 0 {
+		return result, ErrWithMessagef(ErrNotFound, "Container [%s] not found in namespace [%s]", containerID, namespace)
+	}
+
+	resp, err := client.TaskService().Metrics(ctx, &tasks.MetricsRequest{
+		Filters: []string{fmt.Sprintf("id==%q", containerID)},
+	})
+	if err != nil {
+		return result, errors.Wrapf(err, "Error while fetching container [%s] metrics in namespace [%s]", containerID, namespace)
+	}
+	if len(resp.Metrics) == 0 {
+		return result, ErrWithMessagef(ErrNotFound, "Container [%s] is not running in namespace [%s]", containerID, namespace)
+	}
+	return mapMetric(resp.Metrics[0])
+}
+
+func mapMetric(metric *types.Metric) (result model.ContainerMetrics, err error) {
+	if metric.Data == nil {
+		return result, ErrWithMessagef(ErrNotSupported, "Metrics doesn't have data")
+	}
+	switch metric.Data.TypeUrl {
+	case cgroupsV1MetricsTypeURL:
+		return mapCgroupsV1Metric(metric)
+	case cgroupsV2MetricsTypeURL:
+		return mapCgroupsV2Metric(metric)
+	}
+	return result, ErrWithMessagef(ErrNotSupported, "Metrics type [%s] is not supported", metric.Data.TypeUrl)
+}
+
+func mapCgroupsV1Metric(metric *types.Metric) (result model.ContainerMetrics, err error) {
+	data := &cgroupsMetrics{}
+	if err := proto.Unmarshal(metric.Data.Value, data); err != nil {
+		return result, errors.Wrap(err, "Failed to decode cgroups metrics")
+	}
+
+	result.ContainerID = metric.ID
+	result.Timestamp = metric.Timestamp
+	if data.CPU != nil && data.CPU.Usage != nil {
+		result.CPUUsage = data.CPU.Usage.Total
+	}
+	if data.Memory != nil && data.Memory.Usage != nil {
+		result.MemoryUsage = data.Memory.Usage.Usage
+		result.MemoryLimit = data.Memory.Usage.Limit
+	}
+	for _, network := range data.Network {
+		result.NetworkAvailable = true
+		result.NetworkRxBytes += network.RxBytes
+		result.NetworkTxBytes += network.TxBytes
+	}
+	return result, nil
+}
+
+// mapCgroupsV2Metric maps the cgroups v2 metrics to the same units what cgroups v1 reports
+func mapCgroupsV2Metric(metric *types.Metric) (result model.ContainerMetrics, err error) {
+	data := &cgroupsV2Metrics{}
+	if err := proto.Unmarshal(metric.Data.Value, data); err != nil {
+		return result, errors.Wrap(err, "Failed to decode cgroups v2 metrics")
+	}
+
+	result.ContainerID = metric.ID
+	result.Timestamp = metric.Timestamp
+	if data.CPU != nil {
+		result.CPUUsage = data.CPU.UsageUsec * uint64(time.Microsecond)
+	}
+	if data.Memory != nil {
+		result.MemoryUsage = data.Memory.Usage
+		result.MemoryLimit = data.Memory.UsageLimit
+	}
+	return result, nil
+}
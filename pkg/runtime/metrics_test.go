@@ -0,0 +1,63 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/containerd/containerd/api/types"
+	"github.com/gogo/protobuf/proto"
+	prototypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapMetric(t *testing.T) {
+	data, err := proto.Marshal(&cgroupsMetrics{
+		CPU:    &cgroupsCPUStat{Usage: &cgroupsCPUUsage{Total: 1500}},
+		Memory: &cgroupsMemoryStat{Usage: &cgroupsMemoryEntry{Usage: 4096, Limit: 8192}},
+		Network: []*cgroupsNetworkStat{
+			{Name: "eth0", RxBytes: 100, TxBytes: 10},
+			{Name: "eth1", RxBytes: 200, TxBytes: 20},
+		},
+	})
+	assert.NoError(t, err)
+
+	result, err := mapMetric(&types.Metric{
+		ID:   "foo",
+		Data: &prototypes.Any{TypeUrl: cgroupsV1MetricsTypeURL, Value: data},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", result.ContainerID)
+	assert.Equal(t, uint64(1500), result.CPUUsage)
+	assert.Equal(t, uint64(4096), result.MemoryUsage)
+	assert.Equal(t, uint64(8192), result.MemoryLimit)
+	assert.True(t, result.NetworkAvailable)
+	assert.Equal(t, uint64(300), result.NetworkRxBytes, "should sum up all interfaces")
+	assert.Equal(t, uint64(30), result.NetworkTxBytes)
+}
+
+func TestMapCgroupsV2Metric(t *testing.T) {
+	data, err := proto.Marshal(&cgroupsV2Metrics{
+		CPU:    &cgroupsV2CPUStat{UsageUsec: 1500},
+		Memory: &cgroupsV2MemoryStat{Usage: 4096, UsageLimit: 8192},
+	})
+	assert.NoError(t, err)
+
+	result, err := mapMetric(&types.Metric{
+		ID:   "foo",
+		Data: &prototypes.Any{TypeUrl: cgroupsV2MetricsTypeURL, Value: data},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", result.ContainerID)
+	assert.Equal(t, uint64(1500000), result.CPUUsage, "should convert the CPU usage to nanoseconds like in cgroups v1")
+	assert.Equal(t, uint64(4096), result.MemoryUsage)
+	assert.Equal(t, uint64(8192), result.MemoryLimit)
+	assert.False(t, result.NetworkAvailable)
+}
+
+func TestMapMetricRejectsUnknownType(t *testing.T) {
+	_, err := mapMetric(&types.Metric{
+		ID:   "foo",
+		Data: &prototypes.Any{TypeUrl: "io.containerd.unknown.Metrics"},
+	})
+	assert.Equal(t, ErrNotSupported, errors.Cause(err))
+}
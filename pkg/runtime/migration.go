@@ -0,0 +1,209 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/mapping"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// MigrateSnapshotter changes the snapshotter and unpacks the images in all namespaces to it, if recreate
+// is true also the containers get recreated with the new snapshotter
+// The report gets called after each image and container. The migration is resumable, the images and containers
+// what are already in the snapshotter get skipped so running the migration again continues from the failures
+// The recreated container gets fresh filesystem from the image and its task gets stopped so that the
+// Lifecycle controller starts it again
+func (c *ContainerdClient) MigrateSnapshotter(snapshotter string, recreate bool, report func(model.SnapshotterMigrationStep)) error {
+	if err := c.SetSnapshotter(snapshotter); err != nil {
+		return err
+	}
+
+	namespaces, err := c.GetNamespaces()
+	if err != nil {
+		return errors.Wrap(err, "Failed to list namespaces")
+	}
+
+	failures := []string{}
+	for _, namespace := range namespaces {
+		if err := c.migrateNamespace(namespace, snapshotter, recreate, report); err != nil {
+			failures = append(failures, fmt.Sprintf("namespace [%s]: %s", namespace, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("Failed to migrate to snapshotter [%s], %d error(s): %s", snapshotter, len(failures), strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+func (c *ContainerdClient) migrateNamespace(namespace, snapshotter string, recreate bool, report func(model.SnapshotterMigrationStep)) error {
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return err
+	}
+
+	imageList, err := client.ListImages(ctx)
+	if err != nil {
+		return errors.Wrap(err, "Failed to list images")
+	}
+	containerList := []containerd.Container{}
+	if recreate {
+		if containerList, err = client.Containers(ctx, mapping.ContainerFilter()); err != nil {
+			return errors.Wrap(err, "Failed to list containers")
+		}
+	}
+
+	var (
+		total    = len(imageList) + len(containerList)
+		current  = 0
+		failures = []string{}
+	)
+	step := func(kind, name string, skipped bool, err error) {
+		current++
+		result := model.SnapshotterMigrationStep{
+			Namespace: namespace,
+			Kind:      kind,
+			Name:      name,
+			Current:   current,
+			Total:     total,
+			Skipped:   skipped,
+		}
+		if err != nil {
+			result.Error = err.Error()
+			failures = append(failures, fmt.Sprintf("%s [%s]: %s", kind, name, err))
+		}
+		report(result)
+	}
+
+	for _, image := range imageList {
+		skipped, err := c.migrateImage(image, snapshotter)
+		step(model.MigrationImage, image.Name(), skipped, err)
+	}
+
+	for _, container := range containerList {
+		skipped, err := c.recreateContainer(namespace, container, snapshotter)
+		step(model.MigrationContainer, container.ID(), skipped, err)
+	}
+
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, ", "))
+	}
+	return nil
+}
+
+// migrateImage unpacks the image to the snapshotter, returns true if the image was already unpacked
+func (c *ContainerdClient) migrateImage(image containerd.Image, snapshotter string) (bool, error) {
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	unpacked, err := image.IsUnpacked(ctx, snapshotter)
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to check is image [%s] unpacked", image.Name())
+	}
+	if unpacked {
+		return true, nil
+	}
+	return false, c.unpackImageTo(image, snapshotter, "")
+}
+
+// recreateContainer replaces the container with the same container what uses the snapshotter, containerd doesn't
+// allow changing the snapshotter of existing container, returns true if the container already uses the snapshotter
+// If the replace fails, the original container gets restored so that it keeps working with the old snapshotter
+func (c *ContainerdClient) recreateContainer(namespace string, container containerd.Container, snapshotter string) (bool, error) {
+	info, image, err := c.prepareRecreate(container, snapshotter)
+	if err != nil || info.Snapshotter == snapshotter {
+		return err == nil, err
+	}
+
+	if err := c.terminateTasks(namespace, []string{info.ID}, defaultStopGracePeriod); err != nil {
+		return false, errors.Wrap(err, "Failed to stop the container")
+	}
+
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return false, err
+	}
+
+	task, err := container.Task(ctx, nil)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return false, errors.Wrap(err, "Failed to fetch the container task")
+	}
+	if task != nil {
+		if _, err := task.Delete(ctx, containerd.WithProcessKill); err != nil && !errdefs.IsNotFound(err) {
+			return false, errors.Wrap(err, "Failed to delete the container task")
+		}
+	}
+
+	_, err = client.NewContainer(ctx, info.ID,
+		withContainerRecord(info),
+		containerd.WithSnapshotter(snapshotter),
+		containerd.WithNewSnapshot(info.ID, image),
+		withoutExistingRecord(info.ID),
+	)
+	if err != nil {
+		if _, getErr := client.ContainerService().Get(ctx, info.ID); errdefs.IsNotFound(getErr) {
+			if _, restoreErr := client.ContainerService().Create(ctx, info); restoreErr != nil {
+				log.Errorf("Failed to restore container [%s] after failed snapshotter migration: %s", info.ID, restoreErr)
+			}
+		}
+		return false, errors.Wrapf(err, "Failed to recreate the container with snapshotter [%s]", snapshotter)
+	}
+
+	if err := client.SnapshotService(info.Snapshotter).Remove(ctx, info.SnapshotKey); err != nil && !errdefs.IsNotFound(err) {
+		log.Warnf("Failed to remove container [%s] snapshot from the old snapshotter [%s]: %s", info.ID, info.Snapshotter, err)
+	}
+	log.Infof("Recreated container [%s] with snapshotter [%s]", info.ID, snapshotter)
+	return false, nil
+}
+
+// prepareRecreate returns the container info and image, and unpacks the image to the snapshotter
+// before the container gets stopped so that unpack failure doesn't interrupt the container
+func (c *ContainerdClient) prepareRecreate(container containerd.Container, snapshotter string) (info containers.Container, image containerd.Image, err error) {
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	info, err = container.Info(ctx)
+	if err != nil {
+		return info, nil, errors.Wrap(err, "Error while fetching container info")
+	}
+	if info.Snapshotter == snapshotter {
+		return info, nil, nil
+	}
+
+	image, err = container.Image(ctx)
+	if err != nil {
+		return info, nil, errors.Wrapf(err, "Failed to resolve container image [%s]", info.Image)
+	}
+	return info, image, c.ensureUnpacked(ctx, image, snapshotter)
+}
+
+// withContainerRecord copies the existing container record without the snapshot
+func withContainerRecord(info containers.Container) containerd.NewContainerOpts {
+	return func(_ context.Context, _ *containerd.Client, c *containers.Container) error {
+		*c = info
+		c.Snapshotter = ""
+		c.SnapshotKey = ""
+		return nil
+	}
+}
+
+// withoutExistingRecord deletes the existing container record right before the new one gets created,
+// the existing snapshot is kept so that the record can be restored if the create fails
+func withoutExistingRecord(id string) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, _ *containers.Container) error {
+		return client.ContainerService().Delete(ctx, id)
+	}
+}
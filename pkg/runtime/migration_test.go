@@ -0,0 +1,25 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithContainerRecordCopiesWithoutSnapshot(t *testing.T) {
+	info := containers.Container{
+		ID:          "foo",
+		Image:       "docker.io/library/alpine:latest",
+		Labels:      map[string]string{"io.eliot.pod.name": "foo"},
+		Snapshotter: "overlayfs",
+		SnapshotKey: "foo",
+	}
+	result := containers.Container{ID: "foo"}
+
+	assert.NoError(t, withContainerRecord(info)(nil, nil, &result))
+	assert.Equal(t, info.Image, result.Image)
+	assert.Equal(t, info.Labels, result.Labels)
+	assert.Empty(t, result.Snapshotter, "should leave the snapshotter to be set for the new snapshot")
+	assert.Empty(t, result.SnapshotKey)
+}
@@ -0,0 +1,236 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/ernoaapa/eliot/pkg/model"
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// MoveContainer moves the container to another namespace, e.g. to fix a container created in wrong namespace
+// without losing the data in the container filesystem. Containerd doesn't allow moving the records between
+// namespaces, so the container gets recreated in the target namespace with the same spec and labels, the image
+// gets copied to the target namespace and the container filesystem changes get applied to the new snapshot.
+// This is best-effort operation: the container task must be stopped, running container returns ErrInvalid.
+// The host files what eliotd created for the container (e.g. resolv.conf, secret files) stay under the original
+// namespace and the moved container keeps using them, and a manifest what still declares the pod in the original
+// namespace gets the container recreated there. The moved container must fit to the target namespace quota.
+// The original container gets removed only after the moved one is created, so failed move leaves it untouched
+func (c *ContainerdClient) MoveContainer(namespace, id, targetNamespace string) (status model.ContainerStatus, err error) {
+	namespace, targetNamespace = c.resolveNamespace(namespace), c.resolveNamespace(targetNamespace)
+	if namespace == targetNamespace {
+		return status, ErrWithMessagef(ErrInvalid, "Container [%s] is already in namespace [%s]", id, namespace)
+	}
+
+	// Diff and copying big filesystem can take as long as unpacking it
+	ctx, cancel := c.getContextWithTimeout(c.unpackTimeout)
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return status, err
+	}
+
+	var (
+		sourceCtx = namespaces.WithNamespace(ctx, namespace)
+		targetCtx = namespaces.WithNamespace(ctx, targetNamespace)
+	)
+
+	container, err := client.LoadContainer(sourceCtx, id)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return status, ErrWithMessagef(ErrNotFound, "Container [%s] not found in namespace [%s]", id, namespace)
+		}
+		return status, errors.Wrapf(err, "Failed to load container [%s], cannot move it", id)
+	}
+
+	task, err := stoppedTask(sourceCtx, container)
+	if err != nil {
+		return status, err
+	}
+
+	if _, err := client.ContainerService().Get(targetCtx, id); err == nil {
+		return status, ErrWithMessagef(ErrAlreadyExists, "Container with id [%s] already exist in namespace [%s]", id, targetNamespace)
+	} else if !errdefs.IsNotFound(err) {
+		return status, errors.Wrapf(err, "Failed to check container [%s] in namespace [%s]", id, targetNamespace)
+	}
+
+	info, err := container.Info(sourceCtx)
+	if err != nil {
+		return status, errors.Wrap(err, "Error while fetching container info")
+	}
+	if info.SnapshotKey == "" {
+		return status, ErrWithMessagef(ErrNotSupported, "Container [%s] doesn't have snapshot", id)
+	}
+
+	// Leases protect the content from garbage collection until the moved container references it
+	sourceCtx, sourceDone, err := client.WithLease(sourceCtx)
+	if err != nil {
+		return status, errors.Wrap(err, "Failed to create lease for the move")
+	}
+	defer sourceDone(sourceCtx)
+	targetCtx, targetDone, err := client.WithLease(targetCtx)
+	if err != nil {
+		return status, errors.Wrap(err, "Failed to create lease for the move")
+	}
+	defer targetDone(targetCtx)
+
+	layer, err := diffContainerSnapshot(sourceCtx, client, info)
+	if err != nil {
+		return status, errors.Wrapf(err, "Error while capturing container [%s] filesystem changes", id)
+	}
+
+	image, err := copyImage(sourceCtx, targetCtx, client, info.Image)
+	if err != nil {
+		return status, errors.Wrapf(err, "Failed to copy image [%s] to namespace [%s]", info.Image, targetNamespace)
+	}
+	if err := copyContent(sourceCtx, targetCtx, client.ContentStore(), layer); err != nil {
+		return status, errors.Wrapf(err, "Failed to copy container [%s] filesystem changes to namespace [%s]", id, targetNamespace)
+	}
+	if err := image.Unpack(targetCtx, info.Snapshotter); err != nil {
+		return status, errors.Wrapf(err, "Error while unpacking image [%s] in namespace [%s]", info.Image, targetNamespace)
+	}
+
+	c.containerLimitMu.Lock()
+	moved, err := client.NewContainer(targetCtx, id,
+		withContainerRecord(info),
+		containerd.WithSnapshotter(info.Snapshotter),
+		containerd.WithNewSnapshot(id, image),
+		withAppliedLayer(layer),
+	)
+	if err == nil {
+		// The original container gets removed, so the move doesn't change the node container count
+		limited := model.Container{Name: id, Resources: &model.Resources{MemoryLimit: getMemoryLimit(info)}}
+		err = c.withinLimits(targetCtx, client, moved, targetNamespace, limited, 1)
+	}
+	c.containerLimitMu.Unlock()
+	if err != nil {
+		// The snapshot stays if the layer apply fails
+		if err := client.SnapshotService(info.Snapshotter).Remove(targetCtx, id); err != nil && !errdefs.IsNotFound(err) {
+			log.Warnf("Failed to remove container [%s] snapshot from namespace [%s] after failed move: %s", id, targetNamespace, err)
+		}
+		return status, errors.Wrapf(err, "Failed to recreate container [%s] in namespace [%s]", id, targetNamespace)
+	}
+
+	if task != nil {
+		if _, err := task.Delete(sourceCtx); err != nil && !errdefs.IsNotFound(err) {
+			log.Warnf("Failed to delete moved container [%s] task from namespace [%s]: %s", id, namespace, err)
+		}
+	}
+	if err := container.Delete(sourceCtx, containerd.WithSnapshotCleanup); err != nil && !errdefs.IsNotFound(err) {
+		log.Warnf("Container [%s] got moved to namespace [%s] but failed to remove it from namespace [%s]: %s", id, targetNamespace, namespace, err)
+	}
+	log.Infof("Moved container [%s] from namespace [%s] to [%s]", id, namespace, targetNamespace)
+
+	movedInfo, err := moved.Info(targetCtx)
+	if err != nil {
+		return status, errors.Wrap(err, "Error while fetching container info")
+	}
+	return c.mapContainerStatus(targetNamespace, movedInfo, resolveContainerStatus(targetCtx, moved)), nil
+}
+
+// stoppedTask returns the container task, nil if the container doesn't have task
+// Returns ErrInvalid if the task is not stopped
+func stoppedTask(ctx context.Context, container containerd.Container) (containerd.Task, error) {
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Failed to fetch container [%s] task", container.ID())
+	}
+	status, err := task.Status(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to resolve container [%s] task status", container.ID())
+	}
+	if status.Status != containerd.Stopped {
+		return nil, ErrWithMessagef(ErrInvalid, "Container [%s] is %s, stop it before moving", container.ID(), status.Status)
+	}
+	return task, nil
+}
+
+// copyImage copies the image record and content to the target namespace, the content store is shared but
+// the content and the image records are visible only in the namespace where they were created
+// Existing image in the target namespace is used as is if it's the same image
+func copyImage(sourceCtx, targetCtx context.Context, client *containerd.Client, name string) (containerd.Image, error) {
+	source, err := client.ImageService().Get(sourceCtx, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to resolve image [%s]", name)
+	}
+
+	if err := images.Walk(sourceCtx, images.HandlerFunc(func(ctx context.Context, desc imagespecs.Descriptor) ([]imagespecs.Descriptor, error) {
+		if err := copyContent(sourceCtx, targetCtx, client.ContentStore(), desc); err != nil {
+			if errdefs.IsNotFound(err) {
+				// The other platforms of multi-platform image don't get pulled
+				return nil, nil
+			}
+			return nil, err
+		}
+		return images.Children(sourceCtx, client.ContentStore(), desc)
+	}), source.Target); err != nil {
+		return nil, err
+	}
+
+	target, err := client.ImageService().Create(targetCtx, images.Image{
+		Name:   source.Name,
+		Target: source.Target,
+		Labels: source.Labels,
+	})
+	if err != nil {
+		if !errdefs.IsAlreadyExists(err) {
+			return nil, errors.Wrapf(err, "Error while creating image [%s]", name)
+		}
+		if target, err = client.ImageService().Get(targetCtx, name); err != nil {
+			return nil, errors.Wrapf(err, "Failed to resolve image [%s]", name)
+		}
+		if target.Target.Digest != source.Target.Digest {
+			return nil, ErrWithMessagef(ErrAlreadyExists, "Image [%s] in the target namespace is different image (%s), the container needs %s", name, target.Target.Digest, source.Target.Digest)
+		}
+	}
+	return containerd.NewImage(client, target), nil
+}
+
+// copyContent copies the blob with the labels to the target namespace if it doesn't exist there already
+func copyContent(sourceCtx, targetCtx context.Context, store content.Store, desc imagespecs.Descriptor) error {
+	if _, err := store.Info(targetCtx, desc.Digest); err == nil {
+		return nil
+	}
+	info, err := store.Info(sourceCtx, desc.Digest)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to resolve content [%s]", desc.Digest)
+	}
+	reader, err := store.ReaderAt(sourceCtx, desc.Digest)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to read content [%s]", desc.Digest)
+	}
+	defer reader.Close()
+
+	ref := fmt.Sprintf("move-%s", desc.Digest)
+	if err := content.WriteBlob(targetCtx, store, ref, content.NewReader(reader), info.Size, desc.Digest, content.WithLabels(info.Labels)); err != nil {
+		return errors.Wrapf(err, "Failed to write content [%s]", desc.Digest)
+	}
+	return nil
+}
+
+// withAppliedLayer applies the layer to the container snapshot what WithNewSnapshot prepared
+func withAppliedLayer(layer imagespecs.Descriptor) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		mounts, err := client.SnapshotService(c.Snapshotter).Mounts(ctx, c.SnapshotKey)
+		if err != nil {
+			return errors.Wrap(err, "Failed to resolve the snapshot mounts")
+		}
+		if _, err := client.DiffService().Apply(ctx, layer, mounts); err != nil {
+			return errors.Wrap(err, "Failed to apply the container filesystem changes")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,103 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/containerd/containerd/images"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxConcurrentPodDeletes limits how many pods DeleteNamespaceContainers stops in parallel
+const maxConcurrentPodDeletes = 4
+
+// DeleteNamespaceContainers stops and deletes all containers in the namespace, the pods get stopped
+// in parallel with their stop grace period
+// If removeNamespace is true, also the images and the namespace itself get removed
+// Returns the statuses of the deleted containers and single error what lists all the failures
+func (c *ContainerdClient) DeleteNamespaceContainers(namespace string, removeNamespace bool) ([]model.ContainerStatus, error) {
+	namespace = c.resolveNamespace(namespace)
+	pods, err := c.GetPods(namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list pods in namespace [%s]", namespace)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		sem      = make(chan struct{}, maxConcurrentPodDeletes)
+		statuses = []model.ContainerStatus{}
+		failures = []string{}
+	)
+	for _, pod := range pods {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(pod model.Pod) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			ids := []string{}
+			for _, status := range pod.Status.ContainerStatuses {
+				ids = append(ids, status.ContainerID)
+			}
+			stopped, err := c.StopContainers(namespace, ids, pod.Spec.StopGracePeriod)
+
+			mu.Lock()
+			defer mu.Unlock()
+			statuses = append(statuses, stopped...)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("pod [%s]: %s", pod.Metadata.Name, err))
+			}
+		}(pod)
+	}
+	wg.Wait()
+
+	if removeNamespace && len(failures) == 0 {
+		if err := c.removeNamespace(namespace); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		return statuses, fmt.Errorf("Failed to delete namespace [%s] containers, %d error(s): %s", namespace, len(failures), strings.Join(failures, "; "))
+	}
+	return statuses, nil
+}
+
+// removeNamespace removes the pull leases, the images and then the namespace, containerd refuses to remove
+// non-empty namespace and the leases would keep the image content in it
+func (c *ContainerdClient) removeNamespace(namespace string) error {
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := deleteAllPullLeases(ctx, client); err != nil {
+		return errors.Wrapf(err, "Failed to delete pull leases from namespace [%s]", namespace)
+	}
+
+	imageList, err := client.ImageService().List(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to list images in namespace [%s]", namespace)
+	}
+	for _, image := range imageList {
+		// Synchronous delete garbage collects the content so the namespace is empty afterwards
+		if err := client.ImageService().Delete(ctx, image.Name, images.SynchronousDelete()); err != nil {
+			return errors.Wrapf(err, "Failed to delete image [%s] from namespace [%s]", image.Name, namespace)
+		}
+	}
+
+	if err := client.NamespaceService().Delete(ctx, namespace); err != nil {
+		return errors.Wrapf(err, "Failed to remove namespace [%s]", namespace)
+	}
+	log.Infof("Removed namespace [%s]", namespace)
+	return nil
+}
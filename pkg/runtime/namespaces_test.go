@@ -0,0 +1,92 @@
+package runtime
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	imagesapi "github.com/containerd/containerd/api/services/images/v1"
+	leasesapi "github.com/containerd/containerd/api/services/leases/v1"
+	namespacesapi "github.com/containerd/containerd/api/services/namespaces/v1"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/mapping"
+	"github.com/gogo/protobuf/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// fakeNamespaceServices records the order of the containerd calls what remove a namespace
+type fakeNamespaceServices struct {
+	mutex  sync.Mutex
+	leases []*leasesapi.Lease
+	calls  []string
+}
+
+func (f *fakeNamespaceServices) record(call string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.calls = append(f.calls, call)
+}
+
+func (f *fakeNamespaceServices) register(server *grpc.Server) {
+	leasesapi.RegisterLeasesServer(server, leaseService{fake: f})
+	imagesapi.RegisterImagesServer(server, imageService{fake: f})
+	namespacesapi.RegisterNamespacesServer(server, namespaceService{fake: f})
+}
+
+type leaseService struct {
+	leasesapi.LeasesServer
+	fake *fakeNamespaceServices
+}
+
+func (s leaseService) List(context.Context, *leasesapi.ListRequest) (*leasesapi.ListResponse, error) {
+	return &leasesapi.ListResponse{Leases: s.fake.leases}, nil
+}
+
+func (s leaseService) Delete(ctx context.Context, req *leasesapi.DeleteRequest) (*types.Empty, error) {
+	s.fake.record("lease " + req.ID)
+	return &types.Empty{}, nil
+}
+
+type imageService struct {
+	imagesapi.ImagesServer
+	fake *fakeNamespaceServices
+}
+
+func (s imageService) List(context.Context, *imagesapi.ListImagesRequest) (*imagesapi.ListImagesResponse, error) {
+	return &imagesapi.ListImagesResponse{Images: []imagesapi.Image{{Name: "docker.io/library/busybox:latest"}}}, nil
+}
+
+func (s imageService) Delete(ctx context.Context, req *imagesapi.DeleteImageRequest) (*types.Empty, error) {
+	s.fake.record("image " + req.Name)
+	return &types.Empty{}, nil
+}
+
+type namespaceService struct {
+	namespacesapi.NamespacesServer
+	fake *fakeNamespaceServices
+}
+
+func (s namespaceService) Delete(ctx context.Context, req *namespacesapi.DeleteNamespaceRequest) (*types.Empty, error) {
+	s.fake.record("namespace " + req.Name)
+	return &types.Empty{}, nil
+}
+
+func TestRemoveNamespaceDeletesPullLeasesFirst(t *testing.T) {
+	services := &fakeNamespaceServices{leases: []*leasesapi.Lease{
+		{ID: "pull", Labels: map[string]string{mapping.LabelKey(pullLeaseExpireLabel): time.Now().Add(time.Hour).Format(time.RFC3339)}},
+		{ID: "other-tool"},
+	}}
+	address, stop := startFakeContainerd(t, services.register)
+	defer stop()
+
+	client := NewContainerdClient(context.Background(), time.Second, "overlayfs", address, "hostname")
+	defer client.Close()
+
+	assert.NoError(t, client.removeNamespace("tenant-a"))
+	assert.Equal(t, []string{
+		"lease pull",
+		"image docker.io/library/busybox:latest",
+		"namespace tenant-a",
+	}, services.calls, "should delete the not expired pull lease before the images and keep the other leases")
+}
@@ -0,0 +1,116 @@
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/pkg/errors"
+)
+
+// procRoot is where the host proc filesystem is mounted, eliotd runs in the host pid namespace
+var procRoot = "/proc"
+
+// ListProcesses returns the processes running in the container task, like 'docker top'
+// The pids are host pids, the command lines get resolved from the host /proc
+func (c *ContainerdClient) ListProcesses(namespace, id string) (result []model.Process, err error) {
+	namespace = c.resolveNamespace(namespace)
+	ctx, cancel := c.getContext()
+	defer cancel()
+	ctx = namespaces.WithNamespace(ctx, namespace)
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		return result, err
+	}
+
+	container, err := client.LoadContainer(ctx, id)
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to load container [%s], cannot list its processes", id)
+	}
+
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return result, ErrWithMessagef(ErrNotFound, "Container [%s] is not running", id)
+		}
+		return result, errors.Wrapf(err, "Unable to get task in container [%s], cannot list its processes", id)
+	}
+
+	pids, err := task.Pids(ctx)
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to list container [%s] pids", id)
+	}
+
+	for _, info := range pids {
+		process, err := readProcess(procRoot, info.Pid)
+		if err != nil {
+			if os.IsNotExist(errors.Cause(err)) {
+				// Process exited after listing the pids
+				continue
+			}
+			return result, err
+		}
+		result = append(result, process)
+	}
+	return result, nil
+}
+
+// readProcess resolves the process parent, state and command line from the proc filesystem
+func readProcess(root string, pid uint32) (result model.Process, err error) {
+	dir := filepath.Join(root, strconv.FormatUint(uint64(pid), 10))
+
+	stat, err := ioutil.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to read process [%d] stat", pid)
+	}
+
+	// Format is 'pid (comm) state ppid ...', comm can contain spaces and parentheses
+	start := bytes.IndexByte(stat, '(')
+	end := bytes.LastIndexByte(stat, ')')
+	if start < 0 || end < start {
+		return result, fmt.Errorf("Invalid process [%d] stat format", pid)
+	}
+	comm := string(stat[start+1 : end])
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 2 {
+		return result, fmt.Errorf("Invalid process [%d] stat format", pid)
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return result, errors.Wrapf(err, "Invalid process [%d] parent pid", pid)
+	}
+
+	cmdline, err := ioutil.ReadFile(filepath.Join(dir, "cmdline"))
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to read process [%d] command line", pid)
+	}
+
+	result = model.Process{
+		PID:   int(pid),
+		PPID:  ppid,
+		State: fields[0],
+		Args:  splitCmdline(cmdline),
+	}
+	if len(result.Args) == 0 {
+		// Zombies and kernel threads don't have command line, show the name like ps does
+		result.Args = []string{fmt.Sprintf("[%s]", comm)}
+	}
+	return result, nil
+}
+
+func splitCmdline(cmdline []byte) (result []string) {
+	for _, arg := range bytes.Split(bytes.TrimRight(cmdline, "\x00"), []byte{0}) {
+		if len(arg) > 0 {
+			result = append(result, string(arg))
+		}
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+package runtime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestProcess(t *testing.T, root, pid, stat, cmdline string) {
+	dir := filepath.Join(root, pid)
+	assert.NoError(t, os.MkdirAll(dir, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cmdline"), []byte(cmdline), 0644))
+}
+
+func TestReadProcess(t *testing.T) {
+	root, err := ioutil.TempDir("", "proc")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	writeTestProcess(t, root, "123", "123 (my (worker)) S 100 123 123 0 -1", "/bin/worker\x00--verbose\x00")
+	writeTestProcess(t, root, "124", "124 (sh) Z 123 123 123 0 -1", "")
+
+	process, err := readProcess(root, 123)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Process{PID: 123, PPID: 100, State: "S", Args: []string{"/bin/worker", "--verbose"}}, process)
+
+	zombie, err := readProcess(root, 124)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"[sh]"}, zombie.Args, "should use the process name when there's no command line")
+
+	_, err = readProcess(root, 125)
+	assert.True(t, os.IsNotExist(errors.Cause(err)))
+}
@@ -0,0 +1,69 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// pullTracker tracks the in-flight image pulls by namespace and image ref so that they can be cancelled
+type pullTracker struct {
+	mu     sync.Mutex
+	nextID int
+	pulls  map[string]map[int]context.CancelFunc
+}
+
+func newPullTracker() *pullTracker {
+	return &pullTracker{
+		pulls: map[string]map[int]context.CancelFunc{},
+	}
+}
+
+// add registers the pull cancel function, the returned function must be called when the pull ends
+func (t *pullTracker) add(namespace, ref string, cancel context.CancelFunc) (remove func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := pullKey(namespace, ref)
+	id := t.nextID
+	t.nextID++
+	if t.pulls[key] == nil {
+		t.pulls[key] = map[int]context.CancelFunc{}
+	}
+	t.pulls[key][id] = cancel
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.pulls[key], id)
+		if len(t.pulls[key]) == 0 {
+			delete(t.pulls, key)
+		}
+	}
+}
+
+// cancel cancels all the in-flight pulls of the image, returns false if there were none
+func (t *pullTracker) cancel(namespace, ref string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pulls, ok := t.pulls[pullKey(namespace, ref)]
+	for _, cancel := range pulls {
+		cancel()
+	}
+	return ok
+}
+
+func pullKey(namespace, ref string) string {
+	return fmt.Sprintf("%s/%s", namespace, ref)
+}
+
+// CancelPull cancels the in-flight pulls of the image in the namespace
+// The fetched content stays in the content store protected by the pull lease so that pulling again resumes
+func (c *ContainerdClient) CancelPull(namespace, ref string) error {
+	namespace = c.resolveNamespace(namespace)
+	if !c.pulls.cancel(namespace, ref) {
+		return ErrWithMessagef(ErrNotFound, "No pull of image [%s] in progress in namespace [%s]", ref, namespace)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullTrackerCancelsAllPullsOfImage(t *testing.T) {
+	tracker := newPullTracker()
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+	other, cancelOther := context.WithCancel(context.Background())
+	defer cancelOther()
+
+	tracker.add("default", "docker.io/library/alpine:latest", cancelFirst)
+	tracker.add("default", "docker.io/library/alpine:latest", cancelSecond)
+	tracker.add("other", "docker.io/library/alpine:latest", cancelOther)
+
+	assert.True(t, tracker.cancel("default", "docker.io/library/alpine:latest"))
+	assert.Error(t, first.Err())
+	assert.Error(t, second.Err())
+	assert.NoError(t, other.Err(), "should not cancel the pull in other namespace")
+}
+
+func TestPullTrackerRemove(t *testing.T) {
+	tracker := newPullTracker()
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	remove := tracker.add("default", "docker.io/library/alpine:latest", cancel)
+	remove()
+
+	assert.False(t, tracker.cancel("default", "docker.io/library/alpine:latest"), "should not find the ended pull")
+
+	client := &ContainerdClient{pulls: tracker}
+	assert.True(t, IsNotFound(client.CancelPull("default", "docker.io/library/alpine:latest")))
+}
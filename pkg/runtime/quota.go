@@ -0,0 +1,167 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/content"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/mapping"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+// namespaceUsage is the namespace resource usage what the quota limits
+type namespaceUsage struct {
+	containers int
+	memory     int64
+	imageSize  int64
+}
+
+// ParseNamespaceQuota parses the namespace and the quota, e.g. tenant-a:containers=10,memory=512MB,images=2GB
+func ParseNamespaceQuota(value string) (namespace string, quota model.NamespaceQuota, err error) {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return namespace, quota, errors.New("Quota must be namespace and comma separated key=value list")
+	}
+	namespace = parts[0]
+
+	for _, limit := range strings.Split(parts[1], ",") {
+		pair := strings.SplitN(limit, "=", 2)
+		if len(pair) != 2 {
+			return namespace, quota, fmt.Errorf("Invalid quota limit [%s], it must be key=value", limit)
+		}
+		switch pair[0] {
+		case "containers":
+			if quota.MaxContainers, err = strconv.Atoi(pair[1]); err != nil || quota.MaxContainers < 0 {
+				return namespace, quota, fmt.Errorf("Invalid container count [%s]", pair[1])
+			}
+		case "memory":
+			if quota.MaxMemory, err = parseQuotaSize(pair[1]); err != nil {
+				return namespace, quota, err
+			}
+		case "images":
+			if quota.MaxImageSize, err = parseQuotaSize(pair[1]); err != nil {
+				return namespace, quota, err
+			}
+		default:
+			return namespace, quota, fmt.Errorf("Unknown quota limit [%s], must be one of containers, memory or images", pair[0])
+		}
+	}
+	return namespace, quota, nil
+}
+
+func parseQuotaSize(value string) (int64, error) {
+	var size datasize.ByteSize
+	if err := size.UnmarshalText([]byte(value)); err != nil {
+		return 0, fmt.Errorf("Invalid size [%s], it must be size with unit, e.g. 512MB", value)
+	}
+	return int64(size.Bytes()), nil
+}
+
+// CheckNamespaceQuota returns ErrLimitExceeded if creating the pod containers would exceed the pod namespace quota
+// The released are the ids of the containers what get removed before the pod gets created, e.g. when recreating the pod
+func (c *ContainerdClient) CheckNamespaceQuota(pod model.Pod, released []string) error {
+	pod.Metadata.Namespace = c.resolveNamespace(pod.Metadata.Namespace)
+	quota, ok := c.quotas[pod.Metadata.Namespace]
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(pod.Metadata.Namespace)
+	if err != nil {
+		return err
+	}
+	usage, err := getNamespaceUsage(ctx, client, released...)
+	if err != nil {
+		return err
+	}
+	return checkNamespaceQuota(pod.Metadata.Namespace, quota, usage, pod.Spec.Containers)
+}
+
+// withinNamespaceQuota checks the namespace quota for the created container, the usage doesn't include it
+// so that the memory limit gets checked from the container model like before creating
+func (c *ContainerdClient) withinNamespaceQuota(ctx context.Context, client *containerd.Client, namespace, id string, container model.Container) error {
+	quota, ok := c.quotas[namespace]
+	if !ok {
+		return nil
+	}
+	usage, err := getNamespaceUsage(ctx, client, id)
+	if err != nil {
+		return err
+	}
+	return checkNamespaceQuota(namespace, quota, usage, []model.Container{container})
+}
+
+// checkNamespaceQuota returns ErrLimitExceeded with the exceeded limit if the new containers don't fit to the quota
+func checkNamespaceQuota(namespace string, quota model.NamespaceQuota, usage namespaceUsage, adding []model.Container) error {
+	if quota.MaxContainers > 0 && usage.containers+len(adding) > quota.MaxContainers {
+		return ErrWithMessagef(ErrLimitExceeded, "Namespace [%s] quota exceeded, cannot create %d container(s), the namespace has %d containers and the maximum is %d", namespace, len(adding), usage.containers, quota.MaxContainers)
+	}
+
+	if quota.MaxMemory > 0 {
+		memory := usage.memory
+		for _, container := range adding {
+			if container.Resources == nil || container.Resources.MemoryLimit == 0 {
+				return ErrWithMessagef(ErrLimitExceeded, "Namespace [%s] has memory quota, container [%s] must have memory limit", namespace, container.Name)
+			}
+			memory += container.Resources.MemoryLimit
+		}
+		if memory > quota.MaxMemory {
+			return ErrWithMessagef(ErrLimitExceeded, "Namespace [%s] quota exceeded, the container memory limits would be %s in total and the maximum is %s", namespace, formatSize(memory), formatSize(quota.MaxMemory))
+		}
+	}
+
+	if quota.MaxImageSize > 0 && usage.imageSize > quota.MaxImageSize {
+		return ErrWithMessagef(ErrLimitExceeded, "Namespace [%s] quota exceeded, the images use %s and the maximum is %s", namespace, formatSize(usage.imageSize), formatSize(quota.MaxImageSize))
+	}
+	return nil
+}
+
+// getNamespaceUsage resolves the usage in the context namespace, without the excluded containers
+func getNamespaceUsage(ctx context.Context, client *containerd.Client, excluded ...string) (usage namespaceUsage, err error) {
+	list, err := client.ContainerService().List(ctx, mapping.ContainerFilter())
+	if err != nil {
+		return usage, errors.Wrap(err, "Failed to list containers")
+	}
+	for _, container := range list {
+		if contains(excluded, container.ID) {
+			continue
+		}
+		usage.containers++
+		usage.memory += getMemoryLimit(container)
+	}
+
+	err = client.ContentStore().Walk(ctx, func(info content.Info) error {
+		usage.imageSize += info.Size
+		return nil
+	})
+	if err != nil {
+		return usage, errors.Wrap(err, "Error while calculating content store size")
+	}
+	return usage, nil
+}
+
+// getMemoryLimit returns the container memory limit from the spec, zero if not defined
+func getMemoryLimit(container containers.Container) int64 {
+	if container.Spec == nil {
+		return 0
+	}
+	var spec specs.Spec
+	if err := json.Unmarshal(container.Spec.Value, &spec); err != nil {
+		return 0
+	}
+	if spec.Linux == nil || spec.Linux.Resources == nil || spec.Linux.Resources.Memory == nil || spec.Linux.Resources.Memory.Limit == nil {
+		return 0
+	}
+	return *spec.Linux.Resources.Memory.Limit
+}
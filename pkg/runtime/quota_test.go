@@ -0,0 +1,111 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	contentapi "github.com/containerd/containerd/api/services/content/v1"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestParseNamespaceQuota(t *testing.T) {
+	namespace, quota, err := ParseNamespaceQuota("tenant-a:containers=10,memory=512MB,images=2GB")
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant-a", namespace)
+	assert.Equal(t, model.NamespaceQuota{MaxContainers: 10, MaxMemory: 512 * 1024 * 1024, MaxImageSize: 2 * 1024 * 1024 * 1024}, quota)
+
+	_, _, err = ParseNamespaceQuota("containers=10")
+	assert.Error(t, err, "should require namespace")
+
+	_, _, err = ParseNamespaceQuota("tenant-a:cpu=1")
+	assert.Error(t, err, "should reject unknown limit")
+
+	_, _, err = ParseNamespaceQuota("tenant-a:memory=lots")
+	assert.Error(t, err, "should reject invalid size")
+}
+
+func TestCheckNamespaceQuota(t *testing.T) {
+	quota := model.NamespaceQuota{MaxContainers: 3, MaxMemory: 100, MaxImageSize: 1000}
+	usage := namespaceUsage{containers: 2, memory: 60, imageSize: 500}
+	limited := func(memory int64) model.Container {
+		return model.Container{Name: "foo", Resources: &model.Resources{MemoryLimit: memory}}
+	}
+
+	assert.NoError(t, checkNamespaceQuota("default", quota, usage, []model.Container{limited(40)}))
+
+	err := checkNamespaceQuota("default", quota, usage, []model.Container{limited(10), limited(10)})
+	assert.True(t, IsLimitExceeded(err), "should limit container count")
+
+	err = checkNamespaceQuota("default", quota, usage, []model.Container{limited(41)})
+	assert.True(t, IsLimitExceeded(err), "should limit total memory")
+
+	err = checkNamespaceQuota("default", quota, usage, []model.Container{{Name: "foo"}})
+	assert.True(t, IsLimitExceeded(err), "should require memory limit")
+
+	usage.imageSize = 1001
+	err = checkNamespaceQuota("default", quota, usage, []model.Container{limited(10)})
+	assert.True(t, IsLimitExceeded(err), "should limit image size")
+	assert.Contains(t, err.Error(), "Namespace [default] quota exceeded")
+}
+
+func TestCheckNamespaceQuotaWithoutLimits(t *testing.T) {
+	usage := namespaceUsage{containers: 100, memory: 1000, imageSize: 1000}
+
+	assert.NoError(t, checkNamespaceQuota("default", model.NamespaceQuota{}, usage, []model.Container{{Name: "foo"}}))
+}
+
+// emptyContent is content service without any blobs
+type emptyContent struct {
+	contentapi.ContentServer
+}
+
+func (emptyContent) List(req *contentapi.ListContentRequest, stream contentapi.Content_ListServer) error {
+	return nil
+}
+
+func TestWithinNamespaceQuotaExcludesCreatedContainer(t *testing.T) {
+	address, stop := startFakeContainerd(t, func(server *grpc.Server) {
+		containersapi.RegisterContainersServer(server, fakeCountContainers{})
+		contentapi.RegisterContentServer(server, emptyContent{})
+	})
+	defer stop()
+
+	client := NewContainerdClient(context.Background(), time.Second, "overlayfs", address, "hostname", WithQuotas(map[string]model.NamespaceQuota{
+		"tenant-a": {MaxContainers: 1},
+	}))
+	defer client.Close()
+	connection, err := client.getConnection("tenant-a")
+	assert.NoError(t, err)
+	container := model.Container{Name: "foo"}
+
+	assert.NoError(t, client.withinNamespaceQuota(context.Background(), connection, "tenant-a", "managed", container), "should not count the created container twice")
+
+	err = client.withinNamespaceQuota(context.Background(), connection, "tenant-a", "new", container)
+	assert.True(t, IsLimitExceeded(err), "should reject the created container over the quota")
+
+	assert.NoError(t, client.withinNamespaceQuota(context.Background(), connection, "other", "new", container), "should not limit namespace without quota")
+}
+
+func TestCheckNamespaceQuotaReleasedContainers(t *testing.T) {
+	address, stop := startFakeContainerd(t, func(server *grpc.Server) {
+		containersapi.RegisterContainersServer(server, fakeCountContainers{})
+		contentapi.RegisterContentServer(server, emptyContent{})
+	})
+	defer stop()
+
+	client := NewContainerdClient(context.Background(), time.Second, "overlayfs", address, "hostname", WithQuotas(map[string]model.NamespaceQuota{
+		"tenant-a": {MaxContainers: 1},
+	}))
+	defer client.Close()
+	pod := model.Pod{
+		Metadata: model.NewMetadata("tenant-a", "foo"),
+		Spec:     model.PodSpec{Containers: []model.Container{{Name: "foo"}}},
+	}
+
+	assert.True(t, IsLimitExceeded(client.CheckNamespaceQuota(pod, nil)), "should count the current containers")
+	assert.NoError(t, client.CheckNamespaceQuota(pod, []string{"managed"}), "should not count the released containers")
+}
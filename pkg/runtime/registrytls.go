@@ -0,0 +1,65 @@
+package runtime
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+)
+
+// RegistryTLS configures how the registry TLS certificates get verified, e.g. for on-prem
+// registry mirrors what use certificates signed by internal CA
+type RegistryTLS struct {
+	// RootCAs are the trusted CA certificates, nil means the system certificates
+	RootCAs *x509.CertPool
+	// InsecureHosts are the registry hosts, with optional port, what don't get the certificate verified
+	InsecureHosts []string
+}
+
+// isDefault returns true if the configuration doesn't change the default verification
+func (t RegistryTLS) isDefault() bool {
+	return t.RootCAs == nil && len(t.InsecureHosts) == 0
+}
+
+// newRegistryClient returns http client what verifies the registry certificates per the configuration
+func newRegistryClient(config RegistryTLS) *http.Client {
+	if config.isDefault() {
+		return http.DefaultClient
+	}
+
+	insecureHosts := map[string]bool{}
+	for _, host := range config.InsecureHosts {
+		insecureHosts[host] = true
+	}
+	return &http.Client{
+		Transport: &registryTransport{
+			secure:        newTLSTransport(&tls.Config{RootCAs: config.RootCAs}),
+			insecure:      newTLSTransport(&tls.Config{RootCAs: config.RootCAs, InsecureSkipVerify: true}),
+			insecureHosts: insecureHosts,
+		},
+	}
+}
+
+func newTLSTransport(config *tls.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	return transport
+}
+
+// registryTransport skips the certificate verification for the insecure hosts
+type registryTransport struct {
+	secure        http.RoundTripper
+	insecure      http.RoundTripper
+	insecureHosts map[string]bool
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *registryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.isInsecure(req.URL.Host) || t.isInsecure(req.URL.Hostname()) {
+		return t.insecure.RoundTrip(req)
+	}
+	return t.secure.RoundTrip(req)
+}
+
+func (t *registryTransport) isInsecure(host string) bool {
+	return t.insecureHosts[host]
+}
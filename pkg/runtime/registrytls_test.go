@@ -0,0 +1,37 @@
+package runtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryClientDefaultsToDefaultClient(t *testing.T) {
+	assert.Equal(t, http.DefaultClient, newRegistryClient(RegistryTLS{}))
+}
+
+func TestRegistryClientSkipsVerifyOnlyForInsecureHosts(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+
+	resp, err := newRegistryClient(RegistryTLS{InsecureHosts: []string{serverURL.Host}}).Get(server.URL)
+	assert.NoError(t, err, "should skip verifying the insecure host certificate")
+	resp.Body.Close()
+
+	_, err = newRegistryClient(RegistryTLS{InsecureHosts: []string{"other.local"}}).Get(server.URL)
+	assert.Error(t, err, "should verify the other hosts certificate")
+}
+
+func TestRegistryClientTrustsRootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	resp, err := newRegistryClient(RegistryTLS{RootCAs: server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs}).Get(server.URL)
+	assert.NoError(t, err, "should trust the certificate signed by the given CA")
+	resp.Body.Close()
+}
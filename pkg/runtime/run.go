@@ -0,0 +1,142 @@
+package runtime
+
+import (
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxRunOutputSize is how much of the one-shot container stdout and stderr is kept, the end of the output
+// is usually what tells why the job failed
+const maxRunOutputSize = 1024 * 1024
+
+// RunContainer runs the container once to completion and removes it, returns the exit code and the output
+// The container gets killed if it doesn't exit within the timeout, zero timeout waits forever
+// The container is created with 'never' restart policy so that the Lifecycle controller doesn't start it
+// again after it exits
+func (c *ContainerdClient) RunContainer(pod model.Pod, container model.Container, timeout time.Duration) (result model.RunResult, err error) {
+	pod.Metadata.Namespace = c.resolveNamespace(pod.Metadata.Namespace)
+	pod.Spec.RestartPolicy = model.RestartPolicyNever
+	status, err := c.CreateContainer(pod, container)
+	if err != nil {
+		return result, err
+	}
+	defer func() {
+		if _, stopErr := c.StopContainer(pod.Metadata.Namespace, status.ContainerID); stopErr != nil {
+			log.Warnf("Failed to remove one-shot container [%s]: %s", status.ContainerID, stopErr)
+		}
+	}()
+	result = model.RunResult{Name: container.Name, ContainerID: status.ContainerID}
+
+	ctx, cancel := c.getContextWithTimeout(0)
+	defer cancel()
+
+	client, err := c.getConnection(pod.Metadata.Namespace)
+	if err != nil {
+		return result, err
+	}
+
+	created, err := client.LoadContainer(ctx, status.ContainerID)
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to load container [%s], cannot run it", status.ContainerID)
+	}
+	info, err := created.Info(ctx)
+	if err != nil {
+		return result, errors.Wrap(err, "Error while fetching container info")
+	}
+
+	stdout, stderr := newTailBuffer(maxRunOutputSize), newTailBuffer(maxRunOutputSize)
+	task, err := created.NewTask(ctx, cio.NewCreator(cio.WithStreams(nil, stdout, stderr)))
+	if err != nil {
+		return result, errors.Wrapf(err, "Error while creating task for container [%s]", status.ContainerID)
+	}
+	defer func() {
+		// Delete waits the task IO to complete so the output is complete only after it
+		if _, deleteErr := task.Delete(ctx, containerd.WithProcessKill); deleteErr != nil {
+			log.Warnf("Failed to delete one-shot container [%s] task: %s", status.ContainerID, deleteErr)
+		}
+		result.Stdout, result.Stderr = stdout.String(), stderr.String()
+		result.Truncated = stdout.Truncated() || stderr.Truncated()
+	}()
+
+	exit, err := task.Wait(ctx)
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to wait container [%s] task", status.ContainerID)
+	}
+
+	result.StartedAt = time.Now()
+	if err := task.Start(ctx); err != nil {
+		return result, errors.Wrapf(err, "Failed to start task in container [%s]", status.ContainerID)
+	}
+	log.Debugf("One-shot container [%s] started (pid %d)", status.ContainerID, task.Pid())
+
+	var timer <-chan time.Time
+	if timeout > 0 {
+		timer = time.After(timeout)
+	}
+
+	var exitStatus containerd.ExitStatus
+	select {
+	case exitStatus = <-exit:
+	case <-timer:
+		log.Infof("One-shot container [%s] didn't exit in %s, kill it", status.ContainerID, timeout)
+		result.TimedOut = true
+		if err := task.Kill(ctx, syscall.SIGKILL); err != nil {
+			return result, errors.Wrapf(err, "Failed to kill one-shot container [%s] after timeout", status.ContainerID)
+		}
+		exitStatus = <-exit
+	}
+
+	code, exitedAt, err := exitStatus.Result()
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to resolve container [%s] exit status", status.ContainerID)
+	}
+	result.ExitCode = int(code)
+	result.FinishedAt = exitedAt
+	// Must be resolved before the task gets deleted because the task cgroup gets removed with it
+	result.ExitReason = resolveExitReason(info, code, c.cgroupV2, c.statuses.isOOMKilled(pod.Metadata.Namespace, status.ContainerID))
+	return result, nil
+}
+
+// tailBuffer is io.Writer what keeps only the last bytes written to it
+type tailBuffer struct {
+	mu        sync.Mutex
+	size      int
+	data      []byte
+	truncated bool
+}
+
+func newTailBuffer(size int) *tailBuffer {
+	return &tailBuffer{size: size}
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.data = append(b.data, p...)
+	if overflow := len(b.data) - b.size; overflow > 0 {
+		b.data = append(b.data[:0], b.data[overflow:]...)
+		b.truncated = true
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.data)
+}
+
+// Truncated returns true if the beginning of the output got dropped
+func (b *tailBuffer) Truncated() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.truncated
+}
@@ -0,0 +1,21 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTailBufferKeepsEnd(t *testing.T) {
+	buffer := newTailBuffer(5)
+
+	buffer.Write([]byte("abc"))
+	assert.Equal(t, "abc", buffer.String())
+	assert.False(t, buffer.Truncated())
+
+	n, err := buffer.Write([]byte("defg"))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n, "should report all bytes written")
+	assert.Equal(t, "cdefg", buffer.String())
+	assert.True(t, buffer.Truncated())
+}
@@ -0,0 +1,163 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/containerd/containerd"
+	introspection "github.com/containerd/containerd/api/services/introspection/v1"
+	"github.com/containerd/containerd/plugin"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetRuntimeInfo returns the containerd version, the status of each containerd plugin,
+// the snapshotters and runtimes what containerd has loaded successfully and the container count
+func (c *ContainerdClient) GetRuntimeInfo() (result model.RuntimeInfo, err error) {
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getGlobalConnection()
+	if err != nil {
+		return result, err
+	}
+
+	version, err := client.Version(ctx)
+	if err != nil {
+		return result, errors.Wrap(err, "Failed to resolve containerd version")
+	}
+
+	plugins, err := listPlugins(ctx, client)
+	if err != nil {
+		return result, err
+	}
+
+	containers, err := countContainers(ctx, client, c.resolveNamespace(""))
+	if err != nil {
+		return result, err
+	}
+
+	result = mapPlugins(plugins)
+	result.ContainerdVersion = version.Version
+	result.ContainerdRevision = version.Revision
+	result.Snapshotter = c.getSnapshotter()
+	result.Containers = containers
+	result.MaxContainers = c.maxContainers
+	result.Features = c.getFeatures()
+	return result, nil
+}
+
+// getFeatures returns the optional runtime features what are enabled
+func (c *ContainerdClient) getFeatures() (result []string) {
+	enabled := map[string]bool{
+		model.FeatureInit:            c.initPath != "",
+		model.FeatureEgressRateLimit: c.bandwidthDevice != "",
+		model.FeatureImageSignatures: len(c.trustedKeys) > 0,
+		model.FeatureNamespaceQuota:  len(c.quotas) > 0,
+		model.FeatureSnapshotCleanup: c.snapshotCleanup > 0,
+	}
+	for feature, ok := range enabled {
+		if ok {
+			result = append(result, feature)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// SetSnapshotter changes the snapshotter what the new containers get created with
+// The snapshotter must be available in containerd, the existing containers keep their snapshotter
+func (c *ContainerdClient) SetSnapshotter(snapshotter string) error {
+	info, err := c.GetRuntimeInfo()
+	if err != nil {
+		return err
+	}
+	available := false
+	for _, name := range info.Snapshotters {
+		available = available || name == snapshotter
+	}
+	if !available {
+		return ErrWithMessagef(ErrNotFound, "Snapshotter [%s] is not available, available snapshotters: %s", snapshotter, strings.Join(info.Snapshotters, ", "))
+	}
+
+	c.snapshotterMu.Lock()
+	defer c.snapshotterMu.Unlock()
+	if c.snapshotter != snapshotter {
+		log.Infof("Changed snapshotter from [%s] to [%s]", c.snapshotter, snapshotter)
+	}
+	c.snapshotter = snapshotter
+	return nil
+}
+
+func (c *ContainerdClient) getSnapshotter() string {
+	c.snapshotterMu.RLock()
+	defer c.snapshotterMu.RUnlock()
+	return c.snapshotter
+}
+
+// getUnpackSnapshotter returns the snapshotter where pulled images get unpacked to and the containers get created with
+func (c *ContainerdClient) getUnpackSnapshotter() string {
+	if c.unpackSnapshotter != "" {
+		return c.unpackSnapshotter
+	}
+	return c.getSnapshotter()
+}
+
+func listPlugins(ctx context.Context, client *containerd.Client, filters ...string) ([]introspection.Plugin, error) {
+	resp, err := client.IntrospectionService().Plugins(ctx, &introspection.PluginsRequest{
+		Filters: filters,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to list containerd plugins")
+	}
+	return resp.Plugins, nil
+}
+
+// withPluginError adds the plugin initialisation error to the message if the plugin is in error state
+// so that e.g. failed container create tells that the snapshotter is broken instead of just failing
+func withPluginError(ctx context.Context, client *containerd.Client, err error, pluginType plugin.Type, id string) error {
+	plugins, listErr := listPlugins(ctx, client, fmt.Sprintf("type==%s,id==%s", pluginType, id))
+	if listErr != nil {
+		return err
+	}
+	for _, p := range plugins {
+		if p.InitErr != nil {
+			return errors.Wrapf(err, "Containerd %s plugin [%s] is in error: %s", pluginType, id, p.InitErr.Message)
+		}
+	}
+	return err
+}
+
+// mapPlugins collects the plugin statuses and the snapshotters and runtimes,
+// the plugins what failed to initialise are left out from the snapshotters and runtimes
+func mapPlugins(plugins []introspection.Plugin) (result model.RuntimeInfo) {
+	for _, p := range plugins {
+		status := model.PluginStatus{Type: p.Type, ID: p.ID}
+		if p.InitErr != nil {
+			status.Error = p.InitErr.Message
+		}
+		result.Plugins = append(result.Plugins, status)
+
+		if p.InitErr != nil {
+			continue
+		}
+		switch plugin.Type(p.Type) {
+		case plugin.SnapshotPlugin:
+			result.Snapshotters = append(result.Snapshotters, p.ID)
+		case plugin.RuntimePlugin:
+			result.Runtimes = append(result.Runtimes, fmt.Sprintf("%s.%s", p.Type, p.ID))
+		}
+	}
+	sort.Slice(result.Plugins, func(i, j int) bool {
+		if result.Plugins[i].Type != result.Plugins[j].Type {
+			return result.Plugins[i].Type < result.Plugins[j].Type
+		}
+		return result.Plugins[i].ID < result.Plugins[j].ID
+	})
+	sort.Strings(result.Snapshotters)
+	sort.Strings(result.Runtimes)
+	return result
+}
@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	introspection "github.com/containerd/containerd/api/services/introspection/v1"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/gogo/googleapis/google/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapPlugins(t *testing.T) {
+	result := mapPlugins([]introspection.Plugin{
+		{Type: "io.containerd.snapshotter.v1", ID: "overlayfs"},
+		{Type: "io.containerd.snapshotter.v1", ID: "btrfs", InitErr: &rpc.Status{Message: "not supported"}},
+		{Type: "io.containerd.snapshotter.v1", ID: "native"},
+		{Type: "io.containerd.runtime.v1", ID: "linux"},
+	})
+
+	assert.Equal(t, []string{"native", "overlayfs"}, result.Snapshotters, "should leave out failed plugins")
+	assert.Equal(t, []string{"io.containerd.runtime.v1.linux"}, result.Runtimes)
+	assert.Len(t, result.Plugins, 4, "should report all plugins")
+	assert.Equal(t, model.PluginStatus{Type: "io.containerd.snapshotter.v1", ID: "btrfs", Error: "not supported"}, result.Plugins[1])
+}
+
+func TestUnpackSnapshotterFollowsSnapshotter(t *testing.T) {
+	client := NewContainerdClient(nil, 0, "overlayfs", "", "")
+	assert.Equal(t, "overlayfs", client.getUnpackSnapshotter())
+
+	client.snapshotter = "native"
+	assert.Equal(t, "native", client.getUnpackSnapshotter(), "should unpack to the changed snapshotter")
+
+	client = NewContainerdClient(nil, 0, "overlayfs", "", "", WithUnpackSnapshotter("stargz"))
+	client.snapshotter = "native"
+	assert.Equal(t, "stargz", client.getUnpackSnapshotter(), "should keep the explicit unpack snapshotter")
+}
+
+func TestGetFeatures(t *testing.T) {
+	client := NewContainerdClient(nil, 0, "overlayfs", "", "")
+	assert.Empty(t, client.getFeatures())
+
+	client = NewContainerdClient(nil, 0, "overlayfs", "", "",
+		WithInitPath("/usr/bin/tini"),
+		WithBandwidthDevice("eth0"),
+		WithQuotas(map[string]model.NamespaceQuota{"tenant-a": {MaxContainers: 1}}),
+		WithSnapshotCleanup(time.Second),
+	)
+	assert.Equal(t, []string{model.FeatureEgressRateLimit, model.FeatureInit, model.FeatureNamespaceQuota, model.FeatureSnapshotCleanup}, client.getFeatures())
+}
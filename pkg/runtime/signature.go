@@ -0,0 +1,161 @@
+package runtime
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/reference"
+	"github.com/containerd/containerd/remotes"
+	digest "github.com/opencontainers/go-digest"
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// verifiedImageLabel is the image label what tells the image digest what had valid signature when pulled
+	verifiedImageLabel = "io.eliot.image.verified"
+	// cosignSignatureAnnotation is the signature layer annotation what contains the base64 encoded signature
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// maxSignaturePayloadSize limits how much is read when fetching the signature payload
+	maxSignaturePayloadSize = 64 * 1024
+)
+
+// cosignPayload is the cosign simple signing payload what the signature is calculated over
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// ParseTrustedKey parses PEM encoded ECDSA, RSA or Ed25519 public key what the images can be signed with
+func ParseTrustedKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("Key must be PEM encoded PUBLIC KEY")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to parse public key")
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("Unsupported public key type %T", key)
+}
+
+// verifyImageSignature checks that the image has cosign signature made with any of the trusted keys
+// The signatures are fetched from the registry from the sha256-<digest>.sig tag next to the image
+func verifyImageSignature(ctx context.Context, resolver remotes.Resolver, ref string, imageDigest digest.Digest, keys []crypto.PublicKey) error {
+	spec, err := reference.Parse(ref)
+	if err != nil {
+		return errors.Wrapf(err, "Invalid image reference [%s]", ref)
+	}
+	signatureRef := fmt.Sprintf("%s:%s.sig", spec.Locator, strings.Replace(imageDigest.String(), ":", "-", 1))
+
+	name, desc, err := resolver.Resolve(ctx, signatureRef)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return ErrWithMessagef(ErrUnverified, "Image [%s] is not signed, no signatures found from [%s]", ref, signatureRef)
+		}
+		return errors.Wrapf(err, "Failed to resolve image [%s] signatures", ref)
+	}
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to create fetcher for image [%s] signatures", ref)
+	}
+
+	var manifest imagespecs.Manifest
+	if err := fetchJSON(ctx, fetcher, desc, &manifest); err != nil {
+		return errors.Wrapf(err, "Failed to fetch image [%s] signatures", ref)
+	}
+
+	for _, layer := range manifest.Layers {
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+		payload, err := fetchSignaturePayload(ctx, fetcher, layer)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to fetch image [%s] signature payload", ref)
+		}
+		if verifyPayload(payload, signature, imageDigest, keys) {
+			return nil
+		}
+	}
+	return ErrWithMessagef(ErrUnverified, "Image [%s] doesn't have valid signature from any of the trusted keys", ref)
+}
+
+// fetchSignaturePayload fetches the payload and checks that it matches the descriptor digest
+func fetchSignaturePayload(ctx context.Context, fetcher remotes.Fetcher, desc imagespecs.Descriptor) ([]byte, error) {
+	if desc.Size > maxSignaturePayloadSize {
+		return nil, fmt.Errorf("Signature payload size %d exceeds the maximum %d", desc.Size, maxSignaturePayloadSize)
+	}
+	reader, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	payload, err := ioutil.ReadAll(io.LimitReader(reader, maxSignaturePayloadSize))
+	if err != nil {
+		return nil, err
+	}
+	if digest.FromBytes(payload) != desc.Digest {
+		return nil, fmt.Errorf("Signature payload doesn't match digest %s", desc.Digest)
+	}
+	return payload, nil
+}
+
+// verifyPayload returns true if the signature of the payload is valid for any of the keys
+// and the payload is for the image digest
+func verifyPayload(payload, signature []byte, imageDigest digest.Digest, keys []crypto.PublicKey) bool {
+	var content cosignPayload
+	if err := json.Unmarshal(payload, &content); err != nil || content.Critical.Image.DockerManifestDigest != imageDigest.String() {
+		return false
+	}
+
+	hash := sha256.Sum256(payload)
+	for _, key := range keys {
+		switch key := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(key, hash[:], signature) {
+				return true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature) == nil {
+				return true
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(key, payload, signature) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkImageVerified returns ErrUnverified if the image current digest didn't have valid signature when pulled,
+// the label gets checked so that verified images can be used also when the registry is not reachable
+func checkImageVerified(image images.Image) error {
+	if image.Labels[verifiedImageLabel] != image.Target.Digest.String() {
+		return ErrWithMessagef(ErrUnverified, "Image [%s] signature is not verified, pull the image again to verify it", image.Name)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/remotes"
+	digest "github.com/opencontainers/go-digest"
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSignatureResolver serves the signature manifests by reference and the blobs by digest
+type fakeSignatureResolver struct {
+	manifests map[string]imagespecs.Descriptor
+	blobs     map[digest.Digest][]byte
+}
+
+func (r *fakeSignatureResolver) Resolve(ctx context.Context, ref string) (string, imagespecs.Descriptor, error) {
+	desc, ok := r.manifests[ref]
+	if !ok {
+		return "", desc, errdefs.ErrNotFound
+	}
+	return ref, desc, nil
+}
+
+func (r *fakeSignatureResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	return remotes.FetcherFunc(func(ctx context.Context, desc imagespecs.Descriptor) (io.ReadCloser, error) {
+		blob, ok := r.blobs[desc.Digest]
+		if !ok {
+			return nil, errdefs.ErrNotFound
+		}
+		return ioutil.NopCloser(bytes.NewReader(blob)), nil
+	}), nil
+}
+
+func (r *fakeSignatureResolver) Pusher(ctx context.Context, ref string) (remotes.Pusher, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (r *fakeSignatureResolver) addBlob(data []byte) imagespecs.Descriptor {
+	desc := imagespecs.Descriptor{Digest: digest.FromBytes(data), Size: int64(len(data))}
+	r.blobs[desc.Digest] = data
+	return desc
+}
+
+// sign adds cosign style signature of the image digest to the resolver
+func (r *fakeSignatureResolver) sign(t *testing.T, locator string, imageDigest digest.Digest, key *ecdsa.PrivateKey) {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"%s"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, locator, imageDigest))
+	hash := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	assert.NoError(t, err)
+
+	layer := r.addBlob(payload)
+	layer.MediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	layer.Annotations = map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)}
+	manifest, err := json.Marshal(imagespecs.Manifest{Layers: []imagespecs.Descriptor{layer}})
+	assert.NoError(t, err)
+
+	desc := r.addBlob(manifest)
+	desc.MediaType = imagespecs.MediaTypeImageManifest
+	r.manifests[fmt.Sprintf("%s:sha256-%s.sig", locator, imageDigest.Hex())] = desc
+}
+
+func newTestKey(t *testing.T) (*ecdsa.PrivateKey, crypto.PublicKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	public, err := ParseTrustedKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	assert.NoError(t, err)
+	return key, public
+}
+
+func TestVerifyImageSignature(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		resolver    = &fakeSignatureResolver{manifests: map[string]imagespecs.Descriptor{}, blobs: map[digest.Digest][]byte{}}
+		imageDigest = digest.FromString("image")
+		signer, key = newTestKey(t)
+		_, otherKey = newTestKey(t)
+	)
+	resolver.sign(t, "docker.io/library/myapp", imageDigest, signer)
+
+	assert.NoError(t, verifyImageSignature(ctx, resolver, "docker.io/library/myapp:stable", imageDigest, []crypto.PublicKey{otherKey, key}))
+
+	err := verifyImageSignature(ctx, resolver, "docker.io/library/myapp:stable", imageDigest, []crypto.PublicKey{otherKey})
+	assert.True(t, IsUnverified(err), "should reject signature from untrusted key")
+
+	err = verifyImageSignature(ctx, resolver, "docker.io/library/other:stable", imageDigest, []crypto.PublicKey{key})
+	assert.True(t, IsUnverified(err), "should reject image without signatures")
+
+	// The signature of the first image copied next to other image
+	otherDigest := digest.FromString("other")
+	resolver.manifests["docker.io/library/other:sha256-"+otherDigest.Hex()+".sig"] = resolver.manifests["docker.io/library/myapp:sha256-"+imageDigest.Hex()+".sig"]
+	err = verifyImageSignature(ctx, resolver, "docker.io/library/other:stable", otherDigest, []crypto.PublicKey{key})
+	assert.True(t, IsUnverified(err), "should reject signature of other digest")
+}
+
+func TestParseTrustedKeyRejectsInvalidKey(t *testing.T) {
+	_, err := ParseTrustedKey([]byte("not a key"))
+	assert.Error(t, err)
+
+	_, err = ParseTrustedKey(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("foo")}))
+	assert.Error(t, err)
+}
+
+func TestCheckImageVerified(t *testing.T) {
+	image := images.Image{
+		Name:   "docker.io/library/myapp:stable",
+		Target: imagespecs.Descriptor{Digest: digest.FromString("image")},
+	}
+	assert.True(t, IsUnverified(checkImageVerified(image)))
+
+	image.Labels = map[string]string{verifiedImageLabel: digest.FromString("old").String()}
+	assert.True(t, IsUnverified(checkImageVerified(image)), "should require the current digest to be verified")
+
+	image.Labels[verifiedImageLabel] = image.Target.Digest.String()
+	assert.NoError(t, checkImageVerified(image))
+}
+
+func TestForgedVerifiedLabelIsRejected(t *testing.T) {
+	forged := map[string]string{verifiedImageLabel: digest.FromString("image").String()}
+
+	assert.True(t, IsInvalid(checkUserImageLabels(forged)), "should reject the verification label from the user")
+	_, err := getImportLabels(forged)
+	assert.True(t, IsInvalid(err), "should reject the verification label on import")
+
+	labels, err := getImportLabels(map[string]string{"app": "myapp"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"app": "myapp", verifiedImageLabel: ""}, labels, "should clear the verification of the replaced image")
+}
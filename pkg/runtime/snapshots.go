@@ -0,0 +1,43 @@
+package runtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/snapshots"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// snapshotCleanupInterval is how often the snapshot gets checked while waiting it to be removed
+var snapshotCleanupInterval = 100 * time.Millisecond
+
+// waitSnapshotRemoved waits until the snapshot is gone, some snapshotters remove the snapshot
+// asynchronously after the container delete returns and creating container again with the same ID
+// would fail with "snapshot already exists". Removing is retried while waiting so that the snapshot
+// gets removed also if the first removal failed, e.g. because the snapshot was still mounted
+func waitSnapshotRemoved(ctx context.Context, snapshotter snapshots.Snapshotter, key string, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	for {
+		_, err := snapshotter.Stat(ctx, key)
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Failed to check is snapshot [%s] removed", key)
+		}
+
+		if err := snapshotter.Remove(ctx, key); err != nil && !errdefs.IsNotFound(err) {
+			log.Debugf("Snapshot [%s] removal failed, will retry: %s", key, err)
+		}
+
+		select {
+		case <-deadline:
+			return ErrWithMessagef(ErrTimeout, "Snapshot [%s] was not removed within %s", key, timeout)
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(snapshotCleanupInterval):
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/snapshots"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSnapshotter struct {
+	snapshots.Snapshotter
+	// removeAfter is how many Stat calls the snapshot still exists
+	removeAfter int
+	stats       int
+}
+
+func (s *fakeSnapshotter) Stat(ctx context.Context, key string) (snapshots.Info, error) {
+	s.stats++
+	if s.stats > s.removeAfter {
+		return snapshots.Info{}, errdefs.ErrNotFound
+	}
+	return snapshots.Info{Name: key}, nil
+}
+
+func (s *fakeSnapshotter) Remove(ctx context.Context, key string) error {
+	return errdefs.ErrFailedPrecondition
+}
+
+func TestWaitSnapshotRemoved(t *testing.T) {
+	snapshotCleanupInterval = time.Millisecond
+	snapshotter := &fakeSnapshotter{removeAfter: 3}
+
+	assert.NoError(t, waitSnapshotRemoved(context.Background(), snapshotter, "foo", time.Second))
+	assert.Equal(t, 4, snapshotter.stats)
+}
+
+func TestWaitSnapshotRemovedTimeout(t *testing.T) {
+	snapshotCleanupInterval = time.Millisecond
+
+	err := waitSnapshotRemoved(context.Background(), &fakeSnapshotter{removeAfter: 1000000}, "foo", 10*time.Millisecond)
+	assert.True(t, IsTimeout(err))
+}
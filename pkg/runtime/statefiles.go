@@ -0,0 +1,145 @@
+package runtime
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/ernoaapa/eliot/pkg/model"
+	opts "github.com/ernoaapa/eliot/pkg/runtime/containerd"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/extensions"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// SecretResolver returns the data of the namespace secret by name
+type SecretResolver func(namespace, name string) ([]byte, error)
+
+// SetSecretResolver sets the resolver what the secret files get resolved with when the container starts and
+// the files need to be written again, nil means the containers with secret files cannot start after reboot
+func (c *ContainerdClient) SetSecretResolver(resolver SecretResolver) {
+	c.secretsMu.Lock()
+	defer c.secretsMu.Unlock()
+	c.secrets = resolver
+}
+
+func (c *ContainerdClient) getSecretResolver() SecretResolver {
+	c.secretsMu.RLock()
+	defer c.secretsMu.RUnlock()
+	return c.secrets
+}
+
+// restoreStateFiles writes the generated host files and the container files again if they are missing
+// The files are under /run what is tmpfs, so they disappear when the device reboots but the containers still exist
+// The secrets get resolved from the namespace where the container is
+func (c *ContainerdClient) restoreStateFiles(namespace string, info containers.Container, spec *specs.Spec) error {
+	stateFiles, err := extensions.GetStateFilesExtension(info)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to read container [%s] state files", info.ID)
+	}
+	mounted, err := extensions.GetFilesExtension(info)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to read container [%s] files", info.ID)
+	}
+	files := map[string]model.FileMount{}
+	if mounted != nil {
+		for _, file := range mounted.Files {
+			files[file.Path] = model.FileMount{Path: file.Path, Content: file.Content, Secret: file.Secret, Mode: file.Mode}
+		}
+	}
+
+	for _, mount := range spec.Mounts {
+		if mount.Type != "bind" {
+			continue
+		}
+		if stateFiles != nil {
+			if content, ok := stateFiles.Files[mount.Destination]; ok {
+				if err := restoreIfMissing(mount.Source, func() error {
+					return opts.RestoreNetworkFile(mount.Source, []byte(content))
+				}); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if file, ok := files[mount.Destination]; ok {
+			if err := restoreIfMissing(mount.Source, func() error {
+				return c.restoreFile(namespace, mount.Source, file)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *ContainerdClient) restoreFile(namespace, path string, file model.FileMount) error {
+	if file.Secret != "" {
+		resolver := c.getSecretResolver()
+		if resolver == nil {
+			return ErrWithMessagef(ErrInvalid, "Cannot restore file [%s] from secret [%s], the secret store is not configured", file.Path, file.Secret)
+		}
+		data, err := resolver(namespace, file.Secret)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to resolve secret [%s] for file [%s]", file.Secret, file.Path)
+		}
+		file.Content = string(data)
+	}
+	return opts.RestoreFile(path, file)
+}
+
+func restoreIfMissing(path string, restore func() error) error {
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return err
+	}
+	log.Debugf("Restoring missing container file [%s]", path)
+	return restore()
+}
+
+// containerStateDir is the directory where the container generated files get written when the container gets created
+// It gets removed on failure only if the create made it, so that the files of an existing container don't get touched
+type containerStateDir struct {
+	path    string
+	created bool
+}
+
+// create is the container option what makes the state dir before the spec writes the files into it
+// Fails with AlreadyExists if the container exists, the dir of a removed container gets replaced
+// CreateContainer holds containerLimitMu during the create, so concurrent creates don't race for the dir
+func (d *containerStateDir) create(ctx context.Context, client *containerd.Client, container *containers.Container) error {
+	if err := os.MkdirAll(filepath.Dir(d.path), 0755); err != nil {
+		return errors.Wrapf(err, "Error while creating container state directory [%s]", d.path)
+	}
+	err := os.Mkdir(d.path, 0755)
+	if os.IsExist(err) {
+		if _, err := client.ContainerService().Get(ctx, container.ID); !errdefs.IsNotFound(err) {
+			if err == nil {
+				return errors.Wrapf(errdefs.ErrAlreadyExists, "container %q", container.ID)
+			}
+			return errors.Wrapf(err, "Failed to resolve owner of container state directory [%s]", d.path)
+		}
+		log.Debugf("Replacing state directory of removed container [%s]", container.ID)
+		if err = os.RemoveAll(d.path); err == nil {
+			err = os.Mkdir(d.path, 0755)
+		}
+	}
+	if err != nil {
+		return errors.Wrapf(err, "Error while creating container state directory [%s]", d.path)
+	}
+	d.created = true
+	return nil
+}
+
+// remove removes the state dir if the create made it
+func (d *containerStateDir) remove() {
+	if !d.created {
+		return
+	}
+	if err := os.RemoveAll(d.path); err != nil {
+		log.Warnf("Failed to remove container state directory [%s]: %s", d.path, err)
+	}
+}
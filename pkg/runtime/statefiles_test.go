@@ -0,0 +1,111 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/extensions"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestRestoreStateFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "state")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	info := &containers.Container{ID: "foo"}
+	assert.NoError(t, extensions.WithStateFilesExtension(extensions.StateFiles{Files: map[string]string{
+		"/etc/resolv.conf": "nameserver 8.8.8.8\n",
+		"/etc/hostname":    "sensor\n",
+	}})(nil, nil, info))
+	spec := &specs.Spec{Mounts: []specs.Mount{
+		{Type: "bind", Source: filepath.Join(dir, "resolv.conf"), Destination: "/etc/resolv.conf"},
+		{Type: "bind", Source: filepath.Join(dir, "hostname"), Destination: "/etc/hostname"},
+		{Type: "bind", Source: "/data", Destination: "/data"},
+	}}
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "hostname"), []byte("existing\n"), 0644))
+
+	client := &ContainerdClient{}
+	assert.NoError(t, client.restoreStateFiles("default", *info, spec))
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "resolv.conf"))
+	assert.NoError(t, err)
+	assert.Equal(t, "nameserver 8.8.8.8\n", string(content), "should write the missing file again")
+
+	content, err = ioutil.ReadFile(filepath.Join(dir, "hostname"))
+	assert.NoError(t, err)
+	assert.Equal(t, "existing\n", string(content), "should not touch the existing file")
+}
+
+func TestRestoreSecretFileFromContainerNamespace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "state")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	info := &containers.Container{ID: "foo"}
+	assert.NoError(t, extensions.WithFilesExtension(extensions.Files{Files: []extensions.File{
+		{Path: "/run/secrets/api-key", Secret: "api-key"},
+	}})(nil, nil, info))
+	spec := &specs.Spec{Mounts: []specs.Mount{
+		{Type: "bind", Source: filepath.Join(dir, "files", "0"), Destination: "/run/secrets/api-key"},
+	}}
+
+	client := &ContainerdClient{}
+	err = client.restoreStateFiles("dev", *info, spec)
+	assert.True(t, IsInvalid(err), "should fail when the secret store is not configured")
+
+	var namespaces []string
+	client.SetSecretResolver(func(namespace, name string) ([]byte, error) {
+		namespaces = append(namespaces, namespace)
+		return nil, fmt.Errorf("Secret [%s] not found", name)
+	})
+	assert.Error(t, client.restoreStateFiles("dev", *info, spec))
+	assert.Equal(t, []string{"dev"}, namespaces, "should resolve the secret from the container namespace")
+}
+
+func TestContainerStateDir(t *testing.T) {
+	address, stop := startFakeContainerd(t, func(server *grpc.Server) {
+		containersapi.RegisterContainersServer(server, fakeCountContainers{})
+	})
+	defer stop()
+
+	client := NewContainerdClient(context.Background(), time.Second, "overlayfs", address, "hostname")
+	defer client.Close()
+	connection, err := client.getGlobalConnection()
+	assert.NoError(t, err)
+
+	root, err := ioutil.TempDir("", "state")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	existing := &containerStateDir{path: filepath.Join(root, "managed")}
+	assert.NoError(t, os.MkdirAll(existing.path, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(existing.path, "hosts"), []byte("existing"), 0644))
+	err = existing.create(context.Background(), connection, &containers.Container{ID: "managed"})
+	assert.True(t, errdefs.IsAlreadyExists(err), "should fail with AlreadyExists when the container exists")
+	existing.remove()
+	content, err := ioutil.ReadFile(filepath.Join(existing.path, "hosts"))
+	assert.NoError(t, err, "should not remove the existing container files")
+	assert.Equal(t, "existing", string(content))
+
+	stale := &containerStateDir{path: filepath.Join(root, "removed")}
+	assert.NoError(t, os.MkdirAll(stale.path, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(stale.path, "hosts"), []byte("stale"), 0644))
+	assert.NoError(t, stale.create(context.Background(), connection, &containers.Container{ID: "removed"}))
+	_, err = os.Stat(filepath.Join(stale.path, "hosts"))
+	assert.True(t, os.IsNotExist(err), "should replace the removed container state dir")
+
+	stale.remove()
+	_, err = os.Stat(stale.path)
+	assert.True(t, os.IsNotExist(err), "should remove the state dir what the create made")
+}
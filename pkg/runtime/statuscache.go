@@ -0,0 +1,273 @@
+package runtime
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	types "github.com/containerd/containerd/api/types/task"
+	"github.com/containerd/containerd/events"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/runtime/containerd/extensions"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// taskEventsFilter subscribes only the task lifecycle events
+	taskEventsFilter = `topic~="/tasks/"`
+	// taskEventsReconnectInterval is how long to wait before subscribing again after the event stream breaks
+	taskEventsReconnectInterval = 5 * time.Second
+)
+
+// taskEventsResyncDelay is how long after subscribing the cache gets enabled if no event arrives before it.
+// containerd registers the subscriber asynchronously, so the events right after subscribing can be missed
+// and the statuses queried before the resync get dropped
+var taskEventsResyncDelay = time.Second
+
+// statusCache keeps container task statuses up to date from the containerd event stream
+// The cache is used only while the event stream is connected, otherwise the statuses get queried directly
+// The cache also remembers the tasks what containerd has reported OOM event for until the next task gets created
+// Only GetContainerTaskStatus reads the cache, the pod listing queries the tasks directly because it needs
+// also the exit status what the events don't keep and GetContainerTaskStatuses lists all tasks with one call anyway
+type statusCache struct {
+	mutex     sync.RWMutex
+	connected bool
+	statuses  map[string]string
+	oomKilled map[string]bool
+	// generation gets incremented on every change so that directly queried status
+	// doesn't overwrite newer status from the events
+	generation uint64
+	// onOOMExit gets called when the main process of task what got OOM event exits
+	onOOMExit func(namespace, id string, exitStatus uint32)
+}
+
+// taskEvent contains the container id what all containerd task events have as first field
+// The event types are decoded with reflection because the containerd events API isn't vendored
+type taskEvent struct {
+	ContainerID string `protobuf:"bytes,1,opt,name=container_id,proto3"`
+}
+
+func (e *taskEvent) Reset()         { *e = taskEvent{} }
+func (e *taskEvent) String() string { return proto.CompactTextString(e) }
+func (*taskEvent) ProtoMessage()    {}
+
+// taskExitEvent is containerd TaskExit event, the id is the exited process id what equals to the container id for the main process
+type taskExitEvent struct {
+	ContainerID string `protobuf:"bytes,1,opt,name=container_id,proto3"`
+	ID          string `protobuf:"bytes,2,opt,name=id,proto3"`
+	Pid         uint32 `protobuf:"varint,3,opt,name=pid,proto3"`
+	ExitStatus  uint32 `protobuf:"varint,4,opt,name=exit_status,proto3"`
+}
+
+func (e *taskExitEvent) Reset()         { *e = taskExitEvent{} }
+func (e *taskExitEvent) String() string { return proto.CompactTextString(e) }
+func (*taskExitEvent) ProtoMessage()    {}
+
+func newStatusCache() *statusCache {
+	return &statusCache{
+		statuses:  map[string]string{},
+		oomKilled: map[string]bool{},
+	}
+}
+
+// get returns the cached status and the cache generation, false if the cache is not connected
+// or the status isn't known
+func (s *statusCache) get(namespace, id string) (string, uint64, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if !s.connected {
+		return "", s.generation, false
+	}
+	status, ok := s.statuses[getStatusCacheKey(namespace, id)]
+	return status, s.generation, ok
+}
+
+// add stores directly queried status, unless the cache has changed since the given generation
+func (s *statusCache) add(namespace, id, status string, generation uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.connected || s.generation != generation {
+		return
+	}
+	s.statuses[getStatusCacheKey(namespace, id)] = status
+}
+
+// isOOMKilled returns true if containerd have reported OOM event for the current container task
+func (s *statusCache) isOOMKilled(namespace, id string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.oomKilled[getStatusCacheKey(namespace, id)]
+}
+
+// setConnected marks the event stream connected or disconnected, disconnecting drops the statuses
+// because the events might have been missed, the OOM events are kept because they stay true until the next task
+func (s *statusCache) setConnected(connected bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.connected = connected
+	s.statuses = map[string]string{}
+	s.generation++
+}
+
+// handle updates the cache based on the task event
+func (s *statusCache) handle(envelope *events.Envelope) error {
+	if envelope.Event == nil {
+		return nil
+	}
+
+	var (
+		event  = &taskEvent{}
+		status string
+		oom    *taskExitEvent
+	)
+	switch envelope.Topic {
+	case "/tasks/create":
+		status = types.StatusCreated.String()
+	case "/tasks/oom":
+		if err := proto.Unmarshal(envelope.Event.Value, event); err != nil {
+			return errors.Wrapf(err, "Failed to decode task OOM event")
+		}
+		log.Infof("Container [%s/%s] run out of memory", envelope.Namespace, event.ContainerID)
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.oomKilled[getStatusCacheKey(envelope.Namespace, event.ContainerID)] = true
+		return nil
+	case "/tasks/start", "/tasks/resumed":
+		status = types.StatusRunning.String()
+	case "/tasks/paused":
+		status = types.StatusPaused.String()
+	case "/tasks/delete":
+		// Task got removed, the status gets queried directly on next read
+	case "/tasks/exit":
+		exit := &taskExitEvent{}
+		if err := proto.Unmarshal(envelope.Event.Value, exit); err != nil {
+			return errors.Wrapf(err, "Failed to decode task exit event")
+		}
+		if exit.ID != exit.ContainerID {
+			// Exec process exited, the container task keeps running
+			return nil
+		}
+		status = types.StatusStopped.String()
+		oom = exit
+	default:
+		return nil
+	}
+
+	if err := proto.Unmarshal(envelope.Event.Value, event); err != nil {
+		return errors.Wrapf(err, "Failed to decode task event [%s]", envelope.Topic)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.generation++
+	key := getStatusCacheKey(envelope.Namespace, event.ContainerID)
+	if status == "" {
+		delete(s.statuses, key)
+	} else {
+		s.statuses[key] = status
+	}
+
+	switch {
+	case envelope.Topic == "/tasks/create":
+		delete(s.oomKilled, key)
+	case oom != nil && s.oomKilled[key] && s.onOOMExit != nil:
+		go s.onOOMExit(envelope.Namespace, event.ContainerID, oom.ExitStatus)
+	}
+	return nil
+}
+
+func getStatusCacheKey(namespace, id string) string {
+	return fmt.Sprintf("%s/%s", namespace, id)
+}
+
+// ensureWatchingEvents starts watching the containerd task events once
+func (c *ContainerdClient) ensureWatchingEvents() {
+	c.watchStatuses.Do(func() {
+		c.statuses.onOOMExit = c.recordOOMExit
+		go c.watchTaskEvents()
+	})
+}
+
+// recordOOMExit stores the OOM killed task exit to the container lifecycle right away so that the status
+// shows the reason also when the container doesn't get restarted, the restart doesn't change the stored exit
+func (c *ContainerdClient) recordOOMExit(namespace, id string, exitStatus uint32) {
+	ctx, cancel := c.getContext()
+	defer cancel()
+
+	client, err := c.getConnection(namespace)
+	if err != nil {
+		log.Warnf("Failed to store container [%s/%s] OOM exit: %s", namespace, id, err)
+		return
+	}
+
+	container, err := client.LoadContainer(ctx, id)
+	if err != nil {
+		log.Warnf("Failed to store container [%s/%s] OOM exit: %s", namespace, id, err)
+		return
+	}
+	if err := container.Update(ctx, extensions.WithLastExit(exitStatus, model.ExitReasonOOMKilled)); err != nil {
+		log.Warnf("Failed to store container [%s/%s] OOM exit: %s", namespace, id, err)
+	}
+}
+
+// watchTaskEvents keeps the status cache up to date until the client context is done
+// If the event stream breaks, reconnects after interval and until that the statuses get queried directly
+func (c *ContainerdClient) watchTaskEvents() {
+	for {
+		err := c.subscribeTaskEvents()
+		c.statuses.setConnected(false)
+		if c.context.Err() != nil {
+			return
+		}
+		c.connection.set(false)
+
+		log.Warnf("Lost containerd task event stream, status cache disabled and reconnect in %s: %s", taskEventsReconnectInterval, err)
+		select {
+		case <-c.context.Done():
+			return
+		case <-time.After(taskEventsReconnectInterval):
+		}
+	}
+}
+
+func (c *ContainerdClient) subscribeTaskEvents() error {
+	client, err := c.getGlobalConnection()
+	if err != nil {
+		return err
+	}
+	// The shared connection reconnects by itself, so check containerd serves again before reporting it up
+	if err := c.isServing(client); err != nil {
+		return err
+	}
+	c.connection.set(true)
+
+	envelopes, errs := client.Subscribe(c.context, taskEventsFilter)
+	log.Debugln("Subscribed containerd task events, status cache gets enabled on first event or resync")
+
+	var (
+		connected = false
+		resync    = time.After(taskEventsResyncDelay)
+	)
+	for {
+		select {
+		case envelope := <-envelopes:
+			if !connected {
+				c.statuses.setConnected(true)
+				connected = true
+			}
+			if err := c.statuses.handle(envelope); err != nil {
+				return err
+			}
+		case <-resync:
+			if !connected {
+				c.statuses.setConnected(true)
+				connected = true
+				log.Debugln("Containerd task events resynced, status cache enabled")
+			}
+		case err := <-errs:
+			return err
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eventsapi "github.com/containerd/containerd/api/services/events/v1"
+	"github.com/containerd/containerd/events"
+	"github.com/gogo/protobuf/proto"
+	prototypes "github.com/gogo/protobuf/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// taskStartEvent is containerd TaskStart event, has pid what the cache doesn't decode
+type taskStartEvent struct {
+	ContainerID string `protobuf:"bytes,1,opt,name=container_id,proto3"`
+	Pid         uint32 `protobuf:"varint,2,opt,name=pid,proto3"`
+}
+
+func (e *taskStartEvent) Reset()         { *e = taskStartEvent{} }
+func (e *taskStartEvent) String() string { return proto.CompactTextString(e) }
+func (*taskStartEvent) ProtoMessage()    {}
+
+func newTestEnvelope(t *testing.T, topic string, event proto.Message) *events.Envelope {
+	value, err := proto.Marshal(event)
+	assert.NoError(t, err)
+	return &events.Envelope{
+		Namespace: "default",
+		Topic:     topic,
+		Event:     &prototypes.Any{Value: value},
+	}
+}
+
+func TestStatusCacheHandleTaskEvents(t *testing.T) {
+	cache := newStatusCache()
+	cache.setConnected(true)
+
+	assert.NoError(t, cache.handle(newTestEnvelope(t, "/tasks/start", &taskStartEvent{ContainerID: "foo", Pid: 123})))
+	status, _, ok := cache.get("default", "foo")
+	assert.True(t, ok)
+	assert.Equal(t, "RUNNING", status)
+
+	assert.NoError(t, cache.handle(newTestEnvelope(t, "/tasks/exit", &taskExitEvent{ContainerID: "foo", ID: "exec-1"})))
+	status, _, _ = cache.get("default", "foo")
+	assert.Equal(t, "RUNNING", status, "exec process exit should not change the status")
+
+	assert.NoError(t, cache.handle(newTestEnvelope(t, "/tasks/exit", &taskExitEvent{ContainerID: "foo", ID: "foo"})))
+	status, _, _ = cache.get("default", "foo")
+	assert.Equal(t, "STOPPED", status)
+
+	assert.NoError(t, cache.handle(newTestEnvelope(t, "/tasks/delete", &taskStartEvent{ContainerID: "foo"})))
+	_, _, ok = cache.get("default", "foo")
+	assert.False(t, ok, "deleted task status should be queried directly")
+
+	_, _, ok = cache.get("other", "foo")
+	assert.False(t, ok, "should separate namespaces")
+}
+
+func TestStatusCacheDisconnected(t *testing.T) {
+	cache := newStatusCache()
+	_, generation, _ := cache.get("default", "foo")
+	cache.add("default", "foo", "RUNNING", generation)
+	_, _, ok := cache.get("default", "foo")
+	assert.False(t, ok, "should not cache when event stream is not connected")
+
+	cache.setConnected(true)
+	assert.NoError(t, cache.handle(newTestEnvelope(t, "/tasks/start", &taskStartEvent{ContainerID: "foo"})))
+	cache.setConnected(false)
+	_, _, ok = cache.get("default", "foo")
+	assert.False(t, ok, "should drop statuses when event stream disconnects")
+}
+
+func TestStatusCacheAddDoesNotOverwriteNewerEvents(t *testing.T) {
+	cache := newStatusCache()
+	cache.setConnected(true)
+
+	_, generation, _ := cache.get("default", "foo")
+	assert.NoError(t, cache.handle(newTestEnvelope(t, "/tasks/exit", &taskExitEvent{ContainerID: "foo", ID: "foo"})))
+	cache.add("default", "foo", "RUNNING", generation)
+
+	status, generation, _ := cache.get("default", "foo")
+	assert.Equal(t, "STOPPED", status)
+
+	cache.add("default", "bar", "RUNNING", generation)
+	status, _, ok := cache.get("default", "bar")
+	assert.True(t, ok)
+	assert.Equal(t, "RUNNING", status)
+}
+
+func TestStatusCacheTracksOOMEvents(t *testing.T) {
+	cache := newStatusCache()
+	cache.setConnected(true)
+	exits := make(chan uint32, 1)
+	cache.onOOMExit = func(namespace, id string, exitStatus uint32) {
+		assert.Equal(t, "default", namespace)
+		assert.Equal(t, "foo", id)
+		exits <- exitStatus
+	}
+
+	assert.NoError(t, cache.handle(newTestEnvelope(t, "/tasks/oom", &taskEvent{ContainerID: "foo"})))
+	assert.True(t, cache.isOOMKilled("default", "foo"))
+	assert.False(t, cache.isOOMKilled("default", "bar"))
+
+	cache.setConnected(false)
+	cache.setConnected(true)
+	assert.True(t, cache.isOOMKilled("default", "foo"), "should keep OOM events over reconnect")
+
+	assert.NoError(t, cache.handle(newTestEnvelope(t, "/tasks/exit", &taskExitEvent{ContainerID: "foo", ID: "foo", ExitStatus: 137})))
+	assert.Equal(t, uint32(137), <-exits)
+
+	assert.NoError(t, cache.handle(newTestEnvelope(t, "/tasks/create", &taskEvent{ContainerID: "foo"})))
+	assert.False(t, cache.isOOMKilled("default", "foo"), "new task should clear the OOM event")
+}
+
+// fakeEvents sends the task events what the test gives after the client has subscribed
+type fakeEvents struct {
+	eventsapi.EventsServer
+	envelopes chan *eventsapi.Envelope
+}
+
+func (f fakeEvents) Subscribe(req *eventsapi.SubscribeRequest, stream eventsapi.Events_SubscribeServer) error {
+	for {
+		select {
+		case envelope := <-f.envelopes:
+			if err := stream.Send(envelope); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func TestStatusCacheConnectsOnFirstEvent(t *testing.T) {
+	original := taskEventsResyncDelay
+	defer func() { taskEventsResyncDelay = original }()
+	taskEventsResyncDelay = time.Hour
+
+	events := fakeEvents{envelopes: make(chan *eventsapi.Envelope)}
+	address, stop := startFakeContainerd(t, func(server *grpc.Server) {
+		eventsapi.RegisterEventsServer(server, events)
+	})
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := NewContainerdClient(ctx, time.Second, "overlayfs", address, "hostname")
+	defer client.Close()
+	go client.subscribeTaskEvents()
+
+	time.Sleep(100 * time.Millisecond)
+	_, _, ok := client.statuses.get("default", "foo")
+	assert.False(t, ok, "should not use the cache before the subscription is known to receive events")
+
+	envelope := newTestEnvelope(t, "/tasks/start", &taskStartEvent{ContainerID: "foo", Pid: 1})
+	events.envelopes <- &eventsapi.Envelope{Namespace: envelope.Namespace, Topic: envelope.Topic, Event: envelope.Event}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !ok && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		_, _, ok = client.statuses.get("default", "foo")
+	}
+	assert.True(t, ok, "should enable the cache on the first event")
+}
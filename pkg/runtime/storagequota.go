@@ -0,0 +1,151 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/snapshots"
+	"github.com/pkg/errors"
+)
+
+// storageQuotaProjectBase is added to the XFS project IDs so that they don't collide
+// with the projects what the host administrator has defined
+const storageQuotaProjectBase = 1 << 24
+
+var (
+	// lookupMount returns the mount where the path is, variable for testing
+	lookupMount = mount.Lookup
+	// selfMounts returns all mounts, variable for testing
+	selfMounts = mount.Self
+
+	// runQuotaCommand executes the quota tool command, variable for testing
+	runQuotaCommand = func(name string, args ...string) error {
+		output, err := exec.Command(name, args...).CombinedOutput()
+		if err != nil {
+			return errors.Wrapf(err, "%s %s failed: %s", name, strings.Join(args, " "), strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+)
+
+// checkStorageQuota returns ErrNotSupported if the snapshotter cannot limit the container writable layer size
+func checkStorageQuota(snapshotter string) error {
+	switch snapshotter {
+	case "overlayfs", "btrfs":
+		return nil
+	}
+	return ErrWithMessagef(ErrNotSupported, "Snapshotter [%s] doesn't support storage quota, use overlayfs on XFS with project quotas or btrfs with quotas enabled", snapshotter)
+}
+
+// applyStorageQuota limits how much the container writable layer can grow
+// Returns ErrNotSupported if the snapshot filesystem doesn't have quotas enabled
+func applyStorageQuota(ctx context.Context, snapshotter snapshots.Snapshotter, name, key string, size int64) error {
+	if err := checkStorageQuota(name); err != nil {
+		return err
+	}
+	mounts, err := snapshotter.Mounts(ctx, key)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to resolve snapshot [%s] mounts", key)
+	}
+
+	if name == "btrfs" {
+		return setQgroupLimit(mounts, size)
+	}
+	return setProjectQuota(mounts, size)
+}
+
+// setProjectQuota limits the overlay upper directory size with XFS project quota
+func setProjectQuota(mounts []mount.Mount, size int64) error {
+	upper := getMountOption(mounts, "upperdir")
+	if upper == "" {
+		// The view snapshots don't have writable layer
+		return ErrWithMessagef(ErrNotSupported, "Snapshot doesn't have writable layer, cannot set storage quota")
+	}
+
+	info, err := lookupMount(upper)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to resolve snapshot filesystem")
+	}
+	if info.FSType != "xfs" || !hasMountOption(info.VFSOptions, "prjquota", "pquota") {
+		return ErrWithMessagef(ErrNotSupported, "Storage quota with overlayfs snapshotter requires XFS mounted with prjquota option, [%s] is %s with options [%s]", info.Mountpoint, info.FSType, info.VFSOptions)
+	}
+
+	project := getQuotaProjectID(upper)
+	if err := runQuotaCommand("xfs_quota", "-x", "-c", fmt.Sprintf("project -s -p %s %d", upper, project), info.Mountpoint); err != nil {
+		return errors.Wrapf(err, "Failed to create storage quota project for [%s]", upper)
+	}
+	if err := runQuotaCommand("xfs_quota", "-x", "-c", fmt.Sprintf("limit -p bhard=%d %d", size, project), info.Mountpoint); err != nil {
+		return errors.Wrapf(err, "Failed to set storage quota for [%s]", upper)
+	}
+	return nil
+}
+
+// setQgroupLimit limits the btrfs subvolume size with qgroup limit
+func setQgroupLimit(mounts []mount.Mount, size int64) error {
+	subvolume := getMountOption(mounts, "subvolid")
+	if len(mounts) == 0 || subvolume == "" {
+		return fmt.Errorf("Btrfs snapshot doesn't have subvolume id, cannot set storage quota")
+	}
+
+	mountpoint, err := findMountpoint(mounts[0].Source, "btrfs")
+	if err != nil {
+		return err
+	}
+	if err := runQuotaCommand("btrfs", "qgroup", "limit", strconv.FormatInt(size, 10), "0/"+subvolume, mountpoint); err != nil {
+		return ErrWithMessagef(ErrNotSupported, "Failed to set storage quota, check that quotas are enabled with 'btrfs quota enable %s': %s", mountpoint, err)
+	}
+	return nil
+}
+
+// findMountpoint returns where the device is mounted
+func findMountpoint(source, fsType string) (string, error) {
+	mounts, err := selfMounts()
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to list mounts")
+	}
+	for _, info := range mounts {
+		if info.Source == source && info.FSType == fsType {
+			return info.Mountpoint, nil
+		}
+	}
+	return "", fmt.Errorf("Cannot find where %s filesystem [%s] is mounted", fsType, source)
+}
+
+// getQuotaProjectID returns XFS project ID for the overlay snapshot upper directory,
+// the overlay snapshots are in numbered directories so the number is used when possible
+func getQuotaProjectID(upper string) uint32 {
+	if id, err := strconv.ParseUint(filepath.Base(filepath.Dir(upper)), 10, 32); err == nil && id < storageQuotaProjectBase {
+		return uint32(storageQuotaProjectBase + id)
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(upper))
+	return storageQuotaProjectBase | hash.Sum32()>>8
+}
+
+// getMountOption returns the key=value option value from the mounts
+func getMountOption(mounts []mount.Mount, key string) string {
+	for _, m := range mounts {
+		for _, option := range m.Options {
+			if strings.HasPrefix(option, key+"=") {
+				return strings.TrimPrefix(option, key+"=")
+			}
+		}
+	}
+	return ""
+}
+
+// hasMountOption returns true if the comma separated options have some of the names
+func hasMountOption(options string, names ...string) bool {
+	for _, option := range strings.Split(options, ",") {
+		if contains(names, option) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package runtime
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/snapshots"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeQuotaSnapshotter struct {
+	snapshots.Snapshotter
+	mounts []mount.Mount
+}
+
+func (s *fakeQuotaSnapshotter) Mounts(ctx context.Context, key string) ([]mount.Mount, error) {
+	return s.mounts, nil
+}
+
+func fakeQuotaCommands(mounts []mount.Info) (*[]string, func()) {
+	commands := []string{}
+	originalRun, originalLookup, originalSelf := runQuotaCommand, lookupMount, selfMounts
+	runQuotaCommand = func(name string, args ...string) error {
+		commands = append(commands, name+" "+strings.Join(args, " "))
+		return nil
+	}
+	lookupMount = func(dir string) (mount.Info, error) {
+		for _, info := range mounts {
+			if strings.HasPrefix(dir, info.Mountpoint) {
+				return info, nil
+			}
+		}
+		return mount.Info{}, errors.New("not found")
+	}
+	selfMounts = func() ([]mount.Info, error) { return mounts, nil }
+	return &commands, func() { runQuotaCommand, lookupMount, selfMounts = originalRun, originalLookup, originalSelf }
+}
+
+func TestCheckStorageQuota(t *testing.T) {
+	assert.NoError(t, checkStorageQuota("overlayfs"))
+	assert.NoError(t, checkStorageQuota("btrfs"))
+	assert.Equal(t, ErrNotSupported, errors.Cause(checkStorageQuota("native")))
+}
+
+func TestApplyStorageQuotaWithXFSProjectQuota(t *testing.T) {
+	commands, restore := fakeQuotaCommands([]mount.Info{{Mountpoint: "/var/lib/containerd", FSType: "xfs", VFSOptions: "rw,attr2,inode64,prjquota"}})
+	defer restore()
+
+	snapshotter := &fakeQuotaSnapshotter{mounts: []mount.Mount{{
+		Type:    "overlay",
+		Options: []string{"workdir=/var/lib/containerd/snapshots/42/work", "upperdir=/var/lib/containerd/snapshots/42/fs", "lowerdir=/var/lib/containerd/snapshots/1/fs"},
+	}}}
+	assert.NoError(t, applyStorageQuota(nil, snapshotter, "overlayfs", "foo", 1024*1024))
+	assert.Equal(t, []string{
+		"xfs_quota -x -c project -s -p /var/lib/containerd/snapshots/42/fs 16777258 /var/lib/containerd",
+		"xfs_quota -x -c limit -p bhard=1048576 16777258 /var/lib/containerd",
+	}, *commands)
+}
+
+func TestApplyStorageQuotaRequiresProjectQuota(t *testing.T) {
+	commands, restore := fakeQuotaCommands([]mount.Info{{Mountpoint: "/", FSType: "ext4", VFSOptions: "rw"}})
+	defer restore()
+
+	snapshotter := &fakeQuotaSnapshotter{mounts: []mount.Mount{{Type: "overlay", Options: []string{"upperdir=/var/lib/containerd/snapshots/42/fs"}}}}
+	err := applyStorageQuota(nil, snapshotter, "overlayfs", "foo", 1024)
+	assert.Equal(t, ErrNotSupported, errors.Cause(err), "should not silently ignore the quota")
+	assert.Empty(t, *commands)
+}
+
+func TestApplyStorageQuotaWithBtrfsQgroup(t *testing.T) {
+	commands, restore := fakeQuotaCommands([]mount.Info{{Mountpoint: "/var/lib/containerd", FSType: "btrfs", Source: "/dev/sda2"}})
+	defer restore()
+
+	snapshotter := &fakeQuotaSnapshotter{mounts: []mount.Mount{{Type: "btrfs", Source: "/dev/sda2", Options: []string{"subvolid=258", "rw"}}}}
+	assert.NoError(t, applyStorageQuota(nil, snapshotter, "btrfs", "foo", 2048))
+	assert.Equal(t, []string{"btrfs qgroup limit 2048 0/258 /var/lib/containerd"}, *commands)
+}
@@ -1,13 +1,236 @@
 package runtime
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	digest "github.com/opencontainers/go-digest"
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 
 	"github.com/ernoaapa/eliot/pkg/fs"
 	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/ernoaapa/eliot/pkg/progress"
 	"github.com/pkg/errors"
+	"github.com/rs/xid"
+	log "github.com/sirupsen/logrus"
+)
+
+// containerStateRoot is the directory where generated container files (e.g. resolv.conf) are stored
+const containerStateRoot = "/run/eliot/containers"
+
+func getContainerStateDir(namespace, id string) string {
+	return filepath.Join(containerStateRoot, namespace, id)
+}
+
+// maxContainerIDLength is the maximum identifier length containerd accepts
+const maxContainerIDLength = 76
+
+// resolveContainerID returns the container ID if given and valid,
+// otherwise generates unique ID in format <pod>-<container>-<random>
+func resolveContainerID(pod model.Pod, container model.Container) (string, error) {
+	if container.ID != "" {
+		if !model.IsValidContainerID(container.ID) {
+			return "", ErrWithMessagef(ErrInvalid, "Invalid container id [%s]. Must be alphanumeric, separated with '.', '_' or '-' and max %d characters", container.ID, maxContainerIDLength)
+		}
+		return container.ID, nil
+	}
+
+	suffix := xid.New().String()
+	prefix := fmt.Sprintf("%s-%s", pod.Metadata.Name, container.Name)
+	if max := maxContainerIDLength - len(suffix) - 1; len(prefix) > max {
+		prefix = prefix[:max]
+	}
+	prefix = strings.TrimRight(prefix, "-_.")
+	if prefix == "" {
+		return suffix, nil
+	}
+	return fmt.Sprintf("%s-%s", prefix, suffix), nil
+}
+
+const (
+	// maxReconnectAttempts is how many times streaming calls retry after transient containerd error
+	maxReconnectAttempts = 5
+	// reconnectInterval is the initial delay between the retries, doubled after each attempt
+	reconnectInterval = 500 * time.Millisecond
 )
 
+// waitRetry waits the delay before the next retry, returns the context error if the context ends first
+func waitRetry(ctx context.Context, delay time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+// maxPullAttempts is how many times image pull is tried when it fails due to network error
+const maxPullAttempts = 5
+
+// networkErrorMessages are checked when the image fetch error is not typed, e.g. error from registry http response body
+var networkErrorMessages = []string{
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"i/o timeout",
+	"unexpected EOF",
+	"TLS handshake timeout",
+	"no such host",
+}
+
+// isNetworkError returns true if the error is due to network failure and the operation can be retried
+func isNetworkError(err error) bool {
+	cause := errors.Cause(err)
+	if cause == io.ErrUnexpectedEOF {
+		return true
+	}
+	if _, ok := cause.(net.Error); ok {
+		return true
+	}
+
+	message := err.Error()
+	for _, networkError := range networkErrorMessages {
+		if strings.Contains(message, networkError) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchedContent tracks the image content descriptors seen during the pull
+type fetchedContent struct {
+	mu          sync.Mutex
+	descriptors map[digest.Digest]int64
+	layers      map[digest.Digest]bool
+	// existing are the descriptors what were in the content store before the pull started
+	existing map[digest.Digest]bool
+}
+
+func newFetchedContent() *fetchedContent {
+	return &fetchedContent{
+		descriptors: map[digest.Digest]int64{},
+		layers:      map[digest.Digest]bool{},
+		existing:    map[digest.Digest]bool{},
+	}
+}
+
+// add records the descriptor, exists tells if the content is already in the store
+// Only the first add of the descriptor counts so that the content fetched by earlier pull attempts is not counted existing
+func (f *fetchedContent) add(desc imagespecs.Descriptor, exists bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.descriptors[desc.Digest]; ok {
+		return
+	}
+	f.descriptors[desc.Digest] = desc.Size
+	f.existing[desc.Digest] = exists
+	if isLayerMediaType(desc.MediaType) {
+		f.layers[desc.Digest] = true
+	}
+}
+
+// stats returns the pull stats of the seen content
+func (f *fetchedContent) stats(duration time.Duration) progress.PullStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stats := progress.PullStats{Duration: duration, Layers: len(f.layers)}
+	for dgst, size := range f.descriptors {
+		if f.existing[dgst] {
+			continue
+		}
+		stats.BytesFetched += size
+		if f.layers[dgst] {
+			stats.FetchedLayers++
+		}
+	}
+	stats.Cached = stats.BytesFetched == 0
+	return stats
+}
+
+func isLayerMediaType(mediaType string) bool {
+	switch mediaType {
+	case images.MediaTypeDockerSchema2Layer, images.MediaTypeDockerSchema2LayerGzip,
+		images.MediaTypeDockerSchema2LayerForeign, images.MediaTypeDockerSchema2LayerForeignGzip,
+		imagespecs.MediaTypeImageLayer, imagespecs.MediaTypeImageLayerGzip,
+		imagespecs.MediaTypeImageLayerNonDistributable, imagespecs.MediaTypeImageLayerNonDistributableGzip:
+		return true
+	}
+	return false
+}
+
+// size returns the total size of all seen content
+func (f *fetchedContent) size() (total int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, size := range f.descriptors {
+		total += size
+	}
+	return total
+}
+
+// stored returns how many bytes of the seen content is already committed to the store
+// and how many bytes are in the partial ingests what the next fetch can resume
+func (f *fetchedContent) stored(ctx context.Context, store content.Store) (completed, partial int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for dgst, size := range f.descriptors {
+		if _, err := store.Info(ctx, dgst); err == nil {
+			completed += size
+		}
+	}
+
+	statuses, err := store.ListStatuses(ctx)
+	if err != nil {
+		return completed, 0
+	}
+	for _, status := range statuses {
+		if _, ok := f.descriptors[status.Expected]; ok {
+			partial += status.Offset
+		}
+	}
+	return completed, partial
+}
+
+// readyCheckInterval is the delay between checks while waiting containerd to become available
+const readyCheckInterval = time.Second
+
+// orphanStopCheckInterval is the delay between checks while waiting killed orphan task to stop
+const orphanStopCheckInterval = 100 * time.Millisecond
+
+// isTransientError returns true if the error is due to temporarily unavailable containerd
+func isTransientError(err error) bool {
+	cause := errors.Cause(err)
+	return errdefs.IsUnavailable(cause) || errdefs.IsUnavailable(errdefs.FromGRPC(cause))
+}
+
+// cgroupV2ControllersFile exists only when the unified cgroup v2 hierarchy is mounted
+const cgroupV2ControllersFile = "/sys/fs/cgroup/cgroup.controllers"
+
+// isCgroupV2 returns true if the host uses unified cgroup v2 hierarchy
+func isCgroupV2() bool {
+	return fs.FileExist(cgroupV2ControllersFile)
+}
+
 func ensureMountSourceDirExists(mounts []model.Mount) error {
 	for _, mount := range mounts {
 		if fs.FileExist(mount.Source) {
@@ -31,3 +254,89 @@ func getValues(podsByName map[string]*model.Pod) (result []model.Pod) {
 	}
 	return result
 }
+
+// cgroupRoot is the cgroup filesystem mount point
+var cgroupRoot = "/sys/fs/cgroup"
+
+// resolveExitReason resolves why the container task exited, oomEvent is true if containerd
+// have reported OOM event for the task
+func resolveExitReason(container containers.Container, exitCode uint32, cgroupV2, oomEvent bool) string {
+	if oomEvent || isOOMKilled(container, cgroupV2) {
+		return model.ExitReasonOOMKilled
+	}
+	if exitCode == 0 {
+		return model.ExitReasonCompleted
+	}
+	return model.ExitReasonError
+}
+
+// isOOMKilled returns true if the kernel OOM killer have killed process in the container cgroup
+// The memory events are read from the cgroup what exist until the task gets deleted
+func isOOMKilled(container containers.Container, cgroupV2 bool) bool {
+	cgroup := getCgroupsPath(container)
+	if !strings.HasPrefix(cgroup, "/") {
+		return false
+	}
+
+	file := filepath.Join(cgroupRoot, "memory", cgroup, "memory.oom_control")
+	if cgroupV2 {
+		file = filepath.Join(cgroupRoot, cgroup, "memory.events")
+	}
+	return parseOOMKillCount(file) > 0
+}
+
+// getCgroupsPath returns the container cgroup path from the spec, empty if not defined
+func getCgroupsPath(container containers.Container) string {
+	if container.Spec == nil {
+		return ""
+	}
+	var spec specs.Spec
+	if err := json.Unmarshal(container.Spec.Value, &spec); err != nil {
+		log.Warnf("Cannot read container [%s] spec to resolve cgroup: %s", container.ID, err)
+		return ""
+	}
+	if spec.Linux == nil {
+		return ""
+	}
+	return spec.Linux.CgroupsPath
+}
+
+// parseOOMKillCount reads the "oom_kill <count>" line from cgroup memory events file
+func parseOOMKillCount(file string) int {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return 0
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "oom_kill" {
+			count, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return 0
+			}
+			return count
+		}
+	}
+	return 0
+}
+
+// newRegistryResolver returns registry resolver what authenticates with the pull secret matching the registry host
+func newRegistryResolver(client *http.Client, secrets []model.PullSecret) remotes.Resolver {
+	return docker.NewResolver(docker.ResolverOptions{
+		Client: client,
+		Credentials: func(host string) (string, string, error) {
+			username, password := findCredentials(secrets, host)
+			return username, password, nil
+		},
+	})
+}
+
+// findCredentials returns the credentials of the first secret for the host, empty if none match
+func findCredentials(secrets []model.PullSecret, host string) (username, password string) {
+	for _, secret := range secrets {
+		if secret.MatchesHost(host) {
+			return secret.Username, secret.Password
+		}
+	}
+	return "", ""
+}
@@ -1,15 +1,30 @@
 package runtime
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
 	"github.com/ernoaapa/eliot/pkg/fs"
 	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/gogo/protobuf/types"
+	imagespecs "github.com/opencontainers/image-spec/specs-go/v1"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestEnsureMountSourceDirExistsCreatesDirectory(t *testing.T) {
@@ -44,3 +59,110 @@ func TestGetValues(t *testing.T) {
 
 	assert.Equal(t, []model.Pod{*expected}, result)
 }
+
+func TestResolveContainerID(t *testing.T) {
+	pod := model.Pod{Metadata: model.NewMetadata("eliot", "my-pod")}
+
+	id, err := resolveContainerID(pod, model.Container{ID: "custom-id", Name: "foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, "custom-id", id, "should use given id")
+
+	id, err = resolveContainerID(pod, model.Container{Name: "foo"})
+	assert.NoError(t, err)
+	assert.Contains(t, id, "my-pod-foo-", "should generate id from pod and container name")
+	assert.True(t, model.IsValidContainerID(id))
+
+	id, err = resolveContainerID(pod, model.Container{Name: strings.Repeat("a", 100)})
+	assert.NoError(t, err)
+	assert.Len(t, id, maxContainerIDLength, "should truncate too long generated id")
+	assert.True(t, model.IsValidContainerID(id))
+
+	_, err = resolveContainerID(pod, model.Container{ID: "invalid/id", Name: "foo"})
+	assert.Error(t, err)
+}
+
+func TestIsTransientError(t *testing.T) {
+	assert.True(t, isTransientError(status.Error(codes.Unavailable, "transport is closing")), "should detect GRPC unavailable error")
+	assert.True(t, isTransientError(errdefs.ErrUnavailable))
+
+	assert.False(t, isTransientError(status.Error(codes.NotFound, "container not found")))
+	assert.False(t, isTransientError(errors.New("exit status 1")))
+}
+
+func TestIsNetworkError(t *testing.T) {
+	assert.True(t, isNetworkError(io.ErrUnexpectedEOF), "should detect interrupted download")
+	assert.True(t, isNetworkError(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("refused")}), "should detect typed network error")
+	assert.True(t, isNetworkError(errors.New("failed to copy: read tcp 10.0.0.2:51234->1.2.3.4:443: read: connection reset by peer")), "should detect network error from message")
+
+	assert.False(t, isNetworkError(errors.New("unexpected status code 404 Not Found")), "should not retry missing image")
+}
+
+func TestWaitRetry(t *testing.T) {
+	assert.NoError(t, waitRetry(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	started := time.Now()
+	assert.Equal(t, context.Canceled, waitRetry(ctx, time.Hour))
+	assert.True(t, time.Since(started) < time.Second, "should not wait the delay when the context ends")
+}
+
+func TestFetchedContentStats(t *testing.T) {
+	fetched := newFetchedContent()
+	fetched.add(imagespecs.Descriptor{MediaType: images.MediaTypeDockerSchema2Manifest, Digest: "sha256:manifest", Size: 10}, true)
+	fetched.add(imagespecs.Descriptor{MediaType: images.MediaTypeDockerSchema2LayerGzip, Digest: "sha256:base", Size: 100}, true)
+	fetched.add(imagespecs.Descriptor{MediaType: imagespecs.MediaTypeImageLayerGzip, Digest: "sha256:app", Size: 50}, false)
+	fetched.add(imagespecs.Descriptor{MediaType: imagespecs.MediaTypeImageLayerGzip, Digest: "sha256:app", Size: 50}, true)
+
+	stats := fetched.stats(time.Second)
+	assert.False(t, stats.Cached)
+	assert.Equal(t, time.Second, stats.Duration)
+	assert.Equal(t, int64(50), stats.BytesFetched, "should count only the content what didn't exist before the first attempt")
+	assert.Equal(t, 2, stats.Layers)
+	assert.Equal(t, 1, stats.FetchedLayers)
+
+	cached := newFetchedContent()
+	cached.add(imagespecs.Descriptor{MediaType: images.MediaTypeDockerSchema2LayerGzip, Digest: "sha256:base", Size: 100}, true)
+	assert.True(t, cached.stats(time.Second).Cached)
+}
+
+func TestResolveExitReason(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	original := cgroupRoot
+	defer func() { cgroupRoot = original }()
+	cgroupRoot = root
+
+	spec, err := json.Marshal(specs.Spec{Linux: &specs.Linux{CgroupsPath: "/eliot/foo"}})
+	assert.NoError(t, err)
+	container := containers.Container{ID: "foo", Spec: &types.Any{Value: spec}}
+
+	assert.Equal(t, model.ExitReasonCompleted, resolveExitReason(container, 0, true, false))
+	assert.Equal(t, model.ExitReasonError, resolveExitReason(container, 1, true, false))
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "eliot", "foo"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "eliot", "foo", "memory.events"), []byte("low 0\nhigh 0\nmax 3\noom 1\noom_kill 1\n"), 0644))
+	assert.Equal(t, model.ExitReasonOOMKilled, resolveExitReason(container, 137, true, false))
+	assert.Equal(t, model.ExitReasonError, resolveExitReason(container, 137, false, false), "should read cgroup v1 memory controller on v1 host")
+	assert.Equal(t, model.ExitReasonOOMKilled, resolveExitReason(container, 137, false, true), "should trust containerd OOM event")
+}
+
+func TestFindCredentials(t *testing.T) {
+	secrets := []model.PullSecret{
+		{Name: "hub", Registry: "docker.io", Username: "hub-user", Password: "hub-pass"},
+		{Name: "private", Registry: "registry.example.com:5000", Username: "user", Password: "pass"},
+	}
+
+	username, password := findCredentials(secrets, "registry.example.com:5000")
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "pass", password)
+
+	username, _ = findCredentials(secrets, "registry-1.docker.io")
+	assert.Equal(t, "hub-user", username, "should match docker.io secret to the docker hub registry host")
+
+	username, password = findCredentials(secrets, "quay.io")
+	assert.Empty(t, username)
+	assert.Empty(t, password, "should not send credentials to other registries")
+}
@@ -0,0 +1,319 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/containerd/containerd/identifiers"
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/pkg/errors"
+)
+
+const (
+	// keySize is the AES-256 key size in bytes
+	keySize = 32
+	// pullSecretPrefix and dataSecretPrefix separate the pull secret and the data secret authenticated names
+	pullSecretPrefix = "pull/"
+	dataSecretPrefix = "data/"
+)
+
+// Store persists the pull secrets and the data secrets into the directory, each secret encrypted with AES-GCM
+// The secrets belong to a namespace and only the pods in the same namespace can use them
+type Store struct {
+	dir  string
+	aead cipher.AEAD
+	mu   sync.Mutex
+}
+
+// NewStore creates new secret store what writes the secrets encrypted with the key to the directory
+func NewStore(dir string, key []byte) (*Store, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("Invalid secret encryption key, must be %d bytes but was %d", keySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create secret cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create secret cipher")
+	}
+	return &Store{
+		dir:  dir,
+		aead: aead,
+	}, nil
+}
+
+// LoadOrCreateKey reads the encryption key from the file or generates new key to the file if it doesn't exist
+func LoadOrCreateKey(file string) ([]byte, error) {
+	key, err := ioutil.ReadFile(file)
+	if err == nil {
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "Failed to read secret encryption key [%s]", file)
+	}
+
+	key = make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Wrap(err, "Failed to generate secret encryption key")
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return nil, errors.Wrapf(err, "Failed to create directory for secret encryption key [%s]", file)
+	}
+	if err := ioutil.WriteFile(file, key, 0600); err != nil {
+		return nil, errors.Wrapf(err, "Failed to write secret encryption key [%s]", file)
+	}
+	return key, nil
+}
+
+// Put stores the secret to the namespace, replaces existing secret with the same name
+func (s *Store) Put(namespace string, secret model.PullSecret) error {
+	if err := validateNamespace(namespace); err != nil {
+		return err
+	}
+	if err := model.ValidatePullSecret(secret); err != nil {
+		return errors.Wrapf(err, "Invalid pull secret [%s]", secret.Name)
+	}
+	// The namespace and the secret name are authenticated so encrypted file cannot be moved to another secret or namespace
+	return s.write(s.path(namespace, secret.Name), secret.Name, authenticatedName(pullSecretPrefix, namespace, secret.Name), secret, "pull secret")
+}
+
+// Get reads and decrypts the secret of the namespace by name
+func (s *Store) Get(namespace, name string) (model.PullSecret, error) {
+	var secret model.PullSecret
+	if err := validateNamespace(namespace); err != nil {
+		return secret, err
+	}
+	if !model.IsValidPullSecretName(name) {
+		return secret, fmt.Errorf("Invalid pull secret name [%s]", name)
+	}
+	return secret, s.read(s.path(namespace, name), name, authenticatedName(pullSecretPrefix, namespace, name), &secret, "pull secret")
+}
+
+// PutSecret stores the data secret to the namespace, replaces existing secret with the same name
+func (s *Store) PutSecret(namespace string, secret model.Secret) error {
+	if err := validateNamespace(namespace); err != nil {
+		return err
+	}
+	if err := model.ValidateSecret(secret); err != nil {
+		return errors.Wrapf(err, "Invalid secret [%s]", secret.Name)
+	}
+	// The data secrets have own authenticated name so that pull secret file cannot be used as data secret
+	return s.write(s.dataPath(namespace, secret.Name), secret.Name, authenticatedName(dataSecretPrefix, namespace, secret.Name), secret, "secret")
+}
+
+// GetSecret reads and decrypts the data secret of the namespace by name
+func (s *Store) GetSecret(namespace, name string) (model.Secret, error) {
+	var secret model.Secret
+	if err := validateNamespace(namespace); err != nil {
+		return secret, err
+	}
+	if !model.IsValidPullSecretName(name) {
+		return secret, fmt.Errorf("Invalid secret name [%s]", name)
+	}
+	return secret, s.read(s.dataPath(namespace, name), name, authenticatedName(dataSecretPrefix, namespace, name), &secret, "secret")
+}
+
+// DeleteSecret removes the data secret from the namespace, does nothing if the secret doesn't exist
+func (s *Store) DeleteSecret(namespace, name string) error {
+	if err := validateNamespace(namespace); err != nil {
+		return err
+	}
+	if !model.IsValidPullSecretName(name) {
+		return fmt.Errorf("Invalid secret name [%s]", name)
+	}
+	return s.remove(s.dataPath(namespace, name), name, "secret")
+}
+
+// write encrypts the value with the authenticated data and replaces the file atomically
+func (s *Store) write(path, name string, authenticated []byte, value interface{}, kind string) error {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to serialize %s [%s]", kind, name)
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return errors.Wrapf(err, "Failed to generate nonce for %s encryption", kind)
+	}
+	ciphertext := s.aead.Seal(nonce, nonce, plaintext, authenticated)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrapf(err, "Failed to create %s directory [%s]", kind, filepath.Dir(path))
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, ciphertext, 0600); err != nil {
+		return errors.Wrapf(err, "Failed to write %s [%s]", kind, name)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrapf(err, "Failed to write %s [%s]", kind, name)
+	}
+	return nil
+}
+
+// read decrypts the file with the authenticated data into the value
+func (s *Store) read(path, name string, authenticated []byte, value interface{}, kind string) error {
+	s.mu.Lock()
+	ciphertext, err := ioutil.ReadFile(path)
+	s.mu.Unlock()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s [%s] not found", capitalize(kind), name)
+		}
+		return errors.Wrapf(err, "Failed to read %s [%s]", kind, name)
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return fmt.Errorf("%s [%s] file is corrupted", capitalize(kind), name)
+	}
+	plaintext, err := s.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], authenticated)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to decrypt %s [%s]", kind, name)
+	}
+
+	if err := json.Unmarshal(plaintext, value); err != nil {
+		return errors.Wrapf(err, "Failed to deserialize %s [%s]", kind, name)
+	}
+	return nil
+}
+
+func (s *Store) remove(path, name, kind string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "Failed to delete %s [%s]", kind, name)
+	}
+	return nil
+}
+
+// GetAll resolves all secrets of the namespace by names
+func (s *Store) GetAll(namespace string, names []string) (result []model.PullSecret, err error) {
+	for _, name := range names {
+		secret, err := s.Get(namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, secret)
+	}
+	return result, nil
+}
+
+// Delete removes the secret from the namespace, does nothing if the secret doesn't exist
+func (s *Store) Delete(namespace, name string) error {
+	if err := validateNamespace(namespace); err != nil {
+		return err
+	}
+	if !model.IsValidPullSecretName(name) {
+		return fmt.Errorf("Invalid pull secret name [%s]", name)
+	}
+	return s.remove(s.path(namespace, name), name, "pull secret")
+}
+
+// MigrateGlobalSecrets moves the pull secrets and the data secrets stored before the secrets belonged to
+// namespaces into the namespace
+// Returns the number of moved secrets, the secret what already exists in the namespace is kept as is
+func (s *Store) MigrateGlobalSecrets(namespace string) (int, error) {
+	if err := validateNamespace(namespace); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	pullSecrets, err := s.listGlobal(".secret")
+	if err != nil {
+		return count, err
+	}
+	for name, path := range pullSecrets {
+		var secret model.PullSecret
+		if err := s.read(path, name, []byte(name), &secret, "pull secret"); err != nil {
+			return count, err
+		}
+		if !exists(s.path(namespace, name)) {
+			if err := s.Put(namespace, secret); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if err := s.remove(path, name, "pull secret"); err != nil {
+			return count, err
+		}
+	}
+
+	dataSecrets, err := s.listGlobal(".data")
+	if err != nil {
+		return count, err
+	}
+	for name, path := range dataSecrets {
+		var secret model.Secret
+		if err := s.read(path, name, []byte(dataSecretPrefix+name), &secret, "secret"); err != nil {
+			return count, err
+		}
+		if !exists(s.dataPath(namespace, name)) {
+			if err := s.PutSecret(namespace, secret); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if err := s.remove(path, name, "secret"); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// listGlobal returns the secret names and paths of the files with the extension in the root of the directory
+func (s *Store) listGlobal(extension string) (map[string]string, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*"+extension))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to list secrets")
+	}
+	result := map[string]string{}
+	for _, path := range paths {
+		result[strings.TrimSuffix(filepath.Base(path), extension)] = path
+	}
+	return result, nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return !os.IsNotExist(err)
+}
+
+func (s *Store) path(namespace, name string) string {
+	return filepath.Join(s.dir, namespace, name+".secret")
+}
+
+// authenticatedName returns the secret identity what gets authenticated with the encrypted secret
+func authenticatedName(prefix, namespace, name string) []byte {
+	return []byte(prefix + namespace + "/" + name)
+}
+
+// validateNamespace checks the namespace is valid containerd namespace, so it cannot point outside the directory
+func validateNamespace(namespace string) error {
+	if err := identifiers.Validate(namespace); err != nil {
+		return errors.Wrapf(err, "Invalid secret namespace [%s]", namespace)
+	}
+	return nil
+}
+
+func (s *Store) dataPath(namespace, name string) string {
+	return filepath.Join(s.dir, namespace, name+".data")
+}
+
+func capitalize(value string) string {
+	return strings.ToUpper(value[:1]) + value[1:]
+}
@@ -0,0 +1,136 @@
+package secrets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ernoaapa/eliot/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	key, err := LoadOrCreateKey(filepath.Join(dir, "secrets.key"))
+	assert.NoError(t, err)
+	loaded, err := LoadOrCreateKey(filepath.Join(dir, "secrets.key"))
+	assert.NoError(t, err)
+	assert.Equal(t, key, loaded, "should read the existing key")
+
+	store, err := NewStore(filepath.Join(dir, "pull-secrets"), key)
+	assert.NoError(t, err)
+
+	secret := model.PullSecret{Name: "my-registry", Registry: "registry.example.com", Username: "user", Password: "s3cr3t"}
+	assert.NoError(t, store.Put("dev", secret))
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "pull-secrets", "dev", "my-registry.secret"))
+	assert.NoError(t, err)
+	assert.False(t, strings.Contains(string(content), "s3cr3t"), "should encrypt the secret at rest")
+
+	result, err := store.Get("dev", "my-registry")
+	assert.NoError(t, err)
+	assert.Equal(t, secret, result)
+
+	_, err = store.Get("prod", "my-registry")
+	assert.Error(t, err, "should not find the secret from other namespace")
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "pull-secrets", "prod"), 0700))
+	assert.NoError(t, os.Rename(filepath.Join(dir, "pull-secrets", "dev", "my-registry.secret"), filepath.Join(dir, "pull-secrets", "prod", "my-registry.secret")))
+	_, err = store.Get("prod", "my-registry")
+	assert.Error(t, err, "should not decrypt secret moved to other namespace")
+	assert.NoError(t, os.Rename(filepath.Join(dir, "pull-secrets", "prod", "my-registry.secret"), filepath.Join(dir, "pull-secrets", "dev", "my-registry.secret")))
+
+	assert.NoError(t, store.Delete("dev", "my-registry"))
+	_, err = store.Get("dev", "my-registry")
+	assert.Error(t, err, "should return error when secret not found")
+}
+
+func TestStoreRejectsInvalid(t *testing.T) {
+	store, err := NewStore("/non/existing", make([]byte, keySize))
+	assert.NoError(t, err)
+
+	assert.Error(t, store.Put("dev", model.PullSecret{Name: "foo"}), "should reject secret without registry and password")
+	_, err = store.Get("dev", "../foo")
+	assert.Error(t, err, "should reject invalid name")
+	_, err = store.Get("..", "foo")
+	assert.Error(t, err, "should reject invalid namespace")
+
+	_, err = NewStore("/non/existing", []byte("too-short"))
+	assert.Error(t, err)
+}
+
+func TestStoreDataSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := NewStore(dir, make([]byte, keySize))
+	assert.NoError(t, err)
+
+	secret := model.Secret{Name: "api-key", Data: []byte("s3cr3t")}
+	assert.NoError(t, store.PutSecret("default", secret))
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "default", "api-key.data"))
+	assert.NoError(t, err)
+	assert.False(t, strings.Contains(string(content), "s3cr3t"), "should encrypt the secret at rest")
+
+	result, err := store.GetSecret("default", "api-key")
+	assert.NoError(t, err)
+	assert.Equal(t, secret, result)
+
+	_, err = store.GetSecret("other", "api-key")
+	assert.Error(t, err, "should not resolve secret from other namespace")
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "other"), 0700))
+	assert.NoError(t, os.Rename(filepath.Join(dir, "default", "api-key.data"), filepath.Join(dir, "other", "api-key.data")))
+	_, err = store.GetSecret("other", "api-key")
+	assert.Error(t, err, "should not decrypt secret moved to other namespace")
+	assert.NoError(t, os.Rename(filepath.Join(dir, "other", "api-key.data"), filepath.Join(dir, "default", "api-key.data")))
+
+	assert.NoError(t, os.Rename(filepath.Join(dir, "default", "api-key.data"), filepath.Join(dir, "default", "api-key.secret")))
+	_, err = store.Get("default", "api-key")
+	assert.Error(t, err, "should not decrypt data secret as pull secret")
+	assert.NoError(t, os.Rename(filepath.Join(dir, "default", "api-key.secret"), filepath.Join(dir, "default", "api-key.data")))
+
+	_, err = store.GetSecret("..", "api-key")
+	assert.Error(t, err, "should reject invalid namespace")
+
+	assert.NoError(t, store.DeleteSecret("default", "api-key"))
+	_, err = store.GetSecret("default", "api-key")
+	assert.Error(t, err, "should return error when secret not found")
+}
+
+func TestMigrateGlobalSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := NewStore(dir, make([]byte, keySize))
+	assert.NoError(t, err)
+
+	secret := model.PullSecret{Name: "my-registry", Registry: "registry.example.com", Username: "user", Password: "s3cr3t"}
+	assert.NoError(t, store.write(filepath.Join(dir, "my-registry.secret"), secret.Name, []byte(secret.Name), secret, "pull secret"))
+	data := model.Secret{Name: "api-key", Data: []byte("s3cr3t")}
+	assert.NoError(t, store.write(filepath.Join(dir, "api-key.data"), data.Name, []byte(dataSecretPrefix+data.Name), data, "secret"))
+
+	count, err := store.MigrateGlobalSecrets("default")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	dataResult, err := store.GetSecret("default", "api-key")
+	assert.NoError(t, err)
+	assert.Equal(t, data, dataResult)
+	_, err = os.Stat(filepath.Join(dir, "api-key.data"))
+	assert.True(t, os.IsNotExist(err), "should remove the global data secret")
+
+	result, err := store.Get("default", "my-registry")
+	assert.NoError(t, err)
+	assert.Equal(t, secret, result)
+	_, err = os.Stat(filepath.Join(dir, "my-registry.secret"))
+	assert.True(t, os.IsNotExist(err), "should remove the global secret")
+}
@@ -3,7 +3,11 @@ package utils
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
+
+	"github.com/containerd/containerd/reference"
+	"github.com/pkg/errors"
 )
 
 var (
@@ -58,3 +62,64 @@ func GetFQINUsername(fqin string) string {
 	parts := strings.SplitN(fqin, "/", 3)
 	return parts[1]
 }
+
+var (
+	repositoryComponentRe = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*$`)
+	tagRe                 = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+)
+
+// NormalizeImageRef validates the image reference and expands it to fully qualified form.
+// If the reference doesn't include the registry, the given default registry is used.
+// E.g. nginx -> docker.io/library/nginx:latest, myimage:tag -> registry.local/myimage:tag
+func NormalizeImageRef(ref, registry string) (string, error) {
+	if ref == "" {
+		return "", errors.New("Image reference cannot be empty")
+	}
+	if strings.ContainsAny(ref, " \t\n") {
+		return "", fmt.Errorf("Invalid image reference [%s], cannot contain whitespace", ref)
+	}
+	if registry == "" {
+		registry = defaultRegistry
+	}
+
+	name := ref
+	if parts := strings.SplitN(ref, "/", 2); len(parts) == 1 || !isRegistryHost(parts[0]) {
+		if registry == defaultRegistry && len(parts) == 1 {
+			name = fmt.Sprintf("%s/%s", defaultUsername, name)
+		}
+		name = fmt.Sprintf("%s/%s", registry, name)
+	}
+
+	spec, err := reference.Parse(name)
+	if err != nil {
+		return "", errors.Wrapf(err, "Invalid image reference [%s]", ref)
+	}
+
+	repository := strings.TrimPrefix(spec.Locator, spec.Hostname()+"/")
+	for _, component := range strings.Split(repository, "/") {
+		if !repositoryComponentRe.MatchString(component) {
+			return "", fmt.Errorf("Invalid image reference [%s], repository name [%s] must be lowercase alphanumeric, optionally separated by '.', '_' or '-'", ref, repository)
+		}
+	}
+
+	if spec.Object == "" {
+		spec.Object = defaultTag
+	}
+	tag, dgst := reference.SplitObject(spec.Object)
+	tag = strings.TrimSuffix(tag, "@")
+	if tag != "" && !tagRe.MatchString(tag) {
+		return "", fmt.Errorf("Invalid image reference [%s], invalid tag [%s]", ref, tag)
+	}
+	if dgst != "" {
+		if err := dgst.Validate(); err != nil {
+			return "", errors.Wrapf(err, "Invalid image reference [%s], invalid digest", ref)
+		}
+	}
+
+	return spec.String(), nil
+}
+
+// isRegistryHost returns true if the first reference component looks like registry hostname
+func isRegistryHost(component string) bool {
+	return strings.ContainsAny(component, ".:") || component == "localhost"
+}
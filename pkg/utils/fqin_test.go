@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,3 +24,42 @@ func TestGetFQINUsername(t *testing.T) {
 	assert.Equal(t, "eaapa", GetFQINUsername("docker.io/eaapa/hello-world"))
 	assert.Equal(t, "eaapa", GetFQINUsername("docker.io/eaapa/hello-world:latest"))
 }
+
+func TestNormalizeImageRef(t *testing.T) {
+	cases := map[string]string{
+		"nginx":                          "docker.io/library/nginx:latest",
+		"nginx:1.15":                     "docker.io/library/nginx:1.15",
+		"eaapa/hello-world":              "docker.io/eaapa/hello-world:latest",
+		"quay.io/coreos/etcd:v3.3":       "quay.io/coreos/etcd:v3.3",
+		"localhost:5000/myimage":         "localhost:5000/myimage:latest",
+		"localhost/myimage:tag":          "localhost/myimage:tag",
+		"docker.io/library/nginx:latest": "docker.io/library/nginx:latest",
+	}
+	for ref, expected := range cases {
+		result, err := NormalizeImageRef(ref, "")
+		assert.NoError(t, err, "should normalize [%s]", ref)
+		assert.Equal(t, expected, result)
+	}
+
+	result, err := NormalizeImageRef("myimage:tag", "registry.local:5000")
+	assert.NoError(t, err)
+	assert.Equal(t, "registry.local:5000/myimage:tag", result, "should use given default registry")
+
+	dgst := "sha256:" + strings.Repeat("a", 64)
+	result, err = NormalizeImageRef("nginx@"+dgst, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "docker.io/library/nginx@"+dgst, result, "should support digest references")
+}
+
+func TestNormalizeImageRefInvalid(t *testing.T) {
+	for _, ref := range []string{
+		"",
+		"nginx latest",
+		"Nginx",
+		"nginx:-invalid",
+		"nginx@sha256:invalid",
+	} {
+		_, err := NormalizeImageRef(ref, "")
+		assert.Error(t, err, "should reject [%s]", ref)
+	}
+}
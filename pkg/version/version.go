@@ -0,0 +1,70 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VERSION is the running binary version, set by the main package at startup
+var VERSION = "master"
+
+var (
+	// MinClientVersion is the oldest eli client version what the eliotd server supports
+	MinClientVersion = "0.2.0"
+	// MinServerVersion is the oldest eliotd server version what the eli client supports
+	MinServerVersion = "0.2.0"
+)
+
+// IsCompatible returns true if version is same or newer than the minimum version.
+// Development builds (e.g. "master" or "unknown") which are not semantic versions are always compatible
+func IsCompatible(version, minimum string) bool {
+	current, ok := parse(version)
+	if !ok {
+		return true
+	}
+	required, ok := parse(minimum)
+	if !ok {
+		return true
+	}
+
+	for i := range current {
+		if current[i] != required[i] {
+			return current[i] > required[i]
+		}
+	}
+	return true
+}
+
+// CheckServerCompatibility verifies that this client and the server with given version
+// and minimum supported client version can work together
+func CheckServerCompatibility(serverVersion, minClientVersion string) error {
+	if !IsCompatible(serverVersion, MinServerVersion) {
+		return fmt.Errorf("Server version [%s] is too old, minimum supported version is [%s]. Please upgrade eliotd", serverVersion, MinServerVersion)
+	}
+	if minClientVersion != "" && !IsCompatible(VERSION, minClientVersion) {
+		return fmt.Errorf("Client version [%s] is too old for the server, minimum supported version is [%s]. Please upgrade eli", VERSION, minClientVersion)
+	}
+	return nil
+}
+
+// parse parses semantic version in format [v]MAJOR.MINOR.PATCH[-prerelease]
+func parse(version string) (result [3]int, ok bool) {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return result, false
+	}
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil || value < 0 {
+			return result, false
+		}
+		result[i] = value
+	}
+	return result, true
+}
@@ -0,0 +1,28 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsCompatible(t *testing.T) {
+	assert.True(t, IsCompatible("0.2.0", "0.2.0"), "same version should be compatible")
+	assert.True(t, IsCompatible("v0.3.1", "0.2.0"), "newer version should be compatible")
+	assert.True(t, IsCompatible("1.0.0-rc1", "0.9.9"), "should ignore prerelease suffix")
+	assert.True(t, IsCompatible("master", "0.2.0"), "development build should be compatible")
+	assert.True(t, IsCompatible("0.1.0", "unknown"), "unknown minimum should be compatible")
+
+	assert.False(t, IsCompatible("0.1.9", "0.2.0"), "older version should not be compatible")
+	assert.False(t, IsCompatible("v0.2.2", "0.10.0"), "should compare numerically")
+}
+
+func TestCheckServerCompatibility(t *testing.T) {
+	VERSION = "0.3.0"
+	defer func() { VERSION = "master" }()
+
+	assert.NoError(t, CheckServerCompatibility("0.3.0", "0.2.0"))
+	assert.NoError(t, CheckServerCompatibility("0.3.0", ""), "should accept server without minimum client version")
+	assert.Error(t, CheckServerCompatibility("0.1.0", "0.1.0"), "should reject too old server")
+	assert.Error(t, CheckServerCompatibility("0.4.0", "0.4.0"), "should reject too old client")
+}